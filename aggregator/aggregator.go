@@ -61,6 +61,9 @@ type Aggregator struct {
 	finalProof     chan finalProofMsg
 	verifyingProof bool
 
+	provers                *proverFleet
+	proofGenerationTimeout time.Duration
+
 	srv  *grpc.Server
 	ctx  context.Context
 	exit context.CancelFunc
@@ -81,6 +84,15 @@ func New(
 		profitabilityChecker = NewTxProfitabilityCheckerAcceptAll(stateInterface, cfg.IntervalAfterWhichBatchConsolidateAnyway.Duration)
 	}
 
+	var proofGenerationTimeout time.Duration
+	if cfg.GeneratingProofCleanupThreshold != "" {
+		var err error
+		proofGenerationTimeout, err = time.ParseDuration(cfg.GeneratingProofCleanupThreshold)
+		if err != nil {
+			return Aggregator{}, fmt.Errorf("invalid generating proof cleanup threshold %s, %w", cfg.GeneratingProofCleanupThreshold, err)
+		}
+	}
+
 	a := Aggregator{
 		cfg: cfg,
 
@@ -93,6 +105,9 @@ func New(
 		TimeCleanupLockedProofs: cfg.CleanupLockedProofsInterval,
 
 		finalProof: make(chan finalProofMsg),
+
+		provers:                newProverFleet(cfg.ProverFailureBackoff.Duration),
+		proofGenerationTimeout: proofGenerationTimeout,
 	}
 
 	return a, nil
@@ -187,6 +202,9 @@ func (a *Aggregator) Channel(stream prover.AggregatorService_ChannelServer) erro
 		return err
 	}
 
+	a.provers.connected(prover)
+	defer a.provers.disconnected(prover)
+
 	for {
 		select {
 		case <-a.ctx.Done():
@@ -208,6 +226,11 @@ func (a *Aggregator) Channel(stream prover.AggregatorService_ChannelServer) erro
 				time.Sleep(a.cfg.RetryTime.Duration)
 				continue
 			}
+			if !a.provers.isHealthy(prover) {
+				log.Debug("Prover is backing off after failing to generate a proof")
+				time.Sleep(a.cfg.RetryTime.Duration)
+				continue
+			}
 
 			_, err = a.tryBuildFinalProof(ctx, prover, nil)
 			if err != nil {
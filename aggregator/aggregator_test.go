@@ -1456,3 +1456,106 @@ func TestIsSynced(t *testing.T) {
 		})
 	}
 }
+
+func TestWaitRecursiveProof(t *testing.T) {
+	require := require.New(t)
+	assert := assert.New(t)
+	proofID := "proofId"
+	recursiveProof := "recursiveProof"
+	errBanana := errors.New("banana")
+	cfg := Config{
+		GeneratingProofCleanupThreshold: "10ms",
+		ProverFailureBackoff:            configTypes.NewDuration(time.Hour),
+	}
+	waitUntilCanceled := func(ctx context.Context, proofID string) (string, error) {
+		<-ctx.Done()
+		return "", ctx.Err()
+	}
+
+	testCases := []struct {
+		name         string
+		disconnected bool
+		setup        func(mox, *Aggregator)
+		asserts      func(string, *Aggregator, *mocks.ProverMock, error)
+	}{
+		{
+			name: "proof generated",
+			setup: func(m mox, a *Aggregator) {
+				m.proverMock.On("WaitRecursiveProof", mock.Anything, proofID).Return(recursiveProof, nil).Once()
+			},
+			asserts: func(proof string, a *Aggregator, p *mocks.ProverMock, err error) {
+				assert.NoError(err)
+				assert.Equal(recursiveProof, proof)
+				assert.True(a.provers.isHealthy(p))
+			},
+		},
+		{
+			name: "proof not generated in time is canceled",
+			setup: func(m mox, a *Aggregator) {
+				m.proverMock.On("WaitRecursiveProof", mock.Anything, proofID).Return(waitUntilCanceled).Once()
+				m.proverMock.On("CancelProofRequest", proofID).Return(nil).Once()
+			},
+			asserts: func(proof string, a *Aggregator, p *mocks.ProverMock, err error) {
+				assert.ErrorIs(err, errProofGenerationTimeout)
+				assert.False(a.provers.isHealthy(p))
+				assert.Equal(uint64(1), a.provers.provers[p].consecutiveFailures)
+			},
+		},
+		{
+			name: "prover error",
+			setup: func(m mox, a *Aggregator) {
+				m.proverMock.On("WaitRecursiveProof", mock.Anything, proofID).Return("", errBanana).Once()
+			},
+			asserts: func(proof string, a *Aggregator, p *mocks.ProverMock, err error) {
+				assert.ErrorIs(err, errBanana)
+				assert.False(a.provers.isHealthy(p))
+			},
+		},
+		{
+			name:         "prover disconnected",
+			disconnected: true,
+			setup: func(m mox, a *Aggregator) {
+				m.proverMock.On("WaitRecursiveProof", mock.Anything, proofID).Return("", context.Canceled).Once()
+			},
+			asserts: func(proof string, a *Aggregator, p *mocks.ProverMock, err error) {
+				assert.ErrorIs(err, context.Canceled)
+				assert.True(a.provers.isHealthy(p))
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			stateMock := mocks.NewStateMock(t)
+			ethTxManager := mocks.NewEthTxManager(t)
+			etherman := mocks.NewEtherman(t)
+			proverMock := mocks.NewProverMock(t)
+			a, err := New(cfg, stateMock, ethTxManager, etherman)
+			require.NoError(err)
+			m := mox{
+				stateMock:    stateMock,
+				ethTxManager: ethTxManager,
+				etherman:     etherman,
+				proverMock:   proverMock,
+			}
+			proverMock.On("Name").Return("proverName").Once()
+			proverMock.On("ID").Return("proverID").Once()
+			proverMock.On("Addr").Return("addr").Once()
+			a.provers.connected(proverMock)
+			if tc.setup != nil {
+				tc.setup(m, &a)
+			}
+
+			proverCtx, cancel := context.WithCancel(context.Background())
+			if tc.disconnected {
+				cancel()
+			}
+			defer cancel()
+			proof, err := a.waitRecursiveProof(proverCtx, proverMock, proofID)
+
+			if tc.asserts != nil {
+				tc.asserts(proof, &a, proverMock, err)
+			}
+		})
+	}
+}
@@ -74,6 +74,12 @@ type Config struct {
 	// allowed to be cleared.
 	GeneratingProofCleanupThreshold string `mapstructure:"GeneratingProofCleanupThreshold"`
 
+	// ProverFailureBackoff is the time a prover doesn't get new proofs to generate after failing
+	// to generate one, multiplied by its consecutive failures up to 10 times, so the proofs are
+	// generated by the healthy provers. A prover not generating its proof before the
+	// GeneratingProofCleanupThreshold is considered to have failed and its proof request is canceled
+	ProverFailureBackoff types.Duration `mapstructure:"ProverFailureBackoff"`
+
 	// GasOffset is the amount of gas to be added to the gas estimation in order
 	// to provide an amount that is higher than the estimated one. This is used
 	// to avoid the TX getting reverted in case something has changed in the network
@@ -9,6 +9,8 @@ const (
 	prefix                      = "aggregator_"
 	currentConnectedProversName = prefix + "current_connected_provers"
 	currentWorkingProversName   = prefix + "current_working_provers"
+	failedProofsName            = prefix + "failed_proofs"
+	timedOutProofsName          = prefix + "timed_out_proofs"
 )
 
 // Register the metrics for the sequencer package.
@@ -24,7 +26,19 @@ func Register() {
 		},
 	}
 
+	counters := []prometheus.CounterOpts{
+		{
+			Name: failedProofsName,
+			Help: "[AGGREGATOR] proofs the provers failed to generate",
+		},
+		{
+			Name: timedOutProofsName,
+			Help: "[AGGREGATOR] proofs canceled for not being generated in time",
+		},
+	}
+
 	metrics.RegisterGauges(gauges...)
+	metrics.RegisterCounters(counters...)
 }
 
 // ConnectedProver increments the gauge for the current number of connected
@@ -49,3 +63,15 @@ func WorkingProver() {
 func IdlingProver() {
 	metrics.GaugeDec(currentWorkingProversName)
 }
+
+// FailedProof increments the counter of the proofs the provers failed to
+// generate.
+func FailedProof() {
+	metrics.CounterInc(failedProofsName)
+}
+
+// TimedOutProof increments the counter of the proofs canceled for not being
+// generated in time.
+func TimedOutProof() {
+	metrics.CounterInc(timedOutProofsName)
+}
@@ -92,6 +92,24 @@ func (_m *ProverMock) BatchProof(input *prover.InputProver) (*string, error) {
 	return r0, r1
 }
 
+// CancelProofRequest provides a mock function with given fields: proofID
+func (_m *ProverMock) CancelProofRequest(proofID string) error {
+	ret := _m.Called(proofID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CancelProofRequest")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string) error); ok {
+		r0 = rf(proofID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // FinalProof provides a mock function with given fields: inputProof, aggregatorAddr
 func (_m *ProverMock) FinalProof(inputProof string, aggregatorAddr string) (*string, error) {
 	ret := _m.Called(inputProof, aggregatorAddr)
@@ -0,0 +1,158 @@
+package aggregator
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/0xPolygonHermez/zkevm-node/aggregator/metrics"
+	"github.com/0xPolygonHermez/zkevm-node/aggregator/prover"
+	"github.com/0xPolygonHermez/zkevm-node/log"
+)
+
+// maxProverFailureBackoffFactor is the max number of times the failure backoff
+// is applied to a prover failing consecutively.
+const maxProverFailureBackoffFactor = 10
+
+var errProofGenerationTimeout = errors.New("proof not generated in time")
+
+// proverStatus is the health of a prover connected to the aggregator.
+type proverStatus struct {
+	name                string
+	id                  string
+	addr                string
+	consecutiveFailures uint64
+	unhealthyUntil      time.Time
+}
+
+// proverFleet tracks the health of the provers connected to the aggregator.
+// Each prover takes the proofs to generate from the state when it's idle, so a
+// prover failing to generate its proofs doesn't get new ones for a while,
+// leaving them to the healthy provers.
+type proverFleet struct {
+	mutex   sync.Mutex
+	backoff time.Duration
+	provers map[proverInterface]*proverStatus
+}
+
+func newProverFleet(backoff time.Duration) *proverFleet {
+	return &proverFleet{
+		backoff: backoff,
+		provers: make(map[proverInterface]*proverStatus),
+	}
+}
+
+// connected adds the prover to the fleet.
+func (f *proverFleet) connected(prover proverInterface) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	f.provers[prover] = &proverStatus{
+		name: prover.Name(),
+		id:   prover.ID(),
+		addr: prover.Addr(),
+	}
+}
+
+// disconnected removes the prover from the fleet.
+func (f *proverFleet) disconnected(prover proverInterface) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	delete(f.provers, prover)
+}
+
+// isHealthy returns false while the prover is backing off after failing to
+// generate a proof.
+func (f *proverFleet) isHealthy(prover proverInterface) bool {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	status, found := f.provers[prover]
+	return !found || !time.Now().Before(status.unhealthyUntil)
+}
+
+// proofGenerated resets the failures of the prover.
+func (f *proverFleet) proofGenerated(prover proverInterface) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	if status, found := f.provers[prover]; found {
+		status.consecutiveFailures = 0
+	}
+}
+
+// proofFailed keeps the prover without new proofs to generate for the failure
+// backoff multiplied by its consecutive failures.
+func (f *proverFleet) proofFailed(prover proverInterface) {
+	metrics.FailedProof()
+
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	status, found := f.provers[prover]
+	if !found {
+		return
+	}
+	status.consecutiveFailures++
+	factor := status.consecutiveFailures
+	if factor > maxProverFailureBackoffFactor {
+		factor = maxProverFailureBackoffFactor
+	}
+	backoff := f.backoff * time.Duration(factor)
+	status.unhealthyUntil = time.Now().Add(backoff)
+
+	log.WithFields(
+		"prover", status.name,
+		"proverId", status.id,
+		"proverAddr", status.addr,
+	).Warnf("Prover failed %d consecutive times, no new proofs to generate for %v", status.consecutiveFailures, backoff)
+}
+
+// proofGenerationContext returns the context to wait for a proof. The wait is
+// limited to the generating proof cleanup threshold because after it the proof
+// is released to be generated by other provers.
+func (a *Aggregator) proofGenerationContext(ctx context.Context) (context.Context, context.CancelFunc) {
+	if a.proofGenerationTimeout == 0 {
+		return context.WithCancel(ctx)
+	}
+	return context.WithTimeout(ctx, a.proofGenerationTimeout)
+}
+
+// waitRecursiveProof waits for the recursive proof to be generated by the
+// prover and records the result in the health of the prover.
+func (a *Aggregator) waitRecursiveProof(ctx context.Context, prover proverInterface, proofID string) (string, error) {
+	waitCtx, cancel := a.proofGenerationContext(ctx)
+	defer cancel()
+	recursiveProof, err := prover.WaitRecursiveProof(waitCtx, proofID)
+	return recursiveProof, a.handleProofGenerationResult(waitCtx, prover, proofID, err)
+}
+
+// waitFinalProof waits for the final proof to be generated by the prover and
+// records the result in the health of the prover.
+func (a *Aggregator) waitFinalProof(ctx context.Context, prover proverInterface, proofID string) (*prover.FinalProof, error) {
+	waitCtx, cancel := a.proofGenerationContext(ctx)
+	defer cancel()
+	finalProof, err := prover.WaitFinalProof(waitCtx, proofID)
+	return finalProof, a.handleProofGenerationResult(waitCtx, prover, proofID, err)
+}
+
+// handleProofGenerationResult records the result of the proof generation in
+// the health of the prover. The proofs not generated in time are canceled in
+// the prover.
+func (a *Aggregator) handleProofGenerationResult(waitCtx context.Context, prover proverInterface, proofID string, err error) error {
+	if err == nil {
+		a.provers.proofGenerated(prover)
+		return nil
+	}
+	switch {
+	case errors.Is(waitCtx.Err(), context.DeadlineExceeded):
+		metrics.TimedOutProof()
+		if cancelErr := prover.CancelProofRequest(proofID); cancelErr != nil {
+			log.Warnf("Failed to cancel proof ID %s not generated in time: %v", proofID, cancelErr)
+		}
+		err = fmt.Errorf("%w, proof ID %s, timeout %v", errProofGenerationTimeout, proofID, a.proofGenerationTimeout)
+	case waitCtx.Err() != nil:
+		// the prover or the aggregator disconnected, it's not a failure of the prover
+		return err
+	}
+	a.provers.proofFailed(prover)
+	return err
+}
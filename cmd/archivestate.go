@@ -0,0 +1,82 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/0xPolygonHermez/zkevm-node/config"
+	"github.com/0xPolygonHermez/zkevm-node/db"
+	"github.com/0xPolygonHermez/zkevm-node/log"
+	"github.com/0xPolygonHermez/zkevm-node/state"
+	"github.com/0xPolygonHermez/zkevm-node/state/pgstatestorage"
+	"github.com/urfave/cli/v2"
+)
+
+var archiveStateFlags = []cli.Flag{
+	&cli.Uint64Flag{
+		Name:     pruneStateFlagKeepVerifiedBatches,
+		Aliases:  []string{"k"},
+		Usage:    "Number of the most recent verified batches whose receipts and logs are kept in the state DB",
+		Required: true,
+	},
+	&configFileFlag,
+	&yesFlag,
+}
+
+func archiveState(ctx *cli.Context) error {
+	// Load config
+	c, err := config.Load(ctx, false)
+	if err != nil {
+		return err
+	}
+	if !c.State.ColdStorage.Enabled {
+		return errors.New("the cold storage of the state is not enabled, set State.ColdStorage.Enabled to archive the state")
+	}
+	keepVerifiedBatches := ctx.Uint64(pruneStateFlagKeepVerifiedBatches)
+
+	if !ctx.Bool(config.FlagYes) {
+		fmt.Printf("*WARNING* Are you sure you want to move the receipts and logs of the txs older than the last %d verified batches to the cold storage? [y/N]: ", keepVerifiedBatches)
+		var input string
+		if _, err := fmt.Scanln(&input); err != nil {
+			return err
+		}
+		input = strings.ToLower(input)
+		if !(input == "y" || input == "yes") {
+			return nil
+		}
+	}
+
+	setupLog(c.Log)
+
+	// Connect to SQL
+	stateSqlDB, err := db.NewSQLDB(c.State.DB)
+	if err != nil {
+		return err
+	}
+	defer stateSqlDB.Close()
+	runStateMigrations(c.State.ColdStorage.DB)
+	coldSqlDB, err := db.NewSQLDB(c.State.ColdStorage.DB)
+	if err != nil {
+		return err
+	}
+	defer coldSqlDB.Close()
+	storage := pgstatestorage.NewTieredStorage(pgstatestorage.NewPostgresStorage(state.Config{}, stateSqlDB), pgstatestorage.NewPostgresStorage(state.Config{}, coldSqlDB))
+	st := state.NewState(state.Config{}, storage, nil, nil, nil, nil, nil)
+
+	dbCtx := context.Background()
+	lastArchivedBatchNumber, err := st.GetLastHistoryBatchNumber(dbCtx, keepVerifiedBatches, nil)
+	if errors.Is(err, state.ErrNothingToPrune) {
+		log.Info(err.Error())
+		return nil
+	} else if err != nil {
+		return err
+	}
+	archivedReceipts, err := storage.ArchiveUpToBatchNumber(dbCtx, lastArchivedBatchNumber)
+	if err != nil {
+		return err
+	}
+	log.Infof("archived the receipts and logs of %d txs up to the batch %d", archivedReceipts, lastArchivedBatchNumber)
+	return nil
+}
@@ -0,0 +1,113 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/0xPolygonHermez/zkevm-node/config"
+	"github.com/0xPolygonHermez/zkevm-node/db"
+	"github.com/0xPolygonHermez/zkevm-node/log"
+	"github.com/0xPolygonHermez/zkevm-node/state"
+	"github.com/0xPolygonHermez/zkevm-node/state/pgstatestorage"
+	"github.com/jackc/pgx/v4"
+	"github.com/urfave/cli/v2"
+)
+
+const (
+	checkpointFlagName        = "name"
+	checkpointFlagBatchNumber = "batch-number"
+)
+
+var checkpointNameFlag = cli.StringFlag{
+	Name:     checkpointFlagName,
+	Aliases:  []string{"n"},
+	Usage:    "Name of the checkpoint",
+	Required: true,
+}
+
+var createCheckpointFlags = []cli.Flag{
+	&checkpointNameFlag,
+	&cli.Uint64Flag{
+		Name:     checkpointFlagBatchNumber,
+		Aliases:  []string{"b"},
+		Usage:    "Number of the closed batch whose state is kept by the checkpoint",
+		Required: true,
+	},
+	&configFileFlag,
+}
+
+var rollbackToCheckpointFlags = []cli.Flag{
+	&checkpointNameFlag,
+	&configFileFlag,
+	&yesFlag,
+}
+
+func createCheckpoint(ctx *cli.Context) error {
+	// Load config
+	c, err := config.Load(ctx, false)
+	if err != nil {
+		return err
+	}
+	setupLog(c.Log)
+
+	name := ctx.String(checkpointFlagName)
+	batchNumber := ctx.Uint64(checkpointFlagBatchNumber)
+	return runCheckpointDBTx(c.State.DB, func(dbCtx context.Context, st *state.State, dbTx pgx.Tx) error {
+		_, err := st.CreateCheckpoint(dbCtx, name, batchNumber, dbTx)
+		return err
+	})
+}
+
+func rollbackToCheckpoint(ctx *cli.Context) error {
+	// Load config
+	c, err := config.Load(ctx, false)
+	if err != nil {
+		return err
+	}
+	name := ctx.String(checkpointFlagName)
+
+	if !ctx.Bool(config.FlagYes) {
+		fmt.Printf("*WARNING* Are you sure you want to delete the batches newer than the checkpoint %s? [y/N]: ", name)
+		var input string
+		if _, err := fmt.Scanln(&input); err != nil {
+			return err
+		}
+		input = strings.ToLower(input)
+		if !(input == "y" || input == "yes") {
+			return nil
+		}
+	}
+
+	setupLog(c.Log)
+
+	return runCheckpointDBTx(c.State.DB, func(dbCtx context.Context, st *state.State, dbTx pgx.Tx) error {
+		_, err := st.RollbackToCheckpoint(dbCtx, name, dbTx)
+		return err
+	})
+}
+
+// runCheckpointDBTx runs the provided checkpoint action in a state DB transaction, that is committed
+// only if the action succeeds. The node must be stopped, as the action changes the trusted state
+func runCheckpointDBTx(cfg db.Config, action func(ctx context.Context, st *state.State, dbTx pgx.Tx) error) error {
+	// Connect to SQL
+	stateSqlDB, err := db.NewSQLDB(cfg)
+	if err != nil {
+		return err
+	}
+	defer stateSqlDB.Close()
+	st := state.NewState(state.Config{}, pgstatestorage.NewPostgresStorage(state.Config{}, stateSqlDB), nil, nil, nil, nil, nil)
+
+	dbCtx := context.Background()
+	dbTx, err := st.BeginStateTransaction(dbCtx)
+	if err != nil {
+		return err
+	}
+	if err := action(dbCtx, st, dbTx); err != nil {
+		if rollbackErr := dbTx.Rollback(dbCtx); rollbackErr != nil {
+			log.Errorf("failed to rollback the state db tx, error: %v", rollbackErr)
+		}
+		return err
+	}
+	return dbTx.Commit(dbCtx)
+}
@@ -72,7 +72,7 @@ var (
 	httpAPIFlag = cli.StringSliceFlag{
 		Name:     config.FlagHTTPAPI,
 		Aliases:  []string{"ha"},
-		Usage:    fmt.Sprintf("List of JSON RPC apis to be exposed by the server: --http.api=%v,%v,%v,%v,%v,%v", jsonrpc.APIEth, jsonrpc.APINet, jsonrpc.APIDebug, jsonrpc.APIZKEVM, jsonrpc.APITxPool, jsonrpc.APIWeb3),
+		Usage:    fmt.Sprintf("List of JSON RPC apis to be exposed by the server: --http.api=%v,%v,%v,%v,%v,%v,%v,%v,%v", jsonrpc.APIEth, jsonrpc.APINet, jsonrpc.APIDebug, jsonrpc.APIZKEVM, jsonrpc.APITxPool, jsonrpc.APIPool, jsonrpc.APIWeb3, jsonrpc.APITrace, jsonrpc.APIEthTxManager),
 		Required: false,
 		Value:    cli.NewStringSlice(jsonrpc.APIEth, jsonrpc.APINet, jsonrpc.APIZKEVM, jsonrpc.APITxPool, jsonrpc.APIWeb3),
 	}
@@ -166,6 +166,34 @@ func main() {
 			Action:  dumpState,
 			Flags:   dumpStateFlags,
 		},
+		{
+			Name:    "pruneState",
+			Aliases: []string{},
+			Usage:   "Deletes the receipts and logs of the txs of the batches older than the last verified batches to keep",
+			Action:  pruneState,
+			Flags:   pruneStateFlags,
+		},
+		{
+			Name:    "archiveState",
+			Aliases: []string{},
+			Usage:   "Moves the receipts and logs of the txs of the batches older than the last verified batches to keep to the cold storage",
+			Action:  archiveState,
+			Flags:   archiveStateFlags,
+		},
+		{
+			Name:    "createCheckpoint",
+			Aliases: []string{},
+			Usage:   "Creates a named checkpoint of the state at the end of a closed batch",
+			Action:  createCheckpoint,
+			Flags:   createCheckpointFlags,
+		},
+		{
+			Name:    "rollbackToCheckpoint",
+			Aliases: []string{},
+			Usage:   "Deletes the batches newer than the batch of a checkpoint, rolling back the trusted state to it",
+			Action:  rollbackToCheckpoint,
+			Flags:   rollbackToCheckpointFlags,
+		},
 		{
 			Name:   "generate-json-schema",
 			Usage:  "Generate the json-schema for the configuration file, and store it on docs/schema.json",
@@ -186,6 +214,20 @@ func main() {
 			Action:  restore,
 			Flags:   restoreFlags,
 		},
+		{
+			Name:    "exportPool",
+			Aliases: []string{},
+			Usage:   "Exports the pending txs of the pool to a file, to be imported in another pool",
+			Action:  exportPool,
+			Flags:   exportPoolFlags,
+		},
+		{
+			Name:    "importPool",
+			Aliases: []string{},
+			Usage:   "Imports to the pool the txs exported by the exportPool command",
+			Action:  importPool,
+			Flags:   importPoolFlags,
+		},
 	}
 
 	err := app.Run(os.Args)
@@ -0,0 +1,105 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/0xPolygonHermez/zkevm-node/config"
+	"github.com/0xPolygonHermez/zkevm-node/log"
+	"github.com/0xPolygonHermez/zkevm-node/pool"
+	"github.com/0xPolygonHermez/zkevm-node/pool/pgpoolstorage"
+	"github.com/urfave/cli/v2"
+)
+
+const (
+	poolSnapshotFlagOutput = "output"
+	poolSnapshotFlagInput  = "input"
+)
+
+var exportPoolFlags = []cli.Flag{
+	&cli.StringFlag{
+		Name:     poolSnapshotFlagOutput,
+		Aliases:  []string{"o"},
+		Usage:    "Output file to save the pending txs of the pool",
+		Required: true,
+	},
+	&configFileFlag,
+}
+
+var importPoolFlags = []cli.Flag{
+	&cli.StringFlag{
+		Name:     poolSnapshotFlagInput,
+		Aliases:  []string{"i"},
+		Usage:    "Input file with the txs exported by the exportPool command",
+		Required: true,
+	},
+	&configFileFlag,
+}
+
+func exportPool(ctx *cli.Context) error {
+	// Load config
+	c, err := config.Load(ctx, false)
+	if err != nil {
+		return err
+	}
+	setupLog(c.Log)
+
+	poolStorage, err := newPoolSnapshotStorage(c.Pool)
+	if err != nil {
+		return err
+	}
+
+	file, err := os.OpenFile(ctx.String(poolSnapshotFlagOutput), os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0600) //nolint:gomnd
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	count, err := pool.ExportTxs(context.Background(), poolStorage, file)
+	if err != nil {
+		return err
+	}
+	log.Infof("%d txs exported from the pool to %s", count, file.Name())
+	return file.Sync()
+}
+
+func importPool(ctx *cli.Context) error {
+	// Load config
+	c, err := config.Load(ctx, false)
+	if err != nil {
+		return err
+	}
+	setupLog(c.Log)
+
+	poolStorage, err := newPoolSnapshotStorage(c.Pool)
+	if err != nil {
+		return err
+	}
+
+	file, err := os.Open(ctx.String(poolSnapshotFlagInput))
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	count, err := pool.ImportTxs(context.Background(), poolStorage, file)
+	if err != nil {
+		return err
+	}
+	log.Infof("%d txs imported to the pool from %s", count, file.Name())
+	return nil
+}
+
+// newPoolSnapshotStorage connects to the DB of the pool, the memory storage can't be exported nor imported
+func newPoolSnapshotStorage(cfg pool.Config) (pool.Storage, error) {
+	if cfg.StorageType != pool.StorageTypePostgres && cfg.StorageType != "" {
+		return nil, fmt.Errorf("the pool storage type %s can't be exported nor imported", cfg.StorageType)
+	}
+	runPoolMigrations(cfg.DB)
+	poolStorage, err := pgpoolstorage.NewPostgresPoolStorage(cfg.DB)
+	if err != nil {
+		return nil, err
+	}
+	return poolStorage, nil
+}
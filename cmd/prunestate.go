@@ -0,0 +1,81 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/0xPolygonHermez/zkevm-node/config"
+	"github.com/0xPolygonHermez/zkevm-node/db"
+	"github.com/0xPolygonHermez/zkevm-node/log"
+	"github.com/0xPolygonHermez/zkevm-node/state"
+	"github.com/0xPolygonHermez/zkevm-node/state/pgstatestorage"
+	"github.com/urfave/cli/v2"
+)
+
+const pruneStateFlagKeepVerifiedBatches = "keep-verified-batches"
+
+var pruneStateFlags = []cli.Flag{
+	&cli.Uint64Flag{
+		Name:     pruneStateFlagKeepVerifiedBatches,
+		Aliases:  []string{"k"},
+		Usage:    "Number of the most recent verified batches whose receipts and logs are kept",
+		Required: true,
+	},
+	&configFileFlag,
+	&yesFlag,
+}
+
+func pruneState(ctx *cli.Context) error {
+	// Load config
+	c, err := config.Load(ctx, false)
+	if err != nil {
+		return err
+	}
+	keepVerifiedBatches := ctx.Uint64(pruneStateFlagKeepVerifiedBatches)
+
+	if !ctx.Bool(config.FlagYes) {
+		fmt.Printf("*WARNING* Are you sure you want to delete the receipts and logs of the txs older than the last %d verified batches? [y/N]: ", keepVerifiedBatches)
+		var input string
+		if _, err := fmt.Scanln(&input); err != nil {
+			return err
+		}
+		input = strings.ToLower(input)
+		if !(input == "y" || input == "yes") {
+			return nil
+		}
+	}
+
+	setupLog(c.Log)
+
+	// Connect to SQL
+	stateSqlDB, err := db.NewSQLDB(c.State.DB)
+	if err != nil {
+		return err
+	}
+	defer stateSqlDB.Close()
+	st := state.NewState(state.Config{}, pgstatestorage.NewPostgresStorage(state.Config{}, stateSqlDB), nil, nil, nil, nil, nil)
+
+	dbCtx := context.Background()
+	dbTx, err := st.BeginStateTransaction(dbCtx)
+	if err != nil {
+		return err
+	}
+	lastPrunedBatchNumber, prunedReceipts, err := st.PruneHistory(dbCtx, keepVerifiedBatches, dbTx)
+	if err != nil {
+		if rollbackErr := dbTx.Rollback(dbCtx); rollbackErr != nil {
+			log.Errorf("failed to rollback the pruning of the state, error: %v", rollbackErr)
+		}
+		if errors.Is(err, state.ErrNothingToPrune) {
+			log.Info(err.Error())
+			return nil
+		}
+		return err
+	}
+	if err := dbTx.Commit(dbCtx); err != nil {
+		return err
+	}
+	log.Infof("pruned the receipts and logs of %d txs up to the batch %d", prunedReceipts, lastPrunedBatchNumber)
+	return nil
+}
@@ -13,6 +13,8 @@ import (
 	"github.com/0xPolygonHermez/zkevm-node/config"
 	"github.com/0xPolygonHermez/zkevm-node/db"
 	"github.com/0xPolygonHermez/zkevm-node/log"
+	"github.com/0xPolygonHermez/zkevm-node/state"
+	"github.com/0xPolygonHermez/zkevm-node/state/pgstatestorage"
 	pg "github.com/habx/pg-commands"
 	"github.com/urfave/cli/v2"
 )
@@ -49,13 +51,30 @@ func restore(ctx *cli.Context) error {
 	if !strings.Contains(inputFileStateDB, ".sql.tar.gz") {
 		return errors.New("stateDB input file must end in .sql.tar.gz")
 	}
+	inputFileHashDB := ctx.String(restoreHashDbFlag)
+	if !strings.Contains(inputFileHashDB, ".sql.tar.gz") {
+		return errors.New("hashDb input file must end in .sql.tar.gz")
+	}
+	// the snapshots are verified before dropping the current databases
+	for _, inputFile := range []string{inputFileStateDB, inputFileHashDB} {
+		if err := verifySnapshotChecksum(inputFile); err != nil {
+			log.Error("error verifying the snapshot. Error: ", err)
+			return err
+		}
+	}
+	manifest, err := readSnapshotManifest(inputFileStateDB)
+	if err != nil {
+		log.Error("error reading the manifest of the snapshot. Error: ", err)
+		return err
+	}
 
-	d, err := db.NewSQLDB(c.State.DB)
+	stateSqlDB, err := db.NewSQLDB(c.State.DB)
 	if err != nil {
 		log.Error("error conecting to stateDB. Error: ", err)
 		return err
 	}
-	_, err = d.Exec(ctx.Context, "DROP SCHEMA IF EXISTS state CASCADE; DROP TABLE IF EXISTS gorp_migrations;")
+	defer stateSqlDB.Close()
+	_, err = stateSqlDB.Exec(ctx.Context, "DROP SCHEMA IF EXISTS state CASCADE; DROP TABLE IF EXISTS gorp_migrations;")
 	if err != nil {
 		log.Error("error dropping state schema or migration table. Error: ", err)
 		return err
@@ -82,25 +101,22 @@ func restore(ctx *cli.Context) error {
 	if restoreExec.Error != nil {
 		log.Error("error restoring stateDB snapshot. Error: ", restoreExec.Error.Err)
 		log.Debug("restoreExec.Output: ", restoreExec.Output)
-		return err
+		return restoreExec.Error.Err
 	}
 	log.Info("Restore stateDB snapshot success")
 
-	inputFileHashDB := ctx.String(restoreHashDbFlag)
-	if !strings.Contains(inputFileHashDB, ".sql.tar.gz") {
-		return errors.New("hashDb input file must end in .sql.tar.gz")
-	}
 	port, err = strconv.Atoi(c.HashDB.Port)
 	if err != nil {
 		log.Error("error converting port to int. Error: ", err)
 		return err
 	}
-	d, err = db.NewSQLDB(c.HashDB)
+	hashSqlDB, err := db.NewSQLDB(c.HashDB)
 	if err != nil {
 		log.Error("error conecting to hashdb. Error: ", err)
 		return err
 	}
-	_, err = d.Exec(ctx.Context, "DROP SCHEMA IF EXISTS state CASCADE;")
+	defer hashSqlDB.Close()
+	_, err = hashSqlDB.Exec(ctx.Context, "DROP SCHEMA IF EXISTS state CASCADE;")
 	if err != nil {
 		log.Error("error dropping and creating state schema. Error: ", err)
 		return err
@@ -122,10 +138,15 @@ func restore(ctx *cli.Context) error {
 	if restoreExec.Error != nil {
 		log.Error("error restoring hashDB snapshot. Error: ", restoreExec.Error.Err)
 		log.Debug("restoreExec.Output: ", restoreExec.Output)
-		return err
+		return restoreExec.Error.Err
 	}
-
 	log.Info("Restore HashDB snapshot success")
+
+	if err := verifySnapshotState(ctx.Context, pgstatestorage.NewPostgresStorage(state.Config{}, stateSqlDB), manifest); err != nil {
+		log.Error("error verifying the restored state. Error: ", err)
+		return err
+	}
+	log.Infof("Restored state verified at the batch %d with the state root %s", manifest.BatchNumber, manifest.StateRoot.String())
 	return nil
 }
 
@@ -9,6 +9,7 @@ import (
 	"net/http/pprof"
 	"os"
 	"os/signal"
+	"path/filepath"
 	"runtime"
 	"time"
 
@@ -29,6 +30,9 @@ import (
 	"github.com/0xPolygonHermez/zkevm-node/merkletree"
 	"github.com/0xPolygonHermez/zkevm-node/metrics"
 	"github.com/0xPolygonHermez/zkevm-node/pool"
+	"github.com/0xPolygonHermez/zkevm-node/pool/bundler"
+	"github.com/0xPolygonHermez/zkevm-node/pool/gossip"
+	"github.com/0xPolygonHermez/zkevm-node/pool/memorypoolstorage"
 	"github.com/0xPolygonHermez/zkevm-node/pool/pgpoolstorage"
 	"github.com/0xPolygonHermez/zkevm-node/sequencer"
 	"github.com/0xPolygonHermez/zkevm-node/sequencesender"
@@ -37,6 +41,8 @@ import (
 	"github.com/0xPolygonHermez/zkevm-node/state/runtime/executor"
 	"github.com/0xPolygonHermez/zkevm-node/synchronizer"
 	"github.com/0xPolygonHermez/zkevm-node/synchronizer/common/syncinterfaces"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/accounts/keystore"
 	"github.com/ethereum/go-ethereum/ethclient"
 	"github.com/jackc/pgx/v4/pgxpool"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
@@ -68,10 +74,17 @@ func start(cliCtx *cli.Context) error {
 			if comp == SYNCHRONIZER {
 				log.Infof("Running DB migrations host: %s:%s db:%s user:%s", c.State.DB.Host, c.State.DB.Port, c.State.DB.Name, c.State.DB.User)
 				runStateMigrations(c.State.DB)
+				if c.State.ColdStorage.Enabled {
+					log.Infof("Running DB migrations host: %s:%s db:%s user:%s", c.State.ColdStorage.DB.Host, c.State.ColdStorage.DB.Port, c.State.ColdStorage.DB.Name, c.State.ColdStorage.DB.User)
+					runStateMigrations(c.State.ColdStorage.DB)
+				}
 			}
 		}
 	}
 	checkStateMigrations(c.State.DB)
+	if c.State.ColdStorage.Enabled {
+		checkStateMigrations(c.State.ColdStorage.DB)
+	}
 
 	var (
 		eventLog                      *event.EventLog
@@ -108,7 +121,7 @@ func start(cliCtx *cli.Context) error {
 		log.Fatal(err)
 	}
 
-	etherman, err := newEtherman(*c)
+	etherman, err := newEtherman(cliCtx.Context, *c)
 	if err != nil {
 		log.Fatal(err)
 	}
@@ -170,8 +183,18 @@ func start(cliCtx *cli.Context) error {
 				log.Fatal(err)
 			}
 			if poolInstance == nil {
-				poolInstance = createPool(c.Pool, c.State.Batch.Constraints, l2ChainID, st, eventLog)
+				poolInstance = createPool(cliCtx.Context, c.Pool, c.State.Batch.Constraints, l2ChainID, st, eventLog)
+			}
+			// Needed for discarding the txs from/to addresses blocked after the txs were added to the pool
+			poolInstance.StartRefreshingBlockedAddressesPeriodically()
+			poolInstance.StartEvictingTxsPeriodically(cliCtx.Context)
+			poolInstance.StartPromotingScheduledTxsPeriodically(cliCtx.Context)
+			if c.Pool.AccountAbstraction.Enabled {
+				poolInstance.RegisterUserOperationsBundler(createUserOperationsBundler(c.Pool.AccountAbstraction, l2ChainID, poolInstance, st))
 			}
+			poolInstance.StartBundlingUserOperationsPeriodically(cliCtx.Context)
+			poolInstance.StartPruningNonceTooLowTxs()
+			poolInstance.StartPromotingQueuedTxs()
 			seq := createSequencer(*c, poolInstance, st, etherman, eventLog)
 			go seq.Start(cliCtx.Context)
 		case SEQUENCE_SENDER:
@@ -182,9 +205,9 @@ func start(cliCtx *cli.Context) error {
 				log.Fatal(err)
 			}
 			if poolInstance == nil {
-				poolInstance = createPool(c.Pool, c.State.Batch.Constraints, l2ChainID, st, eventLog)
+				poolInstance = createPool(cliCtx.Context, c.Pool, c.State.Batch.Constraints, l2ChainID, st, eventLog)
 			}
-			seqSender := createSequenceSender(*c, poolInstance, ethTxManagerStorage, st, eventLog)
+			seqSender := createSequenceSender(cliCtx.Context, *c, poolInstance, ethTxManagerStorage, st, eventLog)
 			go seqSender.Start(cliCtx.Context)
 		case RPC:
 			ev.Component = event.Component_RPC
@@ -194,19 +217,21 @@ func start(cliCtx *cli.Context) error {
 				log.Fatal(err)
 			}
 			if poolInstance == nil {
-				poolInstance = createPool(c.Pool, c.State.Batch.Constraints, l2ChainID, st, eventLog)
+				poolInstance = createPool(cliCtx.Context, c.Pool, c.State.Batch.Constraints, l2ChainID, st, eventLog)
 			}
 			if c.RPC.EnableL2SuggestedGasPricePolling {
 				// Needed for rejecting transactions with too low gas price
 				poolInstance.StartPollingMinSuggestedGasPrice(cliCtx.Context)
 			}
 			poolInstance.StartRefreshingBlockedAddressesPeriodically()
+			poolInstance.StartPromotingScheduledTxsPeriodically(cliCtx.Context)
+			poolInstance.StartPromotingQueuedTxs()
 			apis := map[string]bool{}
 			for _, a := range cliCtx.StringSlice(config.FlagHTTPAPI) {
 				apis[a] = true
 			}
 			st, _ := newState(cliCtx.Context, c, etherman, l2ChainID, stateSqlDB, eventLog, needsExecutor, needsStateTree, true)
-			go runJSONRPCServer(*c, etherman, l2ChainID, poolInstance, st, apis)
+			go runJSONRPCServer(cliCtx.Context, *c, etherman, etm, l2ChainID, poolInstance, st, apis)
 		case SYNCHRONIZER:
 			ev.Component = event.Component_Synchronizer
 			ev.Description = "Running synchronizer"
@@ -215,9 +240,9 @@ func start(cliCtx *cli.Context) error {
 				log.Fatal(err)
 			}
 			if poolInstance == nil {
-				poolInstance = createPool(c.Pool, c.State.Batch.Constraints, l2ChainID, st, eventLog)
+				poolInstance = createPool(cliCtx.Context, c.Pool, c.State.Batch.Constraints, l2ChainID, st, eventLog)
 			}
-			go runSynchronizer(*c, etherman, ethTxManagerStorage, st, poolInstance, eventLog)
+			go runSynchronizer(cliCtx.Context, *c, etherman, ethTxManagerStorage, st, poolInstance, eventLog)
 		case ETHTXMANAGER:
 			ev.Component = event.Component_EthTxManager
 			ev.Description = "Running eth tx manager service"
@@ -225,7 +250,7 @@ func start(cliCtx *cli.Context) error {
 			if err != nil {
 				log.Fatal(err)
 			}
-			etm := createEthTxManager(*c, ethTxManagerStorage, st)
+			etm := createEthTxManager(cliCtx.Context, *c, ethTxManagerStorage, st)
 			go etm.Start()
 		case L2GASPRICER:
 			ev.Component = event.Component_GasPricer
@@ -235,7 +260,7 @@ func start(cliCtx *cli.Context) error {
 				log.Fatal(err)
 			}
 			if poolInstance == nil {
-				poolInstance = createPool(c.Pool, c.State.Batch.Constraints, l2ChainID, st, eventLog)
+				poolInstance = createPool(cliCtx.Context, c.Pool, c.State.Batch.Constraints, l2ChainID, st, eventLog)
 			}
 			go runL2GasPriceSuggester(c.L2GasPriceSuggester, st, poolInstance, etherman)
 		}
@@ -277,8 +302,8 @@ func runMigrations(c db.Config, name string) {
 	}
 }
 
-func newEtherman(c config.Config) (*etherman.Client, error) {
-	return etherman.NewClient(c.Etherman, c.NetworkConfig.L1Config)
+func newEtherman(ctx context.Context, c config.Config) (*etherman.Client, error) {
+	return etherman.NewClient(ctx, c.Etherman, c.NetworkConfig.L1Config)
 }
 
 func newL2EthClient(url string) (*ethclient.Client, error) {
@@ -290,7 +315,7 @@ func newL2EthClient(url string) (*ethclient.Client, error) {
 	return ethClient, nil
 }
 
-func runSynchronizer(cfg config.Config, etherman *etherman.Client, ethTxManagerStorage *ethtxmanager.PostgresStorage, st *state.State, pool *pool.Pool, eventLog *event.EventLog) {
+func runSynchronizer(ctx context.Context, cfg config.Config, etherman *etherman.Client, ethTxManagerStorage *ethtxmanager.PostgresStorage, st *state.State, pool *pool.Pool, eventLog *event.EventLog) {
 	var trustedSequencerURL string
 	var err error
 	if !cfg.IsTrustedSequencer {
@@ -321,7 +346,7 @@ func runSynchronizer(cfg config.Config, etherman *etherman.Client, ethTxManagerS
 	// If synchronizer are using sequential mode, we only need one etherman client
 	if cfg.Synchronizer.L1SynchronizationMode == synchronizer.ParallelMode {
 		for i := 0; i < int(cfg.Synchronizer.L1ParallelSynchronization.MaxClients+1); i++ {
-			eth, err := newEtherman(cfg)
+			eth, err := newEtherman(ctx, cfg)
 			if err != nil {
 				log.Fatal(err)
 			}
@@ -341,9 +366,10 @@ func runSynchronizer(cfg config.Config, etherman *etherman.Client, ethTxManagerS
 	}
 }
 
-func runJSONRPCServer(c config.Config, etherman *etherman.Client, chainID uint64, pool *pool.Pool, st *state.State, apis map[string]bool) {
+func runJSONRPCServer(ctx context.Context, c config.Config, etherman *etherman.Client, ethTxManager *ethtxmanager.Client, chainID uint64, pool *pool.Pool, st *state.State, apis map[string]bool) {
 	var err error
-	storage := jsonrpc.NewStorage()
+	storage := jsonrpc.NewStorage(c.RPC.MaxFilters, c.RPC.MaxFiltersPerClient, c.RPC.FilterTimeout.Duration)
+	storage.StartUninstallingExpiredFiltersPeriodically(ctx)
 	c.RPC.MaxCumulativeGasUsed = c.State.Batch.Constraints.MaxCumulativeGasUsed
 	c.RPC.L2Coinbase = c.SequenceSender.L2Coinbase
 	c.RPC.ZKCountersLimits = jsonrpc.ZKCountersLimits{
@@ -392,14 +418,28 @@ func runJSONRPCServer(c config.Config, etherman *etherman.Client, chainID uint64
 	if _, ok := apis[jsonrpc.APITxPool]; ok {
 		services = append(services, jsonrpc.Service{
 			Name:    jsonrpc.APITxPool,
-			Service: &jsonrpc.TxPoolEndpoints{},
+			Service: jsonrpc.NewTxPoolEndpoints(c.RPC, pool),
+		})
+	}
+
+	if _, ok := apis[jsonrpc.APIPool]; ok {
+		services = append(services, jsonrpc.Service{
+			Name:    jsonrpc.APIPool,
+			Service: jsonrpc.NewPoolEndpoints(c.RPC, pool),
 		})
 	}
 
 	if _, ok := apis[jsonrpc.APIDebug]; ok {
 		services = append(services, jsonrpc.Service{
 			Name:    jsonrpc.APIDebug,
-			Service: jsonrpc.NewDebugEndpoints(c.RPC, st, etherman),
+			Service: jsonrpc.NewDebugEndpoints(c.RPC, st, etherman, sequencer.NewSelectionSimulator(c.Sequencer.Worker, c.State.Batch.Constraints, pool, st)),
+		})
+	}
+
+	if _, ok := apis[jsonrpc.APITrace]; ok {
+		services = append(services, jsonrpc.Service{
+			Name:    jsonrpc.APITrace,
+			Service: jsonrpc.NewTraceEndpoints(c.RPC, st, etherman),
 		})
 	}
 
@@ -410,6 +450,13 @@ func runJSONRPCServer(c config.Config, etherman *etherman.Client, chainID uint64
 		})
 	}
 
+	if _, ok := apis[jsonrpc.APIEthTxManager]; ok {
+		services = append(services, jsonrpc.Service{
+			Name:    jsonrpc.APIEthTxManager,
+			Service: jsonrpc.NewEthTxManagerEndpoints(c.RPC, ethTxManager),
+		})
+	}
+
 	if err := jsonrpc.NewServer(c.RPC, chainID, pool, st, storage, services).Start(); err != nil {
 		log.Fatal(err)
 	}
@@ -423,13 +470,18 @@ func createSequencer(cfg config.Config, pool *pool.Pool, st *state.State, etherm
 	return seq
 }
 
-func createSequenceSender(cfg config.Config, pool *pool.Pool, etmStorage *ethtxmanager.PostgresStorage, st *state.State, eventLog *event.EventLog) *sequencesender.SequenceSender {
-	etherman, err := newEtherman(cfg)
+func createSequenceSender(ctx context.Context, cfg config.Config, pool *pool.Pool, etmStorage *ethtxmanager.PostgresStorage, st *state.State, eventLog *event.EventLog) *sequencesender.SequenceSender {
+	etherman, err := newEtherman(ctx, cfg)
 	if err != nil {
 		log.Fatal(err)
 	}
 
-	auth, err := etherman.LoadAuthFromKeyStore(cfg.SequenceSender.PrivateKey.Path, cfg.SequenceSender.PrivateKey.Password)
+	var auth *bind.TransactOpts
+	if cfg.SequenceSender.ExternalSigner.URL != "" {
+		auth, err = etherman.LoadAuthFromExternalSigner(cfg.SequenceSender.ExternalSigner)
+	} else {
+		auth, err = etherman.LoadAuthFromKeyStore(cfg.SequenceSender.PrivateKey.Path, cfg.SequenceSender.PrivateKey.Password)
+	}
 	if err != nil {
 		log.Fatal(err)
 	}
@@ -493,7 +545,7 @@ func newState(ctx context.Context, c *config.Config, etherman *etherman.Client,
 	var stateTree *merkletree.StateTree
 	if needsStateTree {
 		stateDBClient, _, _ := merkletree.NewMTDBServiceClient(ctx, c.MTClient)
-		stateTree = merkletree.NewStateTree(stateDBClient)
+		stateTree = merkletree.NewStateTreeWithCache(stateDBClient, c.MTClient.Cache)
 	}
 
 	stateCfg := state.Config{
@@ -508,10 +560,22 @@ func newState(ctx context.Context, c *config.Config, etherman *etherman.Client,
 		MaxLogsBlockRange:            c.RPC.MaxLogsBlockRange,
 		MaxNativeBlockHashBlockRange: c.RPC.MaxNativeBlockHashBlockRange,
 		AvoidForkIDInMemory:          avoidForkIDInMemory,
+		ExecutionCacheMaxBytesSize:   c.State.ExecutionCacheMaxBytesSize,
 	}
 	stateDb := pgstatestorage.NewPostgresStorage(stateCfg, sqlDB)
 
-	st := state.NewState(stateCfg, stateDb, executorClient, stateTree, eventLog, nil, nil)
+	var st *state.State
+	if c.State.ColdStorage.Enabled {
+		// the receipts and logs archived in the cold storage are read from it
+		coldSqlDB, err := db.NewSQLDB(c.State.ColdStorage.DB)
+		if err != nil {
+			log.Fatal("error connecting to the cold storage of the state. Error: ", err)
+		}
+		coldStateDb := pgstatestorage.NewPostgresStorage(stateCfg, coldSqlDB)
+		st = state.NewState(stateCfg, pgstatestorage.NewTieredStorage(stateDb, coldStateDb), executorClient, stateTree, eventLog, nil, nil)
+	} else {
+		st = state.NewState(stateCfg, stateDb, executorClient, stateTree, eventLog, nil, nil)
+	}
 	// This is to force to build cache, and check that DB is ok before starting the application
 	l1InfoRoot, err := st.GetCurrentL1InfoRoot(ctx, nil)
 	if err != nil {
@@ -531,24 +595,65 @@ func newState(ctx context.Context, c *config.Config, etherman *etherman.Client,
 	}
 	st.UpdateForkIDIntervalsInMemory(forkIDIntervals)
 
+	if stateTree != nil && c.MTClient.Cache.MaxBytesSize > 0 && c.MTClient.Cache.WarmupBatches > 0 {
+		go func() {
+			if err := st.WarmupStateTreeCache(ctx, c.MTClient.Cache.WarmupBatches); err != nil {
+				log.Warnf("failed to warm up the state tree cache, error: %v", err)
+			}
+		}()
+	}
+
 	currentForkID := forkIDIntervals[len(forkIDIntervals)-1].ForkId
 	log.Infof("Fork ID read from POE SC = %v", forkIDIntervals[len(forkIDIntervals)-1].ForkId)
 
 	return st, currentForkID
 }
 
-func createPool(cfgPool pool.Config, constraintsCfg state.BatchConstraintsCfg, l2ChainID uint64, st *state.State, eventLog *event.EventLog) *pool.Pool {
-	runPoolMigrations(cfgPool.DB)
-	poolStorage, err := pgpoolstorage.NewPostgresPoolStorage(cfgPool.DB)
-	if err != nil {
-		log.Fatal(err)
+func createPool(ctx context.Context, cfgPool pool.Config, constraintsCfg state.BatchConstraintsCfg, l2ChainID uint64, st *state.State, eventLog *event.EventLog) *pool.Pool {
+	var poolStorage pool.Storage
+	switch cfgPool.StorageType {
+	case pool.StorageTypeMemory:
+		poolStorage = memorypoolstorage.NewMemoryPoolStorage()
+	case pool.StorageTypePostgres, "":
+		runPoolMigrations(cfgPool.DB)
+		pgPoolStorage, err := pgpoolstorage.NewPostgresPoolStorage(cfgPool.DB)
+		if err != nil {
+			log.Fatal(err)
+		}
+		poolStorage = pgPoolStorage
+	default:
+		log.Fatalf("unknown pool storage type %s", cfgPool.StorageType)
 	}
 	poolInstance := pool.NewPool(cfgPool, constraintsCfg, poolStorage, st, l2ChainID, eventLog)
+	poolInstance.StartRefreshingTxsCachePeriodically(ctx)
+	poolInstance.StartUpdatingMetricsPeriodically(ctx)
+	if cfgPool.Gossip.Enabled {
+		gossiper := gossip.New(cfgPool.Gossip)
+		poolInstance.RegisterNewTxEventHandler(gossiper.HandleNewTx)
+		go gossiper.Start(ctx)
+	}
 	return poolInstance
 }
 
-func createEthTxManager(cfg config.Config, etmStorage *ethtxmanager.PostgresStorage, st *state.State) *ethtxmanager.Client {
-	etherman, err := newEtherman(cfg)
+func createUserOperationsBundler(cfg pool.AccountAbstractionCfg, l2ChainID uint64, poolInstance *pool.Pool, st *state.State) *bundler.Bundler {
+	if cfg.BundlerPrivateKey.Path == "" {
+		log.Fatal("the bundler private key is required to bundle the user operations")
+	}
+	keystoreEncrypted, err := os.ReadFile(filepath.Clean(cfg.BundlerPrivateKey.Path))
+	if err != nil {
+		log.Fatal(err)
+	}
+	key, err := keystore.DecryptKey(keystoreEncrypted, cfg.BundlerPrivateKey.Password)
+	if err != nil {
+		log.Fatal(err)
+	}
+	b := bundler.New(key.PrivateKey, l2ChainID, poolInstance, st)
+	log.Infof("bundling the user operations with the account %s", b.Address().String())
+	return b
+}
+
+func createEthTxManager(ctx context.Context, cfg config.Config, etmStorage *ethtxmanager.PostgresStorage, st *state.State) *ethtxmanager.Client {
+	etherman, err := newEtherman(ctx, cfg)
 	if err != nil {
 		log.Fatal(err)
 	}
@@ -559,6 +664,12 @@ func createEthTxManager(cfg config.Config, etmStorage *ethtxmanager.PostgresStor
 			log.Fatal(err)
 		}
 	}
+	for _, externalSigner := range cfg.EthTxManager.ExternalSigners {
+		_, err := etherman.LoadAuthFromExternalSigner(externalSigner)
+		if err != nil {
+			log.Fatal(err)
+		}
+	}
 	etm := ethtxmanager.New(cfg.EthTxManager, etherman, etmStorage, st)
 	return etm
 }
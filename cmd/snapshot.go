@@ -1,17 +1,46 @@
 package main
 
 import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
+	"os"
+	"path/filepath"
 	"strconv"
-	"time"
+	"strings"
 
 	"github.com/0xPolygonHermez/zkevm-node"
 	"github.com/0xPolygonHermez/zkevm-node/config"
+	"github.com/0xPolygonHermez/zkevm-node/db"
 	"github.com/0xPolygonHermez/zkevm-node/log"
+	"github.com/0xPolygonHermez/zkevm-node/state"
+	"github.com/0xPolygonHermez/zkevm-node/state/pgstatestorage"
+	"github.com/ethereum/go-ethereum/common"
 	pg "github.com/habx/pg-commands"
+	"github.com/jackc/pgx/v4"
 	"github.com/urfave/cli/v2"
 )
 
+const (
+	// snapshotChecksumExtension is the extension of the file with the sha256 of a snapshot file,
+	// written next to it in the format of sha256sum so it can also be checked with sha256sum -c
+	snapshotChecksumExtension = ".sha256"
+	// snapshotManifestExtension is the extension of the file with the last verified batch of the
+	// state when the snapshot was created, written next to the snapshot of the state DB
+	snapshotManifestExtension = ".manifest.json"
+)
+
+var (
+	errSnapshotChecksumNotFound  = errors.New("the snapshot doesn't have a checksum file")
+	errSnapshotChecksumMismatch  = errors.New("the checksum of the snapshot doesn't match")
+	errSnapshotManifestNotFound  = errors.New("the snapshot doesn't have a manifest file")
+	errSnapshotStateRootMismatch = errors.New("the state root of the last verified batch doesn't match the snapshot")
+)
+
 var snapshotFlags = []cli.Flag{
 	&configFileFlag,
 	&outputFileFlag,
@@ -26,6 +55,19 @@ func snapshot(ctx *cli.Context) error {
 	}
 	setupLog(c.Log)
 
+	// the snapshots are named by the last verified batch of the state, so the snapshots of the same state have the same names
+	stateSqlDB, err := db.NewSQLDB(c.State.DB)
+	if err != nil {
+		log.Error("error conecting to stateDB. Error: ", err)
+		return err
+	}
+	manifest, err := newSnapshotManifest(ctx.Context, pgstatestorage.NewPostgresStorage(state.Config{}, stateSqlDB))
+	stateSqlDB.Close()
+	if err != nil {
+		log.Error("error reading the last verified batch of the state. Error: ", err)
+		return err
+	}
+
 	port, err := strconv.Atoi(c.State.DB.Port)
 	if err != nil {
 		log.Error("error converting port to int. Error: ", err)
@@ -45,15 +87,23 @@ func snapshot(ctx *cli.Context) error {
 	dump.Options = append(dump.Options, "-Z 9")
 	log.Info("StateDB snapshot is being created...")
 	dump.Path = ctx.String(config.FlagOutputFile)
-	dump.SetFileName(fmt.Sprintf(`%v_%v_%v_%v.sql.tar.gz`, dump.DB, time.Now().Unix(), zkevm.Version, zkevm.GitRev))
+	dump.SetFileName(snapshotFileName(dump.DB, manifest.BatchNumber))
 	dumpExec := dump.Exec(pg.ExecOptions{StreamPrint: false})
 	if dumpExec.Error != nil {
 		log.Error("error dumping statedb. Error: ", dumpExec.Error.Err)
 		log.Debug("dumpExec.Output: ", dumpExec.Output)
-		return err
+		return dumpExec.Error.Err
 	}
 
 	log.Info("StateDB snapshot success. Saved in ", dumpExec.File)
+	if err := writeSnapshotChecksum(dump.Path + dumpExec.File); err != nil {
+		log.Error("error writing the checksum of the statedb snapshot. Error: ", err)
+		return err
+	}
+	if err := writeSnapshotManifest(dump.Path+dumpExec.File, manifest); err != nil {
+		log.Error("error writing the manifest of the statedb snapshot. Error: ", err)
+		return err
+	}
 
 	port, err = strconv.Atoi(c.HashDB.Port)
 	if err != nil {
@@ -74,14 +124,150 @@ func snapshot(ctx *cli.Context) error {
 	dump.Options = append(dump.Options, "-Z 9")
 	log.Info("HashDB snapshot is being created...")
 	dump.Path = ctx.String(config.FlagOutputFile)
-	dump.SetFileName(fmt.Sprintf(`%v_%v_%v_%v.sql.tar.gz`, dump.DB, time.Now().Unix(), zkevm.Version, zkevm.GitRev))
+	dump.SetFileName(snapshotFileName(dump.DB, manifest.BatchNumber))
 	dumpExec = dump.Exec(pg.ExecOptions{StreamPrint: false})
 	if dumpExec.Error != nil {
 		log.Error("error dumping hashdb. Error: ", dumpExec.Error.Err)
 		log.Debug("dumpExec.Output: ", dumpExec.Output)
-		return err
+		return dumpExec.Error.Err
 	}
 
 	log.Info("HashDB snapshot success. Saved in ", dumpExec.File)
+	if err := writeSnapshotChecksum(dump.Path + dumpExec.File); err != nil {
+		log.Error("error writing the checksum of the hashdb snapshot. Error: ", err)
+		return err
+	}
 	return nil
 }
+
+// snapshotFileName returns the name of the snapshot of the database at the provided last verified batch
+func snapshotFileName(dbName string, batchNumber uint64) string {
+	return fmt.Sprintf(`%v_%v_%v_%v.sql.tar.gz`, dbName, batchNumber, zkevm.Version, zkevm.GitRev)
+}
+
+func snapshotChecksum(path string) (string, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer file.Close()
+
+	hash := sha256.New()
+	if _, err := io.Copy(hash, file); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+// writeSnapshotChecksum writes the sha256 of the snapshot file next to it
+func writeSnapshotChecksum(path string) error {
+	checksum, err := snapshotChecksum(path)
+	if err != nil {
+		return err
+	}
+	content := fmt.Sprintf("%s  %s\n", checksum, filepath.Base(path))
+	return os.WriteFile(path+snapshotChecksumExtension, []byte(content), 0600) //nolint:gomnd
+}
+
+// verifySnapshotChecksum checks the snapshot file against the sha256 written next to it when the
+// snapshot was created
+func verifySnapshotChecksum(path string) error {
+	content, err := os.ReadFile(path + snapshotChecksumExtension)
+	if errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("%w: %s", errSnapshotChecksumNotFound, path)
+	} else if err != nil {
+		return err
+	}
+	fields := strings.Fields(string(content))
+	if len(fields) == 0 {
+		return fmt.Errorf("%w: %s", errSnapshotChecksumNotFound, path)
+	}
+
+	checksum, err := snapshotChecksum(path)
+	if err != nil {
+		return err
+	}
+	if !strings.EqualFold(fields[0], checksum) {
+		return fmt.Errorf("%w: %s, expected %s, got %s", errSnapshotChecksumMismatch, path, fields[0], checksum)
+	}
+	log.Infof("checksum of the snapshot %s verified", path)
+	return nil
+}
+
+// snapshotManifest is the last verified batch of the state when the snapshot was created. It's written next
+// to the snapshot of the state DB, the restore checks the restored state has the same verified state root
+type snapshotManifest struct {
+	BatchNumber uint64      `json:"batchNumber"`
+	StateRoot   common.Hash `json:"stateRoot"`
+}
+
+// snapshotStateReader contains the methods of the state storage used to read the last verified batch
+type snapshotStateReader interface {
+	GetLastVerifiedBatch(ctx context.Context, dbTx pgx.Tx) (*state.VerifiedBatch, error)
+	GetVerifiedBatch(ctx context.Context, batchNumber uint64, dbTx pgx.Tx) (*state.VerifiedBatch, error)
+	GetBatchByNumber(ctx context.Context, batchNumber uint64, dbTx pgx.Tx) (*state.Batch, error)
+}
+
+// newSnapshotManifest returns the manifest of the last verified batch of the state. The state root of the
+// trusted batch must match the state root verified on L1, otherwise the snapshot would spread a wrong state
+func newSnapshotManifest(ctx context.Context, st snapshotStateReader) (snapshotManifest, error) {
+	lastVerifiedBatch, err := st.GetLastVerifiedBatch(ctx, nil)
+	if err != nil {
+		return snapshotManifest{}, err
+	}
+	verifiedBatch, err := st.GetVerifiedBatch(ctx, lastVerifiedBatch.BatchNumber, nil)
+	if err != nil {
+		return snapshotManifest{}, err
+	}
+	manifest := snapshotManifest{BatchNumber: verifiedBatch.BatchNumber, StateRoot: verifiedBatch.StateRoot}
+	return manifest, verifySnapshotState(ctx, st, manifest)
+}
+
+// verifySnapshotState checks the batch of the manifest is verified in the state with the state root of
+// the manifest, and the trusted batch has the same state root
+func verifySnapshotState(ctx context.Context, st snapshotStateReader, manifest snapshotManifest) error {
+	verifiedBatch, err := st.GetVerifiedBatch(ctx, manifest.BatchNumber, nil)
+	if errors.Is(err, state.ErrNotFound) {
+		return fmt.Errorf("%w: the batch %d is not verified", errSnapshotStateRootMismatch, manifest.BatchNumber)
+	} else if err != nil {
+		return err
+	}
+	if verifiedBatch.StateRoot != manifest.StateRoot {
+		return fmt.Errorf("%w: the state root verified for the batch %d is %s, expected %s", errSnapshotStateRootMismatch,
+			manifest.BatchNumber, verifiedBatch.StateRoot.String(), manifest.StateRoot.String())
+	}
+
+	batch, err := st.GetBatchByNumber(ctx, manifest.BatchNumber, nil)
+	if err != nil {
+		return err
+	}
+	if batch.StateRoot != manifest.StateRoot {
+		return fmt.Errorf("%w: the state root of the batch %d is %s, expected %s", errSnapshotStateRootMismatch,
+			manifest.BatchNumber, batch.StateRoot.String(), manifest.StateRoot.String())
+	}
+	return nil
+}
+
+// writeSnapshotManifest writes the manifest next to the snapshot file of the state DB
+func writeSnapshotManifest(path string, manifest snapshotManifest) error {
+	content, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path+snapshotManifestExtension, append(content, '\n'), 0600) //nolint:gomnd
+}
+
+// readSnapshotManifest reads the manifest written next to the snapshot file of the state DB
+func readSnapshotManifest(path string) (snapshotManifest, error) {
+	content, err := os.ReadFile(path + snapshotManifestExtension)
+	if errors.Is(err, os.ErrNotExist) {
+		return snapshotManifest{}, fmt.Errorf("%w: %s", errSnapshotManifestNotFound, path)
+	} else if err != nil {
+		return snapshotManifest{}, err
+	}
+	var manifest snapshotManifest
+	if err := json.Unmarshal(content, &manifest); err != nil {
+		return snapshotManifest{}, fmt.Errorf("failed to decode the manifest of the snapshot %s: %w", path, err)
+	}
+	return manifest, nil
+}
@@ -0,0 +1,130 @@
+package main
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/0xPolygonHermez/zkevm-node/state"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/jackc/pgx/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVerifySnapshotChecksum(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "state_db_10_v0.1.0_undefined.sql.tar.gz")
+	require.NoError(t, os.WriteFile(path, []byte("snapshot"), 0600))
+
+	// snapshots without checksum are rejected
+	assert.ErrorIs(t, verifySnapshotChecksum(path), errSnapshotChecksumNotFound)
+
+	require.NoError(t, writeSnapshotChecksum(path))
+	content, err := os.ReadFile(path + snapshotChecksumExtension)
+	require.NoError(t, err)
+	assert.Equal(t, "16a0eeb0791b6c92451fd284dd9f599e0a7dbe7f6ebea6e2d2d06c7f74aec112  state_db_10_v0.1.0_undefined.sql.tar.gz\n", string(content))
+	assert.NoError(t, verifySnapshotChecksum(path))
+
+	// snapshots modified after the checksum was written are rejected
+	require.NoError(t, os.WriteFile(path, []byte("modified snapshot"), 0600))
+	assert.ErrorIs(t, verifySnapshotChecksum(path), errSnapshotChecksumMismatch)
+
+	require.NoError(t, os.WriteFile(path+snapshotChecksumExtension, []byte{}, 0600))
+	assert.ErrorIs(t, verifySnapshotChecksum(path), errSnapshotChecksumNotFound)
+}
+
+func TestSnapshotManifest(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "state_db_10_v0.1.0_undefined.sql.tar.gz")
+
+	_, err := readSnapshotManifest(path)
+	assert.ErrorIs(t, err, errSnapshotManifestNotFound)
+
+	manifest := snapshotManifest{BatchNumber: 10, StateRoot: common.HexToHash("0x1")}
+	require.NoError(t, writeSnapshotManifest(path, manifest))
+	content, err := os.ReadFile(path + snapshotManifestExtension)
+	require.NoError(t, err)
+	assert.Equal(t, "{\n  \"batchNumber\": 10,\n  \"stateRoot\": \"0x0000000000000000000000000000000000000000000000000000000000000001\"\n}\n", string(content))
+
+	readManifest, err := readSnapshotManifest(path)
+	require.NoError(t, err)
+	assert.Equal(t, manifest, readManifest)
+
+	assert.Equal(t, snapshotFileName("state_db", 10), snapshotFileName("state_db", 10))
+	assert.NotEqual(t, snapshotFileName("state_db", 10), snapshotFileName("state_db", 11))
+}
+
+// snapshotStateStub returns the provided verified and trusted batches
+type snapshotStateStub struct {
+	verifiedBatches map[uint64]*state.VerifiedBatch
+	batches         map[uint64]*state.Batch
+}
+
+func (s *snapshotStateStub) GetLastVerifiedBatch(ctx context.Context, dbTx pgx.Tx) (*state.VerifiedBatch, error) {
+	var last *state.VerifiedBatch
+	for _, verifiedBatch := range s.verifiedBatches {
+		if last == nil || verifiedBatch.BatchNumber > last.BatchNumber {
+			last = &state.VerifiedBatch{BlockNumber: verifiedBatch.BlockNumber, BatchNumber: verifiedBatch.BatchNumber}
+		}
+	}
+	if last == nil {
+		return nil, state.ErrNotFound
+	}
+	return last, nil
+}
+
+func (s *snapshotStateStub) GetVerifiedBatch(ctx context.Context, batchNumber uint64, dbTx pgx.Tx) (*state.VerifiedBatch, error) {
+	if verifiedBatch, found := s.verifiedBatches[batchNumber]; found {
+		return verifiedBatch, nil
+	}
+	return nil, state.ErrNotFound
+}
+
+func (s *snapshotStateStub) GetBatchByNumber(ctx context.Context, batchNumber uint64, dbTx pgx.Tx) (*state.Batch, error) {
+	if batch, found := s.batches[batchNumber]; found {
+		return batch, nil
+	}
+	return nil, state.ErrNotFound
+}
+
+func TestVerifySnapshotState(t *testing.T) {
+	ctx := context.Background()
+	root9, root10 := common.HexToHash("0x9"), common.HexToHash("0x10")
+	st := &snapshotStateStub{
+		verifiedBatches: map[uint64]*state.VerifiedBatch{
+			9:  {BatchNumber: 9, StateRoot: root9},
+			10: {BatchNumber: 10, StateRoot: root10},
+		},
+		batches: map[uint64]*state.Batch{
+			9:  {BatchNumber: 9, StateRoot: root9},
+			10: {BatchNumber: 10, StateRoot: root10},
+			11: {BatchNumber: 11, StateRoot: common.HexToHash("0x11")},
+		},
+	}
+
+	manifest, err := newSnapshotManifest(ctx, st)
+	require.NoError(t, err)
+	assert.Equal(t, snapshotManifest{BatchNumber: 10, StateRoot: root10}, manifest)
+
+	testCases := []struct {
+		name        string
+		manifest    snapshotManifest
+		expectedErr error
+	}{
+		{name: "matching root", manifest: snapshotManifest{BatchNumber: 10, StateRoot: root10}},
+		{name: "matching root of a previous batch", manifest: snapshotManifest{BatchNumber: 9, StateRoot: root9}},
+		{name: "mismatched root", manifest: snapshotManifest{BatchNumber: 10, StateRoot: root9}, expectedErr: errSnapshotStateRootMismatch},
+		{name: "batch not verified", manifest: snapshotManifest{BatchNumber: 11, StateRoot: common.HexToHash("0x11")}, expectedErr: errSnapshotStateRootMismatch},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.ErrorIs(t, verifySnapshotState(ctx, st, tc.manifest), tc.expectedErr)
+		})
+	}
+
+	// the trusted state diverged from the state verified on L1
+	st.batches[10] = &state.Batch{BatchNumber: 10, StateRoot: common.HexToHash("0x1010")}
+	assert.ErrorIs(t, verifySnapshotState(ctx, st, snapshotManifest{BatchNumber: 10, StateRoot: root10}), errSnapshotStateRootMismatch)
+	_, err = newSnapshotManifest(ctx, st)
+	assert.ErrorIs(t, err, errSnapshotStateRootMismatch)
+}
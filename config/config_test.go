@@ -13,6 +13,7 @@ import (
 	"github.com/0xPolygonHermez/zkevm-node/config"
 	"github.com/0xPolygonHermez/zkevm-node/config/types"
 	"github.com/0xPolygonHermez/zkevm-node/log"
+	"github.com/0xPolygonHermez/zkevm-node/sequencer"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -89,6 +90,26 @@ func Test_Defaults(t *testing.T) {
 			path:          "Sequencer.StateConsistencyCheckInterval",
 			expectedValue: types.NewDuration(5 * time.Second),
 		},
+		{
+			path:          "Sequencer.Worker.TxSorterType",
+			expectedValue: "gasprice",
+		},
+		{
+			path:          "Sequencer.Worker.MaxTxsPerAccountPerBatch",
+			expectedValue: uint64(0),
+		},
+		{
+			path:          "Sequencer.Worker.PriorityAddresses",
+			expectedValue: []common.Address{},
+		},
+		{
+			path:          "Sequencer.Worker.MinGasPriceWei",
+			expectedValue: uint64(0),
+		},
+		{
+			path:          "Sequencer.Worker.SelectionMode",
+			expectedValue: "greedy",
+		},
 		{
 			path:          "Sequencer.Finalizer.ForcedBatchesTimeout",
 			expectedValue: types.NewDuration(60 * time.Second),
@@ -129,6 +150,26 @@ func Test_Defaults(t *testing.T) {
 			path:          "Sequencer.Finalizer.BatchMaxDeltaTimestamp",
 			expectedValue: types.NewDuration(10 * time.Second),
 		},
+		{
+			path:          "Sequencer.Finalizer.TxProfitabilityCheckerType",
+			expectedValue: sequencer.TxProfitabilityCheckerType(sequencer.ProfitabilityAcceptAll),
+		},
+		{
+			path:          "Sequencer.Finalizer.StoreFailedTxDetails",
+			expectedValue: false,
+		},
+		{
+			path:          "Sequencer.Finalizer.BatchClosingPolicy.MaxTxsPerBatch",
+			expectedValue: uint64(0),
+		},
+		{
+			path:          "Sequencer.Finalizer.BatchClosingPolicy.MaxGasUtilizationPct",
+			expectedValue: uint32(0),
+		},
+		{
+			path:          "Sequencer.Finalizer.BatchClosingPolicy.IdleTimeout",
+			expectedValue: types.NewDuration(0),
+		},
 		{
 			path:          "Sequencer.Finalizer.Metrics.Interval",
 			expectedValue: types.NewDuration(60 * time.Minute),
@@ -153,6 +194,18 @@ func Test_Defaults(t *testing.T) {
 			path:          "Sequencer.StreamServer.Enabled",
 			expectedValue: false,
 		},
+		{
+			path:          "Sequencer.AdminServer.Enabled",
+			expectedValue: false,
+		},
+		{
+			path:          "Sequencer.AdminServer.Host",
+			expectedValue: "127.0.0.1",
+		},
+		{
+			path:          "Sequencer.AdminServer.Port",
+			expectedValue: int(9095),
+		},
 		{
 			path:          "SequenceSender.WaitPeriodSendSequence",
 			expectedValue: types.NewDuration(5 * time.Second),
@@ -177,6 +230,18 @@ func Test_Defaults(t *testing.T) {
 			path:          "Etherman.URL",
 			expectedValue: "http://localhost:8545",
 		},
+		{
+			path:          "Etherman.FallbackURLs",
+			expectedValue: []string{},
+		},
+		{
+			path:          "Etherman.HealthCheckInterval",
+			expectedValue: types.NewDuration(30 * time.Second),
+		},
+		{
+			path:          "Etherman.QuorumReads",
+			expectedValue: false,
+		},
 		{
 			path:          "NetworkConfig.L1Config.L1ChainID",
 			expectedValue: uint64(5),
@@ -221,6 +286,18 @@ func Test_Defaults(t *testing.T) {
 			path:          "EthTxManager.MaxGasPriceLimit",
 			expectedValue: uint64(0),
 		},
+		{
+			path:          "EthTxManager.EIP1559",
+			expectedValue: false,
+		},
+		{
+			path:          "EthTxManager.GasPriceEscalationTimeout",
+			expectedValue: types.NewDuration(0),
+		},
+		{
+			path:          "EthTxManager.GasPriceEscalationFactor",
+			expectedValue: float64(1.2),
+		},
 		{
 			path:          "L2GasPriceSuggester.DefaultGasPriceWei",
 			expectedValue: uint64(2000000000),
@@ -233,6 +310,18 @@ func Test_Defaults(t *testing.T) {
 			path:          "MTClient.URI",
 			expectedValue: "zkevm-prover:50061",
 		},
+		{
+			path:          "MTClient.Cache.MaxBytesSize",
+			expectedValue: uint64(0),
+		},
+		{
+			path:          "MTClient.Cache.WarmupBatches",
+			expectedValue: uint64(0),
+		},
+		{
+			path:          "State.ExecutionCacheMaxBytesSize",
+			expectedValue: uint64(0),
+		},
 		{
 			path:          "State.DB.User",
 			expectedValue: "state_user",
@@ -261,6 +350,38 @@ func Test_Defaults(t *testing.T) {
 			path:          "State.DB.MaxConns",
 			expectedValue: 200,
 		},
+		{
+			path:          "State.ColdStorage.Enabled",
+			expectedValue: false,
+		},
+		{
+			path:          "State.ColdStorage.DB.User",
+			expectedValue: "state_user",
+		},
+		{
+			path:          "State.ColdStorage.DB.Password",
+			expectedValue: "state_password",
+		},
+		{
+			path:          "State.ColdStorage.DB.Name",
+			expectedValue: "state_cold_db",
+		},
+		{
+			path:          "State.ColdStorage.DB.Host",
+			expectedValue: "zkevm-state-cold-db",
+		},
+		{
+			path:          "State.ColdStorage.DB.Port",
+			expectedValue: "5432",
+		},
+		{
+			path:          "State.ColdStorage.DB.EnableLog",
+			expectedValue: false,
+		},
+		{
+			path:          "State.ColdStorage.DB.MaxConns",
+			expectedValue: 200,
+		},
 		{
 			path:          "Pool.IntervalToRefreshGasPrices",
 			expectedValue: types.NewDuration(5 * time.Second),
@@ -290,6 +411,22 @@ func Test_Defaults(t *testing.T) {
 			path:          "Pool.AccountQueue",
 			expectedValue: uint64(64),
 		},
+		{
+			path:          "Pool.TxFeeCap",
+			expectedValue: float64(0),
+		},
+		{
+			path:          "Pool.StorageType",
+			expectedValue: "postgres",
+		},
+		{
+			path:          "Pool.PriceBump",
+			expectedValue: uint64(10),
+		},
+		{
+			path:          "Pool.PruneNonceTooLowTxs",
+			expectedValue: false,
+		},
 		{
 			path:          "Pool.GlobalQueue",
 			expectedValue: uint64(1024),
@@ -330,6 +467,138 @@ func Test_Defaults(t *testing.T) {
 			path:          "Pool.EffectiveGasPrice.EthTransferL1GasPriceFactor",
 			expectedValue: float64(0),
 		},
+		{
+			path:          "Pool.Cache.Enabled",
+			expectedValue: false,
+		},
+		{
+			path:          "Pool.Cache.RefreshInterval",
+			expectedValue: types.NewDuration(10 * time.Second),
+		},
+		{
+			path:          "Pool.Gossip.Enabled",
+			expectedValue: false,
+		},
+		{
+			path:          "Pool.Gossip.Peers",
+			expectedValue: []string{},
+		},
+		{
+			path:          "Pool.Gossip.QueueSize",
+			expectedValue: uint64(10000),
+		},
+		{
+			path:          "Pool.Gossip.Timeout",
+			expectedValue: types.NewDuration(5 * time.Second),
+		},
+		{
+			path:          "Pool.Gossip.MaxKnownTxs",
+			expectedValue: uint64(100000),
+		},
+		{
+			path:          "Pool.RateLimit.IPTxsPerSecond",
+			expectedValue: float64(0),
+		},
+		{
+			path:          "Pool.RateLimit.IPBurst",
+			expectedValue: uint64(10),
+		},
+		{
+			path:          "Pool.RateLimit.SenderTxsPerSecond",
+			expectedValue: float64(0),
+		},
+		{
+			path:          "Pool.RateLimit.SenderBurst",
+			expectedValue: uint64(10),
+		},
+		{
+			path:          "Pool.RateLimit.GossipPeersIPs",
+			expectedValue: []string{},
+		},
+		{
+			path:          "Pool.ScheduledTxs.Enabled",
+			expectedValue: false,
+		},
+		{
+			path:          "Pool.ScheduledTxs.CheckInterval",
+			expectedValue: types.NewDuration(time.Second),
+		},
+		{
+			path:          "Pool.ScheduledTxs.MaxDelay",
+			expectedValue: types.NewDuration(24 * time.Hour),
+		},
+		{
+			path:          "Pool.ScheduledTxs.MaxBlocksAhead",
+			expectedValue: uint64(100000),
+		},
+		{
+			path:          "Pool.ConditionalTxs.Enabled",
+			expectedValue: false,
+		},
+		{
+			path:          "Pool.ConditionalTxs.MaxKnownStorageSlots",
+			expectedValue: uint64(1000),
+		},
+		{
+			path:          "Pool.AccountAbstraction.Enabled",
+			expectedValue: false,
+		},
+		{
+			path:          "Pool.AccountAbstraction.EntryPoints",
+			expectedValue: []common.Address{},
+		},
+		{
+			path:          "Pool.AccountAbstraction.MaxVerificationGas",
+			expectedValue: uint64(3000000),
+		},
+		{
+			path:          "Pool.AccountAbstraction.MaxUserOpsPerSender",
+			expectedValue: uint64(4),
+		},
+		{
+			path:          "Pool.AccountAbstraction.BundleInterval",
+			expectedValue: types.NewDuration(time.Second),
+		},
+		{
+			path:          "Pool.AccountAbstraction.MaxUserOpsPerBundle",
+			expectedValue: uint64(10),
+		},
+		{
+			path:          "Pool.AccountAbstraction.BundlerPrivateKey",
+			expectedValue: types.KeystoreFileConfig{Path: "", Password: ""},
+		},
+		{
+			path:          "Pool.AllowList.Enabled",
+			expectedValue: false,
+		},
+		{
+			path:          "Pool.AllowList.Addresses",
+			expectedValue: []common.Address{},
+		},
+		{
+			path:          "Pool.AllowList.RegistryAddress",
+			expectedValue: common.Address{},
+		},
+		{
+			path:          "Pool.AllowList.RegistrySlot",
+			expectedValue: uint64(0),
+		},
+		{
+			path:          "Pool.Eviction.Interval",
+			expectedValue: types.NewDuration(0),
+		},
+		{
+			path:          "Pool.Eviction.PendingTxTTL",
+			expectedValue: types.NewDuration(0),
+		},
+		{
+			path:          "Pool.Eviction.InvalidTxTTL",
+			expectedValue: types.NewDuration(0),
+		},
+		{
+			path:          "Pool.Eviction.EvictLowestGasPrice",
+			expectedValue: false,
+		},
 		{
 			path:          "Pool.DB.User",
 			expectedValue: "pool_user",
@@ -374,6 +643,26 @@ func Test_Defaults(t *testing.T) {
 			path:          "RPC.WriteTimeout",
 			expectedValue: types.NewDuration(60 * time.Second),
 		},
+		{
+			path:          "RPC.IdleTimeout",
+			expectedValue: types.NewDuration(120 * time.Second),
+		},
+		{
+			path:          "RPC.CORSAllowedOrigins",
+			expectedValue: []string{"*"},
+		},
+		{
+			path:          "RPC.EnableHttpCompression",
+			expectedValue: false,
+		},
+		{
+			path:          "RPC.TLSCertFile",
+			expectedValue: "",
+		},
+		{
+			path:          "RPC.TLSKeyFile",
+			expectedValue: "",
+		},
 		{
 			path:          "RPC.SequencerNodeURI",
 			expectedValue: "",
@@ -402,14 +691,62 @@ func Test_Defaults(t *testing.T) {
 			path:          "RPC.MaxLogsBlockRange",
 			expectedValue: uint64(10000),
 		},
+		{
+			path:          "RPC.MaxFilters",
+			expectedValue: uint64(10000),
+		},
+		{
+			path:          "RPC.MaxFiltersPerClient",
+			expectedValue: uint64(100),
+		},
+		{
+			path:          "RPC.MaxTxPoolTxs",
+			expectedValue: uint64(5000),
+		},
+		{
+			path:          "RPC.FilterTimeout",
+			expectedValue: types.NewDuration(5 * time.Minute),
+		},
 		{
 			path:          "RPC.MaxNativeBlockHashBlockRange",
 			expectedValue: uint64(60000),
 		},
+		{
+			path:          "RPC.MaxTraceBlockRange",
+			expectedValue: uint64(100),
+		},
 		{
 			path:          "RPC.EnableHttpLog",
 			expectedValue: true,
 		},
+		{
+			path:          "RPC.LocalTxsAPIKeys",
+			expectedValue: []string{},
+		},
+		{
+			path:          "RPC.ResponseCacheMaxBytesSize",
+			expectedValue: uint64(0),
+		},
+		{
+			path:          "RPC.ResponseCacheTTL",
+			expectedValue: types.NewDuration(10 * time.Minute),
+		},
+		{
+			path:          "RPC.SlowRequestThreshold",
+			expectedValue: types.NewDuration(5 * time.Second),
+		},
+		{
+			path:          "RPC.AccessControl.AllowedMethods",
+			expectedValue: []string{},
+		},
+		{
+			path:          "RPC.AccessControl.DeniedMethods",
+			expectedValue: []string{},
+		},
+		{
+			path:          "RPC.AccessControl.APIKeyRequired",
+			expectedValue: false,
+		},
 		{
 			path:          "RPC.WebSockets.Enabled",
 			expectedValue: true,
@@ -490,6 +827,10 @@ func Test_Defaults(t *testing.T) {
 			path:          "Aggregator.GeneratingProofCleanupThreshold",
 			expectedValue: "10m",
 		},
+		{
+			path:          "Aggregator.ProverFailureBackoff",
+			expectedValue: types.NewDuration(1 * time.Minute),
+		},
 		{
 			path:          "Aggregator.GasOffset",
 			expectedValue: uint64(0),
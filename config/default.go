@@ -12,6 +12,7 @@ Level = "info"
 Outputs = ["stderr"]
 
 [State]
+ExecutionCacheMaxBytesSize = 0
 	[State.DB]
 	User = "state_user"
 	Password = "state_password"
@@ -20,6 +21,16 @@ Outputs = ["stderr"]
 	Port = "5432"
 	EnableLog = false	
 	MaxConns = 200
+	[State.ColdStorage]
+	Enabled = false
+		[State.ColdStorage.DB]
+		User = "state_user"
+		Password = "state_password"
+		Name = "state_cold_db"
+		Host = "zkevm-state-cold-db"
+		Port = "5432"
+		EnableLog = false
+		MaxConns = 200
 	[State.Batch]
 		[State.Batch.Constraints]
 		MaxTxsPerBatch = 300
@@ -39,11 +50,15 @@ IntervalToRefreshBlockedAddresses = "5m"
 IntervalToRefreshGasPrices = "5s"
 MaxTxBytesSize=100132
 MaxTxDataBytesSize=100000
+TxFeeCap = 0
+StorageType = "postgres"
 DefaultMinGasPriceAllowed = 1000000000
 MinAllowedGasPriceInterval = "5m"
 PollMinAllowedGasPriceInterval = "15s"
 AccountQueue = 64
 GlobalQueue = 1024
+PriceBump = 10
+PruneNonceTooLowTxs = false
     [Pool.EffectiveGasPrice]
 	Enabled = false
 	L1GasPriceFactor = 0.25
@@ -55,6 +70,47 @@ GlobalQueue = 1024
 	EthTransferGasPrice = 0
 	EthTransferL1GasPriceFactor = 0	
 	L2GasPriceSuggesterFactor = 0.5
+    [Pool.Eviction]
+	Interval = "0s"
+	PendingTxTTL = "0s"
+	InvalidTxTTL = "0s"
+	EvictLowestGasPrice = false
+    [Pool.Cache]
+	Enabled = false
+	RefreshInterval = "10s"
+    [Pool.Gossip]
+	Enabled = false
+	Peers = []
+	QueueSize = 10000
+	Timeout = "5s"
+	MaxKnownTxs = 100000
+    [Pool.RateLimit]
+	IPTxsPerSecond = 0
+	IPBurst = 10
+	SenderTxsPerSecond = 0
+	SenderBurst = 10
+	GossipPeersIPs = []
+    [Pool.ScheduledTxs]
+	Enabled = false
+	CheckInterval = "1s"
+	MaxDelay = "24h"
+	MaxBlocksAhead = 100000
+    [Pool.ConditionalTxs]
+	Enabled = false
+	MaxKnownStorageSlots = 1000
+    [Pool.AccountAbstraction]
+	Enabled = false
+	EntryPoints = []
+	MaxVerificationGas = 3000000
+	MaxUserOpsPerSender = 4
+	BundleInterval = "1s"
+	MaxUserOpsPerBundle = 10
+	BundlerPrivateKey = {Path = "", Password = ""}
+    [Pool.AllowList]
+	Enabled = false
+	Addresses = []
+	RegistryAddress = "0x0000000000000000000000000000000000000000"
+	RegistrySlot = 0
     [Pool.DB]
 	User = "pool_user"
 	Password = "pool_password"
@@ -66,6 +122,9 @@ GlobalQueue = 1024
 
 [Etherman]
 URL = "http://localhost:8545"
+FallbackURLs = []
+HealthCheckInterval = "30s"
+QuorumReads = false
 ForkIDChunkSize = 20000
 MultiGasProvider = false
 	[Etherman.Etherscan]
@@ -77,12 +136,20 @@ WaitTxToBeMined = "2m"
 ForcedGas = 0
 GasPriceMarginFactor = 1
 MaxGasPriceLimit = 0
+EIP1559 = false
+GasPriceEscalationTimeout = "0s"
+GasPriceEscalationFactor = 1.2
 
 [RPC]
 Host = "0.0.0.0"
 Port = 8545
 ReadTimeout = "60s"
 WriteTimeout = "60s"
+IdleTimeout = "120s"
+CORSAllowedOrigins = ["*"]
+EnableHttpCompression = false
+TLSCertFile = ""
+TLSKeyFile = ""
 MaxRequestsPerIPAndSecond = 500
 SequencerNodeURI = ""
 EnableL2SuggestedGasPricePolling = true
@@ -90,8 +157,21 @@ BatchRequestsEnabled = false
 BatchRequestsLimit = 20
 MaxLogsCount = 10000
 MaxLogsBlockRange = 10000
+MaxFilters = 10000
+MaxFiltersPerClient = 100
+MaxTxPoolTxs = 5000
+FilterTimeout = "5m"
 MaxNativeBlockHashBlockRange = 60000
+MaxTraceBlockRange = 100
 EnableHttpLog = true
+LocalTxsAPIKeys = []
+ResponseCacheMaxBytesSize = 0
+ResponseCacheTTL = "10m"
+SlowRequestThreshold = "5s"
+	[RPC.AccessControl]
+		AllowedMethods = []
+		DeniedMethods = []
+		APIKeyRequired = false
 	[RPC.WebSockets]
 		Enabled = true
 		Host = "0.0.0.0"
@@ -131,6 +211,12 @@ TxLifetimeCheckInterval = "10m"
 TxLifetimeMax = "3h"
 LoadPoolTxsCheckInterval = "500ms"
 StateConsistencyCheckInterval = "5s"
+	[Sequencer.Worker]
+		TxSorterType = "gasprice"
+		MaxTxsPerAccountPerBatch = 0
+		PriorityAddresses = []
+		MinGasPriceWei = 0
+		SelectionMode = "greedy"
 	[Sequencer.Finalizer]
 		NewTxsWaitInterval = "100ms"
 		ForcedBatchesTimeout = "60s"
@@ -144,6 +230,12 @@ StateConsistencyCheckInterval = "5s"
 		HaltOnBatchNumber = 0
 		SequentialBatchSanityCheck = false
 		SequentialProcessL2Block = true
+		TxProfitabilityCheckerType = "acceptall"
+		StoreFailedTxDetails = false
+	[Sequencer.Finalizer.BatchClosingPolicy]
+		MaxTxsPerBatch = 0
+		MaxGasUtilizationPct = 0
+		IdleTimeout = "0s"
 	[Sequencer.Finalizer.Metrics]
 		Interval = "60m"
 		EnableLog = true
@@ -152,6 +244,10 @@ StateConsistencyCheckInterval = "5s"
 		Filename = ""
 		Version = 0
 		Enabled = false
+	[Sequencer.AdminServer]
+		Enabled = false
+		Host = "127.0.0.1"
+		Port = 9095
 
 [SequenceSender]
 WaitPeriodSendSequence = "5s"
@@ -172,6 +268,7 @@ TxProfitabilityMinReward = "1.1"
 ProofStatePollingInterval = "5s"
 CleanupLockedProofsInterval = "2m"
 GeneratingProofCleanupThreshold = "10m"
+ProverFailureBackoff = "1m"
 GasOffset = 0
 UpgradeEtrogBatchNumber = 0
 BatchProofL1BlockConfirmations = 2
@@ -187,6 +284,9 @@ CleanHistoryTimeRetention = "5m"
 
 [MTClient]
 URI = "zkevm-prover:50061"
+    [MTClient.Cache]
+	MaxBytesSize = 0
+	WarmupBatches = 0
 
 [Executor]
 URI = "zkevm-prover:50071"
@@ -1,5 +1,7 @@
 package types
 
+import "github.com/ethereum/go-ethereum/common"
+
 // KeystoreFileConfig has all the information needed to load a private key from a key store file
 type KeystoreFileConfig struct {
 	// Path is the file path for the key store file
@@ -8,3 +10,19 @@ type KeystoreFileConfig struct {
 	// Password is the password to decrypt the key store file
 	Password string `mapstructure:"Password"`
 }
+
+// ExternalSignerConfig has all the information needed to sign the txs of an account
+// with an external signer, so the private key doesn't need to be on the node host
+type ExternalSignerConfig struct {
+	// Method is the API of the external signer:
+	// - "clef": signers implementing account_signTransaction, like clef
+	// - "web3signer": signers implementing eth_signTransaction, like web3signer
+	//   holding the keys in HashiCorp Vault or a cloud KMS
+	Method string `mapstructure:"Method"`
+
+	// URL is the JSON RPC endpoint of the external signer
+	URL string `mapstructure:"URL"`
+
+	// Address is the account whose txs are signed by the external signer
+	Address common.Address `mapstructure:"Address"`
+}
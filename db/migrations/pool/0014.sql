@@ -0,0 +1,14 @@
+-- +migrate Up
+CREATE TABLE pool.transaction_status_history
+(
+    id            SERIAL PRIMARY KEY,
+    hash          VARCHAR                  NOT NULL REFERENCES pool.transaction (hash) ON DELETE CASCADE,
+    status        VARCHAR(15)              NOT NULL,
+    failed_reason VARCHAR,
+    changed_at    TIMESTAMP WITH TIME ZONE NOT NULL
+);
+CREATE INDEX IF NOT EXISTS idx_transaction_status_history_hash ON pool.transaction_status_history (hash);
+
+-- +migrate Down
+DROP INDEX IF EXISTS pool.idx_transaction_status_history_hash;
+DROP TABLE pool.transaction_status_history;
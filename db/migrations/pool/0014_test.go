@@ -0,0 +1,46 @@
+package pool_migrations_test
+
+import (
+	"database/sql"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// this migration adds the transaction_status_history table
+type migrationTest0014 struct{}
+
+const insertTxStatusHistory = `
+		INSERT INTO pool.transaction_status_history (hash, status, failed_reason, changed_at)
+		VALUES ('0x0001', 'failed', 'reverted', '2024-01-01')`
+
+func (m migrationTest0014) InsertData(db *sql.DB) error {
+	const insertTx = `
+		INSERT INTO pool.transaction (hash, ip, received_at, from_address)
+		VALUES ('0x0001', '127.0.0.1', '2024-01-01', '0x0011')`
+	_, err := db.Exec(insertTx)
+	return err
+}
+
+func (m migrationTest0014) RunAssertsAfterMigrationUp(t *testing.T, db *sql.DB) {
+	_, err := db.Exec(insertTxStatusHistory)
+	require.NoError(t, err)
+
+	// the history is deleted with the tx
+	_, err = db.Exec(`DELETE FROM pool.transaction WHERE hash = '0x0001'`)
+	require.NoError(t, err)
+
+	var count int
+	err = db.QueryRow(`SELECT COUNT(*) FROM pool.transaction_status_history`).Scan(&count)
+	require.NoError(t, err)
+	require.Equal(t, 0, count)
+}
+
+func (m migrationTest0014) RunAssertsAfterMigrationDown(t *testing.T, db *sql.DB) {
+	_, err := db.Exec(insertTxStatusHistory)
+	require.Error(t, err)
+}
+
+func TestMigration0014(t *testing.T) {
+	runMigrationTest(t, 14, migrationTest0014{})
+}
@@ -0,0 +1,7 @@
+-- +migrate Up
+ALTER TABLE pool.transaction
+    ADD COLUMN is_local BOOLEAN NOT NULL DEFAULT FALSE;
+
+-- +migrate Down
+ALTER TABLE pool.transaction
+    DROP COLUMN is_local;
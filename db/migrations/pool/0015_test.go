@@ -0,0 +1,45 @@
+package pool_migrations_test
+
+import (
+	"database/sql"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// this migration adds is_local to the transaction
+type migrationTest0015 struct{}
+
+func (m migrationTest0015) InsertData(db *sql.DB) error {
+	const insertTx = `
+		INSERT INTO pool.transaction (hash, ip, received_at, from_address)
+		VALUES ('0x0001', '127.0.0.1', '2023-12-07', '0x0011')`
+
+	_, err := db.Exec(insertTx)
+	return err
+}
+
+func (m migrationTest0015) RunAssertsAfterMigrationUp(t *testing.T, db *sql.DB) {
+	var isLocal bool
+	err := db.QueryRow("SELECT is_local FROM pool.transaction WHERE hash = '0x0001'").Scan(&isLocal)
+	require.NoError(t, err)
+	assert.False(t, isLocal)
+
+	const insertTx = `
+		INSERT INTO pool.transaction (hash, ip, received_at, from_address, is_local)
+		VALUES ('0x0002', '127.0.0.1', '2023-12-07', '0x0011', TRUE)`
+
+	_, err = db.Exec(insertTx)
+	require.NoError(t, err)
+}
+
+func (m migrationTest0015) RunAssertsAfterMigrationDown(t *testing.T, db *sql.DB) {
+	var isLocal bool
+	err := db.QueryRow("SELECT is_local FROM pool.transaction WHERE hash = '0x0001'").Scan(&isLocal)
+	require.Error(t, err)
+}
+
+func TestMigration0015(t *testing.T) {
+	runMigrationTest(t, 15, migrationTest0015{})
+}
@@ -0,0 +1,9 @@
+-- +migrate Up
+ALTER TABLE pool.transaction
+    ADD COLUMN not_before_timestamp BIGINT NOT NULL DEFAULT 0,
+    ADD COLUMN not_before_block BIGINT NOT NULL DEFAULT 0;
+
+-- +migrate Down
+ALTER TABLE pool.transaction
+    DROP COLUMN not_before_timestamp,
+    DROP COLUMN not_before_block;
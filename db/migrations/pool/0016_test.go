@@ -0,0 +1,46 @@
+package pool_migrations_test
+
+import (
+	"database/sql"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// this migration adds not_before_timestamp and not_before_block to the transaction
+type migrationTest0016 struct{}
+
+func (m migrationTest0016) InsertData(db *sql.DB) error {
+	const insertTx = `
+		INSERT INTO pool.transaction (hash, ip, received_at, from_address)
+		VALUES ('0x0001', '127.0.0.1', '2023-12-07', '0x0011')`
+
+	_, err := db.Exec(insertTx)
+	return err
+}
+
+func (m migrationTest0016) RunAssertsAfterMigrationUp(t *testing.T, db *sql.DB) {
+	var notBeforeTimestamp, notBeforeBlock uint64
+	err := db.QueryRow("SELECT not_before_timestamp, not_before_block FROM pool.transaction WHERE hash = '0x0001'").Scan(&notBeforeTimestamp, &notBeforeBlock)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(0), notBeforeTimestamp)
+	assert.Equal(t, uint64(0), notBeforeBlock)
+
+	const insertTx = `
+		INSERT INTO pool.transaction (hash, ip, received_at, from_address, status, not_before_timestamp, not_before_block)
+		VALUES ('0x0002', '127.0.0.1', '2023-12-07', '0x0011', 'scheduled', 1700000000, 100)`
+
+	_, err = db.Exec(insertTx)
+	require.NoError(t, err)
+}
+
+func (m migrationTest0016) RunAssertsAfterMigrationDown(t *testing.T, db *sql.DB) {
+	var notBeforeTimestamp uint64
+	err := db.QueryRow("SELECT not_before_timestamp FROM pool.transaction WHERE hash = '0x0001'").Scan(&notBeforeTimestamp)
+	require.Error(t, err)
+}
+
+func TestMigration0016(t *testing.T) {
+	runMigrationTest(t, 16, migrationTest0016{})
+}
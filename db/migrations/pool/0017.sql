@@ -0,0 +1,29 @@
+-- +migrate Up
+CREATE TABLE pool.user_operation
+(
+    hash                     VARCHAR PRIMARY KEY,
+    entry_point              VARCHAR                  NOT NULL,
+    sender                   VARCHAR                  NOT NULL,
+    nonce                    DECIMAL(78, 0)           NOT NULL,
+    init_code                BYTEA,
+    call_data                BYTEA,
+    call_gas_limit           DECIMAL(78, 0)           NOT NULL,
+    verification_gas_limit   DECIMAL(78, 0)           NOT NULL,
+    pre_verification_gas     DECIMAL(78, 0)           NOT NULL,
+    max_fee_per_gas          DECIMAL(78, 0)           NOT NULL,
+    max_priority_fee_per_gas DECIMAL(78, 0)           NOT NULL,
+    paymaster_and_data       BYTEA,
+    signature                BYTEA,
+    status                   VARCHAR(15)              NOT NULL,
+    received_at              TIMESTAMP WITH TIME ZONE NOT NULL,
+    ip                       VARCHAR,
+    bundle_tx_hash           VARCHAR,
+    failed_reason            VARCHAR
+);
+CREATE INDEX IF NOT EXISTS idx_user_operation_sender ON pool.user_operation (sender);
+CREATE INDEX IF NOT EXISTS idx_user_operation_status ON pool.user_operation (status);
+
+-- +migrate Down
+DROP INDEX IF EXISTS pool.idx_user_operation_status;
+DROP INDEX IF EXISTS pool.idx_user_operation_sender;
+DROP TABLE pool.user_operation;
@@ -0,0 +1,39 @@
+package pool_migrations_test
+
+import (
+	"database/sql"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// this migration adds the user_operation table
+type migrationTest0017 struct{}
+
+const insertUserOperation = `
+		INSERT INTO pool.user_operation (hash, entry_point, sender, nonce, call_gas_limit, verification_gas_limit, pre_verification_gas,
+			max_fee_per_gas, max_priority_fee_per_gas, status, received_at)
+		VALUES ('0x0001', '0x0011', '0x0022', 18446744073709551616, 100000, 100000, 21000, 1000000000, 1000000000, 'pending', '2024-01-01')`
+
+func (m migrationTest0017) InsertData(db *sql.DB) error {
+	return nil
+}
+
+func (m migrationTest0017) RunAssertsAfterMigrationUp(t *testing.T, db *sql.DB) {
+	_, err := db.Exec(insertUserOperation)
+	require.NoError(t, err)
+
+	var nonce string
+	err = db.QueryRow(`SELECT nonce::TEXT FROM pool.user_operation WHERE hash = '0x0001'`).Scan(&nonce)
+	require.NoError(t, err)
+	require.Equal(t, "18446744073709551616", nonce)
+}
+
+func (m migrationTest0017) RunAssertsAfterMigrationDown(t *testing.T, db *sql.DB) {
+	_, err := db.Exec(insertUserOperation)
+	require.Error(t, err)
+}
+
+func TestMigration0017(t *testing.T) {
+	runMigrationTest(t, 17, migrationTest0017{})
+}
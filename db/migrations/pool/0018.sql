@@ -0,0 +1,7 @@
+-- +migrate Up
+ALTER TABLE pool.transaction
+    ADD COLUMN conditions jsonb DEFAULT '{}'::jsonb;
+
+-- +migrate Down
+ALTER TABLE pool.transaction
+    DROP COLUMN conditions;
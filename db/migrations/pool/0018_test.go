@@ -0,0 +1,45 @@
+package pool_migrations_test
+
+import (
+	"database/sql"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// this migration adds the conditions of the conditional txs to the transaction
+type migrationTest0018 struct{}
+
+func (m migrationTest0018) InsertData(db *sql.DB) error {
+	const insertTx = `
+		INSERT INTO pool.transaction (hash, ip, received_at, from_address)
+		VALUES ('0x0001', '127.0.0.1', '2024-02-01', '0x0011')`
+
+	_, err := db.Exec(insertTx)
+	return err
+}
+
+func (m migrationTest0018) RunAssertsAfterMigrationUp(t *testing.T, db *sql.DB) {
+	var conditions string
+	err := db.QueryRow("SELECT conditions::TEXT FROM pool.transaction WHERE hash = '0x0001'").Scan(&conditions)
+	require.NoError(t, err)
+	assert.Equal(t, "{}", conditions)
+
+	const insertTx = `
+		INSERT INTO pool.transaction (hash, ip, received_at, from_address, status, conditions)
+		VALUES ('0x0002', '127.0.0.1', '2024-02-01', '0x0011', 'pending', '{"blockNumberMax": 100}')`
+
+	_, err = db.Exec(insertTx)
+	require.NoError(t, err)
+}
+
+func (m migrationTest0018) RunAssertsAfterMigrationDown(t *testing.T, db *sql.DB) {
+	var conditions string
+	err := db.QueryRow("SELECT conditions::TEXT FROM pool.transaction WHERE hash = '0x0001'").Scan(&conditions)
+	require.Error(t, err)
+}
+
+func TestMigration0018(t *testing.T) {
+	runMigrationTest(t, 18, migrationTest0018{})
+}
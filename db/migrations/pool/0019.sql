@@ -0,0 +1,14 @@
+-- +migrate Up
+CREATE TABLE pool.pending_block
+(
+    block_num   BIGINT PRIMARY KEY,
+    block_hash  VARCHAR                  NOT NULL,
+    parent_hash VARCHAR                  NOT NULL,
+    state_root  VARCHAR                  NOT NULL,
+    timestamp   TIMESTAMP WITH TIME ZONE NOT NULL,
+    tx_hashes   VARCHAR[]                NOT NULL DEFAULT '{}',
+    updated_at  TIMESTAMP WITH TIME ZONE NOT NULL
+);
+
+-- +migrate Down
+DROP TABLE pool.pending_block;
@@ -0,0 +1,40 @@
+package pool_migrations_test
+
+import (
+	"database/sql"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// this migration adds the table of the pending block processed by the sequencer
+type migrationTest0019 struct{}
+
+func (m migrationTest0019) InsertData(db *sql.DB) error {
+	return nil
+}
+
+func (m migrationTest0019) RunAssertsAfterMigrationUp(t *testing.T, db *sql.DB) {
+	const insertPendingBlock = `
+		INSERT INTO pool.pending_block (block_num, block_hash, parent_hash, state_root, timestamp, tx_hashes, updated_at)
+		VALUES (10, '0x0010', '0x0009', '0x0001', '2024-02-01', '{"0x0002", "0x0003"}', '2024-02-01')`
+
+	_, err := db.Exec(insertPendingBlock)
+	require.NoError(t, err)
+
+	var txsCount int
+	err = db.QueryRow("SELECT cardinality(tx_hashes) FROM pool.pending_block WHERE block_num = 10").Scan(&txsCount)
+	require.NoError(t, err)
+	assert.Equal(t, 2, txsCount)
+}
+
+func (m migrationTest0019) RunAssertsAfterMigrationDown(t *testing.T, db *sql.DB) {
+	var count int
+	err := db.QueryRow("SELECT COUNT(*) FROM pool.pending_block").Scan(&count)
+	require.Error(t, err)
+}
+
+func TestMigration0019(t *testing.T) {
+	runMigrationTest(t, 19, migrationTest0019{})
+}
@@ -0,0 +1,45 @@
+package pool_migrations_test
+
+import (
+	"database/sql"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// this migration adds the flag of the txs waiting for a nonce gap to be filled to the transaction
+type migrationTest0020 struct{}
+
+func (m migrationTest0020) InsertData(db *sql.DB) error {
+	const insertTx = `
+		INSERT INTO pool.transaction (hash, ip, received_at, from_address)
+		VALUES ('0x0001', '127.0.0.1', '2024-02-01', '0x0011')`
+
+	_, err := db.Exec(insertTx)
+	return err
+}
+
+func (m migrationTest0020) RunAssertsAfterMigrationUp(t *testing.T, db *sql.DB) {
+	var queued bool
+	err := db.QueryRow("SELECT queued FROM pool.transaction WHERE hash = '0x0001'").Scan(&queued)
+	require.NoError(t, err)
+	assert.False(t, queued)
+
+	const insertTx = `
+		INSERT INTO pool.transaction (hash, ip, received_at, from_address, status, queued)
+		VALUES ('0x0002', '127.0.0.1', '2024-02-01', '0x0011', 'pending', TRUE)`
+
+	_, err = db.Exec(insertTx)
+	require.NoError(t, err)
+}
+
+func (m migrationTest0020) RunAssertsAfterMigrationDown(t *testing.T, db *sql.DB) {
+	var queued bool
+	err := db.QueryRow("SELECT queued FROM pool.transaction WHERE hash = '0x0001'").Scan(&queued)
+	require.Error(t, err)
+}
+
+func TestMigration0020(t *testing.T) {
+	runMigrationTest(t, 20, migrationTest0020{})
+}
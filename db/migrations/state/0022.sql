@@ -0,0 +1,15 @@
+-- +migrate Up
+CREATE TABLE IF NOT EXISTS state.checkpoint
+(
+    name            VARCHAR PRIMARY KEY,
+    batch_num       BIGINT NOT NULL REFERENCES state.batch (batch_num) ON DELETE CASCADE,
+    state_root      VARCHAR NOT NULL,
+    local_exit_root VARCHAR NOT NULL,
+    acc_input_hash  VARCHAR NOT NULL,
+    created_at      TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS checkpoint_batch_num_idx ON state.checkpoint (batch_num);
+
+-- +migrate Down
+DROP TABLE IF EXISTS state.checkpoint;
@@ -0,0 +1,61 @@
+package migrations_test
+
+import (
+	"database/sql"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type migrationTest0022 struct{}
+
+func (m migrationTest0022) InsertData(db *sql.DB) error {
+	const insertBatch0 = `
+		INSERT INTO state.batch (batch_num, global_exit_root, local_exit_root, acc_input_hash, state_root, timestamp, coinbase, raw_txs_data, forced_batch_num, wip) 
+		VALUES (0,'0x0000', '0x0000', '0x0000', '0x0000', now(), '0x0000', null, null, false)`
+
+	// insert batch
+	_, err := db.Exec(insertBatch0)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (m migrationTest0022) RunAssertsAfterMigrationUp(t *testing.T, db *sql.DB) {
+	var result int
+
+	// Check table checkpoint exists
+	const getCheckpointTable = `SELECT count(*) FROM information_schema.tables WHERE table_schema='state' and table_name='checkpoint'`
+	row := db.QueryRow(getCheckpointTable)
+	assert.NoError(t, row.Scan(&result))
+	assert.Equal(t, 1, result)
+
+	const insertCheckpoint = `
+		INSERT INTO state.checkpoint (name, batch_num, state_root, local_exit_root, acc_input_hash) 
+		VALUES ('batch0', 0, '0x0000', '0x0000', '0x0000')`
+	_, err := db.Exec(insertCheckpoint)
+	assert.NoError(t, err)
+
+	// the checkpoints are deleted together with their batch
+	_, err = db.Exec("DELETE FROM state.batch WHERE batch_num = 0")
+	assert.NoError(t, err)
+	row = db.QueryRow("SELECT count(*) FROM state.checkpoint")
+	assert.NoError(t, row.Scan(&result))
+	assert.Equal(t, 0, result)
+}
+
+func (m migrationTest0022) RunAssertsAfterMigrationDown(t *testing.T, db *sql.DB) {
+	var result int
+
+	// Check table checkpoint doesn't exist
+	const getCheckpointTable = `SELECT count(*) FROM information_schema.tables WHERE table_schema='state' and table_name='checkpoint'`
+	row := db.QueryRow(getCheckpointTable)
+	assert.NoError(t, row.Scan(&result))
+	assert.Equal(t, 0, result)
+}
+
+func TestMigration0022(t *testing.T) {
+	runMigrationTest(t, 22, migrationTest0022{})
+}
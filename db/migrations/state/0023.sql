@@ -0,0 +1,20 @@
+-- +migrate Up
+ALTER TABLE state.log ADD COLUMN IF NOT EXISTS block_num BIGINT;
+
+UPDATE state.log l
+   SET block_num = t.l2_block_num
+  FROM state.transaction t
+ WHERE t.hash = l.tx_hash;
+
+ALTER TABLE state.log ALTER COLUMN block_num SET NOT NULL;
+
+CREATE INDEX IF NOT EXISTS log_block_num_idx ON state.log (block_num);
+CREATE INDEX IF NOT EXISTS log_address_block_num_idx ON state.log (address, block_num);
+CREATE INDEX IF NOT EXISTS log_topic0_block_num_idx ON state.log (topic0, block_num);
+
+-- +migrate Down
+DROP INDEX IF EXISTS state.log_block_num_idx;
+DROP INDEX IF EXISTS state.log_address_block_num_idx;
+DROP INDEX IF EXISTS state.log_topic0_block_num_idx;
+
+ALTER TABLE state.log DROP COLUMN IF EXISTS block_num;
@@ -0,0 +1,87 @@
+package migrations_test
+
+import (
+	"database/sql"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// this migration adds the block number to the logs
+type migrationTest0023 struct{}
+
+var migrationTest0023Indexes = []string{
+	"log_block_num_idx",
+	"log_address_block_num_idx",
+	"log_topic0_block_num_idx",
+}
+
+func (m migrationTest0023) InsertData(db *sql.DB) error {
+	const addBatch0 = `
+		INSERT INTO state.batch (batch_num, global_exit_root, local_exit_root, acc_input_hash, state_root, timestamp, coinbase, raw_txs_data, forced_batch_num, wip) 
+		VALUES (0,'0x0000', '0x0000', '0x0000', '0x0000', now(), '0x0000', null, null, false)`
+	if _, err := db.Exec(addBatch0); err != nil {
+		return err
+	}
+
+	const addL2Block = "INSERT INTO state.l2block (block_num, block_hash, header, uncles, parent_hash, state_root, received_at, batch_num, created_at) VALUES (7, '0x7', '{}', '{}', '0x0', '0x0', now(), 0, now())"
+	if _, err := db.Exec(addL2Block); err != nil {
+		return err
+	}
+
+	const addTransaction = "INSERT INTO state.transaction (hash, encoded, decoded, l2_block_num, effective_percentage, l2_hash) VALUES ('0x1', 'ABCDEF', '{}', 7, 255, '0x1')"
+	if _, err := db.Exec(addTransaction); err != nil {
+		return err
+	}
+
+	const addLog = "INSERT INTO state.log (tx_hash, log_index, address, data, topic0) VALUES ('0x1', 0, '0x2', '0x', '0x3')"
+	if _, err := db.Exec(addLog); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (m migrationTest0023) RunAssertsAfterMigrationUp(t *testing.T, db *sql.DB) {
+	// Check indexes adding
+	for _, idx := range migrationTest0023Indexes {
+		const getIndex = `SELECT count(*) FROM pg_indexes WHERE indexname = $1;`
+		row := db.QueryRow(getIndex, idx)
+		var result int
+		assert.NoError(t, row.Scan(&result))
+		assert.Equal(t, 1, result)
+	}
+
+	// Check the block number of the existing logs is filled
+	var blockNum uint64
+	row := db.QueryRow("SELECT block_num FROM state.log WHERE tx_hash = '0x1'")
+	assert.NoError(t, row.Scan(&blockNum))
+	assert.Equal(t, uint64(7), blockNum)
+
+	// Check the block number is required
+	const addLogWithoutBlockNum = "INSERT INTO state.log (tx_hash, log_index, address, data, topic0) VALUES ('0x1', 1, '0x2', '0x', '0x3')"
+	_, err := db.Exec(addLogWithoutBlockNum)
+	assert.Error(t, err)
+}
+
+func (m migrationTest0023) RunAssertsAfterMigrationDown(t *testing.T, db *sql.DB) {
+	// Check indexes removing
+	for _, idx := range migrationTest0023Indexes {
+		const getIndex = `SELECT count(*) FROM pg_indexes WHERE indexname = $1;`
+		row := db.QueryRow(getIndex, idx)
+		var result int
+		assert.NoError(t, row.Scan(&result))
+		assert.Equal(t, 0, result)
+	}
+
+	// Check column block_num doesn't exist in state.log table
+	const getBlockNumColumn = `SELECT count(*) FROM information_schema.columns WHERE table_name='log' and column_name='block_num'`
+	row := db.QueryRow(getBlockNumColumn)
+	var result int
+	assert.NoError(t, row.Scan(&result))
+	assert.Equal(t, 0, result)
+}
+
+func TestMigration0023(t *testing.T) {
+	runMigrationTest(t, 23, migrationTest0023{})
+}
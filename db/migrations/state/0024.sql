@@ -0,0 +1,13 @@
+-- +migrate Up
+ALTER TABLE state.l2block ADD COLUMN IF NOT EXISTS logs_bloom BYTEA;
+ALTER TABLE state.receipt ADD COLUMN IF NOT EXISTS logs_bloom BYTEA;
+
+-- the bloom of the existing blocks is already part of their header, the
+-- bloom of the existing receipts is computed from their logs when read
+UPDATE state.l2block
+   SET logs_bloom = decode(substring(header->>'logsBloom' FROM 3), 'hex')
+ WHERE header->>'logsBloom' IS NOT NULL;
+
+-- +migrate Down
+ALTER TABLE state.l2block DROP COLUMN IF EXISTS logs_bloom;
+ALTER TABLE state.receipt DROP COLUMN IF EXISTS logs_bloom;
@@ -0,0 +1,68 @@
+package migrations_test
+
+import (
+	"database/sql"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+)
+
+// this migration adds the logs bloom to the l2 blocks and receipts
+type migrationTest0024 struct{}
+
+func (m migrationTest0024) InsertData(db *sql.DB) error {
+	const addBatch0 = `
+		INSERT INTO state.batch (batch_num, global_exit_root, local_exit_root, acc_input_hash, state_root, timestamp, coinbase, raw_txs_data, forced_batch_num, wip) 
+		VALUES (0,'0x0000', '0x0000', '0x0000', '0x0000', now(), '0x0000', null, null, false)`
+	if _, err := db.Exec(addBatch0); err != nil {
+		return err
+	}
+
+	const addL2BlockWithBloom = "INSERT INTO state.l2block (block_num, block_hash, header, uncles, parent_hash, state_root, received_at, batch_num, created_at) VALUES (1, '0x1', '{\"logsBloom\": \"0x0102\"}', '{}', '0x0', '0x0', now(), 0, now())"
+	if _, err := db.Exec(addL2BlockWithBloom); err != nil {
+		return err
+	}
+
+	const addL2BlockWithoutBloom = "INSERT INTO state.l2block (block_num, block_hash, header, uncles, parent_hash, state_root, received_at, batch_num, created_at) VALUES (2, '0x2', '{}', '{}', '0x1', '0x0', now(), 0, now())"
+	if _, err := db.Exec(addL2BlockWithoutBloom); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (m migrationTest0024) RunAssertsAfterMigrationUp(t *testing.T, db *sql.DB) {
+	// Check the bloom of the existing blocks is taken from their header
+	var bloom []byte
+	row := db.QueryRow("SELECT logs_bloom FROM state.l2block WHERE block_num = 1")
+	assert.NoError(t, row.Scan(&bloom))
+	assert.Equal(t, []byte{0x01, 0x02}, bloom)
+
+	row = db.QueryRow("SELECT logs_bloom FROM state.l2block WHERE block_num = 2")
+	assert.NoError(t, row.Scan(&bloom))
+	assert.Nil(t, bloom)
+
+	// Check the bloom can be stored in the receipts
+	const addTransaction = "INSERT INTO state.transaction (hash, encoded, decoded, l2_block_num, effective_percentage, l2_hash) VALUES ('0x3', 'ABCDEF', '{}', 1, 255, '0x3')"
+	_, err := db.Exec(addTransaction)
+	assert.NoError(t, err)
+	const addReceipt = "INSERT INTO state.receipt (tx_hash, type, post_state, status, cumulative_gas_used, gas_used, effective_gas_price, block_num, tx_index, contract_address, logs_bloom) VALUES ('0x3', 1, null, 1, 1234, 1234, 1, 1, 0, '0x0', $1)"
+	_, err = db.Exec(addReceipt, common.Hex2Bytes("0304"))
+	assert.NoError(t, err)
+}
+
+func (m migrationTest0024) RunAssertsAfterMigrationDown(t *testing.T, db *sql.DB) {
+	// Check column logs_bloom doesn't exist in state.l2block and state.receipt tables
+	for _, table := range []string{"l2block", "receipt"} {
+		const getLogsBloomColumn = `SELECT count(*) FROM information_schema.columns WHERE table_name = $1 and column_name='logs_bloom'`
+		row := db.QueryRow(getLogsBloomColumn, table)
+		var result int
+		assert.NoError(t, row.Scan(&result))
+		assert.Equal(t, 0, result)
+	}
+}
+
+func TestMigration0024(t *testing.T) {
+	runMigrationTest(t, 24, migrationTest0024{})
+}
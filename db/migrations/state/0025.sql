@@ -0,0 +1,5 @@
+-- +migrate Up
+ALTER TABLE state.receipt ADD COLUMN IF NOT EXISTS revert_data BYTEA;
+
+-- +migrate Down
+ALTER TABLE state.receipt DROP COLUMN IF EXISTS revert_data;
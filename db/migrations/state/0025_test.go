@@ -0,0 +1,58 @@
+package migrations_test
+
+import (
+	"database/sql"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+)
+
+// this migration adds the revert data of the reverted txs to the receipts
+type migrationTest0025 struct{}
+
+func (m migrationTest0025) InsertData(db *sql.DB) error {
+	const addBatch0 = `
+		INSERT INTO state.batch (batch_num, global_exit_root, local_exit_root, acc_input_hash, state_root, timestamp, coinbase, raw_txs_data, forced_batch_num, wip) 
+		VALUES (0,'0x0000', '0x0000', '0x0000', '0x0000', now(), '0x0000', null, null, false)`
+	if _, err := db.Exec(addBatch0); err != nil {
+		return err
+	}
+
+	const addL2Block = "INSERT INTO state.l2block (block_num, block_hash, header, uncles, parent_hash, state_root, received_at, batch_num, created_at) VALUES (1, '0x1', '{}', '{}', '0x0', '0x0', now(), 0, now())"
+	if _, err := db.Exec(addL2Block); err != nil {
+		return err
+	}
+
+	const addTransaction = "INSERT INTO state.transaction (hash, encoded, decoded, l2_block_num, effective_percentage, l2_hash) VALUES ('0x2', 'ABCDEF', '{}', 1, 255, '0x2')"
+	if _, err := db.Exec(addTransaction); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (m migrationTest0025) RunAssertsAfterMigrationUp(t *testing.T, db *sql.DB) {
+	// Check the revert data can be stored in the receipts
+	const addReceipt = "INSERT INTO state.receipt (tx_hash, type, post_state, status, cumulative_gas_used, gas_used, effective_gas_price, block_num, tx_index, contract_address, revert_data) VALUES ('0x2', 1, null, 0, 1234, 1234, 1, 1, 0, '0x0', $1)"
+	_, err := db.Exec(addReceipt, common.Hex2Bytes("08c379a0"))
+	assert.NoError(t, err)
+
+	var revertData []byte
+	row := db.QueryRow("SELECT revert_data FROM state.receipt WHERE tx_hash = '0x2'")
+	assert.NoError(t, row.Scan(&revertData))
+	assert.Equal(t, common.Hex2Bytes("08c379a0"), revertData)
+}
+
+func (m migrationTest0025) RunAssertsAfterMigrationDown(t *testing.T, db *sql.DB) {
+	// Check column revert_data doesn't exist in state.receipt table
+	const getRevertDataColumn = `SELECT count(*) FROM information_schema.columns WHERE table_name='receipt' and column_name='revert_data'`
+	row := db.QueryRow(getRevertDataColumn)
+	var result int
+	assert.NoError(t, row.Scan(&result))
+	assert.Equal(t, 0, result)
+}
+
+func TestMigration0025(t *testing.T) {
+	runMigrationTest(t, 25, migrationTest0025{})
+}
@@ -0,0 +1,12 @@
+-- +migrate Up
+CREATE TABLE IF NOT EXISTS state.sync_progress
+(
+    block_num         BIGINT PRIMARY KEY REFERENCES state.block (block_num) ON DELETE CASCADE,
+    block_hash        VARCHAR NOT NULL,
+    virtual_batch_num BIGINT NOT NULL,
+    events_hash       VARCHAR NOT NULL,
+    created_at        TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+-- +migrate Down
+DROP TABLE IF EXISTS state.sync_progress;
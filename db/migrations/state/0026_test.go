@@ -0,0 +1,51 @@
+package migrations_test
+
+import (
+	"database/sql"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// this migration adds the progress of the synchronizer per L1 block
+type migrationTest0026 struct{}
+
+func (m migrationTest0026) InsertData(db *sql.DB) error {
+	const addBlock = "INSERT INTO state.block (block_num, received_at, block_hash) VALUES ($1, now(), $2)"
+	if _, err := db.Exec(addBlock, 1, "0x1"); err != nil {
+		return err
+	}
+	if _, err := db.Exec(addBlock, 2, "0x2"); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (m migrationTest0026) RunAssertsAfterMigrationUp(t *testing.T, db *sql.DB) {
+	const addSyncProgress = "INSERT INTO state.sync_progress (block_num, block_hash, virtual_batch_num, events_hash) VALUES ($1, $2, $3, $4)"
+	_, err := db.Exec(addSyncProgress, 1, "0x1", 5, "0xa")
+	assert.NoError(t, err)
+	_, err = db.Exec(addSyncProgress, 2, "0x2", 6, "0xb")
+	assert.NoError(t, err)
+
+	// the progress can only be stored for existing blocks
+	_, err = db.Exec(addSyncProgress, 3, "0x3", 7, "0xc")
+	assert.Error(t, err)
+
+	// the progress of the blocks removed by a reorg is removed with them
+	_, err = db.Exec("DELETE FROM state.block WHERE block_num = 2")
+	assert.NoError(t, err)
+	var count int
+	assert.NoError(t, db.QueryRow("SELECT count(*) FROM state.sync_progress").Scan(&count))
+	assert.Equal(t, 1, count)
+}
+
+func (m migrationTest0026) RunAssertsAfterMigrationDown(t *testing.T, db *sql.DB) {
+	var exists bool
+	assert.NoError(t, db.QueryRow("SELECT EXISTS (SELECT 1 FROM information_schema.tables WHERE table_schema = 'state' AND table_name = 'sync_progress')").Scan(&exists))
+	assert.False(t, exists)
+}
+
+func TestMigration0026(t *testing.T) {
+	runMigrationTest(t, 26, migrationTest0026{})
+}
@@ -0,0 +1,12 @@
+-- +migrate Up
+ALTER TABLE state.monitored_txs
+    ADD COLUMN IF NOT EXISTS gas_tip_cap DECIMAL(78, 0),
+    ADD COLUMN IF NOT EXISTS gas_price_updated_at TIMESTAMP WITH TIME ZONE;
+
+-- the escalation timeout of the gas price of the existing monitored txs starts from their last update
+UPDATE state.monitored_txs SET gas_price_updated_at = updated_at WHERE gas_price_updated_at IS NULL;
+
+-- +migrate Down
+ALTER TABLE state.monitored_txs
+    DROP COLUMN IF EXISTS gas_tip_cap,
+    DROP COLUMN IF EXISTS gas_price_updated_at;
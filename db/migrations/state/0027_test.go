@@ -0,0 +1,53 @@
+package migrations_test
+
+import (
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// this migration adds the gas tip cap of the EIP-1559 txs and the time the gas price was updated to the monitored txs
+type migrationTest0027 struct{}
+
+func (m migrationTest0027) InsertData(db *sql.DB) error {
+	const addMonitoredTx = `
+		INSERT INTO state.monitored_txs (owner, id, from_addr, to_addr, nonce, value, data, gas, gas_offset, gas_price, status, block_num, history, created_at, updated_at)
+		VALUES ('owner', 'id1', '0x1', '0x2', 1, 0, null, 21000, 0, 10, 'created', null, '{}', now(), '2024-01-01 00:00:00+00')`
+	_, err := db.Exec(addMonitoredTx)
+	return err
+}
+
+func (m migrationTest0027) RunAssertsAfterMigrationUp(t *testing.T, db *sql.DB) {
+	// the existing monitored txs are legacy txs
+	var gasTipCap *uint64
+	row := db.QueryRow("SELECT gas_tip_cap FROM state.monitored_txs WHERE owner = 'owner' AND id = 'id1'")
+	assert.NoError(t, row.Scan(&gasTipCap))
+	assert.Nil(t, gasTipCap)
+
+	// the gas price of the existing monitored txs was updated the last time they were updated
+	var gasPriceUpdatedAt, updatedAt time.Time
+	row = db.QueryRow("SELECT gas_price_updated_at, updated_at FROM state.monitored_txs WHERE owner = 'owner' AND id = 'id1'")
+	assert.NoError(t, row.Scan(&gasPriceUpdatedAt, &updatedAt))
+	assert.True(t, updatedAt.Equal(gasPriceUpdatedAt))
+
+	const addMonitoredTx = `
+		INSERT INTO state.monitored_txs (owner, id, from_addr, to_addr, nonce, value, data, gas, gas_offset, gas_price, gas_tip_cap, gas_price_updated_at, status, block_num, history, created_at, updated_at)
+		VALUES ('owner', 'id2', '0x1', '0x2', 2, 0, null, 21000, 0, 10, 2, now(), 'created', null, '{}', now(), now())`
+	_, err := db.Exec(addMonitoredTx)
+	assert.NoError(t, err)
+}
+
+func (m migrationTest0027) RunAssertsAfterMigrationDown(t *testing.T, db *sql.DB) {
+	for _, column := range []string{"gas_tip_cap", "gas_price_updated_at"} {
+		row := db.QueryRow("SELECT count(*) FROM information_schema.columns WHERE table_name='monitored_txs' and column_name=$1", column)
+		var result int
+		assert.NoError(t, row.Scan(&result))
+		assert.Equal(t, 0, result)
+	}
+}
+
+func TestMigration0027(t *testing.T) {
+	runMigrationTest(t, 27, migrationTest0027{})
+}
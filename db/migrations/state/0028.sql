@@ -0,0 +1,11 @@
+-- +migrate Up
+CREATE TABLE IF NOT EXISTS state.pruned_receipt
+(
+    tx_hash       VARCHAR NOT NULL PRIMARY KEY REFERENCES state.transaction (hash) ON DELETE CASCADE,
+    tx_index      INTEGER,
+    post_state    BYTEA,
+    im_state_root BYTEA
+);
+
+-- +migrate Down
+DROP TABLE IF EXISTS state.pruned_receipt;
@@ -0,0 +1,54 @@
+package migrations_test
+
+import (
+	"database/sql"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// this migration adds the table keeping the data of the pruned receipts needed by the data stream
+type migrationTest0028 struct{}
+
+func (m migrationTest0028) InsertData(db *sql.DB) error {
+	const addBatch0 = `
+		INSERT INTO state.batch (batch_num, global_exit_root, local_exit_root, acc_input_hash, state_root, timestamp, coinbase, raw_txs_data, forced_batch_num, wip)
+		VALUES (0,'0x0000', '0x0000', '0x0000', '0x0000', now(), '0x0000', null, null, false)`
+	if _, err := db.Exec(addBatch0); err != nil {
+		return err
+	}
+	const addL2Block = "INSERT INTO state.l2block (block_num, block_hash, header, uncles, parent_hash, state_root, received_at, batch_num, created_at) VALUES (1, '0x1', '{}', '{}', '0x0', '0x0', now(), 0, now())"
+	if _, err := db.Exec(addL2Block); err != nil {
+		return err
+	}
+	const addTransaction = "INSERT INTO state.transaction (hash, encoded, decoded, l2_block_num, effective_percentage, l2_hash) VALUES ('0x11', 'ABCDEF', '{}', 1, 255, '0x11')"
+	_, err := db.Exec(addTransaction)
+	return err
+}
+
+func (m migrationTest0028) RunAssertsAfterMigrationUp(t *testing.T, db *sql.DB) {
+	const addPrunedReceipt = "INSERT INTO state.pruned_receipt (tx_hash, tx_index, post_state, im_state_root) VALUES ($1, 0, $2, $3)"
+	_, err := db.Exec(addPrunedReceipt, "0x11", []byte{1}, []byte{2})
+	assert.NoError(t, err)
+
+	// the pruned receipts can only be stored for existing txs
+	_, err = db.Exec(addPrunedReceipt, "0x12", []byte{1}, []byte{2})
+	assert.Error(t, err)
+
+	// the pruned receipts of the txs removed by a reorg are removed with them
+	_, err = db.Exec("DELETE FROM state.l2block WHERE block_num = 1")
+	assert.NoError(t, err)
+	var count int
+	assert.NoError(t, db.QueryRow("SELECT count(*) FROM state.pruned_receipt").Scan(&count))
+	assert.Equal(t, 0, count)
+}
+
+func (m migrationTest0028) RunAssertsAfterMigrationDown(t *testing.T, db *sql.DB) {
+	var exists bool
+	assert.NoError(t, db.QueryRow("SELECT EXISTS (SELECT FROM information_schema.tables WHERE table_schema = 'state' AND table_name = 'pruned_receipt')").Scan(&exists))
+	assert.False(t, exists)
+}
+
+func TestMigration0028(t *testing.T) {
+	runMigrationTest(t, 28, migrationTest0028{})
+}
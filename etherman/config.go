@@ -1,11 +1,24 @@
 package etherman
 
-import "github.com/0xPolygonHermez/zkevm-node/etherman/etherscan"
+import (
+	"github.com/0xPolygonHermez/zkevm-node/config/types"
+	"github.com/0xPolygonHermez/zkevm-node/etherman/etherscan"
+)
 
 // Config represents the configuration of the etherman
 type Config struct {
 	// URL is the URL of the Ethereum node for L1
 	URL string `mapstructure:"URL"`
+	// FallbackURLs are the URLs of other Ethereum nodes for L1, the requests are sent to the
+	// next one when the current node is unavailable
+	FallbackURLs []string `mapstructure:"FallbackURLs"`
+	// HealthCheckInterval is the interval to check the L1 nodes are healthy, the unhealthy nodes
+	// are only used if all of them are unhealthy. 0 disables the check
+	HealthCheckInterval types.Duration `mapstructure:"HealthCheckInterval"`
+	// QuorumReads if enabled, the calls to the rollup manager and global exit root contracts, as
+	// the latest verified batch or the exit roots, are sent to all the healthy L1 nodes and the
+	// result returned by the majority of them is used. It requires FallbackURLs
+	QuorumReads bool `mapstructure:"QuorumReads"`
 	// ConsensusL1URL is the URL of the consensus L1 RPC endpoint
 	ConsensusL1URL string `mapstructure:"ConsensusL1URL"`
 
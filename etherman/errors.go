@@ -22,6 +22,10 @@ var (
 	ErrNoSigner = errors.New("no signer to authorize the transaction with")
 	// ErrMissingTrieNode means that a node is missing on the trie
 	ErrMissingTrieNode = errors.New("missing trie node")
+	// ErrL1QuorumNotReached means that the majority of the L1 providers didn't return the same result
+	ErrL1QuorumNotReached = errors.New("L1 providers quorum not reached")
+	// ErrL1ProviderBehind means that the head of the L1 provider is before the last block requested
+	ErrL1ProviderBehind = errors.New("L1 provider is behind the block requested")
 
 	errorsCache = map[string]error{
 		ErrGasRequiredExceedsAllowance.Error():             ErrGasRequiredExceedsAllowance,
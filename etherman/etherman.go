@@ -38,7 +38,6 @@ import (
 	"github.com/ethereum/go-ethereum/core"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/crypto"
-	"github.com/ethereum/go-ethereum/ethclient"
 	"github.com/ethereum/go-ethereum/rpc"
 	"golang.org/x/crypto/sha3"
 )
@@ -160,6 +159,7 @@ type ethereumClient interface {
 	ethereum.ContractCaller
 	ethereum.GasEstimator
 	ethereum.GasPricer
+	ethereum.GasPricer1559
 	ethereum.LogFilterer
 	ethereum.TransactionReader
 	ethereum.TransactionSender
@@ -211,12 +211,11 @@ type Client struct {
 	eventFeijoaManager *EventManager
 }
 
-// NewClient creates a new etherman.
-func NewClient(cfg Config, l1Config L1Config) (*Client, error) {
+// NewClient creates a new etherman, the health check of the L1 providers runs until ctx is done.
+func NewClient(ctx context.Context, cfg Config, l1Config L1Config) (*Client, error) {
 	// Connect to ethereum node
-	ethClient, err := ethclient.Dial(cfg.URL)
+	ethClient, err := dialL1(ctx, cfg, []common.Address{l1Config.RollupManagerAddr, l1Config.GlobalExitRootManagerAddr})
 	if err != nil {
-		log.Errorf("error connecting to %s: %+v", cfg.URL, err)
 		return nil, err
 	}
 	if cfg.ConsensusL1URL == "" {
@@ -1880,6 +1879,11 @@ func (etherMan *Client) SuggestedGasPrice(ctx context.Context) (*big.Int, error)
 	return suggestedGasPrice, nil
 }
 
+// SuggestedGasTipCap returns the suggested gas tip cap of the EIP-1559 txs
+func (etherMan *Client) SuggestedGasTipCap(ctx context.Context) (*big.Int, error) {
+	return etherMan.EthClient.SuggestGasTipCap(ctx)
+}
+
 // EstimateGas returns the estimated gas for the tx
 func (etherMan *Client) EstimateGas(ctx context.Context, from common.Address, to *common.Address, value *big.Int, data []byte) (uint64, error) {
 	return etherMan.EthClient.EstimateGas(ctx, ethereum.CallMsg{
@@ -0,0 +1,162 @@
+package etherman
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/0xPolygonHermez/zkevm-node/config/types"
+	"github.com/0xPolygonHermez/zkevm-node/log"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	ethTypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/ethereum/go-ethereum/signer/core/apitypes"
+)
+
+const (
+	// ExternalSignerMethodClef signs the txs with account_signTransaction
+	ExternalSignerMethodClef = "clef"
+	// ExternalSignerMethodWeb3Signer signs the txs with eth_signTransaction
+	ExternalSignerMethodWeb3Signer = "web3signer"
+
+	externalSignerTimeout = 30 * time.Second
+)
+
+var (
+	// ErrUnknownExternalSignerMethod is returned when the method of an external signer is not supported
+	ErrUnknownExternalSignerMethod = errors.New("unknown external signer method")
+	// ErrExternalSignerWrongTx is returned when the tx signed by an external signer is not the tx to sign
+	ErrExternalSignerWrongTx = errors.New("tx signed by the external signer doesn't match the tx to sign")
+	// ErrExternalSignerWrongSender is returned when the tx signed by an external signer has a different sender
+	ErrExternalSignerWrongSender = errors.New("tx signed by the external signer has a different sender")
+)
+
+// clefSignTransactionResult is the response of account_signTransaction
+type clefSignTransactionResult struct {
+	Raw hexutil.Bytes         `json:"raw"`
+	Tx  *ethTypes.Transaction `json:"tx"`
+}
+
+// externalSigner signs the txs of an account calling the JSON RPC API of an external signer
+type externalSigner struct {
+	method  string
+	address common.Address
+	chainID *big.Int
+	client  *rpc.Client
+}
+
+func newExternalSigner(cfg types.ExternalSignerConfig, chainID uint64) (*externalSigner, error) {
+	if cfg.Method != ExternalSignerMethodClef && cfg.Method != ExternalSignerMethodWeb3Signer {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownExternalSignerMethod, cfg.Method)
+	}
+	client, err := rpc.Dial(cfg.URL)
+	if err != nil {
+		return nil, err
+	}
+	return &externalSigner{
+		method:  cfg.Method,
+		address: cfg.Address,
+		chainID: new(big.Int).SetUint64(chainID),
+		client:  client,
+	}, nil
+}
+
+// signTx sends the tx to the external signer and checks the sender of the signed tx
+func (s *externalSigner) signTx(address common.Address, tx *ethTypes.Transaction) (*ethTypes.Transaction, error) {
+	if address != s.address {
+		return nil, bind.ErrNotAuthorized
+	}
+
+	args, err := s.sendTxArgs(tx)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), externalSignerTimeout)
+	defer cancel()
+
+	var signedTx *ethTypes.Transaction
+	switch s.method {
+	case ExternalSignerMethodClef:
+		var res clefSignTransactionResult
+		if err := s.client.CallContext(ctx, &res, "account_signTransaction", args); err != nil {
+			return nil, err
+		}
+		signedTx = res.Tx
+	case ExternalSignerMethodWeb3Signer:
+		var raw hexutil.Bytes
+		if err := s.client.CallContext(ctx, &raw, "eth_signTransaction", args); err != nil {
+			return nil, err
+		}
+		signedTx = new(ethTypes.Transaction)
+		if err := signedTx.UnmarshalBinary(raw); err != nil {
+			return nil, err
+		}
+	}
+	if signedTx == nil {
+		return nil, ErrExternalSignerWrongTx
+	}
+
+	// the signed tx must be the same tx signed by the configured account
+	signer := ethTypes.LatestSignerForChainID(s.chainID)
+	if signer.Hash(signedTx) != signer.Hash(tx) {
+		return nil, ErrExternalSignerWrongTx
+	}
+	sender, err := ethTypes.Sender(signer, signedTx)
+	if err != nil {
+		return nil, err
+	}
+	if sender != s.address {
+		return nil, ErrExternalSignerWrongSender
+	}
+	return signedTx, nil
+}
+
+func (s *externalSigner) sendTxArgs(tx *ethTypes.Transaction) (*apitypes.SendTxArgs, error) {
+	data := hexutil.Bytes(tx.Data())
+	var to *common.MixedcaseAddress
+	if tx.To() != nil {
+		t := common.NewMixedcaseAddress(*tx.To())
+		to = &t
+	}
+	args := &apitypes.SendTxArgs{
+		From:    common.NewMixedcaseAddress(s.address),
+		To:      to,
+		Gas:     hexutil.Uint64(tx.Gas()),
+		Value:   hexutil.Big(*tx.Value()),
+		Nonce:   hexutil.Uint64(tx.Nonce()),
+		Data:    &data,
+		ChainID: (*hexutil.Big)(s.chainID),
+	}
+	switch tx.Type() {
+	case ethTypes.LegacyTxType:
+		args.GasPrice = (*hexutil.Big)(tx.GasPrice())
+	case ethTypes.DynamicFeeTxType:
+		args.MaxFeePerGas = (*hexutil.Big)(tx.GasFeeCap())
+		args.MaxPriorityFeePerGas = (*hexutil.Big)(tx.GasTipCap())
+	default:
+		return nil, fmt.Errorf("tx type %d not supported by the external signer", tx.Type())
+	}
+	return args, nil
+}
+
+// LoadAuthFromExternalSigner loads an authorization that signs the txs with an external signer
+func (etherMan *Client) LoadAuthFromExternalSigner(cfg types.ExternalSignerConfig) (*bind.TransactOpts, error) {
+	signer, err := newExternalSigner(cfg, etherMan.l1Cfg.L1ChainID)
+	if err != nil {
+		return nil, err
+	}
+	auth := bind.TransactOpts{
+		From:    cfg.Address,
+		Signer:  signer.signTx,
+		Context: context.Background(),
+	}
+
+	log.Infof("loaded %s external signer authorization for address: %v", cfg.Method, auth.From.String())
+	etherMan.auth[auth.From] = auth
+	return &auth, nil
+}
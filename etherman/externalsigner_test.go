@@ -0,0 +1,118 @@
+package etherman
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"math/big"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/0xPolygonHermez/zkevm-node/config/types"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	ethTypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/ethereum/go-ethereum/signer/core/apitypes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const externalSignerTestChainID = 1337
+
+// signerServiceStub signs the txs received with its key like a clef or a web3signer
+type signerServiceStub struct {
+	key *ecdsa.PrivateKey
+	// gasPrice, if set, replaces the gas price of the tx before signing it
+	gasPrice *big.Int
+}
+
+func (s *signerServiceStub) sign(args apitypes.SendTxArgs) (*ethTypes.Transaction, error) {
+	if s.gasPrice != nil {
+		args.GasPrice = (*hexutil.Big)(s.gasPrice)
+	}
+	signer := ethTypes.LatestSignerForChainID(big.NewInt(externalSignerTestChainID))
+	return ethTypes.SignTx(args.ToTransaction(), signer, s.key)
+}
+
+type clefServiceStub struct{ *signerServiceStub }
+
+func (s clefServiceStub) SignTransaction(args apitypes.SendTxArgs) (*clefSignTransactionResult, error) {
+	tx, err := s.sign(args)
+	if err != nil {
+		return nil, err
+	}
+	raw, err := tx.MarshalBinary()
+	if err != nil {
+		return nil, err
+	}
+	return &clefSignTransactionResult{Raw: raw, Tx: tx}, nil
+}
+
+type web3SignerServiceStub struct{ *signerServiceStub }
+
+func (s web3SignerServiceStub) SignTransaction(args apitypes.SendTxArgs) (hexutil.Bytes, error) {
+	tx, err := s.sign(args)
+	if err != nil {
+		return nil, err
+	}
+	return tx.MarshalBinary()
+}
+
+func newExternalSignerServer(t *testing.T, stub *signerServiceStub) *httptest.Server {
+	server := rpc.NewServer()
+	require.NoError(t, server.RegisterName("account", clefServiceStub{stub}))
+	require.NoError(t, server.RegisterName("eth", web3SignerServiceStub{stub}))
+	httpServer := httptest.NewServer(server)
+	t.Cleanup(httpServer.Close)
+	return httpServer
+}
+
+func TestLoadAuthFromExternalSigner(t *testing.T) {
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	address := crypto.PubkeyToAddress(key.PublicKey)
+	stub := &signerServiceStub{key: key}
+	server := newExternalSignerServer(t, stub)
+	etherMan := &Client{l1Cfg: L1Config{L1ChainID: externalSignerTestChainID}, auth: map[common.Address]bind.TransactOpts{}}
+	to := common.HexToAddress("0x1")
+
+	txs := []*ethTypes.Transaction{
+		ethTypes.NewTransaction(1, to, big.NewInt(2), 21000, big.NewInt(10), []byte{1}),
+		ethTypes.NewTx(&ethTypes.DynamicFeeTx{Nonce: 2, To: &to, Value: big.NewInt(0), Gas: 21000, GasFeeCap: big.NewInt(10), GasTipCap: big.NewInt(1)}),
+	}
+	for _, method := range []string{ExternalSignerMethodClef, ExternalSignerMethodWeb3Signer} {
+		t.Run(method, func(t *testing.T) {
+			auth, err := etherMan.LoadAuthFromExternalSigner(types.ExternalSignerConfig{Method: method, URL: server.URL, Address: address})
+			require.NoError(t, err)
+			assert.Equal(t, address, auth.From)
+
+			for _, tx := range txs {
+				signedTx, err := etherMan.SignTx(context.Background(), address, tx)
+				require.NoError(t, err)
+				assert.Equal(t, tx.Type(), signedTx.Type())
+				assert.Equal(t, tx.Nonce(), signedTx.Nonce())
+				sender, err := ethTypes.Sender(ethTypes.LatestSignerForChainID(big.NewInt(externalSignerTestChainID)), signedTx)
+				require.NoError(t, err)
+				assert.Equal(t, address, sender)
+			}
+
+			// the txs changed by the signer are rejected
+			stub.gasPrice = big.NewInt(20)
+			_, err = etherMan.SignTx(context.Background(), address, txs[0])
+			require.ErrorIs(t, err, ErrExternalSignerWrongTx)
+			stub.gasPrice = nil
+		})
+	}
+
+	// the txs signed by other accounts are rejected
+	otherAddress := common.HexToAddress("0x2")
+	_, err = etherMan.LoadAuthFromExternalSigner(types.ExternalSignerConfig{Method: ExternalSignerMethodWeb3Signer, URL: server.URL, Address: otherAddress})
+	require.NoError(t, err)
+	_, err = etherMan.SignTx(context.Background(), otherAddress, txs[0])
+	require.ErrorIs(t, err, ErrExternalSignerWrongSender)
+
+	_, err = etherMan.LoadAuthFromExternalSigner(types.ExternalSignerConfig{Method: "kms", URL: server.URL, Address: address})
+	require.ErrorIs(t, err, ErrUnknownExternalSignerMethod)
+}
@@ -0,0 +1,466 @@
+package etherman
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"sync"
+	"time"
+
+	"github.com/0xPolygonHermez/zkevm-node/log"
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// l1Backend is the client used to reach L1, it's used directly and to create the smc clients
+type l1Backend interface {
+	ethereumClient
+	bind.ContractBackend
+}
+
+type l1Provider struct {
+	url     string
+	client  l1Backend
+	healthy bool
+}
+
+// multiClient is a L1 client that sends the requests to several providers. The requests are
+// sent to the last provider that answered, and to the next one when it's unavailable. The
+// calls to the quorum addresses are sent to all the healthy providers if quorum reads are
+// enabled, and the result returned by the majority of them is used
+type multiClient struct {
+	providers       []*l1Provider
+	current         int
+	quorumReads     bool
+	quorumAddresses map[common.Address]struct{}
+	mutex           sync.RWMutex
+}
+
+// dialL1 connects to the L1 providers of the config, it returns a multiClient only if
+// there are fallback URLs configured. The health check of the providers runs until ctx is done
+func dialL1(ctx context.Context, cfg Config, quorumAddresses []common.Address) (l1Backend, error) {
+	ethClient, err := ethclient.Dial(cfg.URL)
+	if err != nil {
+		log.Errorf("error connecting to %s: %+v", cfg.URL, err)
+		return nil, err
+	}
+	if len(cfg.FallbackURLs) == 0 {
+		return ethClient, nil
+	}
+
+	providers := []*l1Provider{{url: cfg.URL, client: ethClient, healthy: true}}
+	for _, url := range cfg.FallbackURLs {
+		fallbackClient, err := ethclient.Dial(url)
+		if err != nil {
+			log.Errorf("error connecting to %s: %+v", url, err)
+			return nil, err
+		}
+		providers = append(providers, &l1Provider{url: url, client: fallbackClient, healthy: true})
+	}
+	client := newMultiClient(providers, cfg.QuorumReads, quorumAddresses)
+	if cfg.HealthCheckInterval.Duration > 0 {
+		go client.healthCheck(ctx, cfg.HealthCheckInterval.Duration)
+	}
+	log.Infof("using %d L1 providers, quorum reads: %t", len(providers), cfg.QuorumReads)
+	return client, nil
+}
+
+func newMultiClient(providers []*l1Provider, quorumReads bool, quorumAddresses []common.Address) *multiClient {
+	addresses := make(map[common.Address]struct{}, len(quorumAddresses))
+	for _, address := range quorumAddresses {
+		addresses[address] = struct{}{}
+	}
+	return &multiClient{
+		providers:       providers,
+		quorumReads:     quorumReads,
+		quorumAddresses: addresses,
+	}
+}
+
+// healthCheck checks periodically the providers can return the latest header
+func (c *multiClient) healthCheck(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			c.checkProviders(ctx, interval)
+		}
+	}
+}
+
+func (c *multiClient) checkProviders(ctx context.Context, timeout time.Duration) {
+	for i, provider := range c.providers {
+		checkCtx, cancel := context.WithTimeout(ctx, timeout)
+		_, err := provider.client.HeaderByNumber(checkCtx, nil)
+		cancel()
+		if err != nil {
+			log.Warnf("L1 provider %d is not healthy. Error: %v", i, err)
+		}
+		c.setHealthy(i, err == nil)
+	}
+}
+
+func (c *multiClient) setHealthy(i int, healthy bool) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	if c.providers[i].healthy != healthy && healthy {
+		log.Infof("L1 provider %d is healthy again", i)
+	}
+	c.providers[i].healthy = healthy
+}
+
+func (c *multiClient) setCurrent(i int) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	if c.current != i {
+		log.Infof("switching to L1 provider %d", i)
+		c.current = i
+	}
+}
+
+// providersByPriority returns the indexes of the providers starting from the current one,
+// with the healthy providers first, the unhealthy ones are only tried as last resort
+func (c *multiClient) providersByPriority() []int {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+	healthy, unhealthy := []int{}, []int{}
+	for n := 0; n < len(c.providers); n++ {
+		i := (c.current + n) % len(c.providers)
+		if c.providers[i].healthy {
+			healthy = append(healthy, i)
+		} else {
+			unhealthy = append(unhealthy, i)
+		}
+	}
+	return append(healthy, unhealthy...)
+}
+
+// isProviderError returns true if the error means the provider is unavailable, the answers
+// of the provider, as a JSON RPC error or a not found, are the same for all the providers
+func isProviderError(ctx context.Context, err error) bool {
+	if err == nil || ctx.Err() != nil || errors.Is(err, ethereum.NotFound) {
+		return false
+	}
+	var rpcErr rpc.Error
+	return !errors.As(err, &rpcErr)
+}
+
+// callWithFailover calls fn with the providers by priority until one of them is available
+func callWithFailover[T any](ctx context.Context, c *multiClient, method string, fn func(client l1Backend) (T, error)) (T, error) {
+	var (
+		result T
+		err    error
+	)
+	for _, i := range c.providersByPriority() {
+		result, err = fn(c.providers[i].client)
+		if !isProviderError(ctx, err) {
+			c.setCurrent(i)
+			return result, err
+		}
+		log.Warnf("error calling %s on L1 provider %d, trying the next one. Error: %v", method, i, err)
+		if !errors.Is(err, ErrL1ProviderBehind) {
+			c.setHealthy(i, false)
+		}
+	}
+	return result, err
+}
+
+// quorumCallContract calls the contract with all the healthy providers, or all of them if none
+// is healthy, and returns the result returned by the majority of the providers called. The calls
+// to the latest block are made to the lowest head of the providers, so all of them call the same block
+func (c *multiClient) quorumCallContract(ctx context.Context, call ethereum.CallMsg, blockNumber *big.Int) ([]byte, error) {
+	indexes := c.providersByPriority()
+	c.mutex.RLock()
+	healthy := 0
+	for _, i := range indexes {
+		if c.providers[i].healthy {
+			healthy++
+		}
+	}
+	c.mutex.RUnlock()
+	if healthy > 0 {
+		indexes = indexes[:healthy]
+	}
+	if blockNumber == nil {
+		var err error
+		if blockNumber, err = c.lowestHead(ctx, indexes); err != nil {
+			return nil, err
+		}
+	}
+
+	results := make([][]byte, len(indexes))
+	errs := make([]error, len(indexes))
+	var wg sync.WaitGroup
+	for n, i := range indexes {
+		wg.Add(1)
+		go func(n, i int) {
+			defer wg.Done()
+			results[n], errs[n] = c.providers[i].client.CallContract(ctx, call, blockNumber)
+		}(n, i)
+	}
+	wg.Wait()
+
+	votes := map[string]int{}
+	var lastErr error
+	for n, i := range indexes {
+		if errs[n] != nil {
+			if isProviderError(ctx, errs[n]) {
+				c.setHealthy(i, false)
+			}
+			lastErr = errs[n]
+			continue
+		}
+		votes[string(results[n])]++
+		if votes[string(results[n])] > len(indexes)/2 {
+			return results[n], nil
+		}
+	}
+	if len(votes) == 0 && lastErr != nil {
+		return nil, lastErr
+	}
+	return nil, fmt.Errorf("%w: %d providers called, %d different results", ErrL1QuorumNotReached, len(indexes), len(votes))
+}
+
+// lowestHead returns the lowest block number of the heads of the providers, the providers that don't
+// return their head are not taken into account
+func (c *multiClient) lowestHead(ctx context.Context, indexes []int) (*big.Int, error) {
+	headers := make([]*types.Header, len(indexes))
+	errs := make([]error, len(indexes))
+	var wg sync.WaitGroup
+	for n, i := range indexes {
+		wg.Add(1)
+		go func(n, i int) {
+			defer wg.Done()
+			headers[n], errs[n] = c.providers[i].client.HeaderByNumber(ctx, nil)
+		}(n, i)
+	}
+	wg.Wait()
+
+	var (
+		lowest  *big.Int
+		lastErr error
+	)
+	for n, i := range indexes {
+		if errs[n] != nil {
+			if isProviderError(ctx, errs[n]) {
+				c.setHealthy(i, false)
+			}
+			lastErr = errs[n]
+			continue
+		}
+		if lowest == nil || headers[n].Number.Cmp(lowest) < 0 {
+			lowest = headers[n].Number
+		}
+	}
+	if lowest == nil {
+		return nil, lastErr
+	}
+	return lowest, nil
+}
+
+// BlockByHash implements ethereum.ChainReader
+func (c *multiClient) BlockByHash(ctx context.Context, hash common.Hash) (*types.Block, error) {
+	return callWithFailover(ctx, c, "BlockByHash", func(client l1Backend) (*types.Block, error) {
+		return client.BlockByHash(ctx, hash)
+	})
+}
+
+// BlockByNumber implements ethereum.ChainReader
+func (c *multiClient) BlockByNumber(ctx context.Context, number *big.Int) (*types.Block, error) {
+	return callWithFailover(ctx, c, "BlockByNumber", func(client l1Backend) (*types.Block, error) {
+		return client.BlockByNumber(ctx, number)
+	})
+}
+
+// HeaderByHash implements ethereum.ChainReader
+func (c *multiClient) HeaderByHash(ctx context.Context, hash common.Hash) (*types.Header, error) {
+	return callWithFailover(ctx, c, "HeaderByHash", func(client l1Backend) (*types.Header, error) {
+		return client.HeaderByHash(ctx, hash)
+	})
+}
+
+// HeaderByNumber implements ethereum.ChainReader
+func (c *multiClient) HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error) {
+	return callWithFailover(ctx, c, "HeaderByNumber", func(client l1Backend) (*types.Header, error) {
+		return client.HeaderByNumber(ctx, number)
+	})
+}
+
+// TransactionCount implements ethereum.ChainReader
+func (c *multiClient) TransactionCount(ctx context.Context, blockHash common.Hash) (uint, error) {
+	return callWithFailover(ctx, c, "TransactionCount", func(client l1Backend) (uint, error) {
+		return client.TransactionCount(ctx, blockHash)
+	})
+}
+
+// TransactionInBlock implements ethereum.ChainReader
+func (c *multiClient) TransactionInBlock(ctx context.Context, blockHash common.Hash, index uint) (*types.Transaction, error) {
+	return callWithFailover(ctx, c, "TransactionInBlock", func(client l1Backend) (*types.Transaction, error) {
+		return client.TransactionInBlock(ctx, blockHash, index)
+	})
+}
+
+// SubscribeNewHead implements ethereum.ChainReader
+func (c *multiClient) SubscribeNewHead(ctx context.Context, ch chan<- *types.Header) (ethereum.Subscription, error) {
+	return callWithFailover(ctx, c, "SubscribeNewHead", func(client l1Backend) (ethereum.Subscription, error) {
+		return client.SubscribeNewHead(ctx, ch)
+	})
+}
+
+// BalanceAt implements ethereum.ChainStateReader
+func (c *multiClient) BalanceAt(ctx context.Context, account common.Address, blockNumber *big.Int) (*big.Int, error) {
+	return callWithFailover(ctx, c, "BalanceAt", func(client l1Backend) (*big.Int, error) {
+		return client.BalanceAt(ctx, account, blockNumber)
+	})
+}
+
+// StorageAt implements ethereum.ChainStateReader
+func (c *multiClient) StorageAt(ctx context.Context, account common.Address, key common.Hash, blockNumber *big.Int) ([]byte, error) {
+	return callWithFailover(ctx, c, "StorageAt", func(client l1Backend) ([]byte, error) {
+		return client.StorageAt(ctx, account, key, blockNumber)
+	})
+}
+
+// CodeAt implements ethereum.ChainStateReader
+func (c *multiClient) CodeAt(ctx context.Context, account common.Address, blockNumber *big.Int) ([]byte, error) {
+	return callWithFailover(ctx, c, "CodeAt", func(client l1Backend) ([]byte, error) {
+		return client.CodeAt(ctx, account, blockNumber)
+	})
+}
+
+// NonceAt implements ethereum.ChainStateReader
+func (c *multiClient) NonceAt(ctx context.Context, account common.Address, blockNumber *big.Int) (uint64, error) {
+	return callWithFailover(ctx, c, "NonceAt", func(client l1Backend) (uint64, error) {
+		return client.NonceAt(ctx, account, blockNumber)
+	})
+}
+
+// CallContract implements ethereum.ContractCaller, the calls to the quorum addresses
+// are sent to all the healthy providers when quorum reads are enabled
+func (c *multiClient) CallContract(ctx context.Context, call ethereum.CallMsg, blockNumber *big.Int) ([]byte, error) {
+	if c.quorumReads && call.To != nil {
+		if _, found := c.quorumAddresses[*call.To]; found {
+			return c.quorumCallContract(ctx, call, blockNumber)
+		}
+	}
+	return callWithFailover(ctx, c, "CallContract", func(client l1Backend) ([]byte, error) {
+		return client.CallContract(ctx, call, blockNumber)
+	})
+}
+
+// EstimateGas implements ethereum.GasEstimator
+func (c *multiClient) EstimateGas(ctx context.Context, call ethereum.CallMsg) (uint64, error) {
+	return callWithFailover(ctx, c, "EstimateGas", func(client l1Backend) (uint64, error) {
+		return client.EstimateGas(ctx, call)
+	})
+}
+
+// SuggestGasPrice implements ethereum.GasPricer
+func (c *multiClient) SuggestGasPrice(ctx context.Context) (*big.Int, error) {
+	return callWithFailover(ctx, c, "SuggestGasPrice", func(client l1Backend) (*big.Int, error) {
+		return client.SuggestGasPrice(ctx)
+	})
+}
+
+// SuggestGasTipCap implements bind.ContractTransactor
+func (c *multiClient) SuggestGasTipCap(ctx context.Context) (*big.Int, error) {
+	return callWithFailover(ctx, c, "SuggestGasTipCap", func(client l1Backend) (*big.Int, error) {
+		return client.SuggestGasTipCap(ctx)
+	})
+}
+
+// FilterLogs implements ethereum.LogFilterer, the logs of a block range are only requested to
+// the providers whose head is at the end of the range, as the other ones miss the last logs
+func (c *multiClient) FilterLogs(ctx context.Context, query ethereum.FilterQuery) ([]types.Log, error) {
+	return callWithFailover(ctx, c, "FilterLogs", func(client l1Backend) ([]types.Log, error) {
+		if query.BlockHash == nil && query.ToBlock != nil && query.ToBlock.Sign() >= 0 {
+			header, err := client.HeaderByNumber(ctx, nil)
+			if err != nil {
+				return nil, err
+			}
+			if header.Number.Cmp(query.ToBlock) < 0 {
+				return nil, fmt.Errorf("%w: head %d, logs requested up to block %d", ErrL1ProviderBehind, header.Number.Uint64(), query.ToBlock.Uint64())
+			}
+		}
+		return client.FilterLogs(ctx, query)
+	})
+}
+
+// SubscribeFilterLogs implements ethereum.LogFilterer
+func (c *multiClient) SubscribeFilterLogs(ctx context.Context, query ethereum.FilterQuery, ch chan<- types.Log) (ethereum.Subscription, error) {
+	return callWithFailover(ctx, c, "SubscribeFilterLogs", func(client l1Backend) (ethereum.Subscription, error) {
+		return client.SubscribeFilterLogs(ctx, query, ch)
+	})
+}
+
+// TransactionByHash implements ethereum.TransactionReader
+func (c *multiClient) TransactionByHash(ctx context.Context, txHash common.Hash) (*types.Transaction, bool, error) {
+	type transactionByHashResult struct {
+		tx        *types.Transaction
+		isPending bool
+	}
+	result, err := callWithFailover(ctx, c, "TransactionByHash", func(client l1Backend) (transactionByHashResult, error) {
+		tx, isPending, err := client.TransactionByHash(ctx, txHash)
+		return transactionByHashResult{tx: tx, isPending: isPending}, err
+	})
+	return result.tx, result.isPending, err
+}
+
+// TransactionReceipt implements ethereum.TransactionReader
+func (c *multiClient) TransactionReceipt(ctx context.Context, txHash common.Hash) (*types.Receipt, error) {
+	return callWithFailover(ctx, c, "TransactionReceipt", func(client l1Backend) (*types.Receipt, error) {
+		return client.TransactionReceipt(ctx, txHash)
+	})
+}
+
+// SendTransaction implements ethereum.TransactionSender, the tx is signed so it's
+// safe to send it again to the next provider
+func (c *multiClient) SendTransaction(ctx context.Context, tx *types.Transaction) error {
+	_, err := callWithFailover(ctx, c, "SendTransaction", func(client l1Backend) (struct{}, error) {
+		return struct{}{}, client.SendTransaction(ctx, tx)
+	})
+	return err
+}
+
+// PendingBalanceAt implements ethereum.PendingStateReader
+func (c *multiClient) PendingBalanceAt(ctx context.Context, account common.Address) (*big.Int, error) {
+	return callWithFailover(ctx, c, "PendingBalanceAt", func(client l1Backend) (*big.Int, error) {
+		return client.PendingBalanceAt(ctx, account)
+	})
+}
+
+// PendingStorageAt implements ethereum.PendingStateReader
+func (c *multiClient) PendingStorageAt(ctx context.Context, account common.Address, key common.Hash) ([]byte, error) {
+	return callWithFailover(ctx, c, "PendingStorageAt", func(client l1Backend) ([]byte, error) {
+		return client.PendingStorageAt(ctx, account, key)
+	})
+}
+
+// PendingCodeAt implements ethereum.PendingStateReader
+func (c *multiClient) PendingCodeAt(ctx context.Context, account common.Address) ([]byte, error) {
+	return callWithFailover(ctx, c, "PendingCodeAt", func(client l1Backend) ([]byte, error) {
+		return client.PendingCodeAt(ctx, account)
+	})
+}
+
+// PendingNonceAt implements ethereum.PendingStateReader
+func (c *multiClient) PendingNonceAt(ctx context.Context, account common.Address) (uint64, error) {
+	return callWithFailover(ctx, c, "PendingNonceAt", func(client l1Backend) (uint64, error) {
+		return client.PendingNonceAt(ctx, account)
+	})
+}
+
+// PendingTransactionCount implements ethereum.PendingStateReader
+func (c *multiClient) PendingTransactionCount(ctx context.Context) (uint, error) {
+	return callWithFailover(ctx, c, "PendingTransactionCount", func(client l1Backend) (uint, error) {
+		return client.PendingTransactionCount(ctx)
+	})
+}
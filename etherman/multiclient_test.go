@@ -0,0 +1,177 @@
+package etherman
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type rpcErrorStub struct{}
+
+func (rpcErrorStub) Error() string  { return "execution reverted" }
+func (rpcErrorStub) ErrorCode() int { return 3 }
+
+// l1BackendStub answers the headers, the contract calls and the logs, the other methods are not implemented
+type l1BackendStub struct {
+	l1Backend
+	header          *types.Header
+	result          []byte
+	logs            []types.Log
+	err             error
+	calls           int
+	contractCalls   int
+	callBlockNumber *big.Int
+	filterCalls     int
+}
+
+func (s *l1BackendStub) HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error) {
+	s.calls++
+	return s.header, s.err
+}
+
+func (s *l1BackendStub) CallContract(ctx context.Context, call ethereum.CallMsg, blockNumber *big.Int) ([]byte, error) {
+	s.contractCalls++
+	s.callBlockNumber = blockNumber
+	return s.result, s.err
+}
+
+func (s *l1BackendStub) FilterLogs(ctx context.Context, query ethereum.FilterQuery) ([]types.Log, error) {
+	s.filterCalls++
+	return s.logs, s.err
+}
+
+func newMultiClientStub(quorumReads bool, quorumAddress common.Address, stubs ...*l1BackendStub) *multiClient {
+	providers := []*l1Provider{}
+	for _, stub := range stubs {
+		providers = append(providers, &l1Provider{client: stub, healthy: true})
+	}
+	return newMultiClient(providers, quorumReads, []common.Address{quorumAddress})
+}
+
+func TestMultiClientFailover(t *testing.T) {
+	ctx := context.Background()
+	failing := &l1BackendStub{err: errors.New("connection refused")}
+	working := &l1BackendStub{header: &types.Header{Number: big.NewInt(10)}}
+	client := newMultiClientStub(false, common.Address{}, failing, working)
+
+	header, err := client.HeaderByNumber(ctx, nil)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(10), header.Number.Uint64())
+	assert.Equal(t, 1, client.current)
+	assert.False(t, client.providers[0].healthy)
+
+	// the provider that answered is used while it's available
+	_, err = client.HeaderByNumber(ctx, nil)
+	require.NoError(t, err)
+	assert.Equal(t, 1, failing.calls)
+	assert.Equal(t, 2, working.calls)
+
+	// the answers of the provider are not failovers
+	working.err = rpcErrorStub{}
+	_, err = client.HeaderByNumber(ctx, nil)
+	require.ErrorIs(t, err, rpcErrorStub{})
+	assert.Equal(t, 1, failing.calls)
+
+	working.err = ethereum.NotFound
+	_, err = client.HeaderByNumber(ctx, nil)
+	require.ErrorIs(t, err, ethereum.NotFound)
+	assert.Equal(t, 1, failing.calls)
+}
+
+func TestMultiClientHealthCheck(t *testing.T) {
+	ctx := context.Background()
+	first := &l1BackendStub{header: &types.Header{Number: big.NewInt(10)}, err: errors.New("connection refused")}
+	second := &l1BackendStub{header: &types.Header{Number: big.NewInt(10)}}
+	client := newMultiClientStub(false, common.Address{}, first, second)
+
+	client.checkProviders(ctx, time.Second)
+	assert.False(t, client.providers[0].healthy)
+	assert.True(t, client.providers[1].healthy)
+	assert.Equal(t, []int{1, 0}, client.providersByPriority())
+
+	first.err = nil
+	client.checkProviders(ctx, time.Second)
+	assert.Equal(t, []int{0, 1}, client.providersByPriority())
+}
+
+func TestMultiClientQuorumReads(t *testing.T) {
+	ctx := context.Background()
+	quorumAddress := common.HexToAddress("0x1")
+	otherAddress := common.HexToAddress("0x2")
+	first := &l1BackendStub{result: []byte{1}, header: &types.Header{Number: big.NewInt(10)}}
+	second := &l1BackendStub{result: []byte{2}, header: &types.Header{Number: big.NewInt(9)}}
+	third := &l1BackendStub{result: []byte{2}, header: &types.Header{Number: big.NewInt(11)}}
+	client := newMultiClientStub(true, quorumAddress, first, second, third)
+
+	// the calls to the latest block are made to the lowest head of the providers
+	result, err := client.CallContract(ctx, ethereum.CallMsg{To: &quorumAddress}, nil)
+	require.NoError(t, err)
+	assert.Equal(t, []byte{2}, result)
+	for _, stub := range []*l1BackendStub{first, second, third} {
+		assert.Equal(t, 1, stub.contractCalls)
+		assert.Equal(t, big.NewInt(9), stub.callBlockNumber)
+	}
+
+	// the calls to a block are made to that block
+	_, err = client.CallContract(ctx, ethereum.CallMsg{To: &quorumAddress}, big.NewInt(8))
+	require.NoError(t, err)
+	assert.Equal(t, big.NewInt(8), first.callBlockNumber)
+	assert.Equal(t, 1, first.calls)
+
+	// the other contracts are only called on the current provider
+	result, err = client.CallContract(ctx, ethereum.CallMsg{To: &otherAddress}, nil)
+	require.NoError(t, err)
+	assert.Equal(t, []byte{1}, result)
+	assert.Equal(t, 3, first.contractCalls)
+	assert.Equal(t, 2, second.contractCalls)
+	assert.Nil(t, first.callBlockNumber)
+
+	third.result = []byte{3}
+	_, err = client.CallContract(ctx, ethereum.CallMsg{To: &quorumAddress}, nil)
+	require.ErrorIs(t, err, ErrL1QuorumNotReached)
+
+	// the unavailable providers don't vote
+	third.err = errors.New("connection refused")
+	_, err = client.CallContract(ctx, ethereum.CallMsg{To: &quorumAddress}, nil)
+	require.ErrorIs(t, err, ErrL1QuorumNotReached)
+	assert.False(t, client.providers[2].healthy)
+	first.result = []byte{2}
+	result, err = client.CallContract(ctx, ethereum.CallMsg{To: &quorumAddress}, nil)
+	require.NoError(t, err)
+	assert.Equal(t, []byte{2}, result)
+	assert.Equal(t, 4, third.contractCalls)
+	assert.Equal(t, big.NewInt(9), first.callBlockNumber)
+}
+
+func TestMultiClientFilterLogsFromProviderAtRangeEnd(t *testing.T) {
+	ctx := context.Background()
+	behind := &l1BackendStub{header: &types.Header{Number: big.NewInt(9)}, logs: []types.Log{{BlockNumber: 9}}}
+	synced := &l1BackendStub{header: &types.Header{Number: big.NewInt(10)}, logs: []types.Log{{BlockNumber: 9}, {BlockNumber: 10}}}
+	client := newMultiClientStub(false, common.Address{}, behind, synced)
+
+	// the provider behind the end of the range is skipped, but it's still healthy
+	logs, err := client.FilterLogs(ctx, ethereum.FilterQuery{FromBlock: big.NewInt(9), ToBlock: big.NewInt(10)})
+	require.NoError(t, err)
+	assert.Equal(t, synced.logs, logs)
+	assert.Zero(t, behind.filterCalls)
+	assert.True(t, client.providers[0].healthy)
+	assert.Equal(t, 1, client.current)
+
+	// the providers are not checked for the ranges up to the latest block
+	client.setCurrent(0)
+	logs, err = client.FilterLogs(ctx, ethereum.FilterQuery{FromBlock: big.NewInt(9)})
+	require.NoError(t, err)
+	assert.Equal(t, behind.logs, logs)
+
+	synced.header = &types.Header{Number: big.NewInt(9)}
+	_, err = client.FilterLogs(ctx, ethereum.FilterQuery{FromBlock: big.NewInt(9), ToBlock: big.NewInt(10)})
+	require.ErrorIs(t, err, ErrL1ProviderBehind)
+}
@@ -13,6 +13,10 @@ type Config struct {
 	// to be read in order to provide the private keys to sign the L1 txs
 	PrivateKeys []types.KeystoreFileConfig `mapstructure:"PrivateKeys"`
 
+	// ExternalSigners defines the accounts whose L1 txs are signed by an external
+	// signer, like clef or web3signer, instead of a key store file
+	ExternalSigners []types.ExternalSignerConfig `mapstructure:"ExternalSigners"`
+
 	// ForcedGas is the amount of gas to be forced in case of gas estimation error
 	ForcedGas uint64 `mapstructure:"ForcedGas"`
 
@@ -48,4 +52,25 @@ type Config struct {
 	// max gas price limit: 110
 	// tx gas price = 110
 	MaxGasPriceLimit uint64 `mapstructure:"MaxGasPriceLimit"`
+
+	// EIP1559 if enabled, the new txs are sent as EIP-1559 txs. The gas tip cap is the one
+	// suggested by the network adjusted by the GasPriceMarginFactor and the gas fee cap is
+	// twice the base fee of the latest block plus the gas tip cap, both are limited by the
+	// MaxGasPriceLimit. The legacy txs are sent if the network doesn't support EIP-1559.
+	EIP1559 bool `mapstructure:"EIP1559"`
+
+	// GasPriceEscalationTimeout is the time a sent tx can stay not mined before its gas price,
+	// and its gas tip cap for the EIP-1559 txs, is escalated to replace it, default value is 0,
+	// which means the gas price is only updated when the suggested gas price increases.
+	GasPriceEscalationTimeout types.Duration `mapstructure:"GasPriceEscalationTimeout"`
+
+	// GasPriceEscalationFactor is used to multiply the gas price of the txs not mined after the
+	// GasPriceEscalationTimeout, up to the MaxGasPriceLimit. The L1 nodes only replace a tx if
+	// the gas price increases at least a 10%, so the minimum value is 1.1.
+	//
+	// ex:
+	// gas price: 100
+	// GasPriceEscalationFactor: 1.2
+	// escalated gas price = 120
+	GasPriceEscalationFactor float64 `mapstructure:"GasPriceEscalationFactor"`
 }
@@ -12,6 +12,7 @@ import (
 	"sync"
 	"time"
 
+	"github.com/0xPolygonHermez/zkevm-node/ethtxmanager/metrics"
 	"github.com/0xPolygonHermez/zkevm-node/log"
 	"github.com/0xPolygonHermez/zkevm-node/state"
 	"github.com/ethereum/go-ethereum"
@@ -23,6 +24,10 @@ import (
 const (
 	failureIntervalInSeconds = 5
 	// maxHistorySize           = 10
+
+	// minGasPriceEscalationFactor is the minimum increase of the gas price
+	// required by the L1 nodes to replace a tx with the same nonce
+	minGasPriceEscalationFactor = 1.1
 )
 
 var (
@@ -34,8 +39,17 @@ var (
 	// ErrExecutionReverted returned when trying to get the revert message
 	// but the call fails without revealing the revert reason
 	ErrExecutionReverted = errors.New("execution reverted")
+
+	// errBaseFeeNotAvailable returned when the latest block has no base fee
+	// because the network doesn't support EIP-1559
+	errBaseFeeNotAvailable = errors.New("base fee not available")
 )
 
+// nonceMutex serializes the nonce assignment of the txs added by all the clients
+// of this process, so the components sending L1 txs from the same account, like
+// the sequence sender and the aggregator, don't get the same nonce
+var nonceMutex sync.Mutex
+
 // Client for eth tx manager
 type Client struct {
 	ctx    context.Context
@@ -56,6 +70,8 @@ func New(cfg Config, ethMan ethermanInterface, storage storageInterface, state s
 		state:    state,
 	}
 
+	metrics.Register()
+
 	return c
 }
 
@@ -89,6 +105,10 @@ func (c *Client) getTxNonce(ctx context.Context, from common.Address) (uint64, e
 
 // Add a transaction to be sent and monitored
 func (c *Client) Add(ctx context.Context, owner, id string, from common.Address, to *common.Address, value *big.Int, data []byte, gasOffset uint64, dbTx pgx.Tx) error {
+	// the nonce is reserved until the monitored tx is stored
+	nonceMutex.Lock()
+	defer nonceMutex.Unlock()
+
 	// get nonce
 	nonce, err := c.getTxNonce(ctx, from)
 	if err != nil {
@@ -109,8 +129,8 @@ func (c *Client) Add(ctx context.Context, owner, id string, from common.Address,
 		}
 	}
 
-	// get gas price
-	gasPrice, err := c.suggestedGasPrice(ctx)
+	// get gas price, and gas tip cap for the EIP-1559 txs
+	gasPrice, gasTipCap, err := c.suggestedGasFees(ctx)
 	if err != nil {
 		err := fmt.Errorf("failed to get suggested gas price: %w", err)
 		log.Errorf(err.Error())
@@ -121,8 +141,9 @@ func (c *Client) Add(ctx context.Context, owner, id string, from common.Address,
 	mTx := monitoredTx{
 		owner: owner, id: id, from: from, to: to,
 		nonce: nonce, value: value, data: data,
-		gas: gas, gasOffset: gasOffset, gasPrice: gasPrice,
-		status: MonitoredTxStatusCreated,
+		gas: gas, gasOffset: gasOffset, gasPrice: gasPrice, gasTipCap: gasTipCap,
+		gasPriceUpdatedAt: time.Now(),
+		status:            MonitoredTxStatusCreated,
 	}
 
 	// add to storage
@@ -282,6 +303,7 @@ func (c *Client) monitorTxs(ctx context.Context) error {
 	}
 
 	log.Infof("found %v monitored tx to process", len(mTxs))
+	metrics.PendingMonitoredTxs(len(mTxs))
 
 	wg := sync.WaitGroup{}
 	wg.Add(len(mTxs))
@@ -446,6 +468,7 @@ func (c *Client) monitorTx(ctx context.Context, mTx monitoredTx, logger *log.Log
 				return
 			}
 			logger.Infof("signed tx sent to the network: %v", signedTx.Hash().String())
+			metrics.TxSent()
 			if mTx.status == MonitoredTxStatusCreated {
 				// update tx status to sent
 				mTx.status = MonitoredTxStatusSent
@@ -504,6 +527,7 @@ func (c *Client) monitorTx(ctx context.Context, mTx monitoredTx, logger *log.Log
 			mTx.status = MonitoredTxStatusConfirmed
 			mTx.blockNumber = lastReceiptChecked.BlockNumber
 			logger.Info("confirmed")
+			metrics.MonitoredTxConfirmed()
 		}
 	} else {
 		// if we should continue to monitor, we move to the next one and this will
@@ -515,6 +539,7 @@ func (c *Client) monitorTx(ctx context.Context, mTx monitoredTx, logger *log.Log
 		mTx.status = MonitoredTxStatusFailed
 		mTx.blockNumber = lastReceiptChecked.BlockNumber
 		logger.Info("failed")
+		metrics.MonitoredTxFailed()
 	}
 
 	// update monitored tx changes into storage
@@ -570,8 +595,20 @@ func (c *Client) reviewMonitoredTx(ctx context.Context, mTx *monitoredTx, mTxLog
 		mTx.gas = gas
 	}
 
-	// get gas price
-	gasPrice, err := c.suggestedGasPrice(ctx)
+	// the escalation timeout of the txs stored without the time of the last update of
+	// their gas price starts now, so they are not escalated right away
+	if mTx.gasPriceUpdatedAt.IsZero() {
+		mTx.gasPriceUpdatedAt = time.Now()
+	}
+
+	// get gas price, the legacy txs are kept as legacy txs
+	gasPriceUpdatedAt := mTx.gasPriceUpdatedAt
+	var gasPrice, gasTipCap *big.Int
+	if mTx.gasTipCap != nil {
+		gasPrice, gasTipCap, err = c.suggestedEIP1559GasFees(ctx)
+	} else {
+		gasPrice, err = c.suggestedGasPrice(ctx)
+	}
 	if err != nil {
 		err := fmt.Errorf("failed to get suggested gas price: %w", err)
 		mTxLogger.Errorf(err.Error())
@@ -582,10 +619,53 @@ func (c *Client) reviewMonitoredTx(ctx context.Context, mTx *monitoredTx, mTxLog
 	if gasPrice.Cmp(mTx.gasPrice) == 1 {
 		mTxLogger.Infof("monitored tx gas price updated from %v to %v", mTx.gasPrice.String(), gasPrice.String())
 		mTx.gasPrice = gasPrice
+		mTx.gasPriceUpdatedAt = time.Now()
+	}
+	if gasTipCap != nil && gasTipCap.Cmp(mTx.gasTipCap) == 1 {
+		mTxLogger.Infof("monitored tx gas tip cap updated from %v to %v", mTx.gasTipCap.String(), gasTipCap.String())
+		mTx.gasTipCap = gasTipCap
+		mTx.gasPriceUpdatedAt = time.Now()
+	}
+
+	c.escalateGasPrice(mTx, mTxLogger)
+	if mTx.gasPriceUpdatedAt != gasPriceUpdatedAt {
+		metrics.GasPriceBumped()
 	}
 	return nil
 }
 
+// escalateGasPrice increases the gas price, and the gas tip cap of the EIP-1559 txs, by the
+// GasPriceEscalationFactor if they were not updated during the GasPriceEscalationTimeout, so
+// the tx not mined is replaced by a tx with a higher gas price
+func (c *Client) escalateGasPrice(mTx *monitoredTx, mTxLogger *log.Logger) {
+	if c.cfg.GasPriceEscalationTimeout.Duration <= 0 || time.Since(mTx.gasPriceUpdatedAt) < c.cfg.GasPriceEscalationTimeout.Duration {
+		return
+	}
+
+	factor := c.cfg.GasPriceEscalationFactor
+	if factor < minGasPriceEscalationFactor {
+		factor = minGasPriceEscalationFactor
+	}
+	gasPrice := c.limitGasPrice(mulByFactor(mTx.gasPrice, factor))
+	if gasPrice.Cmp(mTx.gasPrice) <= 0 {
+		mTxLogger.Warnf("monitored tx not mined after %v, but its gas price %v already reached the max gas price limit",
+			c.cfg.GasPriceEscalationTimeout.Duration, mTx.gasPrice.String())
+		return
+	}
+	mTxLogger.Infof("monitored tx not mined after %v, gas price escalated from %v to %v",
+		c.cfg.GasPriceEscalationTimeout.Duration, mTx.gasPrice.String(), gasPrice.String())
+	mTx.gasPrice = gasPrice
+	if mTx.gasTipCap != nil {
+		gasTipCap := mulByFactor(mTx.gasTipCap, factor)
+		if gasTipCap.Cmp(gasPrice) == 1 {
+			gasTipCap.Set(gasPrice)
+		}
+		mTxLogger.Infof("monitored tx gas tip cap escalated from %v to %v", mTx.gasTipCap.String(), gasTipCap.String())
+		mTx.gasTipCap = gasTipCap
+	}
+	mTx.gasPriceUpdatedAt = time.Now()
+}
+
 // reviewMonitoredTxNonce checks if the nonce needs to be updated accordingly to
 // the current nonce of the sender account.
 //
@@ -609,6 +689,46 @@ func (c *Client) reviewMonitoredTxNonce(ctx context.Context, mTx *monitoredTx, m
 	return nil
 }
 
+// suggestedGasFees returns the gas fee cap and the gas tip cap if EIP-1559 is enabled and
+// supported by the network, or the gas price and a nil gas tip cap for the legacy txs
+func (c *Client) suggestedGasFees(ctx context.Context) (*big.Int, *big.Int, error) {
+	if c.cfg.EIP1559 {
+		gasFeeCap, gasTipCap, err := c.suggestedEIP1559GasFees(ctx)
+		if !errors.Is(err, errBaseFeeNotAvailable) {
+			return gasFeeCap, gasTipCap, err
+		}
+		log.Warnf("EIP-1559 is not supported by the network, sending a legacy tx")
+	}
+	gasPrice, err := c.suggestedGasPrice(ctx)
+	return gasPrice, nil, err
+}
+
+// suggestedEIP1559GasFees returns the gas fee cap and the gas tip cap for the EIP-1559 txs,
+// the gas fee cap allows the base fee to double before the tx is not includable
+func (c *Client) suggestedEIP1559GasFees(ctx context.Context) (*big.Int, *big.Int, error) {
+	header, err := c.etherman.GetLatestBlockHeader(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	if header.BaseFee == nil {
+		return nil, nil, errBaseFeeNotAvailable
+	}
+
+	gasTipCap, err := c.etherman.SuggestedGasTipCap(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	gasTipCap = c.limitGasPrice(mulByFactor(gasTipCap, c.cfg.GasPriceMarginFactor))
+
+	gasFeeCap := big.NewInt(0).Mul(header.BaseFee, big.NewInt(2)) //nolint:gomnd
+	gasFeeCap = c.limitGasPrice(gasFeeCap.Add(gasFeeCap, gasTipCap))
+	if gasTipCap.Cmp(gasFeeCap) == 1 {
+		gasTipCap.Set(gasFeeCap)
+	}
+
+	return gasFeeCap, gasTipCap, nil
+}
+
 func (c *Client) suggestedGasPrice(ctx context.Context) (*big.Int, error) {
 	// get gas price
 	gasPrice, err := c.etherman.SuggestedGasPrice(ctx)
@@ -617,20 +737,29 @@ func (c *Client) suggestedGasPrice(ctx context.Context) (*big.Int, error) {
 	}
 
 	// adjust the gas price by the margin factor
-	marginFactor := big.NewFloat(0).SetFloat64(c.cfg.GasPriceMarginFactor)
-	fGasPrice := big.NewFloat(0).SetInt(gasPrice)
-	adjustedGasPrice, _ := big.NewFloat(0).Mul(fGasPrice, marginFactor).Int(big.NewInt(0))
+	adjustedGasPrice := mulByFactor(gasPrice, c.cfg.GasPriceMarginFactor)
 
-	// if there is a max gas price limit configured and the current
-	// adjusted gas price is over this limit, set the gas price as the limit
+	return c.limitGasPrice(adjustedGasPrice), nil
+}
+
+// limitGasPrice sets the gas price as the limit if there is a max gas price
+// limit configured and the gas price is over this limit
+func (c *Client) limitGasPrice(gasPrice *big.Int) *big.Int {
 	if c.cfg.MaxGasPriceLimit > 0 {
 		maxGasPrice := big.NewInt(0).SetUint64(c.cfg.MaxGasPriceLimit)
-		if adjustedGasPrice.Cmp(maxGasPrice) == 1 {
-			adjustedGasPrice.Set(maxGasPrice)
+		if gasPrice.Cmp(maxGasPrice) == 1 {
+			gasPrice.Set(maxGasPrice)
 		}
 	}
+	return gasPrice
+}
 
-	return adjustedGasPrice, nil
+// mulByFactor returns a new value with the value multiplied by the factor
+func mulByFactor(value *big.Int, factor float64) *big.Int {
+	fFactor := big.NewFloat(0).SetFloat64(factor)
+	fValue := big.NewFloat(0).SetInt(value)
+	result, _ := big.NewFloat(0).Mul(fValue, fFactor).Int(big.NewInt(0))
+	return result
 }
 
 // logErrorAndWait used when an error is detected before trying again
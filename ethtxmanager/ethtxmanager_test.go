@@ -982,3 +982,95 @@ func TestFailedToEstimateTxWithForcedGasGetMined(t *testing.T) {
 	require.Equal(t, receipt, result.Txs[signedTx.Hash()].Receipt)
 	require.Equal(t, "", result.Txs[signedTx.Hash()].RevertMessage)
 }
+
+func TestSuggestedEIP1559GasFees(t *testing.T) {
+	ctx := context.Background()
+	etherman := newEthermanMock(t)
+	cfg := defaultEthTxmanagerConfigForTests
+	cfg.EIP1559 = true
+	cfg.GasPriceMarginFactor = 1.5
+	ethTxManagerClient := New(cfg, etherman, nil, nil)
+
+	etherman.EXPECT().GetLatestBlockHeader(ctx).Return(&ethTypes.Header{BaseFee: big.NewInt(100)}, nil).Once()
+	etherman.EXPECT().SuggestedGasTipCap(ctx).Return(big.NewInt(10), nil).Once()
+	gasFeeCap, gasTipCap, err := ethTxManagerClient.suggestedGasFees(ctx)
+	require.NoError(t, err)
+	require.Equal(t, int64(215), gasFeeCap.Int64())
+	require.Equal(t, int64(15), gasTipCap.Int64())
+
+	// the max gas price limit applies to the gas fee cap and the gas tip cap
+	ethTxManagerClient.cfg.MaxGasPriceLimit = 12
+	etherman.EXPECT().GetLatestBlockHeader(ctx).Return(&ethTypes.Header{BaseFee: big.NewInt(100)}, nil).Once()
+	etherman.EXPECT().SuggestedGasTipCap(ctx).Return(big.NewInt(10), nil).Once()
+	gasFeeCap, gasTipCap, err = ethTxManagerClient.suggestedGasFees(ctx)
+	require.NoError(t, err)
+	require.Equal(t, int64(12), gasFeeCap.Int64())
+	require.Equal(t, int64(12), gasTipCap.Int64())
+
+	// legacy tx if the network doesn't support EIP-1559
+	ethTxManagerClient.cfg.MaxGasPriceLimit = 0
+	etherman.EXPECT().GetLatestBlockHeader(ctx).Return(&ethTypes.Header{}, nil).Once()
+	etherman.EXPECT().SuggestedGasPrice(ctx).Return(big.NewInt(100), nil).Once()
+	gasPrice, gasTipCap, err := ethTxManagerClient.suggestedGasFees(ctx)
+	require.NoError(t, err)
+	require.Equal(t, int64(150), gasPrice.Int64())
+	require.Nil(t, gasTipCap)
+}
+
+func TestEscalateGasPrice(t *testing.T) {
+	cfg := defaultEthTxmanagerConfigForTests
+	cfg.GasPriceEscalationTimeout = types.NewDuration(time.Minute)
+	cfg.GasPriceEscalationFactor = 1.5
+	cfg.MaxGasPriceLimit = 200
+	ethTxManagerClient := New(cfg, nil, nil, nil)
+	mTx := &monitoredTx{owner: "owner", id: "id", gasPrice: big.NewInt(100), gasTipCap: big.NewInt(10), gasPriceUpdatedAt: time.Now()}
+
+	// not escalated before the timeout
+	ethTxManagerClient.escalateGasPrice(mTx, createMonitoredTxLogger(*mTx))
+	require.Equal(t, int64(100), mTx.gasPrice.Int64())
+	require.Equal(t, int64(10), mTx.gasTipCap.Int64())
+
+	mTx.gasPriceUpdatedAt = time.Now().Add(-time.Minute)
+	ethTxManagerClient.escalateGasPrice(mTx, createMonitoredTxLogger(*mTx))
+	require.Equal(t, int64(150), mTx.gasPrice.Int64())
+	require.Equal(t, int64(15), mTx.gasTipCap.Int64())
+	require.WithinDuration(t, time.Now(), mTx.gasPriceUpdatedAt, time.Second)
+
+	// escalated up to the max gas price limit
+	mTx.gasPriceUpdatedAt = time.Now().Add(-time.Minute)
+	ethTxManagerClient.escalateGasPrice(mTx, createMonitoredTxLogger(*mTx))
+	require.Equal(t, int64(200), mTx.gasPrice.Int64())
+	require.Equal(t, int64(22), mTx.gasTipCap.Int64())
+
+	mTx.gasPriceUpdatedAt = time.Now().Add(-time.Minute)
+	ethTxManagerClient.escalateGasPrice(mTx, createMonitoredTxLogger(*mTx))
+	require.Equal(t, int64(200), mTx.gasPrice.Int64())
+	require.Equal(t, int64(22), mTx.gasTipCap.Int64())
+
+	// the factor is at least the replacement minimum
+	ethTxManagerClient.cfg.GasPriceEscalationFactor = 1
+	ethTxManagerClient.cfg.MaxGasPriceLimit = 0
+	mTx.gasPriceUpdatedAt = time.Now().Add(-time.Minute)
+	ethTxManagerClient.escalateGasPrice(mTx, createMonitoredTxLogger(*mTx))
+	require.Equal(t, int64(220), mTx.gasPrice.Int64())
+}
+
+func TestReviewMonitoredTxWithoutGasPriceUpdatedAt(t *testing.T) {
+	ctx := context.Background()
+	etherman := newEthermanMock(t)
+	cfg := defaultEthTxmanagerConfigForTests
+	cfg.GasPriceEscalationTimeout = types.NewDuration(time.Minute)
+	cfg.GasPriceEscalationFactor = 1.5
+	ethTxManagerClient := New(cfg, etherman, nil, nil)
+
+	// the monitored txs stored before the time of the last update of the gas price was stored
+	to := common.HexToAddress("0x2")
+	mTx := &monitoredTx{owner: "owner", id: "id", from: common.HexToAddress("0x1"), to: &to, value: big.NewInt(0), gas: 21000, gasPrice: big.NewInt(100)}
+	etherman.On("EstimateGas", ctx, mTx.from, mTx.to, mTx.value, mTx.data).Return(uint64(21000), nil).Once()
+	etherman.On("SuggestedGasPrice", ctx).Return(big.NewInt(50), nil).Once()
+
+	// the gas price is not escalated until the timeout passes from now
+	require.NoError(t, ethTxManagerClient.reviewMonitoredTx(ctx, mTx, createMonitoredTxLogger(*mTx)))
+	require.Equal(t, int64(100), mTx.gasPrice.Int64())
+	require.WithinDuration(t, time.Now(), mTx.gasPriceUpdatedAt, time.Second)
+}
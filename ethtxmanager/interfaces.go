@@ -19,6 +19,8 @@ type ethermanInterface interface {
 	PendingNonce(ctx context.Context, account common.Address) (uint64, error)
 	CurrentNonce(ctx context.Context, account common.Address) (uint64, error)
 	SuggestedGasPrice(ctx context.Context) (*big.Int, error)
+	SuggestedGasTipCap(ctx context.Context) (*big.Int, error)
+	GetLatestBlockHeader(ctx context.Context) (*types.Header, error)
 	EstimateGas(ctx context.Context, from common.Address, to *common.Address, value *big.Int, data []byte) (uint64, error)
 	CheckTxWasMined(ctx context.Context, txHash common.Hash) (bool, *types.Receipt, error)
 	SignTx(ctx context.Context, sender common.Address, tx *types.Transaction) (*types.Transaction, error)
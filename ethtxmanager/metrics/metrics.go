@@ -0,0 +1,83 @@
+package metrics
+
+import (
+	"github.com/0xPolygonHermez/zkevm-node/metrics"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const (
+	// Prefix for the metrics of the ethtxmanager package.
+	Prefix = "ethtxmanager_"
+
+	// PendingMonitoredTxsName is the name of the label for the number of monitored txs not mined yet.
+	PendingMonitoredTxsName = Prefix + "pending_monitored_txs"
+
+	// SentTxsName is the name of the label for the number of txs sent to L1.
+	SentTxsName = Prefix + "sent_txs"
+
+	// GasPriceBumpsName is the name of the label for the number of gas price increases of the monitored txs.
+	GasPriceBumpsName = Prefix + "gas_price_bumps"
+
+	// ConfirmedMonitoredTxsName is the name of the label for the number of monitored txs confirmed.
+	ConfirmedMonitoredTxsName = Prefix + "confirmed_monitored_txs"
+
+	// FailedMonitoredTxsName is the name of the label for the number of monitored txs failed.
+	FailedMonitoredTxsName = Prefix + "failed_monitored_txs"
+)
+
+// Register the metrics for the ethtxmanager package.
+func Register() {
+	gauges := []prometheus.GaugeOpts{
+		{
+			Name: PendingMonitoredTxsName,
+			Help: "[ETHTXMANAGER] number of monitored txs not mined yet",
+		},
+	}
+
+	counters := []prometheus.CounterOpts{
+		{
+			Name: SentTxsName,
+			Help: "[ETHTXMANAGER] number of txs sent to L1",
+		},
+		{
+			Name: GasPriceBumpsName,
+			Help: "[ETHTXMANAGER] number of gas price increases of the monitored txs",
+		},
+		{
+			Name: ConfirmedMonitoredTxsName,
+			Help: "[ETHTXMANAGER] number of monitored txs confirmed",
+		},
+		{
+			Name: FailedMonitoredTxsName,
+			Help: "[ETHTXMANAGER] number of monitored txs failed",
+		},
+	}
+
+	metrics.RegisterGauges(gauges...)
+	metrics.RegisterCounters(counters...)
+}
+
+// PendingMonitoredTxs sets the gauge for the number of monitored txs not mined yet.
+func PendingMonitoredTxs(count int) {
+	metrics.GaugeSet(PendingMonitoredTxsName, float64(count))
+}
+
+// TxSent increments the counter for the number of txs sent to L1.
+func TxSent() {
+	metrics.CounterInc(SentTxsName)
+}
+
+// GasPriceBumped increments the counter for the number of gas price increases.
+func GasPriceBumped() {
+	metrics.CounterInc(GasPriceBumpsName)
+}
+
+// MonitoredTxConfirmed increments the counter for the number of monitored txs confirmed.
+func MonitoredTxConfirmed() {
+	metrics.CounterInc(ConfirmedMonitoredTxsName)
+}
+
+// MonitoredTxFailed increments the counter for the number of monitored txs failed.
+func MonitoredTxFailed() {
+	metrics.CounterInc(FailedMonitoredTxsName)
+}
@@ -211,6 +211,64 @@ func (_c *ethermanMock_EstimateGas_Call) RunAndReturn(run func(context.Context,
 	return _c
 }
 
+// GetLatestBlockHeader provides a mock function with given fields: ctx
+func (_m *ethermanMock) GetLatestBlockHeader(ctx context.Context) (*types.Header, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetLatestBlockHeader")
+	}
+
+	var r0 *types.Header
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) (*types.Header, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) *types.Header); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*types.Header)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ethermanMock_GetLatestBlockHeader_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetLatestBlockHeader'
+type ethermanMock_GetLatestBlockHeader_Call struct {
+	*mock.Call
+}
+
+// GetLatestBlockHeader is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *ethermanMock_Expecter) GetLatestBlockHeader(ctx interface{}) *ethermanMock_GetLatestBlockHeader_Call {
+	return &ethermanMock_GetLatestBlockHeader_Call{Call: _e.mock.On("GetLatestBlockHeader", ctx)}
+}
+
+func (_c *ethermanMock_GetLatestBlockHeader_Call) Run(run func(ctx context.Context)) *ethermanMock_GetLatestBlockHeader_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *ethermanMock_GetLatestBlockHeader_Call) Return(_a0 *types.Header, _a1 error) *ethermanMock_GetLatestBlockHeader_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *ethermanMock_GetLatestBlockHeader_Call) RunAndReturn(run func(context.Context) (*types.Header, error)) *ethermanMock_GetLatestBlockHeader_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // GetRevertMessage provides a mock function with given fields: ctx, tx
 func (_m *ethermanMock) GetRevertMessage(ctx context.Context, tx *types.Transaction) (string, error) {
 	ret := _m.Called(ctx, tx)
@@ -615,6 +673,64 @@ func (_c *ethermanMock_SuggestedGasPrice_Call) RunAndReturn(run func(context.Con
 	return _c
 }
 
+// SuggestedGasTipCap provides a mock function with given fields: ctx
+func (_m *ethermanMock) SuggestedGasTipCap(ctx context.Context) (*big.Int, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SuggestedGasTipCap")
+	}
+
+	var r0 *big.Int
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) (*big.Int, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) *big.Int); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*big.Int)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ethermanMock_SuggestedGasTipCap_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SuggestedGasTipCap'
+type ethermanMock_SuggestedGasTipCap_Call struct {
+	*mock.Call
+}
+
+// SuggestedGasTipCap is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *ethermanMock_Expecter) SuggestedGasTipCap(ctx interface{}) *ethermanMock_SuggestedGasTipCap_Call {
+	return &ethermanMock_SuggestedGasTipCap_Call{Call: _e.mock.On("SuggestedGasTipCap", ctx)}
+}
+
+func (_c *ethermanMock_SuggestedGasTipCap_Call) Run(run func(ctx context.Context)) *ethermanMock_SuggestedGasTipCap_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *ethermanMock_SuggestedGasTipCap_Call) Return(_a0 *big.Int, _a1 error) *ethermanMock_SuggestedGasTipCap_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *ethermanMock_SuggestedGasTipCap_Call) RunAndReturn(run func(context.Context) (*big.Int, error)) *ethermanMock_SuggestedGasTipCap_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // WaitTxToBeMined provides a mock function with given fields: ctx, tx, timeout
 func (_m *ethermanMock) WaitTxToBeMined(ctx context.Context, tx *types.Transaction, timeout time.Duration) (bool, error) {
 	ret := _m.Called(ctx, tx, timeout)
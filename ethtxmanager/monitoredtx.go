@@ -76,9 +76,16 @@ type monitoredTx struct {
 	// tx gas offset
 	gasOffset uint64
 
-	// tx gas price
+	// tx gas price, it's the gas fee cap of the EIP-1559 txs
 	gasPrice *big.Int
 
+	// tx gas tip cap, only set for the EIP-1559 txs
+	gasTipCap *big.Int
+
+	// gasPriceUpdatedAt last date time the gas price was set, used
+	// to escalate the gas price of the txs that are not mined
+	gasPriceUpdatedAt time.Time
+
 	// status of this monitoring
 	status MonitoredTxStatus
 
@@ -99,8 +106,21 @@ type monitoredTx struct {
 	updatedAt time.Time
 }
 
-// Tx uses the current information to build a tx
+// Tx uses the current information to build a tx, a EIP-1559 tx
+// if there is a gas tip cap or a legacy tx otherwise
 func (mTx monitoredTx) Tx() *types.Transaction {
+	if mTx.gasTipCap != nil {
+		return types.NewTx(&types.DynamicFeeTx{
+			To:        mTx.to,
+			Nonce:     mTx.nonce,
+			Value:     mTx.value,
+			Data:      mTx.data,
+			Gas:       mTx.gas + mTx.gasOffset,
+			GasFeeCap: mTx.gasPrice,
+			GasTipCap: mTx.gasTipCap,
+		})
+	}
+
 	tx := types.NewTx(&types.LegacyTx{
 		To:       mTx.to,
 		Nonce:    mTx.nonce,
@@ -142,6 +162,26 @@ func (mTx *monitoredTx) valueU64Ptr() *uint64 {
 	return value
 }
 
+// gasTipCapU64Ptr returns the current gasTipCap field as a uint64 pointer
+func (mTx *monitoredTx) gasTipCapU64Ptr() *uint64 {
+	var gasTipCap *uint64
+	if mTx.gasTipCap != nil {
+		tmp := mTx.gasTipCap.Uint64()
+		gasTipCap = &tmp
+	}
+	return gasTipCap
+}
+
+// gasPriceUpdatedAtPtr returns the current gasPriceUpdatedAt field as a time pointer
+func (mTx *monitoredTx) gasPriceUpdatedAtPtr() *time.Time {
+	var gasPriceUpdatedAt *time.Time
+	if !mTx.gasPriceUpdatedAt.IsZero() {
+		tmp := mTx.gasPriceUpdatedAt.UTC().Round(time.Microsecond)
+		gasPriceUpdatedAt = &tmp
+	}
+	return gasPriceUpdatedAt
+}
+
 // dataStringPtr returns the current data field as a string pointer
 func (mTx *monitoredTx) dataStringPtr() *string {
 	var data *string
@@ -36,13 +36,14 @@ func NewPostgresStorage(dbCfg db.Config) (*PostgresStorage, error) {
 func (s *PostgresStorage) Add(ctx context.Context, mTx monitoredTx, dbTx pgx.Tx) error {
 	conn := s.dbConn(dbTx)
 	cmd := `
-        INSERT INTO state.monitored_txs (owner, id, from_addr, to_addr, nonce, value, data, gas, gas_offset, gas_price, status, block_num, history, created_at, updated_at)
-                                 VALUES (   $1, $2,        $3,      $4,    $5,    $6,   $7,  $8,         $9,       $10,    $11,       $12,     $13,        $14,        $15)`
+        INSERT INTO state.monitored_txs (owner, id, from_addr, to_addr, nonce, value, data, gas, gas_offset, gas_price, gas_tip_cap, gas_price_updated_at, status, block_num, history, created_at, updated_at)
+                                 VALUES (   $1, $2,        $3,      $4,    $5,    $6,   $7,  $8,         $9,       $10,         $11,                  $12,    $13,       $14,     $15,        $16,        $17)`
 
 	_, err := conn.Exec(ctx, cmd, mTx.owner,
 		mTx.id, mTx.from.String(), mTx.toStringPtr(),
 		mTx.nonce, mTx.valueU64Ptr(), mTx.dataStringPtr(),
-		mTx.gas, mTx.gasOffset, mTx.gasPrice.Uint64(), string(mTx.status), mTx.blockNumberU64Ptr(),
+		mTx.gas, mTx.gasOffset, mTx.gasPrice.Uint64(), mTx.gasTipCapU64Ptr(), mTx.gasPriceUpdatedAtPtr(),
+		string(mTx.status), mTx.blockNumberU64Ptr(),
 		mTx.historyStringSlice(), time.Now().UTC().Round(time.Microsecond),
 		time.Now().UTC().Round(time.Microsecond))
 
@@ -61,7 +62,7 @@ func (s *PostgresStorage) Add(ctx context.Context, mTx monitoredTx, dbTx pgx.Tx)
 func (s *PostgresStorage) Get(ctx context.Context, owner, id string, dbTx pgx.Tx) (monitoredTx, error) {
 	conn := s.dbConn(dbTx)
 	cmd := `
-        SELECT owner, id, from_addr, to_addr, nonce, value, data, gas, gas_offset, gas_price, status, block_num, history, created_at, updated_at
+        SELECT owner, id, from_addr, to_addr, nonce, value, data, gas, gas_offset, gas_price, gas_tip_cap, gas_price_updated_at, status, block_num, history, created_at, updated_at
           FROM state.monitored_txs
          WHERE owner = $1 
            AND id = $2`
@@ -85,7 +86,7 @@ func (s *PostgresStorage) GetByStatus(ctx context.Context, owner *string, status
 
 	conn := s.dbConn(dbTx)
 	cmd := `
-        SELECT owner, id, from_addr, to_addr, nonce, value, data, gas, gas_offset, gas_price, status, block_num, history, created_at, updated_at
+        SELECT owner, id, from_addr, to_addr, nonce, value, data, gas, gas_offset, gas_price, gas_tip_cap, gas_price_updated_at, status, block_num, history, created_at, updated_at
           FROM state.monitored_txs
          WHERE (owner = $1 OR $1 IS NULL)`
 	if hasStatusToFilter {
@@ -129,7 +130,7 @@ func (s *PostgresStorage) GetBySenderAndStatus(ctx context.Context, sender commo
 
 	conn := s.dbConn(dbTx)
 	cmd := `
-        SELECT owner, id, from_addr, to_addr, nonce, value, data, gas, gas_offset, gas_price, status, block_num, history, created_at, updated_at
+        SELECT owner, id, from_addr, to_addr, nonce, value, data, gas, gas_offset, gas_price, gas_tip_cap, gas_price_updated_at, status, block_num, history, created_at, updated_at
           FROM state.monitored_txs
          WHERE from_addr = $1`
 	if hasStatusToFilter {
@@ -172,7 +173,7 @@ func (s *PostgresStorage) GetBySenderAndStatus(ctx context.Context, sender commo
 func (s *PostgresStorage) GetByBlock(ctx context.Context, fromBlock, toBlock *uint64, dbTx pgx.Tx) ([]monitoredTx, error) {
 	conn := s.dbConn(dbTx)
 	cmd := `
-        SELECT owner, id, from_addr, to_addr, nonce, value, data, gas, gas_offset, gas_price, status, block_num, history, created_at, updated_at
+        SELECT owner, id, from_addr, to_addr, nonce, value, data, gas, gas_offset, gas_price, gas_tip_cap, gas_price_updated_at, status, block_num, history, created_at, updated_at
           FROM state.monitored_txs
          WHERE (block_num >= $1 OR $1 IS NULL)
            AND (block_num <= $2 OR $2 IS NULL)
@@ -228,10 +229,12 @@ func (s *PostgresStorage) Update(ctx context.Context, mTx monitoredTx, dbTx pgx.
              , gas = $8
              , gas_offset = $9
              , gas_price = $10
-             , status = $11
-             , block_num = $12
-             , history = $13
-             , updated_at = $14
+             , gas_tip_cap = $11
+             , gas_price_updated_at = $12
+             , status = $13
+             , block_num = $14
+             , history = $15
+             , updated_at = $16
          WHERE owner = $1
            AND id = $2`
 
@@ -244,7 +247,8 @@ func (s *PostgresStorage) Update(ctx context.Context, mTx monitoredTx, dbTx pgx.
 	_, err := conn.Exec(ctx, cmd, mTx.owner,
 		mTx.id, mTx.from.String(), mTx.toStringPtr(),
 		mTx.nonce, mTx.valueU64Ptr(), mTx.dataStringPtr(),
-		mTx.gas, mTx.gasOffset, mTx.gasPrice.Uint64(), string(mTx.status), bn,
+		mTx.gas, mTx.gasOffset, mTx.gasPrice.Uint64(), mTx.gasTipCapU64Ptr(), mTx.gasPriceUpdatedAtPtr(),
+		string(mTx.status), bn,
 		mTx.historyStringSlice(), time.Now().UTC().Round(time.Microsecond))
 
 	if err != nil {
@@ -257,15 +261,16 @@ func (s *PostgresStorage) Update(ctx context.Context, mTx monitoredTx, dbTx pgx.
 // scanMtx scans a row and fill the provided instance of monitoredTx with
 // the row data
 func (s *PostgresStorage) scanMtx(row pgx.Row, mTx *monitoredTx) error {
-	// id, from, to, nonce, value, data, gas, gas_offset, gas_price, status, history, created_at, updated_at
+	// id, from, to, nonce, value, data, gas, gas_offset, gas_price, gas_tip_cap, gas_price_updated_at, status, history, created_at, updated_at
 	var from, status string
 	var to, data *string
 	var history []string
-	var value, blockNumber *uint64
+	var value, blockNumber, gasTipCap *uint64
 	var gasPrice uint64
+	var gasPriceUpdatedAt *time.Time
 
 	err := row.Scan(&mTx.owner, &mTx.id, &from, &to, &mTx.nonce, &value,
-		&data, &mTx.gas, &mTx.gasOffset, &gasPrice, &status, &blockNumber, &history,
+		&data, &mTx.gas, &mTx.gasOffset, &gasPrice, &gasTipCap, &gasPriceUpdatedAt, &status, &blockNumber, &history,
 		&mTx.createdAt, &mTx.updatedAt)
 	if err != nil {
 		return err
@@ -273,6 +278,14 @@ func (s *PostgresStorage) scanMtx(row pgx.Row, mTx *monitoredTx) error {
 
 	mTx.from = common.HexToAddress(from)
 	mTx.gasPrice = big.NewInt(0).SetUint64(gasPrice)
+	if gasTipCap != nil {
+		mTx.gasTipCap = big.NewInt(0).SetUint64(*gasTipCap)
+	}
+	// the txs created before the gas price escalation was added use the creation time
+	mTx.gasPriceUpdatedAt = mTx.createdAt
+	if gasPriceUpdatedAt != nil {
+		mTx.gasPriceUpdatedAt = *gasPriceUpdatedAt
+	}
 	mTx.status = MonitoredTxStatus(status)
 
 	if to != nil {
@@ -40,6 +40,8 @@ const (
 	EventID_SynchronizerRestart EventID = "SYNCHRONIZER RESTART"
 	// EventID_SynchronizerHalt is triggered when the synchronizer halts
 	EventID_SynchronizerHalt EventID = "SYNCHRONIZER HALT"
+	// EventID_SynchronizerL1Reorg is triggered when the synchronizer detects a L1 reorg and reverts the state
+	EventID_SynchronizerL1Reorg EventID = "SYNCHRONIZER L1 REORG"
 	// EventID_SequenceSenderHalt is triggered when the SequenceSender halts
 	EventID_SequenceSenderHalt EventID = "SEQUENCESENDER HALT"
 	// EventID_NodeOOC is triggered when an OOC at node level is detected
@@ -0,0 +1,118 @@
+package jsonrpc
+
+import (
+	"crypto/subtle"
+	"math"
+	"net/http"
+	"strings"
+
+	"github.com/0xPolygonHermez/zkevm-node/jsonrpc/types"
+	"golang.org/x/time/rate"
+)
+
+const (
+	// apiKeyHeader is the header used to send the API key of the requests
+	apiKeyHeader = "X-API-Key"
+	// methodWildcard is the suffix of the method patterns that match all the methods of a namespace
+	methodWildcard = "_*"
+)
+
+type apiKeyQuota struct {
+	key     []byte
+	name    string
+	limiter *rate.Limiter
+}
+
+// accessControl checks if the requests to the JSON-RPC methods are allowed by the
+// allow and deny lists, the rate limits per method and the quotas per API key
+type accessControl struct {
+	allowedMethods []string
+	deniedMethods  []string
+	methodLimiters map[string]*rate.Limiter
+	apiKeys        []*apiKeyQuota
+	apiKeyRequired bool
+}
+
+func newAccessControl(cfg AccessControlConfig) *accessControl {
+	a := &accessControl{
+		allowedMethods: cfg.AllowedMethods,
+		deniedMethods:  cfg.DeniedMethods,
+		methodLimiters: make(map[string]*rate.Limiter, len(cfg.MethodRateLimits)),
+		apiKeys:        make([]*apiKeyQuota, 0, len(cfg.APIKeys)),
+		apiKeyRequired: cfg.APIKeyRequired,
+	}
+	for _, methodRateLimit := range cfg.MethodRateLimits {
+		a.methodLimiters[methodRateLimit.Method] = newLimiter(methodRateLimit.MaxRequestsPerSecond)
+	}
+	for _, apiKey := range cfg.APIKeys {
+		a.apiKeys = append(a.apiKeys, &apiKeyQuota{
+			key:     []byte(apiKey.Key),
+			name:    apiKey.Name,
+			limiter: newLimiter(apiKey.MaxRequestsPerSecond),
+		})
+	}
+	return a
+}
+
+// newLimiter returns a limiter that allows up to maxRequestsPerSecond requests per second,
+// zero means no limit
+func newLimiter(maxRequestsPerSecond float64) *rate.Limiter {
+	if maxRequestsPerSecond <= 0 {
+		return rate.NewLimiter(rate.Inf, 0)
+	}
+	return rate.NewLimiter(rate.Limit(maxRequestsPerSecond), int(math.Ceil(maxRequestsPerSecond)))
+}
+
+// check returns an error if the request to the method is not allowed
+func (a *accessControl) check(method string, httpRequest *http.Request) types.Error {
+	if a == nil {
+		return nil
+	}
+
+	if matchesAnyMethod(method, a.deniedMethods) || (len(a.allowedMethods) > 0 && !matchesAnyMethod(method, a.allowedMethods)) {
+		return types.NewRPCError(types.NotFoundErrorCode, "the method %s does not exist/is not available", method)
+	}
+
+	if len(a.apiKeys) > 0 {
+		quota := a.getAPIKeyQuota(httpRequest)
+		if quota == nil && a.apiKeyRequired {
+			return types.NewRPCError(types.InvalidRequestErrorCode, "missing or invalid API key")
+		} else if quota != nil && !quota.limiter.Allow() {
+			return types.NewRPCError(types.LimitExceededErrorCode, "requests quota exceeded for API key %s", quota.name)
+		}
+	}
+
+	if limiter, found := a.methodLimiters[method]; found && !limiter.Allow() {
+		return types.NewRPCError(types.LimitExceededErrorCode, "rate limit exceeded for method %s", method)
+	}
+	return nil
+}
+
+// getAPIKeyQuota returns the quota of the API key sent in the request header, nil if
+// the request has no API key or it's not configured
+func (a *accessControl) getAPIKeyQuota(httpRequest *http.Request) *apiKeyQuota {
+	if httpRequest == nil {
+		return nil
+	}
+	apiKey := httpRequest.Header.Get(apiKeyHeader)
+	if apiKey == "" {
+		return nil
+	}
+	for _, quota := range a.apiKeys {
+		if subtle.ConstantTimeCompare([]byte(apiKey), quota.key) == 1 {
+			return quota
+		}
+	}
+	return nil
+}
+
+// matchesAnyMethod checks if the method is one of the methods or matches one of
+// the namespace wildcards, for example eth_*
+func matchesAnyMethod(method string, methods []string) bool {
+	for _, m := range methods {
+		if m == method || (strings.HasSuffix(m, methodWildcard) && strings.HasPrefix(method, strings.TrimSuffix(m, "*"))) {
+			return true
+		}
+	}
+	return false
+}
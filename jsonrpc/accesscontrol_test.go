@@ -0,0 +1,110 @@
+package jsonrpc
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/0xPolygonHermez/zkevm-node/jsonrpc/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAccessControl(t *testing.T) {
+	newHttpRequest := func(apiKey string) *http.Request {
+		req, err := http.NewRequest(http.MethodPost, "http://localhost", nil)
+		require.NoError(t, err)
+		if apiKey != "" {
+			req.Header.Set(apiKeyHeader, apiKey)
+		}
+		return req
+	}
+
+	type call struct {
+		method            string
+		apiKey            string
+		expectedErrorCode int
+	}
+
+	testCases := []struct {
+		name  string
+		cfg   AccessControlConfig
+		calls []call
+	}{
+		{
+			name: "no restrictions",
+			calls: []call{
+				{method: "eth_blockNumber"},
+				{method: "debug_traceTransaction"},
+			},
+		},
+		{
+			name: "allowed and denied methods",
+			cfg: AccessControlConfig{
+				AllowedMethods: []string{"eth_*", "net_version"},
+				DeniedMethods:  []string{"eth_getLogs"},
+			},
+			calls: []call{
+				{method: "eth_blockNumber"},
+				{method: "net_version"},
+				{method: "eth_getLogs", expectedErrorCode: types.NotFoundErrorCode},
+				{method: "net_listening", expectedErrorCode: types.NotFoundErrorCode},
+				{method: "debug_traceTransaction", expectedErrorCode: types.NotFoundErrorCode},
+			},
+		},
+		{
+			name: "method rate limit",
+			cfg: AccessControlConfig{
+				MethodRateLimits: []MethodRateLimit{{Method: "eth_call", MaxRequestsPerSecond: 1}},
+			},
+			calls: []call{
+				{method: "eth_call"},
+				{method: "eth_call", expectedErrorCode: types.LimitExceededErrorCode},
+				{method: "eth_blockNumber"},
+			},
+		},
+		{
+			name: "api key quotas",
+			cfg: AccessControlConfig{
+				APIKeys: []APIKey{
+					{Name: "limited", Key: "key1", MaxRequestsPerSecond: 1},
+					{Name: "unlimited", Key: "key2"},
+				},
+			},
+			calls: []call{
+				{method: "eth_blockNumber", apiKey: "key1"},
+				{method: "eth_blockNumber", apiKey: "key1", expectedErrorCode: types.LimitExceededErrorCode},
+				{method: "eth_blockNumber", apiKey: "key2"},
+				{method: "eth_blockNumber", apiKey: "key2"},
+				{method: "eth_blockNumber"},
+				{method: "eth_blockNumber", apiKey: "unknown"},
+			},
+		},
+		{
+			name: "api key required",
+			cfg: AccessControlConfig{
+				APIKeys:        []APIKey{{Name: "client", Key: "key1"}},
+				APIKeyRequired: true,
+			},
+			calls: []call{
+				{method: "eth_blockNumber", apiKey: "key1"},
+				{method: "eth_blockNumber", expectedErrorCode: types.InvalidRequestErrorCode},
+				{method: "eth_blockNumber", apiKey: "unknown", expectedErrorCode: types.InvalidRequestErrorCode},
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			a := newAccessControl(testCase.cfg)
+			for _, c := range testCase.calls {
+				err := a.check(c.method, newHttpRequest(c.apiKey))
+				if c.expectedErrorCode == 0 {
+					assert.Nil(t, err, c.method)
+				} else {
+					require.NotNil(t, err, c.method)
+					assert.Equal(t, c.expectedErrorCode, err.ErrorCode(), c.method)
+				}
+			}
+		})
+	}
+}
@@ -2,6 +2,7 @@ package client
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -28,6 +29,12 @@ func NewClient(url string) *Client {
 // the provided method and parameters, which is compatible with the Ethereum
 // JSON RPC Server.
 func JSONRPCCall(url, method string, parameters ...interface{}) (types.Response, error) {
+	return JSONRPCCallWithContext(context.Background(), url, method, parameters...)
+}
+
+// JSONRPCCallWithContext executes a 2.0 JSON RPC HTTP Post Request as JSONRPCCall,
+// the request is canceled when the provided context is done
+func JSONRPCCallWithContext(ctx context.Context, url, method string, parameters ...interface{}) (types.Response, error) {
 	params, err := json.Marshal(parameters)
 	if err != nil {
 		return types.Response{}, err
@@ -40,7 +47,7 @@ func JSONRPCCall(url, method string, parameters ...interface{}) (types.Response,
 		Params:  params,
 	}
 
-	httpRes, err := sendJSONRPC_HTTPRequest(url, request)
+	httpRes, err := sendJSONRPC_HTTPRequest(ctx, url, request)
 	if err != nil {
 		return types.Response{}, err
 	}
@@ -91,7 +98,7 @@ func JSONRPCBatchCall(url string, calls ...BatchCall) ([]types.Response, error)
 		requests = append(requests, req)
 	}
 
-	httpRes, err := sendJSONRPC_HTTPRequest(url, requests)
+	httpRes, err := sendJSONRPC_HTTPRequest(context.Background(), url, requests)
 	if err != nil {
 		return nil, err
 	}
@@ -116,14 +123,14 @@ func JSONRPCBatchCall(url string, calls ...BatchCall) ([]types.Response, error)
 	return res, nil
 }
 
-func sendJSONRPC_HTTPRequest(url string, payload interface{}) (*http.Response, error) {
+func sendJSONRPC_HTTPRequest(ctx context.Context, url string, payload interface{}) (*http.Response, error) {
 	reqBody, err := json.Marshal(payload)
 	if err != nil {
 		return nil, err
 	}
 
 	reqBodyReader := bytes.NewReader(reqBody)
-	httpReq, err := http.NewRequest(http.MethodPost, url, reqBodyReader)
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, url, reqBodyReader)
 	if err != nil {
 		return nil, err
 	}
@@ -21,6 +21,24 @@ type Config struct {
 	// check net/http.server.WriteTimeout
 	WriteTimeout types.Duration `mapstructure:"WriteTimeout"`
 
+	// IdleTimeout is the max time to wait for the next request on keep-alive connections,
+	// if zero the ReadTimeout is used. check net/http.server.IdleTimeout
+	IdleTimeout types.Duration `mapstructure:"IdleTimeout"`
+
+	// CORSAllowedOrigins is the list of origins allowed to send cross-origin requests to the HTTP
+	// and WebSocket servers, if empty or if it contains * all the origins are allowed
+	CORSAllowedOrigins []string `mapstructure:"CORSAllowedOrigins"`
+
+	// EnableHttpCompression compresses with gzip the HTTP responses of the clients that accept it
+	EnableHttpCompression bool `mapstructure:"EnableHttpCompression"`
+
+	// TLSCertFile is the path of the certificate file used to serve the HTTP and WebSocket
+	// requests over TLS, which also enables HTTP/2. TLS is disabled if it's empty
+	TLSCertFile string `mapstructure:"TLSCertFile"`
+
+	// TLSKeyFile is the path of the private key file of the TLS certificate
+	TLSKeyFile string `mapstructure:"TLSKeyFile"`
+
 	// MaxRequestsPerIPAndSecond defines how much requests a single IP can
 	// send within a single second
 	MaxRequestsPerIPAndSecond float64 `mapstructure:"MaxRequestsPerIPAndSecond"`
@@ -59,14 +77,100 @@ type Config struct {
 	// native block hashes in a single call to the state, if zero it means no limit
 	MaxNativeBlockHashBlockRange uint64 `mapstructure:"MaxNativeBlockHashBlockRange"`
 
+	// MaxTraceBlockRange is a configuration to set the max range for block number when tracing
+	// the txs of a block range with debug_traceBlockRange, if zero it means no limit
+	MaxTraceBlockRange uint64 `mapstructure:"MaxTraceBlockRange"`
+
+	// MaxFilters is the max number of filters that can be installed at the same time,
+	// if zero it means no limit
+	MaxFilters uint64 `mapstructure:"MaxFilters"`
+
+	// MaxFiltersPerClient is the max number of filters that can be installed at the same time
+	// by the same client, identified by its IP, if zero it means no limit
+	MaxFiltersPerClient uint64 `mapstructure:"MaxFiltersPerClient"`
+
+	// MaxTxPoolTxs is the max number of pending txs loaded from the pool by the txpool endpoints,
+	// the txs with the highest gas price are kept. If zero it means no limit
+	MaxTxPoolTxs uint64 `mapstructure:"MaxTxPoolTxs"`
+
+	// FilterTimeout is the time after which the filters installed via HTTP that are not
+	// polled with eth_getFilterChanges are uninstalled, if zero they are never uninstalled
+	FilterTimeout types.Duration `mapstructure:"FilterTimeout"`
+
 	// EnableHttpLog allows the user to enable or disable the logs related to the HTTP
 	// requests to be captured by the server.
 	EnableHttpLog bool `mapstructure:"EnableHttpLog"`
 
+	// LocalTxsAPIKeys is the list of privileged API keys. The txs sent to eth_sendRawTransaction with one of
+	// these keys in the X-API-Key header are added to the pool as local txs, so they are exempted from the
+	// gas price floors and are never evicted from the pool
+	LocalTxsAPIKeys []string `mapstructure:"LocalTxsAPIKeys"`
+
+	// ResponseCacheMaxBytesSize is the memory budget in bytes of the cache of the responses that
+	// don't change, like the blocks and txs by hash or the eth_call on a fixed block. The responses
+	// of a block are only cached once the block is virtualized. 0 disables the cache
+	ResponseCacheMaxBytesSize uint64 `mapstructure:"ResponseCacheMaxBytesSize"`
+
+	// ResponseCacheTTL is the time the responses are kept in the cache
+	ResponseCacheTTL types.Duration `mapstructure:"ResponseCacheTTL"`
+
+	// SlowRequestThreshold is the duration above which the requests are logged as slow requests,
+	// along with their method and params. 0 disables the log
+	SlowRequestThreshold types.Duration `mapstructure:"SlowRequestThreshold"`
+
+	// AccessControl defines the methods that are served and the rate limits per method and per API key
+	AccessControl AccessControlConfig `mapstructure:"AccessControl"`
+
 	// ZKCountersLimits defines the ZK Counter limits
 	ZKCountersLimits ZKCountersLimits
 }
 
+// AccessControlConfig has parameters to restrict the access to the JSON-RPC methods
+type AccessControlConfig struct {
+	// AllowedMethods is the list of methods that are served, if empty all the methods are served.
+	// All the methods of a namespace can be set with a wildcard, for example eth_*
+	AllowedMethods []string `mapstructure:"AllowedMethods"`
+
+	// DeniedMethods is the list of methods that are never served, it takes precedence over
+	// AllowedMethods and also supports namespace wildcards
+	DeniedMethods []string `mapstructure:"DeniedMethods"`
+
+	// MethodRateLimits defines the max number of requests per second of each method
+	// across all the clients
+	MethodRateLimits []MethodRateLimit `mapstructure:"MethodRateLimits"`
+
+	// APIKeys is the list of API keys the clients can send in the X-API-Key header,
+	// each one with its own quota of requests
+	APIKeys []APIKey `mapstructure:"APIKeys"`
+
+	// APIKeyRequired rejects the requests without one of the APIKeys, it only applies
+	// when APIKeys is not empty
+	APIKeyRequired bool `mapstructure:"APIKeyRequired"`
+}
+
+// MethodRateLimit defines the rate limit of a JSON-RPC method
+type MethodRateLimit struct {
+	// Method is the name of the method, for example eth_getLogs
+	Method string `mapstructure:"Method"`
+
+	// MaxRequestsPerSecond is the max number of requests per second to the method,
+	// if zero it means no limit
+	MaxRequestsPerSecond float64 `mapstructure:"MaxRequestsPerSecond"`
+}
+
+// APIKey defines an API key and its quota of requests
+type APIKey struct {
+	// Name identifies the API key in the error messages
+	Name string `mapstructure:"Name"`
+
+	// Key is the value of the API key
+	Key string `mapstructure:"Key"`
+
+	// MaxRequestsPerSecond is the max number of requests per second with the API key,
+	// if zero it means no limit
+	MaxRequestsPerSecond float64 `mapstructure:"MaxRequestsPerSecond"`
+}
+
 // ZKCountersLimits defines the ZK Counter limits
 type ZKCountersLimits struct {
 	MaxKeccakHashes     uint32
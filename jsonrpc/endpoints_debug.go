@@ -12,9 +12,11 @@ import (
 
 	"github.com/0xPolygonHermez/zkevm-node/jsonrpc/types"
 	"github.com/0xPolygonHermez/zkevm-node/log"
+	"github.com/0xPolygonHermez/zkevm-node/sequencer"
 	"github.com/0xPolygonHermez/zkevm-node/state"
 	"github.com/ethereum/go-ethereum/common"
 	ethTypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/gorilla/websocket"
 	"github.com/jackc/pgx/v4"
 )
 
@@ -28,18 +30,20 @@ var defaultTraceConfig = &traceConfig{
 
 // DebugEndpoints is the debug jsonrpc endpoint
 type DebugEndpoints struct {
-	cfg      Config
-	state    types.StateInterface
-	etherman types.EthermanInterface
-	txMan    DBTxManager
+	cfg                Config
+	state              types.StateInterface
+	etherman           types.EthermanInterface
+	selectionSimulator selectionSimulatorInterface
+	txMan              DBTxManager
 }
 
 // NewDebugEndpoints returns DebugEndpoints
-func NewDebugEndpoints(cfg Config, state types.StateInterface, etherman types.EthermanInterface) *DebugEndpoints {
+func NewDebugEndpoints(cfg Config, state types.StateInterface, etherman types.EthermanInterface, selectionSimulator selectionSimulatorInterface) *DebugEndpoints {
 	return &DebugEndpoints{
-		cfg:      cfg,
-		state:    state,
-		etherman: etherman,
+		cfg:                cfg,
+		state:              state,
+		etherman:           etherman,
+		selectionSimulator: selectionSimulator,
 	}
 }
 
@@ -52,6 +56,26 @@ type traceConfig struct {
 	TracerConfig     json.RawMessage `json:"tracerConfig"`
 }
 
+type simulateSelectionConfig struct {
+	TxSorterType  string          `json:"txSorterType"`
+	SelectionMode string          `json:"selectionMode"`
+	Limit         types.ArgUint64 `json:"limit"`
+}
+
+type simulateSelectionResponse struct {
+	Txs          []simulatedTxResponse `json:"txs"`
+	EstimatedGas types.ArgUint64       `json:"estimatedGas"`
+	EstimatedFee types.ArgBig          `json:"estimatedFee"`
+}
+
+type simulatedTxResponse struct {
+	Hash     common.Hash     `json:"hash"`
+	From     common.Address  `json:"from"`
+	Nonce    types.ArgUint64 `json:"nonce"`
+	GasPrice types.ArgBig    `json:"gasPrice"`
+	GasUsed  types.ArgUint64 `json:"gasUsed"`
+}
+
 type traceBlockTransactionResponse struct {
 	Result interface{} `json:"result"`
 }
@@ -61,6 +85,12 @@ type traceBatchTransactionResponse struct {
 	Result interface{} `json:"result"`
 }
 
+type traceBlockRangeBlockResponse struct {
+	BlockNumber types.ArgUint64                 `json:"blockNumber"`
+	BlockHash   common.Hash                     `json:"blockHash"`
+	Traces      []traceBatchTransactionResponse `json:"traces"`
+}
+
 // TraceTransaction creates a response for debug_traceTransaction request.
 // See https://geth.ethereum.org/docs/interacting-with-geth/rpc/ns-debug#debugtracetransaction
 func (d *DebugEndpoints) TraceTransaction(hash types.ArgHash, cfg *traceConfig) (interface{}, types.Error) {
@@ -114,6 +144,117 @@ func (d *DebugEndpoints) TraceBlockByHash(hash types.ArgHash, cfg *traceConfig)
 	})
 }
 
+// TraceBlockRange creates a response for debug_traceBlockRange request.
+// It traces all the transactions of the blocks from fromBlock to toBlock, both
+// included, block by block and each block in its own db tx, so the db txs are
+// not kept open while the whole range is traced. The size of the range is
+// limited by the MaxTraceBlockRange configuration.
+//
+// When the request is received via web sockets, the traces of each block are
+// streamed in a debug_subscription notification as soon as the block is traced
+// and the response is the subscription id of the notifications. Via http, the
+// response contains the traces of all the blocks.
+func (d *DebugEndpoints) TraceBlockRange(wsConn *concurrentWsConn, fromBlock types.BlockNumber, toBlock types.BlockNumber, cfg *traceConfig) (interface{}, types.Error) {
+	type blockRange struct {
+		from, to uint64
+	}
+	res, rpcErr := d.txMan.NewDbTxScope(d.state, func(ctx context.Context, dbTx pgx.Tx) (interface{}, types.Error) {
+		fromBlockNumber, toBlockNumber, rpcErr := getNumericBlockNumbers(ctx, d.state, d.etherman, &fromBlock, &toBlock, d.cfg.MaxTraceBlockRange, state.ErrMaxTraceBlockRangeLimitExceeded, dbTx)
+		if rpcErr != nil {
+			return nil, rpcErr
+		}
+		return blockRange{from: fromBlockNumber, to: toBlockNumber}, nil
+	})
+	if rpcErr != nil {
+		return nil, rpcErr
+	}
+	r := res.(blockRange)
+
+	var subscriptionID string
+	if wsConn != nil {
+		id, err := generateSubscriptionID()
+		if err != nil {
+			return RPCErrorResponse(types.DefaultErrorCode, "failed to generate the subscription id", err, true)
+		}
+		subscriptionID = id
+	}
+
+	blocks := []traceBlockRangeBlockResponse{}
+	for blockNumber := r.from; blockNumber <= r.to; blockNumber++ {
+		block, rpcErr := d.traceBlockOfRange(blockNumber, cfg)
+		if rpcErr != nil {
+			return nil, rpcErr
+		}
+
+		if wsConn == nil {
+			blocks = append(blocks, block)
+		} else if err := sendTraceBlockRangeNotification(wsConn, subscriptionID, block); err != nil {
+			return RPCErrorResponse(types.DefaultErrorCode, fmt.Sprintf("failed to send the traces of block #%d", blockNumber), err, true)
+		}
+	}
+
+	if wsConn != nil {
+		return subscriptionID, nil
+	}
+	return blocks, nil
+}
+
+// traceBlockOfRange traces all the transactions of a block of debug_traceBlockRange in its own db tx
+func (d *DebugEndpoints) traceBlockOfRange(blockNumber uint64, cfg *traceConfig) (traceBlockRangeBlockResponse, types.Error) {
+	res, rpcErr := d.txMan.NewDbTxScope(d.state, func(ctx context.Context, dbTx pgx.Tx) (interface{}, types.Error) {
+		block, err := d.state.GetL2BlockByNumber(ctx, blockNumber, dbTx)
+		if errors.Is(err, state.ErrNotFound) {
+			return nil, types.NewRPCError(types.DefaultErrorCode, fmt.Sprintf("block #%d not found", blockNumber))
+		} else if err != nil {
+			return RPCErrorResponse(types.DefaultErrorCode, "failed to get block by number", err, true)
+		}
+
+		traces := make([]traceBatchTransactionResponse, 0, len(block.Transactions()))
+		for _, tx := range block.Transactions() {
+			trace, rpcErr := d.buildTraceTransaction(ctx, tx.Hash(), cfg, dbTx)
+			if rpcErr != nil {
+				errMsg := fmt.Sprintf("failed to get trace for transaction %v: %v", tx.Hash().String(), rpcErr.Error())
+				return RPCErrorResponse(types.DefaultErrorCode, errMsg, rpcErr, true)
+			}
+			traces = append(traces, traceBatchTransactionResponse{
+				TxHash: tx.Hash(),
+				Result: trace,
+			})
+		}
+
+		return traceBlockRangeBlockResponse{
+			BlockNumber: types.ArgUint64(blockNumber),
+			BlockHash:   block.Hash(),
+			Traces:      traces,
+		}, nil
+	})
+	if rpcErr != nil {
+		return traceBlockRangeBlockResponse{}, rpcErr
+	}
+	return res.(traceBlockRangeBlockResponse), nil
+}
+
+// sendTraceBlockRangeNotification sends the traces of a block of debug_traceBlockRange
+// in a debug_subscription notification via the web socket connection
+func sendTraceBlockRangeNotification(wsConn *concurrentWsConn, subscriptionID string, block traceBlockRangeBlockResponse) error {
+	data, err := json.Marshal(block)
+	if err != nil {
+		return err
+	}
+	message, err := types.SubscriptionResponse{
+		JSONRPC: "2.0",
+		Method:  "debug_subscription",
+		Params: types.SubscriptionResponseParams{
+			Subscription: subscriptionID,
+			Result:       data,
+		},
+	}.Bytes()
+	if err != nil {
+		return err
+	}
+	return wsConn.WriteMessage(websocket.TextMessage, message)
+}
+
 // TraceBatchByNumber creates a response for debug_traceBatchByNumber request.
 // this endpoint tries to help clients to get traces at once for all the transactions
 // attached to the same batch.
@@ -253,6 +394,41 @@ func (d *DebugEndpoints) TraceBatchByNumber(httpRequest *http.Request, number ty
 	})
 }
 
+// SimulateSelection creates a response for debug_simulateSelection request. It simulates the selection of the
+// pending txs of the pool by the sequencer for an empty batch, in selection order, without modifying the pool.
+// The tx sorter type and the selection mode of the sequencer config can be overridden to compare them
+func (d *DebugEndpoints) SimulateSelection(cfg *simulateSelectionConfig) (interface{}, types.Error) {
+	if d.selectionSimulator == nil {
+		return RPCErrorResponse(types.DefaultErrorCode, "the selection simulation is not available", nil, false)
+	}
+	if cfg == nil {
+		cfg = &simulateSelectionConfig{}
+	}
+
+	simulation, err := d.selectionSimulator.SimulateSelection(context.Background(), cfg.TxSorterType, cfg.SelectionMode, uint64(cfg.Limit))
+	if errors.Is(err, sequencer.ErrUnknownTxSorter) || errors.Is(err, sequencer.ErrUnknownSelectionMode) {
+		return RPCErrorResponse(types.InvalidParamsErrorCode, err.Error(), nil, false)
+	} else if err != nil {
+		return RPCErrorResponse(types.DefaultErrorCode, "failed to simulate the selection", err, true)
+	}
+
+	res := simulateSelectionResponse{
+		Txs:          make([]simulatedTxResponse, 0, len(simulation.Txs)),
+		EstimatedGas: types.ArgUint64(simulation.EstimatedGas),
+		EstimatedFee: types.ArgBig(*simulation.EstimatedFee),
+	}
+	for _, tx := range simulation.Txs {
+		res.Txs = append(res.Txs, simulatedTxResponse{
+			Hash:     tx.Hash,
+			From:     tx.From,
+			Nonce:    types.ArgUint64(tx.Nonce),
+			GasPrice: types.ArgBig(*tx.GasPrice),
+			GasUsed:  types.ArgUint64(tx.UsedZKCounters.GasUsed),
+		})
+	}
+	return res, nil
+}
+
 func (d *DebugEndpoints) buildTraceBlock(ctx context.Context, txs []*ethTypes.Transaction, cfg *traceConfig, dbTx pgx.Tx) (interface{}, types.Error) {
 	traces := []traceBlockTransactionResponse{}
 	for _, tx := range txs {
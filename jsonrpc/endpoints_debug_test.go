@@ -0,0 +1,266 @@
+package jsonrpc
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"testing"
+
+	"github.com/0xPolygonHermez/zkevm-node/jsonrpc/types"
+	"github.com/0xPolygonHermez/zkevm-node/sequencer"
+	"github.com/0xPolygonHermez/zkevm-node/state"
+	"github.com/0xPolygonHermez/zkevm-node/state/runtime"
+	"github.com/ethereum/go-ethereum/common"
+	ethTypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/trie"
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type selectionSimulatorStub struct {
+	simulation    sequencer.SelectionSimulation
+	err           error
+	txSorterType  string
+	selectionMode string
+	limit         uint64
+}
+
+func (s *selectionSimulatorStub) SimulateSelection(ctx context.Context, txSorterType string, selectionMode string, limit uint64) (sequencer.SelectionSimulation, error) {
+	s.txSorterType, s.selectionMode, s.limit = txSorterType, selectionMode, limit
+	return s.simulation, s.err
+}
+
+func TestSimulateSelection(t *testing.T) {
+	simulator := &selectionSimulatorStub{
+		simulation: sequencer.SelectionSimulation{
+			Txs: []*sequencer.TxTracker{{
+				Hash:           common.HexToHash("0x1"),
+				From:           common.HexToAddress("0x2"),
+				Nonce:          3,
+				GasPrice:       big.NewInt(4),
+				UsedZKCounters: state.ZKCounters{GasUsed: 5},
+			}},
+			EstimatedGas: 5,
+			EstimatedFee: big.NewInt(20),
+		},
+	}
+	d := NewDebugEndpoints(Config{}, nil, nil, simulator)
+
+	res, rpcErr := d.SimulateSelection(&simulateSelectionConfig{TxSorterType: sequencer.TxSorterTypeReceivedTime, SelectionMode: sequencer.SelectionModeKnapsack, Limit: 100})
+	require.Nil(t, rpcErr)
+	assert.Equal(t, sequencer.TxSorterTypeReceivedTime, simulator.txSorterType)
+	assert.Equal(t, sequencer.SelectionModeKnapsack, simulator.selectionMode)
+	assert.Equal(t, uint64(100), simulator.limit)
+
+	b, err := json.Marshal(res)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"txs":[{"hash":"0x0000000000000000000000000000000000000000000000000000000000000001",
+		"from":"0x0000000000000000000000000000000000000002","nonce":"0x3","gasPrice":"0x4","gasUsed":"0x5"}],
+		"estimatedGas":"0x5","estimatedFee":"0x14"}`, string(b))
+
+	// the config is optional
+	_, rpcErr = d.SimulateSelection(nil)
+	require.Nil(t, rpcErr)
+	assert.Equal(t, "", simulator.txSorterType)
+	assert.Equal(t, uint64(0), simulator.limit)
+
+	simulator.err = sequencer.ErrUnknownTxSorter
+	_, rpcErr = d.SimulateSelection(&simulateSelectionConfig{TxSorterType: "unknown"})
+	require.NotNil(t, rpcErr)
+	assert.Equal(t, types.InvalidParamsErrorCode, rpcErr.ErrorCode())
+
+	_, rpcErr = NewDebugEndpoints(Config{}, nil, nil, nil).SimulateSelection(nil)
+	require.NotNil(t, rpcErr)
+	assert.Equal(t, types.DefaultErrorCode, rpcErr.ErrorCode())
+}
+
+// newTraceBlockRangeBlocks returns the blocks from 1 to 2 traced by the tests of debug_traceBlockRange,
+// the first one with one tx and the second one with two txs
+func newTraceBlockRangeBlocks() []*state.L2Block {
+	blocks := make([]*state.L2Block, 0, 2)
+	nonce := uint64(0)
+	for blockNumber, txsCount := range []int{1, 2} {
+		txs := make([]*ethTypes.Transaction, 0, txsCount)
+		receipts := make([]*ethTypes.Receipt, 0, txsCount)
+		for i := 0; i < txsCount; i++ {
+			tx := ethTypes.NewTransaction(nonce, common.HexToAddress("0x1"), big.NewInt(0), 21000, big.NewInt(1), nil)
+			txs = append(txs, tx)
+			receipts = append(receipts, &ethTypes.Receipt{TxHash: tx.Hash()})
+			nonce++
+		}
+		header := state.NewL2Header(&ethTypes.Header{Number: big.NewInt(int64(blockNumber + 1))})
+		blocks = append(blocks, state.NewL2Block(header, txs, nil, receipts, trie.NewStackTrie(nil)))
+	}
+	return blocks
+}
+
+// setupTraceBlockRangeMocks mocks the db txs of debug_traceBlockRange, one to get the range and one per block,
+// and the traces of the txs of the blocks
+func setupTraceBlockRangeMocks(m *mocksWrapper, blocks []*state.L2Block) {
+	m.State.On("BeginStateTransaction", context.Background()).Return(m.DbTx, nil).Times(len(blocks) + 1)
+	m.DbTx.On("Commit", context.Background()).Return(nil).Times(len(blocks) + 1)
+	for _, block := range blocks {
+		m.State.On("GetL2BlockByNumber", context.Background(), block.NumberU64(), m.DbTx).Return(block, nil).Once()
+		for _, tx := range block.Transactions() {
+			m.State.
+				On("DebugTransaction", context.Background(), tx.Hash(), mock.Anything, m.DbTx).
+				Return(&runtime.ExecutionResult{TraceResult: json.RawMessage(fmt.Sprintf(`{"tx":"%v"}`, tx.Hash().String()))}, nil).
+				Once()
+		}
+	}
+}
+
+// assertTraceBlockRangeBlock checks the traces of a block returned by debug_traceBlockRange
+func assertTraceBlockRangeBlock(t *testing.T, block *state.L2Block, data []byte) {
+	var res struct {
+		BlockNumber types.ArgUint64 `json:"blockNumber"`
+		BlockHash   common.Hash     `json:"blockHash"`
+		Traces      []struct {
+			TxHash common.Hash     `json:"txHash"`
+			Result json.RawMessage `json:"result"`
+		} `json:"traces"`
+	}
+	require.NoError(t, json.Unmarshal(data, &res))
+	assert.Equal(t, types.ArgUint64(block.NumberU64()), res.BlockNumber)
+	assert.Equal(t, block.Hash(), res.BlockHash)
+	require.Len(t, res.Traces, len(block.Transactions()))
+	for i, tx := range block.Transactions() {
+		assert.Equal(t, tx.Hash(), res.Traces[i].TxHash)
+		assert.JSONEq(t, fmt.Sprintf(`{"tx":"%v"}`, tx.Hash().String()), string(res.Traces[i].Result))
+	}
+}
+
+func TestTraceBlockRange(t *testing.T) {
+	cfg := getSequencerDefaultConfig()
+	cfg.MaxTraceBlockRange = 2
+	s, m, _ := newMockedServerWithCustomConfig(t, cfg)
+	defer s.Stop()
+
+	blocks := newTraceBlockRangeBlocks()
+
+	type testCase struct {
+		name          string
+		fromBlock     string
+		toBlock       string
+		expectedErr   *types.RPCError
+		setupMocks    func(m *mocksWrapper)
+		expectsBlocks []*state.L2Block
+	}
+
+	testCases := []testCase{
+		{
+			name:          "trace the txs of the blocks",
+			fromBlock:     "0x1",
+			toBlock:       "0x2",
+			setupMocks:    func(m *mocksWrapper) { setupTraceBlockRangeMocks(m, blocks) },
+			expectsBlocks: blocks,
+		},
+		{
+			name:        "range over the limit",
+			fromBlock:   "0x1",
+			toBlock:     "0x4",
+			expectedErr: types.NewRPCError(types.InvalidParamsErrorCode, "traces are limited to a 2 block range"),
+			setupMocks: func(m *mocksWrapper) {
+				m.State.On("BeginStateTransaction", context.Background()).Return(m.DbTx, nil).Once()
+				m.DbTx.On("Rollback", context.Background()).Return(nil).Once()
+			},
+		},
+		{
+			name:        "block not found",
+			fromBlock:   "0x1",
+			toBlock:     "0x3",
+			expectedErr: types.NewRPCError(types.DefaultErrorCode, "block #3 not found"),
+			setupMocks: func(m *mocksWrapper) {
+				setupTraceBlockRangeMocks(m, blocks)
+				m.State.On("BeginStateTransaction", context.Background()).Return(m.DbTx, nil).Once()
+				m.State.On("GetL2BlockByNumber", context.Background(), uint64(3), m.DbTx).Return(nil, state.ErrNotFound).Once()
+				m.DbTx.On("Rollback", context.Background()).Return(nil).Once()
+			},
+		},
+		{
+			name:        "failed to trace a tx",
+			fromBlock:   "0x2",
+			toBlock:     "0x2",
+			expectedErr: types.NewRPCError(types.DefaultErrorCode, fmt.Sprintf("failed to get trace for transaction %v: transaction not found", blocks[1].Transactions()[0].Hash().String())),
+			setupMocks: func(m *mocksWrapper) {
+				m.State.On("BeginStateTransaction", context.Background()).Return(m.DbTx, nil).Twice()
+				m.DbTx.On("Commit", context.Background()).Return(nil).Once()
+				m.State.On("GetL2BlockByNumber", context.Background(), uint64(2), m.DbTx).Return(blocks[1], nil).Once()
+				m.State.On("DebugTransaction", context.Background(), blocks[1].Transactions()[0].Hash(), mock.Anything, m.DbTx).Return(nil, state.ErrNotFound).Once()
+				m.DbTx.On("Rollback", context.Background()).Return(nil).Once()
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.setupMocks(m)
+
+			res, err := s.JSONRPCCall("debug_traceBlockRange", tc.fromBlock, tc.toBlock)
+			require.NoError(t, err)
+
+			if tc.expectedErr != nil {
+				require.NotNil(t, res.Error)
+				assert.Equal(t, tc.expectedErr.ErrorCode(), res.Error.Code)
+				assert.Equal(t, tc.expectedErr.Error(), res.Error.Message)
+				return
+			}
+			require.Nil(t, res.Error)
+
+			var tracedBlocks []json.RawMessage
+			require.NoError(t, json.Unmarshal(res.Result, &tracedBlocks))
+			require.Len(t, tracedBlocks, len(tc.expectsBlocks))
+			for i, block := range tc.expectsBlocks {
+				assertTraceBlockRangeBlock(t, block, tracedBlocks[i])
+			}
+		})
+	}
+}
+
+func TestTraceBlockRangeWebSockets(t *testing.T) {
+	s, m, _ := newSequencerMockedServer(t)
+	defer s.Stop()
+
+	blocks := newTraceBlockRangeBlocks()
+	setupTraceBlockRangeMocks(m, blocks)
+	m.Storage.On("UninstallFilterByWSConn", mock.IsType(&concurrentWsConn{})).Return(nil).Maybe()
+
+	wsConn, _, err := websocket.DefaultDialer.Dial(s.ServerWebSocketsURL, nil)
+	require.NoError(t, err)
+	defer wsConn.Close()
+
+	req := types.Request{JSONRPC: "2.0", ID: float64(1), Method: "debug_traceBlockRange", Params: json.RawMessage(`["0x1","0x2"]`)}
+	require.NoError(t, wsConn.WriteJSON(req))
+
+	// the traces of each block are streamed before the response
+	subscriptionIDs := map[string]struct{}{}
+	for _, block := range blocks {
+		var notification types.SubscriptionResponse
+		require.NoError(t, wsConn.ReadJSON(&notification))
+		assert.Equal(t, "debug_subscription", notification.Method)
+		assertTraceBlockRangeBlock(t, block, notification.Params.Result)
+		subscriptionIDs[notification.Params.Subscription] = struct{}{}
+	}
+	require.Len(t, subscriptionIDs, 1)
+
+	var res types.Response
+	require.NoError(t, wsConn.ReadJSON(&res))
+	require.Nil(t, res.Error)
+	var subscriptionID string
+	require.NoError(t, json.Unmarshal(res.Result, &subscriptionID))
+	assert.Contains(t, subscriptionIDs, subscriptionID)
+}
+
+func TestSubscribeViaHttp(t *testing.T) {
+	s, _, _ := newSequencerMockedServer(t)
+	defer s.Stop()
+
+	res, err := s.JSONRPCCall("eth_subscribe", "newHeads")
+	require.NoError(t, err)
+	require.NotNil(t, res.Error)
+	assert.Equal(t, types.DefaultErrorCode, res.Error.Code)
+	assert.Equal(t, notificationsNotSupportedErrorMsg, res.Error.Message)
+}
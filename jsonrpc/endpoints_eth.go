@@ -2,11 +2,14 @@ package jsonrpc
 
 import (
 	"context"
+	"crypto/subtle"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"math/big"
+	"net"
 	"net/http"
+	"sort"
 	"strings"
 	"sync"
 	"time"
@@ -21,12 +24,22 @@ import (
 	"github.com/0xPolygonHermez/zkevm-node/state/runtime/executor"
 	"github.com/ethereum/go-ethereum/common"
 	ethTypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/trie"
 	"github.com/jackc/pgx/v4"
 )
 
 const (
 	// maxTopics is the max number of topics a log can have
 	maxTopics = 4
+
+	// pendingTxsCheckInterval is the interval to check the new pending txs
+	// to notify to the pending tx subscriptions
+	pendingTxsCheckInterval = time.Second
+
+	// maxFeeHistoryBlockCount is the max number of blocks returned by eth_feeHistory
+	maxFeeHistoryBlockCount = 1024
+	// maxFeeHistoryRewardPercentiles is the max number of reward percentiles of eth_feeHistory
+	maxFeeHistoryRewardPercentiles = 100
 )
 
 // EthEndpoints contains implementations for the "eth" RPC endpoints
@@ -38,6 +51,10 @@ type EthEndpoints struct {
 	etherman types.EthermanInterface
 	storage  storageInterface
 	txMan    DBTxManager
+
+	// pendingTxsMonitor starts the monitor of the pending txs once the
+	// first pending tx filter with a web socket connection is created
+	pendingTxsMonitor sync.Once
 }
 
 // NewEthEndpoints creates an new instance of Eth
@@ -64,7 +81,7 @@ func (e *EthEndpoints) BlockNumber() (interface{}, types.Error) {
 // executed contract and potential error.
 // Note, this function doesn't make any changes in the state/blockchain and is
 // useful to execute view/pure methods and retrieve values.
-func (e *EthEndpoints) Call(arg *types.TxArgs, blockArg *types.BlockNumberOrHash) (interface{}, types.Error) {
+func (e *EthEndpoints) Call(arg *types.TxArgs, blockArg *types.BlockNumberOrHash, stateOverride *types.StateOverride) (interface{}, types.Error) {
 	return e.txMan.NewDbTxScope(e.state, func(ctx context.Context, dbTx pgx.Tx) (interface{}, types.Error) {
 		if arg == nil {
 			return RPCErrorResponse(types.InvalidParamsErrorCode, "missing value for required argument 0", nil, false)
@@ -76,10 +93,14 @@ func (e *EthEndpoints) Call(arg *types.TxArgs, blockArg *types.BlockNumberOrHash
 			return nil, respErr
 		}
 		var blockToProcess *uint64
+		var pendingBlock *pool.PendingBlock
 		if blockArg != nil {
 			blockNumArg := blockArg.Number()
 			if blockNumArg != nil && (*blockArg.Number() == types.LatestBlockNumber || *blockArg.Number() == types.PendingBlockNumber) {
 				blockToProcess = nil
+				if *blockNumArg == types.PendingBlockNumber {
+					pendingBlock = e.getPendingBlock(ctx, block.NumberU64())
+				}
 			} else {
 				n := block.NumberU64()
 				blockToProcess = &n
@@ -97,13 +118,23 @@ func (e *EthEndpoints) Call(arg *types.TxArgs, blockArg *types.BlockNumberOrHash
 			arg.Gas = &gas
 		}
 
+		root := block.Root()
+		if pendingBlock != nil {
+			root = pendingBlock.StateRoot
+		}
+
 		defaultSenderAddress := common.HexToAddress(state.DefaultSenderAddress)
-		sender, tx, err := arg.ToTransaction(ctx, e.state, state.MaxTxGasLimit, block.Root(), defaultSenderAddress, dbTx)
+		sender, tx, err := arg.ToTransaction(ctx, e.state, state.MaxTxGasLimit, root, defaultSenderAddress, dbTx)
 		if err != nil {
 			return RPCErrorResponse(types.DefaultErrorCode, "failed to convert arguments into an unsigned transaction", err, false)
 		}
 
-		result, err := e.state.ProcessUnsignedTransaction(ctx, tx, sender, blockToProcess, true, dbTx)
+		var result *runtime.ExecutionResult
+		if pendingBlock != nil {
+			result, err = e.state.ProcessUnsignedTransactionOnPendingL2Block(ctx, tx, sender, pendingBlock.StateRoot, uint64(pendingBlock.Timestamp.Unix()), true, stateOverride.ToStateOverride(), dbTx)
+		} else {
+			result, err = e.state.ProcessUnsignedTransaction(ctx, tx, sender, blockToProcess, true, stateOverride.ToStateOverride(), dbTx)
+		}
 		if err != nil {
 			errMsg := fmt.Sprintf("failed to execute the unsigned transaction: %v", err.Error())
 			logError := !executor.IsROMOutOfCountersError(executor.RomErrorCode(err)) && !errors.Is(err, runtime.ErrOutOfGas)
@@ -153,13 +184,74 @@ func (e *EthEndpoints) getCoinbaseFromSequencerNode() (interface{}, types.Error)
 	return coinbaseAddress.String(), nil
 }
 
+// CreateAccessList returns the addresses and storage keys accessed by the transaction
+// when it's executed on top of the state of the given block, along with the gas used.
+// The transaction will not be added to the blockchain.
+func (e *EthEndpoints) CreateAccessList(arg *types.TxArgs, blockArg *types.BlockNumberOrHash) (interface{}, types.Error) {
+	return e.txMan.NewDbTxScope(e.state, func(ctx context.Context, dbTx pgx.Tx) (interface{}, types.Error) {
+		if arg == nil {
+			return RPCErrorResponse(types.InvalidParamsErrorCode, "missing value for required argument 0", nil, false)
+		}
+
+		block, respErr := e.getBlockByArg(ctx, blockArg, dbTx)
+		if respErr != nil {
+			return nil, respErr
+		}
+
+		var blockToProcess *uint64
+		if blockArg != nil {
+			blockNumArg := blockArg.Number()
+			if blockNumArg != nil && (*blockArg.Number() == types.LatestBlockNumber || *blockArg.Number() == types.PendingBlockNumber) {
+				blockToProcess = nil
+			} else {
+				n := block.NumberU64()
+				blockToProcess = &n
+			}
+		}
+
+		// If the caller didn't supply the gas limit in the message, then we set it to maximum possible => block gas limit
+		if arg.Gas == nil || uint64(*arg.Gas) <= 0 {
+			header, err := e.state.GetL2BlockHeaderByNumber(ctx, block.NumberU64(), dbTx)
+			if err != nil {
+				return RPCErrorResponse(types.DefaultErrorCode, "failed to get block header", err, true)
+			}
+
+			gas := types.ArgUint64(header.GasLimit)
+			arg.Gas = &gas
+		}
+
+		defaultSenderAddress := common.HexToAddress(state.DefaultSenderAddress)
+		sender, tx, err := arg.ToTransaction(ctx, e.state, state.MaxTxGasLimit, block.Root(), defaultSenderAddress, dbTx)
+		if err != nil {
+			return RPCErrorResponse(types.DefaultErrorCode, "failed to convert arguments into an unsigned transaction", err, false)
+		}
+
+		accessList, result, err := e.state.CreateAccessList(ctx, tx, sender, blockToProcess, dbTx)
+		if err != nil {
+			errMsg := fmt.Sprintf("failed to execute the unsigned transaction: %v", err.Error())
+			logError := !executor.IsROMOutOfCountersError(executor.RomErrorCode(err)) && !errors.Is(err, runtime.ErrOutOfGas)
+			return RPCErrorResponse(types.DefaultErrorCode, errMsg, nil, logError)
+		}
+
+		res := types.AccessListResult{
+			AccessList: accessList,
+			GasUsed:    types.ArgUint64(result.GasUsed),
+		}
+		if result.Failed() {
+			res.Error = result.Err.Error()
+		}
+		return res, nil
+	})
+}
+
 // EstimateGas generates and returns an estimate of how much gas is necessary to
 // allow the transaction to complete.
 // The transaction will not be added to the blockchain.
 // Note that the estimate may be significantly more than the amount of gas actually
 // used by the transaction, for a variety of reasons including EVM mechanics and
 // node performance.
-func (e *EthEndpoints) EstimateGas(arg *types.TxArgs, blockArg *types.BlockNumberOrHash) (interface{}, types.Error) {
+// The accounts of the optional state override are overridden before executing the transaction.
+func (e *EthEndpoints) EstimateGas(arg *types.TxArgs, blockArg *types.BlockNumberOrHash, stateOverride *types.StateOverride) (interface{}, types.Error) {
 	return e.txMan.NewDbTxScope(e.state, func(ctx context.Context, dbTx pgx.Tx) (interface{}, types.Error) {
 		if arg == nil {
 			return RPCErrorResponse(types.InvalidParamsErrorCode, "missing value for required argument 0", nil, false)
@@ -187,7 +279,7 @@ func (e *EthEndpoints) EstimateGas(arg *types.TxArgs, blockArg *types.BlockNumbe
 			return RPCErrorResponse(types.DefaultErrorCode, "failed to convert arguments into an unsigned transaction", err, false)
 		}
 
-		gasEstimation, returnValue, err := e.state.EstimateGas(tx, sender, blockToProcess, dbTx)
+		gasEstimation, returnValue, err := e.state.EstimateGas(tx, sender, blockToProcess, stateOverride.ToStateOverride(), dbTx)
 		if errors.Is(err, runtime.ErrExecutionReverted) {
 			data := make([]byte, len(returnValue))
 			copy(data, returnValue)
@@ -199,6 +291,168 @@ func (e *EthEndpoints) EstimateGas(arg *types.TxArgs, blockArg *types.BlockNumbe
 	})
 }
 
+// FeeHistory returns the gas used ratio and the gas prices paid at the reward percentiles
+// of a range of blocks ending in the newest block. The L2 txs don't pay a base fee, so the
+// base fees are always zero and the rewards are the effective gas prices paid by the txs.
+// The rewards of the blocks without txs are the current suggested gas price
+func (e *EthEndpoints) FeeHistory(blockCount types.ArgUint64, newestBlock types.BlockNumber, rewardPercentiles []float64) (interface{}, types.Error) {
+	if len(rewardPercentiles) > maxFeeHistoryRewardPercentiles {
+		return RPCErrorResponse(types.InvalidParamsErrorCode, fmt.Sprintf("too many reward percentiles, max is %d", maxFeeHistoryRewardPercentiles), nil, false)
+	}
+	for i, percentile := range rewardPercentiles {
+		if percentile < 0 || percentile > 100 || (i > 0 && percentile < rewardPercentiles[i-1]) {
+			return RPCErrorResponse(types.InvalidParamsErrorCode, fmt.Sprintf("invalid reward percentile %v, they must be in ascending order between 0 and 100", percentile), nil, false)
+		}
+	}
+
+	return e.txMan.NewDbTxScope(e.state, func(ctx context.Context, dbTx pgx.Tx) (interface{}, types.Error) {
+		newestBlockNumber, rpcErr := newestBlock.GetNumericBlockNumber(ctx, e.state, e.etherman, dbTx)
+		if rpcErr != nil {
+			return nil, rpcErr
+		}
+
+		count := min(uint64(blockCount), maxFeeHistoryBlockCount, newestBlockNumber+1)
+		if count == 0 {
+			return &types.FeeHistory{BaseFee: []types.ArgBig{}, GasUsedRatio: []float64{}}, nil
+		}
+		oldestBlockNumber := newestBlockNumber + 1 - count
+		feeHistory := &types.FeeHistory{
+			OldestBlock:  types.ArgUint64(oldestBlockNumber),
+			BaseFee:      make([]types.ArgBig, 0, count+1),
+			GasUsedRatio: make([]float64, 0, count),
+		}
+		if len(rewardPercentiles) > 0 {
+			feeHistory.Reward = make([][]types.ArgBig, 0, count)
+		}
+
+		var suggestedGasPrice *big.Int
+		for blockNumber := oldestBlockNumber; blockNumber <= newestBlockNumber; blockNumber++ {
+			l2Block, err := e.state.GetL2BlockByNumber(ctx, blockNumber, dbTx)
+			if errors.Is(err, state.ErrNotFound) {
+				return RPCErrorResponse(types.InvalidParamsErrorCode, fmt.Sprintf("block %d not found", blockNumber), nil, false)
+			} else if err != nil {
+				return RPCErrorResponse(types.DefaultErrorCode, fmt.Sprintf("couldn't load block from state by number %v", blockNumber), err, true)
+			}
+
+			feeHistory.BaseFee = append(feeHistory.BaseFee, types.ArgBig{})
+			gasUsedRatio := float64(0)
+			if l2Block.GasLimit() > 0 {
+				gasUsedRatio = float64(l2Block.GasUsed()) / float64(l2Block.GasLimit())
+			}
+			feeHistory.GasUsedRatio = append(feeHistory.GasUsedRatio, gasUsedRatio)
+
+			if len(rewardPercentiles) == 0 {
+				continue
+			}
+
+			txs := l2Block.Transactions()
+			if len(txs) == 0 {
+				if suggestedGasPrice == nil {
+					gasPrice, rpcErr := e.getSuggestedGasPrice(ctx)
+					if rpcErr != nil {
+						return nil, rpcErr
+					}
+					suggestedGasPrice = gasPrice
+				}
+				rewards := make([]types.ArgBig, len(rewardPercentiles))
+				for i := range rewards {
+					rewards[i] = types.ArgBig(*suggestedGasPrice)
+				}
+				feeHistory.Reward = append(feeHistory.Reward, rewards)
+				continue
+			}
+
+			receipts, err := e.state.GetL2BlockReceipts(ctx, blockNumber, dbTx)
+			if err != nil {
+				return RPCErrorResponse(types.DefaultErrorCode, fmt.Sprintf("couldn't load the receipts of block %v", blockNumber), err, true)
+			} else if len(receipts) == 0 {
+				return RPCErrorResponse(types.DefaultErrorCode, prunedReceiptsErrorMsg(blockNumber), nil, false)
+			}
+			gasPrices := make(map[common.Hash]*big.Int, len(txs))
+			for _, tx := range txs {
+				gasPrices[tx.Hash()] = tx.GasPrice()
+			}
+			txRewards := make([]txGasAndReward, 0, len(receipts))
+			for _, receipt := range receipts {
+				reward := receipt.EffectiveGasPrice
+				if reward == nil {
+					reward = gasPrices[receipt.TxHash]
+				}
+				txRewards = append(txRewards, txGasAndReward{gasUsed: receipt.GasUsed, reward: reward})
+			}
+			feeHistory.Reward = append(feeHistory.Reward, feeHistoryRewards(txRewards, rewardPercentiles))
+		}
+		// the base fee of the next block is also returned
+		feeHistory.BaseFee = append(feeHistory.BaseFee, types.ArgBig{})
+
+		return feeHistory, nil
+	})
+}
+
+// txGasAndReward is the gas used by a tx and the gas price it paid
+type txGasAndReward struct {
+	gasUsed uint64
+	reward  *big.Int
+}
+
+// notificationsNotSupportedErrorMsg is the error returned when a method that sends notifications
+// via web sockets is requested via http
+const notificationsNotSupportedErrorMsg = "notifications not supported"
+
+// prunedReceiptsErrorMsg is the error returned when the receipts of a block with txs are not
+// found, as they have been deleted by the pruning of the state history
+func prunedReceiptsErrorMsg(blockNumber uint64) string {
+	return fmt.Sprintf("the receipts of block %v are not available, they have been pruned", blockNumber)
+}
+
+// feeHistoryRewards returns the rewards at the percentiles of the gas used by the txs
+// of a block, the txs are sorted by reward and each one is weighted by its gas used.
+// The rewards are zero if there are no txs
+func feeHistoryRewards(txRewards []txGasAndReward, rewardPercentiles []float64) []types.ArgBig {
+	rewards := make([]types.ArgBig, len(rewardPercentiles))
+	if len(txRewards) == 0 {
+		return rewards
+	}
+
+	sort.SliceStable(txRewards, func(i, j int) bool {
+		return txRewards[i].reward.Cmp(txRewards[j].reward) < 0
+	})
+
+	var totalGasUsed uint64
+	for _, txReward := range txRewards {
+		totalGasUsed += txReward.gasUsed
+	}
+
+	txIndex := 0
+	sumGasUsed := txRewards[0].gasUsed
+	for i, percentile := range rewardPercentiles {
+		thresholdGasUsed := uint64(float64(totalGasUsed) * percentile / 100) //nolint:gomnd
+		for sumGasUsed < thresholdGasUsed && txIndex < len(txRewards)-1 {
+			txIndex++
+			sumGasUsed += txRewards[txIndex].gasUsed
+		}
+		rewards[i] = types.ArgBig(*txRewards[txIndex].reward)
+	}
+	return rewards
+}
+
+// getSuggestedGasPrice returns the gas price suggested by eth_gasPrice
+func (e *EthEndpoints) getSuggestedGasPrice(ctx context.Context) (*big.Int, types.Error) {
+	if e.cfg.SequencerNodeURI != "" {
+		gasPrice, rpcErr := e.getPriceFromSequencerNode()
+		if rpcErr != nil {
+			return nil, rpcErr
+		}
+		return new(big.Int).SetUint64(uint64(gasPrice.(types.ArgUint64))), nil
+	}
+	gasPrices, err := e.pool.GetGasPrices(ctx)
+	if err != nil {
+		log.Errorf("failed to get the suggested gas price: %v", err)
+		return nil, types.NewRPCError(types.DefaultErrorCode, "failed to get the suggested gas price")
+	}
+	return new(big.Int).SetUint64(gasPrices.L2GasPrice), nil
+}
+
 // GasPrice returns the average gas price based on the last x blocks
 func (e *EthEndpoints) GasPrice() (interface{}, types.Error) {
 	ctx := context.Background()
@@ -302,6 +556,24 @@ func (e *EthEndpoints) getBlockByArg(ctx context.Context, blockArg *types.BlockN
 	return block, nil
 }
 
+// getPendingBlock returns the block processed by the sequencer after the provided last block
+// stored in the state. It returns nil if the state is up to date with the sequencer or if the
+// pending block is not available, so the pending tag falls back to the last block in the state
+func (e *EthEndpoints) getPendingBlock(ctx context.Context, lastBlockNumber uint64) *pool.PendingBlock {
+	pendingBlock, err := e.pool.GetPendingBlock(ctx)
+	if errors.Is(err, pool.ErrNotFound) {
+		return nil
+	} else if err != nil {
+		log.Warnf("failed to get the pending block from the pool, using the last block instead: %v", err)
+		return nil
+	}
+
+	if pendingBlock.Number <= lastBlockNumber {
+		return nil
+	}
+	return pendingBlock
+}
+
 // GetBlockByHash returns information about a block by hash
 func (e *EthEndpoints) GetBlockByHash(hash types.ArgHash, fullTx bool, includeExtraInfo *bool) (interface{}, types.Error) {
 	return e.txMan.NewDbTxScope(e.state, func(ctx context.Context, dbTx pgx.Tx) (interface{}, types.Error) {
@@ -331,6 +603,31 @@ func (e *EthEndpoints) GetBlockByHash(hash types.ArgHash, fullTx bool, includeEx
 	})
 }
 
+// buildPendingL2Block builds the provisional L2 block of the pending block processed by the sequencer with
+// its txs that are still in the pool
+func (e *EthEndpoints) buildPendingL2Block(ctx context.Context, pendingBlock *pool.PendingBlock) (*state.L2Block, error) {
+	txs := make([]*ethTypes.Transaction, 0, len(pendingBlock.TxHashes))
+	for _, txHash := range pendingBlock.TxHashes {
+		poolTx, err := e.pool.GetTransactionByHash(ctx, txHash)
+		if errors.Is(err, pool.ErrNotFound) {
+			continue
+		} else if err != nil {
+			return nil, err
+		}
+		tx := poolTx.Transaction
+		txs = append(txs, &tx)
+	}
+
+	l2Header := state.NewL2Header(&ethTypes.Header{
+		ParentHash: pendingBlock.ParentHash,
+		Number:     big.NewInt(0).SetUint64(pendingBlock.Number),
+		Root:       pendingBlock.StateRoot,
+		Time:       uint64(pendingBlock.Timestamp.Unix()),
+		UncleHash:  ethTypes.EmptyUncleHash,
+	})
+	return state.NewL2Block(l2Header, txs, nil, nil, trie.NewStackTrie(nil)), nil
+}
+
 // GetBlockByNumber returns information about a block by block number
 func (e *EthEndpoints) GetBlockByNumber(number types.BlockNumber, fullTx bool, includeExtraInfo *bool) (interface{}, types.Error) {
 	return e.txMan.NewDbTxScope(e.state, func(ctx context.Context, dbTx pgx.Tx) (interface{}, types.Error) {
@@ -339,13 +636,23 @@ func (e *EthEndpoints) GetBlockByNumber(number types.BlockNumber, fullTx bool, i
 			if err != nil {
 				return RPCErrorResponse(types.DefaultErrorCode, "couldn't load last block from state to compute the pending block", err, true)
 			}
-			l2Header := state.NewL2Header(&ethTypes.Header{
-				ParentHash: lastBlock.Hash(),
-				Number:     big.NewInt(0).SetUint64(lastBlock.Number().Uint64() + 1),
-				TxHash:     ethTypes.EmptyRootHash,
-				UncleHash:  ethTypes.EmptyUncleHash,
-			})
-			l2Block := state.NewL2BlockWithHeader(l2Header)
+			var l2Block *state.L2Block
+			if pendingBlock := e.getPendingBlock(ctx, lastBlock.NumberU64()); pendingBlock != nil {
+				l2Block, err = e.buildPendingL2Block(ctx, pendingBlock)
+				if err != nil {
+					return RPCErrorResponse(types.DefaultErrorCode, "couldn't load the txs of the pending block", err, true)
+				}
+				// the L2 hashes of the pending txs are not stored in the state yet
+				includeExtraInfo = nil
+			} else {
+				l2Header := state.NewL2Header(&ethTypes.Header{
+					ParentHash: lastBlock.Hash(),
+					Number:     big.NewInt(0).SetUint64(lastBlock.Number().Uint64() + 1),
+					TxHash:     ethTypes.EmptyRootHash,
+					UncleHash:  ethTypes.EmptyUncleHash,
+				})
+				l2Block = state.NewL2BlockWithHeader(l2Header)
+			}
 			rpcBlock, err := types.NewBlock(ctx, e.state, nil, l2Block, nil, fullTx, false, includeExtraInfo, dbTx)
 			if err != nil {
 				return RPCErrorResponse(types.DefaultErrorCode, "couldn't build the pending block response", err, true)
@@ -391,6 +698,47 @@ func (e *EthEndpoints) GetBlockByNumber(number types.BlockNumber, fullTx bool, i
 	})
 }
 
+// GetBlockReceipts returns the receipts of all the transactions of a block,
+// sorted by transaction index
+func (e *EthEndpoints) GetBlockReceipts(blockArg types.BlockNumberOrHash) (interface{}, types.Error) {
+	return e.txMan.NewDbTxScope(e.state, func(ctx context.Context, dbTx pgx.Tx) (interface{}, types.Error) {
+		l2Block, rpcErr := e.getBlockByArg(ctx, &blockArg, dbTx)
+		if rpcErr != nil {
+			return nil, rpcErr
+		}
+
+		rs, err := e.state.GetL2BlockReceipts(ctx, l2Block.NumberU64(), dbTx)
+		if err != nil {
+			return RPCErrorResponse(types.DefaultErrorCode, fmt.Sprintf("couldn't load the receipts of block %v", l2Block.NumberU64()), err, true)
+		} else if len(rs) < len(l2Block.Transactions()) {
+			return RPCErrorResponse(types.DefaultErrorCode, prunedReceiptsErrorMsg(l2Block.NumberU64()), nil, false)
+		}
+
+		txs := make(map[common.Hash]*ethTypes.Transaction, len(l2Block.Transactions()))
+		for _, tx := range l2Block.Transactions() {
+			txs[tx.Hash()] = tx
+		}
+
+		receipts := make([]types.Receipt, 0, len(rs))
+		for _, r := range rs {
+			tx, found := txs[r.TxHash]
+			if !found {
+				return RPCErrorResponse(types.DefaultErrorCode, fmt.Sprintf("couldn't find tx %v in block %v", r.TxHash.String(), l2Block.NumberU64()), nil, true)
+			}
+			receipt, err := types.NewReceipt(*tx, r, nil)
+			if err != nil {
+				return RPCErrorResponse(types.DefaultErrorCode, "failed to build the receipt response", err, true)
+			}
+			if err := setRevertReason(ctx, e.state, &receipt, dbTx); err != nil {
+				return RPCErrorResponse(types.DefaultErrorCode, "failed to get the revert reason of the tx", err, true)
+			}
+			receipts = append(receipts, receipt)
+		}
+
+		return receipts, nil
+	})
+}
+
 // GetCode returns account code at given block number
 func (e *EthEndpoints) GetCode(address types.ArgAddress, blockArg *types.BlockNumberOrHash) (interface{}, types.Error) {
 	return e.txMan.NewDbTxScope(e.state, func(ctx context.Context, dbTx pgx.Tx) (interface{}, types.Error) {
@@ -568,6 +916,29 @@ func (e *EthEndpoints) GetStorageAt(address types.ArgAddress, storageKeyStr stri
 	})
 }
 
+// GetProof returns the merkle proofs of the account and of the provided
+// storage keys at the referenced block
+func (e *EthEndpoints) GetProof(address types.ArgAddress, storageKeys []types.ArgHash, blockArg *types.BlockNumberOrHash) (interface{}, types.Error) {
+	storagePositions := make([]*big.Int, 0, len(storageKeys))
+	for _, storageKey := range storageKeys {
+		storagePositions = append(storagePositions, storageKey.Hash().Big())
+	}
+
+	return e.txMan.NewDbTxScope(e.state, func(ctx context.Context, dbTx pgx.Tx) (interface{}, types.Error) {
+		block, respErr := e.getBlockByArg(ctx, blockArg, dbTx)
+		if respErr != nil {
+			return nil, respErr
+		}
+
+		proof, err := e.state.GetAccountProof(ctx, address.Address(), storagePositions, block.Root())
+		if err != nil {
+			return RPCErrorResponse(types.DefaultErrorCode, "failed to get proof from state", err, true)
+		}
+
+		return types.NewAccountProof(proof), nil
+	})
+}
+
 // GetTransactionByBlockHashAndIndex returns information about a transaction by
 // block hash and transaction index position.
 func (e *EthEndpoints) GetTransactionByBlockHashAndIndex(hash types.ArgHash, index types.Index, includeExtraInfo *bool) (interface{}, types.Error) {
@@ -726,40 +1097,40 @@ func (e *EthEndpoints) getTransactionByHashFromSequencerNode(hash common.Hash, i
 // GetTransactionCount returns account nonce
 func (e *EthEndpoints) GetTransactionCount(address types.ArgAddress, blockArg *types.BlockNumberOrHash) (interface{}, types.Error) {
 	return e.txMan.NewDbTxScope(e.state, func(ctx context.Context, dbTx pgx.Tx) (interface{}, types.Error) {
-		var (
-			pendingNonce uint64
-			nonce        uint64
-			err          error
-		)
-
 		block, respErr := e.getBlockByArg(ctx, blockArg, dbTx)
 		if respErr != nil {
 			return nil, respErr
 		}
 
+		root := block.Root()
+		isPending := false
 		if blockArg != nil {
 			blockNumArg := blockArg.Number()
 			if blockNumArg != nil && *blockNumArg == types.PendingBlockNumber {
 				if e.cfg.SequencerNodeURI != "" {
 					return e.getTransactionCountFromSequencerNode(address.Address(), blockArg.Number())
 				}
-				pendingNonce, err = e.pool.GetNonce(ctx, address.Address())
-				if err != nil {
-					return RPCErrorResponse(types.DefaultErrorCode, "failed to count pending transactions", err, true)
+				isPending = true
+				if pendingBlock := e.getPendingBlock(ctx, block.NumberU64()); pendingBlock != nil {
+					root = pendingBlock.StateRoot
 				}
 			}
 		}
 
-		nonce, err = e.state.GetNonce(ctx, address.Address(), block.Root())
-
+		nonce, err := e.state.GetNonce(ctx, address.Address(), root)
 		if errors.Is(err, state.ErrNotFound) {
-			return hex.EncodeUint64(0), nil
+			nonce = 0
 		} else if err != nil {
 			return RPCErrorResponse(types.DefaultErrorCode, "failed to count transactions", err, true)
 		}
 
-		if pendingNonce > nonce {
-			nonce = pendingNonce
+		// the pending nonce counts the txs of the pool that can be executed next,
+		// so the txs sent back-to-back by the same sender get consecutive nonces
+		if isPending {
+			nonce, err = e.pool.GetPendingNonce(ctx, address.Address(), nonce)
+			if err != nil {
+				return RPCErrorResponse(types.DefaultErrorCode, "failed to count pending transactions", err, true)
+			}
 		}
 
 		return hex.EncodeUint64(nonce), nil
@@ -866,6 +1237,9 @@ func (e *EthEndpoints) GetTransactionReceipt(hash types.ArgHash) (interface{}, t
 		if err != nil {
 			return RPCErrorResponse(types.DefaultErrorCode, "failed to build the receipt response", err, true)
 		}
+		if err := setRevertReason(ctx, e.state, &receipt, dbTx); err != nil {
+			return RPCErrorResponse(types.DefaultErrorCode, "failed to get the revert reason of the tx", err, true)
+		}
 
 		return receipt, nil
 	})
@@ -874,14 +1248,16 @@ func (e *EthEndpoints) GetTransactionReceipt(hash types.ArgHash) (interface{}, t
 // NewBlockFilter creates a filter in the node, to notify when
 // a new block arrives. To check if the state has changed,
 // call eth_getFilterChanges.
-func (e *EthEndpoints) NewBlockFilter() (interface{}, types.Error) {
-	return e.newBlockFilter(nil)
+func (e *EthEndpoints) NewBlockFilter(httpRequest *http.Request) (interface{}, types.Error) {
+	return e.newBlockFilter(nil, requestClient(httpRequest))
 }
 
 // internal
-func (e *EthEndpoints) newBlockFilter(wsConn *concurrentWsConn) (interface{}, types.Error) {
-	id, err := e.storage.NewBlockFilter(wsConn)
-	if err != nil {
+func (e *EthEndpoints) newBlockFilter(wsConn *concurrentWsConn, client string) (interface{}, types.Error) {
+	id, err := e.storage.NewBlockFilter(wsConn, client)
+	if errors.Is(err, ErrMaxFiltersReached) || errors.Is(err, ErrMaxFiltersPerClientReached) {
+		return RPCErrorResponse(types.DefaultErrorCode, err.Error(), nil, false)
+	} else if err != nil {
 		return RPCErrorResponse(types.DefaultErrorCode, "failed to create new block filter", err, true)
 	}
 
@@ -891,14 +1267,14 @@ func (e *EthEndpoints) newBlockFilter(wsConn *concurrentWsConn) (interface{}, ty
 // NewFilter creates a filter object, based on filter options,
 // to notify when the state changes (logs). To check if the state
 // has changed, call eth_getFilterChanges.
-func (e *EthEndpoints) NewFilter(filter LogFilter) (interface{}, types.Error) {
+func (e *EthEndpoints) NewFilter(httpRequest *http.Request, filter LogFilter) (interface{}, types.Error) {
 	return e.txMan.NewDbTxScope(e.state, func(ctx context.Context, dbTx pgx.Tx) (interface{}, types.Error) {
-		return e.newFilter(ctx, nil, filter, dbTx)
+		return e.newFilter(ctx, nil, requestClient(httpRequest), filter, dbTx)
 	})
 }
 
 // internal
-func (e *EthEndpoints) newFilter(ctx context.Context, wsConn *concurrentWsConn, filter LogFilter, dbTx pgx.Tx) (interface{}, types.Error) {
+func (e *EthEndpoints) newFilter(ctx context.Context, wsConn *concurrentWsConn, client string, filter LogFilter, dbTx pgx.Tx) (interface{}, types.Error) {
 	if filter.ShouldFilterByBlockRange() {
 		_, _, rpcErr := filter.GetNumericBlockNumbers(ctx, e.cfg, e.state, e.etherman, nil)
 		if rpcErr != nil {
@@ -906,9 +1282,11 @@ func (e *EthEndpoints) newFilter(ctx context.Context, wsConn *concurrentWsConn,
 		}
 	}
 
-	id, err := e.storage.NewLogFilter(wsConn, filter)
+	id, err := e.storage.NewLogFilter(wsConn, client, filter)
 	if errors.Is(err, ErrFilterInvalidPayload) {
 		return RPCErrorResponse(types.InvalidParamsErrorCode, err.Error(), nil, false)
+	} else if errors.Is(err, ErrMaxFiltersReached) || errors.Is(err, ErrMaxFiltersPerClientReached) {
+		return RPCErrorResponse(types.DefaultErrorCode, err.Error(), nil, false)
 	} else if err != nil {
 		return RPCErrorResponse(types.DefaultErrorCode, "failed to create new log filter", err, true)
 	}
@@ -919,19 +1297,26 @@ func (e *EthEndpoints) newFilter(ctx context.Context, wsConn *concurrentWsConn,
 // NewPendingTransactionFilter creates a filter in the node, to
 // notify when new pending transactions arrive. To check if the
 // state has changed, call eth_getFilterChanges.
-func (e *EthEndpoints) NewPendingTransactionFilter() (interface{}, types.Error) {
-	return e.newPendingTransactionFilter(nil)
+func (e *EthEndpoints) NewPendingTransactionFilter(httpRequest *http.Request) (interface{}, types.Error) {
+	return e.newPendingTransactionFilter(nil, requestClient(httpRequest))
 }
 
 // internal
-func (e *EthEndpoints) newPendingTransactionFilter(wsConn *concurrentWsConn) (interface{}, types.Error) {
-	return nil, types.NewRPCError(types.DefaultErrorCode, "not supported yet")
-	// id, err := e.storage.NewPendingTransactionFilter(wsConn)
-	// if err != nil {
-	// 	return rpcErrorResponse(types.DefaultErrorCode, "failed to create new pending transaction filter", err)
-	// }
+func (e *EthEndpoints) newPendingTransactionFilter(wsConn *concurrentWsConn, client string) (interface{}, types.Error) {
+	id, err := e.storage.NewPendingTransactionFilter(wsConn, client)
+	if errors.Is(err, ErrMaxFiltersReached) || errors.Is(err, ErrMaxFiltersPerClientReached) {
+		return RPCErrorResponse(types.DefaultErrorCode, err.Error(), nil, false)
+	} else if err != nil {
+		return RPCErrorResponse(types.DefaultErrorCode, "failed to create new pending transaction filter", err, true)
+	}
 
-	// return id, nil
+	if wsConn != nil {
+		e.pendingTxsMonitor.Do(func() {
+			go state.InfiniteSafeRun(e.monitorPendingTxs, "failed to monitor pending txs: %v", time.Second)
+		})
+	}
+
+	return id, nil
 }
 
 // SendRawTransaction has two different ways to handle new transactions:
@@ -952,8 +1337,124 @@ func (e *EthEndpoints) SendRawTransaction(httpRequest *http.Request, input strin
 			ip = strings.Split(ips, ",")[0]
 		}
 
-		return e.tryToAddTxToPool(input, ip)
+		return e.tryToAddTxToPool(input, ip, e.isLocalTxRequest(httpRequest))
+	}
+}
+
+// SendRawTransactionConditional adds a tx to the pool that is only sequenced if the
+// provided conditions are met, they are checked when the tx is added and when the
+// sequencer selects it. Non-Sequencer nodes relay the tx to the Sequencer node
+func (e *EthEndpoints) SendRawTransactionConditional(httpRequest *http.Request, input string, options types.TxConditionsArgs) (interface{}, types.Error) {
+	if e.cfg.SequencerNodeURI != "" {
+		return e.relayToSequencerNode("eth_sendRawTransactionConditional", "failed to relay conditional tx to the sequencer node", input, options)
+	}
+
+	tx, err := hexToTx(input)
+	if err != nil {
+		return RPCErrorResponse(types.InvalidParamsErrorCode, "invalid tx input", err, false)
+	}
+	conditions, err := options.ToTxConditions()
+	if err != nil {
+		return RPCErrorResponse(types.InvalidParamsErrorCode, err.Error(), nil, false)
+	}
+
+	log.Infof("adding conditional TX to the pool: %v", tx.Hash().Hex())
+	err = e.pool.AddConditionalTx(context.Background(), *tx, requestIP(httpRequest), conditions)
+	if errors.Is(err, pool.ErrTxRateLimitExceeded) || errors.Is(err, pool.ErrTooManyKnownStorageSlots) {
+		return RPCErrorResponse(types.LimitExceededErrorCode, err.Error(), nil, false)
+	} else if errors.Is(err, pool.ErrBlockNumberConditionNotMet) || errors.Is(err, pool.ErrTimestampConditionNotMet) || errors.Is(err, pool.ErrKnownAccountsConditionNotMet) {
+		return RPCErrorResponse(types.ConditionsNotMetErrorCode, err.Error(), nil, false)
+	} else if err != nil {
+		return RPCErrorResponse(types.DefaultErrorCode, err.Error(), nil, false)
+	}
+	log.Infof("conditional TX added to the pool: %v", tx.Hash().Hex())
+
+	return tx.Hash().Hex(), nil
+}
+
+// SendUserOperation adds an EIP-4337 user operation to the alternate mempool of the pool and returns
+// its hash. Non-Sequencer nodes relay the user operation to the Sequencer node
+func (e *EthEndpoints) SendUserOperation(httpRequest *http.Request, userOp types.UserOperation, entryPoint common.Address) (interface{}, types.Error) {
+	if e.cfg.SequencerNodeURI != "" {
+		return e.relayToSequencerNode("eth_sendUserOperation", "failed to relay user operation to the sequencer node", userOp, entryPoint)
+	}
+
+	hash, err := e.pool.AddUserOperation(context.Background(), userOp.ToUserOp(), entryPoint, requestIP(httpRequest))
+	if errors.Is(err, pool.ErrTxRateLimitExceeded) {
+		return RPCErrorResponse(types.LimitExceededErrorCode, err.Error(), nil, false)
+	} else if err != nil {
+		return RPCErrorResponse(types.DefaultErrorCode, err.Error(), nil, false)
+	}
+	log.Infof("user operation added to the pool: %v", hash.Hex())
+
+	return hash.Hex(), nil
+}
+
+// SupportedEntryPoints returns the addresses of the entry points of the user operations accepted by the pool
+func (e *EthEndpoints) SupportedEntryPoints() (interface{}, types.Error) {
+	if e.cfg.SequencerNodeURI != "" {
+		return e.relayToSequencerNode("eth_supportedEntryPoints", "failed to get supported entry points from the sequencer node")
+	}
+	return e.pool.SupportedEntryPoints(), nil
+}
+
+func (e *EthEndpoints) relayToSequencerNode(method, errMsg string, parameters ...interface{}) (interface{}, types.Error) {
+	res, err := client.JSONRPCCall(e.cfg.SequencerNodeURI, method, parameters...)
+	if err != nil {
+		return RPCErrorResponse(types.DefaultErrorCode, errMsg, err, true)
+	}
+
+	if res.Error != nil {
+		return RPCErrorResponse(res.Error.Code, res.Error.Message, nil, false)
+	}
+
+	return res.Result, nil
+}
+
+// requestIP returns the IP of the client that sent the request, as it's forwarded by the proxy
+func requestIP(httpRequest *http.Request) string {
+	if httpRequest == nil {
+		return ""
+	}
+	if ips := httpRequest.Header.Get("X-Forwarded-For"); ips != "" {
+		return strings.Split(ips, ",")[0]
+	}
+	return ""
+}
+
+// requestClient returns the client that sent the request to limit the filters it installs, it's the
+// IP forwarded by the proxy or the remote address of the request if it was not forwarded
+func requestClient(httpRequest *http.Request) string {
+	if ip := requestIP(httpRequest); ip != "" {
+		return ip
+	}
+	if httpRequest == nil {
+		return ""
+	}
+	host, _, err := net.SplitHostPort(httpRequest.RemoteAddr)
+	if err != nil {
+		return httpRequest.RemoteAddr
 	}
+	return host
+}
+
+// isLocalTxRequest returns true if the request contains one of the privileged API keys configured
+// to send local txs
+func (e *EthEndpoints) isLocalTxRequest(httpRequest *http.Request) bool {
+	if httpRequest == nil || len(e.cfg.LocalTxsAPIKeys) == 0 {
+		return false
+	}
+
+	apiKey := httpRequest.Header.Get(apiKeyHeader)
+	if apiKey == "" {
+		return false
+	}
+	for _, localTxsAPIKey := range e.cfg.LocalTxsAPIKeys {
+		if subtle.ConstantTimeCompare([]byte(apiKey), []byte(localTxsAPIKey)) == 1 {
+			return true
+		}
+	}
+	return false
 }
 
 func (e *EthEndpoints) relayTxToSequencerNode(input string) (interface{}, types.Error) {
@@ -971,13 +1472,19 @@ func (e *EthEndpoints) relayTxToSequencerNode(input string) (interface{}, types.
 	return txHash, nil
 }
 
-func (e *EthEndpoints) tryToAddTxToPool(input, ip string) (interface{}, types.Error) {
+func (e *EthEndpoints) tryToAddTxToPool(input, ip string, isLocal bool) (interface{}, types.Error) {
 	tx, err := hexToTx(input)
 	if err != nil {
 		return RPCErrorResponse(types.InvalidParamsErrorCode, "invalid tx input", err, false)
 	}
-	log.Infof("adding TX to the pool: %v", tx.Hash().Hex())
-	if err := e.pool.AddTx(context.Background(), *tx, ip); err != nil {
+	addTx := e.pool.AddTx
+	if isLocal {
+		addTx = e.pool.AddLocalTx
+	}
+	log.Infof("adding TX to the pool: %v, local: %t", tx.Hash().Hex(), isLocal)
+	if err := addTx(context.Background(), *tx, ip); errors.Is(err, pool.ErrTxRateLimitExceeded) {
+		return RPCErrorResponse(types.LimitExceededErrorCode, err.Error(), nil, false)
+	} else if err != nil {
 		// it's not needed to log the error here, because we check and log if needed
 		// for each specific case during the "pool.AddTx" internal steps
 		return RPCErrorResponse(types.DefaultErrorCode, err.Error(), nil, false)
@@ -1101,19 +1608,22 @@ func (e *EthEndpoints) updateFilterLastPoll(filterID string) types.Error {
 // For each event that matches the subscription a notification with relevant
 // data is sent together with the subscription id.
 func (e *EthEndpoints) Subscribe(wsConn *concurrentWsConn, name string, logFilter *LogFilter) (interface{}, types.Error) {
+	if wsConn == nil {
+		return nil, types.NewRPCError(types.DefaultErrorCode, notificationsNotSupportedErrorMsg)
+	}
 	switch name {
 	case "newHeads":
-		return e.newBlockFilter(wsConn)
+		return e.newBlockFilter(wsConn, wsConn.client)
 	case "logs":
 		return e.txMan.NewDbTxScope(e.state, func(ctx context.Context, dbTx pgx.Tx) (interface{}, types.Error) {
 			var lf LogFilter
 			if logFilter != nil {
 				lf = *logFilter
 			}
-			return e.newFilter(ctx, wsConn, lf, dbTx)
+			return e.newFilter(ctx, wsConn, wsConn.client, lf, dbTx)
 		})
 	case "pendingTransactions", "newPendingTransactions":
-		return e.newPendingTransactionFilter(wsConn)
+		return e.newPendingTransactionFilter(wsConn, wsConn.client)
 	case "syncing":
 		return nil, types.NewRPCError(types.DefaultErrorCode, "not supported yet")
 	default:
@@ -1123,6 +1633,9 @@ func (e *EthEndpoints) Subscribe(wsConn *concurrentWsConn, name string, logFilte
 
 // Unsubscribe uninstalls the filter based on the provided filterID
 func (e *EthEndpoints) Unsubscribe(wsConn *concurrentWsConn, filterID string) (interface{}, types.Error) {
+	if wsConn == nil {
+		return nil, types.NewRPCError(types.DefaultErrorCode, notificationsNotSupportedErrorMsg)
+	}
 	return e.UninstallFilter(filterID)
 }
 
@@ -1216,6 +1729,57 @@ func (e *EthEndpoints) notifyNewLogs(wg *sync.WaitGroup, event state.NewL2BlockE
 	log.Debugf("[notifyNewLogs] new l2 block event for block %v took %v to send all the messages for log filters", event.Block.NumberU64(), time.Since(start))
 }
 
+// monitorPendingTxs checks periodically the txs added to the pool and
+// notifies their hashes to the pending tx filters with web socket connections
+func (e *EthEndpoints) monitorPendingTxs() {
+	since := time.Now().UTC()
+	notified := map[common.Hash]struct{}{}
+	for {
+		time.Sleep(pendingTxsCheckInterval)
+		since, notified = e.notifyNewPendingTxs(since, notified)
+	}
+}
+
+// notifyNewPendingTxs notifies the hashes of the pending txs received since the provided time to the
+// pending tx filters with web socket connections. The txs received while checking are returned again
+// in the next check, so the txs already notified are skipped. It returns the time of the check and
+// the txs notified, to be used in the next check
+func (e *EthEndpoints) notifyNewPendingTxs(since time.Time, notified map[common.Hash]struct{}) (time.Time, map[common.Hash]struct{}) {
+	checkTime := time.Now().UTC()
+	filters := e.storage.GetAllPendingTxFiltersWithWSConn()
+	if len(filters) == 0 {
+		return checkTime, map[common.Hash]struct{}{}
+	}
+
+	hashes, err := e.pool.GetPendingTxHashesSince(context.Background(), since)
+	if err != nil {
+		log.Errorf("failed to get pending tx hashes to notify: %v", err)
+		return since, notified
+	}
+
+	newNotified := make(map[common.Hash]struct{}, len(hashes))
+	messages := make([][]byte, 0, len(hashes))
+	for _, hash := range hashes {
+		newNotified[hash] = struct{}{}
+		if _, found := notified[hash]; found {
+			continue
+		}
+		data, err := json.Marshal(hash)
+		if err != nil {
+			log.Errorf("failed to marshal pending tx hash response to subscription: %v", err)
+			continue
+		}
+		messages = append(messages, data)
+	}
+
+	for _, filter := range filters {
+		for _, data := range messages {
+			filter.EnqueueSubscriptionDataToBeSent(data)
+		}
+	}
+	return checkTime, newNotified
+}
+
 // shouldSkipLogFilter checks if the log filter can be skipped while notifying new logs.
 // it checks the log filter information against the block in the event to decide if the
 // information in the event is required by the filter or can be ignored to save resources.
@@ -1264,7 +1828,10 @@ func (e *EthEndpoints) shouldSkipLogFilter(event state.NewL2BlockEvent, filter *
 			}
 		}
 	}
-	return false
+
+	// if the bloom of the block doesn't match the addresses and topics
+	// of the filter, none of the logs of the block are required
+	return !state.BloomMatchesFilter(event.Block.Bloom(), logFilter.Addresses, logFilter.Topics)
 }
 
 // filterLogs will filter the provided logsToFilter accordingly to the filters provided
@@ -1337,6 +1904,26 @@ func contains[T comparable](items []T, itemsToFind T) bool {
 	return false
 }
 
+// setRevertReason sets the data returned by the reverted tx of the receipt as its revert reason,
+// only the receipts of the failed txs are looked up
+func setRevertReason(ctx context.Context, s types.StateInterface, receipt *types.Receipt, dbTx pgx.Tx) error {
+	if uint64(receipt.Status) != ethTypes.ReceiptStatusFailed {
+		return nil
+	}
+
+	revertData, err := s.GetTransactionRevertData(ctx, receipt.TxHash, dbTx)
+	if errors.Is(err, state.ErrNotFound) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+
+	if len(revertData) > 0 {
+		receipt.RevertReason = types.ArgBytesPtr(revertData)
+	}
+	return nil
+}
+
 // parallelize split the items into workers accordingly
 // to the max number of workers and the number of items,
 // allowing the fn to be executed in concurrently for different
@@ -6,6 +6,8 @@ import (
 	"errors"
 	"fmt"
 	"math/big"
+	"net/http"
+	"net/http/httptest"
 	"sync"
 	"testing"
 	"time"
@@ -13,7 +15,9 @@ import (
 	"github.com/0xPolygonHermez/zkevm-node/encoding"
 	"github.com/0xPolygonHermez/zkevm-node/hex"
 	"github.com/0xPolygonHermez/zkevm-node/jsonrpc/client"
+	"github.com/0xPolygonHermez/zkevm-node/jsonrpc/mocks"
 	"github.com/0xPolygonHermez/zkevm-node/jsonrpc/types"
+	"github.com/0xPolygonHermez/zkevm-node/merkletree"
 	"github.com/0xPolygonHermez/zkevm-node/pool"
 	"github.com/0xPolygonHermez/zkevm-node/state"
 	"github.com/0xPolygonHermez/zkevm-node/state/runtime"
@@ -174,7 +178,7 @@ func TestCall(t *testing.T) {
 				m.State.On("GetL2BlockByNumber", context.Background(), blockNumOneUint64, m.DbTx).Return(block, nil).Once()
 				m.State.On("GetNonce", context.Background(), *txArgs.From, blockRoot).Return(nonce, nil).Once()
 				m.State.
-					On("ProcessUnsignedTransaction", context.Background(), txMatchBy, *txArgs.From, &blockNumOneUint64, true, m.DbTx).
+					On("ProcessUnsignedTransaction", context.Background(), txMatchBy, *txArgs.From, &blockNumOneUint64, true, state.StateOverride(nil), m.DbTx).
 					Return(&runtime.ExecutionResult{ReturnValue: testCase.expectedResult}, nil).
 					Once()
 			},
@@ -218,7 +222,7 @@ func TestCall(t *testing.T) {
 				})
 				m.State.On("GetNonce", context.Background(), *txArgs.From, blockRoot).Return(nonce, nil).Once()
 				m.State.
-					On("ProcessUnsignedTransaction", context.Background(), txMatchBy, *txArgs.From, &blockNumOneUint64, true, m.DbTx).
+					On("ProcessUnsignedTransaction", context.Background(), txMatchBy, *txArgs.From, &blockNumOneUint64, true, state.StateOverride(nil), m.DbTx).
 					Return(&runtime.ExecutionResult{ReturnValue: testCase.expectedResult}, nil).
 					Once()
 			},
@@ -260,7 +264,47 @@ func TestCall(t *testing.T) {
 				m.State.On("GetL2BlockByNumber", context.Background(), blockNumOneUint64, m.DbTx).Return(block, nil).Once()
 				m.State.On("GetNonce", context.Background(), *txArgs.From, blockRoot).Return(nonce, nil).Once()
 				m.State.
-					On("ProcessUnsignedTransaction", context.Background(), txMatchBy, *txArgs.From, nilUint64, true, m.DbTx).
+					On("ProcessUnsignedTransaction", context.Background(), txMatchBy, *txArgs.From, nilUint64, true, state.StateOverride(nil), m.DbTx).
+					Return(&runtime.ExecutionResult{ReturnValue: testCase.expectedResult}, nil).
+					Once()
+			},
+		},
+		{
+			name: "Transaction with state override from latest block",
+			params: []interface{}{
+				types.TxArgs{
+					From: state.HexToAddressPtr("0x1"),
+					To:   state.HexToAddressPtr("0x2"),
+					Gas:  types.ArgUint64Ptr(24000),
+					Data: types.ArgBytesPtr([]byte("data")),
+				},
+				latest,
+				map[string]interface{}{
+					"0x0000000000000000000000000000000000000002": map[string]interface{}{
+						"code":  "0x6001",
+						"state": map[string]interface{}{common.HexToHash("0x3").String(): common.HexToHash("0x4").String()},
+					},
+				},
+			},
+			expectedResult: []byte("hello world"),
+			expectedError:  nil,
+			setupMocks: func(c Config, m *mocksWrapper, testCase *testCase) {
+				nonce := uint64(7)
+				m.DbTx.On("Commit", context.Background()).Return(nil).Once()
+				m.State.On("BeginStateTransaction", context.Background()).Return(m.DbTx, nil).Once()
+				m.State.On("GetLastL2BlockNumber", context.Background(), m.DbTx).Return(blockNumOne.Uint64(), nil).Once()
+				txArgs := testCase.params[0].(types.TxArgs)
+				stateOverrideMatchBy := mock.MatchedBy(func(stateOverride state.StateOverride) bool {
+					account, found := stateOverride[*txArgs.To]
+					return len(stateOverride) == 1 && found &&
+						hex.EncodeToHex(*account.Code) == "0x6001" && account.Nonce == nil && account.StateDiff == nil &&
+						(*account.State)[common.HexToHash("0x3")] == common.HexToHash("0x4")
+				})
+				block := state.NewL2BlockWithHeader(state.NewL2Header(&ethTypes.Header{Number: blockNumOne, Root: blockRoot}))
+				m.State.On("GetL2BlockByNumber", context.Background(), blockNumOneUint64, m.DbTx).Return(block, nil).Once()
+				m.State.On("GetNonce", context.Background(), *txArgs.From, blockRoot).Return(nonce, nil).Once()
+				m.State.
+					On("ProcessUnsignedTransaction", context.Background(), mock.IsType(&ethTypes.Transaction{}), *txArgs.From, nilUint64, true, stateOverrideMatchBy, m.DbTx).
 					Return(&runtime.ExecutionResult{ReturnValue: testCase.expectedResult}, nil).
 					Once()
 			},
@@ -302,7 +346,7 @@ func TestCall(t *testing.T) {
 				})
 				m.State.On("GetNonce", context.Background(), *txArgs.From, blockRoot).Return(nonce, nil).Once()
 				m.State.
-					On("ProcessUnsignedTransaction", context.Background(), txMatchBy, *txArgs.From, &blockNumTenUint64, true, m.DbTx).
+					On("ProcessUnsignedTransaction", context.Background(), txMatchBy, *txArgs.From, &blockNumTenUint64, true, state.StateOverride(nil), m.DbTx).
 					Return(&runtime.ExecutionResult{ReturnValue: testCase.expectedResult}, nil).
 					Once()
 			},
@@ -342,7 +386,7 @@ func TestCall(t *testing.T) {
 				m.State.On("GetL2BlockByNumber", context.Background(), blockNumTenUint64, m.DbTx).Return(block, nil).Once()
 				m.State.On("GetNonce", context.Background(), *txArgs.From, blockRoot).Return(nonce, nil).Once()
 				m.State.
-					On("ProcessUnsignedTransaction", context.Background(), txMatchBy, *txArgs.From, &blockNumTenUint64, true, m.DbTx).
+					On("ProcessUnsignedTransaction", context.Background(), txMatchBy, *txArgs.From, &blockNumTenUint64, true, state.StateOverride(nil), m.DbTx).
 					Return(&runtime.ExecutionResult{ReturnValue: testCase.expectedResult}, nil).
 					Once()
 			},
@@ -381,7 +425,7 @@ func TestCall(t *testing.T) {
 				block := state.NewL2BlockWithHeader(state.NewL2Header(&ethTypes.Header{Number: blockNumOne, Root: blockRoot}))
 				m.State.On("GetL2BlockByNumber", context.Background(), blockNumOneUint64, m.DbTx).Return(block, nil).Once()
 				m.State.
-					On("ProcessUnsignedTransaction", context.Background(), txMatchBy, common.HexToAddress(state.DefaultSenderAddress), nilUint64, true, m.DbTx).
+					On("ProcessUnsignedTransaction", context.Background(), txMatchBy, common.HexToAddress(state.DefaultSenderAddress), nilUint64, true, state.StateOverride(nil), m.DbTx).
 					Return(&runtime.ExecutionResult{ReturnValue: testCase.expectedResult}, nil).
 					Once()
 			},
@@ -404,6 +448,7 @@ func TestCall(t *testing.T) {
 				m.DbTx.On("Commit", context.Background()).Return(nil).Once()
 				m.State.On("BeginStateTransaction", context.Background()).Return(m.DbTx, nil).Once()
 				m.State.On("GetLastL2BlockNumber", context.Background(), m.DbTx).Return(blockNumOne.Uint64(), nil).Once()
+				m.Pool.On("GetPendingBlock", context.Background()).Return(nil, pool.ErrNotFound).Once()
 				m.State.On("GetL2BlockHeaderByNumber", context.Background(), blockNumOne.Uint64(), m.DbTx).Return(blockHeader, nil).Once()
 				txArgs := testCase.params[0].(types.TxArgs)
 				txMatchBy := mock.MatchedBy(func(tx *ethTypes.Transaction) bool {
@@ -420,7 +465,7 @@ func TestCall(t *testing.T) {
 				block := state.NewL2BlockWithHeader(state.NewL2Header(&ethTypes.Header{Number: blockNumOne, Root: blockRoot}))
 				m.State.On("GetL2BlockByNumber", context.Background(), blockNumOneUint64, m.DbTx).Return(block, nil).Once()
 				m.State.
-					On("ProcessUnsignedTransaction", context.Background(), txMatchBy, common.HexToAddress(state.DefaultSenderAddress), nilUint64, true, m.DbTx).
+					On("ProcessUnsignedTransaction", context.Background(), txMatchBy, common.HexToAddress(state.DefaultSenderAddress), nilUint64, true, state.StateOverride(nil), m.DbTx).
 					Return(&runtime.ExecutionResult{ReturnValue: testCase.expectedResult}, nil).
 					Once()
 			},
@@ -484,7 +529,7 @@ func TestCall(t *testing.T) {
 				m.State.On("GetL2BlockByNumber", context.Background(), blockNumOneUint64, m.DbTx).Return(block, nil).Once()
 				m.State.On("GetNonce", context.Background(), *txArgs.From, blockRoot).Return(nonce, nil).Once()
 				m.State.
-					On("ProcessUnsignedTransaction", context.Background(), txMatchBy, *txArgs.From, nilUint64, true, m.DbTx).
+					On("ProcessUnsignedTransaction", context.Background(), txMatchBy, *txArgs.From, nilUint64, true, state.StateOverride(nil), m.DbTx).
 					Return(&runtime.ExecutionResult{Err: errors.New("failed to process unsigned transaction")}, nil).
 					Once()
 			},
@@ -526,7 +571,7 @@ func TestCall(t *testing.T) {
 				m.State.On("GetL2BlockByNumber", context.Background(), blockNumOneUint64, m.DbTx).Return(block, nil).Once()
 				m.State.On("GetNonce", context.Background(), *txArgs.From, blockRoot).Return(nonce, nil).Once()
 				m.State.
-					On("ProcessUnsignedTransaction", context.Background(), txMatchBy, *txArgs.From, nilUint64, true, m.DbTx).
+					On("ProcessUnsignedTransaction", context.Background(), txMatchBy, *txArgs.From, nilUint64, true, state.StateOverride(nil), m.DbTx).
 					Return(&runtime.ExecutionResult{Err: runtime.ErrExecutionReverted}, nil).
 					Once()
 			},
@@ -688,7 +733,7 @@ func TestEstimateGas(t *testing.T) {
 					Return(nonce, nil).
 					Once()
 				m.State.
-					On("EstimateGas", txMatchBy, *txArgs.From, nilUint64, m.DbTx).
+					On("EstimateGas", txMatchBy, *txArgs.From, nilUint64, state.StateOverride(nil), m.DbTx).
 					Return(*testCase.expectedResult, nil, nil).
 					Once()
 			},
@@ -729,7 +774,56 @@ func TestEstimateGas(t *testing.T) {
 				m.State.On("GetLastL2Block", context.Background(), m.DbTx).Return(block, nil).Once()
 
 				m.State.
-					On("EstimateGas", txMatchBy, common.HexToAddress(state.DefaultSenderAddress), nilUint64, m.DbTx).
+					On("EstimateGas", txMatchBy, common.HexToAddress(state.DefaultSenderAddress), nilUint64, state.StateOverride(nil), m.DbTx).
+					Return(*testCase.expectedResult, nil, nil).
+					Once()
+			},
+		},
+		{
+			name: "Transaction with state override",
+			params: []interface{}{
+				types.TxArgs{
+					From: state.HexToAddressPtr("0x1"),
+					To:   state.HexToAddressPtr("0x2"),
+					Data: types.ArgBytesPtr([]byte("data")),
+				},
+				"latest",
+				map[string]interface{}{
+					"0x0000000000000000000000000000000000000001": map[string]interface{}{
+						"balance": "0x3e8",
+						"nonce":   "0x9",
+					},
+					"0x0000000000000000000000000000000000000002": map[string]interface{}{
+						"code":      "0x6001",
+						"stateDiff": map[string]interface{}{common.HexToHash("0x3").String(): common.HexToHash("0x4").String()},
+					},
+				},
+			},
+			expectedResult: state.Ptr(uint64(100)),
+			setupMocks: func(c Config, m *mocksWrapper, testCase *testCase) {
+				nonce := uint64(7)
+				txArgs := testCase.params[0].(types.TxArgs)
+				stateOverrideMatchBy := mock.MatchedBy(func(stateOverride state.StateOverride) bool {
+					sender, receiver := stateOverride[*txArgs.From], stateOverride[*txArgs.To]
+					return len(stateOverride) == 2 &&
+						sender.Balance.Cmp(big.NewInt(1000)) == 0 && *sender.Nonce == 9 && sender.Code == nil &&
+						hex.EncodeToHex(*receiver.Code) == "0x6001" && receiver.Balance == nil &&
+						(*receiver.StateDiff)[common.HexToHash("0x3")] == common.HexToHash("0x4")
+				})
+
+				m.DbTx.On("Commit", context.Background()).Return(nil).Once()
+				m.State.On("BeginStateTransaction", context.Background()).Return(m.DbTx, nil).Once()
+
+				block := state.NewL2BlockWithHeader(state.NewL2Header(&ethTypes.Header{Number: blockNumTen, Root: blockRoot}))
+				m.State.On("GetLastL2BlockNumber", context.Background(), m.DbTx).Return(blockNumTen.Uint64(), nil).Once()
+				m.State.On("GetL2BlockByNumber", context.Background(), blockNumTen.Uint64(), m.DbTx).Return(block, nil).Once()
+
+				m.State.
+					On("GetNonce", context.Background(), *txArgs.From, blockRoot).
+					Return(nonce, nil).
+					Once()
+				m.State.
+					On("EstimateGas", mock.IsType(&ethTypes.Transaction{}), *txArgs.From, nilUint64, stateOverrideMatchBy, m.DbTx).
 					Return(*testCase.expectedResult, nil, nil).
 					Once()
 			},
@@ -799,6 +893,148 @@ func TestGasPrice(t *testing.T) {
 	}
 }
 
+func TestFeeHistory(t *testing.T) {
+	s, m, _ := newSequencerMockedServer(t)
+	defer s.Stop()
+
+	txs := []*ethTypes.Transaction{
+		ethTypes.NewTx(&ethTypes.LegacyTx{Nonce: 1, GasPrice: big.NewInt(2)}),
+		ethTypes.NewTx(&ethTypes.LegacyTx{Nonce: 2, GasPrice: big.NewInt(3)}),
+	}
+	receipts := []*ethTypes.Receipt{
+		{TxHash: txs[0].Hash(), GasUsed: 21000, EffectiveGasPrice: big.NewInt(2)},
+		{TxHash: txs[1].Hash(), GasUsed: 63000, EffectiveGasPrice: big.NewInt(1)},
+	}
+	st := trie.NewStackTrie(nil)
+	blockWithTxs := state.NewL2Block(state.NewL2Header(&ethTypes.Header{Number: big.NewInt(1), GasLimit: 100000, GasUsed: 84000}), txs, nil, receipts, st)
+	emptyBlock := state.NewL2Block(state.NewL2Header(&ethTypes.Header{Number: big.NewInt(2), GasLimit: 100000}), nil, nil, nil, st)
+
+	m.DbTx.On("Commit", context.Background()).Return(nil).Once()
+	m.State.On("BeginStateTransaction", context.Background()).Return(m.DbTx, nil).Once()
+	m.State.On("GetLastL2BlockNumber", context.Background(), m.DbTx).Return(uint64(2), nil).Once()
+	m.State.On("GetL2BlockByNumber", context.Background(), uint64(1), m.DbTx).Return(blockWithTxs, nil).Once()
+	m.State.On("GetL2BlockByNumber", context.Background(), uint64(2), m.DbTx).Return(emptyBlock, nil).Once()
+	m.State.On("GetL2BlockReceipts", context.Background(), uint64(1), m.DbTx).Return(receipts, nil).Once()
+	m.Pool.On("GetGasPrices", context.Background()).Return(pool.GasPrices{L2GasPrice: 5}, nil).Once()
+
+	res, err := s.JSONRPCCall("eth_feeHistory", "0x2", "latest", []float64{10, 90})
+	require.NoError(t, err)
+	require.Nil(t, res.Error)
+
+	var feeHistory types.FeeHistory
+	require.NoError(t, json.Unmarshal(res.Result, &feeHistory))
+	assert.Equal(t, types.ArgUint64(1), feeHistory.OldestBlock)
+	assert.Equal(t, []float64{0.84, 0}, feeHistory.GasUsedRatio)
+	require.Len(t, feeHistory.BaseFee, 3)
+	for _, baseFee := range feeHistory.BaseFee {
+		assert.Equal(t, "0x0", baseFee.Hex())
+	}
+	require.Len(t, feeHistory.Reward, 2)
+	assert.Equal(t, []string{"0x1", "0x2"}, []string{feeHistory.Reward[0][0].Hex(), feeHistory.Reward[0][1].Hex()})
+	assert.Equal(t, []string{"0x5", "0x5"}, []string{feeHistory.Reward[1][0].Hex(), feeHistory.Reward[1][1].Hex()})
+
+	res, err = s.JSONRPCCall("eth_feeHistory", "0x5", "latest", []float64{90, 10})
+	require.NoError(t, err)
+	require.NotNil(t, res.Error)
+	assert.Equal(t, types.InvalidParamsErrorCode, res.Error.Code)
+
+	// the receipts of the block with txs have been pruned
+	m.DbTx.On("Rollback", context.Background()).Return(nil).Once()
+	m.State.On("BeginStateTransaction", context.Background()).Return(m.DbTx, nil).Once()
+	m.State.On("GetLastL2BlockNumber", context.Background(), m.DbTx).Return(uint64(2), nil).Once()
+	m.State.On("GetL2BlockByNumber", context.Background(), uint64(1), m.DbTx).Return(blockWithTxs, nil).Once()
+	m.State.On("GetL2BlockReceipts", context.Background(), uint64(1), m.DbTx).Return([]*ethTypes.Receipt{}, nil).Once()
+
+	res, err = s.JSONRPCCall("eth_feeHistory", "0x2", "latest", []float64{10, 90})
+	require.NoError(t, err)
+	require.NotNil(t, res.Error)
+	assert.Equal(t, types.DefaultErrorCode, res.Error.Code)
+	assert.Equal(t, prunedReceiptsErrorMsg(1), res.Error.Message)
+}
+
+func Test_feeHistoryRewards(t *testing.T) {
+	rewards := feeHistoryRewards(nil, []float64{10, 90})
+	assert.Equal(t, []string{"0x0", "0x0"}, []string{rewards[0].Hex(), rewards[1].Hex()})
+
+	rewards = feeHistoryRewards([]txGasAndReward{
+		{gasUsed: 63000, reward: big.NewInt(1)},
+		{gasUsed: 21000, reward: big.NewInt(2)},
+	}, []float64{10, 90})
+	assert.Equal(t, []string{"0x1", "0x2"}, []string{rewards[0].Hex(), rewards[1].Hex()})
+}
+
+func TestGetBlockReceipts(t *testing.T) {
+	s, m, _ := newSequencerMockedServer(t)
+	defer s.Stop()
+
+	auth := operations.MustGetAuth(operations.DefaultSequencerPrivateKey, operations.DefaultL2ChainID)
+	signedTransactions := []*ethTypes.Transaction{}
+	for nonce := uint64(1); nonce <= 2; nonce++ {
+		signedTx, err := auth.Signer(auth.From, ethTypes.NewTx(&ethTypes.LegacyTx{Nonce: nonce, GasPrice: big.NewInt(1), Gas: 21000, To: state.Ptr(common.HexToAddress("0x1"))}))
+		require.NoError(t, err)
+		signedTransactions = append(signedTransactions, signedTx)
+	}
+
+	blockNumber := uint64(10)
+	receipts := []*ethTypes.Receipt{}
+	for i, tx := range signedTransactions {
+		receipts = append(receipts, &ethTypes.Receipt{
+			TxHash:           tx.Hash(),
+			TransactionIndex: uint(i),
+			GasUsed:          21000,
+			Status:           ethTypes.ReceiptStatusSuccessful,
+			BlockNumber:      big.NewInt(0).SetUint64(blockNumber),
+			Logs:             []*ethTypes.Log{{TxHash: tx.Hash(), Index: uint(i), Topics: []common.Hash{}}},
+		})
+	}
+	st := trie.NewStackTrie(nil)
+	l2Block := state.NewL2Block(state.NewL2Header(&ethTypes.Header{Number: big.NewInt(0).SetUint64(blockNumber)}), signedTransactions, nil, receipts, st)
+
+	m.DbTx.On("Commit", context.Background()).Return(nil).Once()
+	m.State.On("BeginStateTransaction", context.Background()).Return(m.DbTx, nil).Once()
+	m.State.On("GetL2BlockByNumber", context.Background(), blockNumber, m.DbTx).Return(l2Block, nil).Once()
+	m.State.On("GetL2BlockReceipts", context.Background(), blockNumber, m.DbTx).Return(receipts, nil).Once()
+
+	res, err := s.JSONRPCCall("eth_getBlockReceipts", hex.EncodeUint64(blockNumber))
+	require.NoError(t, err)
+	require.Nil(t, res.Error)
+
+	var result []types.Receipt
+	require.NoError(t, json.Unmarshal(res.Result, &result))
+	require.Len(t, result, len(receipts))
+	for i, receipt := range result {
+		assert.Equal(t, signedTransactions[i].Hash(), receipt.TxHash)
+		assert.Equal(t, types.ArgUint64(i), receipt.TxIndex)
+		assert.Equal(t, types.ArgUint64(blockNumber), receipt.BlockNumber)
+		assert.Equal(t, auth.From, receipt.FromAddr)
+		require.Len(t, receipt.Logs, 1)
+	}
+
+	// the receipts of the block have been pruned
+	m.DbTx.On("Rollback", context.Background()).Return(nil).Once()
+	m.State.On("BeginStateTransaction", context.Background()).Return(m.DbTx, nil).Once()
+	m.State.On("GetL2BlockByNumber", context.Background(), blockNumber, m.DbTx).Return(l2Block, nil).Once()
+	m.State.On("GetL2BlockReceipts", context.Background(), blockNumber, m.DbTx).Return([]*ethTypes.Receipt{}, nil).Once()
+
+	res, err = s.JSONRPCCall("eth_getBlockReceipts", hex.EncodeUint64(blockNumber))
+	require.NoError(t, err)
+	require.NotNil(t, res.Error)
+	assert.Equal(t, types.DefaultErrorCode, res.Error.Code)
+	assert.Equal(t, prunedReceiptsErrorMsg(blockNumber), res.Error.Message)
+
+	// the blocks without txs have no receipts
+	emptyBlock := state.NewL2Block(state.NewL2Header(&ethTypes.Header{Number: big.NewInt(0).SetUint64(blockNumber + 1)}), nil, nil, nil, trie.NewStackTrie(nil))
+	m.DbTx.On("Commit", context.Background()).Return(nil).Once()
+	m.State.On("BeginStateTransaction", context.Background()).Return(m.DbTx, nil).Once()
+	m.State.On("GetL2BlockByNumber", context.Background(), blockNumber+1, m.DbTx).Return(emptyBlock, nil).Once()
+	m.State.On("GetL2BlockReceipts", context.Background(), blockNumber+1, m.DbTx).Return([]*ethTypes.Receipt{}, nil).Once()
+
+	res, err = s.JSONRPCCall("eth_getBlockReceipts", hex.EncodeUint64(blockNumber+1))
+	require.NoError(t, err)
+	require.Nil(t, res.Error)
+	assert.JSONEq(t, "[]", string(res.Result))
+}
+
 func TestGetBalance(t *testing.T) {
 	s, m, _ := newSequencerMockedServer(t)
 	defer s.Stop()
@@ -1441,6 +1677,11 @@ func TestGetL2BlockByNumber(t *testing.T) {
 					On("GetLastL2Block", context.Background(), m.DbTx).
 					Return(lastBlock, nil).
 					Once()
+
+				m.Pool.
+					On("GetPendingBlock", context.Background()).
+					Return(nil, pool.ErrNotFound).
+					Once()
 			},
 		},
 		{
@@ -3176,6 +3417,49 @@ func TestGetTransactionCount(t *testing.T) {
 					Once()
 			},
 		},
+		{
+			Name: "Count pending txs up to the first nonce gap in the pool",
+			Params: []interface{}{
+				addressArg.String(),
+				"pending",
+			},
+			ExpectedResult: uint(12),
+			ExpectedError:  nil,
+			SetupMocks: func(m *mocksWrapper, tc testCase) {
+				m.DbTx.
+					On("Commit", context.Background()).
+					Return(nil).
+					Once()
+
+				m.State.
+					On("BeginStateTransaction", context.Background()).
+					Return(m.DbTx, nil).
+					Once()
+
+				m.State.
+					On("GetLastL2BlockNumber", context.Background(), m.DbTx).
+					Return(blockNumTen.Uint64(), nil).
+					Once()
+
+				block := state.NewL2BlockWithHeader(state.NewL2Header(&ethTypes.Header{Number: blockNumTen, Root: blockRoot}))
+				m.State.On("GetL2BlockByNumber", context.Background(), blockNumTenUint64, m.DbTx).Return(block, nil).Once()
+
+				m.Pool.
+					On("GetPendingBlock", context.Background()).
+					Return(nil, pool.ErrNotFound).
+					Once()
+
+				m.State.
+					On("GetNonce", context.Background(), addressArg, blockRoot).
+					Return(uint64(10), nil).
+					Once()
+
+				m.Pool.
+					On("GetPendingNonce", context.Background(), addressArg, uint64(10)).
+					Return(uint64(12), nil).
+					Once()
+			},
+		},
 		{
 			Name: "failed to get last block number",
 			Params: []interface{}{
@@ -3538,6 +3822,41 @@ func TestGetTransactionReceipt(t *testing.T) {
 	}
 }
 
+func TestGetTransactionReceiptRevertReason(t *testing.T) {
+	s, m, _ := newSequencerMockedServer(t)
+	defer s.Stop()
+
+	auth := operations.MustGetAuth(operations.DefaultSequencerPrivateKey, operations.DefaultL2ChainID)
+	signedTx, err := auth.Signer(auth.From, ethTypes.NewTransaction(1, common.HexToAddress("0x111"), big.NewInt(2), 21000, big.NewInt(4), nil))
+	require.NoError(t, err)
+
+	// ABI encoded Error("not enough balance")
+	revertData := common.Hex2Bytes("08c379a0000000000000000000000000000000000000000000000000000000000000002000000000000000000000000000000000000000000000000000000000000000126e6f7420656e6f7567682062616c616e63650000000000000000000000000000")
+	receipt := &ethTypes.Receipt{
+		Type:        signedTx.Type(),
+		BlockNumber: big.NewInt(2),
+		TxHash:      signedTx.Hash(),
+		Status:      ethTypes.ReceiptStatusFailed,
+		BlockHash:   common.HexToHash("0x1"),
+	}
+
+	m.DbTx.On("Commit", context.Background()).Return(nil).Once()
+	m.State.On("BeginStateTransaction", context.Background()).Return(m.DbTx, nil).Once()
+	m.State.On("GetTransactionByHash", context.Background(), signedTx.Hash(), m.DbTx).Return(signedTx, nil).Once()
+	m.State.On("GetTransactionReceipt", context.Background(), signedTx.Hash(), m.DbTx).Return(receipt, nil).Once()
+	m.State.On("GetTransactionRevertData", context.Background(), signedTx.Hash(), m.DbTx).Return(revertData, nil).Once()
+
+	res, err := s.JSONRPCCall("eth_getTransactionReceipt", signedTx.Hash().String())
+	require.NoError(t, err)
+	require.Nil(t, res.Error)
+
+	var result types.Receipt
+	require.NoError(t, json.Unmarshal(res.Result, &result))
+	assert.Equal(t, types.ArgUint64(ethTypes.ReceiptStatusFailed), result.Status)
+	require.NotNil(t, result.RevertReason)
+	assert.Equal(t, revertData, []byte(*result.RevertReason))
+}
+
 func TestSendRawTransactionViaGeth(t *testing.T) {
 	s, m, c := newSequencerMockedServer(t)
 	defer s.Stop()
@@ -3664,6 +3983,25 @@ func TestSendRawTransactionJSONRPCCall(t *testing.T) {
 					Once()
 			},
 		},
+		{
+			Name: "Send TX rate limited by the pool",
+			Prepare: func(t *testing.T, tc *testCase) {
+				tx := ethTypes.NewTransaction(1, common.HexToAddress("0x1"), big.NewInt(1), uint64(1), big.NewInt(1), []byte{})
+
+				txBinary, err := tx.MarshalBinary()
+				require.NoError(t, err)
+
+				tc.Input = hex.EncodeToHex(txBinary)
+				tc.ExpectedResult = nil
+				tc.ExpectedError = types.NewRPCError(types.LimitExceededErrorCode, pool.ErrTxRateLimitExceeded.Error())
+			},
+			SetupMocks: func(t *testing.T, m *mocksWrapper, tc testCase) {
+				m.Pool.
+					On("AddTx", context.Background(), mock.IsType(ethTypes.Transaction{}), "").
+					Return(pool.ErrTxRateLimitExceeded).
+					Once()
+			},
+		},
 		{
 			Name: "Send invalid tx input",
 			Prepare: func(t *testing.T, tc *testCase) {
@@ -3808,6 +4146,335 @@ func TestSendRawTransactionViaGethForNonSequencerNodeFailsToRelayTxToSequencerNo
 	}
 }
 
+func TestSendRawTransactionConditional(t *testing.T) {
+	s, m, _ := newSequencerMockedServer(t)
+	defer s.Stop()
+
+	type testCase struct {
+		Name           string
+		Input          string
+		Options        interface{}
+		ExpectedResult *common.Hash
+		ExpectedError  types.Error
+		SetupMocks     func(m *mocksWrapper, tc testCase)
+	}
+
+	tx := ethTypes.NewTransaction(1, common.HexToAddress("0x1"), big.NewInt(1), uint64(1), big.NewInt(1), []byte{})
+	txBinary, err := tx.MarshalBinary()
+	require.NoError(t, err)
+	txHash := tx.Hash()
+
+	address := common.HexToAddress("0x2")
+	slot := common.HexToHash("0x3")
+	value := common.HexToHash("0x4")
+
+	testCases := []testCase{
+		{
+			Name:  "Send conditional TX successfully",
+			Input: hex.EncodeToHex(txBinary),
+			Options: map[string]interface{}{
+				"knownAccounts":  map[string]interface{}{address.String(): map[string]string{slot.String(): value.String()}},
+				"blockNumberMin": "0x10",
+				"timestampMax":   "0x6553f100",
+			},
+			ExpectedResult: &txHash,
+			SetupMocks: func(m *mocksWrapper, tc testCase) {
+				conditions := pool.TxConditions{
+					KnownAccounts:  map[common.Address]map[common.Hash]common.Hash{address: {slot: value}},
+					BlockNumberMin: 16,
+					TimestampMax:   1700000000,
+				}
+				m.Pool.On("AddConditionalTx", context.Background(), mock.IsType(ethTypes.Transaction{}), "", conditions).Return(nil).Once()
+			},
+		},
+		{
+			Name:          "Send conditional TX with conditions not met",
+			Input:         hex.EncodeToHex(txBinary),
+			Options:       map[string]interface{}{"blockNumberMax": "0x1"},
+			ExpectedError: types.NewRPCError(types.ConditionsNotMetErrorCode, pool.ErrBlockNumberConditionNotMet.Error()),
+			SetupMocks: func(m *mocksWrapper, tc testCase) {
+				conditions := pool.TxConditions{BlockNumberMax: 1}
+				m.Pool.On("AddConditionalTx", context.Background(), mock.IsType(ethTypes.Transaction{}), "", conditions).Return(pool.ErrBlockNumberConditionNotMet).Once()
+			},
+		},
+		{
+			Name:          "Send conditional TX with the storage root of a known account",
+			Input:         hex.EncodeToHex(txBinary),
+			Options:       map[string]interface{}{"knownAccounts": map[string]string{address.String(): value.String()}},
+			ExpectedError: types.NewRPCError(types.InvalidParamsErrorCode, fmt.Sprintf("storage root of the known account %s is not supported, provide the storage slots instead", address.String())),
+			SetupMocks:    func(m *mocksWrapper, tc testCase) {},
+		},
+		{
+			Name:          "Send conditional TX with invalid tx input",
+			Input:         "0x1234",
+			Options:       map[string]interface{}{},
+			ExpectedError: types.NewRPCError(types.InvalidParamsErrorCode, "invalid tx input"),
+			SetupMocks:    func(m *mocksWrapper, tc testCase) {},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			tc := testCase
+			tc.SetupMocks(m, tc)
+
+			res, err := s.JSONRPCCall("eth_sendRawTransactionConditional", tc.Input, tc.Options)
+			require.NoError(t, err)
+
+			if tc.ExpectedResult != nil {
+				require.Nil(t, res.Error)
+				var result common.Hash
+				err = json.Unmarshal(res.Result, &result)
+				require.NoError(t, err)
+				assert.Equal(t, *tc.ExpectedResult, result)
+			}
+			if res.Error != nil || tc.ExpectedError != nil {
+				assert.Equal(t, tc.ExpectedError.ErrorCode(), res.Error.Code)
+				assert.Equal(t, tc.ExpectedError.Error(), res.Error.Message)
+			}
+		})
+	}
+}
+
+func TestSendUserOperation(t *testing.T) {
+	sequencerServer, sequencerMocks, _ := newSequencerMockedServer(t)
+	defer sequencerServer.Stop()
+	nonSequencerServer, _, _ := newNonSequencerMockedServer(t, sequencerServer.ServerURL)
+	defer nonSequencerServer.Stop()
+
+	type testCase struct {
+		Name           string
+		Server         *mockedServer
+		ExpectedResult *common.Hash
+		ExpectedError  types.Error
+		SetupMocks     func(m *mocksWrapper, tc testCase)
+	}
+
+	entryPoint := common.HexToAddress("0x5FF137D4b0FDCD49DcA30c7CF57E578a026d2789")
+	userOp := types.UserOperation{
+		Sender:               common.HexToAddress("0x1"),
+		Nonce:                types.ArgBig(*big.NewInt(1)),
+		CallData:             types.ArgBytes{0x1},
+		CallGasLimit:         types.ArgBig(*big.NewInt(100000)),
+		VerificationGasLimit: types.ArgBig(*big.NewInt(100000)),
+		PreVerificationGas:   types.ArgBig(*big.NewInt(21000)),
+		MaxFeePerGas:         types.ArgBig(*big.NewInt(10)),
+		MaxPriorityFeePerGas: types.ArgBig(*big.NewInt(1)),
+		Signature:            types.ArgBytes{0x2},
+	}
+	userOpHash := common.HexToHash("0x3")
+	userOpMatch := mock.MatchedBy(func(u pool.UserOp) bool {
+		return u.Sender == userOp.Sender && u.Nonce.Uint64() == 1
+	})
+
+	testCases := []testCase{
+		{
+			Name:           "Send user operation successfully",
+			Server:         sequencerServer,
+			ExpectedResult: &userOpHash,
+			SetupMocks: func(m *mocksWrapper, tc testCase) {
+				m.Pool.On("AddUserOperation", context.Background(), userOpMatch, entryPoint, "").Return(userOpHash, nil).Once()
+			},
+		},
+		{
+			Name:          "Send user operation with unsupported entry point",
+			Server:        sequencerServer,
+			ExpectedError: types.NewRPCError(types.DefaultErrorCode, pool.ErrUnsupportedEntryPoint.Error()),
+			SetupMocks: func(m *mocksWrapper, tc testCase) {
+				m.Pool.On("AddUserOperation", context.Background(), userOpMatch, entryPoint, "").Return(common.Hash{}, pool.ErrUnsupportedEntryPoint).Once()
+			},
+		},
+		{
+			Name:          "Send user operation exceeding the rate limit",
+			Server:        sequencerServer,
+			ExpectedError: types.NewRPCError(types.LimitExceededErrorCode, pool.ErrTxRateLimitExceeded.Error()),
+			SetupMocks: func(m *mocksWrapper, tc testCase) {
+				m.Pool.On("AddUserOperation", context.Background(), userOpMatch, entryPoint, "").Return(common.Hash{}, pool.ErrTxRateLimitExceeded).Once()
+			},
+		},
+		{
+			Name:           "Send user operation via non sequencer node",
+			Server:         nonSequencerServer,
+			ExpectedResult: &userOpHash,
+			SetupMocks: func(m *mocksWrapper, tc testCase) {
+				m.Pool.On("AddUserOperation", context.Background(), userOpMatch, entryPoint, "").Return(userOpHash, nil).Once()
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			tc := testCase
+			tc.SetupMocks(sequencerMocks, tc)
+
+			res, err := tc.Server.JSONRPCCall("eth_sendUserOperation", userOp, entryPoint)
+			require.NoError(t, err)
+
+			if tc.ExpectedResult != nil {
+				require.Nil(t, res.Error)
+				var result common.Hash
+				err = json.Unmarshal(res.Result, &result)
+				require.NoError(t, err)
+				assert.Equal(t, *tc.ExpectedResult, result)
+			}
+			if res.Error != nil || tc.ExpectedError != nil {
+				assert.Equal(t, tc.ExpectedError.ErrorCode(), res.Error.Code)
+				assert.Equal(t, tc.ExpectedError.Error(), res.Error.Message)
+			}
+		})
+	}
+}
+
+func TestSupportedEntryPoints(t *testing.T) {
+	sequencerServer, sequencerMocks, _ := newSequencerMockedServer(t)
+	defer sequencerServer.Stop()
+	nonSequencerServer, _, _ := newNonSequencerMockedServer(t, sequencerServer.ServerURL)
+	defer nonSequencerServer.Stop()
+
+	entryPoints := []common.Address{common.HexToAddress("0x5FF137D4b0FDCD49DcA30c7CF57E578a026d2789")}
+	for _, s := range []*mockedServer{sequencerServer, nonSequencerServer} {
+		sequencerMocks.Pool.On("SupportedEntryPoints").Return(entryPoints).Once()
+
+		res, err := s.JSONRPCCall("eth_supportedEntryPoints")
+		require.NoError(t, err)
+		require.Nil(t, res.Error)
+
+		var result []common.Address
+		err = json.Unmarshal(res.Result, &result)
+		require.NoError(t, err)
+		assert.Equal(t, entryPoints, result)
+	}
+}
+
+func TestGetProof(t *testing.T) {
+	s, m, _ := newSequencerMockedServer(t)
+	defer s.Stop()
+
+	blockNumber := big.NewInt(1)
+	block := state.NewL2BlockWithHeader(state.NewL2Header(&ethTypes.Header{Number: blockNumber, Root: blockRoot}))
+	newProof := func(value int64) *merkletree.MerkleProof {
+		return &merkletree.MerkleProof{
+			Value: big.NewInt(value),
+			Nodes: []merkletree.ProofNode{{Hash: common.HexToHash("0x1"), Value: [12]uint64{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}}},
+		}
+	}
+	proof := &state.AccountProof{
+		Address:          addressArg,
+		BalanceProof:     newProof(1000),
+		NonceProof:       newProof(2),
+		CodeHashProof:    newProof(0),
+		CodeLengthProof:  newProof(0),
+		StoragePositions: []*big.Int{keyArg.Big()},
+		StorageProofs:    []*merkletree.MerkleProof{newProof(123)},
+	}
+	params := []interface{}{addressArg.String(), []string{keyArg.String()}, map[string]interface{}{types.BlockNumberKey: hex.EncodeBig(blockNumOne)}}
+
+	// failed to get the proof
+	m.DbTx.On("Rollback", context.Background()).Return(nil).Once()
+	m.State.On("BeginStateTransaction", context.Background()).Return(m.DbTx, nil).Once()
+	m.State.On("GetL2BlockByNumber", context.Background(), blockNumber.Uint64(), m.DbTx).Return(block, nil).Once()
+	m.State.On("GetAccountProof", context.Background(), addressArg, []*big.Int{keyArg.Big()}, blockRoot).Return(nil, errors.New("failed to read the tree")).Once()
+
+	res, err := s.JSONRPCCall("eth_getProof", params...)
+	require.NoError(t, err)
+	require.NotNil(t, res.Error)
+	assert.Equal(t, "failed to get proof from state", res.Error.Message)
+
+	// get the proof successfully
+	m.DbTx.On("Commit", context.Background()).Return(nil).Once()
+	m.State.On("BeginStateTransaction", context.Background()).Return(m.DbTx, nil).Once()
+	m.State.On("GetL2BlockByNumber", context.Background(), blockNumber.Uint64(), m.DbTx).Return(block, nil).Once()
+	m.State.On("GetAccountProof", context.Background(), addressArg, []*big.Int{keyArg.Big()}, blockRoot).Return(proof, nil).Once()
+
+	res, err = s.JSONRPCCall("eth_getProof", params...)
+	require.NoError(t, err)
+	require.Nil(t, res.Error)
+
+	var result types.AccountProof
+	err = json.Unmarshal(res.Result, &result)
+	require.NoError(t, err)
+	assert.Equal(t, addressArg, result.Address)
+	assert.Equal(t, big.NewInt(1000).String(), (*big.Int)(&result.Balance).String())
+	assert.Equal(t, types.ArgUint64(2), result.Nonce)
+	require.Len(t, result.BalanceProof, 1)
+	assert.Len(t, result.BalanceProof[0], 96)
+	require.Len(t, result.StorageProof, 1)
+	assert.Equal(t, common.BigToHash(keyArg.Big()), result.StorageProof[0].Key)
+	assert.Equal(t, big.NewInt(123).String(), (*big.Int)(&result.StorageProof[0].Value).String())
+}
+
+func TestCreateAccessList(t *testing.T) {
+	s, m, _ := newSequencerMockedServer(t)
+	defer s.Stop()
+
+	nonce := uint64(7)
+	from := common.HexToAddress("0x1")
+	block := state.NewL2BlockWithHeader(state.NewL2Header(&ethTypes.Header{Number: blockNumOne, Root: blockRoot}))
+	accessList := ethTypes.AccessList{{Address: common.HexToAddress("0x3"), StorageKeys: []common.Hash{common.HexToHash("0x4")}}}
+	params := []interface{}{
+		types.TxArgs{
+			From: &from,
+			To:   state.HexToAddressPtr("0x2"),
+			Gas:  types.ArgUint64Ptr(24000),
+			Data: types.ArgBytesPtr([]byte("data")),
+		},
+		map[string]interface{}{types.BlockNumberKey: hex.EncodeBig(blockNumOne)},
+	}
+	txMatchBy := mock.MatchedBy(func(tx *ethTypes.Transaction) bool {
+		return tx != nil && tx.To().Hex() == common.HexToAddress("0x2").Hex() && tx.Gas() == 24000 && tx.Nonce() == nonce
+	})
+
+	// failed to execute the transaction
+	m.DbTx.On("Rollback", context.Background()).Return(nil).Once()
+	m.State.On("BeginStateTransaction", context.Background()).Return(m.DbTx, nil).Once()
+	m.State.On("GetL2BlockByNumber", context.Background(), blockNumOneUint64, m.DbTx).Return(block, nil).Once()
+	m.State.On("GetNonce", context.Background(), from, blockRoot).Return(nonce, nil).Once()
+	m.State.On("CreateAccessList", context.Background(), txMatchBy, from, &blockNumOneUint64, m.DbTx).Return(nil, nil, errors.New("failed to process")).Once()
+
+	res, err := s.JSONRPCCall("eth_createAccessList", params...)
+	require.NoError(t, err)
+	require.NotNil(t, res.Error)
+	assert.Equal(t, "failed to execute the unsigned transaction: failed to process", res.Error.Message)
+
+	// reverted transaction
+	m.DbTx.On("Commit", context.Background()).Return(nil).Once()
+	m.State.On("BeginStateTransaction", context.Background()).Return(m.DbTx, nil).Once()
+	m.State.On("GetL2BlockByNumber", context.Background(), blockNumOneUint64, m.DbTx).Return(block, nil).Once()
+	m.State.On("GetNonce", context.Background(), from, blockRoot).Return(nonce, nil).Once()
+	m.State.On("CreateAccessList", context.Background(), txMatchBy, from, &blockNumOneUint64, m.DbTx).
+		Return(accessList, &runtime.ExecutionResult{GasUsed: 21500, Err: runtime.ErrExecutionReverted}, nil).Once()
+
+	res, err = s.JSONRPCCall("eth_createAccessList", params...)
+	require.NoError(t, err)
+	require.Nil(t, res.Error)
+
+	var result types.AccessListResult
+	err = json.Unmarshal(res.Result, &result)
+	require.NoError(t, err)
+	assert.Equal(t, accessList, result.AccessList)
+	assert.Equal(t, types.ArgUint64(21500), result.GasUsed)
+	assert.Equal(t, runtime.ErrExecutionReverted.Error(), result.Error)
+
+	// access list created successfully
+	m.DbTx.On("Commit", context.Background()).Return(nil).Once()
+	m.State.On("BeginStateTransaction", context.Background()).Return(m.DbTx, nil).Once()
+	m.State.On("GetL2BlockByNumber", context.Background(), blockNumOneUint64, m.DbTx).Return(block, nil).Once()
+	m.State.On("GetNonce", context.Background(), from, blockRoot).Return(nonce, nil).Once()
+	m.State.On("CreateAccessList", context.Background(), txMatchBy, from, &blockNumOneUint64, m.DbTx).
+		Return(accessList, &runtime.ExecutionResult{GasUsed: 23000}, nil).Once()
+
+	res, err = s.JSONRPCCall("eth_createAccessList", params...)
+	require.NoError(t, err)
+	require.Nil(t, res.Error)
+
+	result = types.AccessListResult{}
+	err = json.Unmarshal(res.Result, &result)
+	require.NoError(t, err)
+	assert.Equal(t, accessList, result.AccessList)
+	assert.Equal(t, types.ArgUint64(23000), result.GasUsed)
+	assert.Empty(t, result.Error)
+}
+
 func TestProtocolVersion(t *testing.T) {
 	s, _, _ := newSequencerMockedServer(t)
 	defer s.Stop()
@@ -3863,7 +4530,7 @@ func TestNewFilter(t *testing.T) {
 					Once()
 
 				m.Storage.
-					On("NewLogFilter", mock.IsType(&concurrentWsConn{}), mock.IsType(LogFilter{})).
+					On("NewLogFilter", mock.IsType(&concurrentWsConn{}), "127.0.0.1", mock.IsType(LogFilter{})).
 					Return("1", nil).
 					Once()
 			},
@@ -3887,7 +4554,7 @@ func TestNewFilter(t *testing.T) {
 					Once()
 
 				m.Storage.
-					On("NewLogFilter", mock.IsType(&concurrentWsConn{}), mock.IsType(LogFilter{})).
+					On("NewLogFilter", mock.IsType(&concurrentWsConn{}), "127.0.0.1", mock.IsType(LogFilter{})).
 					Return("1", nil).
 					Once()
 			},
@@ -3950,7 +4617,7 @@ func TestNewFilter(t *testing.T) {
 					Return(m.DbTx, nil).
 					Once()
 				m.Storage.
-					On("NewLogFilter", mock.IsType(&concurrentWsConn{}), mock.IsType(LogFilter{})).
+					On("NewLogFilter", mock.IsType(&concurrentWsConn{}), "127.0.0.1", mock.IsType(LogFilter{})).
 					Return("", errors.New("failed to add new filter")).
 					Once()
 			},
@@ -4001,7 +4668,7 @@ func TestNewBlockFilter(t *testing.T) {
 			ExpectedError:  nil,
 			SetupMocks: func(m *mocksWrapper, tc testCase) {
 				m.Storage.
-					On("NewBlockFilter", mock.IsType(&concurrentWsConn{})).
+					On("NewBlockFilter", mock.IsType(&concurrentWsConn{}), "127.0.0.1").
 					Return("1", nil).
 					Once()
 			},
@@ -4012,11 +4679,22 @@ func TestNewBlockFilter(t *testing.T) {
 			ExpectedError:  types.NewRPCError(types.DefaultErrorCode, "failed to create new block filter"),
 			SetupMocks: func(m *mocksWrapper, tc testCase) {
 				m.Storage.
-					On("NewBlockFilter", mock.IsType(&concurrentWsConn{})).
+					On("NewBlockFilter", mock.IsType(&concurrentWsConn{}), "127.0.0.1").
 					Return("", errors.New("failed to add new block filter")).
 					Once()
 			},
 		},
+		{
+			Name:           "max number of filters reached",
+			ExpectedResult: "",
+			ExpectedError:  types.NewRPCError(types.DefaultErrorCode, ErrMaxFiltersReached.Error()),
+			SetupMocks: func(m *mocksWrapper, tc testCase) {
+				m.Storage.
+					On("NewBlockFilter", mock.IsType(&concurrentWsConn{}), "127.0.0.1").
+					Return("", ErrMaxFiltersReached).
+					Once()
+			},
+		},
 	}
 
 	for _, testCase := range testCases {
@@ -4057,33 +4735,27 @@ func TestNewPendingTransactionFilter(t *testing.T) {
 	}
 
 	testCases := []testCase{
-		// {
-		// 	Name:           "New pending transaction filter created successfully",
-		// 	ExpectedResult: "1",
-		// 	ExpectedError:  nil,
-		// 	SetupMocks: func(m *mocksWrapper, tc testCase) {
-		// 		m.Storage.
-		// 			On("NewPendingTransactionFilter", mock.IsType(&concurrentWsConn{})).
-		// 			Return("1", nil).
-		// 			Once()
-		// 	},
-		// },
-		// {
-		// 	Name:           "failed to create new pending transaction filter",
-		// 	ExpectedResult: "",
-		// 	ExpectedError:  types.NewRPCError(types.DefaultErrorCode, "failed to create new pending transaction filter"),
-		// 	SetupMocks: func(m *mocksWrapper, tc testCase) {
-		// 		m.Storage.
-		// 			On("NewPendingTransactionFilter", mock.IsType(&concurrentWsConn{})).
-		// 			Return("", errors.New("failed to add new pending transaction filter")).
-		// 			Once()
-		// 	},
-		// },
-		{
-			Name:           "can't create pending tx filter",
+		{
+			Name:           "New pending transaction filter created successfully",
+			ExpectedResult: "1",
+			ExpectedError:  nil,
+			SetupMocks: func(m *mocksWrapper, tc testCase) {
+				m.Storage.
+					On("NewPendingTransactionFilter", mock.IsType(&concurrentWsConn{}), "127.0.0.1").
+					Return("1", nil).
+					Once()
+			},
+		},
+		{
+			Name:           "failed to create new pending transaction filter",
 			ExpectedResult: "",
-			ExpectedError:  types.NewRPCError(types.DefaultErrorCode, "not supported yet"),
-			SetupMocks:     func(m *mocksWrapper, tc testCase) {},
+			ExpectedError:  types.NewRPCError(types.DefaultErrorCode, "failed to create new pending transaction filter"),
+			SetupMocks: func(m *mocksWrapper, tc testCase) {
+				m.Storage.
+					On("NewPendingTransactionFilter", mock.IsType(&concurrentWsConn{}), "127.0.0.1").
+					Return("", errors.New("failed to add new pending transaction filter")).
+					Once()
+			},
 		},
 	}
 
@@ -5131,7 +5803,7 @@ func TestSubscribeNewHeads(t *testing.T) {
 			Name: "Subscribe to new heads Successfully",
 			SetupMocks: func(m *mocksWrapper, tc testCase) {
 				m.Storage.
-					On("NewBlockFilter", mock.IsType(&concurrentWsConn{})).
+					On("NewBlockFilter", mock.IsType(&concurrentWsConn{}), "127.0.0.1").
 					Return("0x1", nil).
 					Once()
 			},
@@ -5141,7 +5813,7 @@ func TestSubscribeNewHeads(t *testing.T) {
 			ExpectedError: types.NewRPCError(types.DefaultErrorCode, "failed to create new block filter"),
 			SetupMocks: func(m *mocksWrapper, tc testCase) {
 				m.Storage.
-					On("NewBlockFilter", mock.IsType(&concurrentWsConn{})).
+					On("NewBlockFilter", mock.IsType(&concurrentWsConn{}), "127.0.0.1").
 					Return("", fmt.Errorf("failed to add filter to storage")).
 					Once()
 			},
@@ -5209,7 +5881,7 @@ func TestSubscribeNewLogs(t *testing.T) {
 					Once()
 
 				m.Storage.
-					On("NewLogFilter", mock.IsType(&concurrentWsConn{}), mock.IsType(LogFilter{})).
+					On("NewLogFilter", mock.IsType(&concurrentWsConn{}), "127.0.0.1", mock.IsType(LogFilter{})).
 					Return("0x1", nil).
 					Once()
 			},
@@ -5234,7 +5906,7 @@ func TestSubscribeNewLogs(t *testing.T) {
 					Once()
 
 				m.Storage.
-					On("NewLogFilter", mock.IsType(&concurrentWsConn{}), mock.IsType(LogFilter{})).
+					On("NewLogFilter", mock.IsType(&concurrentWsConn{}), "127.0.0.1", mock.IsType(LogFilter{})).
 					Return("", fmt.Errorf("failed to add filter to storage")).
 					Once()
 			},
@@ -5290,6 +5962,42 @@ func TestSubscribeNewLogs(t *testing.T) {
 	}
 }
 
+func TestNotifyNewPendingTxs(t *testing.T) {
+	pool := mocks.NewPoolMock(t)
+	storage := newStorageMock(t)
+	e := &EthEndpoints{pool: pool, storage: storage}
+	filter := &Filter{ID: "0x1", wsQueue: state.NewQueue[[]byte](), wsQueueSignal: sync.NewCond(&sync.Mutex{})}
+	since := time.Now().UTC().Add(-time.Minute)
+	tx1 := common.HexToHash("0x1")
+	tx2 := common.HexToHash("0x2")
+
+	// without pending tx filters the pool is not checked
+	storage.On("GetAllPendingTxFiltersWithWSConn").Return([]*Filter{}).Once()
+	checkTime, notified := e.notifyNewPendingTxs(since, map[common.Hash]struct{}{})
+	assert.True(t, checkTime.After(since))
+	assert.Empty(t, notified)
+
+	// the new pending txs are notified
+	storage.On("GetAllPendingTxFiltersWithWSConn").Return([]*Filter{filter}).Twice()
+	pool.On("GetPendingTxHashesSince", context.Background(), since).Return([]common.Hash{tx1}, nil).Once()
+	checkTime, notified = e.notifyNewPendingTxs(since, notified)
+	assert.Equal(t, map[common.Hash]struct{}{tx1: {}}, notified)
+
+	// the txs already notified are not notified again
+	pool.On("GetPendingTxHashesSince", context.Background(), checkTime).Return([]common.Hash{tx1, tx2}, nil).Once()
+	_, notified = e.notifyNewPendingTxs(checkTime, notified)
+	assert.Equal(t, map[common.Hash]struct{}{tx1: {}, tx2: {}}, notified)
+
+	require.Equal(t, 2, filter.wsQueue.Len())
+	for _, expectedHash := range []common.Hash{tx1, tx2} {
+		data, err := filter.wsQueue.Pop()
+		require.NoError(t, err)
+		var hash common.Hash
+		require.NoError(t, json.Unmarshal(data, &hash))
+		assert.Equal(t, expectedHash, hash)
+	}
+}
+
 func TestFilterLogs(t *testing.T) {
 	logs := []*ethTypes.Log{{
 		Address: common.HexToAddress("0x1"),
@@ -5385,6 +6093,26 @@ func TestFilterLogs(t *testing.T) {
 	assert.Equal(t, 0, len(filteredLogs))
 }
 
+func TestShouldSkipLogFilter(t *testing.T) {
+	address := common.HexToAddress("0x1")
+	topic := common.HexToHash("0xA")
+	receipts := []*ethTypes.Receipt{{Logs: []*ethTypes.Log{{Address: address, Topics: []common.Hash{topic}}}}}
+	header := state.NewL2Header(&ethTypes.Header{Number: big.NewInt(1)})
+	block := state.NewL2Block(header, []*ethTypes.Transaction{}, []*state.L2Header{}, receipts, trie.NewStackTrie(nil))
+	event := state.NewL2BlockEvent{Block: *block}
+	blockHash := block.Hash()
+	e := &EthEndpoints{}
+
+	// the block bloom matches the filter
+	assert.False(t, e.shouldSkipLogFilter(event, &Filter{Parameters: LogFilter{BlockHash: &blockHash, Addresses: []common.Address{address}, Topics: [][]common.Hash{{topic}}}}))
+
+	// the block bloom doesn't match the address of the filter
+	assert.True(t, e.shouldSkipLogFilter(event, &Filter{Parameters: LogFilter{BlockHash: &blockHash, Addresses: []common.Address{common.HexToAddress("0x2")}}}))
+
+	// the block bloom doesn't match the topics of the filter
+	assert.True(t, e.shouldSkipLogFilter(event, &Filter{Parameters: LogFilter{BlockHash: &blockHash, Topics: [][]common.Hash{{common.HexToHash("0xB")}}}}))
+}
+
 func TestContains(t *testing.T) {
 	items := []int{1, 2, 3}
 	assert.Equal(t, false, contains(items, 0))
@@ -5416,3 +6144,112 @@ func TestParalelize(t *testing.T) {
 	assert.ElementsMatch(t, []int{13, 14, 15}, results[4])
 	assert.ElementsMatch(t, []int{16}, results[5])
 }
+
+func TestIsLocalTxRequest(t *testing.T) {
+	e := &EthEndpoints{cfg: Config{LocalTxsAPIKeys: []string{"key1", "key2"}}}
+
+	newRequest := func(apiKey string) *http.Request {
+		req := httptest.NewRequest(http.MethodPost, "/", nil)
+		if apiKey != "" {
+			req.Header.Set(apiKeyHeader, apiKey)
+		}
+		return req
+	}
+
+	assert.True(t, e.isLocalTxRequest(newRequest("key1")))
+	assert.True(t, e.isLocalTxRequest(newRequest("key2")))
+	assert.False(t, e.isLocalTxRequest(newRequest("key3")))
+	assert.False(t, e.isLocalTxRequest(newRequest("")))
+	assert.False(t, e.isLocalTxRequest(nil))
+
+	e.cfg.LocalTxsAPIKeys = nil
+	assert.False(t, e.isLocalTxRequest(newRequest("key1")))
+}
+
+func TestPendingBlockProcessedBySequencer(t *testing.T) {
+	s, m, _ := newSequencerMockedServer(t)
+	defer s.Stop()
+
+	auth := operations.MustGetAuth(operations.DefaultSequencerPrivateKey, operations.DefaultL2ChainID)
+	signedTx, err := auth.Signer(auth.From, ethTypes.NewTx(&ethTypes.LegacyTx{Nonce: 4, GasPrice: big.NewInt(1), Gas: 21000, To: state.Ptr(common.HexToAddress("0x2"))}))
+	require.NoError(t, err)
+
+	lastBlockRoot := common.HexToHash("0x1")
+	lastBlock := state.NewL2BlockWithHeader(state.NewL2Header(&ethTypes.Header{Number: big.NewInt(10), Root: lastBlockRoot, GasLimit: 30000000}))
+	pendingBlock := &pool.PendingBlock{
+		Number:     11,
+		ParentHash: lastBlock.Hash(),
+		StateRoot:  common.HexToHash("0x2"),
+		Timestamp:  time.Unix(1000, 0),
+		TxHashes:   []common.Hash{signedTx.Hash()},
+	}
+	blockArgMocks := func(pendingBlock *pool.PendingBlock) {
+		m.DbTx.On("Commit", context.Background()).Return(nil).Once()
+		m.State.On("BeginStateTransaction", context.Background()).Return(m.DbTx, nil).Once()
+		m.State.On("GetLastL2BlockNumber", context.Background(), m.DbTx).Return(lastBlock.NumberU64(), nil).Once()
+		m.State.On("GetL2BlockByNumber", context.Background(), lastBlock.NumberU64(), m.DbTx).Return(lastBlock, nil).Once()
+		m.Pool.On("GetPendingBlock", context.Background()).Return(pendingBlock, nil).Once()
+	}
+
+	t.Run("eth_getTransactionCount uses the state of the pending block", func(t *testing.T) {
+		blockArgMocks(pendingBlock)
+		m.State.On("GetNonce", context.Background(), auth.From, pendingBlock.StateRoot).Return(uint64(5), nil).Once()
+		m.Pool.On("GetPendingNonce", context.Background(), auth.From, uint64(5)).Return(uint64(5), nil).Once()
+
+		res, err := s.JSONRPCCall("eth_getTransactionCount", auth.From.String(), "pending")
+		require.NoError(t, err)
+		require.Nil(t, res.Error)
+		var nonce types.ArgUint64
+		require.NoError(t, json.Unmarshal(res.Result, &nonce))
+		assert.Equal(t, types.ArgUint64(5), nonce)
+	})
+
+	t.Run("eth_call is executed on top of the pending block", func(t *testing.T) {
+		blockArgMocks(pendingBlock)
+		m.State.On("GetL2BlockHeaderByNumber", context.Background(), lastBlock.NumberU64(), m.DbTx).Return(lastBlock.Header(), nil).Once()
+		m.State.On("GetNonce", context.Background(), auth.From, pendingBlock.StateRoot).Return(uint64(5), nil).Once()
+		m.State.
+			On("ProcessUnsignedTransactionOnPendingL2Block", context.Background(), mock.Anything, auth.From, pendingBlock.StateRoot, uint64(1000), true, state.StateOverride(nil), m.DbTx).
+			Return(&runtime.ExecutionResult{ReturnValue: []byte{0x1}}, nil).
+			Once()
+
+		res, err := s.JSONRPCCall("eth_call", types.TxArgs{From: &auth.From, To: state.HexToAddressPtr("0x2")}, "pending")
+		require.NoError(t, err)
+		require.Nil(t, res.Error)
+		assert.Equal(t, `"0x01"`, string(res.Result))
+	})
+
+	t.Run("eth_getBlockByNumber returns the pending block with its txs", func(t *testing.T) {
+		m.DbTx.On("Commit", context.Background()).Return(nil).Once()
+		m.State.On("BeginStateTransaction", context.Background()).Return(m.DbTx, nil).Once()
+		m.State.On("GetLastL2Block", context.Background(), m.DbTx).Return(lastBlock, nil).Once()
+		m.Pool.On("GetPendingBlock", context.Background()).Return(pendingBlock, nil).Once()
+		m.Pool.On("GetTransactionByHash", context.Background(), signedTx.Hash()).Return(&pool.Transaction{Transaction: *signedTx}, nil).Once()
+
+		res, err := s.JSONRPCCall("eth_getBlockByNumber", "pending", false)
+		require.NoError(t, err)
+		require.Nil(t, res.Error)
+		var block types.Block
+		require.NoError(t, json.Unmarshal(res.Result, &block))
+		assert.Equal(t, types.ArgUint64(11), block.Number)
+		assert.Equal(t, lastBlock.Hash(), block.ParentHash)
+		assert.Equal(t, pendingBlock.StateRoot, block.StateRoot)
+		assert.Equal(t, types.ArgUint64(1000), block.Timestamp)
+		assert.Nil(t, block.Hash)
+		require.Len(t, block.Transactions, 1)
+		assert.Equal(t, signedTx.Hash(), *block.Transactions[0].Hash)
+	})
+
+	t.Run("the pending block is ignored once it's stored in the state", func(t *testing.T) {
+		blockArgMocks(&pool.PendingBlock{Number: lastBlock.NumberU64(), StateRoot: common.HexToHash("0x3")})
+		m.State.On("GetNonce", context.Background(), auth.From, lastBlockRoot).Return(uint64(4), nil).Once()
+		m.Pool.On("GetPendingNonce", context.Background(), auth.From, uint64(4)).Return(uint64(4), nil).Once()
+
+		res, err := s.JSONRPCCall("eth_getTransactionCount", auth.From.String(), "pending")
+		require.NoError(t, err)
+		require.Nil(t, res.Error)
+		var nonce types.ArgUint64
+		require.NoError(t, json.Unmarshal(res.Result, &nonce))
+		assert.Equal(t, types.ArgUint64(4), nonce)
+	})
+}
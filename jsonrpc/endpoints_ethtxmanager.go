@@ -0,0 +1,108 @@
+package jsonrpc
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/0xPolygonHermez/zkevm-node/ethtxmanager"
+	"github.com/0xPolygonHermez/zkevm-node/jsonrpc/types"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// EthTxManagerEndpoints is the ethtxmanager jsonrpc endpoint, it exposes the
+// status of the L1 txs sent by the sequence sender and the aggregator
+type EthTxManagerEndpoints struct {
+	cfg          Config
+	ethTxManager types.EthTxManagerInterface
+}
+
+// NewEthTxManagerEndpoints returns EthTxManagerEndpoints
+func NewEthTxManagerEndpoints(cfg Config, ethTxManager types.EthTxManagerInterface) *EthTxManagerEndpoints {
+	return &EthTxManagerEndpoints{
+		cfg:          cfg,
+		ethTxManager: ethTxManager,
+	}
+}
+
+type monitoredTxResponse struct {
+	ID     string                     `json:"id"`
+	Status string                     `json:"status"`
+	Txs    []*monitoredTxHistoryEntry `json:"txs"`
+}
+
+type monitoredTxHistoryEntry struct {
+	Hash          common.Hash      `json:"hash"`
+	Nonce         *types.ArgUint64 `json:"nonce"`
+	GasPrice      *types.ArgBig    `json:"gasPrice"`
+	BlockNumber   *types.ArgUint64 `json:"blockNumber"`
+	ReceiptStatus *types.ArgUint64 `json:"receiptStatus"`
+	RevertMessage string           `json:"revertMessage,omitempty"`
+}
+
+// GetMonitoredTx returns the status of the monitored tx of the owner with the given id
+// and the txs sent to L1 for it, or null if the monitored tx doesn't exist
+func (e *EthTxManagerEndpoints) GetMonitoredTx(owner, id string) (interface{}, types.Error) {
+	result, err := e.ethTxManager.Result(context.Background(), owner, id, nil)
+	if errors.Is(err, ethtxmanager.ErrNotFound) {
+		return nil, nil
+	} else if err != nil {
+		return RPCErrorResponse(types.DefaultErrorCode, fmt.Sprintf("failed to get monitored tx %v of %v", id, owner), err, true)
+	}
+
+	return newMonitoredTxResponse(result), nil
+}
+
+// GetMonitoredTxsByStatus returns the monitored txs of the owner matching the given
+// statuses, all the statuses are considered if no status is provided
+func (e *EthTxManagerEndpoints) GetMonitoredTxsByStatus(owner string, statuses []string) (interface{}, types.Error) {
+	mTxStatuses := make([]ethtxmanager.MonitoredTxStatus, 0, len(statuses))
+	for _, status := range statuses {
+		mTxStatuses = append(mTxStatuses, ethtxmanager.MonitoredTxStatus(status))
+	}
+
+	results, err := e.ethTxManager.ResultsByStatus(context.Background(), owner, mTxStatuses, nil)
+	if err != nil {
+		return RPCErrorResponse(types.DefaultErrorCode, fmt.Sprintf("failed to get monitored txs of %v", owner), err, true)
+	}
+
+	res := make([]*monitoredTxResponse, 0, len(results))
+	for _, result := range results {
+		res = append(res, newMonitoredTxResponse(result))
+	}
+
+	return res, nil
+}
+
+func newMonitoredTxResponse(result ethtxmanager.MonitoredTxResult) *monitoredTxResponse {
+	res := &monitoredTxResponse{
+		ID:     result.ID,
+		Status: string(result.Status),
+		Txs:    make([]*monitoredTxHistoryEntry, 0, len(result.Txs)),
+	}
+	for hash, txResult := range result.Txs {
+		entry := &monitoredTxHistoryEntry{
+			Hash:          hash,
+			RevertMessage: txResult.RevertMessage,
+		}
+		if txResult.Tx != nil {
+			entry.Nonce = types.ArgUint64Ptr(types.ArgUint64(txResult.Tx.Nonce()))
+			gasPrice := types.ArgBig(*txResult.Tx.GasPrice())
+			entry.GasPrice = &gasPrice
+		}
+		if txResult.Receipt != nil {
+			if txResult.Receipt.BlockNumber != nil {
+				entry.BlockNumber = types.ArgUint64Ptr(types.ArgUint64(txResult.Receipt.BlockNumber.Uint64()))
+			}
+			entry.ReceiptStatus = types.ArgUint64Ptr(types.ArgUint64(txResult.Receipt.Status))
+		}
+		res.Txs = append(res.Txs, entry)
+	}
+	sort.Slice(res.Txs, func(i, j int) bool {
+		return bytes.Compare(res.Txs[i].Hash.Bytes(), res.Txs[j].Hash.Bytes()) < 0
+	})
+
+	return res
+}
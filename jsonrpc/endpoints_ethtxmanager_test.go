@@ -0,0 +1,100 @@
+package jsonrpc
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/0xPolygonHermez/zkevm-node/ethtxmanager"
+	"github.com/0xPolygonHermez/zkevm-node/jsonrpc/types"
+	"github.com/ethereum/go-ethereum/common"
+	ethTypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/jackc/pgx/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEthTxManagerEndpoints(t *testing.T) {
+	s, m, _ := newSequencerMockedServer(t)
+	defer s.Stop()
+
+	to := common.HexToAddress("0x1")
+	tx1 := ethTypes.NewTransaction(5, to, big.NewInt(0), 21000, big.NewInt(10), nil)
+	tx2 := ethTypes.NewTransaction(5, to, big.NewInt(0), 21000, big.NewInt(20), nil)
+	receipt := &ethTypes.Receipt{Status: ethTypes.ReceiptStatusSuccessful, BlockNumber: big.NewInt(100)}
+	result := ethtxmanager.MonitoredTxResult{
+		ID:     "sequence-from-1-to-2",
+		Status: ethtxmanager.MonitoredTxStatusConfirmed,
+		Txs: map[common.Hash]ethtxmanager.TxResult{
+			tx1.Hash(): {Tx: tx1},
+			tx2.Hash(): {Tx: tx2, Receipt: receipt},
+		},
+	}
+
+	// ethtxmanager_getMonitoredTx
+	m.EthTxManager.
+		On("Result", context.Background(), "sequencer", result.ID, pgx.Tx(nil)).
+		Return(result, nil).
+		Once()
+
+	res, err := s.JSONRPCCall("ethtxmanager_getMonitoredTx", "sequencer", result.ID)
+	require.NoError(t, err)
+	require.Nil(t, res.Error)
+
+	var mTx monitoredTxResponse
+	require.NoError(t, json.Unmarshal(res.Result, &mTx))
+	assert.Equal(t, result.ID, mTx.ID)
+	assert.Equal(t, string(ethtxmanager.MonitoredTxStatusConfirmed), mTx.Status)
+	require.Len(t, mTx.Txs, 2)
+	for _, entry := range mTx.Txs {
+		assert.Equal(t, uint64(5), uint64(*entry.Nonce))
+		if entry.Hash == tx2.Hash() {
+			assert.Equal(t, int64(20), (*big.Int)(entry.GasPrice).Int64())
+			assert.Equal(t, uint64(100), uint64(*entry.BlockNumber))
+			assert.Equal(t, ethTypes.ReceiptStatusSuccessful, uint64(*entry.ReceiptStatus))
+		} else {
+			assert.Equal(t, tx1.Hash(), entry.Hash)
+			assert.Nil(t, entry.BlockNumber)
+			assert.Nil(t, entry.ReceiptStatus)
+		}
+	}
+
+	m.EthTxManager.
+		On("Result", context.Background(), "sequencer", "unknown", pgx.Tx(nil)).
+		Return(ethtxmanager.MonitoredTxResult{}, ethtxmanager.ErrNotFound).
+		Once()
+
+	res, err = s.JSONRPCCall("ethtxmanager_getMonitoredTx", "sequencer", "unknown")
+	require.NoError(t, err)
+	require.Nil(t, res.Error)
+	assert.Equal(t, "null", string(res.Result))
+
+	// ethtxmanager_getMonitoredTxsByStatus
+	statuses := []ethtxmanager.MonitoredTxStatus{ethtxmanager.MonitoredTxStatusSent, ethtxmanager.MonitoredTxStatusConfirmed}
+	m.EthTxManager.
+		On("ResultsByStatus", context.Background(), "sequencer", statuses, pgx.Tx(nil)).
+		Return([]ethtxmanager.MonitoredTxResult{result}, nil).
+		Once()
+
+	res, err = s.JSONRPCCall("ethtxmanager_getMonitoredTxsByStatus", "sequencer", []string{"sent", "confirmed"})
+	require.NoError(t, err)
+	require.Nil(t, res.Error)
+
+	var mTxs []monitoredTxResponse
+	require.NoError(t, json.Unmarshal(res.Result, &mTxs))
+	require.Len(t, mTxs, 1)
+	assert.Equal(t, result.ID, mTxs[0].ID)
+
+	m.EthTxManager.
+		On("ResultsByStatus", context.Background(), "aggregator", []ethtxmanager.MonitoredTxStatus{}, pgx.Tx(nil)).
+		Return(nil, errors.New("failed to connect to db")).
+		Once()
+
+	res, err = s.JSONRPCCall("ethtxmanager_getMonitoredTxsByStatus", "aggregator", []string{})
+	require.NoError(t, err)
+	require.NotNil(t, res.Error)
+	assert.Equal(t, types.DefaultErrorCode, res.Error.Code)
+	assert.Equal(t, "failed to get monitored txs of aggregator", res.Error.Message)
+}
@@ -0,0 +1,60 @@
+package jsonrpc
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+
+	"github.com/0xPolygonHermez/zkevm-node/jsonrpc/client"
+	"github.com/0xPolygonHermez/zkevm-node/jsonrpc/types"
+	"github.com/0xPolygonHermez/zkevm-node/pool"
+)
+
+// PoolEndpoints contains implementations for the "pool" RPC endpoints
+type PoolEndpoints struct {
+	cfg  Config
+	pool types.PoolInterface
+}
+
+// NewPoolEndpoints returns PoolEndpoints
+func NewPoolEndpoints(cfg Config, p types.PoolInterface) *PoolEndpoints {
+	return &PoolEndpoints{
+		cfg:  cfg,
+		pool: p,
+	}
+}
+
+// GetTxStatus returns the status of a tx in the pool and, if the tx was discarded
+// by the sequencer, the reason why it was discarded
+func (p *PoolEndpoints) GetTxStatus(hash types.ArgHash) (interface{}, types.Error) {
+	if p.cfg.SequencerNodeURI != "" {
+		return p.getTxStatusFromSequencerNode(hash)
+	}
+
+	poolTx, err := p.pool.GetTransactionByHash(context.Background(), hash.Hash())
+	if errors.Is(err, pool.ErrNotFound) {
+		return nil, nil
+	} else if err != nil {
+		return RPCErrorResponse(types.DefaultErrorCode, "failed to load transaction by hash from pool", err, true)
+	}
+
+	return types.NewPoolTxStatus(*poolTx), nil
+}
+
+func (p *PoolEndpoints) getTxStatusFromSequencerNode(hash types.ArgHash) (interface{}, types.Error) {
+	res, err := client.JSONRPCCall(p.cfg.SequencerNodeURI, "pool_getTxStatus", hash.Hash().String())
+	if err != nil {
+		return RPCErrorResponse(types.DefaultErrorCode, "failed to get tx status from sequencer node", err, true)
+	}
+
+	if res.Error != nil {
+		return RPCErrorResponse(res.Error.Code, res.Error.Message, nil, false)
+	}
+
+	var txStatus *types.PoolTxStatus
+	err = json.Unmarshal(res.Result, &txStatus)
+	if err != nil {
+		return RPCErrorResponse(types.DefaultErrorCode, "failed to read tx status from sequencer node", err, true)
+	}
+	return txStatus, nil
+}
@@ -0,0 +1,56 @@
+package jsonrpc
+
+import (
+	"context"
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	"github.com/0xPolygonHermez/zkevm-node/jsonrpc/types"
+	"github.com/0xPolygonHermez/zkevm-node/pool"
+	"github.com/ethereum/go-ethereum/common"
+	ethTypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetTxStatus(t *testing.T) {
+	s, m, _ := newSequencerMockedServer(t)
+	defer s.Stop()
+
+	failedReason := "invalid balance"
+	tx := ethTypes.NewTransaction(1, common.HexToAddress("0x111"), big.NewInt(2), 3, big.NewInt(4), []byte{5, 6, 7, 8})
+	poolTx := pool.NewTransaction(*tx, "", false)
+	poolTx.Status = pool.TxStatusFailed
+	poolTx.FailedReason = &failedReason
+
+	m.Pool.
+		On("GetTransactionByHash", context.Background(), tx.Hash()).
+		Return(poolTx, nil).
+		Once()
+
+	res, err := s.JSONRPCCall("pool_getTxStatus", tx.Hash().String())
+	require.NoError(t, err)
+	require.Nil(t, res.Error)
+
+	var result types.PoolTxStatus
+	err = json.Unmarshal(res.Result, &result)
+	require.NoError(t, err)
+
+	assert.Equal(t, tx.Hash(), result.Hash)
+	assert.Equal(t, pool.TxStatusFailed.String(), result.Status)
+	require.NotNil(t, result.FailedReason)
+	assert.Equal(t, failedReason, *result.FailedReason)
+
+	// the tx is unknown by the pool
+	unknownHash := common.HexToHash("0x123")
+	m.Pool.
+		On("GetTransactionByHash", context.Background(), unknownHash).
+		Return(nil, pool.ErrNotFound).
+		Once()
+
+	res, err = s.JSONRPCCall("pool_getTxStatus", unknownHash.String())
+	require.NoError(t, err)
+	require.Nil(t, res.Error)
+	assert.Equal(t, "null", string(res.Result))
+}
@@ -0,0 +1,396 @@
+package jsonrpc
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/0xPolygonHermez/zkevm-node/jsonrpc/types"
+	"github.com/0xPolygonHermez/zkevm-node/state"
+	"github.com/0xPolygonHermez/zkevm-node/state/runtime"
+	"github.com/0xPolygonHermez/zkevm-node/state/runtime/executor"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/jackc/pgx/v4"
+)
+
+const (
+	traceTypeCall    = "call"
+	traceTypeCreate  = "create"
+	traceTypeSuicide = "suicide"
+
+	// traceCallTraceType is the only trace type supported by trace_call
+	traceCallTraceType = "trace"
+)
+
+var callTracer = "callTracer"
+
+// TraceEndpoints is the trace jsonrpc endpoint, it provides the traces in the
+// OpenEthereum format used by the indexers, built from the callTracer traces
+type TraceEndpoints struct {
+	cfg      Config
+	state    types.StateInterface
+	etherman types.EthermanInterface
+	txMan    DBTxManager
+}
+
+// NewTraceEndpoints returns TraceEndpoints
+func NewTraceEndpoints(cfg Config, state types.StateInterface, etherman types.EthermanInterface) *TraceEndpoints {
+	return &TraceEndpoints{
+		cfg:      cfg,
+		state:    state,
+		etherman: etherman,
+	}
+}
+
+type traceFilter struct {
+	FromBlock   *types.BlockNumber `json:"fromBlock"`
+	ToBlock     *types.BlockNumber `json:"toBlock"`
+	FromAddress []common.Address   `json:"fromAddress"`
+	ToAddress   []common.Address   `json:"toAddress"`
+	After       *uint64            `json:"after"`
+	Count       *uint64            `json:"count"`
+}
+
+// matches checks if the trace was sent by one of the from addresses and to one
+// of the to addresses of the filter, an empty list of addresses matches all the traces
+func (f *traceFilter) matches(trace types.Trace) bool {
+	from, to := trace.Action.From, trace.Action.To
+	switch trace.Type {
+	case traceTypeCreate:
+		if trace.Result != nil {
+			to = trace.Result.Address
+		}
+	case traceTypeSuicide:
+		from, to = trace.Action.Address, trace.Action.RefundAddress
+	}
+	return containsAddress(f.FromAddress, from) && containsAddress(f.ToAddress, to)
+}
+
+func containsAddress(addresses []common.Address, address *common.Address) bool {
+	if len(addresses) == 0 {
+		return true
+	}
+	if address == nil {
+		return false
+	}
+	for _, a := range addresses {
+		if a == *address {
+			return true
+		}
+	}
+	return false
+}
+
+type traceCallResponse struct {
+	Output    types.ArgBytes `json:"output"`
+	StateDiff interface{}    `json:"stateDiff"`
+	Trace     []types.Trace  `json:"trace"`
+	VMTrace   interface{}    `json:"vmTrace"`
+}
+
+// callFrame is the trace of a call generated by the callTracer
+type callFrame struct {
+	Type    string          `json:"type"`
+	From    common.Address  `json:"from"`
+	Gas     types.ArgUint64 `json:"gas"`
+	GasUsed types.ArgUint64 `json:"gasUsed"`
+	To      *common.Address `json:"to"`
+	Input   types.ArgBytes  `json:"input"`
+	Output  types.ArgBytes  `json:"output"`
+	Error   string          `json:"error"`
+	Calls   []callFrame     `json:"calls"`
+	Value   *types.ArgBig   `json:"value"`
+}
+
+// Transaction creates a response for trace_transaction request.
+// See https://openethereum.github.io/JSONRPC-trace-module#trace_transaction
+func (t *TraceEndpoints) Transaction(hash types.ArgHash) (interface{}, types.Error) {
+	return t.txMan.NewDbTxScope(t.state, func(ctx context.Context, dbTx pgx.Tx) (interface{}, types.Error) {
+		receipt, err := t.state.GetTransactionReceipt(ctx, hash.Hash(), dbTx)
+		if errors.Is(err, state.ErrNotFound) {
+			return RPCErrorResponse(types.DefaultErrorCode, "transaction not found", nil, false)
+		} else if err != nil {
+			return RPCErrorResponse(types.DefaultErrorCode, "failed to get tx receipt", err, true)
+		}
+
+		return t.buildTraceTransaction(ctx, hash.Hash(), receipt.BlockHash, receipt.BlockNumber.Uint64(), uint64(receipt.TransactionIndex), dbTx)
+	})
+}
+
+// Block creates a response for trace_block request.
+// See https://openethereum.github.io/JSONRPC-trace-module#trace_block
+func (t *TraceEndpoints) Block(number types.BlockNumber) (interface{}, types.Error) {
+	return t.txMan.NewDbTxScope(t.state, func(ctx context.Context, dbTx pgx.Tx) (interface{}, types.Error) {
+		blockNumber, rpcErr := number.GetNumericBlockNumber(ctx, t.state, t.etherman, dbTx)
+		if rpcErr != nil {
+			return nil, rpcErr
+		}
+
+		return t.buildTraceBlock(ctx, blockNumber, dbTx)
+	})
+}
+
+// Filter creates a response for trace_filter request.
+// It returns the traces of the blocks from fromBlock to toBlock, both included,
+// that match the from and to addresses. The size of the range is limited by the
+// MaxTraceBlockRange configuration.
+// See https://openethereum.github.io/JSONRPC-trace-module#trace_filter
+func (t *TraceEndpoints) Filter(filter traceFilter) (interface{}, types.Error) {
+	return t.txMan.NewDbTxScope(t.state, func(ctx context.Context, dbTx pgx.Tx) (interface{}, types.Error) {
+		fromBlockNumber, toBlockNumber, rpcErr := getNumericBlockNumbers(ctx, t.state, t.etherman, filter.FromBlock, filter.ToBlock, t.cfg.MaxTraceBlockRange, state.ErrMaxTraceBlockRangeLimitExceeded, dbTx)
+		if rpcErr != nil {
+			return nil, rpcErr
+		}
+
+		var skipped uint64
+		traces := []types.Trace{}
+		for blockNumber := fromBlockNumber; blockNumber <= toBlockNumber; blockNumber++ {
+			blockTraces, rpcErr := t.buildTraceBlock(ctx, blockNumber, dbTx)
+			if rpcErr != nil {
+				return nil, rpcErr
+			}
+
+			for _, trace := range blockTraces {
+				if !filter.matches(trace) {
+					continue
+				}
+				if filter.After != nil && skipped < *filter.After {
+					skipped++
+					continue
+				}
+				traces = append(traces, trace)
+				if filter.Count != nil && uint64(len(traces)) >= *filter.Count {
+					return traces, nil
+				}
+			}
+		}
+
+		return traces, nil
+	})
+}
+
+// Call creates a response for trace_call request.
+// The unsigned txs are executed without generating the full trace, so only the
+// trace of the top call is returned, the stateDiff and vmTrace types are not supported.
+// See https://openethereum.github.io/JSONRPC-trace-module#trace_call
+func (t *TraceEndpoints) Call(arg *types.TxArgs, traceTypes []string, blockArg *types.BlockNumberOrHash) (interface{}, types.Error) {
+	return t.txMan.NewDbTxScope(t.state, func(ctx context.Context, dbTx pgx.Tx) (interface{}, types.Error) {
+		if arg == nil {
+			return RPCErrorResponse(types.InvalidParamsErrorCode, "missing value for required argument 0", nil, false)
+		}
+		for _, traceType := range traceTypes {
+			if traceType != traceCallTraceType {
+				return RPCErrorResponse(types.InvalidParamsErrorCode, fmt.Sprintf("trace type %s is not supported", traceType), nil, false)
+			}
+		}
+
+		block, respErr := t.getBlockByArg(ctx, blockArg, dbTx)
+		if respErr != nil {
+			return nil, respErr
+		}
+		var blockToProcess *uint64
+		if blockArg != nil && (blockArg.IsHash() || (*blockArg.Number() != types.LatestBlockNumber && *blockArg.Number() != types.PendingBlockNumber)) {
+			n := block.NumberU64()
+			blockToProcess = &n
+		}
+
+		// If the caller didn't supply the gas limit in the message, then we set it to maximum possible => block gas limit
+		if arg.Gas == nil || uint64(*arg.Gas) <= 0 {
+			header, err := t.state.GetL2BlockHeaderByNumber(ctx, block.NumberU64(), dbTx)
+			if err != nil {
+				return RPCErrorResponse(types.DefaultErrorCode, "failed to get block header", err, true)
+			}
+
+			gas := types.ArgUint64(header.GasLimit)
+			arg.Gas = &gas
+		}
+
+		defaultSenderAddress := common.HexToAddress(state.DefaultSenderAddress)
+		sender, tx, err := arg.ToTransaction(ctx, t.state, state.MaxTxGasLimit, block.Root(), defaultSenderAddress, dbTx)
+		if err != nil {
+			return RPCErrorResponse(types.DefaultErrorCode, "failed to convert arguments into an unsigned transaction", err, false)
+		}
+
+		result, err := t.state.ProcessUnsignedTransaction(ctx, tx, sender, blockToProcess, true, state.StateOverride{}, dbTx)
+		if err != nil {
+			errMsg := fmt.Sprintf("failed to execute the unsigned transaction: %v", err.Error())
+			logError := !executor.IsROMOutOfCountersError(executor.RomErrorCode(err)) && !errors.Is(err, runtime.ErrOutOfGas)
+			return RPCErrorResponse(types.DefaultErrorCode, errMsg, nil, logError)
+		}
+
+		frame := callFrame{
+			Type:    "CALL",
+			From:    sender,
+			Gas:     types.ArgUint64(tx.Gas()),
+			GasUsed: types.ArgUint64(result.GasUsed),
+			To:      tx.To(),
+			Input:   tx.Data(),
+			Output:  result.ReturnValue,
+			Value:   (*types.ArgBig)(tx.Value()),
+		}
+		if tx.To() == nil {
+			frame.Type = "CREATE"
+			frame.To = &result.CreateAddress
+		}
+		if result.Failed() {
+			frame.Error = result.Err.Error()
+		}
+
+		response := traceCallResponse{Output: result.ReturnValue}
+		if len(traceTypes) > 0 {
+			response.Trace = flattenCallFrame(frame, []int{}, []types.Trace{})
+		}
+		return response, nil
+	})
+}
+
+func (t *TraceEndpoints) getBlockByArg(ctx context.Context, blockArg *types.BlockNumberOrHash, dbTx pgx.Tx) (*state.L2Block, types.Error) {
+	// If no block argument is provided, return the latest block
+	if blockArg == nil {
+		block, err := t.state.GetLastL2Block(ctx, dbTx)
+		if err != nil {
+			return nil, types.NewRPCError(types.DefaultErrorCode, "failed to get the last block number from state")
+		}
+		return block, nil
+	}
+
+	// If we have a block hash, try to get the block by hash
+	if blockArg.IsHash() {
+		block, err := t.state.GetL2BlockByHash(ctx, blockArg.Hash().Hash(), dbTx)
+		if errors.Is(err, state.ErrNotFound) {
+			return nil, types.NewRPCError(types.DefaultErrorCode, "header for hash not found")
+		} else if err != nil {
+			return nil, types.NewRPCError(types.DefaultErrorCode, fmt.Sprintf("failed to get block by hash %v", blockArg.Hash().Hash()))
+		}
+		return block, nil
+	}
+
+	// Otherwise, try to get the block by number
+	blockNum, rpcErr := blockArg.Number().GetNumericBlockNumber(ctx, t.state, t.etherman, dbTx)
+	if rpcErr != nil {
+		return nil, rpcErr
+	}
+	block, err := t.state.GetL2BlockByNumber(ctx, blockNum, dbTx)
+	if errors.Is(err, state.ErrNotFound) || block == nil {
+		return nil, types.NewRPCError(types.DefaultErrorCode, "header not found")
+	} else if err != nil {
+		return nil, types.NewRPCError(types.DefaultErrorCode, fmt.Sprintf("failed to get block by number %v", blockNum))
+	}
+
+	return block, nil
+}
+
+func (t *TraceEndpoints) buildTraceBlock(ctx context.Context, blockNumber uint64, dbTx pgx.Tx) ([]types.Trace, types.Error) {
+	block, err := t.state.GetL2BlockByNumber(ctx, blockNumber, dbTx)
+	if errors.Is(err, state.ErrNotFound) {
+		return nil, types.NewRPCError(types.DefaultErrorCode, fmt.Sprintf("block #%d not found", blockNumber))
+	} else if err != nil {
+		return nil, types.NewRPCError(types.DefaultErrorCode, "failed to get block by number")
+	}
+
+	traces := []types.Trace{}
+	for i, tx := range block.Transactions() {
+		txTraces, rpcErr := t.buildTraceTransaction(ctx, tx.Hash(), block.Hash(), blockNumber, uint64(i), dbTx)
+		if rpcErr != nil {
+			errMsg := fmt.Sprintf("failed to get trace for transaction %v: %v", tx.Hash().String(), rpcErr.Error())
+			return nil, types.NewRPCError(types.DefaultErrorCode, errMsg)
+		}
+		traces = append(traces, txTraces...)
+	}
+
+	return traces, nil
+}
+
+func (t *TraceEndpoints) buildTraceTransaction(ctx context.Context, hash, blockHash common.Hash, blockNumber, txIndex uint64, dbTx pgx.Tx) ([]types.Trace, types.Error) {
+	stateTraceConfig := state.TraceConfig{
+		Tracer: &callTracer,
+	}
+	result, err := t.state.DebugTransaction(ctx, hash, stateTraceConfig, dbTx)
+	if errors.Is(err, state.ErrNotFound) {
+		return nil, types.NewRPCError(types.DefaultErrorCode, "transaction not found")
+	} else if err != nil {
+		errorMessage := fmt.Sprintf("failed to get trace: %v", err.Error())
+		return nil, types.NewRPCError(types.DefaultErrorCode, errorMessage)
+	}
+
+	var frame callFrame
+	if err := json.Unmarshal(result.TraceResult, &frame); err != nil {
+		errorMessage := fmt.Sprintf("failed to decode trace: %v", err.Error())
+		return nil, types.NewRPCError(types.DefaultErrorCode, errorMessage)
+	}
+
+	traces := flattenCallFrame(frame, []int{}, []types.Trace{})
+	for i := range traces {
+		traces[i].BlockHash = &blockHash
+		traces[i].BlockNumber = types.ArgUint64Ptr(types.ArgUint64(blockNumber))
+		traces[i].TransactionHash = &hash
+		traces[i].TransactionPosition = types.ArgUint64Ptr(types.ArgUint64(txIndex))
+	}
+	return traces, nil
+}
+
+// flattenCallFrame converts the call frame and its inner calls into a list of traces,
+// the trace address of each trace is the list of indexes of the calls from the top call
+func flattenCallFrame(frame callFrame, traceAddress []int, traces []types.Trace) []types.Trace {
+	value := frame.Value
+	if value == nil {
+		value = (*types.ArgBig)(big.NewInt(0))
+	}
+
+	trace := types.Trace{
+		Subtraces:    len(frame.Calls),
+		TraceAddress: traceAddress,
+		Error:        frame.Error,
+	}
+	switch frame.Type {
+	case "CREATE", "CREATE2":
+		trace.Type = traceTypeCreate
+		trace.Action = types.TraceAction{
+			From:  &frame.From,
+			Gas:   &frame.Gas,
+			Init:  &frame.Input,
+			Value: value,
+		}
+		if frame.Error == "" {
+			trace.Result = &types.TraceResult{
+				GasUsed: frame.GasUsed,
+				Address: frame.To,
+				Code:    &frame.Output,
+			}
+		}
+	case "SELFDESTRUCT":
+		trace.Type = traceTypeSuicide
+		trace.Action = types.TraceAction{
+			Address:       &frame.From,
+			RefundAddress: frame.To,
+			Balance:       value,
+		}
+	default:
+		trace.Type = traceTypeCall
+		trace.Action = types.TraceAction{
+			CallType: strings.ToLower(frame.Type),
+			From:     &frame.From,
+			To:       frame.To,
+			Gas:      &frame.Gas,
+			Input:    &frame.Input,
+			Value:    value,
+		}
+		if frame.Error == "" {
+			trace.Result = &types.TraceResult{
+				GasUsed: frame.GasUsed,
+				Output:  &frame.Output,
+			}
+		}
+	}
+	traces = append(traces, trace)
+
+	for i, call := range frame.Calls {
+		callTraceAddress := make([]int, len(traceAddress), len(traceAddress)+1)
+		copy(callTraceAddress, traceAddress)
+		traces = flattenCallFrame(call, append(callTraceAddress, i), traces)
+	}
+	return traces
+}
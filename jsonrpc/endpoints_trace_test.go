@@ -0,0 +1,188 @@
+package jsonrpc
+
+import (
+	"context"
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	"github.com/0xPolygonHermez/zkevm-node/hex"
+	"github.com/0xPolygonHermez/zkevm-node/jsonrpc/types"
+	"github.com/0xPolygonHermez/zkevm-node/state"
+	"github.com/0xPolygonHermez/zkevm-node/state/runtime"
+	"github.com/0xPolygonHermez/zkevm-node/test/operations"
+	"github.com/ethereum/go-ethereum/common"
+	ethTypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/trie"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+const testCallTracerResult = `{
+	"type": "CALL",
+	"from": "0x0000000000000000000000000000000000000001",
+	"to": "0x0000000000000000000000000000000000000002",
+	"gas": "0x5208",
+	"gasUsed": "0x5000",
+	"input": "0x01",
+	"output": "0x02",
+	"value": "0x10",
+	"calls": [
+		{
+			"type": "CREATE",
+			"from": "0x0000000000000000000000000000000000000002",
+			"to": "0x0000000000000000000000000000000000000003",
+			"gas": "0x100",
+			"gasUsed": "0x50",
+			"input": "0x03",
+			"output": "0x04",
+			"calls": [
+				{
+					"type": "STATICCALL",
+					"from": "0x0000000000000000000000000000000000000003",
+					"to": "0x0000000000000000000000000000000000000004",
+					"gas": "0x10",
+					"gasUsed": "0x10",
+					"input": "0x",
+					"error": "execution reverted"
+				}
+			]
+		},
+		{
+			"type": "SELFDESTRUCT",
+			"from": "0x0000000000000000000000000000000000000002",
+			"to": "0x0000000000000000000000000000000000000005",
+			"gas": "0x0",
+			"gasUsed": "0x0",
+			"input": "0x",
+			"value": "0x20"
+		}
+	]
+}`
+
+func TestTraceTransaction(t *testing.T) {
+	s, m, _ := newSequencerMockedServer(t)
+	defer s.Stop()
+
+	txHash := common.HexToHash("0x10")
+	blockHash := common.HexToHash("0x20")
+	receipt := &ethTypes.Receipt{TxHash: txHash, BlockHash: blockHash, BlockNumber: big.NewInt(5), TransactionIndex: 1}
+
+	m.DbTx.On("Commit", context.Background()).Return(nil).Once()
+	m.State.On("BeginStateTransaction", context.Background()).Return(m.DbTx, nil).Once()
+	m.State.On("GetTransactionReceipt", context.Background(), txHash, m.DbTx).Return(receipt, nil).Once()
+	m.State.
+		On("DebugTransaction", context.Background(), txHash, mock.MatchedBy(func(cfg state.TraceConfig) bool { return cfg.IsCallTracer() }), m.DbTx).
+		Return(&runtime.ExecutionResult{TraceResult: json.RawMessage(testCallTracerResult)}, nil).
+		Once()
+
+	res, err := s.JSONRPCCall("trace_transaction", txHash.String())
+	require.NoError(t, err)
+	require.Nil(t, res.Error)
+
+	var traces []types.Trace
+	require.NoError(t, json.Unmarshal(res.Result, &traces))
+	require.Len(t, traces, 4)
+
+	expected := []struct {
+		traceType    string
+		callType     string
+		traceAddress []int
+		subtraces    int
+		err          string
+	}{
+		{traceTypeCall, "call", []int{}, 2, ""},
+		{traceTypeCreate, "", []int{0}, 1, ""},
+		{traceTypeCall, "staticcall", []int{0, 0}, 0, "execution reverted"},
+		{traceTypeSuicide, "", []int{1}, 0, ""},
+	}
+	for i, trace := range traces {
+		assert.Equal(t, expected[i].traceType, trace.Type)
+		assert.Equal(t, expected[i].callType, trace.Action.CallType)
+		assert.Equal(t, expected[i].traceAddress, trace.TraceAddress)
+		assert.Equal(t, expected[i].subtraces, trace.Subtraces)
+		assert.Equal(t, expected[i].err, trace.Error)
+		assert.Equal(t, txHash, *trace.TransactionHash)
+		assert.Equal(t, blockHash, *trace.BlockHash)
+		assert.Equal(t, types.ArgUint64(5), *trace.BlockNumber)
+		assert.Equal(t, types.ArgUint64(1), *trace.TransactionPosition)
+	}
+
+	assert.Equal(t, "0x10", traces[0].Action.Value.Hex())
+	assert.Equal(t, "0x02", traces[0].Result.Output.Hex())
+	assert.Equal(t, common.HexToAddress("0x3"), *traces[1].Result.Address)
+	assert.Equal(t, "0x04", traces[1].Result.Code.Hex())
+	assert.Equal(t, "0x0", traces[1].Action.Value.Hex())
+	assert.Nil(t, traces[2].Result)
+	assert.Equal(t, common.HexToAddress("0x2"), *traces[3].Action.Address)
+	assert.Equal(t, common.HexToAddress("0x5"), *traces[3].Action.RefundAddress)
+	assert.Equal(t, "0x20", traces[3].Action.Balance.Hex())
+}
+
+func TestTraceFilter(t *testing.T) {
+	s, m, _ := newSequencerMockedServer(t)
+	defer s.Stop()
+
+	auth := operations.MustGetAuth(operations.DefaultSequencerPrivateKey, operations.DefaultL2ChainID)
+	signedTx, err := auth.Signer(auth.From, ethTypes.NewTx(&ethTypes.LegacyTx{Nonce: 1, GasPrice: big.NewInt(1), Gas: 21000, To: state.Ptr(common.HexToAddress("0x2"))}))
+	require.NoError(t, err)
+
+	blockNumber := uint64(7)
+	receipts := []*ethTypes.Receipt{{TxHash: signedTx.Hash(), BlockNumber: big.NewInt(0).SetUint64(blockNumber)}}
+	l2Block := state.NewL2Block(state.NewL2Header(&ethTypes.Header{Number: big.NewInt(0).SetUint64(blockNumber)}), []*ethTypes.Transaction{signedTx}, nil, receipts, trie.NewStackTrie(nil))
+
+	testCases := []struct {
+		name                   string
+		filter                 map[string]interface{}
+		expectedTraceAddresses [][]int
+	}{
+		{
+			name:                   "all the traces",
+			filter:                 map[string]interface{}{},
+			expectedTraceAddresses: [][]int{{}, {0}, {0, 0}, {1}},
+		},
+		{
+			name:                   "from address",
+			filter:                 map[string]interface{}{"fromAddress": []string{"0x0000000000000000000000000000000000000002"}},
+			expectedTraceAddresses: [][]int{{0}, {1}},
+		},
+		{
+			name:                   "to address",
+			filter:                 map[string]interface{}{"toAddress": []string{"0x0000000000000000000000000000000000000003", "0x0000000000000000000000000000000000000005"}},
+			expectedTraceAddresses: [][]int{{0}, {1}},
+		},
+		{
+			name:                   "after and count",
+			filter:                 map[string]interface{}{"after": 1, "count": 2},
+			expectedTraceAddresses: [][]int{{0}, {0, 0}},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			m.DbTx.On("Commit", context.Background()).Return(nil).Once()
+			m.State.On("BeginStateTransaction", context.Background()).Return(m.DbTx, nil).Once()
+			m.State.On("GetL2BlockByNumber", context.Background(), blockNumber, m.DbTx).Return(l2Block, nil).Once()
+			m.State.
+				On("DebugTransaction", context.Background(), signedTx.Hash(), mock.Anything, m.DbTx).
+				Return(&runtime.ExecutionResult{TraceResult: json.RawMessage(testCallTracerResult)}, nil).
+				Once()
+
+			filter := testCase.filter
+			filter["fromBlock"] = hex.EncodeUint64(blockNumber)
+			filter["toBlock"] = hex.EncodeUint64(blockNumber)
+			res, err := s.JSONRPCCall("trace_filter", filter)
+			require.NoError(t, err)
+			require.Nil(t, res.Error)
+
+			var traces []types.Trace
+			require.NoError(t, json.Unmarshal(res.Result, &traces))
+			require.Len(t, traces, len(testCase.expectedTraceAddresses))
+			for i, trace := range traces {
+				assert.Equal(t, testCase.expectedTraceAddresses[i], trace.TraceAddress)
+				assert.Equal(t, signedTx.Hash(), *trace.TransactionHash)
+			}
+		})
+	}
+}
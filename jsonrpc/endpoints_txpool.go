@@ -1,18 +1,50 @@
 package jsonrpc
 
 import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/0xPolygonHermez/zkevm-node/jsonrpc/client"
 	"github.com/0xPolygonHermez/zkevm-node/jsonrpc/types"
+	"github.com/0xPolygonHermez/zkevm-node/pool"
 	"github.com/ethereum/go-ethereum/common"
 )
 
 // TxPoolEndpoints is the txpool jsonrpc endpoint
-type TxPoolEndpoints struct{}
+type TxPoolEndpoints struct {
+	cfg  Config
+	pool types.PoolInterface
+}
+
+// NewTxPoolEndpoints returns TxPoolEndpoints
+func NewTxPoolEndpoints(cfg Config, p types.PoolInterface) *TxPoolEndpoints {
+	return &TxPoolEndpoints{
+		cfg:  cfg,
+		pool: p,
+	}
+}
 
 type contentResponse struct {
 	Pending map[common.Address]map[uint64]*txPoolTransaction `json:"pending"`
 	Queued  map[common.Address]map[uint64]*txPoolTransaction `json:"queued"`
 }
 
+type contentFromResponse struct {
+	Pending map[uint64]*txPoolTransaction `json:"pending"`
+	Queued  map[uint64]*txPoolTransaction `json:"queued"`
+}
+
+type inspectResponse struct {
+	Pending map[common.Address]map[uint64]string `json:"pending"`
+	Queued  map[common.Address]map[uint64]string `json:"queued"`
+}
+
+type statusResponse struct {
+	Pending types.ArgUint64 `json:"pending"`
+	Queued  types.ArgUint64 `json:"queued"`
+}
+
 type txPoolTransaction struct {
 	Nonce       types.ArgUint64 `json:"nonce"`
 	GasPrice    types.ArgBig    `json:"gasPrice"`
@@ -27,13 +59,156 @@ type txPoolTransaction struct {
 	TxIndex     interface{}     `json:"transactionIndex"`
 }
 
+func newTxPoolTransaction(tx pool.Transaction, from common.Address) *txPoolTransaction {
+	return &txPoolTransaction{
+		Nonce:    types.ArgUint64(tx.Nonce()),
+		GasPrice: types.ArgBig(*tx.GasPrice()),
+		Gas:      types.ArgUint64(tx.Gas()),
+		To:       tx.To(),
+		Value:    types.ArgBig(*tx.Value()),
+		Input:    tx.Data(),
+		Hash:     tx.Hash(),
+		From:     from,
+	}
+}
+
+// inspectTx returns the summary of the tx in the same format used by geth
+func inspectTx(tx pool.Transaction) string {
+	if to := tx.To(); to != nil {
+		return fmt.Sprintf("%s: %d wei + %d gas × %d wei", to.Hex(), tx.Value(), tx.Gas(), tx.GasPrice())
+	}
+	return fmt.Sprintf("contract creation: %d wei + %d gas × %d wei", tx.Value(), tx.Gas(), tx.GasPrice())
+}
+
 // Content creates a response for txpool_content request.
 // See https://geth.ethereum.org/docs/rpc/ns-txpool#txpool_content.
 func (e *TxPoolEndpoints) Content() (interface{}, types.Error) {
+	if e.cfg.SequencerNodeURI != "" {
+		return e.relayToSequencerNode("txpool_content", &contentResponse{})
+	}
+
+	pending, queued, err := e.pool.GetPendingAndQueuedTxs(context.Background(), e.cfg.MaxTxPoolTxs)
+	if err != nil {
+		return RPCErrorResponse(types.DefaultErrorCode, "failed to load pending txs from pool", err, true)
+	}
+
 	resp := contentResponse{
 		Pending: make(map[common.Address]map[uint64]*txPoolTransaction),
 		Queued:  make(map[common.Address]map[uint64]*txPoolTransaction),
 	}
+	for from, txs := range pending {
+		resp.Pending[from] = make(map[uint64]*txPoolTransaction, len(txs))
+		for _, tx := range txs {
+			resp.Pending[from][tx.Nonce()] = newTxPoolTransaction(tx, from)
+		}
+	}
+	for from, txs := range queued {
+		resp.Queued[from] = make(map[uint64]*txPoolTransaction, len(txs))
+		for _, tx := range txs {
+			resp.Queued[from][tx.Nonce()] = newTxPoolTransaction(tx, from)
+		}
+	}
+
+	return resp, nil
+}
+
+// ContentFrom creates a response for txpool_contentFrom request.
+// See https://geth.ethereum.org/docs/rpc/ns-txpool#txpool_contentfrom.
+func (e *TxPoolEndpoints) ContentFrom(address types.ArgAddress) (interface{}, types.Error) {
+	if e.cfg.SequencerNodeURI != "" {
+		return e.relayToSequencerNode("txpool_contentFrom", &contentFromResponse{}, address.Address().String())
+	}
+
+	pending, queued, err := e.pool.GetPendingAndQueuedTxs(context.Background(), e.cfg.MaxTxPoolTxs)
+	if err != nil {
+		return RPCErrorResponse(types.DefaultErrorCode, "failed to load pending txs from pool", err, true)
+	}
+
+	from := address.Address()
+	resp := contentFromResponse{
+		Pending: make(map[uint64]*txPoolTransaction),
+		Queued:  make(map[uint64]*txPoolTransaction),
+	}
+	for _, tx := range pending[from] {
+		resp.Pending[tx.Nonce()] = newTxPoolTransaction(tx, from)
+	}
+	for _, tx := range queued[from] {
+		resp.Queued[tx.Nonce()] = newTxPoolTransaction(tx, from)
+	}
+
+	return resp, nil
+}
+
+// Inspect creates a response for txpool_inspect request.
+// See https://geth.ethereum.org/docs/rpc/ns-txpool#txpool_inspect.
+func (e *TxPoolEndpoints) Inspect() (interface{}, types.Error) {
+	if e.cfg.SequencerNodeURI != "" {
+		return e.relayToSequencerNode("txpool_inspect", &inspectResponse{})
+	}
+
+	pending, queued, err := e.pool.GetPendingAndQueuedTxs(context.Background(), e.cfg.MaxTxPoolTxs)
+	if err != nil {
+		return RPCErrorResponse(types.DefaultErrorCode, "failed to load pending txs from pool", err, true)
+	}
+
+	resp := inspectResponse{
+		Pending: make(map[common.Address]map[uint64]string),
+		Queued:  make(map[common.Address]map[uint64]string),
+	}
+	for from, txs := range pending {
+		resp.Pending[from] = make(map[uint64]string, len(txs))
+		for _, tx := range txs {
+			resp.Pending[from][tx.Nonce()] = inspectTx(tx)
+		}
+	}
+	for from, txs := range queued {
+		resp.Queued[from] = make(map[uint64]string, len(txs))
+		for _, tx := range txs {
+			resp.Queued[from][tx.Nonce()] = inspectTx(tx)
+		}
+	}
 
 	return resp, nil
 }
+
+// Status creates a response for txpool_status request.
+// See https://geth.ethereum.org/docs/rpc/ns-txpool#txpool_status.
+func (e *TxPoolEndpoints) Status() (interface{}, types.Error) {
+	if e.cfg.SequencerNodeURI != "" {
+		return e.relayToSequencerNode("txpool_status", &statusResponse{})
+	}
+
+	pending, queued, err := e.pool.GetPendingAndQueuedTxs(context.Background(), e.cfg.MaxTxPoolTxs)
+	if err != nil {
+		return RPCErrorResponse(types.DefaultErrorCode, "failed to load pending txs from pool", err, true)
+	}
+
+	resp := statusResponse{}
+	for _, txs := range pending {
+		resp.Pending += types.ArgUint64(len(txs))
+	}
+	for _, txs := range queued {
+		resp.Queued += types.ArgUint64(len(txs))
+	}
+
+	return resp, nil
+}
+
+// relayToSequencerNode forwards the request to the trusted sequencer node, as the pool of
+// the non-trusted nodes doesn't contain the txs pending to be sequenced
+func (e *TxPoolEndpoints) relayToSequencerNode(method string, result interface{}, parameters ...interface{}) (interface{}, types.Error) {
+	res, err := client.JSONRPCCall(e.cfg.SequencerNodeURI, method, parameters...)
+	if err != nil {
+		return RPCErrorResponse(types.DefaultErrorCode, fmt.Sprintf("failed to get %s from sequencer node", method), err, true)
+	}
+
+	if res.Error != nil {
+		return RPCErrorResponse(res.Error.Code, res.Error.Message, nil, false)
+	}
+
+	err = json.Unmarshal(res.Result, result)
+	if err != nil {
+		return RPCErrorResponse(types.DefaultErrorCode, fmt.Sprintf("failed to read %s from sequencer node", method), err, true)
+	}
+	return result, nil
+}
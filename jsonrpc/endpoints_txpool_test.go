@@ -0,0 +1,84 @@
+package jsonrpc
+
+import (
+	"context"
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	"github.com/0xPolygonHermez/zkevm-node/pool"
+	"github.com/ethereum/go-ethereum/common"
+	ethTypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTxPoolEndpoints(t *testing.T) {
+	s, m, _ := newSequencerMockedServer(t)
+	defer s.Stop()
+
+	from1 := common.HexToAddress("0x1")
+	from2 := common.HexToAddress("0x2")
+	to := common.HexToAddress("0x111")
+
+	tx1 := ethTypes.NewTransaction(0, to, big.NewInt(1), 21000, big.NewInt(10), nil)
+	tx2 := ethTypes.NewTransaction(1, to, big.NewInt(2), 21000, big.NewInt(20), nil)
+	tx3 := ethTypes.NewContractCreation(5, big.NewInt(0), 50000, big.NewInt(30), []byte{1, 2})
+
+	pending := map[common.Address][]pool.Transaction{
+		from1: {*pool.NewTransaction(*tx1, "", false), *pool.NewTransaction(*tx2, "", false)},
+	}
+	queued := map[common.Address][]pool.Transaction{
+		from2: {*pool.NewTransaction(*tx3, "", false)},
+	}
+
+	m.Pool.
+		On("GetPendingAndQueuedTxs", context.Background(), uint64(5000)).
+		Return(pending, queued, nil)
+
+	// txpool_status
+	res, err := s.JSONRPCCall("txpool_status")
+	require.NoError(t, err)
+	require.Nil(t, res.Error)
+
+	var status statusResponse
+	require.NoError(t, json.Unmarshal(res.Result, &status))
+	assert.Equal(t, uint64(2), uint64(status.Pending))
+	assert.Equal(t, uint64(1), uint64(status.Queued))
+
+	// txpool_content
+	res, err = s.JSONRPCCall("txpool_content")
+	require.NoError(t, err)
+	require.Nil(t, res.Error)
+
+	var content contentResponse
+	require.NoError(t, json.Unmarshal(res.Result, &content))
+	require.Len(t, content.Pending[from1], 2)
+	assert.Equal(t, tx1.Hash(), content.Pending[from1][0].Hash)
+	assert.Equal(t, tx2.Hash(), content.Pending[from1][1].Hash)
+	assert.Equal(t, from1, content.Pending[from1][1].From)
+	require.Len(t, content.Queued[from2], 1)
+	assert.Equal(t, tx3.Hash(), content.Queued[from2][5].Hash)
+	assert.Nil(t, content.Queued[from2][5].To)
+
+	// txpool_contentFrom
+	res, err = s.JSONRPCCall("txpool_contentFrom", from2.String())
+	require.NoError(t, err)
+	require.Nil(t, res.Error)
+
+	var contentFrom contentFromResponse
+	require.NoError(t, json.Unmarshal(res.Result, &contentFrom))
+	assert.Len(t, contentFrom.Pending, 0)
+	require.Len(t, contentFrom.Queued, 1)
+	assert.Equal(t, tx3.Hash(), contentFrom.Queued[5].Hash)
+
+	// txpool_inspect
+	res, err = s.JSONRPCCall("txpool_inspect")
+	require.NoError(t, err)
+	require.Nil(t, res.Error)
+
+	var inspect inspectResponse
+	require.NoError(t, json.Unmarshal(res.Result, &inspect))
+	assert.Equal(t, to.Hex()+": 2 wei + 21000 gas × 20 wei", inspect.Pending[from1][1])
+	assert.Equal(t, "contract creation: 0 wei + 50000 gas × 30 wei", inspect.Queued[from2][5])
+}
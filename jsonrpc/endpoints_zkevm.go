@@ -6,6 +6,7 @@ import (
 	"errors"
 	"fmt"
 	"math/big"
+	"net/http"
 	"time"
 
 	"github.com/0xPolygonHermez/zkevm-node/hex"
@@ -392,6 +393,9 @@ func (z *ZKEVMEndpoints) GetTransactionReceiptByL2Hash(hash types.ArgHash) (inte
 		if err != nil {
 			return RPCErrorResponse(types.DefaultErrorCode, "failed to build the receipt response", err, true)
 		}
+		if err := setRevertReason(ctx, z.state, &receipt, dbTx); err != nil {
+			return RPCErrorResponse(types.DefaultErrorCode, "failed to get the revert reason of the tx", err, true)
+		}
 
 		return receipt, nil
 	})
@@ -415,6 +419,121 @@ func (z *ZKEVMEndpoints) getTransactionByL2HashFromSequencerNode(hash common.Has
 	return tx, nil
 }
 
+// GetTransactionStatus returns the status of a transaction and, when the tx
+// is known by the pool, the history of the changes of its status
+func (z *ZKEVMEndpoints) GetTransactionStatus(hash types.ArgHash) (interface{}, types.Error) {
+	return z.txMan.NewDbTxScope(z.state, func(ctx context.Context, dbTx pgx.Tx) (interface{}, types.Error) {
+		var res *types.TransactionStatus
+
+		receipt, err := z.state.GetTransactionReceipt(ctx, hash.Hash(), dbTx)
+		if err != nil && !errors.Is(err, state.ErrNotFound) {
+			return RPCErrorResponse(types.DefaultErrorCode, "failed to load transaction receipt from state", err, true)
+		}
+		if receipt != nil {
+			blockNumber := receipt.BlockNumber.Uint64()
+			batchNumber, err := z.state.BatchNumberByL2BlockNumber(ctx, blockNumber, dbTx)
+			if err != nil {
+				return RPCErrorResponse(types.DefaultErrorCode, "failed to get batch number from block number", err, true)
+			}
+			res = &types.TransactionStatus{
+				Hash:        hash.Hash(),
+				Status:      types.TxStatusMined,
+				BlockNumber: types.ArgUint64Ptr(types.ArgUint64(blockNumber)),
+				BatchNumber: types.ArgUint64Ptr(types.ArgUint64(batchNumber)),
+				History:     []types.TransactionStatusChange{},
+			}
+		}
+
+		// the status history is only known by the pool of the trusted sequencer
+		if z.cfg.SequencerNodeURI != "" {
+			if res != nil {
+				return res, nil
+			}
+			return z.getTransactionStatusFromSequencerNode(hash.Hash())
+		}
+
+		poolTx, err := z.pool.GetTransactionByHash(ctx, hash.Hash())
+		if errors.Is(err, pool.ErrNotFound) {
+			if res != nil {
+				return res, nil
+			}
+			return nil, nil
+		} else if err != nil {
+			return RPCErrorResponse(types.DefaultErrorCode, "failed to load transaction from pool", err, true)
+		}
+
+		history, err := z.pool.GetTxStatusHistory(ctx, hash.Hash())
+		if err != nil {
+			return RPCErrorResponse(types.DefaultErrorCode, "failed to load transaction status history from pool", err, true)
+		}
+
+		if res == nil {
+			res = &types.TransactionStatus{
+				Hash:         hash.Hash(),
+				Status:       poolTx.Status.String(),
+				FailedReason: poolTx.FailedReason,
+			}
+		}
+		res.History = types.NewTransactionStatusHistory(history)
+
+		return res, nil
+	})
+}
+
+func (z *ZKEVMEndpoints) getTransactionStatusFromSequencerNode(hash common.Hash) (interface{}, types.Error) {
+	res, err := client.JSONRPCCall(z.cfg.SequencerNodeURI, "zkevm_getTransactionStatus", hash.String())
+	if err != nil {
+		return RPCErrorResponse(types.DefaultErrorCode, "failed to get tx status from sequencer node", err, true)
+	}
+
+	if res.Error != nil {
+		return RPCErrorResponse(res.Error.Code, res.Error.Message, nil, false)
+	}
+
+	var txStatus *types.TransactionStatus
+	err = json.Unmarshal(res.Result, &txStatus)
+	if err != nil {
+		return RPCErrorResponse(types.DefaultErrorCode, "failed to read tx status from sequencer node", err, true)
+	}
+	return txStatus, nil
+}
+
+// SendScheduledTransaction adds a tx to the pool that is not sequenced before the provided
+// timestamp and L2 block number. Non-Sequencer nodes relay the tx to the Sequencer node
+func (z *ZKEVMEndpoints) SendScheduledTransaction(httpRequest *http.Request, input string, schedule types.ScheduledTxArgs) (interface{}, types.Error) {
+	if z.cfg.SequencerNodeURI != "" {
+		return z.relayScheduledTxToSequencerNode(input, schedule)
+	}
+
+	tx, err := hexToTx(input)
+	if err != nil {
+		return RPCErrorResponse(types.InvalidParamsErrorCode, "invalid tx input", err, false)
+	}
+
+	log.Infof("adding scheduled TX to the pool: %v", tx.Hash().Hex())
+	if err := z.pool.AddScheduledTx(context.Background(), *tx, requestIP(httpRequest), schedule.ToTxSchedule()); errors.Is(err, pool.ErrTxRateLimitExceeded) {
+		return RPCErrorResponse(types.LimitExceededErrorCode, err.Error(), nil, false)
+	} else if err != nil {
+		return RPCErrorResponse(types.DefaultErrorCode, err.Error(), nil, false)
+	}
+	log.Infof("scheduled TX added to the pool: %v", tx.Hash().Hex())
+
+	return tx.Hash().Hex(), nil
+}
+
+func (z *ZKEVMEndpoints) relayScheduledTxToSequencerNode(input string, schedule types.ScheduledTxArgs) (interface{}, types.Error) {
+	res, err := client.JSONRPCCall(z.cfg.SequencerNodeURI, "zkevm_sendScheduledTransaction", input, schedule)
+	if err != nil {
+		return RPCErrorResponse(types.DefaultErrorCode, "failed to relay scheduled tx to the sequencer node", err, true)
+	}
+
+	if res.Error != nil {
+		return RPCErrorResponse(res.Error.Code, res.Error.Message, nil, false)
+	}
+
+	return res.Result, nil
+}
+
 // GetExitRootsByGER returns the exit roots accordingly to the provided Global Exit Root
 func (z *ZKEVMEndpoints) GetExitRootsByGER(globalExitRoot common.Hash) (interface{}, types.Error) {
 	return z.txMan.NewDbTxScope(z.state, func(ctx context.Context, dbTx pgx.Tx) (interface{}, types.Error) {
@@ -484,7 +603,7 @@ func (z *ZKEVMEndpoints) internalEstimateGasPriceAndFee(ctx context.Context, arg
 		return nil, nil, types.NewRPCError(types.DefaultErrorCode, "failed to convert arguments into an unsigned transaction")
 	}
 
-	gasEstimation, returnValue, err := z.state.EstimateGas(tx, sender, blockToProcess, dbTx)
+	gasEstimation, returnValue, err := z.state.EstimateGas(tx, sender, blockToProcess, nil, dbTx)
 	if errors.Is(err, runtime.ErrExecutionReverted) {
 		data := make([]byte, len(returnValue))
 		copy(data, returnValue)
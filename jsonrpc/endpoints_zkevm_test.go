@@ -2497,6 +2497,194 @@ func TestGetTransactionReceiptByL2Hash(t *testing.T) {
 	}
 }
 
+func TestGetTransactionStatus(t *testing.T) {
+	s, m, _ := newSequencerMockedServer(t)
+	defer s.Stop()
+
+	type testCase struct {
+		Name           string
+		Hash           common.Hash
+		ExpectedResult *types.TransactionStatus
+		ExpectedError  *types.RPCError
+		SetupMocks     func(m *mocksWrapper, tc testCase)
+	}
+
+	failedReason := "reverted"
+	receivedAt := time.Unix(1000, 0)
+	failedAt := time.Unix(1010, 0)
+	history := []pool.TxStatusChange{
+		{Status: pool.TxStatusPending, ChangedAt: receivedAt},
+		{Status: pool.TxStatusFailed, FailedReason: &failedReason, ChangedAt: failedAt},
+	}
+	receipt := &ethTypes.Receipt{BlockNumber: big.NewInt(2)}
+
+	testCases := []testCase{
+		{
+			Name: "Get status of a mined tx",
+			Hash: common.HexToHash("0x123"),
+			ExpectedResult: &types.TransactionStatus{
+				Hash:        common.HexToHash("0x123"),
+				Status:      types.TxStatusMined,
+				BlockNumber: types.ArgUint64Ptr(2),
+				BatchNumber: types.ArgUint64Ptr(1),
+				History: []types.TransactionStatusChange{
+					{Status: pool.TxStatusPending.String(), Timestamp: types.ArgUint64(receivedAt.Unix())},
+				},
+			},
+			SetupMocks: func(m *mocksWrapper, tc testCase) {
+				m.DbTx.On("Commit", context.Background()).Return(nil).Once()
+				m.State.On("BeginStateTransaction", context.Background()).Return(m.DbTx, nil).Once()
+				m.State.On("GetTransactionReceipt", context.Background(), tc.Hash, m.DbTx).Return(receipt, nil).Once()
+				m.State.On("BatchNumberByL2BlockNumber", context.Background(), uint64(2), m.DbTx).Return(uint64(1), nil).Once()
+				m.Pool.On("GetTransactionByHash", context.Background(), tc.Hash).Return(&pool.Transaction{Status: pool.TxStatusSelected}, nil).Once()
+				m.Pool.On("GetTxStatusHistory", context.Background(), tc.Hash).Return(history[:1], nil).Once()
+			},
+		},
+		{
+			Name: "Get status of a failed tx",
+			Hash: common.HexToHash("0x123"),
+			ExpectedResult: &types.TransactionStatus{
+				Hash:         common.HexToHash("0x123"),
+				Status:       pool.TxStatusFailed.String(),
+				FailedReason: &failedReason,
+				History: []types.TransactionStatusChange{
+					{Status: pool.TxStatusPending.String(), Timestamp: types.ArgUint64(receivedAt.Unix())},
+					{Status: pool.TxStatusFailed.String(), FailedReason: &failedReason, Timestamp: types.ArgUint64(failedAt.Unix())},
+				},
+			},
+			SetupMocks: func(m *mocksWrapper, tc testCase) {
+				m.DbTx.On("Commit", context.Background()).Return(nil).Once()
+				m.State.On("BeginStateTransaction", context.Background()).Return(m.DbTx, nil).Once()
+				m.State.On("GetTransactionReceipt", context.Background(), tc.Hash, m.DbTx).Return(nil, state.ErrNotFound).Once()
+				m.Pool.On("GetTransactionByHash", context.Background(), tc.Hash).Return(&pool.Transaction{Status: pool.TxStatusFailed, FailedReason: &failedReason}, nil).Once()
+				m.Pool.On("GetTxStatusHistory", context.Background(), tc.Hash).Return(history, nil).Once()
+			},
+		},
+		{
+			Name:           "Get status of an unknown tx",
+			Hash:           common.HexToHash("0x123"),
+			ExpectedResult: nil,
+			SetupMocks: func(m *mocksWrapper, tc testCase) {
+				m.DbTx.On("Commit", context.Background()).Return(nil).Once()
+				m.State.On("BeginStateTransaction", context.Background()).Return(m.DbTx, nil).Once()
+				m.State.On("GetTransactionReceipt", context.Background(), tc.Hash, m.DbTx).Return(nil, state.ErrNotFound).Once()
+				m.Pool.On("GetTransactionByHash", context.Background(), tc.Hash).Return(nil, pool.ErrNotFound).Once()
+			},
+		},
+		{
+			Name:           "Get status fails to load the tx from the pool",
+			Hash:           common.HexToHash("0x123"),
+			ExpectedResult: nil,
+			ExpectedError:  types.NewRPCError(types.DefaultErrorCode, "failed to load transaction from pool"),
+			SetupMocks: func(m *mocksWrapper, tc testCase) {
+				m.DbTx.On("Rollback", context.Background()).Return(nil).Once()
+				m.State.On("BeginStateTransaction", context.Background()).Return(m.DbTx, nil).Once()
+				m.State.On("GetTransactionReceipt", context.Background(), tc.Hash, m.DbTx).Return(nil, state.ErrNotFound).Once()
+				m.Pool.On("GetTransactionByHash", context.Background(), tc.Hash).Return(nil, errors.New("failed to load tx")).Once()
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			tc := testCase
+			tc.SetupMocks(m, tc)
+
+			res, err := s.JSONRPCCall("zkevm_getTransactionStatus", tc.Hash.String())
+			require.NoError(t, err)
+
+			if tc.ExpectedResult != nil {
+				require.NotNil(t, res.Result)
+				require.Nil(t, res.Error)
+
+				var result types.TransactionStatus
+				err = json.Unmarshal(res.Result, &result)
+				require.NoError(t, err)
+				assert.Equal(t, *tc.ExpectedResult, result)
+			} else if tc.ExpectedError == nil {
+				require.Nil(t, res.Error)
+				assert.Equal(t, "null", string(res.Result))
+			}
+
+			if res.Error != nil || tc.ExpectedError != nil {
+				rpcErr := res.Error.RPCError()
+				assert.Equal(t, tc.ExpectedError.ErrorCode(), rpcErr.ErrorCode())
+				assert.Equal(t, tc.ExpectedError.Error(), rpcErr.Error())
+			}
+		})
+	}
+}
+
+func TestSendScheduledTransaction(t *testing.T) {
+	s, m, _ := newSequencerMockedServer(t)
+	defer s.Stop()
+
+	type testCase struct {
+		Name           string
+		Input          string
+		Schedule       types.ScheduledTxArgs
+		ExpectedResult *common.Hash
+		ExpectedError  types.Error
+		SetupMocks     func(m *mocksWrapper, tc testCase)
+	}
+
+	tx := ethTypes.NewTransaction(1, common.HexToAddress("0x1"), big.NewInt(1), uint64(1), big.NewInt(1), []byte{})
+	txBinary, err := tx.MarshalBinary()
+	require.NoError(t, err)
+	txHash := tx.Hash()
+
+	testCases := []testCase{
+		{
+			Name:           "Send scheduled TX successfully",
+			Input:          hex.EncodeToHex(txBinary),
+			Schedule:       types.ScheduledTxArgs{Timestamp: types.ArgUint64Ptr(1700000000), BlockNumber: types.ArgUint64Ptr(100)},
+			ExpectedResult: &txHash,
+			SetupMocks: func(m *mocksWrapper, tc testCase) {
+				schedule := pool.TxSchedule{NotBeforeTimestamp: 1700000000, NotBeforeBlock: 100}
+				m.Pool.On("AddScheduledTx", context.Background(), mock.IsType(ethTypes.Transaction{}), "", schedule).Return(nil).Once()
+			},
+		},
+		{
+			Name:          "Send scheduled TX too far ahead",
+			Input:         hex.EncodeToHex(txBinary),
+			Schedule:      types.ScheduledTxArgs{BlockNumber: types.ArgUint64Ptr(1000000)},
+			ExpectedError: types.NewRPCError(types.DefaultErrorCode, pool.ErrScheduleTooFarAhead.Error()),
+			SetupMocks: func(m *mocksWrapper, tc testCase) {
+				schedule := pool.TxSchedule{NotBeforeBlock: 1000000}
+				m.Pool.On("AddScheduledTx", context.Background(), mock.IsType(ethTypes.Transaction{}), "", schedule).Return(pool.ErrScheduleTooFarAhead).Once()
+			},
+		},
+		{
+			Name:          "Send scheduled TX with invalid tx input",
+			Input:         "0x1234",
+			ExpectedError: types.NewRPCError(types.InvalidParamsErrorCode, "invalid tx input"),
+			SetupMocks:    func(m *mocksWrapper, tc testCase) {},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			tc := testCase
+			tc.SetupMocks(m, tc)
+
+			res, err := s.JSONRPCCall("zkevm_sendScheduledTransaction", tc.Input, tc.Schedule)
+			require.NoError(t, err)
+
+			if tc.ExpectedResult != nil {
+				require.Nil(t, res.Error)
+				var result common.Hash
+				err = json.Unmarshal(res.Result, &result)
+				require.NoError(t, err)
+				assert.Equal(t, *tc.ExpectedResult, result)
+			}
+			if res.Error != nil || tc.ExpectedError != nil {
+				assert.Equal(t, tc.ExpectedError.ErrorCode(), res.Error.Code)
+				assert.Equal(t, tc.ExpectedError.Error(), res.Error.Message)
+			}
+		})
+	}
+}
+
 func ptrArgUint64FromUint(n uint) *types.ArgUint64 {
 	tmp := types.ArgUint64(n)
 	return &tmp
@@ -6,14 +6,20 @@ import (
 	"net/http"
 	"reflect"
 	"strings"
+	"time"
 	"unicode"
 
+	"github.com/0xPolygonHermez/zkevm-node/jsonrpc/metrics"
 	"github.com/0xPolygonHermez/zkevm-node/jsonrpc/types"
 	"github.com/0xPolygonHermez/zkevm-node/log"
 )
 
 const (
 	requiredReturnParamsPerFn = 2
+
+	// unknownMethodLabel is the metrics label of the requests to methods that don't exist,
+	// so the clients can't create a new time series per method name they send
+	unknownMethodLabel = "unknown"
 )
 
 type serviceData struct {
@@ -61,12 +67,20 @@ type handleRequest struct {
 //
 // check the `eth.go` file for more example on how the methods are implemented
 type Handler struct {
-	serviceMap map[string]*serviceData
+	serviceMap           map[string]*serviceData
+	accessControl        *accessControl
+	responseCache        *responseCache
+	slowRequestThreshold time.Duration
 }
 
-func newJSONRpcHandler() *Handler {
+func newJSONRpcHandler(cfg Config, s types.StateInterface) *Handler {
 	handler := &Handler{
-		serviceMap: map[string]*serviceData{},
+		serviceMap:           map[string]*serviceData{},
+		accessControl:        newAccessControl(cfg.AccessControl),
+		slowRequestThreshold: cfg.SlowRequestThreshold.Duration,
+	}
+	if cfg.ResponseCacheMaxBytesSize > 0 {
+		handler.responseCache = newResponseCache(cfg.ResponseCacheMaxBytesSize, cfg.ResponseCacheTTL.Duration, s)
 	}
 	return handler
 }
@@ -74,9 +88,53 @@ func newJSONRpcHandler() *Handler {
 // Handle is the function that knows which and how a function should
 // be executed when a JSON RPC request is received
 func (h *Handler) Handle(req handleRequest) types.Response {
+	start := time.Now()
+	res := h.handle(req)
+	h.trackRequest(req, res, start)
+	return res
+}
+
+// trackRequest updates the metrics of the method of the request and logs the
+// requests that took longer than the slow request threshold
+func (h *Handler) trackRequest(req handleRequest, res types.Response, start time.Time) {
+	duration := time.Since(start)
+
+	method := req.Method
+	if _, _, err := h.getFnHandler(req.Request); err != nil {
+		method = unknownMethodLabel
+	}
+	metrics.RequestMethodHandled(method, start)
+	if res.Error != nil {
+		metrics.RequestMethodError(method, res.Error.Code)
+	}
+
+	if h.slowRequestThreshold > 0 && duration >= h.slowRequestThreshold {
+		transport := metrics.HTTPConnLabel
+		if req.wsConn != nil {
+			transport = metrics.WSConnLabel
+		}
+		log.Warnf("slow request: method %s, transport %s, duration %v, params %v", req.Method, transport, duration, string(req.Params))
+	}
+}
+
+func (h *Handler) handle(req handleRequest) types.Response {
 	log := log.WithFields("method", req.Method, "requestId", req.ID)
 	log.Debugf("request params %v", string(req.Params))
 
+	if err := h.accessControl.check(req.Method, req.HttpRequest); err != nil {
+		return types.NewResponse(req.Request, nil, err)
+	}
+
+	var cacheKey string
+	if h.responseCache != nil {
+		cacheKey = responseCacheKey(req.Request)
+		if cacheKey != "" {
+			if result, found := h.responseCache.get(cacheKey); found {
+				return types.NewResponse(req.Request, result, nil)
+			}
+		}
+	}
+
 	service, fd, err := h.getFnHandler(req.Request)
 	if err != nil {
 		return types.NewResponse(req.Request, nil, err)
@@ -86,7 +144,6 @@ func (h *Handler) Handle(req handleRequest) types.Response {
 	inArgs := make([]reflect.Value, fd.inNum)
 	inArgs[0] = service.sv
 
-	requestHasWebSocketConn := req.wsConn != nil
 	funcHasMoreThanOneInputParams := len(fd.reqt) > 1
 	firstFuncParamIsWebSocketConn := false
 	firstFuncParamIsHttpRequest := false
@@ -94,7 +151,8 @@ func (h *Handler) Handle(req handleRequest) types.Response {
 		firstFuncParamIsWebSocketConn = fd.reqt[1].AssignableTo(reflect.TypeOf(&concurrentWsConn{}))
 		firstFuncParamIsHttpRequest = fd.reqt[1].AssignableTo(reflect.TypeOf(&http.Request{}))
 	}
-	if requestHasWebSocketConn && firstFuncParamIsWebSocketConn {
+	if firstFuncParamIsWebSocketConn {
+		// the web socket connection is nil when the request is received via http
 		inArgs[1] = reflect.ValueOf(req.wsConn)
 		inArgsOffset++
 	} else if firstFuncParamIsHttpRequest {
@@ -137,6 +195,10 @@ func (h *Handler) Handle(req handleRequest) types.Response {
 		data = d
 	}
 
+	if cacheKey != "" {
+		h.responseCache.add(cacheKey, req.Request, data)
+	}
+
 	return types.NewResponse(req.Request, data, nil)
 }
 
@@ -0,0 +1,46 @@
+package jsonrpc
+
+import (
+	"encoding/json"
+	"strconv"
+	"testing"
+
+	jsonrpcMetrics "github.com/0xPolygonHermez/zkevm-node/jsonrpc/metrics"
+	"github.com/0xPolygonHermez/zkevm-node/jsonrpc/types"
+	"github.com/0xPolygonHermez/zkevm-node/metrics"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHandleRequestMetrics(t *testing.T) {
+	metrics.Init()
+	jsonrpcMetrics.Register()
+
+	h := newJSONRpcHandler(Config{}, nil)
+	h.registerService(Service{Name: APIWeb3, Service: &Web3Endpoints{}})
+
+	counterValue := func(name string, label string) float64 {
+		counterVec, exist := metrics.CounterVec(name)
+		require.True(t, exist)
+		return testutil.ToFloat64(counterVec.WithLabelValues(label))
+	}
+	handledBefore := counterValue("jsonrpc_request_method_handled", "web3_clientVersion")
+	unknownBefore := counterValue("jsonrpc_request_method_handled", unknownMethodLabel)
+	unknownErrorsBefore := counterValue("jsonrpc_request_method_error", unknownMethodLabel)
+	notFoundBefore := counterValue("jsonrpc_request_error_code", strconv.Itoa(types.NotFoundErrorCode))
+
+	res := h.Handle(handleRequest{Request: types.Request{JSONRPC: "2.0", ID: 1, Method: "web3_clientVersion", Params: json.RawMessage("[]")}})
+	require.Nil(t, res.Error)
+	res = h.Handle(handleRequest{Request: types.Request{JSONRPC: "2.0", ID: 2, Method: "web3_notAMethod", Params: json.RawMessage("[]")}})
+	require.NotNil(t, res.Error)
+
+	assert.Equal(t, handledBefore+1, counterValue("jsonrpc_request_method_handled", "web3_clientVersion"))
+	assert.Equal(t, unknownBefore+1, counterValue("jsonrpc_request_method_handled", unknownMethodLabel))
+	assert.Equal(t, unknownErrorsBefore+1, counterValue("jsonrpc_request_method_error", unknownMethodLabel))
+	assert.Equal(t, notFoundBefore+1, counterValue("jsonrpc_request_error_code", strconv.Itoa(types.NotFoundErrorCode)))
+
+	histogramVec, exist := metrics.HistogramVec("jsonrpc_request_method_duration")
+	require.True(t, exist)
+	assert.GreaterOrEqual(t, testutil.CollectAndCount(histogramVec), 2)
+}
@@ -1,14 +1,26 @@
 package jsonrpc
 
+import (
+	"context"
+
+	"github.com/0xPolygonHermez/zkevm-node/sequencer"
+)
+
 // storageInterface json rpc internal storage to persist data
 type storageInterface interface {
 	GetAllBlockFiltersWithWSConn() []*Filter
 	GetAllLogFiltersWithWSConn() []*Filter
+	GetAllPendingTxFiltersWithWSConn() []*Filter
 	GetFilter(filterID string) (*Filter, error)
-	NewBlockFilter(wsConn *concurrentWsConn) (string, error)
-	NewLogFilter(wsConn *concurrentWsConn, filter LogFilter) (string, error)
-	NewPendingTransactionFilter(wsConn *concurrentWsConn) (string, error)
+	NewBlockFilter(wsConn *concurrentWsConn, client string) (string, error)
+	NewLogFilter(wsConn *concurrentWsConn, client string, filter LogFilter) (string, error)
+	NewPendingTransactionFilter(wsConn *concurrentWsConn, client string) (string, error)
 	UninstallFilter(filterID string) error
 	UninstallFilterByWSConn(wsConn *concurrentWsConn) error
 	UpdateFilterLastPoll(filterID string) error
 }
+
+// selectionSimulatorInterface simulates the selection of the pending txs of the pool by the sequencer
+type selectionSimulatorInterface interface {
+	SimulateSelection(ctx context.Context, txSorterType string, selectionMode string, limit uint64) (sequencer.SelectionSimulation, error)
+}
@@ -1,6 +1,7 @@
 package metrics
 
 import (
+	"strconv"
 	"time"
 
 	"github.com/0xPolygonHermez/zkevm-node/metrics"
@@ -14,7 +15,15 @@ const (
 	requestDurationName = requestPrefix + "duration"
 	connName            = requestPrefix + "connection"
 
+	requestMethodHandledName  = requestPrefix + "method_handled"
+	requestMethodDurationName = requestPrefix + "method_duration"
+	requestMethodErrorName    = requestPrefix + "method_error"
+	requestErrorCodeName      = requestPrefix + "error_code"
+
 	requestHandledTypeLabelName = "type"
+	connTypeLabelName           = "type"
+	methodLabelName             = "method"
+	errorCodeLabelName          = "code"
 )
 
 // RequestHandledLabel represents the possible values for the
@@ -46,6 +55,7 @@ func Register() {
 	var (
 		counterVecs []metrics.CounterVecOpts
 		histograms  []prometheus.HistogramOpts
+		histVecs    []metrics.HistogramVecOpts
 	)
 
 	counterVecs = []metrics.CounterVecOpts{
@@ -56,6 +66,34 @@ func Register() {
 			},
 			Labels: []string{requestHandledTypeLabelName},
 		},
+		{
+			CounterOpts: prometheus.CounterOpts{
+				Name: connName,
+				Help: "[JSONRPC] number of requests received per connection type",
+			},
+			Labels: []string{connTypeLabelName},
+		},
+		{
+			CounterOpts: prometheus.CounterOpts{
+				Name: requestMethodHandledName,
+				Help: "[JSONRPC] number of requests handled per method",
+			},
+			Labels: []string{methodLabelName},
+		},
+		{
+			CounterOpts: prometheus.CounterOpts{
+				Name: requestMethodErrorName,
+				Help: "[JSONRPC] number of requests that returned an error per method",
+			},
+			Labels: []string{methodLabelName},
+		},
+		{
+			CounterOpts: prometheus.CounterOpts{
+				Name: requestErrorCodeName,
+				Help: "[JSONRPC] number of requests that returned an error per error code",
+			},
+			Labels: []string{errorCodeLabelName},
+		},
 	}
 
 	start := 0.1
@@ -69,8 +107,20 @@ func Register() {
 		},
 	}
 
+	histVecs = []metrics.HistogramVecOpts{
+		{
+			HistogramOpts: prometheus.HistogramOpts{
+				Name:    requestMethodDurationName,
+				Help:    "[JSONRPC] Histogram for the runtime of requests per method",
+				Buckets: prometheus.ExponentialBuckets(0.005, 2, 14), // nolint:gomnd
+			},
+			Labels: []string{methodLabelName},
+		},
+	}
+
 	metrics.RegisterCounterVecs(counterVecs...)
 	metrics.RegisterHistograms(histograms...)
+	metrics.RegisterHistogramVecs(histVecs...)
 }
 
 // CountConn increments the connection counter vector by one for the
@@ -90,3 +140,18 @@ func RequestHandled(label RequestHandledLabel) {
 func RequestDuration(start time.Time) {
 	metrics.HistogramObserve(requestDurationName, time.Since(start).Seconds())
 }
+
+// RequestMethodHandled increments the requests handled counter vector of the
+// provided method by one and observes (histogram) the duration of the request
+// from the provided starting time.
+func RequestMethodHandled(method string, start time.Time) {
+	metrics.CounterVecInc(requestMethodHandledName, method)
+	metrics.HistogramVecObserve(requestMethodDurationName, method, time.Since(start).Seconds())
+}
+
+// RequestMethodError increments by one the error counter vectors of the provided
+// method and of the provided error code.
+func RequestMethodError(method string, errorCode int) {
+	metrics.CounterVecInc(requestMethodErrorName, method)
+	metrics.CounterVecInc(requestErrorCodeName, strconv.Itoa(errorCode))
+}
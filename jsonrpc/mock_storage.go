@@ -49,6 +49,26 @@ func (_m *storageMock) GetAllLogFiltersWithWSConn() []*Filter {
 	return r0
 }
 
+// GetAllPendingTxFiltersWithWSConn provides a mock function with given fields:
+func (_m *storageMock) GetAllPendingTxFiltersWithWSConn() []*Filter {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAllPendingTxFiltersWithWSConn")
+	}
+
+	var r0 []*Filter
+	if rf, ok := ret.Get(0).(func() []*Filter); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*Filter)
+		}
+	}
+
+	return r0
+}
+
 // GetFilter provides a mock function with given fields: filterID
 func (_m *storageMock) GetFilter(filterID string) (*Filter, error) {
 	ret := _m.Called(filterID)
@@ -79,9 +99,9 @@ func (_m *storageMock) GetFilter(filterID string) (*Filter, error) {
 	return r0, r1
 }
 
-// NewBlockFilter provides a mock function with given fields: wsConn
-func (_m *storageMock) NewBlockFilter(wsConn *concurrentWsConn) (string, error) {
-	ret := _m.Called(wsConn)
+// NewBlockFilter provides a mock function with given fields: wsConn, client
+func (_m *storageMock) NewBlockFilter(wsConn *concurrentWsConn, client string) (string, error) {
+	ret := _m.Called(wsConn, client)
 
 	if len(ret) == 0 {
 		panic("no return value specified for NewBlockFilter")
@@ -89,17 +109,17 @@ func (_m *storageMock) NewBlockFilter(wsConn *concurrentWsConn) (string, error)
 
 	var r0 string
 	var r1 error
-	if rf, ok := ret.Get(0).(func(*concurrentWsConn) (string, error)); ok {
-		return rf(wsConn)
+	if rf, ok := ret.Get(0).(func(*concurrentWsConn, string) (string, error)); ok {
+		return rf(wsConn, client)
 	}
-	if rf, ok := ret.Get(0).(func(*concurrentWsConn) string); ok {
-		r0 = rf(wsConn)
+	if rf, ok := ret.Get(0).(func(*concurrentWsConn, string) string); ok {
+		r0 = rf(wsConn, client)
 	} else {
 		r0 = ret.Get(0).(string)
 	}
 
-	if rf, ok := ret.Get(1).(func(*concurrentWsConn) error); ok {
-		r1 = rf(wsConn)
+	if rf, ok := ret.Get(1).(func(*concurrentWsConn, string) error); ok {
+		r1 = rf(wsConn, client)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -107,9 +127,9 @@ func (_m *storageMock) NewBlockFilter(wsConn *concurrentWsConn) (string, error)
 	return r0, r1
 }
 
-// NewLogFilter provides a mock function with given fields: wsConn, filter
-func (_m *storageMock) NewLogFilter(wsConn *concurrentWsConn, filter LogFilter) (string, error) {
-	ret := _m.Called(wsConn, filter)
+// NewLogFilter provides a mock function with given fields: wsConn, client, filter
+func (_m *storageMock) NewLogFilter(wsConn *concurrentWsConn, client string, filter LogFilter) (string, error) {
+	ret := _m.Called(wsConn, client, filter)
 
 	if len(ret) == 0 {
 		panic("no return value specified for NewLogFilter")
@@ -117,17 +137,17 @@ func (_m *storageMock) NewLogFilter(wsConn *concurrentWsConn, filter LogFilter)
 
 	var r0 string
 	var r1 error
-	if rf, ok := ret.Get(0).(func(*concurrentWsConn, LogFilter) (string, error)); ok {
-		return rf(wsConn, filter)
+	if rf, ok := ret.Get(0).(func(*concurrentWsConn, string, LogFilter) (string, error)); ok {
+		return rf(wsConn, client, filter)
 	}
-	if rf, ok := ret.Get(0).(func(*concurrentWsConn, LogFilter) string); ok {
-		r0 = rf(wsConn, filter)
+	if rf, ok := ret.Get(0).(func(*concurrentWsConn, string, LogFilter) string); ok {
+		r0 = rf(wsConn, client, filter)
 	} else {
 		r0 = ret.Get(0).(string)
 	}
 
-	if rf, ok := ret.Get(1).(func(*concurrentWsConn, LogFilter) error); ok {
-		r1 = rf(wsConn, filter)
+	if rf, ok := ret.Get(1).(func(*concurrentWsConn, string, LogFilter) error); ok {
+		r1 = rf(wsConn, client, filter)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -135,9 +155,9 @@ func (_m *storageMock) NewLogFilter(wsConn *concurrentWsConn, filter LogFilter)
 	return r0, r1
 }
 
-// NewPendingTransactionFilter provides a mock function with given fields: wsConn
-func (_m *storageMock) NewPendingTransactionFilter(wsConn *concurrentWsConn) (string, error) {
-	ret := _m.Called(wsConn)
+// NewPendingTransactionFilter provides a mock function with given fields: wsConn, client
+func (_m *storageMock) NewPendingTransactionFilter(wsConn *concurrentWsConn, client string) (string, error) {
+	ret := _m.Called(wsConn, client)
 
 	if len(ret) == 0 {
 		panic("no return value specified for NewPendingTransactionFilter")
@@ -145,17 +165,17 @@ func (_m *storageMock) NewPendingTransactionFilter(wsConn *concurrentWsConn) (st
 
 	var r0 string
 	var r1 error
-	if rf, ok := ret.Get(0).(func(*concurrentWsConn) (string, error)); ok {
-		return rf(wsConn)
+	if rf, ok := ret.Get(0).(func(*concurrentWsConn, string) (string, error)); ok {
+		return rf(wsConn, client)
 	}
-	if rf, ok := ret.Get(0).(func(*concurrentWsConn) string); ok {
-		r0 = rf(wsConn)
+	if rf, ok := ret.Get(0).(func(*concurrentWsConn, string) string); ok {
+		r0 = rf(wsConn, client)
 	} else {
 		r0 = ret.Get(0).(string)
 	}
 
-	if rf, ok := ret.Get(1).(func(*concurrentWsConn) error); ok {
-		r1 = rf(wsConn)
+	if rf, ok := ret.Get(1).(func(*concurrentWsConn, string) error); ok {
+		r1 = rf(wsConn, client)
 	} else {
 		r1 = ret.Error(1)
 	}
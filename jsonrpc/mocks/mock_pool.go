@@ -22,6 +22,60 @@ type PoolMock struct {
 	mock.Mock
 }
 
+// AddConditionalTx provides a mock function with given fields: ctx, tx, ip, conditions
+func (_m *PoolMock) AddConditionalTx(ctx context.Context, tx types.Transaction, ip string, conditions pool.TxConditions) error {
+	ret := _m.Called(ctx, tx, ip, conditions)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AddConditionalTx")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, types.Transaction, string, pool.TxConditions) error); ok {
+		r0 = rf(ctx, tx, ip, conditions)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// AddLocalTx provides a mock function with given fields: ctx, tx, ip
+func (_m *PoolMock) AddLocalTx(ctx context.Context, tx types.Transaction, ip string) error {
+	ret := _m.Called(ctx, tx, ip)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AddLocalTx")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, types.Transaction, string) error); ok {
+		r0 = rf(ctx, tx, ip)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// AddScheduledTx provides a mock function with given fields: ctx, tx, ip, schedule
+func (_m *PoolMock) AddScheduledTx(ctx context.Context, tx types.Transaction, ip string, schedule pool.TxSchedule) error {
+	ret := _m.Called(ctx, tx, ip, schedule)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AddScheduledTx")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, types.Transaction, string, pool.TxSchedule) error); ok {
+		r0 = rf(ctx, tx, ip, schedule)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // AddTx provides a mock function with given fields: ctx, tx, ip
 func (_m *PoolMock) AddTx(ctx context.Context, tx types.Transaction, ip string) error {
 	ret := _m.Called(ctx, tx, ip)
@@ -40,6 +94,36 @@ func (_m *PoolMock) AddTx(ctx context.Context, tx types.Transaction, ip string)
 	return r0
 }
 
+// AddUserOperation provides a mock function with given fields: ctx, userOp, entryPoint, ip
+func (_m *PoolMock) AddUserOperation(ctx context.Context, userOp pool.UserOp, entryPoint common.Address, ip string) (common.Hash, error) {
+	ret := _m.Called(ctx, userOp, entryPoint, ip)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AddUserOperation")
+	}
+
+	var r0 common.Hash
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, pool.UserOp, common.Address, string) (common.Hash, error)); ok {
+		return rf(ctx, userOp, entryPoint, ip)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, pool.UserOp, common.Address, string) common.Hash); ok {
+		r0 = rf(ctx, userOp, entryPoint, ip)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(common.Hash)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, pool.UserOp, common.Address, string) error); ok {
+		r1 = rf(ctx, userOp, entryPoint, ip)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // CalculateEffectiveGasPrice provides a mock function with given fields: rawTx, txGasPrice, txGasUsed, l1GasPrice, l2GasPrice
 func (_m *PoolMock) CalculateEffectiveGasPrice(rawTx []byte, txGasPrice *big.Int, txGasUsed uint64, l1GasPrice uint64, l2GasPrice uint64) (*big.Int, error) {
 	ret := _m.Called(rawTx, txGasPrice, txGasUsed, l1GasPrice, l2GasPrice)
@@ -98,6 +182,24 @@ func (_m *PoolMock) CalculateEffectiveGasPricePercentage(gasPrice *big.Int, effe
 	return r0, r1
 }
 
+// CheckHealth provides a mock function with given fields: ctx
+func (_m *PoolMock) CheckHealth(ctx context.Context) error {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CheckHealth")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context) error); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // CountPendingTransactions provides a mock function with given fields: ctx
 func (_m *PoolMock) CountPendingTransactions(ctx context.Context) (uint64, error) {
 	ret := _m.Called(ctx)
@@ -172,27 +274,96 @@ func (_m *PoolMock) GetGasPrices(ctx context.Context) (pool.GasPrices, error) {
 	return r0, r1
 }
 
-// GetNonce provides a mock function with given fields: ctx, address
-func (_m *PoolMock) GetNonce(ctx context.Context, address common.Address) (uint64, error) {
-	ret := _m.Called(ctx, address)
+// GetPendingBlock provides a mock function with given fields: ctx
+func (_m *PoolMock) GetPendingBlock(ctx context.Context) (*pool.PendingBlock, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetPendingBlock")
+	}
+
+	var r0 *pool.PendingBlock
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) (*pool.PendingBlock, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) *pool.PendingBlock); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*pool.PendingBlock)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetPendingAndQueuedTxs provides a mock function with given fields: ctx, limit
+func (_m *PoolMock) GetPendingAndQueuedTxs(ctx context.Context, limit uint64) (map[common.Address][]pool.Transaction, map[common.Address][]pool.Transaction, error) {
+	ret := _m.Called(ctx, limit)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetNonce")
+		panic("no return value specified for GetPendingAndQueuedTxs")
+	}
+
+	var r0 map[common.Address][]pool.Transaction
+	var r1 map[common.Address][]pool.Transaction
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint64) (map[common.Address][]pool.Transaction, map[common.Address][]pool.Transaction, error)); ok {
+		return rf(ctx, limit)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uint64) map[common.Address][]pool.Transaction); ok {
+		r0 = rf(ctx, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[common.Address][]pool.Transaction)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uint64) map[common.Address][]pool.Transaction); ok {
+		r1 = rf(ctx, limit)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(map[common.Address][]pool.Transaction)
+		}
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, uint64) error); ok {
+		r2 = rf(ctx, limit)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// GetPendingNonce provides a mock function with given fields: ctx, address, accountNonce
+func (_m *PoolMock) GetPendingNonce(ctx context.Context, address common.Address, accountNonce uint64) (uint64, error) {
+	ret := _m.Called(ctx, address, accountNonce)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetPendingNonce")
 	}
 
 	var r0 uint64
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, common.Address) (uint64, error)); ok {
-		return rf(ctx, address)
+	if rf, ok := ret.Get(0).(func(context.Context, common.Address, uint64) (uint64, error)); ok {
+		return rf(ctx, address, accountNonce)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, common.Address) uint64); ok {
-		r0 = rf(ctx, address)
+	if rf, ok := ret.Get(0).(func(context.Context, common.Address, uint64) uint64); ok {
+		r0 = rf(ctx, address, accountNonce)
 	} else {
 		r0 = ret.Get(0).(uint64)
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, common.Address) error); ok {
-		r1 = rf(ctx, address)
+	if rf, ok := ret.Get(1).(func(context.Context, common.Address, uint64) error); ok {
+		r1 = rf(ctx, address, accountNonce)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -320,6 +491,56 @@ func (_m *PoolMock) GetTransactionByL2Hash(ctx context.Context, hash common.Hash
 	return r0, r1
 }
 
+// GetTxStatusHistory provides a mock function with given fields: ctx, hash
+func (_m *PoolMock) GetTxStatusHistory(ctx context.Context, hash common.Hash) ([]pool.TxStatusChange, error) {
+	ret := _m.Called(ctx, hash)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetTxStatusHistory")
+	}
+
+	var r0 []pool.TxStatusChange
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, common.Hash) ([]pool.TxStatusChange, error)); ok {
+		return rf(ctx, hash)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, common.Hash) []pool.TxStatusChange); ok {
+		r0 = rf(ctx, hash)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]pool.TxStatusChange)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, common.Hash) error); ok {
+		r1 = rf(ctx, hash)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SupportedEntryPoints provides a mock function with given fields:
+func (_m *PoolMock) SupportedEntryPoints() []common.Address {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for SupportedEntryPoints")
+	}
+
+	var r0 []common.Address
+	if rf, ok := ret.Get(0).(func() []common.Address); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]common.Address)
+		}
+	}
+
+	return r0
+}
+
 // NewPoolMock creates a new instance of PoolMock. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewPoolMock(t interface {
@@ -33,6 +33,7 @@ type Filter struct {
 	LastPoll   time.Time
 	WsConn     *concurrentWsConn
 
+	client        string
 	wsQueue       *state.Queue[[]byte]
 	wsQueueSignal *sync.Cond
 }
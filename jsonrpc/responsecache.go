@@ -0,0 +1,211 @@
+package jsonrpc
+
+import (
+	"bytes"
+	"container/list"
+	"context"
+	"encoding/json"
+	"sync"
+	"time"
+
+	"github.com/0xPolygonHermez/zkevm-node/jsonrpc/types"
+	"github.com/0xPolygonHermez/zkevm-node/log"
+	"github.com/jackc/pgx/v4"
+)
+
+// cacheableMethods are the methods whose responses don't change once they are available, with
+// the function that checks if the result of a request with the provided params can be cached
+var cacheableMethods = map[string]cacheableFunc{
+	"eth_chainId":                           alwaysCacheable,
+	"net_version":                           alwaysCacheable,
+	"eth_getBlockByHash":                    cacheableIfInBlock,
+	"eth_getTransactionByBlockHashAndIndex": cacheableIfInBlock,
+	"eth_getTransactionReceipt":             cacheableIfInBlock,
+	"eth_getTransactionByHash":              cacheableIfInBlock,
+	"zkevm_getFullBlockByHash":              cacheableIfInBlock,
+	"eth_getBlockReceipts":                  cacheableIfBlockParamIsFixed(0),
+	"eth_call":                              cacheableIfBlockParamIsFixed(1),
+}
+
+// cacheableFunc checks if the result of a request with the provided params can be cached. The
+// results that depend on an L2 block return the number of the block, as they change if the
+// block is reorganized, nil otherwise
+type cacheableFunc func(params json.RawMessage, result json.RawMessage) (cacheable bool, blockNumber *uint64)
+
+func alwaysCacheable(json.RawMessage, json.RawMessage) (bool, *uint64) {
+	return true, nil
+}
+
+// cacheableIfInBlock caches the blocks, txs and receipts found in a block, with the
+// number of the block. The objects not found yet or the pending txs change later
+func cacheableIfInBlock(_ json.RawMessage, result json.RawMessage) (bool, *uint64) {
+	blockNumber := resultBlockNumber(result)
+	return blockNumber != nil, blockNumber
+}
+
+// resultBlockNumber returns the number of the block of a block, tx or receipt, nil if the result
+// is null or it's not in a block
+func resultBlockNumber(result json.RawMessage) *uint64 {
+	var object struct {
+		Number      *types.ArgUint64 `json:"number"`
+		BlockNumber *types.ArgUint64 `json:"blockNumber"`
+	}
+	if len(result) == 0 || bytes.Equal(result, []byte("null")) || json.Unmarshal(result, &object) != nil {
+		return nil
+	}
+	if object.Number != nil {
+		return (*uint64)(object.Number)
+	}
+	return (*uint64)(object.BlockNumber)
+}
+
+// cacheableIfBlockParamIsFixed caches the results of the requests whose block param, at the provided
+// index, is a block number instead of a tag like latest, or a block hash of a block whose number is
+// known from the result
+func cacheableIfBlockParamIsFixed(index int) cacheableFunc {
+	return func(params json.RawMessage, result json.RawMessage) (bool, *uint64) {
+		var args []json.RawMessage
+		if json.Unmarshal(params, &args) != nil || len(args) <= index {
+			return false, nil
+		}
+		var blockArg types.BlockNumberOrHash
+		if json.Unmarshal(args[index], &blockArg) != nil {
+			return false, nil
+		}
+		if blockArg.IsHash() {
+			var receipts []json.RawMessage
+			if json.Unmarshal(result, &receipts) != nil || len(receipts) == 0 {
+				return false, nil
+			}
+			blockNumber := resultBlockNumber(receipts[0])
+			return blockNumber != nil, blockNumber
+		}
+		if blockArg.Number() == nil || *blockArg.Number() < 0 {
+			return false, nil
+		}
+		blockNumber := uint64(*blockArg.Number())
+		return true, &blockNumber
+	}
+}
+
+// virtualizedBlockNumberGetter gets the last L2 block virtualized, from the state
+type virtualizedBlockNumberGetter interface {
+	GetLastVirtualizedL2BlockNumber(ctx context.Context, dbTx pgx.Tx) (uint64, error)
+}
+
+type responseCacheEntry struct {
+	key        string
+	result     json.RawMessage
+	expiration time.Time
+	size       uint64
+}
+
+// responseCache is a thread-safe LRU cache of the results of the JSON-RPC requests that don't
+// change, limited by the size in bytes of the results. The key of a result is the method and
+// the params of the request, the results expire after the TTL.
+//
+// The blocks not virtualized yet can be replaced by a trusted reorg, so the results that depend
+// on a block are only cached once the block is virtualized
+type responseCache struct {
+	mutex   sync.Mutex
+	maxSize uint64
+	ttl     time.Duration
+	size    uint64
+	entries map[string]*list.Element
+	lru     *list.List
+
+	state                    virtualizedBlockNumberGetter
+	lastVirtualizedL2BlockMu sync.Mutex
+	lastVirtualizedL2Block   uint64
+}
+
+func newResponseCache(maxSize uint64, ttl time.Duration, state virtualizedBlockNumberGetter) *responseCache {
+	return &responseCache{
+		maxSize: maxSize,
+		ttl:     ttl,
+		entries: make(map[string]*list.Element),
+		lru:     list.New(),
+		state:   state,
+	}
+}
+
+// responseCacheKey returns the key of the request in the cache, empty if the method is not cacheable
+func responseCacheKey(req types.Request) string {
+	if _, found := cacheableMethods[req.Method]; !found {
+		return ""
+	}
+	return req.Method + ":" + string(req.Params)
+}
+
+func (c *responseCache) get(key string) (json.RawMessage, bool) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	element, found := c.entries[key]
+	if !found {
+		return nil, false
+	}
+	entry := element.Value.(*responseCacheEntry)
+	if time.Now().After(entry.expiration) {
+		c.remove(element)
+		return nil, false
+	}
+	c.lru.MoveToFront(element)
+	return entry.result, true
+}
+
+// add caches the result of the request if the result of its method can be cached
+func (c *responseCache) add(key string, req types.Request, result json.RawMessage) {
+	size := uint64(len(key) + len(result))
+	if size > c.maxSize {
+		return
+	}
+	cacheable, blockNumber := cacheableMethods[req.Method](req.Params, result)
+	if !cacheable || (blockNumber != nil && !c.isVirtualized(*blockNumber)) {
+		return
+	}
+	entry := &responseCacheEntry{
+		key:        key,
+		result:     result,
+		expiration: time.Now().Add(c.ttl),
+		size:       size,
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if element, found := c.entries[key]; found {
+		c.remove(element)
+	}
+	c.entries[key] = c.lru.PushFront(entry)
+	c.size += entry.size
+
+	for c.size > c.maxSize {
+		c.remove(c.lru.Back())
+	}
+}
+
+func (c *responseCache) remove(element *list.Element) {
+	c.lru.Remove(element)
+	entry := element.Value.(*responseCacheEntry)
+	delete(c.entries, entry.key)
+	c.size -= entry.size
+}
+
+// isVirtualized checks if the L2 block is virtualized. The last L2 block virtualized is only
+// read from the state when the block is after the one read last time
+func (c *responseCache) isVirtualized(blockNumber uint64) bool {
+	c.lastVirtualizedL2BlockMu.Lock()
+	defer c.lastVirtualizedL2BlockMu.Unlock()
+
+	if blockNumber <= c.lastVirtualizedL2Block {
+		return true
+	}
+	lastVirtualizedL2Block, err := c.state.GetLastVirtualizedL2BlockNumber(context.Background(), nil)
+	if err != nil {
+		log.Errorf("failed to get the last virtualized L2 block to cache a response: %v", err)
+		return false
+	}
+	c.lastVirtualizedL2Block = lastVirtualizedL2Block
+	return blockNumber <= lastVirtualizedL2Block
+}
@@ -0,0 +1,131 @@
+package jsonrpc
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	cfgTypes "github.com/0xPolygonHermez/zkevm-node/config/types"
+	"github.com/0xPolygonHermez/zkevm-node/jsonrpc/mocks"
+	"github.com/0xPolygonHermez/zkevm-node/jsonrpc/types"
+	"github.com/jackc/pgx/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// lastVirtualizedL2Block is the last L2 block virtualized of the tests of the response cache
+type lastVirtualizedL2Block uint64
+
+func (b lastVirtualizedL2Block) GetLastVirtualizedL2BlockNumber(context.Context, pgx.Tx) (uint64, error) {
+	return uint64(b), nil
+}
+
+func TestResponseCacheableRequests(t *testing.T) {
+	testCases := []struct {
+		method    string
+		params    string
+		result    string
+		cacheable bool
+	}{
+		{"eth_chainId", `[]`, `"0x3e9"`, true},
+		{"eth_blockNumber", `[]`, `"0x1"`, false},
+		{"eth_getBlockByHash", `["0x1", false]`, `{"number":"0x1"}`, true},
+		{"eth_getBlockByHash", `["0x1", false]`, `{"number":"0x11"}`, false},
+		{"eth_getBlockByHash", `["0x1", false]`, `null`, false},
+		{"eth_getBlockTransactionCountByHash", `["0x1"]`, `"0x1"`, false},
+		{"eth_getTransactionByHash", `["0x1"]`, `{"blockNumber":"0x1"}`, true},
+		{"eth_getTransactionByHash", `["0x1"]`, `{"blockNumber":null}`, false},
+		{"eth_getTransactionReceipt", `["0x1"]`, `{"blockNumber":"0x11"}`, false},
+		{"eth_call", `[{"to":"0x1"}, "0x10"]`, `"0x"`, true},
+		{"eth_call", `[{"to":"0x1"}, "0x11"]`, `"0x"`, false},
+		{"eth_call", `[{"to":"0x1"}, {"blockHash":"0x0000000000000000000000000000000000000000000000000000000000000001"}]`, `"0x"`, false},
+		{"eth_call", `[{"to":"0x1"}, "latest"]`, `"0x"`, false},
+		{"eth_call", `[{"to":"0x1"}]`, `"0x"`, false},
+		{"eth_getBlockReceipts", `["0x10"]`, `[]`, true},
+		{"eth_getBlockReceipts", `[{"blockHash":"0x0000000000000000000000000000000000000000000000000000000000000001"}]`, `[{"blockNumber":"0x10"}]`, true},
+		{"eth_getBlockReceipts", `[{"blockHash":"0x0000000000000000000000000000000000000000000000000000000000000001"}]`, `[]`, false},
+		{"eth_getBlockReceipts", `["pending"]`, `[]`, false},
+	}
+
+	for _, testCase := range testCases {
+		c := newResponseCache(1024, time.Minute, lastVirtualizedL2Block(0x10))
+		req := types.Request{Method: testCase.method, Params: json.RawMessage(testCase.params)}
+		key := responseCacheKey(req)
+		if key != "" {
+			c.add(key, req, json.RawMessage(testCase.result))
+		}
+		result, found := c.get(key)
+		assert.Equal(t, testCase.cacheable, found, "%s %s %s", testCase.method, testCase.params, testCase.result)
+		if testCase.cacheable {
+			assert.Equal(t, testCase.result, string(result))
+		}
+	}
+}
+
+func TestResponseCacheEviction(t *testing.T) {
+	newRequest := func(hash string) types.Request {
+		return types.Request{Method: "eth_chainId", Params: json.RawMessage(`["` + hash + `"]`)}
+	}
+	result := json.RawMessage(`{"status":"0x1"}`)
+	entrySize := uint64(len(responseCacheKey(newRequest("0x1"))) + len(result))
+
+	// the least recently used result is evicted when the cache is full
+	c := newResponseCache(2*entrySize, time.Minute, nil)
+	for _, hash := range []string{"0x1", "0x2"} {
+		c.add(responseCacheKey(newRequest(hash)), newRequest(hash), result)
+	}
+	_, found := c.get(responseCacheKey(newRequest("0x1")))
+	assert.True(t, found)
+	c.add(responseCacheKey(newRequest("0x3")), newRequest("0x3"), result)
+
+	_, found = c.get(responseCacheKey(newRequest("0x2")))
+	assert.False(t, found)
+	for _, hash := range []string{"0x1", "0x3"} {
+		_, found = c.get(responseCacheKey(newRequest(hash)))
+		assert.True(t, found)
+	}
+	assert.Equal(t, 2*entrySize, c.size)
+
+	// the results expire after the TTL
+	c = newResponseCache(2*entrySize, time.Millisecond, nil)
+	c.add(responseCacheKey(newRequest("0x1")), newRequest("0x1"), result)
+	time.Sleep(2 * time.Millisecond)
+	_, found = c.get(responseCacheKey(newRequest("0x1")))
+	assert.False(t, found)
+	assert.Zero(t, c.size)
+}
+
+// reorgTestEndpoints returns the block of the hash requested, that is replaced by the reorgs of the tests
+type reorgTestEndpoints struct {
+	block string
+}
+
+func (e *reorgTestEndpoints) GetBlockByHash(hash types.ArgHash, fullTx bool) (interface{}, types.Error) {
+	return json.RawMessage(e.block), nil
+}
+
+func TestResponseCacheTrustedReorg(t *testing.T) {
+	st := mocks.NewStateMock(t)
+	h := newJSONRpcHandler(Config{ResponseCacheMaxBytesSize: 1024, ResponseCacheTTL: cfgTypes.NewDuration(time.Minute)}, st)
+	endpoints := &reorgTestEndpoints{block: `{"number":"0x2","stateRoot":"0x1"}`}
+	h.registerService(Service{Name: APIEth, Service: endpoints})
+
+	getBlock := func() string {
+		res := h.Handle(handleRequest{Request: types.Request{JSONRPC: "2.0", ID: 1, Method: "eth_getBlockByHash", Params: json.RawMessage(`["0x2", false]`)}})
+		require.Nil(t, res.Error)
+		return string(res.Result)
+	}
+
+	// the block is not virtualized, so it's not cached and the block that replaces it after the reorg is returned
+	st.On("GetLastVirtualizedL2BlockNumber", mock.Anything, nil).Return(uint64(1), nil).Once()
+	assert.Equal(t, endpoints.block, getBlock())
+	endpoints.block = `{"number":"0x2","stateRoot":"0x2"}`
+	st.On("GetLastVirtualizedL2BlockNumber", mock.Anything, nil).Return(uint64(2), nil).Once()
+	assert.Equal(t, `{"number":"0x2","stateRoot":"0x2"}`, getBlock())
+
+	// the block is cached once it's virtualized
+	endpoints.block = `{"number":"0x2","stateRoot":"0x3"}`
+	assert.Equal(t, `{"number":"0x2","stateRoot":"0x2"}`, getBlock())
+}
@@ -2,6 +2,7 @@ package jsonrpc
 
 import (
 	"bytes"
+	"compress/gzip"
 	"context"
 	"encoding/json"
 	"errors"
@@ -10,6 +11,8 @@ import (
 	"mime"
 	"net"
 	"net/http"
+	"slices"
+	"strings"
 	"syscall"
 	"time"
 
@@ -31,12 +34,22 @@ const (
 	APIZKEVM = "zkevm"
 	// APITxPool represents the txpool API prefix.
 	APITxPool = "txpool"
+	// APIPool represents the pool API prefix.
+	APIPool = "pool"
 	// APIWeb3 represents the web3 API prefix.
 	APIWeb3 = "web3"
+	// APITrace represents the trace API prefix.
+	APITrace = "trace"
+	// APIEthTxManager represents the ethtxmanager API prefix.
+	APIEthTxManager = "ethtxmanager"
+
+	// HealthzEndpoint is the endpoint that reports if the node is able to serve requests
+	HealthzEndpoint = "/healthz"
 
 	wsBufferSizeLimitInBytes = 1024
 	maxRequestContentLength  = 1024 * 1024 * 5
 	contentType              = "application/json"
+	healthCheckTimeout       = 5 * time.Second
 )
 
 // https://www.jsonrpc.org/historical/json-rpc-over-http.html#http-header
@@ -46,6 +59,7 @@ var acceptedContentTypes = []string{contentType, "application/json-rpc", "applic
 type Server struct {
 	config     Config
 	chainID    uint64
+	pool       types.PoolInterface
 	handler    *Handler
 	srv        *http.Server
 	wsSrv      *http.Server
@@ -78,7 +92,7 @@ func NewServer(
 		s.StartToMonitorNewL2Blocks()
 	}
 
-	handler := newJSONRpcHandler()
+	handler := newJSONRpcHandler(cfg, s)
 
 	for _, service := range services {
 		handler.registerService(service)
@@ -88,6 +102,7 @@ func NewServer(
 		config:  cfg,
 		handler: handler,
 		chainID: chainID,
+		pool:    p,
 	}
 	return srv
 }
@@ -121,15 +136,17 @@ func (s *Server) startHTTP() error {
 
 	lmt := tollbooth.NewLimiter(s.config.MaxRequestsPerIPAndSecond, nil)
 	mux.Handle("/", tollbooth.LimitFuncHandler(lmt, s.handle))
+	mux.HandleFunc(HealthzEndpoint, s.handleHealthz)
 
 	s.srv = &http.Server{
 		Handler:           mux,
 		ReadHeaderTimeout: s.config.ReadTimeout.Duration,
 		ReadTimeout:       s.config.ReadTimeout.Duration,
 		WriteTimeout:      s.config.WriteTimeout.Duration,
+		IdleTimeout:       s.config.IdleTimeout.Duration,
 	}
 	log.Infof("http server started: %s", address)
-	if err := s.srv.Serve(lis); err != nil {
+	if err := s.serve(s.srv, lis); err != nil {
 		if err == http.ErrServerClosed {
 			log.Infof("http server stopped")
 			return nil
@@ -140,6 +157,25 @@ func (s *Server) startHTTP() error {
 	return nil
 }
 
+// handleHealthz responds with an error status when the pool storage is unreachable,
+// as the node is not able to accept txs
+func (s *Server) handleHealthz(w http.ResponseWriter, req *http.Request) {
+	if s.pool != nil {
+		ctx, cancel := context.WithTimeout(req.Context(), healthCheckTimeout)
+		defer cancel()
+		if err := s.pool.CheckHealth(ctx); err != nil {
+			log.Errorf("health check failed, pool storage is unreachable: %v", err)
+			http.Error(w, "pool storage is unreachable", http.StatusServiceUnavailable)
+			return
+		}
+	}
+
+	w.WriteHeader(http.StatusOK)
+	if _, err := w.Write([]byte("OK")); err != nil {
+		log.Error(err)
+	}
+}
+
 // startWS starts a server to respond WebSockets connections
 func (s *Server) startWS() {
 	log.Infof("starting websocket server")
@@ -171,7 +207,7 @@ func (s *Server) startWS() {
 		WriteBufferSize: wsBufferSizeLimitInBytes,
 	}
 	log.Infof("websocket server started: %s", address)
-	if err := s.wsSrv.Serve(lis); err != nil {
+	if err := s.serve(s.wsSrv, lis); err != nil {
 		if err == http.ErrServerClosed {
 			log.Infof("websocket server stopped")
 			return
@@ -181,6 +217,15 @@ func (s *Server) startWS() {
 	}
 }
 
+// serve accepts the connections of the listener, over TLS if a certificate is configured, which
+// also enables HTTP/2
+func (s *Server) serve(srv *http.Server, lis net.Listener) error {
+	if s.config.TLSCertFile != "" {
+		return srv.ServeTLS(lis, s.config.TLSCertFile, s.config.TLSKeyFile)
+	}
+	return srv.Serve(lis)
+}
+
 // Stop shutdown the rpc server
 func (s *Server) Stop() error {
 	if s.srv != nil {
@@ -210,9 +255,9 @@ func (s *Server) Stop() error {
 
 func (s *Server) handle(w http.ResponseWriter, req *http.Request) {
 	w.Header().Set("Content-Type", contentType)
-	w.Header().Set("Access-Control-Allow-Origin", "*")
+	s.setCORSAllowedOrigin(w, req)
 	w.Header().Set("Access-Control-Allow-Methods", "POST, OPTIONS")
-	w.Header().Set("Access-Control-Allow-Headers", "Accept, Content-Type, Content-Length, Accept-Encoding, X-CSRF-Token, Authorization")
+	w.Header().Set("Access-Control-Allow-Headers", "Accept, Content-Type, Content-Length, Accept-Encoding, X-CSRF-Token, Authorization, "+apiKeyHeader)
 
 	if req.Method == http.MethodOptions {
 		return
@@ -231,6 +276,18 @@ func (s *Server) handle(w http.ResponseWriter, req *http.Request) {
 		return
 	}
 
+	if s.config.EnableHttpCompression && acceptsGzip(req) {
+		w.Header().Set("Content-Encoding", "gzip")
+		w.Header().Add("Vary", "Accept-Encoding")
+		gzipWriter := gzip.NewWriter(w)
+		defer func() {
+			if err := gzipWriter.Close(); err != nil {
+				log.Errorf("failed to close the gzip response: %v", err)
+			}
+		}()
+		w = &gzipResponseWriter{ResponseWriter: w, writer: gzipWriter}
+	}
+
 	body := io.LimitReader(req.Body, maxRequestContentLength)
 	data, err := io.ReadAll(body)
 	if err != nil {
@@ -257,6 +314,45 @@ func (s *Server) handle(w http.ResponseWriter, req *http.Request) {
 	s.combinedLog(req, start, http.StatusOK, respLen)
 }
 
+// setCORSAllowedOrigin allows the cross-origin requests from the configured origins
+func (s *Server) setCORSAllowedOrigin(w http.ResponseWriter, req *http.Request) {
+	origin := req.Header.Get("Origin")
+	if len(s.config.CORSAllowedOrigins) == 0 || slices.Contains(s.config.CORSAllowedOrigins, "*") {
+		w.Header().Set("Access-Control-Allow-Origin", "*")
+	} else if origin != "" && slices.Contains(s.config.CORSAllowedOrigins, origin) {
+		w.Header().Set("Access-Control-Allow-Origin", origin)
+		w.Header().Add("Vary", "Origin")
+	}
+}
+
+// isOriginAllowed checks if the WebSocket connections from the origin are allowed, the
+// requests without origin don't come from browsers so they are always allowed
+func (s *Server) isOriginAllowed(req *http.Request) bool {
+	origin := req.Header.Get("Origin")
+	return origin == "" || len(s.config.CORSAllowedOrigins) == 0 ||
+		slices.Contains(s.config.CORSAllowedOrigins, "*") || slices.Contains(s.config.CORSAllowedOrigins, origin)
+}
+
+// acceptsGzip checks if the client accepts gzip compressed responses
+func acceptsGzip(req *http.Request) bool {
+	for _, encoding := range strings.Split(req.Header.Get("Accept-Encoding"), ",") {
+		if strings.TrimSpace(strings.Split(encoding, ";")[0]) == "gzip" {
+			return true
+		}
+	}
+	return false
+}
+
+// gzipResponseWriter compresses the body of the response
+type gzipResponseWriter struct {
+	http.ResponseWriter
+	writer io.Writer
+}
+
+func (w *gzipResponseWriter) Write(b []byte) (int, error) {
+	return w.writer.Write(b)
+}
+
 // validateRequest returns a non-zero response code and error message if the
 // request is invalid.
 func validateRequest(req *http.Request) (int, error) {
@@ -377,8 +473,8 @@ func (s *Server) parseRequests(data []byte) ([]types.Request, error) {
 }
 
 func (s *Server) handleWs(w http.ResponseWriter, req *http.Request) {
-	// CORS rule - Allow requests from anywhere
-	s.wsUpgrader.CheckOrigin = func(r *http.Request) bool { return true }
+	// CORS rule - Allow requests from the configured origins
+	s.wsUpgrader.CheckOrigin = s.isOriginAllowed
 
 	// Upgrade the connection to a WS one
 	innerWsConn, err := s.wsUpgrader.Upgrade(w, req, nil)
@@ -387,7 +483,7 @@ func (s *Server) handleWs(w http.ResponseWriter, req *http.Request) {
 		return
 	}
 
-	wsConn := newConcurrentWsConn(innerWsConn)
+	wsConn := newConcurrentWsConn(innerWsConn, requestClient(req))
 
 	// Set read limit
 	wsConn.SetReadLimit(s.config.WebSockets.ReadLimit)
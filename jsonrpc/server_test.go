@@ -2,7 +2,9 @@ package jsonrpc
 
 import (
 	"bytes"
+	"compress/gzip"
 	"context"
+	"encoding/json"
 	"fmt"
 	"io"
 	"math/big"
@@ -12,6 +14,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/0xPolygonHermez/zkevm-node/hex"
 	"github.com/0xPolygonHermez/zkevm-node/jsonrpc/client"
 	"github.com/0xPolygonHermez/zkevm-node/jsonrpc/mocks"
 	"github.com/0xPolygonHermez/zkevm-node/jsonrpc/types"
@@ -38,11 +41,12 @@ type mockedServer struct {
 }
 
 type mocksWrapper struct {
-	Pool     *mocks.PoolMock
-	State    *mocks.StateMock
-	Etherman *mocks.EthermanMock
-	Storage  *storageMock
-	DbTx     *mocks.DBTxMock
+	Pool         *mocks.PoolMock
+	State        *mocks.StateMock
+	Etherman     *mocks.EthermanMock
+	Storage      *storageMock
+	DbTx         *mocks.DBTxMock
+	EthTxManager *mocks.EthTxManagerMock
 }
 
 func newMockedServer(t *testing.T, cfg Config) (*mockedServer, *mocksWrapper, *ethclient.Client) {
@@ -51,13 +55,17 @@ func newMockedServer(t *testing.T, cfg Config) (*mockedServer, *mocksWrapper, *e
 	etherman := mocks.NewEthermanMock(t)
 	storage := newStorageMock(t)
 	dbTx := mocks.NewDBTxMock(t)
+	ethTxManager := mocks.NewEthTxManagerMock(t)
 	apis := map[string]bool{
-		APIEth:    true,
-		APINet:    true,
-		APIDebug:  true,
-		APIZKEVM:  true,
-		APITxPool: true,
-		APIWeb3:   true,
+		APIEth:          true,
+		APINet:          true,
+		APIDebug:        true,
+		APIZKEVM:        true,
+		APITxPool:       true,
+		APIPool:         true,
+		APIWeb3:         true,
+		APITrace:        true,
+		APIEthTxManager: true,
 	}
 
 	var newL2BlockEventHandler state.NewL2BlockEventHandler = func(e state.NewL2BlockEvent) {}
@@ -89,14 +97,28 @@ func newMockedServer(t *testing.T, cfg Config) (*mockedServer, *mocksWrapper, *e
 	if _, ok := apis[APITxPool]; ok {
 		services = append(services, Service{
 			Name:    APITxPool,
-			Service: &TxPoolEndpoints{},
+			Service: NewTxPoolEndpoints(cfg, pool),
+		})
+	}
+
+	if _, ok := apis[APIPool]; ok {
+		services = append(services, Service{
+			Name:    APIPool,
+			Service: NewPoolEndpoints(cfg, pool),
 		})
 	}
 
 	if _, ok := apis[APIDebug]; ok {
 		services = append(services, Service{
 			Name:    APIDebug,
-			Service: NewDebugEndpoints(cfg, st, etherman),
+			Service: NewDebugEndpoints(cfg, st, etherman, nil),
+		})
+	}
+
+	if _, ok := apis[APITrace]; ok {
+		services = append(services, Service{
+			Name:    APITrace,
+			Service: NewTraceEndpoints(cfg, st, etherman),
 		})
 	}
 
@@ -106,6 +128,13 @@ func newMockedServer(t *testing.T, cfg Config) (*mockedServer, *mocksWrapper, *e
 			Service: &Web3Endpoints{},
 		})
 	}
+
+	if _, ok := apis[APIEthTxManager]; ok {
+		services = append(services, Service{
+			Name:    APIEthTxManager,
+			Service: NewEthTxManagerEndpoints(cfg, ethTxManager),
+		})
+	}
 	server := NewServer(cfg, chainID, pool, st, storage, services)
 
 	go func() {
@@ -139,11 +168,12 @@ func newMockedServer(t *testing.T, cfg Config) (*mockedServer, *mocksWrapper, *e
 	}
 
 	mks := &mocksWrapper{
-		Pool:     pool,
-		State:    st,
-		Etherman: etherman,
-		Storage:  storage,
-		DbTx:     dbTx,
+		Pool:         pool,
+		State:        st,
+		Etherman:     etherman,
+		Storage:      storage,
+		DbTx:         dbTx,
+		EthTxManager: ethTxManager,
 	}
 
 	return msv, mks, ethClient
@@ -159,6 +189,7 @@ func getSequencerDefaultConfig() Config {
 		MaxLogsCount:                 10000,
 		MaxLogsBlockRange:            10000,
 		MaxNativeBlockHashBlockRange: 60000,
+		MaxTxPoolTxs:                 5000,
 		WebSockets: WebSocketsConfig{
 			Enabled:   true,
 			Host:      "0.0.0.0",
@@ -352,7 +383,7 @@ func TestRequestValidation(t *testing.T) {
 				"Content-Type":                 {"application/json"},
 				"Access-Control-Allow-Origin":  {"*"},
 				"Access-Control-Allow-Methods": {"POST, OPTIONS"},
-				"Access-Control-Allow-Headers": {"Accept, Content-Type, Content-Length, Accept-Encoding, X-CSRF-Token, Authorization"},
+				"Access-Control-Allow-Headers": {"Accept, Content-Type, Content-Length, Accept-Encoding, X-CSRF-Token, Authorization, X-API-Key"},
 			},
 			ExpectedMessage: "",
 		},
@@ -364,7 +395,7 @@ func TestRequestValidation(t *testing.T) {
 				"Content-Type":                 {"application/json"},
 				"Access-Control-Allow-Origin":  {"*"},
 				"Access-Control-Allow-Methods": {"POST, OPTIONS"},
-				"Access-Control-Allow-Headers": {"Accept, Content-Type, Content-Length, Accept-Encoding, X-CSRF-Token, Authorization"},
+				"Access-Control-Allow-Headers": {"Accept, Content-Type, Content-Length, Accept-Encoding, X-CSRF-Token, Authorization, X-API-Key"},
 			},
 			ExpectedMessage: "zkEVM JSON RPC Server",
 		},
@@ -376,7 +407,7 @@ func TestRequestValidation(t *testing.T) {
 				"Content-Type":                 {"text/plain; charset=utf-8"},
 				"Access-Control-Allow-Origin":  {"*"},
 				"Access-Control-Allow-Methods": {"POST, OPTIONS"},
-				"Access-Control-Allow-Headers": {"Accept, Content-Type, Content-Length, Accept-Encoding, X-CSRF-Token, Authorization"},
+				"Access-Control-Allow-Headers": {"Accept, Content-Type, Content-Length, Accept-Encoding, X-CSRF-Token, Authorization, X-API-Key"},
 			},
 			ExpectedMessage: "",
 		},
@@ -388,7 +419,7 @@ func TestRequestValidation(t *testing.T) {
 				"Content-Type":                 {"text/plain; charset=utf-8"},
 				"Access-Control-Allow-Origin":  {"*"},
 				"Access-Control-Allow-Methods": {"POST, OPTIONS"},
-				"Access-Control-Allow-Headers": {"Accept, Content-Type, Content-Length, Accept-Encoding, X-CSRF-Token, Authorization"},
+				"Access-Control-Allow-Headers": {"Accept, Content-Type, Content-Length, Accept-Encoding, X-CSRF-Token, Authorization, X-API-Key"},
 			},
 			ExpectedMessage: "method PUT not allowed\n",
 		},
@@ -400,7 +431,7 @@ func TestRequestValidation(t *testing.T) {
 				"Content-Type":                 {"text/plain; charset=utf-8"},
 				"Access-Control-Allow-Origin":  {"*"},
 				"Access-Control-Allow-Methods": {"POST, OPTIONS"},
-				"Access-Control-Allow-Headers": {"Accept, Content-Type, Content-Length, Accept-Encoding, X-CSRF-Token, Authorization"},
+				"Access-Control-Allow-Headers": {"Accept, Content-Type, Content-Length, Accept-Encoding, X-CSRF-Token, Authorization, X-API-Key"},
 			},
 			ExpectedMessage: "method PATCH not allowed\n",
 		},
@@ -412,7 +443,7 @@ func TestRequestValidation(t *testing.T) {
 				"Content-Type":                 {"text/plain; charset=utf-8"},
 				"Access-Control-Allow-Origin":  {"*"},
 				"Access-Control-Allow-Methods": {"POST, OPTIONS"},
-				"Access-Control-Allow-Headers": {"Accept, Content-Type, Content-Length, Accept-Encoding, X-CSRF-Token, Authorization"},
+				"Access-Control-Allow-Headers": {"Accept, Content-Type, Content-Length, Accept-Encoding, X-CSRF-Token, Authorization, X-API-Key"},
 			},
 			ExpectedMessage: "method DELETE not allowed\n",
 		},
@@ -433,7 +464,7 @@ func TestRequestValidation(t *testing.T) {
 				"Content-Type":                 {"text/plain; charset=utf-8"},
 				"Access-Control-Allow-Origin":  {"*"},
 				"Access-Control-Allow-Methods": {"POST, OPTIONS"},
-				"Access-Control-Allow-Headers": {"Accept, Content-Type, Content-Length, Accept-Encoding, X-CSRF-Token, Authorization"},
+				"Access-Control-Allow-Headers": {"Accept, Content-Type, Content-Length, Accept-Encoding, X-CSRF-Token, Authorization, X-API-Key"},
 			},
 			ExpectedMessage: "method TRACE not allowed\n",
 		},
@@ -446,7 +477,7 @@ func TestRequestValidation(t *testing.T) {
 				"Content-Type":                 {"text/plain; charset=utf-8"},
 				"Access-Control-Allow-Origin":  {"*"},
 				"Access-Control-Allow-Methods": {"POST, OPTIONS"},
-				"Access-Control-Allow-Headers": {"Accept, Content-Type, Content-Length, Accept-Encoding, X-CSRF-Token, Authorization"},
+				"Access-Control-Allow-Headers": {"Accept, Content-Type, Content-Length, Accept-Encoding, X-CSRF-Token, Authorization, X-API-Key"},
 			},
 			ExpectedMessage: "content length too large (5242881>5242880)\n",
 		},
@@ -459,7 +490,7 @@ func TestRequestValidation(t *testing.T) {
 				"Content-Type":                 {"text/plain; charset=utf-8"},
 				"Access-Control-Allow-Origin":  {"*"},
 				"Access-Control-Allow-Methods": {"POST, OPTIONS"},
-				"Access-Control-Allow-Headers": {"Accept, Content-Type, Content-Length, Accept-Encoding, X-CSRF-Token, Authorization"},
+				"Access-Control-Allow-Headers": {"Accept, Content-Type, Content-Length, Accept-Encoding, X-CSRF-Token, Authorization, X-API-Key"},
 			},
 			ExpectedMessage: "invalid content type, only application/json is supported\n",
 		},
@@ -473,7 +504,7 @@ func TestRequestValidation(t *testing.T) {
 				"Content-Type":                 {"text/plain; charset=utf-8"},
 				"Access-Control-Allow-Origin":  {"*"},
 				"Access-Control-Allow-Methods": {"POST, OPTIONS"},
-				"Access-Control-Allow-Headers": {"Accept, Content-Type, Content-Length, Accept-Encoding, X-CSRF-Token, Authorization"},
+				"Access-Control-Allow-Headers": {"Accept, Content-Type, Content-Length, Accept-Encoding, X-CSRF-Token, Authorization, X-API-Key"},
 			},
 			ExpectedMessage: "empty request body\n",
 		},
@@ -487,7 +518,7 @@ func TestRequestValidation(t *testing.T) {
 				"Content-Type":                 {"text/plain; charset=utf-8"},
 				"Access-Control-Allow-Origin":  {"*"},
 				"Access-Control-Allow-Methods": {"POST, OPTIONS"},
-				"Access-Control-Allow-Headers": {"Accept, Content-Type, Content-Length, Accept-Encoding, X-CSRF-Token, Authorization"},
+				"Access-Control-Allow-Headers": {"Accept, Content-Type, Content-Length, Accept-Encoding, X-CSRF-Token, Authorization, X-API-Key"},
 			},
 			ExpectedMessage: "invalid json object request body\n",
 		},
@@ -501,7 +532,7 @@ func TestRequestValidation(t *testing.T) {
 				"Content-Type":                 {"text/plain; charset=utf-8"},
 				"Access-Control-Allow-Origin":  {"*"},
 				"Access-Control-Allow-Methods": {"POST, OPTIONS"},
-				"Access-Control-Allow-Headers": {"Accept, Content-Type, Content-Length, Accept-Encoding, X-CSRF-Token, Authorization"},
+				"Access-Control-Allow-Headers": {"Accept, Content-Type, Content-Length, Accept-Encoding, X-CSRF-Token, Authorization, X-API-Key"},
 			},
 			ExpectedMessage: "invalid json object request body\n",
 		},
@@ -515,7 +546,7 @@ func TestRequestValidation(t *testing.T) {
 				"Content-Type":                 {"text/plain; charset=utf-8"},
 				"Access-Control-Allow-Origin":  {"*"},
 				"Access-Control-Allow-Methods": {"POST, OPTIONS"},
-				"Access-Control-Allow-Headers": {"Accept, Content-Type, Content-Length, Accept-Encoding, X-CSRF-Token, Authorization"},
+				"Access-Control-Allow-Headers": {"Accept, Content-Type, Content-Length, Accept-Encoding, X-CSRF-Token, Authorization, X-API-Key"},
 			},
 			ExpectedMessage: "invalid json array request body\n",
 		},
@@ -551,6 +582,57 @@ func TestRequestValidation(t *testing.T) {
 	}
 }
 
+func TestCORSAndHttpCompression(t *testing.T) {
+	const allowedOrigin = "https://allowed.origin"
+	cfg := getSequencerDefaultConfig()
+	cfg.CORSAllowedOrigins = []string{allowedOrigin}
+	cfg.EnableHttpCompression = true
+	s, _, _ := newMockedServerWithCustomConfig(t, cfg)
+	defer s.Stop()
+
+	testCases := []struct {
+		name                  string
+		origin                string
+		acceptEncoding        string
+		expectedAllowedOrigin []string
+		expectedCompression   bool
+	}{
+		{"allowed origin with compression", allowedOrigin, "gzip, deflate", []string{allowedOrigin}, true},
+		{"not allowed origin without compression", "https://another.origin", "", nil, false},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			httpReq, err := http.NewRequest(http.MethodPost, s.ServerURL, bytes.NewReader([]byte(`{"jsonrpc":"2.0","id":1,"method":"eth_chainId","params":[]}`)))
+			require.NoError(t, err)
+			httpReq.Header.Set("Content-Type", "application/json")
+			httpReq.Header.Set("Origin", testCase.origin)
+			if testCase.acceptEncoding != "" {
+				httpReq.Header.Set("Accept-Encoding", testCase.acceptEncoding)
+			}
+
+			httpRes, err := http.DefaultClient.Do(httpReq)
+			require.NoError(t, err)
+			defer httpRes.Body.Close()
+			assert.Equal(t, testCase.expectedAllowedOrigin, httpRes.Header["Access-Control-Allow-Origin"])
+
+			var body io.Reader = httpRes.Body
+			if testCase.expectedCompression {
+				assert.Equal(t, "gzip", httpRes.Header.Get("Content-Encoding"))
+				body, err = gzip.NewReader(httpRes.Body)
+				require.NoError(t, err)
+			} else {
+				assert.Empty(t, httpRes.Header.Get("Content-Encoding"))
+			}
+
+			var res types.Response
+			require.NoError(t, json.NewDecoder(body).Decode(&res))
+			require.Nil(t, res.Error)
+			assert.Equal(t, fmt.Sprintf("%q", hex.EncodeUint64(s.ChainID())), string(res.Result))
+		})
+	}
+}
+
 func TestMaxRequestPerIPPerSec(t *testing.T) {
 	// this is the number of requests the test will execute
 	// it's important to keep this number with an amount of
@@ -639,3 +721,27 @@ func TestMaxRequestPerIPPerSec(t *testing.T) {
 	// connection abruptly
 	time.Sleep(time.Second)
 }
+
+func TestHealthz(t *testing.T) {
+	s, m, _ := newSequencerMockedServer(t)
+	defer s.Stop()
+
+	get := func() (int, string) {
+		res, err := http.Get(s.ServerURL + HealthzEndpoint)
+		require.NoError(t, err)
+		defer res.Body.Close()
+		body, err := io.ReadAll(res.Body)
+		require.NoError(t, err)
+		return res.StatusCode, string(body)
+	}
+
+	m.Pool.On("CheckHealth", mock.Anything).Return(nil).Once()
+	status, body := get()
+	assert.Equal(t, http.StatusOK, status)
+	assert.Equal(t, "OK", body)
+
+	m.Pool.On("CheckHealth", mock.Anything).Return(fmt.Errorf("connection refused")).Once()
+	status, body = get()
+	assert.Equal(t, http.StatusServiceUnavailable, status)
+	assert.Equal(t, "pool storage is unreachable\n", body)
+}
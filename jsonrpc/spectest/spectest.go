@@ -0,0 +1,240 @@
+// Package spectest runs the conformance tests of the Ethereum execution-apis
+// (https://github.com/ethereum/execution-apis/tree/main/tests) against a JSON RPC
+// server and reports the responses that diverge from the spec.
+package spectest
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/0xPolygonHermez/zkevm-node/jsonrpc/client"
+	"github.com/0xPolygonHermez/zkevm-node/jsonrpc/types"
+)
+
+const (
+	testFileExtension = ".io"
+	requestPrefix     = ">>"
+	responsePrefix    = "<<"
+	commentPrefix     = "//"
+	// specOnlyComment flags the tests whose result depends on the client, for
+	// them only the presence of a result or an error is checked
+	specOnlyComment = "speconly"
+	// maxLineSize is the size of the longest line of a test file, the responses
+	// with full blocks or receipts are longer than the default of the scanner
+	maxLineSize = 16 * 1024 * 1024
+)
+
+// Exchange is a request sent to the server and the response expected by the spec
+type Exchange struct {
+	Request  types.Request
+	Response types.Response
+}
+
+// Test is a conformance test of a method, with the exchanges of the test file
+type Test struct {
+	Method    string
+	Name      string
+	SpecOnly  bool
+	Exchanges []Exchange
+}
+
+// Divergence is a response of the server that doesn't match the one expected by the spec
+type Divergence struct {
+	Method   string
+	Test     string
+	Request  types.Request
+	Expected types.Response
+	Actual   types.Response
+	Reason   string
+}
+
+// String returns a readable description of the divergence
+func (d Divergence) String() string {
+	return fmt.Sprintf("%s/%s: %s, expected %s, got %s", d.Method, d.Test, d.Reason, responseString(d.Expected), responseString(d.Actual))
+}
+
+// LoadTests loads the tests of the directory, which has a subdirectory per method
+// with a test file per test case, as the tests directory of the execution-apis
+func LoadTests(dir string) ([]Test, error) {
+	methodDirs, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	tests := []Test{}
+	for _, methodDir := range methodDirs {
+		if !methodDir.IsDir() {
+			continue
+		}
+		files, err := os.ReadDir(filepath.Join(dir, methodDir.Name()))
+		if err != nil {
+			return nil, err
+		}
+		for _, file := range files {
+			if file.IsDir() || filepath.Ext(file.Name()) != testFileExtension {
+				continue
+			}
+			test, err := LoadTest(filepath.Join(dir, methodDir.Name(), file.Name()))
+			if err != nil {
+				return nil, fmt.Errorf("failed to load test %s/%s: %w", methodDir.Name(), file.Name(), err)
+			}
+			test.Method = methodDir.Name()
+			tests = append(tests, test)
+		}
+	}
+
+	sort.Slice(tests, func(i, j int) bool {
+		if tests[i].Method != tests[j].Method {
+			return tests[i].Method < tests[j].Method
+		}
+		return tests[i].Name < tests[j].Name
+	})
+	return tests, nil
+}
+
+// LoadTest loads a test file, where the lines starting with >> are requests, each
+// followed by a line starting with << with the response expected by the spec
+func LoadTest(path string) (Test, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return Test{}, err
+	}
+	defer f.Close()
+
+	test := Test{Name: strings.TrimSuffix(filepath.Base(path), testFileExtension)}
+	var request *types.Request
+
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 0, bufio.MaxScanTokenSize), maxLineSize)
+	for lineNumber := 1; scanner.Scan(); lineNumber++ {
+		line := strings.TrimSpace(scanner.Text())
+		switch {
+		case line == "":
+			continue
+		case strings.HasPrefix(line, commentPrefix):
+			if strings.Contains(line, specOnlyComment) {
+				test.SpecOnly = true
+			}
+		case strings.HasPrefix(line, requestPrefix):
+			if request != nil {
+				return Test{}, fmt.Errorf("line %d: request without response", lineNumber-1)
+			}
+			request = &types.Request{}
+			if err := json.Unmarshal([]byte(strings.TrimPrefix(line, requestPrefix)), request); err != nil {
+				return Test{}, fmt.Errorf("line %d: invalid request: %w", lineNumber, err)
+			}
+		case strings.HasPrefix(line, responsePrefix):
+			if request == nil {
+				return Test{}, fmt.Errorf("line %d: response without request", lineNumber)
+			}
+			var response types.Response
+			if err := json.Unmarshal([]byte(strings.TrimPrefix(line, responsePrefix)), &response); err != nil {
+				return Test{}, fmt.Errorf("line %d: invalid response: %w", lineNumber, err)
+			}
+			test.Exchanges = append(test.Exchanges, Exchange{Request: *request, Response: response})
+			request = nil
+		default:
+			return Test{}, fmt.Errorf("line %d: unexpected content", lineNumber)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return Test{}, err
+	}
+	if request != nil {
+		return Test{}, fmt.Errorf("request without response at the end of the file")
+	}
+
+	return test, nil
+}
+
+// Run sends the requests of the test to the server and returns the responses that
+// diverge from the spec. The error results are compared by presence, the error
+// messages and codes given by the spec are not normative
+func Run(url string, test Test) ([]Divergence, error) {
+	divergences := []Divergence{}
+	for _, exchange := range test.Exchanges {
+		params := []interface{}{}
+		if len(exchange.Request.Params) > 0 {
+			var rawParams []json.RawMessage
+			if err := json.Unmarshal(exchange.Request.Params, &rawParams); err != nil {
+				return nil, fmt.Errorf("invalid params of %s/%s: %w", test.Method, test.Name, err)
+			}
+			for _, rawParam := range rawParams {
+				params = append(params, rawParam)
+			}
+		}
+
+		response, err := client.JSONRPCCall(url, exchange.Request.Method, params...)
+		if err != nil {
+			return nil, fmt.Errorf("failed to send the request of %s/%s: %w", test.Method, test.Name, err)
+		}
+
+		if reason := compareResponses(exchange.Response, response, test.SpecOnly); reason != "" {
+			divergences = append(divergences, Divergence{
+				Method:   test.Method,
+				Test:     test.Name,
+				Request:  exchange.Request,
+				Expected: exchange.Response,
+				Actual:   response,
+				Reason:   reason,
+			})
+		}
+	}
+	return divergences, nil
+}
+
+// DivergencesByMethod groups the divergences by the method they were found in
+func DivergencesByMethod(divergences []Divergence) map[string][]Divergence {
+	byMethod := map[string][]Divergence{}
+	for _, divergence := range divergences {
+		byMethod[divergence.Method] = append(byMethod[divergence.Method], divergence)
+	}
+	return byMethod
+}
+
+// compareResponses returns the reason why the actual response doesn't
+// match the expected one, or an empty string if they match
+func compareResponses(expected, actual types.Response, specOnly bool) string {
+	if expected.Error != nil && actual.Error == nil {
+		return "error expected"
+	}
+	if expected.Error == nil && actual.Error != nil {
+		return "unexpected error"
+	}
+	if expected.Error != nil || specOnly {
+		return ""
+	}
+
+	var expectedResult, actualResult interface{}
+	if err := json.Unmarshal(normalizeResult(expected.Result), &expectedResult); err != nil {
+		return fmt.Sprintf("invalid expected result: %v", err)
+	}
+	if err := json.Unmarshal(normalizeResult(actual.Result), &actualResult); err != nil {
+		return fmt.Sprintf("invalid result: %v", err)
+	}
+	if !reflect.DeepEqual(expectedResult, actualResult) {
+		return "result mismatch"
+	}
+	return ""
+}
+
+// normalizeResult returns a null result for the responses without result
+func normalizeResult(result json.RawMessage) json.RawMessage {
+	if len(result) == 0 {
+		return json.RawMessage("null")
+	}
+	return result
+}
+
+func responseString(response types.Response) string {
+	if response.Error != nil {
+		return fmt.Sprintf("error %d %q", response.Error.Code, response.Error.Message)
+	}
+	return string(normalizeResult(response.Result))
+}
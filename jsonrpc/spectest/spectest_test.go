@@ -0,0 +1,130 @@
+package spectest
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"github.com/0xPolygonHermez/zkevm-node/jsonrpc/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	// testsDirEnv is the path to the tests directory of the execution-apis repo
+	testsDirEnv = "ZKEVM_NODE_SPEC_TESTS_DIR"
+	// rpcURLEnv is the URL of the RPC server the spec tests are run against
+	rpcURLEnv = "ZKEVM_NODE_SPEC_RPC_URL"
+)
+
+// TestSpec runs the execution-apis tests against a running RPC server, it's skipped
+// unless both the tests directory and the URL of the server are provided, e.g.:
+//
+//	ZKEVM_NODE_SPEC_TESTS_DIR=../execution-apis/tests ZKEVM_NODE_SPEC_RPC_URL=http://localhost:8123 go test -v -run TestSpec ./jsonrpc/spectest/
+func TestSpec(t *testing.T) {
+	testsDir, url := os.Getenv(testsDirEnv), os.Getenv(rpcURLEnv)
+	if testsDir == "" || url == "" {
+		t.Skipf("%s and %s are required to run the spec tests", testsDirEnv, rpcURLEnv)
+	}
+
+	tests, err := LoadTests(testsDir)
+	require.NoError(t, err)
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.Method+"/"+test.Name, func(t *testing.T) {
+			divergences, err := Run(url, test)
+			require.NoError(t, err)
+			for _, divergence := range divergences {
+				t.Error(divergence.String())
+			}
+		})
+	}
+}
+
+func TestLoadTests(t *testing.T) {
+	tests, err := LoadTests("testdata")
+	require.NoError(t, err)
+	require.Len(t, tests, 4)
+
+	assert.Equal(t, "eth_chainId", tests[0].Method)
+	assert.Equal(t, "get-chain-id", tests[0].Name)
+	require.Len(t, tests[0].Exchanges, 1)
+	assert.Equal(t, "eth_chainId", tests[0].Exchanges[0].Request.Method)
+	assert.JSONEq(t, `"0x3e9"`, string(tests[0].Exchanges[0].Response.Result))
+
+	assert.Equal(t, "eth_getBalance", tests[1].Method)
+	assert.Equal(t, "get-balance", tests[1].Name)
+	assert.Equal(t, "get-balance-unknown-block", tests[2].Name)
+	require.NotNil(t, tests[2].Exchanges[0].Response.Error)
+	assert.Equal(t, types.DefaultErrorCode, tests[2].Exchanges[0].Response.Error.Code)
+
+	assert.Equal(t, "web3_clientVersion", tests[3].Method)
+	assert.True(t, tests[3].SpecOnly)
+	assert.False(t, tests[0].SpecOnly)
+}
+
+func TestRun(t *testing.T) {
+	results := map[string]string{
+		"eth_chainId":        `"0x3e9"`,
+		"eth_getBalance":     `"0x2"`,
+		"web3_clientVersion": `"zkEVM/v0.1.0"`,
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req types.Request
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		result, found := results[req.Method]
+		if !found {
+			result = "null"
+		}
+		_, err := w.Write([]byte(`{"jsonrpc":"2.0","id":1,"result":` + result + `}`))
+		require.NoError(t, err)
+	}))
+	defer server.Close()
+
+	tests, err := LoadTests("testdata")
+	require.NoError(t, err)
+
+	var divergences []Divergence
+	for _, test := range tests {
+		testDivergences, err := Run(server.URL, test)
+		require.NoError(t, err)
+		divergences = append(divergences, testDivergences...)
+	}
+
+	byMethod := DivergencesByMethod(divergences)
+	require.Len(t, byMethod, 1)
+	require.Len(t, byMethod["eth_getBalance"], 2)
+	assert.Equal(t, "get-balance", byMethod["eth_getBalance"][0].Test)
+	assert.Equal(t, "result mismatch", byMethod["eth_getBalance"][0].Reason)
+	assert.Equal(t, "get-balance-unknown-block", byMethod["eth_getBalance"][1].Test)
+	assert.Equal(t, "error expected", byMethod["eth_getBalance"][1].Reason)
+}
+
+func TestCompareResponses(t *testing.T) {
+	errorResponse := types.Response{Error: &types.ErrorObject{Code: types.DefaultErrorCode, Message: "not found"}}
+
+	testCases := []struct {
+		name           string
+		expected       types.Response
+		actual         types.Response
+		specOnly       bool
+		expectedReason string
+	}{
+		{"same result with a different formatting", types.Response{Result: json.RawMessage(`{"a":"0x1","b":["0x2"]}`)}, types.Response{Result: json.RawMessage(`{ "b": ["0x2"], "a": "0x1" }`)}, false, ""},
+		{"different result", types.Response{Result: json.RawMessage(`"0x1"`)}, types.Response{Result: json.RawMessage(`"0x2"`)}, false, "result mismatch"},
+		{"null result and no result", types.Response{Result: json.RawMessage(`null`)}, types.Response{}, false, ""},
+		{"errors with different messages", errorResponse, types.Response{Error: &types.ErrorObject{Code: types.InvalidParamsErrorCode, Message: "invalid"}}, false, ""},
+		{"error expected", errorResponse, types.Response{Result: json.RawMessage(`null`)}, false, "error expected"},
+		{"unexpected error", types.Response{Result: json.RawMessage(`"0x1"`)}, errorResponse, false, "unexpected error"},
+		{"spec only result", types.Response{Result: json.RawMessage(`"Geth"`)}, types.Response{Result: json.RawMessage(`"zkEVM"`)}, true, ""},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			assert.Equal(t, testCase.expectedReason, compareResponses(testCase.expected, testCase.actual, testCase.specOnly))
+		})
+	}
+}
@@ -0,0 +1,3 @@
+// retrieves the client's current chain id
+>> {"jsonrpc":"2.0","id":1,"method":"eth_chainId"}
+<< {"jsonrpc":"2.0","id":1,"result":"0x3e9"}
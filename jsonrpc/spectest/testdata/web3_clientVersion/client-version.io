@@ -0,0 +1,3 @@
+// speconly: client response is only checked for schema validity.
+>> {"jsonrpc":"2.0","id":1,"method":"web3_clientVersion"}
+<< {"jsonrpc":"2.0","id":1,"result":"Geth/v1.13.5"}
@@ -1,12 +1,14 @@
 package jsonrpc
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"sync"
 	"time"
 
 	"github.com/0xPolygonHermez/zkevm-node/hex"
+	"github.com/0xPolygonHermez/zkevm-node/log"
 	"github.com/0xPolygonHermez/zkevm-node/state"
 	"github.com/google/uuid"
 )
@@ -17,6 +19,12 @@ var ErrNotFound = errors.New("object not found")
 // ErrFilterInvalidPayload indicates there is an invalid payload when creating a filter
 var ErrFilterInvalidPayload = errors.New("invalid argument 0: cannot specify both BlockHash and FromBlock/ToBlock, choose one or the other")
 
+// ErrMaxFiltersReached indicates the max number of filters installed at the same time was reached
+var ErrMaxFiltersReached = errors.New("max number of filters reached, uninstall unused filters")
+
+// ErrMaxFiltersPerClientReached indicates the max number of filters installed at the same time by a client was reached
+var ErrMaxFiltersPerClientReached = errors.New("max number of filters per client reached, uninstall unused filters")
+
 // Storage uses memory to store the data
 // related to the json rpc server
 type Storage struct {
@@ -29,11 +37,24 @@ type Storage struct {
 	blockMutex     *sync.Mutex
 	logMutex       *sync.Mutex
 	pendingTxMutex *sync.Mutex
+
+	maxFilters          uint64
+	maxFiltersPerClient uint64
+	filtersPerClient    map[string]uint64
+	filterTimeout       time.Duration
 }
 
-// NewStorage creates and initializes an instance of Storage
-func NewStorage() *Storage {
+// NewStorage creates and initializes an instance of Storage, limited to
+// maxFilters filters installed at the same time and to maxFiltersPerClient
+// filters installed at the same time by the same client, zero means no limit.
+// The filters without web socket connection that are not polled for the
+// filterTimeout are uninstalled, zero means they are never uninstalled
+func NewStorage(maxFilters, maxFiltersPerClient uint64, filterTimeout time.Duration) *Storage {
 	return &Storage{
+		maxFilters:                 maxFilters,
+		maxFiltersPerClient:        maxFiltersPerClient,
+		filtersPerClient:           make(map[string]uint64),
+		filterTimeout:              filterTimeout,
 		allFilters:                 make(map[string]*Filter),
 		allFiltersWithWSConn:       make(map[*concurrentWsConn]map[string]*Filter),
 		blockFiltersWithWSConn:     make(map[string]*Filter),
@@ -45,27 +66,27 @@ func NewStorage() *Storage {
 	}
 }
 
-// NewLogFilter persists a new log filter
-func (s *Storage) NewLogFilter(wsConn *concurrentWsConn, filter LogFilter) (string, error) {
+// NewLogFilter persists a new log filter installed by the client
+func (s *Storage) NewLogFilter(wsConn *concurrentWsConn, client string, filter LogFilter) (string, error) {
 	if err := filter.Validate(); err != nil {
 		return "", err
 	}
 
-	return s.createFilter(FilterTypeLog, filter, wsConn)
+	return s.createFilter(FilterTypeLog, filter, wsConn, client)
 }
 
-// NewBlockFilter persists a new block log filter
-func (s *Storage) NewBlockFilter(wsConn *concurrentWsConn) (string, error) {
-	return s.createFilter(FilterTypeBlock, nil, wsConn)
+// NewBlockFilter persists a new block log filter installed by the client
+func (s *Storage) NewBlockFilter(wsConn *concurrentWsConn, client string) (string, error) {
+	return s.createFilter(FilterTypeBlock, nil, wsConn, client)
 }
 
-// NewPendingTransactionFilter persists a new pending transaction filter
-func (s *Storage) NewPendingTransactionFilter(wsConn *concurrentWsConn) (string, error) {
-	return s.createFilter(FilterTypePendingTx, nil, wsConn)
+// NewPendingTransactionFilter persists a new pending transaction filter installed by the client
+func (s *Storage) NewPendingTransactionFilter(wsConn *concurrentWsConn, client string) (string, error) {
+	return s.createFilter(FilterTypePendingTx, nil, wsConn, client)
 }
 
 // create persists the filter to the memory and provides the filter id
-func (s *Storage) createFilter(t FilterType, parameters interface{}, wsConn *concurrentWsConn) (string, error) {
+func (s *Storage) createFilter(t FilterType, parameters interface{}, wsConn *concurrentWsConn, client string) (string, error) {
 	lastPoll := time.Now().UTC()
 	id, err := s.generateFilterID()
 	if err != nil {
@@ -79,12 +100,20 @@ func (s *Storage) createFilter(t FilterType, parameters interface{}, wsConn *con
 	defer s.logMutex.Unlock()
 	defer s.pendingTxMutex.Unlock()
 
+	if s.maxFilters > 0 && uint64(len(s.allFilters)) >= s.maxFilters {
+		return "", ErrMaxFiltersReached
+	}
+	if s.maxFiltersPerClient > 0 && s.filtersPerClient[client] >= s.maxFiltersPerClient {
+		return "", ErrMaxFiltersPerClientReached
+	}
+
 	f := &Filter{
 		ID:            id,
 		Type:          t,
 		Parameters:    parameters,
 		LastPoll:      lastPoll,
 		WsConn:        wsConn,
+		client:        client,
 		wsQueue:       state.NewQueue[[]byte](),
 		wsQueueSignal: sync.NewCond(&sync.Mutex{}),
 	}
@@ -92,6 +121,7 @@ func (s *Storage) createFilter(t FilterType, parameters interface{}, wsConn *con
 	go state.InfiniteSafeRun(f.SendEnqueuedSubscriptionData, fmt.Sprintf("failed to send enqueued subscription data to filter %v", id), time.Second)
 
 	s.allFilters[id] = f
+	s.filtersPerClient[client]++
 	if f.WsConn != nil {
 		if _, found := s.allFiltersWithWSConn[f.WsConn]; !found {
 			s.allFiltersWithWSConn[f.WsConn] = make(map[string]*Filter)
@@ -110,6 +140,12 @@ func (s *Storage) createFilter(t FilterType, parameters interface{}, wsConn *con
 }
 
 func (s *Storage) generateFilterID() (string, error) {
+	return generateSubscriptionID()
+}
+
+// generateSubscriptionID generates a random id for the filters and the
+// notifications sent via web sockets
+func generateSubscriptionID() (string, error) {
 	r, err := uuid.NewRandom()
 	if err != nil {
 		return "", err
@@ -152,6 +188,20 @@ func (s *Storage) GetAllLogFiltersWithWSConn() []*Filter {
 	return filters
 }
 
+// GetAllPendingTxFiltersWithWSConn returns an array with all filter that have
+// a web socket connection and are filtering by new pending txs
+func (s *Storage) GetAllPendingTxFiltersWithWSConn() []*Filter {
+	s.pendingTxMutex.Lock()
+	defer s.pendingTxMutex.Unlock()
+
+	filters := []*Filter{}
+	for _, filter := range s.pendingTxFiltersWithWSConn {
+		f := filter
+		filters = append(filters, f)
+	}
+	return filters
+}
+
 // GetFilter gets a filter by its id
 func (s *Storage) GetFilter(filterID string) (*Filter, error) {
 	s.blockMutex.Lock()
@@ -226,6 +276,54 @@ func (s *Storage) UninstallFilterByWSConn(wsConn *concurrentWsConn) error {
 	return nil
 }
 
+// StartUninstallingExpiredFiltersPeriodically uninstalls periodically the filters
+// without web socket connection that were not polled within the filter timeout,
+// until the context is done
+func (s *Storage) StartUninstallingExpiredFiltersPeriodically(ctx context.Context) {
+	if s.filterTimeout <= 0 {
+		return
+	}
+	go func() {
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(s.filterTimeout):
+				if uninstalled := s.UninstallExpiredFilters(); uninstalled > 0 {
+					log.Infof("uninstalled %d filters not polled for %v", uninstalled, s.filterTimeout)
+				}
+			}
+		}
+	}()
+}
+
+// UninstallExpiredFilters deletes the filters without web socket connection that were
+// not polled within the filter timeout, it returns the number of uninstalled filters
+func (s *Storage) UninstallExpiredFilters() int {
+	s.blockMutex.Lock()
+	s.logMutex.Lock()
+	s.pendingTxMutex.Lock()
+	defer s.blockMutex.Unlock()
+	defer s.logMutex.Unlock()
+	defer s.pendingTxMutex.Unlock()
+
+	if s.filterTimeout <= 0 {
+		return 0
+	}
+
+	expiredFilters := []*Filter{}
+	for _, filter := range s.allFilters {
+		if filter.WsConn == nil && time.Since(filter.LastPoll) > s.filterTimeout {
+			expiredFilters = append(expiredFilters, filter)
+		}
+	}
+
+	for _, filter := range expiredFilters {
+		s.deleteFilter(filter)
+	}
+	return len(expiredFilters)
+}
+
 // deleteFilter deletes a filter from all the maps
 func (s *Storage) deleteFilter(filter *Filter) {
 	if filter.Type == FilterTypeBlock {
@@ -243,5 +341,11 @@ func (s *Storage) deleteFilter(filter *Filter) {
 		}
 	}
 
+	if _, found := s.allFilters[filter.ID]; found {
+		s.filtersPerClient[filter.client]--
+		if s.filtersPerClient[filter.client] == 0 {
+			delete(s.filtersPerClient, filter.client)
+		}
+	}
 	delete(s.allFilters, filter.ID)
 }
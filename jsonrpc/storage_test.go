@@ -0,0 +1,68 @@
+package jsonrpc
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStorageMaxFilters(t *testing.T) {
+	s := NewStorage(2, 0, 0)
+
+	firstID, err := s.NewBlockFilter(nil, "")
+	require.NoError(t, err)
+	_, err = s.NewPendingTransactionFilter(nil, "")
+	require.NoError(t, err)
+
+	_, err = s.NewBlockFilter(nil, "")
+	assert.ErrorIs(t, err, ErrMaxFiltersReached)
+
+	require.NoError(t, s.UninstallFilter(firstID))
+	_, err = s.NewBlockFilter(nil, "")
+	assert.NoError(t, err)
+}
+
+func TestStorageMaxFiltersPerClient(t *testing.T) {
+	const client1, client2 = "10.0.0.1", "10.0.0.2"
+	s := NewStorage(0, 2, 0)
+
+	firstID, err := s.NewBlockFilter(nil, client1)
+	require.NoError(t, err)
+	_, err = s.NewLogFilter(&concurrentWsConn{}, client1, LogFilter{})
+	require.NoError(t, err)
+
+	_, err = s.NewPendingTransactionFilter(nil, client1)
+	assert.ErrorIs(t, err, ErrMaxFiltersPerClientReached)
+	_, err = s.NewBlockFilter(nil, client2)
+	assert.NoError(t, err)
+
+	require.NoError(t, s.UninstallFilter(firstID))
+	_, err = s.NewBlockFilter(nil, client1)
+	assert.NoError(t, err)
+}
+
+func TestStorageUninstallExpiredFilters(t *testing.T) {
+	const filterTimeout = time.Minute
+	s := NewStorage(0, 0, filterTimeout)
+
+	expiredID, err := s.NewBlockFilter(nil, "")
+	require.NoError(t, err)
+	polledID, err := s.NewBlockFilter(nil, "")
+	require.NoError(t, err)
+	wsConnID, err := s.NewBlockFilter(&concurrentWsConn{}, "")
+	require.NoError(t, err)
+
+	s.allFilters[expiredID].LastPoll = time.Now().Add(-2 * filterTimeout)
+	s.allFilters[wsConnID].LastPoll = time.Now().Add(-2 * filterTimeout)
+
+	assert.Equal(t, 1, s.UninstallExpiredFilters())
+
+	_, err = s.GetFilter(expiredID)
+	assert.ErrorIs(t, err, ErrNotFound)
+	_, err = s.GetFilter(polledID)
+	assert.NoError(t, err)
+	_, err = s.GetFilter(wsConnID)
+	assert.NoError(t, err)
+}
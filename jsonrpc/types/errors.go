@@ -15,6 +15,10 @@ const (
 	InvalidParamsErrorCode = -32602
 	// ParserErrorCode error code for parsing errors
 	ParserErrorCode = -32700
+	// ConditionsNotMetErrorCode error code for conditional txs rejected because their conditions are not met
+	ConditionsNotMetErrorCode = -32003
+	// LimitExceededErrorCode error code for requests rejected because a rate limit was exceeded
+	LimitExceededErrorCode = -32005
 )
 
 var (
@@ -2,6 +2,7 @@ package types
 
 import (
 	"context"
+	"encoding/binary"
 	"encoding/json"
 	"fmt"
 	"math/big"
@@ -9,6 +10,8 @@ import (
 	"strings"
 
 	"github.com/0xPolygonHermez/zkevm-node/hex"
+	"github.com/0xPolygonHermez/zkevm-node/merkletree"
+	"github.com/0xPolygonHermez/zkevm-node/pool"
 	"github.com/0xPolygonHermez/zkevm-node/state"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
@@ -237,6 +240,46 @@ func (args *TxArgs) ToTransaction(ctx context.Context, st StateInterface, maxCum
 	return sender, tx, nil
 }
 
+// OverrideAccount indicates the overriding fields of an account in the state override argument
+type OverrideAccount struct {
+	Nonce     *ArgUint64                   `json:"nonce"`
+	Code      *ArgBytes                    `json:"code"`
+	Balance   *ArgBig                      `json:"balance"`
+	State     *map[common.Hash]common.Hash `json:"state"`
+	StateDiff *map[common.Hash]common.Hash `json:"stateDiff"`
+}
+
+// StateOverride is the state override argument for the rpc endpoints, the accounts
+// to override before executing the transaction
+type StateOverride map[common.Address]OverrideAccount
+
+// ToStateOverride transforms the state override argument into the state override of the state
+func (so *StateOverride) ToStateOverride() state.StateOverride {
+	if so == nil {
+		return nil
+	}
+	stateOverride := make(state.StateOverride, len(*so))
+	for address, account := range *so {
+		overrideAccount := state.OverrideAccount{
+			State:     account.State,
+			StateDiff: account.StateDiff,
+		}
+		if account.Nonce != nil {
+			nonce := uint64(*account.Nonce)
+			overrideAccount.Nonce = &nonce
+		}
+		if account.Code != nil {
+			code := []byte(*account.Code)
+			overrideAccount.Code = &code
+		}
+		if account.Balance != nil {
+			overrideAccount.Balance = (*big.Int)(account.Balance)
+		}
+		stateOverride[address] = overrideAccount
+	}
+	return stateOverride
+}
+
 // Block structure
 type Block struct {
 	ParentHash      common.Hash         `json:"parentHash"`
@@ -617,6 +660,7 @@ type Receipt struct {
 	Type              ArgUint64       `json:"type"`
 	EffectiveGasPrice *ArgBig         `json:"effectiveGasPrice,omitempty"`
 	TxL2Hash          *common.Hash    `json:"transactionL2Hash,omitempty"`
+	RevertReason      *ArgBytes       `json:"revertReason,omitempty"`
 }
 
 // NewReceipt creates a new Receipt instance
@@ -773,3 +817,297 @@ func NewZKCountersResponse(zkCounters state.ZKCounters, limits ZKCountersLimits,
 		OOCError:       oocErrMsg,
 	}
 }
+
+// PoolTxStatus contains the status of a tx in the pool
+type PoolTxStatus struct {
+	Hash         common.Hash `json:"hash"`
+	Status       string      `json:"status"`
+	ReceivedAt   ArgUint64   `json:"receivedAt"`
+	FailedReason *string     `json:"failedReason,omitempty"`
+}
+
+// NewPoolTxStatus creates an instance of PoolTxStatus to be returned
+// by the RPC to the caller
+func NewPoolTxStatus(tx pool.Transaction) PoolTxStatus {
+	return PoolTxStatus{
+		Hash:         tx.Hash(),
+		Status:       tx.Status.String(),
+		ReceivedAt:   ArgUint64(tx.ReceivedAt.Unix()),
+		FailedReason: tx.FailedReason,
+	}
+}
+
+// TxStatusMined is the status of a tx that is already included in an L2 block
+const TxStatusMined = "mined"
+
+// TransactionStatus contains the status of a tx and the changes of its status in the pool
+type TransactionStatus struct {
+	Hash         common.Hash               `json:"hash"`
+	Status       string                    `json:"status"`
+	FailedReason *string                   `json:"failedReason,omitempty"`
+	BlockNumber  *ArgUint64                `json:"blockNumber,omitempty"`
+	BatchNumber  *ArgUint64                `json:"batchNumber,omitempty"`
+	History      []TransactionStatusChange `json:"history"`
+}
+
+// TransactionStatusChange contains a change of the status of a tx in the pool
+type TransactionStatusChange struct {
+	Status       string    `json:"status"`
+	FailedReason *string   `json:"failedReason,omitempty"`
+	Timestamp    ArgUint64 `json:"timestamp"`
+}
+
+// NewTransactionStatusHistory creates the list of changes of the status of a tx
+// to be returned by the RPC to the caller
+func NewTransactionStatusHistory(history []pool.TxStatusChange) []TransactionStatusChange {
+	res := make([]TransactionStatusChange, 0, len(history))
+	for _, change := range history {
+		res = append(res, TransactionStatusChange{
+			Status:       change.Status.String(),
+			FailedReason: change.FailedReason,
+			Timestamp:    ArgUint64(change.ChangedAt.Unix()),
+		})
+	}
+	return res
+}
+
+// ScheduledTxArgs contains the conditions of a scheduled tx, the tx is not
+// sequenced before the provided timestamp and L2 block number
+type ScheduledTxArgs struct {
+	Timestamp   *ArgUint64 `json:"timestamp,omitempty"`
+	BlockNumber *ArgUint64 `json:"blockNumber,omitempty"`
+}
+
+// ToTxSchedule transforms the args into the schedule of a pool tx
+func (args ScheduledTxArgs) ToTxSchedule() pool.TxSchedule {
+	var schedule pool.TxSchedule
+	if args.Timestamp != nil {
+		schedule.NotBeforeTimestamp = uint64(*args.Timestamp)
+	}
+	if args.BlockNumber != nil {
+		schedule.NotBeforeBlock = uint64(*args.BlockNumber)
+	}
+	return schedule
+}
+
+// TxConditionsArgs contains the conditions of a conditional tx, the tx is only
+// sequenced if they are met. The known accounts are the expected storage values
+// of the accounts, by storage slot
+type TxConditionsArgs struct {
+	KnownAccounts  map[common.Address]json.RawMessage `json:"knownAccounts,omitempty"`
+	BlockNumberMin *ArgUint64                         `json:"blockNumberMin,omitempty"`
+	BlockNumberMax *ArgUint64                         `json:"blockNumberMax,omitempty"`
+	TimestampMin   *ArgUint64                         `json:"timestampMin,omitempty"`
+	TimestampMax   *ArgUint64                         `json:"timestampMax,omitempty"`
+}
+
+// NewTxConditionsArgs creates the args of the provided conditions of a pool tx,
+// so the tx can be sent to another node with eth_sendRawTransactionConditional
+func NewTxConditionsArgs(conditions pool.TxConditions) (TxConditionsArgs, error) {
+	var args TxConditionsArgs
+	if len(conditions.KnownAccounts) > 0 {
+		args.KnownAccounts = make(map[common.Address]json.RawMessage, len(conditions.KnownAccounts))
+		for address, storage := range conditions.KnownAccounts {
+			known, err := json.Marshal(storage)
+			if err != nil {
+				return TxConditionsArgs{}, err
+			}
+			args.KnownAccounts[address] = known
+		}
+	}
+	if conditions.BlockNumberMin != 0 {
+		args.BlockNumberMin = ArgUint64Ptr(ArgUint64(conditions.BlockNumberMin))
+	}
+	if conditions.BlockNumberMax != 0 {
+		args.BlockNumberMax = ArgUint64Ptr(ArgUint64(conditions.BlockNumberMax))
+	}
+	if conditions.TimestampMin != 0 {
+		args.TimestampMin = ArgUint64Ptr(ArgUint64(conditions.TimestampMin))
+	}
+	if conditions.TimestampMax != 0 {
+		args.TimestampMax = ArgUint64Ptr(ArgUint64(conditions.TimestampMax))
+	}
+	return args, nil
+}
+
+// ToTxConditions transforms the args into the conditions of a pool tx. The
+// storage root of the known accounts is not supported, as the zkEVM state
+// has no storage root per account, the storage slots must be provided instead
+func (args TxConditionsArgs) ToTxConditions() (pool.TxConditions, error) {
+	var conditions pool.TxConditions
+	if len(args.KnownAccounts) > 0 {
+		conditions.KnownAccounts = make(map[common.Address]map[common.Hash]common.Hash, len(args.KnownAccounts))
+		for address, known := range args.KnownAccounts {
+			var storageRoot common.Hash
+			if json.Unmarshal(known, &storageRoot) == nil {
+				return pool.TxConditions{}, fmt.Errorf("storage root of the known account %s is not supported, provide the storage slots instead", address.String())
+			}
+			var storage map[common.Hash]common.Hash
+			if err := json.Unmarshal(known, &storage); err != nil {
+				return pool.TxConditions{}, fmt.Errorf("invalid storage slots of the known account %s: %w", address.String(), err)
+			}
+			conditions.KnownAccounts[address] = storage
+		}
+	}
+	if args.BlockNumberMin != nil {
+		conditions.BlockNumberMin = uint64(*args.BlockNumberMin)
+	}
+	if args.BlockNumberMax != nil {
+		conditions.BlockNumberMax = uint64(*args.BlockNumberMax)
+	}
+	if args.TimestampMin != nil {
+		conditions.TimestampMin = uint64(*args.TimestampMin)
+	}
+	if args.TimestampMax != nil {
+		conditions.TimestampMax = uint64(*args.TimestampMax)
+	}
+	return conditions, nil
+}
+
+// UserOperation is the EIP-4337 user operation argument of the rpc endpoints
+type UserOperation struct {
+	Sender               common.Address `json:"sender"`
+	Nonce                ArgBig         `json:"nonce"`
+	InitCode             ArgBytes       `json:"initCode"`
+	CallData             ArgBytes       `json:"callData"`
+	CallGasLimit         ArgBig         `json:"callGasLimit"`
+	VerificationGasLimit ArgBig         `json:"verificationGasLimit"`
+	PreVerificationGas   ArgBig         `json:"preVerificationGas"`
+	MaxFeePerGas         ArgBig         `json:"maxFeePerGas"`
+	MaxPriorityFeePerGas ArgBig         `json:"maxPriorityFeePerGas"`
+	PaymasterAndData     ArgBytes       `json:"paymasterAndData"`
+	Signature            ArgBytes       `json:"signature"`
+}
+
+// ToUserOp transforms the user operation argument into a pool user operation
+func (u UserOperation) ToUserOp() pool.UserOp {
+	toBig := func(a ArgBig) *big.Int {
+		b := big.Int(a)
+		return &b
+	}
+	return pool.UserOp{
+		Sender:               u.Sender,
+		Nonce:                toBig(u.Nonce),
+		InitCode:             u.InitCode,
+		CallData:             u.CallData,
+		CallGasLimit:         toBig(u.CallGasLimit),
+		VerificationGasLimit: toBig(u.VerificationGasLimit),
+		PreVerificationGas:   toBig(u.PreVerificationGas),
+		MaxFeePerGas:         toBig(u.MaxFeePerGas),
+		MaxPriorityFeePerGas: toBig(u.MaxPriorityFeePerGas),
+		PaymasterAndData:     u.PaymasterAndData,
+		Signature:            u.Signature,
+	}
+}
+
+// AccountProof is the response of eth_getProof. The zkEVM keeps the accounts and their storage in a
+// single sparse merkle tree, so every value has its own proof with the nodes of the path from the
+// state root to the leaf, each node encoded as its 12 field elements in big endian
+type AccountProof struct {
+	Address         common.Address `json:"address"`
+	Balance         ArgBig         `json:"balance"`
+	Nonce           ArgUint64      `json:"nonce"`
+	CodeHash        common.Hash    `json:"codeHash"`
+	CodeLength      ArgUint64      `json:"codeLength"`
+	BalanceProof    []ArgBytes     `json:"balanceProof"`
+	NonceProof      []ArgBytes     `json:"nonceProof"`
+	CodeHashProof   []ArgBytes     `json:"codeHashProof"`
+	CodeLengthProof []ArgBytes     `json:"codeLengthProof"`
+	StorageProof    []StorageProof `json:"storageProof"`
+}
+
+// StorageProof is the proof of a storage position of the eth_getProof response
+type StorageProof struct {
+	Key   common.Hash `json:"key"`
+	Value ArgBig      `json:"value"`
+	Proof []ArgBytes  `json:"proof"`
+}
+
+// NewAccountProof creates the eth_getProof response from the proofs of the state
+func NewAccountProof(p *state.AccountProof) AccountProof {
+	accountProof := AccountProof{
+		Address:         p.Address,
+		Balance:         ArgBig(*p.BalanceProof.Value),
+		Nonce:           ArgUint64(p.NonceProof.Value.Uint64()),
+		CodeHash:        common.BigToHash(p.CodeHashProof.Value),
+		CodeLength:      ArgUint64(p.CodeLengthProof.Value.Uint64()),
+		BalanceProof:    newMerkleProofNodes(p.BalanceProof),
+		NonceProof:      newMerkleProofNodes(p.NonceProof),
+		CodeHashProof:   newMerkleProofNodes(p.CodeHashProof),
+		CodeLengthProof: newMerkleProofNodes(p.CodeLengthProof),
+		StorageProof:    make([]StorageProof, 0, len(p.StorageProofs)),
+	}
+	for i, storageProof := range p.StorageProofs {
+		accountProof.StorageProof = append(accountProof.StorageProof, StorageProof{
+			Key:   common.BigToHash(p.StoragePositions[i]),
+			Value: ArgBig(*storageProof.Value),
+			Proof: newMerkleProofNodes(storageProof),
+		})
+	}
+	return accountProof
+}
+
+func newMerkleProofNodes(proof *merkletree.MerkleProof) []ArgBytes {
+	const fieldElementSize = 8
+	nodes := make([]ArgBytes, 0, len(proof.Nodes))
+	for _, node := range proof.Nodes {
+		encoded := make([]byte, 0, len(node.Value)*fieldElementSize)
+		for _, fe := range node.Value {
+			encoded = binary.BigEndian.AppendUint64(encoded, fe)
+		}
+		nodes = append(nodes, encoded)
+	}
+	return nodes
+}
+
+// AccessListResult is the response of eth_createAccessList
+type AccessListResult struct {
+	AccessList types.AccessList `json:"accessList"`
+	Error      string           `json:"error,omitempty"`
+	GasUsed    ArgUint64        `json:"gasUsed"`
+}
+
+// FeeHistory is the response of eth_feeHistory
+type FeeHistory struct {
+	OldestBlock  ArgUint64  `json:"oldestBlock"`
+	Reward       [][]ArgBig `json:"reward,omitempty"`
+	BaseFee      []ArgBig   `json:"baseFeePerGas"`
+	GasUsedRatio []float64  `json:"gasUsedRatio"`
+}
+
+// Trace is an OpenEthereum style trace of a call, create or self destruct
+// executed by a transaction
+type Trace struct {
+	Action              TraceAction  `json:"action"`
+	BlockHash           *common.Hash `json:"blockHash"`
+	BlockNumber         *ArgUint64   `json:"blockNumber"`
+	Error               string       `json:"error,omitempty"`
+	Result              *TraceResult `json:"result"`
+	Subtraces           int          `json:"subtraces"`
+	TraceAddress        []int        `json:"traceAddress"`
+	TransactionHash     *common.Hash `json:"transactionHash"`
+	TransactionPosition *ArgUint64   `json:"transactionPosition"`
+	Type                string       `json:"type"`
+}
+
+// TraceAction contains the params of the traced call, create or self destruct
+type TraceAction struct {
+	CallType      string          `json:"callType,omitempty"`
+	From          *common.Address `json:"from,omitempty"`
+	To            *common.Address `json:"to,omitempty"`
+	Gas           *ArgUint64      `json:"gas,omitempty"`
+	Input         *ArgBytes       `json:"input,omitempty"`
+	Init          *ArgBytes       `json:"init,omitempty"`
+	Value         *ArgBig         `json:"value,omitempty"`
+	Address       *common.Address `json:"address,omitempty"`
+	RefundAddress *common.Address `json:"refundAddress,omitempty"`
+	Balance       *ArgBig         `json:"balance,omitempty"`
+}
+
+// TraceResult contains the result of the traced call or create
+type TraceResult struct {
+	GasUsed ArgUint64       `json:"gasUsed"`
+	Output  *ArgBytes       `json:"output,omitempty"`
+	Address *common.Address `json:"address,omitempty"`
+	Code    *ArgBytes       `json:"code,omitempty"`
+}
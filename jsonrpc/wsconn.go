@@ -11,13 +11,16 @@ import (
 type concurrentWsConn struct {
 	wsConn *websocket.Conn
 	mutex  *sync.Mutex
+	// client is the client that opened the connection, used to limit the filters it installs
+	client string
 }
 
 // NewConcurrentWsConn creates a new instance of concurrentWsConn
-func newConcurrentWsConn(wsConn *websocket.Conn) *concurrentWsConn {
+func newConcurrentWsConn(wsConn *websocket.Conn, client string) *concurrentWsConn {
 	return &concurrentWsConn{
 		wsConn: wsConn,
 		mutex:  &sync.Mutex{},
+		client: client,
 	}
 }
 
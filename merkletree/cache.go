@@ -0,0 +1,154 @@
+package merkletree
+
+import (
+	"container/list"
+	"sync"
+
+	"github.com/0xPolygonHermez/zkevm-node/merkletree/metrics"
+)
+
+// cacheEntryOverhead is the approximate size in bytes of the key and the bookkeeping of an entry
+// of the cache, it's added to the size of the value to account the memory budget
+const cacheEntryOverhead = 128
+
+// cacheKey identifies a value of the merkletree. The values of the tree are addressed by the
+// root and the key, and the programs by the hash of their bytecode, so an entry never
+// becomes stale and the cache doesn't need to be invalidated when the state changes
+type cacheKey struct {
+	root    [4]uint64
+	key     [4]uint64
+	program bool
+}
+
+type cacheEntry struct {
+	key   cacheKey
+	value []uint64
+	data  []byte
+	size  uint64
+}
+
+// nodeCache is a thread-safe LRU cache of the values read from the merkletree, limited by the
+// size in bytes of its entries
+type nodeCache struct {
+	mutex   sync.Mutex
+	maxSize uint64
+	size    uint64
+	entries map[cacheKey]*list.Element
+	lru     *list.List
+}
+
+func newNodeCache(maxSize uint64) *nodeCache {
+	return &nodeCache{
+		maxSize: maxSize,
+		entries: make(map[cacheKey]*list.Element),
+		lru:     list.New(),
+	}
+}
+
+func newValueCacheKey(root, key []uint64) cacheKey {
+	return cacheKey{
+		root: [4]uint64{root[0], root[1], root[2], root[3]},
+		key:  [4]uint64{key[0], key[1], key[2], key[3]},
+	}
+}
+
+func newProgramCacheKey(key []uint64) cacheKey {
+	return cacheKey{
+		key:     [4]uint64{key[0], key[1], key[2], key[3]},
+		program: true,
+	}
+}
+
+// getValue returns the cached value of the key in the tree with the provided root
+func (c *nodeCache) getValue(root, key []uint64) ([]uint64, bool) {
+	entry, found := c.get(newValueCacheKey(root, key))
+	if !found {
+		return nil, false
+	}
+	return entry.value, true
+}
+
+// addValue caches the value of the key in the tree with the provided root
+func (c *nodeCache) addValue(root, key, value []uint64) {
+	const uint64Size = 8
+	c.add(&cacheEntry{
+		key:   newValueCacheKey(root, key),
+		value: value,
+		size:  cacheEntryOverhead + uint64(len(value))*uint64Size,
+	})
+}
+
+// getProgram returns the cached bytecode of the program with the provided hash
+func (c *nodeCache) getProgram(key []uint64) ([]byte, bool) {
+	entry, found := c.get(newProgramCacheKey(key))
+	if !found {
+		return nil, false
+	}
+	return entry.data, true
+}
+
+// addProgram caches the bytecode of the program with the provided hash
+func (c *nodeCache) addProgram(key []uint64, data []byte) {
+	c.add(&cacheEntry{
+		key:  newProgramCacheKey(key),
+		data: data,
+		size: cacheEntryOverhead + uint64(len(data)),
+	})
+}
+
+func (c *nodeCache) get(key cacheKey) (*cacheEntry, bool) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	element, found := c.entries[key]
+	if !found {
+		metrics.CacheMiss()
+		return nil, false
+	}
+	c.lru.MoveToFront(element)
+	metrics.CacheHit()
+	return element.Value.(*cacheEntry), true
+}
+
+func (c *nodeCache) add(entry *cacheEntry) {
+	// an entry that doesn't fit in the budget would evict the whole cache
+	if entry.size > c.maxSize {
+		return
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if element, found := c.entries[entry.key]; found {
+		c.size -= element.Value.(*cacheEntry).size
+		element.Value = entry
+		c.size += entry.size
+		c.lru.MoveToFront(element)
+	} else {
+		c.entries[entry.key] = c.lru.PushFront(entry)
+		c.size += entry.size
+	}
+
+	for c.size > c.maxSize {
+		oldest := c.lru.Back()
+		c.lru.Remove(oldest)
+		oldestEntry := oldest.Value.(*cacheEntry)
+		delete(c.entries, oldestEntry.key)
+		c.size -= oldestEntry.size
+	}
+	metrics.CacheSize(c.size)
+}
+
+// len returns the number of entries of the cache
+func (c *nodeCache) len() int {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	return c.lru.Len()
+}
+
+// bytesSize returns the size in bytes of the entries of the cache
+func (c *nodeCache) bytesSize() uint64 {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	return c.size
+}
@@ -0,0 +1,101 @@
+package merkletree
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/0xPolygonHermez/zkevm-node/hex"
+	"github.com/0xPolygonHermez/zkevm-node/merkletree/hashdb"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+)
+
+// hashDBClientStub returns the same value for every key and counts the calls to the server
+type hashDBClientStub struct {
+	hashdb.HashDBServiceClient
+	value       *big.Int
+	getCalls    int
+	programData []byte
+	programGets int
+}
+
+func (c *hashDBClientStub) Get(ctx context.Context, in *hashdb.GetRequest, opts ...grpc.CallOption) (*hashdb.GetResponse, error) {
+	c.getCalls++
+	return &hashdb.GetResponse{Value: c.value.Text(hex.Base)}, nil
+}
+
+func (c *hashDBClientStub) GetProgram(ctx context.Context, in *hashdb.GetProgramRequest, opts ...grpc.CallOption) (*hashdb.GetProgramResponse, error) {
+	c.programGets++
+	return &hashdb.GetProgramResponse{Data: c.programData}, nil
+}
+
+func TestNodeCacheEviction(t *testing.T) {
+	const valueSize = cacheEntryOverhead + 8*8
+	cache := newNodeCache(2 * valueSize)
+
+	value := []uint64{1, 2, 3, 4, 5, 6, 7, 8}
+	root := []uint64{1, 1, 1, 1}
+	cache.addValue(root, []uint64{1, 0, 0, 0}, value)
+	cache.addValue(root, []uint64{2, 0, 0, 0}, value)
+	assert.Equal(t, 2, cache.len())
+	assert.Equal(t, uint64(2*valueSize), cache.bytesSize())
+
+	// reading the first key makes the second one the least recently used
+	_, found := cache.getValue(root, []uint64{1, 0, 0, 0})
+	require.True(t, found)
+	cache.addValue(root, []uint64{3, 0, 0, 0}, value)
+	assert.Equal(t, 2, cache.len())
+	_, found = cache.getValue(root, []uint64{2, 0, 0, 0})
+	assert.False(t, found)
+	_, found = cache.getValue(root, []uint64{1, 0, 0, 0})
+	assert.True(t, found)
+
+	// the same key in another tree is a different entry
+	_, found = cache.getValue([]uint64{2, 2, 2, 2}, []uint64{1, 0, 0, 0})
+	assert.False(t, found)
+
+	// a program that doesn't fit in the budget is not cached
+	cache.addProgram([]uint64{1, 0, 0, 0}, make([]byte, 2*valueSize))
+	_, found = cache.getProgram([]uint64{1, 0, 0, 0})
+	assert.False(t, found)
+	assert.Equal(t, 2, cache.len())
+}
+
+func TestStateTreeCache(t *testing.T) {
+	ctx := context.Background()
+	address := common.HexToAddress("0x1")
+	root := common.HexToHash("0x2").Bytes()
+
+	client := &hashDBClientStub{value: big.NewInt(100)}
+	tree := NewStateTreeWithCache(client, CacheCfg{MaxBytesSize: 1024})
+	for i := 0; i < 3; i++ {
+		balance, err := tree.GetBalance(ctx, address, root)
+		require.NoError(t, err)
+		assert.Equal(t, big.NewInt(100), balance)
+	}
+	assert.Equal(t, 1, client.getCalls)
+
+	// the nonce is another key of the tree
+	_, err := tree.GetNonce(ctx, address, root)
+	require.NoError(t, err)
+	assert.Equal(t, 2, client.getCalls)
+
+	client.programData = []byte{0x60, 0x80}
+	for i := 0; i < 2; i++ {
+		code, err := tree.GetCode(ctx, address, root)
+		require.NoError(t, err)
+		assert.Equal(t, client.programData, code)
+	}
+	assert.Equal(t, 1, client.programGets)
+
+	client = &hashDBClientStub{value: big.NewInt(100)}
+	tree = NewStateTreeWithCache(client, CacheCfg{})
+	for i := 0; i < 2; i++ {
+		_, err := tree.GetBalance(ctx, address, root)
+		require.NoError(t, err)
+	}
+	assert.Equal(t, 2, client.getCalls)
+}
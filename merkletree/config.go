@@ -4,4 +4,18 @@ package merkletree
 type Config struct {
 	// URI is the server URI.
 	URI string `mapstructure:"URI"`
+
+	// Cache is the configuration of the in-memory cache of the values read from the merkletree server.
+	Cache CacheCfg `mapstructure:"Cache"`
+}
+
+// CacheCfg represents the configuration of the in-memory cache of the merkletree.
+type CacheCfg struct {
+	// MaxBytesSize is the memory budget in bytes of the cache, the least recently used
+	// entries are evicted when it's exceeded. 0 disables the cache.
+	MaxBytesSize uint64 `mapstructure:"MaxBytesSize"`
+
+	// WarmupBatches is the number of the most recent batches whose accounts are loaded
+	// into the cache on startup. 0 disables the warmup.
+	WarmupBatches uint64 `mapstructure:"WarmupBatches"`
 }
@@ -0,0 +1,58 @@
+package metrics
+
+import (
+	"github.com/0xPolygonHermez/zkevm-node/metrics"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const (
+	// Prefix for the metrics of the merkletree package.
+	Prefix = "merkletree_"
+
+	// CacheHitsName is the name of the metric that counts the reads of the merkletree served by the cache.
+	CacheHitsName = Prefix + "cache_hits"
+
+	// CacheMissesName is the name of the metric that counts the reads of the merkletree not found in the cache.
+	CacheMissesName = Prefix + "cache_misses"
+
+	// CacheSizeName is the name of the metric with the size in bytes of the entries of the cache.
+	CacheSizeName = Prefix + "cache_size_bytes"
+)
+
+// Register the metrics for the merkletree package.
+func Register() {
+	gauges := []prometheus.GaugeOpts{
+		{
+			Name: CacheSizeName,
+			Help: "[MERKLETREE] size in bytes of the entries of the cache",
+		},
+	}
+	counters := []prometheus.CounterOpts{
+		{
+			Name: CacheHitsName,
+			Help: "[MERKLETREE] number of reads of the merkletree served by the cache",
+		},
+		{
+			Name: CacheMissesName,
+			Help: "[MERKLETREE] number of reads of the merkletree not found in the cache",
+		},
+	}
+
+	metrics.RegisterGauges(gauges...)
+	metrics.RegisterCounters(counters...)
+}
+
+// CacheHit increases the counter of reads of the merkletree served by the cache.
+func CacheHit() {
+	metrics.CounterInc(CacheHitsName)
+}
+
+// CacheMiss increases the counter of reads of the merkletree not found in the cache.
+func CacheMiss() {
+	metrics.CounterInc(CacheMissesName)
+}
+
+// CacheSize sets the gauge with the size in bytes of the entries of the cache.
+func CacheSize(size uint64) {
+	metrics.GaugeSet(CacheSizeName, float64(size))
+}
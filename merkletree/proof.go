@@ -0,0 +1,116 @@
+package merkletree
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	"github.com/0xPolygonHermez/zkevm-node/merkletree/hashdb"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// ProofNode is a node of the merkletree included in a MerkleProof.
+type ProofNode struct {
+	// Hash is the hash of the node.
+	Hash common.Hash
+	// Value is the content of the node: the hashes of the children of an intermediate node,
+	// or the remaining key and the hash of the value of a leaf.
+	Value [12]uint64
+}
+
+// MerkleProof is the proof of the value of a key in the merkletree with a given root.
+type MerkleProof struct {
+	// Key is the key of the merkletree.
+	Key common.Hash
+	// Value is the value of the key, 0 if the key is not in the tree.
+	Value *big.Int
+	// Nodes are the nodes of the path from the root to the key.
+	Nodes []ProofNode
+}
+
+// GetBalanceProof returns the proof of the balance of the address.
+func (tree *StateTree) GetBalanceProof(ctx context.Context, address common.Address, root []byte) (*MerkleProof, error) {
+	key, err := KeyEthAddrBalance(address)
+	if err != nil {
+		return nil, err
+	}
+	return tree.readTree(ctx, root, key)
+}
+
+// GetNonceProof returns the proof of the nonce of the address.
+func (tree *StateTree) GetNonceProof(ctx context.Context, address common.Address, root []byte) (*MerkleProof, error) {
+	key, err := KeyEthAddrNonce(address)
+	if err != nil {
+		return nil, err
+	}
+	return tree.readTree(ctx, root, key)
+}
+
+// GetCodeHashProof returns the proof of the hash of the code of the address.
+func (tree *StateTree) GetCodeHashProof(ctx context.Context, address common.Address, root []byte) (*MerkleProof, error) {
+	key, err := KeyContractCode(address)
+	if err != nil {
+		return nil, err
+	}
+	return tree.readTree(ctx, root, key)
+}
+
+// GetCodeLengthProof returns the proof of the length of the code of the address.
+func (tree *StateTree) GetCodeLengthProof(ctx context.Context, address common.Address, root []byte) (*MerkleProof, error) {
+	key, err := KeyCodeLength(address)
+	if err != nil {
+		return nil, err
+	}
+	return tree.readTree(ctx, root, key)
+}
+
+// GetStorageProof returns the proof of the storage value at the specified position.
+func (tree *StateTree) GetStorageProof(ctx context.Context, address common.Address, position *big.Int, root []byte) (*MerkleProof, error) {
+	key, err := KeyContractStorage(address, position.Bytes())
+	if err != nil {
+		return nil, err
+	}
+	return tree.readTree(ctx, root, key)
+}
+
+func (tree *StateTree) readTree(ctx context.Context, root, key []byte) (*MerkleProof, error) {
+	r := scalarToh4(new(big.Int).SetBytes(root))
+	k := scalarToh4(new(big.Int).SetBytes(key))
+	result, err := tree.grpcClient.ReadTree(ctx, &hashdb.ReadTreeRequest{
+		StateRoot: &hashdb.Fea{Fe0: r[0], Fe1: r[1], Fe2: r[2], Fe3: r[3]},
+		Keys:      []*hashdb.Fea{{Fe0: k[0], Fe1: k[1], Fe2: k[2], Fe3: k[3]}},
+	})
+	if err != nil {
+		return nil, err
+	}
+	if code := result.GetResult().GetCode(); code != hashdb.ResultCode_CODE_SUCCESS {
+		return nil, fmt.Errorf("failed to read the merkletree, result code: %s", code.String())
+	}
+
+	proof := &MerkleProof{
+		Key:   common.BytesToHash(key),
+		Value: big.NewInt(0),
+		Nodes: make([]ProofNode, 0, len(result.HashValue)),
+	}
+	for _, keyValue := range result.KeyValue {
+		if keyValue.Key == nil || keyValue.Value == "" {
+			continue
+		}
+		value, err := string2fea(keyValue.Value)
+		if err != nil {
+			return nil, err
+		}
+		proof.Value = fea2scalar(value)
+	}
+	for _, hashValue := range result.HashValue {
+		h, v := hashValue.Hash, hashValue.Value
+		if h == nil || v == nil {
+			continue
+		}
+		proof.Nodes = append(proof.Nodes, ProofNode{
+			Hash:  common.BytesToHash(h4ToFilledByteSlice([]uint64{h.Fe0, h.Fe1, h.Fe2, h.Fe3})),
+			Value: [12]uint64{v.Fe0, v.Fe1, v.Fe2, v.Fe3, v.Fe4, v.Fe5, v.Fe6, v.Fe7, v.Fe8, v.Fe9, v.Fe10, v.Fe11},
+		})
+	}
+	return proof, nil
+}
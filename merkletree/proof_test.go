@@ -0,0 +1,60 @@
+package merkletree
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/0xPolygonHermez/zkevm-node/hex"
+	"github.com/0xPolygonHermez/zkevm-node/merkletree/hashdb"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+)
+
+// readTreeClientStub returns the value and the nodes of a single key
+type readTreeClientStub struct {
+	hashdb.HashDBServiceClient
+	result *hashdb.ReadTreeResponse
+	req    *hashdb.ReadTreeRequest
+}
+
+func (c *readTreeClientStub) ReadTree(ctx context.Context, in *hashdb.ReadTreeRequest, opts ...grpc.CallOption) (*hashdb.ReadTreeResponse, error) {
+	c.req = in
+	return c.result, nil
+}
+
+func TestGetStorageProof(t *testing.T) {
+	ctx := context.Background()
+	address := common.HexToAddress("0x1")
+	position := big.NewInt(2)
+	root := common.HexToHash("0x3").Bytes()
+
+	client := &readTreeClientStub{
+		result: &hashdb.ReadTreeResponse{
+			KeyValue: []*hashdb.KeyValue{{Key: &hashdb.Fea{}, Value: big.NewInt(123).Text(hex.Base)}},
+			HashValue: []*hashdb.HashValueGL{
+				{Hash: &hashdb.Fea{Fe0: 1}, Value: &hashdb.Fea12{Fe0: 1, Fe11: 12}},
+				{Hash: &hashdb.Fea{Fe0: 2}, Value: &hashdb.Fea12{Fe0: 2}},
+			},
+			Result: &hashdb.ResultCode{Code: hashdb.ResultCode_CODE_SUCCESS},
+		},
+	}
+	tree := NewStateTree(client)
+
+	proof, err := tree.GetStorageProof(ctx, address, position, root)
+	require.NoError(t, err)
+	key, err := KeyContractStorage(address, position.Bytes())
+	require.NoError(t, err)
+	assert.Equal(t, common.BytesToHash(key), proof.Key)
+	assert.Equal(t, big.NewInt(123), proof.Value)
+	require.Len(t, proof.Nodes, 2)
+	assert.Equal(t, [12]uint64{1, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 12}, proof.Nodes[0].Value)
+	require.Len(t, client.req.Keys, 1)
+	assert.Equal(t, scalarToh4(new(big.Int).SetBytes(root))[0], client.req.StateRoot.Fe0)
+
+	client.result = &hashdb.ReadTreeResponse{Result: &hashdb.ResultCode{Code: hashdb.ResultCode_CODE_DB_ERROR}}
+	_, err = tree.GetStorageProof(ctx, address, position, root)
+	require.Error(t, err)
+}
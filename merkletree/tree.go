@@ -8,12 +8,14 @@ import (
 
 	"github.com/0xPolygonHermez/zkevm-node/hex"
 	"github.com/0xPolygonHermez/zkevm-node/merkletree/hashdb"
+	"github.com/0xPolygonHermez/zkevm-node/merkletree/metrics"
 	"github.com/ethereum/go-ethereum/common"
 )
 
 // StateTree provides methods to access and modify state in merkletree
 type StateTree struct {
 	grpcClient hashdb.HashDBServiceClient
+	cache      *nodeCache
 }
 
 // NewStateTree creates new StateTree.
@@ -23,6 +25,18 @@ func NewStateTree(client hashdb.HashDBServiceClient) *StateTree {
 	}
 }
 
+// NewStateTreeWithCache creates new StateTree that keeps the values read from the merkletree
+// server in an in-memory LRU cache limited by the configured memory budget. The cache is
+// disabled if the budget is 0.
+func NewStateTreeWithCache(client hashdb.HashDBServiceClient, cfg CacheCfg) *StateTree {
+	tree := NewStateTree(client)
+	if cfg.MaxBytesSize > 0 {
+		metrics.Register()
+		tree.cache = newNodeCache(cfg.MaxBytesSize)
+	}
+	return tree
+}
+
 // GetBalance returns balance.
 func (tree *StateTree) GetBalance(ctx context.Context, address common.Address, root []byte) (*big.Int, error) {
 	r := new(big.Int).SetBytes(root)
@@ -244,6 +258,16 @@ func (tree *StateTree) SetStorageAt(ctx context.Context, address common.Address,
 }
 
 func (tree *StateTree) get(ctx context.Context, root, key []uint64) (*Proof, error) {
+	if tree.cache != nil {
+		if value, found := tree.cache.getValue(root, key); found {
+			return &Proof{
+				Root:  []uint64{root[0], root[1], root[2], root[3]},
+				Key:   key,
+				Value: value,
+			}, nil
+		}
+	}
+
 	result, err := tree.grpcClient.Get(ctx, &hashdb.GetRequest{
 		Root: &hashdb.Fea{Fe0: root[0], Fe1: root[1], Fe2: root[2], Fe3: root[3]},
 		Key:  &hashdb.Fea{Fe0: key[0], Fe1: key[1], Fe2: key[2], Fe3: key[3]},
@@ -256,6 +280,9 @@ func (tree *StateTree) get(ctx context.Context, root, key []uint64) (*Proof, err
 	if err != nil {
 		return nil, err
 	}
+	if tree.cache != nil {
+		tree.cache.addValue(root, key, value)
+	}
 	return &Proof{
 		Root:  []uint64{root[0], root[1], root[2], root[3]},
 		Key:   key,
@@ -264,12 +291,23 @@ func (tree *StateTree) get(ctx context.Context, root, key []uint64) (*Proof, err
 }
 
 func (tree *StateTree) getProgram(ctx context.Context, key []uint64) (*ProgramProof, error) {
+	if tree.cache != nil {
+		if data, found := tree.cache.getProgram(key); found {
+			return &ProgramProof{
+				Data: data,
+			}, nil
+		}
+	}
+
 	result, err := tree.grpcClient.GetProgram(ctx, &hashdb.GetProgramRequest{
 		Key: &hashdb.Fea{Fe0: key[0], Fe1: key[1], Fe2: key[2], Fe3: key[3]},
 	})
 	if err != nil {
 		return nil, err
 	}
+	if tree.cache != nil {
+		tree.cache.addProgram(key, result.Data)
+	}
 
 	return &ProgramProof{
 		Data: result.Data,
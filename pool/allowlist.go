@@ -0,0 +1,98 @@
+package pool
+
+import (
+	"context"
+	"math/big"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+const registryWordLength = 32
+
+// newAllowedAddresses returns the set of the addresses of the local allow list
+func newAllowedAddresses(addresses []common.Address) map[common.Address]struct{} {
+	allowedAddresses := make(map[common.Address]struct{}, len(addresses))
+	for _, address := range addresses {
+		allowedAddresses[address] = struct{}{}
+	}
+	return allowedAddresses
+}
+
+// registryCache keeps the registry results read at the state of the same L2 block, so the
+// senders of the txs added to the pool while the block is the last one are read only once
+type registryCache struct {
+	mutex       sync.Mutex
+	blockNumber uint64
+	allowed     map[common.Address]bool
+}
+
+// get returns the registry result of the address cached for the provided L2 block, if any
+func (c *registryCache) get(blockNumber uint64, address common.Address) (bool, bool) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	if c.blockNumber != blockNumber {
+		return false, false
+	}
+	allowed, found := c.allowed[address]
+	return allowed, found
+}
+
+// set caches the registry result of the address for the provided L2 block, discarding the
+// results of the previous block
+func (c *registryCache) set(blockNumber uint64, address common.Address, allowed bool) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	if c.allowed == nil || c.blockNumber != blockNumber {
+		c.blockNumber = blockNumber
+		c.allowed = make(map[common.Address]bool)
+	}
+	c.allowed[address] = allowed
+}
+
+// IsAddressAllowed returns true if the allow-list mode is disabled or the address is in the local
+// allow list or in the on-chain registry. An error is returned if the registry can't be read, in
+// that case it's unknown if the address is allowed
+func (p *Pool) IsAddressAllowed(ctx context.Context, address common.Address) (bool, error) {
+	if !p.cfg.AllowList.Enabled {
+		return true, nil
+	}
+	if _, found := p.allowedAddresses[address]; found {
+		return true, nil
+	}
+	if p.cfg.AllowList.RegistryAddress == (common.Address{}) {
+		return false, nil
+	}
+	return p.isAddressInRegistry(ctx, address)
+}
+
+// isAddressInRegistry returns true if the value of the address in the mapping of the registry
+// contract is not zero in the state of the last L2 block
+func (p *Pool) isAddressInRegistry(ctx context.Context, address common.Address) (bool, error) {
+	lastL2Block, err := p.state.GetLastL2Block(ctx, nil)
+	if err != nil {
+		return false, err
+	}
+	blockNumber := lastL2Block.NumberU64()
+	if allowed, found := p.registryCache.get(blockNumber, address); found {
+		return allowed, nil
+	}
+
+	position := registryPosition(address, p.cfg.AllowList.RegistrySlot)
+	value, err := p.state.GetStorageAt(ctx, p.cfg.AllowList.RegistryAddress, position, lastL2Block.Root())
+	if err != nil {
+		return false, err
+	}
+	allowed := value.Sign() != 0
+	p.registryCache.set(blockNumber, address, allowed)
+	return allowed, nil
+}
+
+// registryPosition returns the storage position of the value of the address in a solidity
+// mapping(address => ...) declared at the provided slot
+func registryPosition(address common.Address, slot uint64) *big.Int {
+	key := common.LeftPadBytes(address.Bytes(), registryWordLength)
+	mappingSlot := common.LeftPadBytes(new(big.Int).SetUint64(slot).Bytes(), registryWordLength)
+	return new(big.Int).SetBytes(crypto.Keccak256(key, mappingSlot))
+}
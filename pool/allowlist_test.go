@@ -0,0 +1,140 @@
+package pool
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/0xPolygonHermez/zkevm-node/state"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/jackc/pgx/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// registryStateStub keeps the storage of the registry contract
+type registryStateStub struct {
+	stateInterface
+	registry    common.Address
+	storage     map[string]*big.Int
+	blockNumber int64
+	storageErr  error
+	reads       int
+}
+
+func (s *registryStateStub) GetLastL2Block(ctx context.Context, dbTx pgx.Tx) (*state.L2Block, error) {
+	return state.NewL2BlockWithHeader(state.NewL2Header(&types.Header{Number: big.NewInt(s.blockNumber)})), nil
+}
+
+func (s *registryStateStub) GetStorageAt(ctx context.Context, address common.Address, position *big.Int, root common.Hash) (*big.Int, error) {
+	s.reads++
+	if s.storageErr != nil {
+		return nil, s.storageErr
+	}
+	if address != s.registry {
+		return big.NewInt(0), nil
+	}
+	if value, found := s.storage[position.String()]; found {
+		return value, nil
+	}
+	return big.NewInt(0), nil
+}
+
+func TestRegistryPosition(t *testing.T) {
+	// keccak256(abi.encode(address(0), uint256(0)))
+	expected, _ := new(big.Int).SetString("ad3228b676f7d3cd4284a5443f17f1962b36e491b30a40b2405849e597ba5fb5", 16)
+	assert.Equal(t, expected, registryPosition(common.Address{}, 0))
+	assert.NotEqual(t, registryPosition(common.Address{}, 0), registryPosition(common.Address{}, 1))
+}
+
+func TestIsAddressAllowed(t *testing.T) {
+	localAddr := common.HexToAddress("0x1")
+	registryAddr := common.HexToAddress("0x2")
+	removedAddr := common.HexToAddress("0x3")
+	otherAddr := common.HexToAddress("0x4")
+	registry := common.HexToAddress("0x100")
+	const slot = 3
+
+	st := &registryStateStub{
+		registry: registry,
+		storage: map[string]*big.Int{
+			registryPosition(registryAddr, slot).String(): big.NewInt(1),
+			registryPosition(removedAddr, slot).String():  big.NewInt(0),
+		},
+	}
+
+	testCases := []struct {
+		name     string
+		cfg      AllowListCfg
+		expected map[common.Address]bool
+	}{
+		{
+			name:     "allow-list mode disabled",
+			cfg:      AllowListCfg{Addresses: []common.Address{localAddr}},
+			expected: map[common.Address]bool{localAddr: true, registryAddr: true, otherAddr: true},
+		},
+		{
+			name:     "local allow list",
+			cfg:      AllowListCfg{Enabled: true, Addresses: []common.Address{localAddr}},
+			expected: map[common.Address]bool{localAddr: true, registryAddr: false, otherAddr: false},
+		},
+		{
+			name:     "local allow list and registry",
+			cfg:      AllowListCfg{Enabled: true, Addresses: []common.Address{localAddr}, RegistryAddress: registry, RegistrySlot: slot},
+			expected: map[common.Address]bool{localAddr: true, registryAddr: true, removedAddr: false, otherAddr: false},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			p := &Pool{state: st, cfg: Config{AllowList: tc.cfg}, allowedAddresses: newAllowedAddresses(tc.cfg.Addresses)}
+			for address, expected := range tc.expected {
+				allowed, err := p.IsAddressAllowed(context.Background(), address)
+				require.NoError(t, err)
+				assert.Equal(t, expected, allowed, address.String())
+			}
+		})
+	}
+}
+
+func TestIsAddressAllowedRegistryCache(t *testing.T) {
+	ctx := context.Background()
+	registryAddr := common.HexToAddress("0x1")
+	registry := common.HexToAddress("0x100")
+	cfg := AllowListCfg{Enabled: true, RegistryAddress: registry}
+	st := &registryStateStub{
+		registry:    registry,
+		storage:     map[string]*big.Int{registryPosition(registryAddr, 0).String(): big.NewInt(1)},
+		blockNumber: 1,
+	}
+	p := &Pool{state: st, cfg: Config{AllowList: cfg}, allowedAddresses: newAllowedAddresses(cfg.Addresses)}
+
+	// the registry is read once per L2 block
+	for i := 0; i < 2; i++ {
+		allowed, err := p.IsAddressAllowed(ctx, registryAddr)
+		require.NoError(t, err)
+		assert.True(t, allowed)
+	}
+	assert.Equal(t, 1, st.reads)
+
+	// the sender is removed from the registry in a new L2 block
+	st.blockNumber = 2
+	st.storage = map[string]*big.Int{}
+	allowed, err := p.IsAddressAllowed(ctx, registryAddr)
+	require.NoError(t, err)
+	assert.False(t, allowed)
+	assert.Equal(t, 2, st.reads)
+
+	// a read error is returned instead of rejecting the sender, and it's not cached
+	st.blockNumber = 3
+	st.storageErr = errors.New("storage error")
+	_, err = p.IsAddressAllowed(ctx, registryAddr)
+	assert.ErrorIs(t, err, st.storageErr)
+	st.storageErr = nil
+	st.storage = map[string]*big.Int{registryPosition(registryAddr, 0).String(): big.NewInt(1)}
+	allowed, err = p.IsAddressAllowed(ctx, registryAddr)
+	require.NoError(t, err)
+	assert.True(t, allowed)
+}
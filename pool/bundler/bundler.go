@@ -0,0 +1,181 @@
+package bundler
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"math/big"
+	"strings"
+
+	"github.com/0xPolygonHermez/zkevm-node/pool"
+	"github.com/0xPolygonHermez/zkevm-node/state"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/jackc/pgx/v4"
+)
+
+const (
+	// handleOpsABI is the handleOps method of the EIP-4337 entry point contract
+	handleOpsABI = `[{"type":"function","name":"handleOps","inputs":[{"name":"ops","type":"tuple[]","components":[
+		{"name":"sender","type":"address"},{"name":"nonce","type":"uint256"},{"name":"initCode","type":"bytes"},
+		{"name":"callData","type":"bytes"},{"name":"callGasLimit","type":"uint256"},{"name":"verificationGasLimit","type":"uint256"},
+		{"name":"preVerificationGas","type":"uint256"},{"name":"maxFeePerGas","type":"uint256"},{"name":"maxPriorityFeePerGas","type":"uint256"},
+		{"name":"paymasterAndData","type":"bytes"},{"name":"signature","type":"bytes"}]},{"name":"beneficiary","type":"address"}],"outputs":[]}]`
+
+	// bundleGasOverhead is the gas consumed by a bundle tx besides the gas of its user operations
+	bundleGasOverhead = uint64(100000)
+
+	// paymasterVerificationMultiplier is the number of times the verification gas limit is consumed by
+	// a user operation with paymaster, as it's used for the account, the paymaster and its post op
+	paymasterVerificationMultiplier = 3
+)
+
+var entryPointABI, _ = abi.JSON(strings.NewReader(handleOpsABI))
+
+type poolInterface interface {
+	GetGasPrices(ctx context.Context) (pool.GasPrices, error)
+	GetPendingNonce(ctx context.Context, address common.Address, accountNonce uint64) (uint64, error)
+}
+
+type stateInterface interface {
+	GetLastL2Block(ctx context.Context, dbTx pgx.Tx) (*state.L2Block, error)
+	GetNonce(ctx context.Context, address common.Address, root common.Hash) (uint64, error)
+}
+
+// Bundler builds the bundle txs of the pending user operations of the pool, each bundle tx calls
+// handleOps of the entry point of its user operations with the bundler account as the beneficiary,
+// so the account is refunded by the entry point for the gas of the bundles. It implements the
+// pool.UserOperationsBundler interface
+type Bundler struct {
+	key     *ecdsa.PrivateKey
+	address common.Address
+	signer  types.Signer
+	pool    poolInterface
+	state   stateInterface
+}
+
+// New creates a new Bundler that signs the bundle txs with the provided key
+func New(key *ecdsa.PrivateKey, chainID uint64, p poolInterface, st stateInterface) *Bundler {
+	return &Bundler{
+		key:     key,
+		address: crypto.PubkeyToAddress(key.PublicKey),
+		signer:  types.NewEIP155Signer(new(big.Int).SetUint64(chainID)),
+		pool:    p,
+		state:   st,
+	}
+}
+
+// Address returns the account that signs the bundle txs and receives the fees of the user operations
+func (b *Bundler) Address() common.Address {
+	return b.address
+}
+
+// Bundle builds the bundle txs of the provided user operations. The user operations are grouped by
+// entry point keeping their order, and a new bundle is started when the gas of a bundle would exceed
+// the max gas limit of a tx. The bundle txs take consecutive nonces from the pending nonce of the
+// bundler account and pay the current L2 gas price
+func (b *Bundler) Bundle(ctx context.Context, userOps []pool.UserOperation) ([]pool.UserOperationsBundle, error) {
+	lastL2Block, err := b.state.GetLastL2Block(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	accountNonce, err := b.state.GetNonce(ctx, b.address, lastL2Block.Root())
+	if err != nil {
+		return nil, err
+	}
+	nonce, err := b.pool.GetPendingNonce(ctx, b.address, accountNonce)
+	if err != nil {
+		return nil, err
+	}
+	gasPrices, err := b.pool.GetGasPrices(ctx)
+	if err != nil {
+		return nil, err
+	}
+	gasPrice := new(big.Int).SetUint64(gasPrices.L2GasPrice)
+
+	bundles := make([]pool.UserOperationsBundle, 0)
+	for _, group := range splitUserOperations(userOps) {
+		tx, err := b.bundleTx(group, nonce, gasPrice)
+		if err != nil {
+			return nil, err
+		}
+		hashes := make([]common.Hash, 0, len(group))
+		for _, userOp := range group {
+			hashes = append(hashes, userOp.Hash)
+		}
+		bundles = append(bundles, pool.UserOperationsBundle{Tx: *tx, UserOperations: hashes})
+		nonce++
+	}
+	return bundles, nil
+}
+
+// bundleTx builds and signs the handleOps tx of the provided user operations, that share the entry point
+func (b *Bundler) bundleTx(userOps []pool.UserOperation, nonce uint64, gasPrice *big.Int) (*types.Transaction, error) {
+	ops := make([]pool.UserOp, 0, len(userOps))
+	gas := bundleGasOverhead
+	for _, userOp := range userOps {
+		ops = append(ops, withZeroValues(userOp.UserOp))
+		gas += userOpGas(userOp.UserOp)
+	}
+	data, err := entryPointABI.Pack("handleOps", ops, b.address)
+	if err != nil {
+		return nil, err
+	}
+	tx := types.NewTransaction(nonce, userOps[0].EntryPoint, big.NewInt(0), gas, gasPrice, data)
+	return types.SignTx(tx, b.signer, b.key)
+}
+
+// splitUserOperations groups the user operations by entry point, splitting the groups whose gas
+// exceeds the max gas limit of a tx
+func splitUserOperations(userOps []pool.UserOperation) [][]pool.UserOperation {
+	groups := make([][]pool.UserOperation, 0)
+	current := map[common.Address]int{}
+	gas := map[common.Address]uint64{}
+	for _, userOp := range userOps {
+		userOpGas := userOpGas(userOp.UserOp)
+		i, found := current[userOp.EntryPoint]
+		if !found || gas[userOp.EntryPoint]+userOpGas > state.MaxTxGasLimit {
+			groups = append(groups, []pool.UserOperation{})
+			i = len(groups) - 1
+			current[userOp.EntryPoint] = i
+			gas[userOp.EntryPoint] = bundleGasOverhead
+		}
+		groups[i] = append(groups[i], userOp)
+		gas[userOp.EntryPoint] += userOpGas
+	}
+	return groups
+}
+
+// userOpGas returns the max gas the entry point can consume to handle the user operation
+func userOpGas(op pool.UserOp) uint64 {
+	verificationGas := zeroIfNil(op.VerificationGasLimit)
+	if _, found := op.Paymaster(); found {
+		verificationGas.Mul(verificationGas, big.NewInt(paymasterVerificationMultiplier))
+	}
+	gas := new(big.Int).Add(verificationGas, zeroIfNil(op.CallGasLimit))
+	gas.Add(gas, zeroIfNil(op.PreVerificationGas))
+	if !gas.IsUint64() {
+		return state.MaxTxGasLimit
+	}
+	return gas.Uint64()
+}
+
+// withZeroValues returns the user operation with the missing numbers set to zero, so it can be packed
+func withZeroValues(op pool.UserOp) pool.UserOp {
+	op.Nonce = zeroIfNil(op.Nonce)
+	op.CallGasLimit = zeroIfNil(op.CallGasLimit)
+	op.VerificationGasLimit = zeroIfNil(op.VerificationGasLimit)
+	op.PreVerificationGas = zeroIfNil(op.PreVerificationGas)
+	op.MaxFeePerGas = zeroIfNil(op.MaxFeePerGas)
+	op.MaxPriorityFeePerGas = zeroIfNil(op.MaxPriorityFeePerGas)
+	return op
+}
+
+// zeroIfNil returns a copy of the provided number, or zero if it's nil
+func zeroIfNil(n *big.Int) *big.Int {
+	if n == nil {
+		return big.NewInt(0)
+	}
+	return new(big.Int).Set(n)
+}
@@ -0,0 +1,157 @@
+package bundler
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/0xPolygonHermez/zkevm-node/pool"
+	"github.com/0xPolygonHermez/zkevm-node/state"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/jackc/pgx/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const chainID = uint64(1000)
+
+type poolStub struct {
+	l2GasPrice   uint64
+	pendingNonce uint64
+	accountNonce uint64
+}
+
+func (p *poolStub) GetGasPrices(ctx context.Context) (pool.GasPrices, error) {
+	return pool.GasPrices{L2GasPrice: p.l2GasPrice}, nil
+}
+
+func (p *poolStub) GetPendingNonce(ctx context.Context, address common.Address, accountNonce uint64) (uint64, error) {
+	p.accountNonce = accountNonce
+	return p.pendingNonce, nil
+}
+
+type stateStub struct {
+	root  common.Hash
+	nonce uint64
+}
+
+func (s *stateStub) GetLastL2Block(ctx context.Context, dbTx pgx.Tx) (*state.L2Block, error) {
+	header := state.NewL2Header(&types.Header{Number: big.NewInt(1), Root: s.root})
+	return state.NewL2BlockWithHeader(header), nil
+}
+
+func (s *stateStub) GetNonce(ctx context.Context, address common.Address, root common.Hash) (uint64, error) {
+	if root != s.root {
+		return 0, state.ErrNotFound
+	}
+	return s.nonce, nil
+}
+
+func newUserOperation(entryPoint common.Address, nonce int64, callGasLimit int64, paymasterAndData []byte) pool.UserOperation {
+	userOp := pool.UserOp{
+		Sender:               common.HexToAddress("0x1"),
+		Nonce:                big.NewInt(nonce),
+		CallData:             []byte{0x1},
+		CallGasLimit:         big.NewInt(callGasLimit),
+		VerificationGasLimit: big.NewInt(100000),
+		PreVerificationGas:   big.NewInt(50000),
+		MaxFeePerGas:         big.NewInt(10),
+		PaymasterAndData:     paymasterAndData,
+		Signature:            []byte{0x2},
+	}
+	return pool.UserOperation{
+		UserOp:     userOp,
+		Hash:       userOp.Hash(entryPoint, chainID),
+		EntryPoint: entryPoint,
+		Status:     pool.UserOperationStatusPending,
+	}
+}
+
+func TestBundle(t *testing.T) {
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	entryPoint := common.HexToAddress("0x10")
+	otherEntryPoint := common.HexToAddress("0x20")
+
+	paymasterAndData := common.HexToAddress("0x30").Bytes()
+	userOps := []pool.UserOperation{
+		newUserOperation(entryPoint, 0, 200000, nil),
+		newUserOperation(otherEntryPoint, 0, 200000, nil),
+		newUserOperation(entryPoint, 1, 300000, paymasterAndData),
+	}
+	p := &poolStub{l2GasPrice: 5, pendingNonce: 7}
+	st := &stateStub{root: common.HexToHash("0x40"), nonce: 6}
+
+	b := New(key, chainID, p, st)
+	bundles, err := b.Bundle(context.Background(), userOps)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(6), p.accountNonce)
+	require.Len(t, bundles, 2)
+
+	assert.Equal(t, []common.Hash{userOps[0].Hash, userOps[2].Hash}, bundles[0].UserOperations)
+	assert.Equal(t, []common.Hash{userOps[1].Hash}, bundles[1].UserOperations)
+
+	signer := types.NewEIP155Signer(new(big.Int).SetUint64(chainID))
+	for i, expected := range []struct {
+		to    common.Address
+		nonce uint64
+		gas   uint64
+		ops   []pool.UserOperation
+	}{
+		{to: entryPoint, nonce: 7, gas: bundleGasOverhead + 350000 + 650000, ops: []pool.UserOperation{userOps[0], userOps[2]}},
+		{to: otherEntryPoint, nonce: 8, gas: bundleGasOverhead + 350000, ops: []pool.UserOperation{userOps[1]}},
+	} {
+		tx := bundles[i].Tx
+		sender, err := types.Sender(signer, &tx)
+		require.NoError(t, err)
+		assert.Equal(t, b.Address(), sender)
+		assert.Equal(t, expected.to, *tx.To())
+		assert.Equal(t, expected.nonce, tx.Nonce())
+		assert.Equal(t, expected.gas, tx.Gas())
+		assert.Equal(t, big.NewInt(5), tx.GasPrice())
+
+		args, err := entryPointABI.Methods["handleOps"].Inputs.Unpack(tx.Data()[4:])
+		require.NoError(t, err)
+		require.Len(t, args, 2)
+		assert.Equal(t, b.Address(), args[1])
+		ops, ok := args[0].([]struct {
+			Sender               common.Address `json:"sender"`
+			Nonce                *big.Int       `json:"nonce"`
+			InitCode             []byte         `json:"initCode"`
+			CallData             []byte         `json:"callData"`
+			CallGasLimit         *big.Int       `json:"callGasLimit"`
+			VerificationGasLimit *big.Int       `json:"verificationGasLimit"`
+			PreVerificationGas   *big.Int       `json:"preVerificationGas"`
+			MaxFeePerGas         *big.Int       `json:"maxFeePerGas"`
+			MaxPriorityFeePerGas *big.Int       `json:"maxPriorityFeePerGas"`
+			PaymasterAndData     []byte         `json:"paymasterAndData"`
+			Signature            []byte         `json:"signature"`
+		})
+		require.True(t, ok)
+		require.Len(t, ops, len(expected.ops))
+		for j, op := range ops {
+			assert.Equal(t, expected.ops[j].Sender, op.Sender)
+			assert.Equal(t, expected.ops[j].Nonce.String(), op.Nonce.String())
+			assert.Equal(t, expected.ops[j].CallGasLimit.String(), op.CallGasLimit.String())
+			assert.Equal(t, "0", op.MaxPriorityFeePerGas.String())
+			assert.Equal(t, common.Bytes2Hex(expected.ops[j].PaymasterAndData), common.Bytes2Hex(op.PaymasterAndData))
+		}
+	}
+}
+
+func TestSplitUserOperationsByGas(t *testing.T) {
+	entryPoint := common.HexToAddress("0x10")
+	callGasLimit := int64(state.MaxTxGasLimit / 2)
+	userOps := []pool.UserOperation{
+		newUserOperation(entryPoint, 0, callGasLimit, nil),
+		newUserOperation(entryPoint, 1, callGasLimit, nil),
+		newUserOperation(entryPoint, 2, 100000, nil),
+	}
+
+	groups := splitUserOperations(userOps)
+	require.Len(t, groups, 2)
+	assert.Equal(t, userOps[:1], groups[0])
+	assert.Equal(t, userOps[1:], groups[1])
+}
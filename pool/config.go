@@ -3,6 +3,15 @@ package pool
 import (
 	"github.com/0xPolygonHermez/zkevm-node/config/types"
 	"github.com/0xPolygonHermez/zkevm-node/db"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+const (
+	// StorageTypePostgres stores the pool data in the Postgres DB configured in the DB section (default)
+	StorageTypePostgres = "postgres"
+	// StorageTypeMemory stores the pool data in memory, it's lost when the node stops. Intended for tests
+	// and local devnets where all the components run in the same process
+	StorageTypeMemory = "memory"
 )
 
 // Config is the pool configuration
@@ -20,6 +29,12 @@ type Config struct {
 	// MaxTxDataBytesSize is the max size of the data field of a transaction in bytes
 	MaxTxDataBytesSize int `mapstructure:"MaxTxDataBytesSize"`
 
+	// TxFeeCap is the max fee (gasPrice * gas) in ether allowed for a transaction (0 means no cap)
+	TxFeeCap float64 `mapstructure:"TxFeeCap"`
+
+	// StorageType is the backend used to store the pool data, accepted values are "postgres" and "memory"
+	StorageType string `mapstructure:"StorageType"`
+
 	// DB is the database configuration
 	DB db.Config `mapstructure:"DB"`
 
@@ -35,16 +50,188 @@ type Config struct {
 	// AccountQueue represents the maximum number of non-executable transaction slots permitted per account
 	AccountQueue uint64 `mapstructure:"AccountQueue"`
 
+	// PriceBump is the min percentage a tx must increase the gas price of a pending tx with the same sender
+	// and nonce to replace it
+	PriceBump uint64 `mapstructure:"PriceBump"`
+
 	// GlobalQueue represents the maximum number of non-executable transaction slots for all accounts
 	GlobalQueue uint64 `mapstructure:"GlobalQueue"`
 
+	// PruneNonceTooLowTxs enables marking as failed, every time a new L2 block is added to the state, the pending
+	// and scheduled txs whose nonce is lower than the nonce of their sender in the state
+	PruneNonceTooLowTxs bool `mapstructure:"PruneNonceTooLowTxs"`
+
 	// EffectiveGasPrice is the config for the effective gas price calculation
 	EffectiveGasPrice EffectiveGasPriceCfg `mapstructure:"EffectiveGasPrice"`
 
+	// Eviction is the config for the eviction of txs from the pool
+	Eviction EvictionCfg `mapstructure:"Eviction"`
+
+	// Cache is the config for the in-memory cache of the pending and selected txs
+	Cache CacheCfg `mapstructure:"Cache"`
+
+	// Gossip is the config for the propagation of the txs added to the pool to other nodes
+	Gossip GossipCfg `mapstructure:"Gossip"`
+
+	// RateLimit is the config for the rate limiting of the txs added to the pool
+	RateLimit RateLimitCfg `mapstructure:"RateLimit"`
+
+	// ScheduledTxs is the config for the txs that are held in the pool until a not-before timestamp or block
+	ScheduledTxs ScheduledTxsCfg `mapstructure:"ScheduledTxs"`
+
+	// ConditionalTxs is the config for the txs that are only sequenced if their conditions are met
+	ConditionalTxs ConditionalTxsCfg `mapstructure:"ConditionalTxs"`
+
+	// AccountAbstraction is the config for the alternate mempool of EIP-4337 user operations
+	AccountAbstraction AccountAbstractionCfg `mapstructure:"AccountAbstraction"`
+
+	// AllowList is the config for the allow-list mode of permissioned deployments
+	AllowList AllowListCfg `mapstructure:"AllowList"`
+
 	// ForkID is the current fork ID of the chain
 	ForkID uint64 `mapstructure:"ForkID"`
 }
 
+// EvictionCfg contains the configuration properties for the eviction of txs from the pool. The max size of the pool
+// and the max txs per account are limited by the GlobalQueue and AccountQueue parameters
+type EvictionCfg struct {
+	// Interval is the time the pool waits between eviction runs of the expired txs (0 means disabled)
+	Interval types.Duration `mapstructure:"Interval"`
+
+	// PendingTxTTL is the time after which a pending tx that has not been loaded by the sequencer is evicted (0 means disabled)
+	PendingTxTTL types.Duration `mapstructure:"PendingTxTTL"`
+
+	// InvalidTxTTL is the time after which an invalid tx is removed from the pool (0 means disabled)
+	InvalidTxTTL types.Duration `mapstructure:"InvalidTxTTL"`
+
+	// EvictLowestGasPrice indicates if a new tx is allowed to evict the pending tx with the lowest gas price when the
+	// pool is full (GlobalQueue). Only the txs that have not been loaded by the sequencer yet can be evicted
+	EvictLowestGasPrice bool `mapstructure:"EvictLowestGasPrice"`
+}
+
+// CacheCfg contains the configuration properties for the in-memory cache of the pending and selected txs
+type CacheCfg struct {
+	// Enabled indicates if the pending nonce, the pending txs and the count of pending txs are read from memory
+	// instead of from the DB. The DB remains the durable store of the pool
+	Enabled bool `mapstructure:"Enabled"`
+
+	// RefreshInterval is the time between reloads of the cache from the DB, to get the changes done by other
+	// instances of the pool sharing the same DB (e.g. RPC and sequencer running in different processes)
+	RefreshInterval types.Duration `mapstructure:"RefreshInterval"`
+}
+
+// GossipCfg contains the configuration properties for the propagation of the txs added to the pool to other nodes
+type GossipCfg struct {
+	// Enabled indicates if the txs added to this pool are propagated to the peers. It allows the RPC nodes of a
+	// cluster to use their own pool DB instead of sharing the DB of the sequencer
+	Enabled bool `mapstructure:"Enabled"`
+
+	// Peers is the list of JSON RPC URLs of the nodes the txs are propagated to (e.g. the trusted sequencer node
+	// and the other RPC nodes of the cluster). The txs are sent using eth_sendRawTransaction
+	Peers []string `mapstructure:"Peers"`
+
+	// QueueSize is the max number of txs waiting to be propagated to each peer, the txs added while the queue of a peer
+	// is full are not propagated to it
+	QueueSize uint64 `mapstructure:"QueueSize"`
+
+	// Timeout is the max time to wait for a peer to receive a tx (0 means no timeout)
+	Timeout types.Duration `mapstructure:"Timeout"`
+
+	// MaxKnownTxs is the number of hashes of the last propagated txs remembered to avoid propagating the same tx twice
+	MaxKnownTxs uint64 `mapstructure:"MaxKnownTxs"`
+}
+
+// RateLimitCfg contains the configuration properties for the rate limiting of the txs added to the pool. The rate is
+// limited using a token bucket per IP and per sender address, the local txs are not limited
+type RateLimitCfg struct {
+	// IPTxsPerSecond is the max number of txs per second accepted from the same IP (0 means no limit)
+	IPTxsPerSecond float64 `mapstructure:"IPTxsPerSecond"`
+
+	// IPBurst is the max number of txs accepted at once from the same IP
+	IPBurst uint64 `mapstructure:"IPBurst"`
+
+	// SenderTxsPerSecond is the max number of txs per second accepted from the same sender address (0 means no limit)
+	SenderTxsPerSecond float64 `mapstructure:"SenderTxsPerSecond"`
+
+	// SenderBurst is the max number of txs accepted at once from the same sender address
+	SenderBurst uint64 `mapstructure:"SenderBurst"`
+
+	// GossipPeersIPs are the IPs of the nodes propagating the txs of their pools to this node (see Gossip), the txs
+	// received from them are not limited as they are limited by the peers
+	GossipPeersIPs []string `mapstructure:"GossipPeersIPs"`
+}
+
+// ScheduledTxsCfg contains the configuration properties for the scheduled txs. A scheduled tx is validated when it's
+// added to the pool, but it's not provided to the sequencer until its not-before timestamp and block are reached
+type ScheduledTxsCfg struct {
+	// Enabled indicates if the pool accepts scheduled txs
+	Enabled bool `mapstructure:"Enabled"`
+
+	// CheckInterval is the time between checks of the scheduled txs that are eligible to be sequenced
+	CheckInterval types.Duration `mapstructure:"CheckInterval"`
+
+	// MaxDelay is the max time in the future a tx can be scheduled for
+	MaxDelay types.Duration `mapstructure:"MaxDelay"`
+
+	// MaxBlocksAhead is the max number of L2 blocks ahead of the last one a tx can be scheduled for
+	MaxBlocksAhead uint64 `mapstructure:"MaxBlocksAhead"`
+}
+
+// ConditionalTxsCfg contains the configuration properties for the conditional txs. The conditions of a
+// conditional tx are checked when it's added to the pool and again when the sequencer selects it
+type ConditionalTxsCfg struct {
+	// Enabled indicates if the pool accepts conditional txs
+	Enabled bool `mapstructure:"Enabled"`
+
+	// MaxKnownStorageSlots is the max number of storage slots the known accounts of a conditional tx can check
+	MaxKnownStorageSlots uint64 `mapstructure:"MaxKnownStorageSlots"`
+}
+
+// AccountAbstractionCfg contains the configuration properties for the alternate mempool of EIP-4337 user operations.
+// The user operations are kept apart from the txs until a bundler includes them in a bundle tx added to the pool
+type AccountAbstractionCfg struct {
+	// Enabled indicates if the pool accepts user operations
+	Enabled bool `mapstructure:"Enabled"`
+
+	// EntryPoints is the list of addresses of the entry point contracts supported
+	EntryPoints []common.Address `mapstructure:"EntryPoints"`
+
+	// MaxVerificationGas is the max verification gas limit allowed for a user operation
+	MaxVerificationGas uint64 `mapstructure:"MaxVerificationGas"`
+
+	// MaxUserOpsPerSender is the max number of pending user operations allowed for the same sender (0 means no limit)
+	MaxUserOpsPerSender uint64 `mapstructure:"MaxUserOpsPerSender"`
+
+	// BundleInterval is the time between calls to the bundler with the pending user operations
+	BundleInterval types.Duration `mapstructure:"BundleInterval"`
+
+	// MaxUserOpsPerBundle is the max number of pending user operations provided to the bundler at once
+	MaxUserOpsPerBundle uint64 `mapstructure:"MaxUserOpsPerBundle"`
+
+	// BundlerPrivateKey is the key of the account that signs the bundle txs, it's required by the sequencer when
+	// the user operations are enabled. The account pays the gas of the bundle txs and it's refunded by the entry
+	// point with the fees of the user operations, so it needs enough balance for the gas limit of a bundle
+	BundlerPrivateKey types.KeystoreFileConfig `mapstructure:"BundlerPrivateKey"`
+}
+
+// AllowListCfg contains the configuration properties for the allow-list mode. When it's enabled only the senders
+// in the local allow list or in the on-chain registry can add txs to the pool, and the sequencer discards the txs
+// of the senders that are no longer allowed when they are selected
+type AllowListCfg struct {
+	// Enabled indicates if the allow-list mode is enabled
+	Enabled bool `mapstructure:"Enabled"`
+
+	// Addresses is the local allow list of senders
+	Addresses []common.Address `mapstructure:"Addresses"`
+
+	// RegistryAddress is the address of the L2 contract that keeps the allowed senders in a mapping(address => bool),
+	// the zero address disables the on-chain registry
+	RegistryAddress common.Address `mapstructure:"RegistryAddress"`
+
+	// RegistrySlot is the storage slot of the mapping of the allowed senders in the registry contract
+	RegistrySlot uint64 `mapstructure:"RegistrySlot"`
+}
+
 // EffectiveGasPriceCfg contains the configuration properties for the effective gas price
 type EffectiveGasPriceCfg struct {
 	// Enabled is a flag to enable/disable the effective gas price
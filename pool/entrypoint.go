@@ -0,0 +1,120 @@
+package pool
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"strings"
+	"time"
+
+	"github.com/0xPolygonHermez/zkevm-node/hex"
+	"github.com/0xPolygonHermez/zkevm-node/state"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// entryPointABIJSON contains the parts of the ABI of the EIP-4337 entry point (v0.6) used by the pool
+const entryPointABIJSON = `[
+	{"type":"function","name":"simulateValidation","stateMutability":"nonpayable","outputs":[],"inputs":[
+		{"name":"userOp","type":"tuple","components":[
+			{"name":"sender","type":"address"},{"name":"nonce","type":"uint256"},{"name":"initCode","type":"bytes"},
+			{"name":"callData","type":"bytes"},{"name":"callGasLimit","type":"uint256"},{"name":"verificationGasLimit","type":"uint256"},
+			{"name":"preVerificationGas","type":"uint256"},{"name":"maxFeePerGas","type":"uint256"},{"name":"maxPriorityFeePerGas","type":"uint256"},
+			{"name":"paymasterAndData","type":"bytes"},{"name":"signature","type":"bytes"}]}]},
+	{"type":"error","name":"ValidationResult","inputs":[
+		{"name":"returnInfo","type":"tuple","components":[
+			{"name":"preOpGas","type":"uint256"},{"name":"prefund","type":"uint256"},{"name":"sigFailed","type":"bool"},
+			{"name":"validAfter","type":"uint48"},{"name":"validUntil","type":"uint48"},{"name":"paymasterContext","type":"bytes"}]},
+		{"name":"senderInfo","type":"tuple","components":[{"name":"stake","type":"uint256"},{"name":"unstakeDelaySec","type":"uint256"}]},
+		{"name":"factoryInfo","type":"tuple","components":[{"name":"stake","type":"uint256"},{"name":"unstakeDelaySec","type":"uint256"}]},
+		{"name":"paymasterInfo","type":"tuple","components":[{"name":"stake","type":"uint256"},{"name":"unstakeDelaySec","type":"uint256"}]}]},
+	{"type":"error","name":"FailedOp","inputs":[{"name":"opIndex","type":"uint256"},{"name":"reason","type":"string"}]},
+	{"type":"event","name":"UserOperationEvent","anonymous":false,"inputs":[
+		{"name":"userOpHash","type":"bytes32","indexed":true},{"name":"sender","type":"address","indexed":true},
+		{"name":"paymaster","type":"address","indexed":true},{"name":"nonce","type":"uint256","indexed":false},
+		{"name":"success","type":"bool","indexed":false},{"name":"actualGasCost","type":"uint256","indexed":false},
+		{"name":"actualGasUsed","type":"uint256","indexed":false}]}
+]`
+
+var (
+	entryPointABI = func() abi.ABI {
+		parsed, err := abi.JSON(strings.NewReader(entryPointABIJSON))
+		if err != nil {
+			panic(err)
+		}
+		return parsed
+	}()
+	validationResultError = entryPointABI.Errors["ValidationResult"]
+	failedOpError         = entryPointABI.Errors["FailedOp"]
+	userOperationEventID  = entryPointABI.Events["UserOperationEvent"].ID
+)
+
+// validationReturnInfo is the result of the validation of a user operation returned by the
+// entry point in the ValidationResult revert of simulateValidation
+type validationReturnInfo struct {
+	PreOpGas         *big.Int
+	Prefund          *big.Int
+	SigFailed        bool
+	ValidAfter       *big.Int
+	ValidUntil       *big.Int
+	PaymasterContext []byte
+}
+
+// simulateUserOperation calls simulateValidation of the entry point with the user operation on top of the last
+// L2 block. The call always reverts, with ValidationResult when the validation of the account (and of the
+// paymaster, if any) succeeds or with FailedOp and the reason of the failure otherwise
+func (p *Pool) simulateUserOperation(ctx context.Context, userOp UserOperation) error {
+	data, err := entryPointABI.Pack("simulateValidation", userOp.UserOp.withDefaults())
+	if err != nil {
+		return err
+	}
+	tx := types.NewTx(&types.LegacyTx{
+		To:       &userOp.EntryPoint,
+		Gas:      state.MaxTxGasLimit,
+		GasPrice: big.NewInt(0),
+		Data:     data,
+	})
+	result, err := p.state.ProcessUnsignedTransaction(ctx, tx, common.Address{}, nil, true, nil, nil)
+	if err != nil {
+		return err
+	}
+	if result.Succeeded() {
+		return fmt.Errorf("%w: simulateValidation didn't revert", ErrUserOpSimulationFailed)
+	} else if !result.Reverted() {
+		return fmt.Errorf("%w: %v", ErrUserOpSimulationFailed, result.Err)
+	}
+	return checkValidationResult(result.ReturnValue, time.Now())
+}
+
+// checkValidationResult decodes the revert data of simulateValidation and checks the user operation
+// is signed by its sender and it's valid at the provided time
+func checkValidationResult(revertData []byte, now time.Time) error {
+	if out, err := failedOpError.Unpack(revertData); err == nil {
+		return fmt.Errorf("%w: %v", ErrUserOpSimulationFailed, out.([]interface{})[1])
+	}
+	out, err := validationResultError.Unpack(revertData)
+	if err != nil {
+		return fmt.Errorf("%w: unexpected revert data %s", ErrUserOpSimulationFailed, hex.EncodeToHex(revertData))
+	}
+	returnInfo := abi.ConvertType(out.([]interface{})[0], new(validationReturnInfo)).(*validationReturnInfo)
+	if returnInfo.SigFailed {
+		return ErrUserOpInvalidSignature
+	}
+	nowUnix := big.NewInt(now.Unix())
+	if returnInfo.ValidAfter.Cmp(nowUnix) > 0 || (returnInfo.ValidUntil.Sign() > 0 && returnInfo.ValidUntil.Cmp(nowUnix) <= 0) {
+		return ErrUserOpOutOfTimeRange
+	}
+	return nil
+}
+
+// withDefaults returns a copy of the user operation with the nil values set to zero, so it can be packed
+func (op UserOp) withDefaults() UserOp {
+	op.Nonce = bigOrZero(op.Nonce)
+	op.CallGasLimit = bigOrZero(op.CallGasLimit)
+	op.VerificationGasLimit = bigOrZero(op.VerificationGasLimit)
+	op.PreVerificationGas = bigOrZero(op.PreVerificationGas)
+	op.MaxFeePerGas = bigOrZero(op.MaxFeePerGas)
+	op.MaxPriorityFeePerGas = bigOrZero(op.MaxPriorityFeePerGas)
+	return op
+}
@@ -30,10 +30,53 @@ var (
 	// ErrBlockedSender is returned if the transaction is sent by a blocked account.
 	ErrBlockedSender = errors.New("blocked sender")
 
+	// ErrBlockedRecipient is returned if the transaction is sent to a blocked account.
+	ErrBlockedRecipient = errors.New("blocked recipient")
+
+	// ErrSenderNotAllowed is returned if the allow-list mode is enabled and the transaction
+	// is sent by an account that is not allowed.
+	ErrSenderNotAllowed = errors.New("sender not allowed")
+
 	// ErrGasLimit is returned if a transaction's requested gas limit exceeds the
 	// maximum allowance of the current block.
 	ErrGasLimit = errors.New("exceeds block gas limit")
 
+	// ErrTxFeeCapExceeded is returned if the fee of a transaction (gasPrice * gas)
+	// exceeds the configured tx fee cap.
+	ErrTxFeeCapExceeded = errors.New("tx fee exceeds the configured cap")
+
+	// ErrTxRateLimitExceeded is returned if the IP or the account sending the transaction
+	// has exceeded the rate of transactions allowed by the config
+	ErrTxRateLimitExceeded = errors.New("tx rate limit exceeded")
+
+	// ErrScheduledTxsDisabled is returned if a scheduled transaction is sent to a pool that
+	// doesn't accept scheduled transactions
+	ErrScheduledTxsDisabled = errors.New("scheduled txs are disabled")
+
+	// ErrScheduleTooFarAhead is returned if the schedule of a transaction is beyond the max
+	// delay or the max number of blocks ahead allowed by the config
+	ErrScheduleTooFarAhead = errors.New("tx schedule is too far ahead")
+
+	// ErrConditionalTxsDisabled is returned if a conditional transaction is sent to a pool that
+	// doesn't accept conditional transactions
+	ErrConditionalTxsDisabled = errors.New("conditional txs are disabled")
+
+	// ErrTooManyKnownStorageSlots is returned if the known accounts of a conditional transaction
+	// check more storage slots than the max allowed by the config
+	ErrTooManyKnownStorageSlots = errors.New("too many known storage slots")
+
+	// ErrBlockNumberConditionNotMet is returned if the current L2 block number is out of the
+	// block number range of a conditional transaction
+	ErrBlockNumberConditionNotMet = errors.New("block number condition not met")
+
+	// ErrTimestampConditionNotMet is returned if the current timestamp is out of the timestamp
+	// range of a conditional transaction
+	ErrTimestampConditionNotMet = errors.New("timestamp condition not met")
+
+	// ErrKnownAccountsConditionNotMet is returned if a storage value of the known accounts of a
+	// conditional transaction doesn't match the current one
+	ErrKnownAccountsConditionNotMet = errors.New("known accounts condition not met")
+
 	// ErrTxPoolAccountOverflow is returned if the account sending the transaction
 	// has already reached the limit of transactions in the pool set by the config
 	// AccountQueue and can't accept another remote transaction.
@@ -76,4 +119,68 @@ var (
 
 	// ErrZeroL1GasPrice is returned if the L1 gas price is 0.
 	ErrZeroL1GasPrice = errors.New("L1 gas price 0")
+
+	// ErrUserOperationsDisabled is returned if a user operation is sent to a pool that
+	// doesn't accept user operations
+	ErrUserOperationsDisabled = errors.New("user operations are disabled")
+
+	// ErrUnsupportedEntryPoint is returned if the entry point of a user operation is not
+	// one of the entry points configured
+	ErrUnsupportedEntryPoint = errors.New("unsupported entry point")
+
+	// ErrUserOpVerificationGasLimit is returned if the verification gas limit of a user
+	// operation is higher than the max allowed by the config
+	ErrUserOpVerificationGasLimit = errors.New("user operation verification gas limit too high")
+
+	// ErrUserOpInvalidFees is returned if the max priority fee per gas of a user operation
+	// is higher than its max fee per gas
+	ErrUserOpInvalidFees = errors.New("user operation max priority fee per gas higher than max fee per gas")
+
+	// ErrUserOpMissingSignature is returned if a user operation is not signed
+	ErrUserOpMissingSignature = errors.New("user operation signature is missing")
+
+	// ErrUserOpSimulationFailed is returned if the validation of a user operation simulated
+	// with simulateValidation of the entry point fails
+	ErrUserOpSimulationFailed = errors.New("user operation simulation failed")
+
+	// ErrUserOpInvalidSignature is returned if the account of the sender of a user operation
+	// doesn't accept its signature
+	ErrUserOpInvalidSignature = errors.New("user operation signature is invalid")
+
+	// ErrUserOpOutOfTimeRange is returned if a user operation is not valid at the current time
+	// according to the validity time range returned by its account or paymaster
+	ErrUserOpOutOfTimeRange = errors.New("user operation is out of its validity time range")
+
+	// ErrUserOpSenderNotDeployed is returned if the sender of a user operation has no code
+	// and the user operation has no init code to deploy it
+	ErrUserOpSenderNotDeployed = errors.New("user operation sender not deployed and init code is missing")
+
+	// ErrUserOpSenderAlreadyDeployed is returned if a user operation has init code but its
+	// sender is already deployed
+	ErrUserOpSenderAlreadyDeployed = errors.New("user operation sender already deployed")
+
+	// ErrUserOpAccountOverflow is returned if the sender of a user operation has already
+	// reached the limit of pending user operations set by the config
+	ErrUserOpAccountOverflow = errors.New("sender has reached the user operations limit in the pool")
 )
+
+// rejectionReasons are the errors returned by the pool when rejecting a tx that are
+// used as reason of the rejections in the metrics
+var rejectionReasons = []error{
+	ErrInvalidChainID, ErrTxTypeNotSupported, ErrOversizedData, ErrNegativeValue, ErrInvalidSender,
+	ErrBlockedSender, ErrBlockedRecipient, ErrSenderNotAllowed, ErrGasLimit, ErrTxFeeCapExceeded, ErrTxRateLimitExceeded,
+	ErrScheduledTxsDisabled, ErrScheduleTooFarAhead, ErrTxPoolAccountOverflow, ErrTxPoolOverflow,
+	ErrNonceTooLow, ErrNonceTooHigh, ErrInsufficientFunds, ErrIntrinsicGas, ErrGasUintOverflow, ErrGasPrice, ErrInvalidIP, ErrOutOfCounters, ErrAlreadyKnown,
+	ErrReplaceUnderpriced, ErrEffectiveGasPriceGasPriceTooLow,
+}
+
+// rejectionReason returns the reason of the rejection of a tx to be used in the metrics,
+// the errors not known are grouped as "other" to keep the cardinality of the metric bounded
+func rejectionReason(err error) string {
+	for _, reason := range rejectionReasons {
+		if errors.Is(err, reason) {
+			return reason.Error()
+		}
+	}
+	return "other"
+}
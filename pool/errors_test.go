@@ -0,0 +1,15 @@
+package pool
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_rejectionReason(t *testing.T) {
+	assert.Equal(t, ErrNonceTooLow.Error(), rejectionReason(ErrNonceTooLow))
+	assert.Equal(t, ErrOutOfCounters.Error(), rejectionReason(fmt.Errorf("failed to add tx to the pool: %w", ErrOutOfCounters)))
+	assert.Equal(t, "other", rejectionReason(errors.New("connection refused")))
+}
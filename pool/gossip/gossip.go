@@ -0,0 +1,167 @@
+package gossip
+
+import (
+	"context"
+	"sync"
+
+	"github.com/0xPolygonHermez/zkevm-node/hex"
+	"github.com/0xPolygonHermez/zkevm-node/jsonrpc/client"
+	"github.com/0xPolygonHermez/zkevm-node/jsonrpc/types"
+	"github.com/0xPolygonHermez/zkevm-node/log"
+	"github.com/0xPolygonHermez/zkevm-node/pool"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// Gossiper propagates the txs added to the pool to the configured peers, so the txs
+// received by any RPC node of a cluster reach the pool of the trusted sequencer
+type Gossiper struct {
+	cfg   pool.GossipCfg
+	peers []*peer
+	known *knownTxs
+}
+
+// peer keeps the txs waiting to be propagated to a peer, each peer has its own queue
+// so a slow or unreachable peer doesn't delay the propagation to the others
+type peer struct {
+	url string
+	txs chan encodedTx
+}
+
+// encodedTx is a tx encoded to be sent with eth_sendRawTransaction, or with
+// eth_sendRawTransactionConditional when the tx has conditions
+type encodedTx struct {
+	hash       common.Hash
+	rawTx      string
+	conditions *types.TxConditionsArgs
+}
+
+// New creates a new Gossiper
+func New(cfg pool.GossipCfg) *Gossiper {
+	peers := make([]*peer, 0, len(cfg.Peers))
+	for _, url := range cfg.Peers {
+		peers = append(peers, &peer{url: url, txs: make(chan encodedTx, cfg.QueueSize)})
+	}
+	return &Gossiper{
+		cfg:   cfg,
+		peers: peers,
+		known: newKnownTxs(cfg.MaxKnownTxs),
+	}
+}
+
+// HandleNewTx queues the provided tx to be propagated to the peers, it's registered
+// in the pool as a pool.NewTxEventHandler. The txs already propagated are ignored and
+// the conditional txs are propagated with their conditions
+func (g *Gossiper) HandleNewTx(tx pool.Transaction) {
+	if !g.known.add(tx.Hash()) {
+		return
+	}
+
+	b, err := tx.MarshalBinary()
+	if err != nil {
+		log.Errorf("failed to encode tx %s to propagate it, error: %v", tx.Hash().String(), err)
+		return
+	}
+	encoded := encodedTx{hash: tx.Hash(), rawTx: hex.EncodeToHex(b)}
+	if !tx.Conditions.IsEmpty() {
+		conditions, err := types.NewTxConditionsArgs(tx.Conditions)
+		if err != nil {
+			log.Errorf("failed to encode the conditions of tx %s to propagate it, error: %v", tx.Hash().String(), err)
+			return
+		}
+		encoded.conditions = &conditions
+	}
+
+	for _, p := range g.peers {
+		select {
+		case p.txs <- encoded:
+		default:
+			log.Warnf("gossip queue of peer %s is full, tx %s will not be propagated to it", p.url, tx.Hash().String())
+		}
+	}
+}
+
+// Start propagates the queued txs to each peer until the context is done
+func (g *Gossiper) Start(ctx context.Context) {
+	log.Infof("propagating the pool txs to %d peers", len(g.peers))
+	var wg sync.WaitGroup
+	for _, p := range g.peers {
+		wg.Add(1)
+		go func(p *peer) {
+			defer wg.Done()
+			for {
+				select {
+				case <-ctx.Done():
+					return
+				case tx := <-p.txs:
+					g.propagate(ctx, p.url, tx)
+				}
+			}
+		}(p)
+	}
+	wg.Wait()
+}
+
+// propagate sends the tx to the peer, waiting for it up to the configured timeout. A peer
+// that already has the tx doesn't propagate it again, as it's rejected by its pool as already known
+func (g *Gossiper) propagate(ctx context.Context, url string, tx encodedTx) {
+	if g.cfg.Timeout.Duration > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, g.cfg.Timeout.Duration)
+		defer cancel()
+	}
+
+	var (
+		res types.Response
+		err error
+	)
+	if tx.conditions != nil {
+		res, err = client.JSONRPCCallWithContext(ctx, url, "eth_sendRawTransactionConditional", tx.rawTx, *tx.conditions)
+	} else {
+		res, err = client.JSONRPCCallWithContext(ctx, url, "eth_sendRawTransaction", tx.rawTx)
+	}
+	if err != nil {
+		log.Warnf("failed to propagate tx %s to peer %s, error: %v", tx.hash.String(), url, err)
+		return
+	}
+	if res.Error != nil && res.Error.Message != pool.ErrAlreadyKnown.Error() {
+		log.Warnf("tx %s rejected by peer %s, error: %s", tx.hash.String(), url, res.Error.Message)
+		return
+	}
+	log.Debugf("tx %s propagated to peer %s", tx.hash.String(), url)
+}
+
+// knownTxs keeps the hashes of the last propagated txs, forgetting the oldest
+// ones when the max size is reached
+type knownTxs struct {
+	mu     sync.Mutex
+	max    uint64
+	hashes map[common.Hash]struct{}
+	order  []common.Hash
+}
+
+func newKnownTxs(max uint64) *knownTxs {
+	return &knownTxs{
+		max:    max,
+		hashes: make(map[common.Hash]struct{}),
+	}
+}
+
+// add returns false if the hash was already known
+func (k *knownTxs) add(hash common.Hash) bool {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+
+	if _, found := k.hashes[hash]; found {
+		return false
+	}
+	if k.max == 0 {
+		return true
+	}
+	if uint64(len(k.order)) >= k.max {
+		delete(k.hashes, k.order[0])
+		k.order = k.order[1:]
+	}
+	k.hashes[hash] = struct{}{}
+	k.order = append(k.order, hash)
+	return true
+}
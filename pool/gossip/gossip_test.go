@@ -0,0 +1,174 @@
+package gossip
+
+import (
+	"context"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	cfgTypes "github.com/0xPolygonHermez/zkevm-node/config/types"
+	"github.com/0xPolygonHermez/zkevm-node/hex"
+	jsonrpcTypes "github.com/0xPolygonHermez/zkevm-node/jsonrpc/types"
+	"github.com/0xPolygonHermez/zkevm-node/pool"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// peerStub is a JSON RPC server that keeps the raw txs received by eth_sendRawTransaction
+// and the conditions of the txs received by eth_sendRawTransactionConditional
+type peerStub struct {
+	mu         sync.Mutex
+	rawTxs     []string
+	conditions map[string]jsonrpcTypes.TxConditionsArgs
+	server     *httptest.Server
+}
+
+func newPeerStub(t *testing.T) *peerStub {
+	p := &peerStub{conditions: map[string]jsonrpcTypes.TxConditionsArgs{}}
+	p.server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req jsonrpcTypes.Request
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+
+		var params []json.RawMessage
+		require.NoError(t, json.Unmarshal(req.Params, &params))
+		var rawTx string
+		require.NoError(t, json.Unmarshal(params[0], &rawTx))
+		p.mu.Lock()
+		p.rawTxs = append(p.rawTxs, rawTx)
+		switch req.Method {
+		case "eth_sendRawTransaction":
+			assert.Len(t, params, 1)
+		case "eth_sendRawTransactionConditional":
+			require.Len(t, params, 2)
+			var conditions jsonrpcTypes.TxConditionsArgs
+			require.NoError(t, json.Unmarshal(params[1], &conditions))
+			p.conditions[rawTx] = conditions
+		default:
+			t.Errorf("unexpected method %s", req.Method)
+		}
+		p.mu.Unlock()
+
+		reply, err := json.Marshal(common.Hash{}.Hex())
+		require.NoError(t, err)
+		res, err := jsonrpcTypes.NewResponse(req, reply, nil).Bytes()
+		require.NoError(t, err)
+		_, err = w.Write(res)
+		require.NoError(t, err)
+	}))
+	return p
+}
+
+func (p *peerStub) received() []string {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return append([]string{}, p.rawTxs...)
+}
+
+func TestGossiper(t *testing.T) {
+	peer1 := newPeerStub(t)
+	defer peer1.server.Close()
+	peer2 := newPeerStub(t)
+	defer peer2.server.Close()
+
+	g := New(pool.GossipCfg{Enabled: true, Peers: []string{peer1.server.URL, peer2.server.URL}, QueueSize: 10, MaxKnownTxs: 10})
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go g.Start(ctx)
+
+	tx := types.NewTransaction(1, common.HexToAddress("0x1"), big.NewInt(1), 21000, big.NewInt(1), nil)
+	b, err := tx.MarshalBinary()
+	require.NoError(t, err)
+	rawTx := hex.EncodeToHex(b)
+
+	// the same tx is propagated only once
+	g.HandleNewTx(*pool.NewTransaction(*tx, "", false))
+	g.HandleNewTx(*pool.NewTransaction(*tx, "", false))
+
+	for _, peer := range []*peerStub{peer1, peer2} {
+		require.Eventually(t, func() bool { return len(peer.received()) > 0 }, time.Second, 10*time.Millisecond)
+	}
+	time.Sleep(50 * time.Millisecond)
+	assert.Equal(t, []string{rawTx}, peer1.received())
+	assert.Equal(t, []string{rawTx}, peer2.received())
+}
+
+func TestGossiperConditionalTx(t *testing.T) {
+	peer := newPeerStub(t)
+	defer peer.server.Close()
+
+	g := New(pool.GossipCfg{Enabled: true, Peers: []string{peer.server.URL}, QueueSize: 10, MaxKnownTxs: 10})
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go g.Start(ctx)
+
+	tx := types.NewTransaction(1, common.HexToAddress("0x1"), big.NewInt(1), 21000, big.NewInt(1), nil)
+	b, err := tx.MarshalBinary()
+	require.NoError(t, err)
+	rawTx := hex.EncodeToHex(b)
+
+	conditions := pool.TxConditions{
+		KnownAccounts:  map[common.Address]map[common.Hash]common.Hash{common.HexToAddress("0x2"): {common.HexToHash("0x3"): common.HexToHash("0x4")}},
+		BlockNumberMin: 10,
+		TimestampMax:   1000,
+	}
+	poolTx := pool.NewTransaction(*tx, "", false)
+	poolTx.Conditions = conditions
+	g.HandleNewTx(*poolTx)
+
+	require.Eventually(t, func() bool { return len(peer.received()) > 0 }, time.Second, 10*time.Millisecond)
+	assert.Equal(t, []string{rawTx}, peer.received())
+	peer.mu.Lock()
+	args, found := peer.conditions[rawTx]
+	peer.mu.Unlock()
+	require.True(t, found)
+	received, err := args.ToTxConditions()
+	require.NoError(t, err)
+	assert.Equal(t, conditions, received)
+}
+
+func TestGossiperHungPeer(t *testing.T) {
+	peer := newPeerStub(t)
+	defer peer.server.Close()
+	release := make(chan struct{})
+	hungPeer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-release
+	}))
+	defer hungPeer.Close()
+	defer close(release)
+
+	g := New(pool.GossipCfg{Enabled: true, Peers: []string{hungPeer.URL, peer.server.URL}, QueueSize: 10, MaxKnownTxs: 10, Timeout: cfgTypes.NewDuration(time.Minute)})
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go g.Start(ctx)
+
+	// the txs are propagated to the peer while the hung peer doesn't reply to the first one
+	rawTxs := []string{}
+	for nonce := uint64(0); nonce < 3; nonce++ {
+		tx := types.NewTransaction(nonce, common.HexToAddress("0x1"), big.NewInt(1), 21000, big.NewInt(1), nil)
+		b, err := tx.MarshalBinary()
+		require.NoError(t, err)
+		rawTxs = append(rawTxs, hex.EncodeToHex(b))
+		g.HandleNewTx(*pool.NewTransaction(*tx, "", false))
+	}
+	require.Eventually(t, func() bool { return len(peer.received()) == len(rawTxs) }, time.Second, 10*time.Millisecond)
+	assert.Equal(t, rawTxs, peer.received())
+}
+
+func Test_knownTxs(t *testing.T) {
+	k := newKnownTxs(2)
+	h1, h2, h3 := common.HexToHash("0x1"), common.HexToHash("0x2"), common.HexToHash("0x3")
+
+	assert.True(t, k.add(h1))
+	assert.False(t, k.add(h1))
+	assert.True(t, k.add(h2))
+	// h1 is forgotten when h3 is added
+	assert.True(t, k.add(h3))
+	assert.True(t, k.add(h1))
+	assert.False(t, k.add(h3))
+}
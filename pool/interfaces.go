@@ -6,26 +6,34 @@ import (
 	"time"
 
 	"github.com/0xPolygonHermez/zkevm-node/state"
+	"github.com/0xPolygonHermez/zkevm-node/state/runtime"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/jackc/pgx/v4"
 )
 
-type storage interface {
+// Storage is the interface for the pool storage
+type Storage interface {
 	AddTx(ctx context.Context, tx Transaction) error
 	CountTransactionsByStatus(ctx context.Context, status ...TxStatus) (uint64, error)
 	CountTransactionsByFromAndStatus(ctx context.Context, from common.Address, status ...TxStatus) (uint64, error)
 	DeleteTransactionsByHashes(ctx context.Context, hashes []common.Hash) error
 	GetGasPrices(ctx context.Context) (uint64, uint64, error)
 	GetNonce(ctx context.Context, address common.Address) (uint64, error)
+	GetNoncesByFrom(ctx context.Context, from common.Address) ([]uint64, error)
 	GetPendingTxHashesSince(ctx context.Context, since time.Time) ([]common.Hash, error)
 	GetTxsByFromAndNonce(ctx context.Context, from common.Address, nonce uint64) ([]Transaction, error)
+	GetNonWIPTxsByFromWithNonceLowerThan(ctx context.Context, from common.Address, nonce uint64) ([]Transaction, error)
 	GetTxsByStatus(ctx context.Context, state TxStatus, limit uint64) ([]Transaction, error)
 	GetNonWIPPendingTxs(ctx context.Context) ([]Transaction, error)
+	GetQueuedTxs(ctx context.Context, limit uint64) ([]Transaction, error)
+	PromoteQueuedTxs(ctx context.Context, from common.Address, nonce uint64) error
 	IsTxPending(ctx context.Context, hash common.Hash) (bool, error)
 	SetGasPrices(ctx context.Context, l2GasPrice uint64, l1GasPrice uint64) error
 	DeleteGasPricesHistoryOlderThan(ctx context.Context, date time.Time) error
 	DeleteFailedTransactionsOlderThan(ctx context.Context, date time.Time) error
+	DeleteNonWIPTransactionsByStatusOlderThan(ctx context.Context, status TxStatus, date time.Time) (uint64, error)
+	GetLowestGasPriceNonWIPPendingTx(ctx context.Context) (*Transaction, error)
 	UpdateTxsStatus(ctx context.Context, updateInfo []TxStatusUpdateInfo) error
 	UpdateTxStatus(ctx context.Context, updateInfo TxStatusUpdateInfo) error
 	UpdateTxWIPStatus(ctx context.Context, hash common.Hash, isWIP bool) error
@@ -34,17 +42,34 @@ type storage interface {
 	GetTransactionByHash(ctx context.Context, hash common.Hash) (*Transaction, error)
 	GetTransactionByL2Hash(ctx context.Context, hash common.Hash) (*Transaction, error)
 	GetTxZkCountersByHash(ctx context.Context, hash common.Hash) (*state.ZKCounters, *state.ZKCounters, error)
+	GetTxStatusHistory(ctx context.Context, hash common.Hash) ([]TxStatusChange, error)
 	DeleteTransactionByHash(ctx context.Context, hash common.Hash) error
 	MarkWIPTxsAsPending(ctx context.Context) error
 	GetAllAddressesBlocked(ctx context.Context) ([]common.Address, error)
 	MinL2GasPriceSince(ctx context.Context, timestamp time.Time) (uint64, error)
 	GetEarliestProcessedTx(ctx context.Context) (common.Hash, error)
+	GetOldestPendingTxReceivedAt(ctx context.Context) (time.Time, error)
+	Ping(ctx context.Context) error
+	AddUserOperation(ctx context.Context, userOp UserOperation) error
+	GetUserOperationByHash(ctx context.Context, hash common.Hash) (*UserOperation, error)
+	GetPendingUserOperations(ctx context.Context, limit uint64) ([]UserOperation, error)
+	GetPendingUserOperationsBySender(ctx context.Context, sender common.Address) ([]UserOperation, error)
+	GetBundledUserOperations(ctx context.Context) ([]UserOperation, error)
+	UpdateUserOperationsStatus(ctx context.Context, hashes []common.Hash, status UserOperationStatus, bundleTxHash *common.Hash, failedReason *string) error
+	UpdatePendingBlock(ctx context.Context, block PendingBlock) error
+	GetPendingBlock(ctx context.Context) (*PendingBlock, error)
 }
 
 type stateInterface interface {
 	GetBalance(ctx context.Context, address common.Address, root common.Hash) (*big.Int, error)
+	GetCode(ctx context.Context, address common.Address, root common.Hash) ([]byte, error)
 	GetLastL2Block(ctx context.Context, dbTx pgx.Tx) (*state.L2Block, error)
 	GetNonce(ctx context.Context, address common.Address, root common.Hash) (uint64, error)
+	GetStorageAt(ctx context.Context, address common.Address, position *big.Int, root common.Hash) (*big.Int, error)
 	GetTransactionByHash(ctx context.Context, transactionHash common.Hash, dbTx pgx.Tx) (*types.Transaction, error)
+	GetTransactionReceipt(ctx context.Context, transactionHash common.Hash, dbTx pgx.Tx) (*types.Receipt, error)
 	PreProcessTransaction(ctx context.Context, tx *types.Transaction, dbTx pgx.Tx) (*state.ProcessBatchResponse, error)
+	ProcessUnsignedTransaction(ctx context.Context, tx *types.Transaction, senderAddress common.Address, l2BlockNumber *uint64, noZKEVMCounters bool, stateOverride state.StateOverride, dbTx pgx.Tx) (*runtime.ExecutionResult, error)
+	RegisterNewL2BlockEventHandler(h state.NewL2BlockEventHandler)
+	StartToMonitorNewL2Blocks()
 }
@@ -0,0 +1,690 @@
+package memorypoolstorage
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/0xPolygonHermez/zkevm-node/pool"
+	"github.com/0xPolygonHermez/zkevm-node/state"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// memoryTx is a pool tx stored in memory together with its sender and the history of its status
+type memoryTx struct {
+	tx      pool.Transaction
+	from    common.Address
+	history []pool.TxStatusChange
+}
+
+// gasPrice is an entry of the gas prices history
+type gasPrice struct {
+	l2GasPrice uint64
+	l1GasPrice uint64
+	timestamp  time.Time
+}
+
+// MemoryPoolStorage is an implementation of the pool storage interface that keeps the data in memory.
+// The data is lost when the node stops, so it's intended for tests and local devnets where all the
+// components run in the same process and share the same pool instance
+type MemoryPoolStorage struct {
+	txs              map[common.Hash]*memoryTx
+	userOps          map[common.Hash]pool.UserOperation
+	gasPrices        []gasPrice
+	blockedAddresses []common.Address
+	pendingBlock     *pool.PendingBlock
+	mu               sync.RWMutex
+}
+
+// NewMemoryPoolStorage creates and initializes an instance of MemoryPoolStorage
+func NewMemoryPoolStorage() *MemoryPoolStorage {
+	return &MemoryPoolStorage{
+		txs:     make(map[common.Hash]*memoryTx),
+		userOps: make(map[common.Hash]pool.UserOperation),
+	}
+}
+
+// filterTxs returns the stored txs that match the filter
+func (p *MemoryPoolStorage) filterTxs(filter func(mtx *memoryTx) bool) []*memoryTx {
+	txs := make([]*memoryTx, 0)
+	for _, mtx := range p.txs {
+		if filter(mtx) {
+			txs = append(txs, mtx)
+		}
+	}
+	return txs
+}
+
+// hasStatus returns true if the status of the tx is any of the provided statuses
+func hasStatus(tx pool.Transaction, status ...pool.TxStatus) bool {
+	for _, s := range status {
+		if tx.Status == s {
+			return true
+		}
+	}
+	return false
+}
+
+// AddTx adds a transaction to the pool table with the provided status
+func (p *MemoryPoolStorage) AddTx(ctx context.Context, tx pool.Transaction) error {
+	from, err := state.GetSender(tx.Transaction)
+	if err != nil {
+		return err
+	}
+
+	tx.FailedReason = nil
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	var history []pool.TxStatusChange
+	if mtx, found := p.txs[tx.Hash()]; found {
+		history = mtx.history
+	}
+	history = append(history, pool.TxStatusChange{Status: tx.Status, ChangedAt: time.Now().UTC()})
+	p.txs[tx.Hash()] = &memoryTx{tx: tx, from: from, history: history}
+	return nil
+}
+
+// GetTxsByStatus returns an array of transactions filtered by status sorted by gas price (higher first).
+// limit parameter is used to limit amount txs, 0 means no limit
+func (p *MemoryPoolStorage) GetTxsByStatus(ctx context.Context, status pool.TxStatus, limit uint64) ([]pool.Transaction, error) {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	mtxs := p.filterTxs(func(mtx *memoryTx) bool { return mtx.tx.Status == status })
+	sort.SliceStable(mtxs, func(i, j int) bool { return mtxs[i].tx.GasPrice().Cmp(mtxs[j].tx.GasPrice()) > 0 })
+	if limit > 0 && uint64(len(mtxs)) > limit {
+		mtxs = mtxs[:limit]
+	}
+
+	txs := make([]pool.Transaction, 0, len(mtxs))
+	for _, mtx := range mtxs {
+		txs = append(txs, mtx.tx)
+	}
+	return txs, nil
+}
+
+// GetNonWIPPendingTxs returns the pending txs that are not WIP nor queued
+func (p *MemoryPoolStorage) GetNonWIPPendingTxs(ctx context.Context) ([]pool.Transaction, error) {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	txs := make([]pool.Transaction, 0)
+	for _, mtx := range p.filterTxs(func(mtx *memoryTx) bool {
+		return !mtx.tx.IsWIP && !mtx.tx.Queued && mtx.tx.Status == pool.TxStatusPending
+	}) {
+		txs = append(txs, mtx.tx)
+	}
+	return txs, nil
+}
+
+// GetPendingTxHashesSince returns the pending tx since the given time.
+func (p *MemoryPoolStorage) GetPendingTxHashesSince(ctx context.Context, since time.Time) ([]common.Hash, error) {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	hashes := make([]common.Hash, 0)
+	for _, mtx := range p.filterTxs(func(mtx *memoryTx) bool {
+		return mtx.tx.Status == pool.TxStatusPending && !mtx.tx.ReceivedAt.Before(since)
+	}) {
+		hashes = append(hashes, mtx.tx.Hash())
+	}
+	return hashes, nil
+}
+
+// GetTxs gets txs with the lowest nonce
+func (p *MemoryPoolStorage) GetTxs(ctx context.Context, filterStatus pool.TxStatus, minGasPrice, limit uint64) ([]*pool.Transaction, error) {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	mtxs := p.filterTxs(func(mtx *memoryTx) bool {
+		return mtx.tx.Status == filterStatus && mtx.tx.GasPrice().Uint64() >= minGasPrice
+	})
+	sort.SliceStable(mtxs, func(i, j int) bool { return mtxs[i].tx.Nonce() < mtxs[j].tx.Nonce() })
+	if uint64(len(mtxs)) > limit {
+		mtxs = mtxs[:limit]
+	}
+
+	txs := make([]*pool.Transaction, 0, len(mtxs))
+	for _, mtx := range mtxs {
+		tx := mtx.tx
+		txs = append(txs, &tx)
+	}
+	return txs, nil
+}
+
+// CountTransactionsByStatus get number of transactions
+// accordingly to the provided statuses
+func (p *MemoryPoolStorage) CountTransactionsByStatus(ctx context.Context, status ...pool.TxStatus) (uint64, error) {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	return uint64(len(p.filterTxs(func(mtx *memoryTx) bool { return hasStatus(mtx.tx, status...) }))), nil
+}
+
+// CountTransactionsByFromAndStatus get number of transactions
+// accordingly to the from address and provided statuses
+func (p *MemoryPoolStorage) CountTransactionsByFromAndStatus(ctx context.Context, from common.Address, status ...pool.TxStatus) (uint64, error) {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	return uint64(len(p.filterTxs(func(mtx *memoryTx) bool { return mtx.from == from && hasStatus(mtx.tx, status...) }))), nil
+}
+
+// UpdateTxStatus updates a transaction status accordingly to the
+// provided status and hash
+func (p *MemoryPoolStorage) UpdateTxStatus(ctx context.Context, updateInfo pool.TxStatusUpdateInfo) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.updateTxStatus(updateInfo)
+	return nil
+}
+
+func (p *MemoryPoolStorage) updateTxStatus(updateInfo pool.TxStatusUpdateInfo) {
+	mtx, found := p.txs[updateInfo.Hash]
+	if !found {
+		return
+	}
+	change := pool.TxStatusChange{Status: updateInfo.NewStatus, ChangedAt: time.Now().UTC()}
+	mtx.tx.Status = updateInfo.NewStatus
+	mtx.tx.IsWIP = updateInfo.IsWIP
+	if updateInfo.FailedReason != nil {
+		failedReason := *updateInfo.FailedReason
+		mtx.tx.FailedReason = &failedReason
+		change.FailedReason = &failedReason
+	}
+	mtx.history = append(mtx.history, change)
+}
+
+// UpdateTxsStatus updates transactions status accordingly to the provided status and hashes
+func (p *MemoryPoolStorage) UpdateTxsStatus(ctx context.Context, updateInfos []pool.TxStatusUpdateInfo) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	for _, updateInfo := range updateInfos {
+		p.updateTxStatus(updateInfo)
+	}
+	return nil
+}
+
+// DeleteTransactionsByHashes deletes txs by their hashes
+func (p *MemoryPoolStorage) DeleteTransactionsByHashes(ctx context.Context, hashes []common.Hash) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	for _, hash := range hashes {
+		delete(p.txs, hash)
+	}
+	return nil
+}
+
+// DeleteFailedTransactionsOlderThan deletes all failed transactions older than the given date
+func (p *MemoryPoolStorage) DeleteFailedTransactionsOlderThan(ctx context.Context, date time.Time) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	for _, mtx := range p.filterTxs(func(mtx *memoryTx) bool {
+		return mtx.tx.Status == pool.TxStatusFailed && mtx.tx.ReceivedAt.Before(date)
+	}) {
+		delete(p.txs, mtx.tx.Hash())
+	}
+	return nil
+}
+
+// DeleteNonWIPTransactionsByStatusOlderThan deletes the txs with the given status that are not WIP nor local and were
+// received before the given date, it returns the number of deleted txs
+func (p *MemoryPoolStorage) DeleteNonWIPTransactionsByStatusOlderThan(ctx context.Context, status pool.TxStatus, date time.Time) (uint64, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	mtxs := p.filterTxs(func(mtx *memoryTx) bool {
+		return mtx.tx.Status == status && !mtx.tx.IsWIP && !mtx.tx.IsLocal && mtx.tx.ReceivedAt.Before(date)
+	})
+	for _, mtx := range mtxs {
+		delete(p.txs, mtx.tx.Hash())
+	}
+	return uint64(len(mtxs)), nil
+}
+
+// GetLowestGasPriceNonWIPPendingTx returns the pending tx with the lowest gas price that is not WIP nor local
+func (p *MemoryPoolStorage) GetLowestGasPriceNonWIPPendingTx(ctx context.Context) (*pool.Transaction, error) {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	var lowest *memoryTx
+	for _, mtx := range p.filterTxs(func(mtx *memoryTx) bool {
+		return !mtx.tx.IsWIP && !mtx.tx.IsLocal && mtx.tx.Status == pool.TxStatusPending
+	}) {
+		if lowest == nil {
+			lowest = mtx
+			continue
+		}
+		cmp := mtx.tx.GasPrice().Cmp(lowest.tx.GasPrice())
+		if cmp < 0 || (cmp == 0 && mtx.tx.ReceivedAt.After(lowest.tx.ReceivedAt)) {
+			lowest = mtx
+		}
+	}
+	if lowest == nil {
+		return nil, pool.ErrNotFound
+	}
+
+	tx := lowest.tx
+	return &tx, nil
+}
+
+// SetGasPrices sets the latest l2 and l1 gas prices
+func (p *MemoryPoolStorage) SetGasPrices(ctx context.Context, l2GasPrice, l1GasPrice uint64) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.gasPrices = append(p.gasPrices, gasPrice{l2GasPrice: l2GasPrice, l1GasPrice: l1GasPrice, timestamp: time.Now().UTC()})
+	return nil
+}
+
+// GetGasPrices returns the latest l2 and l1 gas prices
+func (p *MemoryPoolStorage) GetGasPrices(ctx context.Context) (uint64, uint64, error) {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	if len(p.gasPrices) == 0 {
+		return 0, 0, nil
+	}
+	last := p.gasPrices[len(p.gasPrices)-1]
+	return last.l2GasPrice, last.l1GasPrice, nil
+}
+
+// DeleteGasPricesHistoryOlderThan deletes all gas prices older than the given date except the last one
+func (p *MemoryPoolStorage) DeleteGasPricesHistoryOlderThan(ctx context.Context, date time.Time) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if len(p.gasPrices) == 0 {
+		return nil
+	}
+	gasPrices := make([]gasPrice, 0, len(p.gasPrices))
+	for i, gp := range p.gasPrices {
+		if !gp.timestamp.Before(date) || i == len(p.gasPrices)-1 {
+			gasPrices = append(gasPrices, gp)
+		}
+	}
+	p.gasPrices = gasPrices
+	return nil
+}
+
+// MinL2GasPriceSince returns the min L2 gas price after given timestamp
+func (p *MemoryPoolStorage) MinL2GasPriceSince(ctx context.Context, timestamp time.Time) (uint64, error) {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	minGasPrice := uint64(0)
+	for _, gp := range p.gasPrices {
+		if !gp.timestamp.Before(timestamp) && (minGasPrice == 0 || gp.l2GasPrice < minGasPrice) {
+			minGasPrice = gp.l2GasPrice
+		}
+	}
+	if minGasPrice == 0 {
+		return 0, state.ErrNotFound
+	}
+	return minGasPrice, nil
+}
+
+// IsTxPending determines if the tx associated to the given hash is pending or
+// not.
+func (p *MemoryPoolStorage) IsTxPending(ctx context.Context, hash common.Hash) (bool, error) {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	mtx, found := p.txs[hash]
+	return found && mtx.tx.Status == pool.TxStatusPending, nil
+}
+
+// GetTxsByFromAndNonce get all the transactions from the pool with the same from and nonce
+func (p *MemoryPoolStorage) GetTxsByFromAndNonce(ctx context.Context, from common.Address, nonce uint64) ([]pool.Transaction, error) {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	txs := make([]pool.Transaction, 0)
+	for _, mtx := range p.filterTxs(func(mtx *memoryTx) bool { return mtx.from == from && mtx.tx.Nonce() == nonce }) {
+		txs = append(txs, mtx.tx)
+	}
+	return txs, nil
+}
+
+// GetNonWIPTxsByFromWithNonceLowerThan returns the pending and scheduled txs of the sender that are not WIP
+// and whose nonce is lower than the provided one
+func (p *MemoryPoolStorage) GetNonWIPTxsByFromWithNonceLowerThan(ctx context.Context, from common.Address, nonce uint64) ([]pool.Transaction, error) {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	txs := make([]pool.Transaction, 0)
+	for _, mtx := range p.filterTxs(func(mtx *memoryTx) bool {
+		return mtx.from == from && mtx.tx.Nonce() < nonce && !mtx.tx.IsWIP && hasStatus(mtx.tx, pool.TxStatusPending, pool.TxStatusScheduled)
+	}) {
+		txs = append(txs, mtx.tx)
+	}
+	return txs, nil
+}
+
+// GetTxFromAddressFromByHash gets tx from address by hash
+func (p *MemoryPoolStorage) GetTxFromAddressFromByHash(ctx context.Context, hash common.Hash) (common.Address, uint64, error) {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	mtx, found := p.txs[hash]
+	if !found {
+		return common.Address{}, 0, pool.ErrNotFound
+	}
+	return mtx.from, mtx.tx.Nonce(), nil
+}
+
+// GetQueuedTxs returns the pending txs that are waiting for a nonce gap to be filled sorted by gas price (higher first).
+// If the limit is 0 all the txs are returned
+func (p *MemoryPoolStorage) GetQueuedTxs(ctx context.Context, limit uint64) ([]pool.Transaction, error) {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	mtxs := p.filterTxs(func(mtx *memoryTx) bool { return mtx.tx.Queued && mtx.tx.Status == pool.TxStatusPending })
+	sort.SliceStable(mtxs, func(i, j int) bool { return mtxs[i].tx.GasPrice().Cmp(mtxs[j].tx.GasPrice()) > 0 })
+	if limit > 0 && uint64(len(mtxs)) > limit {
+		mtxs = mtxs[:limit]
+	}
+
+	txs := make([]pool.Transaction, 0, len(mtxs))
+	for _, mtx := range mtxs {
+		txs = append(txs, mtx.tx)
+	}
+	return txs, nil
+}
+
+// PromoteQueuedTxs unsets the queued flag of the txs of the sender with a nonce lower than the provided one
+func (p *MemoryPoolStorage) PromoteQueuedTxs(ctx context.Context, from common.Address, nonce uint64) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	for _, mtx := range p.txs {
+		if mtx.from == from && mtx.tx.Nonce() < nonce {
+			mtx.tx.Queued = false
+		}
+	}
+	return nil
+}
+
+// GetNonce gets the nonce to the provided address accordingly to the txs in the pool
+func (p *MemoryPoolStorage) GetNonce(ctx context.Context, address common.Address) (uint64, error) {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	nonce := uint64(0)
+	for _, mtx := range p.filterTxs(func(mtx *memoryTx) bool {
+		return mtx.from == address && hasStatus(mtx.tx, pool.TxStatusPending, pool.TxStatusSelected)
+	}) {
+		if mtx.tx.Nonce()+1 > nonce {
+			nonce = mtx.tx.Nonce() + 1
+		}
+	}
+	return nonce, nil
+}
+
+// GetNoncesByFrom returns the nonces of the pending and selected txs of the sender sorted in ascending order
+func (p *MemoryPoolStorage) GetNoncesByFrom(ctx context.Context, from common.Address) ([]uint64, error) {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	nonces := make([]uint64, 0)
+	found := make(map[uint64]bool)
+	for _, mtx := range p.filterTxs(func(mtx *memoryTx) bool {
+		return mtx.from == from && hasStatus(mtx.tx, pool.TxStatusPending, pool.TxStatusSelected)
+	}) {
+		if !found[mtx.tx.Nonce()] {
+			found[mtx.tx.Nonce()] = true
+			nonces = append(nonces, mtx.tx.Nonce())
+		}
+	}
+	sort.Slice(nonces, func(i, j int) bool { return nonces[i] < nonces[j] })
+	return nonces, nil
+}
+
+// GetTransactionByHash gets a transaction in the pool by its hash
+func (p *MemoryPoolStorage) GetTransactionByHash(ctx context.Context, hash common.Hash) (*pool.Transaction, error) {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	mtx, found := p.txs[hash]
+	if !found {
+		return nil, pool.ErrNotFound
+	}
+	tx := mtx.tx
+	return &tx, nil
+}
+
+// GetTransactionByL2Hash gets a transaction in the pool by its l2 hash. As the l2 hash
+// is not stored when the tx is added to the pool, it always returns pool.ErrNotFound
+func (p *MemoryPoolStorage) GetTransactionByL2Hash(ctx context.Context, hash common.Hash) (*pool.Transaction, error) {
+	return nil, pool.ErrNotFound
+}
+
+// DeleteTransactionByHash deletes tx by its hash
+func (p *MemoryPoolStorage) DeleteTransactionByHash(ctx context.Context, hash common.Hash) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	delete(p.txs, hash)
+	return nil
+}
+
+// GetTxZkCountersByHash gets a transaction zkcounters by its hash
+func (p *MemoryPoolStorage) GetTxZkCountersByHash(ctx context.Context, hash common.Hash) (*state.ZKCounters, *state.ZKCounters, error) {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	mtx, found := p.txs[hash]
+	if !found {
+		return nil, nil, pool.ErrNotFound
+	}
+	usedZKCounters, reservedZKCounters := mtx.tx.ZKCounters, mtx.tx.ReservedZKCounters
+	return &usedZKCounters, &reservedZKCounters, nil
+}
+
+// GetTxStatusHistory gets the changes of the status of a transaction sorted from the oldest to the newest
+func (p *MemoryPoolStorage) GetTxStatusHistory(ctx context.Context, hash common.Hash) ([]pool.TxStatusChange, error) {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	mtx, found := p.txs[hash]
+	if !found {
+		return []pool.TxStatusChange{}, nil
+	}
+	return append([]pool.TxStatusChange{}, mtx.history...), nil
+}
+
+// MarkWIPTxsAsPending updates WIP status to non WIP
+func (p *MemoryPoolStorage) MarkWIPTxsAsPending(ctx context.Context) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	for _, mtx := range p.txs {
+		mtx.tx.IsWIP = false
+	}
+	return nil
+}
+
+// UpdateTxWIPStatus updates a transaction wip status accordingly to the
+// provided WIP status and hash
+func (p *MemoryPoolStorage) UpdateTxWIPStatus(ctx context.Context, hash common.Hash, isWIP bool) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if mtx, found := p.txs[hash]; found {
+		mtx.tx.IsWIP = isWIP
+	}
+	return nil
+}
+
+// GetAllAddressesBlocked get all addresses blocked
+func (p *MemoryPoolStorage) GetAllAddressesBlocked(ctx context.Context) ([]common.Address, error) {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	return append([]common.Address{}, p.blockedAddresses...), nil
+}
+
+// BlockAddress adds the address to the blocked addresses
+func (p *MemoryPoolStorage) BlockAddress(address common.Address) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.blockedAddresses = append(p.blockedAddresses, address)
+}
+
+// GetEarliestProcessedTx gets the earliest processed tx from the pool. Mainly used for cleanup
+func (p *MemoryPoolStorage) GetEarliestProcessedTx(ctx context.Context) (common.Hash, error) {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	var earliest *memoryTx
+	for _, mtx := range p.filterTxs(func(mtx *memoryTx) bool { return mtx.tx.Status == pool.TxStatusSelected }) {
+		if earliest == nil || mtx.tx.ReceivedAt.Before(earliest.tx.ReceivedAt) {
+			earliest = mtx
+		}
+	}
+	if earliest == nil {
+		return common.Hash{}, nil
+	}
+	return earliest.tx.Hash(), nil
+}
+
+// GetOldestPendingTxReceivedAt returns the time the oldest pending tx was received,
+// it returns pool.ErrNotFound if there are no pending txs
+func (p *MemoryPoolStorage) GetOldestPendingTxReceivedAt(ctx context.Context) (time.Time, error) {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	var oldest *memoryTx
+	for _, mtx := range p.filterTxs(func(mtx *memoryTx) bool { return mtx.tx.Status == pool.TxStatusPending }) {
+		if oldest == nil || mtx.tx.ReceivedAt.Before(oldest.tx.ReceivedAt) {
+			oldest = mtx
+		}
+	}
+	if oldest == nil {
+		return time.Time{}, pool.ErrNotFound
+	}
+	return oldest.tx.ReceivedAt, nil
+}
+
+// Ping always succeeds as the data is kept in memory
+func (p *MemoryPoolStorage) Ping(ctx context.Context) error {
+	return nil
+}
+
+// AddUserOperation adds a user operation to the pool with the provided status
+func (p *MemoryPoolStorage) AddUserOperation(ctx context.Context, userOp pool.UserOperation) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.userOps[userOp.Hash] = userOp
+	return nil
+}
+
+// GetUserOperationByHash returns the user operation with the provided hash,
+// it returns pool.ErrNotFound if the user operation is not in the pool
+func (p *MemoryPoolStorage) GetUserOperationByHash(ctx context.Context, hash common.Hash) (*pool.UserOperation, error) {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	userOp, found := p.userOps[hash]
+	if !found {
+		return nil, pool.ErrNotFound
+	}
+	return &userOp, nil
+}
+
+// GetPendingUserOperations returns the pending user operations sorted by the time they were received
+// (older first), if limit = 0 then there is no limit
+func (p *MemoryPoolStorage) GetPendingUserOperations(ctx context.Context, limit uint64) ([]pool.UserOperation, error) {
+	userOps := p.filterUserOps(func(userOp pool.UserOperation) bool {
+		return userOp.Status == pool.UserOperationStatusPending
+	})
+	sort.Slice(userOps, func(i, j int) bool { return userOps[i].ReceivedAt.Before(userOps[j].ReceivedAt) })
+	if limit > 0 && uint64(len(userOps)) > limit {
+		userOps = userOps[:limit]
+	}
+	return userOps, nil
+}
+
+// GetPendingUserOperationsBySender returns the pending user operations of the provided sender
+func (p *MemoryPoolStorage) GetPendingUserOperationsBySender(ctx context.Context, sender common.Address) ([]pool.UserOperation, error) {
+	return p.filterUserOps(func(userOp pool.UserOperation) bool {
+		return userOp.Status == pool.UserOperationStatusPending && userOp.Sender == sender
+	}), nil
+}
+
+// GetBundledUserOperations returns the user operations included in a bundle tx whose result is not known yet
+func (p *MemoryPoolStorage) GetBundledUserOperations(ctx context.Context) ([]pool.UserOperation, error) {
+	return p.filterUserOps(func(userOp pool.UserOperation) bool {
+		return userOp.Status == pool.UserOperationStatusBundled
+	}), nil
+}
+
+// UpdateUserOperationsStatus updates the status of the user operations with the provided hashes, the
+// bundle tx hash and the failed reason are only updated when they are provided
+func (p *MemoryPoolStorage) UpdateUserOperationsStatus(ctx context.Context, hashes []common.Hash, status pool.UserOperationStatus, bundleTxHash *common.Hash, failedReason *string) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	for _, hash := range hashes {
+		userOp, found := p.userOps[hash]
+		if !found {
+			continue
+		}
+		userOp.Status = status
+		if bundleTxHash != nil {
+			userOp.BundleTxHash = bundleTxHash
+		}
+		if failedReason != nil {
+			userOp.FailedReason = failedReason
+		}
+		p.userOps[hash] = userOp
+	}
+	return nil
+}
+
+// filterUserOps returns the stored user operations that match the filter
+func (p *MemoryPoolStorage) filterUserOps(filter func(userOp pool.UserOperation) bool) []pool.UserOperation {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	userOps := make([]pool.UserOperation, 0)
+	for _, userOp := range p.userOps {
+		if filter(userOp) {
+			userOps = append(userOps, userOp)
+		}
+	}
+	return userOps
+}
+
+// UpdatePendingBlock replaces the pending block processed by the sequencer
+func (p *MemoryPoolStorage) UpdatePendingBlock(ctx context.Context, block pool.PendingBlock) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	block.TxHashes = append([]common.Hash{}, block.TxHashes...)
+	p.pendingBlock = &block
+	return nil
+}
+
+// GetPendingBlock returns the pending block processed by the sequencer,
+// it returns pool.ErrNotFound if the sequencer hasn't processed any block yet
+func (p *MemoryPoolStorage) GetPendingBlock(ctx context.Context) (*pool.PendingBlock, error) {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	if p.pendingBlock == nil {
+		return nil, pool.ErrNotFound
+	}
+	block := *p.pendingBlock
+	block.TxHashes = append([]common.Hash{}, block.TxHashes...)
+	return &block, nil
+}
@@ -0,0 +1,196 @@
+package memorypoolstorage
+
+import (
+	"context"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/0xPolygonHermez/zkevm-node/pool"
+	"github.com/0xPolygonHermez/zkevm-node/state"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var _ pool.Storage = (*MemoryPoolStorage)(nil)
+
+func TestMemoryPoolStorage(t *testing.T) {
+	ctx := context.Background()
+	s := NewMemoryPoolStorage()
+
+	signer := types.NewEIP155Signer(big.NewInt(1000))
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	from := crypto.PubkeyToAddress(key.PublicKey)
+
+	now := time.Now()
+	newTx := func(nonce uint64, gasPrice int64, receivedAt time.Time) pool.Transaction {
+		tx := types.NewTransaction(nonce, common.Address{}, big.NewInt(0), 21000, big.NewInt(gasPrice), nil)
+		signedTx, err := types.SignTx(tx, signer, key)
+		require.NoError(t, err)
+		poolTx := pool.NewTransaction(*signedTx, "", false)
+		poolTx.ReceivedAt = receivedAt
+		return *poolTx
+	}
+
+	tx0 := newTx(0, 20, now.Add(-time.Hour))
+	tx1 := newTx(1, 10, now)
+	tx2 := newTx(2, 30, now)
+	for _, tx := range []pool.Transaction{tx0, tx1, tx2} {
+		require.NoError(t, s.AddTx(ctx, tx))
+	}
+
+	nonce, err := s.GetNonce(ctx, from)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(3), nonce)
+
+	txs, err := s.GetTxsByStatus(ctx, pool.TxStatusPending, 0)
+	require.NoError(t, err)
+	require.Len(t, txs, 3)
+	assert.Equal(t, tx2.Hash(), txs[0].Hash())
+	assert.Equal(t, tx0.Hash(), txs[1].Hash())
+	assert.Equal(t, tx1.Hash(), txs[2].Hash())
+
+	lowest, err := s.GetLowestGasPriceNonWIPPendingTx(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, tx1.Hash(), lowest.Hash())
+
+	receivedAt, err := s.GetOldestPendingTxReceivedAt(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, tx0.ReceivedAt, receivedAt)
+
+	failedReason := "reverted"
+	require.NoError(t, s.UpdateTxStatus(ctx, pool.TxStatusUpdateInfo{Hash: tx2.Hash(), NewStatus: pool.TxStatusFailed, FailedReason: &failedReason}))
+	tx, err := s.GetTransactionByHash(ctx, tx2.Hash())
+	require.NoError(t, err)
+	assert.Equal(t, pool.TxStatusFailed, tx.Status)
+	require.NotNil(t, tx.FailedReason)
+	assert.Equal(t, failedReason, *tx.FailedReason)
+
+	nonce, err = s.GetNonce(ctx, from)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(2), nonce)
+
+	history, err := s.GetTxStatusHistory(ctx, tx2.Hash())
+	require.NoError(t, err)
+	require.Len(t, history, 2)
+	assert.Equal(t, pool.TxStatusPending, history[0].Status)
+	assert.Nil(t, history[0].FailedReason)
+	assert.Equal(t, pool.TxStatusFailed, history[1].Status)
+	assert.Equal(t, failedReason, *history[1].FailedReason)
+
+	count, err := s.CountTransactionsByStatus(ctx, pool.TxStatusPending, pool.TxStatusFailed)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(3), count)
+
+	hashes, err := s.GetPendingTxHashesSince(ctx, now)
+	require.NoError(t, err)
+	assert.Equal(t, []common.Hash{tx1.Hash()}, hashes)
+
+	deleted, err := s.DeleteNonWIPTransactionsByStatusOlderThan(ctx, pool.TxStatusPending, now.Add(-time.Minute))
+	require.NoError(t, err)
+	assert.Equal(t, uint64(1), deleted)
+	_, err = s.GetTransactionByHash(ctx, tx0.Hash())
+	assert.ErrorIs(t, err, pool.ErrNotFound)
+
+	// local txs are never evicted
+	localTx := newTx(3, 1, now.Add(-time.Hour))
+	localTx.IsLocal = true
+	require.NoError(t, s.AddTx(ctx, localTx))
+	lowest, err = s.GetLowestGasPriceNonWIPPendingTx(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, tx1.Hash(), lowest.Hash())
+	deleted, err = s.DeleteNonWIPTransactionsByStatusOlderThan(ctx, pool.TxStatusPending, now.Add(-time.Minute))
+	require.NoError(t, err)
+	assert.Equal(t, uint64(0), deleted)
+
+	nonces, err := s.GetNoncesByFrom(ctx, from)
+	require.NoError(t, err)
+	assert.Equal(t, []uint64{1, 3}, nonces)
+
+	// nonce too low txs
+	txs, err = s.GetNonWIPTxsByFromWithNonceLowerThan(ctx, from, 3)
+	require.NoError(t, err)
+	require.Len(t, txs, 1)
+	assert.Equal(t, tx1.Hash(), txs[0].Hash())
+
+	// queued txs
+	queuedTx := newTx(5, 40, now)
+	queuedTx.Queued = true
+	require.NoError(t, s.AddTx(ctx, queuedTx))
+	txs, err = s.GetQueuedTxs(ctx, 0)
+	require.NoError(t, err)
+	require.Len(t, txs, 1)
+	assert.Equal(t, queuedTx.Hash(), txs[0].Hash())
+	txs, err = s.GetNonWIPPendingTxs(ctx)
+	require.NoError(t, err)
+	assert.Len(t, txs, 2)
+	require.NoError(t, s.PromoteQueuedTxs(ctx, from, 5))
+	txs, err = s.GetQueuedTxs(ctx, 0)
+	require.NoError(t, err)
+	assert.Len(t, txs, 1)
+	require.NoError(t, s.PromoteQueuedTxs(ctx, from, 6))
+	txs, err = s.GetQueuedTxs(ctx, 0)
+	require.NoError(t, err)
+	assert.Empty(t, txs)
+	require.NoError(t, s.DeleteTransactionByHash(ctx, queuedTx.Hash()))
+
+	// user operations
+	userOp1 := pool.UserOperation{UserOp: pool.UserOp{Sender: from}, Hash: common.HexToHash("0x1"), Status: pool.UserOperationStatusPending, ReceivedAt: now}
+	userOp2 := pool.UserOperation{UserOp: pool.UserOp{Sender: common.HexToAddress("0x2")}, Hash: common.HexToHash("0x2"), Status: pool.UserOperationStatusPending, ReceivedAt: now.Add(-time.Minute)}
+	for _, userOp := range []pool.UserOperation{userOp1, userOp2} {
+		require.NoError(t, s.AddUserOperation(ctx, userOp))
+	}
+	userOps, err := s.GetPendingUserOperations(ctx, 1)
+	require.NoError(t, err)
+	require.Len(t, userOps, 1)
+	assert.Equal(t, userOp2.Hash, userOps[0].Hash)
+	userOps, err = s.GetPendingUserOperationsBySender(ctx, from)
+	require.NoError(t, err)
+	require.Len(t, userOps, 1)
+	assert.Equal(t, userOp1.Hash, userOps[0].Hash)
+
+	bundleTxHash := common.HexToHash("0x3")
+	require.NoError(t, s.UpdateUserOperationsStatus(ctx, []common.Hash{userOp1.Hash}, pool.UserOperationStatusBundled, &bundleTxHash, nil))
+	userOp, err := s.GetUserOperationByHash(ctx, userOp1.Hash)
+	require.NoError(t, err)
+	assert.Equal(t, pool.UserOperationStatusBundled, userOp.Status)
+	assert.Equal(t, &bundleTxHash, userOp.BundleTxHash)
+	userOps, err = s.GetBundledUserOperations(ctx)
+	require.NoError(t, err)
+	require.Len(t, userOps, 1)
+	assert.Equal(t, userOp1.Hash, userOps[0].Hash)
+	_, err = s.GetUserOperationByHash(ctx, common.HexToHash("0x4"))
+	assert.ErrorIs(t, err, pool.ErrNotFound)
+
+	// pending block
+	_, err = s.GetPendingBlock(ctx)
+	assert.ErrorIs(t, err, pool.ErrNotFound)
+	pendingBlock := pool.PendingBlock{Number: 10, StateRoot: common.HexToHash("0x5"), Timestamp: now, TxHashes: []common.Hash{tx1.Hash()}}
+	require.NoError(t, s.UpdatePendingBlock(ctx, pendingBlock))
+	storedPendingBlock, err := s.GetPendingBlock(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, pendingBlock, *storedPendingBlock)
+
+	// gas prices
+	_, err = s.MinL2GasPriceSince(ctx, now)
+	assert.ErrorIs(t, err, state.ErrNotFound)
+	require.NoError(t, s.SetGasPrices(ctx, 5, 50))
+	require.NoError(t, s.SetGasPrices(ctx, 3, 30))
+	l2GasPrice, l1GasPrice, err := s.GetGasPrices(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(3), l2GasPrice)
+	assert.Equal(t, uint64(30), l1GasPrice)
+	minGasPrice, err := s.MinL2GasPriceSince(ctx, now)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(3), minGasPrice)
+
+	require.NoError(t, s.DeleteGasPricesHistoryOlderThan(ctx, time.Now().Add(time.Minute)))
+	minGasPrice, err = s.MinL2GasPriceSince(ctx, now)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(3), minGasPrice)
+	assert.Len(t, s.gasPrices, 1)
+}
@@ -0,0 +1,105 @@
+package metrics
+
+import (
+	"time"
+
+	"github.com/0xPolygonHermez/zkevm-node/metrics"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const (
+	// Prefix for the metrics of the pool package.
+	Prefix = "pool_"
+
+	// PendingTxsName is the name of the metric with the number of pending txs in the pool.
+	PendingTxsName = Prefix + "pending_txs"
+
+	// OldestPendingTxAgeName is the name of the metric with the age in seconds of the oldest pending tx in the pool.
+	OldestPendingTxAgeName = Prefix + "oldest_pending_tx_age_seconds"
+
+	// TxsAddedName is the name of the metric that counts the txs added to the pool.
+	TxsAddedName = Prefix + "txs_added"
+
+	// TxsEvictedName is the name of the metric that counts the txs evicted from the pool.
+	TxsEvictedName = Prefix + "txs_evicted"
+
+	// TxsPrunedName is the name of the metric that counts the txs of the pool marked as failed because their nonce is too low.
+	TxsPrunedName = Prefix + "txs_pruned"
+
+	// TxsRejectedName is the name of the metric that counts the txs rejected by the pool by reason.
+	TxsRejectedName = Prefix + "txs_rejected"
+
+	// TxsRejectedReasonLabelName is the name of the label with the reason of the rejection of a tx.
+	TxsRejectedReasonLabelName = "reason"
+)
+
+// Register the metrics for the pool package.
+func Register() {
+	gauges := []prometheus.GaugeOpts{
+		{
+			Name: PendingTxsName,
+			Help: "[POOL] number of pending txs in the pool",
+		},
+		{
+			Name: OldestPendingTxAgeName,
+			Help: "[POOL] age in seconds of the oldest pending tx in the pool",
+		},
+	}
+	counters := []prometheus.CounterOpts{
+		{
+			Name: TxsAddedName,
+			Help: "[POOL] number of txs added to the pool",
+		},
+		{
+			Name: TxsEvictedName,
+			Help: "[POOL] number of txs evicted from the pool",
+		},
+		{
+			Name: TxsPrunedName,
+			Help: "[POOL] number of txs of the pool marked as failed because their nonce is too low",
+		},
+	}
+	counterVecs := []metrics.CounterVecOpts{
+		{
+			CounterOpts: prometheus.CounterOpts{
+				Name: TxsRejectedName,
+				Help: "[POOL] number of txs rejected by the pool",
+			},
+			Labels: []string{TxsRejectedReasonLabelName},
+		},
+	}
+
+	metrics.RegisterGauges(gauges...)
+	metrics.RegisterCounters(counters...)
+	metrics.RegisterCounterVecs(counterVecs...)
+}
+
+// PendingTxs sets the gauge with the number of pending txs in the pool.
+func PendingTxs(count uint64) {
+	metrics.GaugeSet(PendingTxsName, float64(count))
+}
+
+// OldestPendingTxAge sets the gauge with the age of the oldest pending tx in the pool.
+func OldestPendingTxAge(age time.Duration) {
+	metrics.GaugeSet(OldestPendingTxAgeName, age.Seconds())
+}
+
+// TxAdded increases the counter of txs added to the pool.
+func TxAdded() {
+	metrics.CounterInc(TxsAddedName)
+}
+
+// TxsEvicted increases the counter of txs evicted from the pool.
+func TxsEvicted(count uint64) {
+	metrics.CounterAdd(TxsEvictedName, float64(count))
+}
+
+// TxsPruned increases the counter of txs of the pool marked as failed because their nonce is too low.
+func TxsPruned(count uint64) {
+	metrics.CounterAdd(TxsPrunedName, float64(count))
+}
+
+// TxRejected increases the counter of txs rejected by the pool for the provided reason.
+func TxRejected(reason string) {
+	metrics.CounterVecInc(TxsRejectedName, reason)
+}
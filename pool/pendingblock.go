@@ -0,0 +1,20 @@
+package pool
+
+import (
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// PendingBlock is the last L2 block processed by the sequencer, which may not be
+// stored in the state yet. It's shared through the pool storage so the RPC nodes
+// can serve the pending block tag while the state DB lags behind the sequencer
+type PendingBlock struct {
+	Number     uint64
+	Hash       common.Hash
+	ParentHash common.Hash
+	StateRoot  common.Hash
+	Timestamp  time.Time
+	TxHashes   []common.Hash
+	UpdatedAt  time.Time
+}
@@ -0,0 +1,64 @@
+package pgpoolstorage
+
+import (
+	"context"
+	"errors"
+
+	"github.com/0xPolygonHermez/zkevm-node/pool"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/jackc/pgx/v4"
+)
+
+// UpdatePendingBlock replaces the pending block processed by the sequencer
+func (p *PostgresPoolStorage) UpdatePendingBlock(ctx context.Context, block pool.PendingBlock) error {
+	const sql = `
+		WITH deleted AS (
+			DELETE FROM pool.pending_block WHERE block_num <> $1
+		)
+		INSERT INTO pool.pending_block (block_num, block_hash, parent_hash, state_root, timestamp, tx_hashes, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		ON CONFLICT (block_num) DO UPDATE SET
+			block_hash = $2, parent_hash = $3, state_root = $4, timestamp = $5, tx_hashes = $6, updated_at = $7`
+
+	txHashes := make([]string, 0, len(block.TxHashes))
+	for _, txHash := range block.TxHashes {
+		txHashes = append(txHashes, txHash.String())
+	}
+
+	if _, err := p.db.Exec(ctx, sql, block.Number, block.Hash.String(), block.ParentHash.String(),
+		block.StateRoot.String(), block.Timestamp, txHashes, block.UpdatedAt); err != nil {
+		return err
+	}
+	return nil
+}
+
+// GetPendingBlock returns the pending block processed by the sequencer,
+// it returns pool.ErrNotFound if the sequencer hasn't processed any block yet
+func (p *PostgresPoolStorage) GetPendingBlock(ctx context.Context) (*pool.PendingBlock, error) {
+	const sql = `
+		SELECT block_num, block_hash, parent_hash, state_root, timestamp, tx_hashes, updated_at
+		  FROM pool.pending_block
+		 ORDER BY block_num DESC
+		 LIMIT 1`
+
+	var (
+		block                       pool.PendingBlock
+		hash, parentHash, stateRoot string
+		txHashes                    []string
+	)
+	err := p.db.QueryRow(ctx, sql).Scan(&block.Number, &hash, &parentHash, &stateRoot, &block.Timestamp, &txHashes, &block.UpdatedAt)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, pool.ErrNotFound
+	} else if err != nil {
+		return nil, err
+	}
+
+	block.Hash = common.HexToHash(hash)
+	block.ParentHash = common.HexToHash(parentHash)
+	block.StateRoot = common.HexToHash(stateRoot)
+	block.TxHashes = make([]common.Hash, 0, len(txHashes))
+	for _, txHash := range txHashes {
+		block.TxHashes = append(block.TxHashes, common.HexToHash(txHash))
+	}
+	return &block, nil
+}
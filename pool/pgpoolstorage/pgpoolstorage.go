@@ -76,10 +76,15 @@ func (p *PostgresPoolStorage) AddTx(ctx context.Context, tx pool.Transaction) er
 			is_wip,
 			ip,
 			failed_reason,
-			reserved_zkcounters
+			reserved_zkcounters,
+			is_local,
+			not_before_timestamp,
+			not_before_block,
+			conditions,
+			queued
 		) 
 		VALUES 
-			($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, NULL, $20)
+			($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, NULL, $20, $21, $22, $23, $24, $25)
 			ON CONFLICT (hash) DO UPDATE SET 
 			encoded = $2,
 			decoded = $3,
@@ -100,7 +105,12 @@ func (p *PostgresPoolStorage) AddTx(ctx context.Context, tx pool.Transaction) er
 			is_wip = $18,
 			ip = $19,
 			failed_reason = NULL,
-			reserved_zkcounters = $20
+			reserved_zkcounters = $20,
+			is_local = $21,
+			not_before_timestamp = $22,
+			not_before_block = $23,
+			conditions = $24,
+			queued = $25
 	`
 
 	// Get FromAddress from the JSON data
@@ -130,7 +140,17 @@ func (p *PostgresPoolStorage) AddTx(ctx context.Context, tx pool.Transaction) er
 		fromAddress,
 		tx.IsWIP,
 		tx.IP,
-		tx.ReservedZKCounters); err != nil {
+		tx.ReservedZKCounters,
+		tx.IsLocal,
+		tx.Schedule.NotBeforeTimestamp,
+		tx.Schedule.NotBeforeBlock,
+		tx.Conditions,
+		tx.Queued); err != nil {
+		return err
+	}
+
+	const historySQL = `INSERT INTO pool.transaction_status_history (hash, status, changed_at) VALUES ($1, $2, $3)`
+	if _, err := p.db.Exec(ctx, historySQL, hash, tx.Status, time.Now().UTC()); err != nil {
 		return err
 	}
 	return nil
@@ -147,11 +167,11 @@ func (p *PostgresPoolStorage) GetTxsByStatus(ctx context.Context, status pool.Tx
 	)
 	if limit == 0 {
 		sql = `SELECT encoded, status, received_at, is_wip, ip, cumulative_gas_used, used_keccak_hashes, used_poseidon_hashes, used_poseidon_paddings, used_mem_aligns,
-				used_arithmetics, used_binaries, used_steps, used_sha256_hashes, failed_reason, reserved_zkcounters FROM pool.transaction WHERE status = $1 ORDER BY gas_price DESC`
+				used_arithmetics, used_binaries, used_steps, used_sha256_hashes, failed_reason, reserved_zkcounters, is_local, not_before_timestamp, not_before_block, conditions, queued FROM pool.transaction WHERE status = $1 ORDER BY gas_price DESC`
 		rows, err = p.db.Query(ctx, sql, status.String())
 	} else {
 		sql = `SELECT encoded, status, received_at, is_wip, ip, cumulative_gas_used, used_keccak_hashes, used_poseidon_hashes, used_poseidon_paddings, used_mem_aligns,
-				used_arithmetics, used_binaries, used_steps, used_sha256_hashes, failed_reason, reserved_zkcounters FROM pool.transaction WHERE status = $1 ORDER BY gas_price DESC LIMIT $2`
+				used_arithmetics, used_binaries, used_steps, used_sha256_hashes, failed_reason, reserved_zkcounters, is_local, not_before_timestamp, not_before_block, conditions, queued FROM pool.transaction WHERE status = $1 ORDER BY gas_price DESC LIMIT $2`
 		rows, err = p.db.Query(ctx, sql, status.String(), limit)
 	}
 	if err != nil {
@@ -180,7 +200,7 @@ func (p *PostgresPoolStorage) GetNonWIPPendingTxs(ctx context.Context) ([]pool.T
 	)
 
 	sql = `SELECT encoded, status, received_at, is_wip, ip, cumulative_gas_used, used_keccak_hashes, used_poseidon_hashes, used_poseidon_paddings, used_mem_aligns,
-		used_arithmetics, used_binaries, used_steps, used_sha256_hashes, failed_reason, reserved_zkcounters FROM pool.transaction WHERE is_wip IS FALSE and status = $1`
+		used_arithmetics, used_binaries, used_steps, used_sha256_hashes, failed_reason, reserved_zkcounters, is_local, not_before_timestamp, not_before_block, conditions, queued FROM pool.transaction WHERE is_wip IS FALSE and status = $1 AND queued IS FALSE`
 	rows, err = p.db.Query(ctx, sql, pool.TxStatusPending)
 
 	if err != nil {
@@ -386,20 +406,17 @@ func (p *PostgresPoolStorage) CountTransactionsByFromAndStatus(ctx context.Conte
 // UpdateTxStatus updates a transaction status accordingly to the
 // provided status and hash
 func (p *PostgresPoolStorage) UpdateTxStatus(ctx context.Context, updateInfo pool.TxStatusUpdateInfo) error {
-	sql := "UPDATE pool.transaction SET status = $1, is_wip = $2"
-	args := []interface{}{updateInfo.NewStatus, updateInfo.IsWIP}
-
-	if updateInfo.FailedReason != nil {
-		sql += ", failed_reason = $3"
-		args = append(args, *updateInfo.FailedReason)
-		sql += " WHERE hash = $4"
-	} else {
-		sql += " WHERE hash = $3"
-	}
-
-	args = append(args, updateInfo.Hash.Hex())
+	// the status change is added to the history only if the tx exists
+	const sql = `
+		WITH updated AS (
+			UPDATE pool.transaction SET status = $1, is_wip = $2, failed_reason = COALESCE($3::VARCHAR, failed_reason)
+			 WHERE hash = $4
+			RETURNING hash
+		)
+		INSERT INTO pool.transaction_status_history (hash, status, failed_reason, changed_at)
+		SELECT hash, $1, $3::VARCHAR, $5 FROM updated`
 
-	if _, err := p.db.Exec(ctx, sql, args...); err != nil {
+	if _, err := p.db.Exec(ctx, sql, updateInfo.NewStatus, updateInfo.IsWIP, updateInfo.FailedReason, updateInfo.Hash.Hex(), time.Now().UTC()); err != nil {
 		return err
 	}
 
@@ -440,6 +457,38 @@ func (p *PostgresPoolStorage) DeleteFailedTransactionsOlderThan(ctx context.Cont
 	return nil
 }
 
+// DeleteNonWIPTransactionsByStatusOlderThan deletes the txs with the given status that are not WIP nor local and were
+// received before the given date, it returns the number of deleted txs
+func (p *PostgresPoolStorage) DeleteNonWIPTransactionsByStatusOlderThan(ctx context.Context, status pool.TxStatus, date time.Time) (uint64, error) {
+	sql := `DELETE FROM pool.transaction WHERE status = $1 AND is_wip IS FALSE AND is_local IS FALSE AND received_at < $2`
+
+	res, err := p.db.Exec(ctx, sql, status.String(), date)
+	if err != nil {
+		return 0, err
+	}
+	return uint64(res.RowsAffected()), nil
+}
+
+// GetLowestGasPriceNonWIPPendingTx returns the pending tx with the lowest gas price that is not WIP nor local
+func (p *PostgresPoolStorage) GetLowestGasPriceNonWIPPendingTx(ctx context.Context) (*pool.Transaction, error) {
+	sql := `SELECT encoded, status, received_at, is_wip, ip, cumulative_gas_used, used_keccak_hashes, used_poseidon_hashes, used_poseidon_paddings, used_mem_aligns,
+		used_arithmetics, used_binaries, used_steps, used_sha256_hashes, failed_reason, reserved_zkcounters, is_local, not_before_timestamp, not_before_block, conditions, queued FROM pool.transaction
+		WHERE is_wip IS FALSE AND is_local IS FALSE AND status = $1 ORDER BY gas_price ASC, received_at DESC LIMIT 1`
+	rows, err := p.db.Query(ctx, sql, pool.TxStatusPending)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	if !rows.Next() {
+		if err := rows.Err(); err != nil {
+			return nil, err
+		}
+		return nil, pool.ErrNotFound
+	}
+	return scanTx(rows)
+}
+
 // SetGasPrices sets the latest l2 and l1 gas prices
 func (p *PostgresPoolStorage) SetGasPrices(ctx context.Context, l2GasPrice, l1GasPrice uint64) error {
 	sql := "INSERT INTO pool.gas_price (price, l1_price, timestamp) VALUES ($1, $2, $3)"
@@ -519,7 +568,8 @@ func (p *PostgresPoolStorage) IsTxPending(ctx context.Context, hash common.Hash)
 // GetTxsByFromAndNonce get all the transactions from the pool with the same from and nonce
 func (p *PostgresPoolStorage) GetTxsByFromAndNonce(ctx context.Context, from common.Address, nonce uint64) ([]pool.Transaction, error) {
 	sql := `SELECT encoded, status, received_at, is_wip, ip, cumulative_gas_used, used_keccak_hashes, used_poseidon_hashes, 
-				   used_poseidon_paddings, used_mem_aligns,	used_arithmetics, used_binaries, used_steps, used_sha256_hashes, failed_reason, reserved_zkcounters
+				   used_poseidon_paddings, used_mem_aligns,	used_arithmetics, used_binaries, used_steps, used_sha256_hashes, failed_reason, reserved_zkcounters, is_local,
+				   not_before_timestamp, not_before_block, conditions, queued
 	          FROM pool.transaction
 			 WHERE from_address = $1
 			   AND nonce = $2`
@@ -543,6 +593,37 @@ func (p *PostgresPoolStorage) GetTxsByFromAndNonce(ctx context.Context, from com
 	return txs, nil
 }
 
+// GetNonWIPTxsByFromWithNonceLowerThan returns the pending and scheduled txs of the sender that are not WIP
+// and whose nonce is lower than the provided one
+func (p *PostgresPoolStorage) GetNonWIPTxsByFromWithNonceLowerThan(ctx context.Context, from common.Address, nonce uint64) ([]pool.Transaction, error) {
+	sql := `SELECT encoded, status, received_at, is_wip, ip, cumulative_gas_used, used_keccak_hashes, used_poseidon_hashes, 
+				   used_poseidon_paddings, used_mem_aligns,	used_arithmetics, used_binaries, used_steps, used_sha256_hashes, failed_reason, reserved_zkcounters, is_local,
+				   not_before_timestamp, not_before_block, conditions, queued
+	          FROM pool.transaction
+			 WHERE from_address = $1
+			   AND nonce < $2
+			   AND status IN ($3, $4)
+			   AND is_wip IS FALSE`
+	rows, err := p.db.Query(ctx, sql, from.String(), nonce, pool.TxStatusPending, pool.TxStatusScheduled)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	txs := make([]pool.Transaction, 0, len(rows.RawValues()))
+	for rows.Next() {
+		tx, err := scanTx(rows)
+		if err != nil {
+			return nil, err
+		}
+		txs = append(txs, *tx)
+	}
+
+	return txs, nil
+}
+
 // GetTxFromAddressFromByHash gets tx from address by hash
 func (p *PostgresPoolStorage) GetTxFromAddressFromByHash(ctx context.Context, hash common.Hash) (common.Address, uint64, error) {
 	query := `SELECT from_address, nonce
@@ -597,18 +678,81 @@ func (p *PostgresPoolStorage) GetNonce(ctx context.Context, address common.Addre
 	return *nonce, nil
 }
 
+// GetNoncesByFrom returns the nonces of the pending and selected txs of the sender sorted in ascending order
+func (p *PostgresPoolStorage) GetNoncesByFrom(ctx context.Context, from common.Address) ([]uint64, error) {
+	const sql = `SELECT DISTINCT nonce
+	               FROM pool.transaction
+	              WHERE from_address = $1
+	                AND status IN ($2, $3)
+	              ORDER BY nonce ASC`
+	rows, err := p.db.Query(ctx, sql, from.String(), pool.TxStatusPending, pool.TxStatusSelected)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	nonces := make([]uint64, 0)
+	for rows.Next() {
+		var nonce uint64
+		if err := rows.Scan(&nonce); err != nil {
+			return nil, err
+		}
+		nonces = append(nonces, nonce)
+	}
+	return nonces, rows.Err()
+}
+
+// GetQueuedTxs returns the pending txs that are waiting for a nonce gap to be filled sorted by gas price (higher first)
+// limit parameter is used to limit amount txs from the db,
+// if limit = 0, then there is no limit
+func (p *PostgresPoolStorage) GetQueuedTxs(ctx context.Context, limit uint64) ([]pool.Transaction, error) {
+	sql := `SELECT encoded, status, received_at, is_wip, ip, cumulative_gas_used, used_keccak_hashes, used_poseidon_hashes, used_poseidon_paddings, used_mem_aligns,
+		used_arithmetics, used_binaries, used_steps, used_sha256_hashes, failed_reason, reserved_zkcounters, is_local, not_before_timestamp, not_before_block, conditions, queued
+		FROM pool.transaction WHERE status = $1 AND queued IS TRUE ORDER BY gas_price DESC`
+	args := []interface{}{pool.TxStatusPending}
+	if limit > 0 {
+		sql += " LIMIT $2"
+		args = append(args, limit)
+	}
+
+	rows, err := p.db.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	txs := make([]pool.Transaction, 0, len(rows.RawValues()))
+	for rows.Next() {
+		tx, err := scanTx(rows)
+		if err != nil {
+			return nil, err
+		}
+		txs = append(txs, *tx)
+	}
+
+	return txs, rows.Err()
+}
+
+// PromoteQueuedTxs unsets the queued flag of the txs of the sender with a nonce lower than the provided one
+func (p *PostgresPoolStorage) PromoteQueuedTxs(ctx context.Context, from common.Address, nonce uint64) error {
+	const sql = `UPDATE pool.transaction SET queued = FALSE WHERE from_address = $1 AND nonce < $2 AND queued IS TRUE`
+	_, err := p.db.Exec(ctx, sql, from.String(), nonce)
+	return err
+}
+
 // GetTransactionByHash gets a transaction in the pool by its hash
 func (p *PostgresPoolStorage) GetTransactionByHash(ctx context.Context, hash common.Hash) (*pool.Transaction, error) {
 	var (
 		encoded, status, ip string
 		receivedAt          time.Time
 		isWIP               bool
+		failedReason        *string
 	)
 
-	sql := `SELECT encoded, status, received_at, is_wip, ip
+	sql := `SELECT encoded, status, received_at, is_wip, ip, failed_reason
 	          FROM pool.transaction
 			 WHERE hash = $1`
-	err := p.db.QueryRow(ctx, sql, hash.String()).Scan(&encoded, &status, &receivedAt, &isWIP, &ip)
+	err := p.db.QueryRow(ctx, sql, hash.String()).Scan(&encoded, &status, &receivedAt, &isWIP, &ip, &failedReason)
 	if errors.Is(err, pgx.ErrNoRows) {
 		return nil, pool.ErrNotFound
 	} else if err != nil {
@@ -626,11 +770,12 @@ func (p *PostgresPoolStorage) GetTransactionByHash(ctx context.Context, hash com
 	}
 
 	poolTx := &pool.Transaction{
-		ReceivedAt:  receivedAt,
-		Status:      pool.TxStatus(status),
-		Transaction: *tx,
-		IsWIP:       isWIP,
-		IP:          ip,
+		ReceivedAt:   receivedAt,
+		Status:       pool.TxStatus(status),
+		Transaction:  *tx,
+		IsWIP:        isWIP,
+		IP:           ip,
+		FailedReason: failedReason,
 	}
 
 	return poolTx, nil
@@ -679,7 +824,7 @@ func scanTx(rows pgx.Rows) (*pool.Transaction, error) {
 	var (
 		encoded, status, ip  string
 		receivedAt           time.Time
-		isWIP                bool
+		isWIP, isLocal       bool
 		cumulativeGasUsed    uint64
 		usedKeccakHashes     uint32
 		usedPoseidonHashes   uint32
@@ -691,10 +836,14 @@ func scanTx(rows pgx.Rows) (*pool.Transaction, error) {
 		usedSHA256Hashes     uint32
 		failedReason         *string
 		reservedZKCounters   state.ZKCounters
+		notBeforeTimestamp   uint64
+		notBeforeBlock       uint64
+		conditions           pool.TxConditions
+		queued               bool
 	)
 
 	if err := rows.Scan(&encoded, &status, &receivedAt, &isWIP, &ip, &cumulativeGasUsed, &usedKeccakHashes, &usedPoseidonHashes,
-		&usedPoseidonPaddings, &usedMemAligns, &usedArithmetics, &usedBinaries, &usedSteps, &usedSHA256Hashes, &failedReason, &reservedZKCounters); err != nil {
+		&usedPoseidonPaddings, &usedMemAligns, &usedArithmetics, &usedBinaries, &usedSteps, &usedSHA256Hashes, &failedReason, &reservedZKCounters, &isLocal, &notBeforeTimestamp, &notBeforeBlock, &conditions, &queued); err != nil {
 		return nil, err
 	}
 
@@ -724,6 +873,10 @@ func scanTx(rows pgx.Rows) (*pool.Transaction, error) {
 	tx.ZKCounters.Sha256Hashes_V2 = usedSHA256Hashes
 	tx.FailedReason = failedReason
 	tx.ReservedZKCounters = reservedZKCounters
+	tx.IsLocal = isLocal
+	tx.Schedule = pool.TxSchedule{NotBeforeTimestamp: notBeforeTimestamp, NotBeforeBlock: notBeforeBlock}
+	tx.Conditions = conditions
+	tx.Queued = queued
 
 	return tx, nil
 }
@@ -756,6 +909,34 @@ func (p *PostgresPoolStorage) GetTxZkCountersByHash(ctx context.Context, hash co
 	return &usedZKCounters, &reservedZKCounters, nil
 }
 
+// GetTxStatusHistory gets the changes of the status of a transaction sorted from the oldest to the newest
+func (p *PostgresPoolStorage) GetTxStatusHistory(ctx context.Context, hash common.Hash) ([]pool.TxStatusChange, error) {
+	sql := `SELECT status, failed_reason, changed_at
+	          FROM pool.transaction_status_history
+			 WHERE hash = $1
+		  ORDER BY id ASC`
+	rows, err := p.db.Query(ctx, sql, hash.String())
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	history := make([]pool.TxStatusChange, 0, len(rows.RawValues()))
+	for rows.Next() {
+		var (
+			status       string
+			failedReason *string
+			changedAt    time.Time
+		)
+		if err := rows.Scan(&status, &failedReason, &changedAt); err != nil {
+			return nil, err
+		}
+		history = append(history, pool.TxStatusChange{Status: pool.TxStatus(status), FailedReason: failedReason, ChangedAt: changedAt})
+	}
+
+	return history, nil
+}
+
 // MarkWIPTxsAsPending updates WIP status to non WIP
 func (p *PostgresPoolStorage) MarkWIPTxsAsPending(ctx context.Context) error {
 	const query = `UPDATE pool.transaction SET is_wip = false WHERE is_wip = true`
@@ -821,3 +1002,23 @@ func (p *PostgresPoolStorage) GetEarliestProcessedTx(ctx context.Context) (commo
 
 	return common.HexToHash(txnHash), nil
 }
+
+// GetOldestPendingTxReceivedAt returns the time the oldest pending tx was received,
+// it returns pool.ErrNotFound if there are no pending txs
+func (p *PostgresPoolStorage) GetOldestPendingTxReceivedAt(ctx context.Context) (time.Time, error) {
+	const sql = `SELECT MIN(received_at) FROM pool.transaction WHERE status = $1`
+
+	var receivedAt *time.Time
+	if err := p.db.QueryRow(ctx, sql, pool.TxStatusPending).Scan(&receivedAt); err != nil {
+		return time.Time{}, err
+	}
+	if receivedAt == nil {
+		return time.Time{}, pool.ErrNotFound
+	}
+	return *receivedAt, nil
+}
+
+// Ping checks the connection to the pool DB
+func (p *PostgresPoolStorage) Ping(ctx context.Context) error {
+	return p.db.Ping(ctx)
+}
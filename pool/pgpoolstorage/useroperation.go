@@ -0,0 +1,213 @@
+package pgpoolstorage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/0xPolygonHermez/zkevm-node/pool"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/jackc/pgx/v4"
+)
+
+const getUserOperationsSQL = `
+	SELECT hash, entry_point, sender, nonce::TEXT, init_code, call_data, call_gas_limit::TEXT, verification_gas_limit::TEXT,
+	       pre_verification_gas::TEXT, max_fee_per_gas::TEXT, max_priority_fee_per_gas::TEXT, paymaster_and_data, signature,
+	       status, received_at, ip, bundle_tx_hash, failed_reason
+	  FROM pool.user_operation`
+
+// AddUserOperation adds a user operation to the user operations table with the provided status
+func (p *PostgresPoolStorage) AddUserOperation(ctx context.Context, userOp pool.UserOperation) error {
+	const sql = `
+		INSERT INTO pool.user_operation
+		(
+			hash,
+			entry_point,
+			sender,
+			nonce,
+			init_code,
+			call_data,
+			call_gas_limit,
+			verification_gas_limit,
+			pre_verification_gas,
+			max_fee_per_gas,
+			max_priority_fee_per_gas,
+			paymaster_and_data,
+			signature,
+			status,
+			received_at,
+			ip
+		)
+		VALUES
+			($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16)`
+
+	if _, err := p.db.Exec(ctx, sql,
+		userOp.Hash.String(),
+		userOp.EntryPoint.String(),
+		userOp.Sender.String(),
+		bigToString(userOp.Nonce),
+		userOp.InitCode,
+		userOp.CallData,
+		bigToString(userOp.CallGasLimit),
+		bigToString(userOp.VerificationGasLimit),
+		bigToString(userOp.PreVerificationGas),
+		bigToString(userOp.MaxFeePerGas),
+		bigToString(userOp.MaxPriorityFeePerGas),
+		userOp.PaymasterAndData,
+		userOp.Signature,
+		userOp.Status,
+		userOp.ReceivedAt,
+		userOp.IP); err != nil {
+		return err
+	}
+	return nil
+}
+
+// GetUserOperationByHash returns the user operation with the provided hash,
+// it returns pool.ErrNotFound if the user operation is not in the pool
+func (p *PostgresPoolStorage) GetUserOperationByHash(ctx context.Context, hash common.Hash) (*pool.UserOperation, error) {
+	row := p.db.QueryRow(ctx, getUserOperationsSQL+" WHERE hash = $1", hash.String())
+	userOp, err := scanUserOperation(row)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, pool.ErrNotFound
+	} else if err != nil {
+		return nil, err
+	}
+	return userOp, nil
+}
+
+// GetPendingUserOperations returns the pending user operations sorted by the time they were received
+// (older first), if limit = 0 then there is no limit
+func (p *PostgresPoolStorage) GetPendingUserOperations(ctx context.Context, limit uint64) ([]pool.UserOperation, error) {
+	var (
+		rows pgx.Rows
+		err  error
+	)
+	const sql = getUserOperationsSQL + " WHERE status = $1 ORDER BY received_at ASC"
+	if limit == 0 {
+		rows, err = p.db.Query(ctx, sql, pool.UserOperationStatusPending)
+	} else {
+		rows, err = p.db.Query(ctx, sql+" LIMIT $2", pool.UserOperationStatusPending, limit)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return scanUserOperations(rows)
+}
+
+// GetPendingUserOperationsBySender returns the pending user operations of the provided sender
+func (p *PostgresPoolStorage) GetPendingUserOperationsBySender(ctx context.Context, sender common.Address) ([]pool.UserOperation, error) {
+	const sql = getUserOperationsSQL + " WHERE status = $1 AND sender = $2"
+	rows, err := p.db.Query(ctx, sql, pool.UserOperationStatusPending, sender.String())
+	if err != nil {
+		return nil, err
+	}
+	return scanUserOperations(rows)
+}
+
+// GetBundledUserOperations returns the user operations included in a bundle tx whose result is not known yet
+func (p *PostgresPoolStorage) GetBundledUserOperations(ctx context.Context) ([]pool.UserOperation, error) {
+	const sql = getUserOperationsSQL + " WHERE status = $1"
+	rows, err := p.db.Query(ctx, sql, pool.UserOperationStatusBundled)
+	if err != nil {
+		return nil, err
+	}
+	return scanUserOperations(rows)
+}
+
+// UpdateUserOperationsStatus updates the status of the user operations with the provided hashes, the
+// bundle tx hash and the failed reason are only updated when they are provided
+func (p *PostgresPoolStorage) UpdateUserOperationsStatus(ctx context.Context, hashes []common.Hash, status pool.UserOperationStatus, bundleTxHash *common.Hash, failedReason *string) error {
+	hh := make([]string, 0, len(hashes))
+	for _, h := range hashes {
+		hh = append(hh, h.String())
+	}
+	var bundleTxHashStr *string
+	if bundleTxHash != nil {
+		s := bundleTxHash.String()
+		bundleTxHashStr = &s
+	}
+
+	const sql = `
+		UPDATE pool.user_operation
+		   SET status = $1, bundle_tx_hash = COALESCE($2::VARCHAR, bundle_tx_hash), failed_reason = COALESCE($3::VARCHAR, failed_reason)
+		 WHERE hash = ANY ($4)`
+	if _, err := p.db.Exec(ctx, sql, status, bundleTxHashStr, failedReason, hh); err != nil {
+		return err
+	}
+	return nil
+}
+
+func scanUserOperations(rows pgx.Rows) ([]pool.UserOperation, error) {
+	defer rows.Close()
+
+	userOps := make([]pool.UserOperation, 0)
+	for rows.Next() {
+		userOp, err := scanUserOperation(rows)
+		if err != nil {
+			return nil, err
+		}
+		userOps = append(userOps, *userOp)
+	}
+	return userOps, rows.Err()
+}
+
+func scanUserOperation(row pgx.Row) (*pool.UserOperation, error) {
+	var (
+		hash, entryPoint, sender                                      string
+		nonce, callGasLimit, verificationGasLimit, preVerificationGas string
+		maxFeePerGas, maxPriorityFeePerGas                            string
+		status                                                        string
+		receivedAt                                                    time.Time
+		ip, bundleTxHash, failedReason                                *string
+		userOp                                                        pool.UserOperation
+	)
+	if err := row.Scan(&hash, &entryPoint, &sender, &nonce, &userOp.InitCode, &userOp.CallData, &callGasLimit,
+		&verificationGasLimit, &preVerificationGas, &maxFeePerGas, &maxPriorityFeePerGas, &userOp.PaymasterAndData,
+		&userOp.Signature, &status, &receivedAt, &ip, &bundleTxHash, &failedReason); err != nil {
+		return nil, err
+	}
+
+	bigs := []struct {
+		str string
+		dst **big.Int
+	}{
+		{nonce, &userOp.Nonce},
+		{callGasLimit, &userOp.CallGasLimit},
+		{verificationGasLimit, &userOp.VerificationGasLimit},
+		{preVerificationGas, &userOp.PreVerificationGas},
+		{maxFeePerGas, &userOp.MaxFeePerGas},
+		{maxPriorityFeePerGas, &userOp.MaxPriorityFeePerGas},
+	}
+	for _, b := range bigs {
+		v, ok := new(big.Int).SetString(b.str, 10) //nolint:gomnd
+		if !ok {
+			return nil, fmt.Errorf("failed to read value %s of user operation %s", b.str, hash)
+		}
+		*b.dst = v
+	}
+
+	userOp.Hash = common.HexToHash(hash)
+	userOp.EntryPoint = common.HexToAddress(entryPoint)
+	userOp.Sender = common.HexToAddress(sender)
+	userOp.Status = pool.UserOperationStatus(status)
+	userOp.ReceivedAt = receivedAt
+	if ip != nil {
+		userOp.IP = *ip
+	}
+	if bundleTxHash != nil {
+		h := common.HexToHash(*bundleTxHash)
+		userOp.BundleTxHash = &h
+	}
+	userOp.FailedReason = failedReason
+	return &userOp, nil
+}
+
+func bigToString(b *big.Int) string {
+	if b == nil {
+		return "0"
+	}
+	return b.String()
+}
@@ -10,6 +10,7 @@ import (
 
 	"github.com/0xPolygonHermez/zkevm-node/event"
 	"github.com/0xPolygonHermez/zkevm-node/log"
+	poolMetrics "github.com/0xPolygonHermez/zkevm-node/pool/metrics"
 	"github.com/0xPolygonHermez/zkevm-node/state"
 	"github.com/0xPolygonHermez/zkevm-node/state/runtime"
 	"github.com/0xPolygonHermez/zkevm-node/state/runtime/executor"
@@ -18,6 +19,9 @@ import (
 	"github.com/ethereum/go-ethereum/core/types"
 )
 
+// metricsUpdateInterval is the time between updates of the metrics that are read from the storage
+const metricsUpdateInterval = 10 * time.Second
+
 var (
 	// ErrNotFound indicates an object has not been found for the search criteria used
 	ErrNotFound = errors.New("object not found")
@@ -30,6 +34,10 @@ var (
 	// with a different one without the required price bump.
 	ErrReplaceUnderpriced = errors.New("replacement transaction underpriced")
 
+	// ErrReplacedTransaction is set as failed reason of the txs replaced by a new tx with the same
+	// sender and nonce and a higher gas price
+	ErrReplacedTransaction = errors.New("replaced transaction")
+
 	// ErrEffectiveGasPriceGasPriceTooLow the tx gas price is lower than breakEvenGasPrice and lower than L2GasPrice
 	ErrEffectiveGasPriceGasPriceTooLow = errors.New("effective gas price: gas price too low")
 )
@@ -37,12 +45,20 @@ var (
 // Pool is an implementation of the Pool interface
 // that uses a postgres database to store the data
 type Pool struct {
-	storage
+	Storage
 	state                   stateInterface
 	chainID                 uint64
 	cfg                     Config
 	batchConstraintsCfg     state.BatchConstraintsCfg
 	blockedAddresses        sync.Map
+	allowedAddresses        map[common.Address]struct{}
+	registryCache           registryCache
+	refreshBlockedAddrsOnce sync.Once
+	evictTxsOnce            sync.Once
+	updateMetricsOnce       sync.Once
+	promoteScheduledTxsOnce sync.Once
+	pruneNonceTooLowTxsOnce sync.Once
+	promoteQueuedTxsOnce    sync.Once
 	minSuggestedGasPrice    *big.Int
 	minSuggestedGasPriceMux *sync.RWMutex
 	eventLog                *event.EventLog
@@ -50,8 +66,18 @@ type Pool struct {
 	gasPrices               GasPrices
 	gasPricesMux            *sync.RWMutex
 	effectiveGasPrice       *EffectiveGasPrice
+	newTxEventHandlers      []NewTxEventHandler
+	ipRateLimiter           *rateLimiter
+	senderRateLimiter       *rateLimiter
+	gossipPeersIPs          map[string]struct{}
+	userOpsBundler          UserOperationsBundler
+	bundleUserOpsOnce       sync.Once
 }
 
+// NewTxEventHandler represents a func that will be called by the pool when a
+// new tx is added to it by AddTx or AddLocalTx. The handlers must not block
+type NewTxEventHandler func(tx Transaction)
+
 type preExecutionResponse struct {
 	usedZKCounters       state.ZKCounters
 	reservedZKCounters   state.ZKCounters
@@ -69,22 +95,34 @@ type GasPrices struct {
 }
 
 // NewPool creates and initializes an instance of Pool
-func NewPool(cfg Config, batchConstraintsCfg state.BatchConstraintsCfg, s storage, st stateInterface, chainID uint64, eventLog *event.EventLog) *Pool {
+func NewPool(cfg Config, batchConstraintsCfg state.BatchConstraintsCfg, s Storage, st stateInterface, chainID uint64, eventLog *event.EventLog) *Pool {
+	poolMetrics.Register()
+
 	startTimestamp := time.Now()
+	if cfg.Cache.Enabled {
+		s = newCachedStorage(s)
+	}
 	p := &Pool{
 		cfg:                     cfg,
 		batchConstraintsCfg:     batchConstraintsCfg,
 		startTimestamp:          startTimestamp,
-		storage:                 s,
+		Storage:                 s,
 		state:                   st,
 		chainID:                 chainID,
 		blockedAddresses:        sync.Map{},
+		allowedAddresses:        newAllowedAddresses(cfg.AllowList.Addresses),
 		minSuggestedGasPriceMux: new(sync.RWMutex),
 		minSuggestedGasPrice:    big.NewInt(int64(cfg.DefaultMinGasPriceAllowed)),
 		eventLog:                eventLog,
 		gasPrices:               GasPrices{0, 0},
 		gasPricesMux:            new(sync.RWMutex),
 		effectiveGasPrice:       NewEffectiveGasPrice(cfg.EffectiveGasPrice),
+		ipRateLimiter:           newRateLimiter(cfg.RateLimit.IPTxsPerSecond, cfg.RateLimit.IPBurst),
+		senderRateLimiter:       newRateLimiter(cfg.RateLimit.SenderTxsPerSecond, cfg.RateLimit.SenderBurst),
+		gossipPeersIPs:          make(map[string]struct{}, len(cfg.RateLimit.GossipPeersIPs)),
+	}
+	for _, ip := range cfg.RateLimit.GossipPeersIPs {
+		p.gossipPeersIPs[ip] = struct{}{}
 	}
 	p.refreshGasPrices()
 	go func(cfg *Config, p *Pool) {
@@ -112,20 +150,230 @@ func (p *Pool) refreshGasPrices() {
 
 // StartRefreshingBlockedAddressesPeriodically will make this instance of the pool
 // to check periodically(accordingly to the configuration) for updates regarding
-// the blocked address and update the in memory blocked addresses
+// the blocked address and update the in memory blocked addresses. If it's called
+// more than once (e.g. pool shared by the RPC and the sequencer) only the first call
+// starts the refreshing
 func (p *Pool) StartRefreshingBlockedAddressesPeriodically() {
-	p.refreshBlockedAddresses()
-	go func(p *Pool) {
-		for {
-			time.Sleep(p.cfg.IntervalToRefreshBlockedAddresses.Duration)
-			p.refreshBlockedAddresses()
+	p.refreshBlockedAddrsOnce.Do(func() {
+		p.refreshBlockedAddresses()
+		go func(p *Pool) {
+			for {
+				time.Sleep(p.cfg.IntervalToRefreshBlockedAddresses.Duration)
+				p.refreshBlockedAddresses()
+			}
+		}(p)
+	})
+}
+
+// StartEvictingTxsPeriodically will make this instance of the pool to delete periodically
+// (accordingly to the eviction configuration) the pending txs that were not loaded by the
+// sequencer and the invalid txs older than their TTL, until the context is done. If it's
+// called more than once only the first call starts the eviction
+func (p *Pool) StartEvictingTxsPeriodically(ctx context.Context) {
+	if p.cfg.Eviction.Interval.Duration == 0 {
+		return
+	}
+	p.evictTxsOnce.Do(func() {
+		go func(p *Pool) {
+			for {
+				select {
+				case <-ctx.Done():
+					return
+				case <-time.After(p.cfg.Eviction.Interval.Duration):
+					p.evictExpiredTxs(ctx)
+				}
+			}
+		}(p)
+	})
+}
+
+// evictExpiredTxs deletes the pending txs not loaded by the sequencer and the invalid txs older than their TTL
+func (p *Pool) evictExpiredTxs(ctx context.Context) {
+	ttls := map[TxStatus]time.Duration{
+		TxStatusPending: p.cfg.Eviction.PendingTxTTL.Duration,
+		TxStatusInvalid: p.cfg.Eviction.InvalidTxTTL.Duration,
+	}
+	for status, ttl := range ttls {
+		if ttl == 0 {
+			continue
 		}
-	}(p)
+		count, err := p.Storage.DeleteNonWIPTransactionsByStatusOlderThan(ctx, status, time.Now().Add(-ttl))
+		if err != nil {
+			log.Errorf("failed to evict %s txs older than %s from the pool, error: %v", status, ttl, err)
+			continue
+		}
+		if count > 0 {
+			poolMetrics.TxsEvicted(count)
+			log.Infof("evicted %d %s txs older than %s from the pool", count, status, ttl)
+		}
+	}
+}
+
+// StartRefreshingTxsCachePeriodically loads the cache of the pending txs, when it's enabled,
+// and reloads it from the storage every Cache.RefreshInterval until ctx is done
+func (p *Pool) StartRefreshingTxsCachePeriodically(ctx context.Context) {
+	if cs, ok := p.Storage.(*cachedStorage); ok {
+		go cs.startRefreshing(ctx, p.cfg.Cache.RefreshInterval.Duration)
+	}
+}
+
+// StartUpdatingMetricsPeriodically will make this instance of the pool to update periodically
+// the metrics of the pending txs read from the storage, until the context is done. If it's called
+// more than once only the first call starts the updates
+func (p *Pool) StartUpdatingMetricsPeriodically(ctx context.Context) {
+	p.updateMetricsOnce.Do(func() {
+		go func(p *Pool) {
+			for {
+				p.updateMetrics(ctx)
+				select {
+				case <-ctx.Done():
+					return
+				case <-time.After(metricsUpdateInterval):
+				}
+			}
+		}(p)
+	})
+}
+
+// updateMetrics updates the metrics of the number of pending txs and the age of the oldest one
+func (p *Pool) updateMetrics(ctx context.Context) {
+	count, err := p.Storage.CountTransactionsByStatus(ctx, TxStatusPending)
+	if err != nil {
+		log.Errorf("failed to count pending txs to update the pool metrics, error: %v", err)
+		return
+	}
+	poolMetrics.PendingTxs(count)
+
+	receivedAt, err := p.Storage.GetOldestPendingTxReceivedAt(ctx)
+	if errors.Is(err, ErrNotFound) {
+		poolMetrics.OldestPendingTxAge(0)
+		return
+	} else if err != nil {
+		log.Errorf("failed to get the oldest pending tx to update the pool metrics, error: %v", err)
+		return
+	}
+	poolMetrics.OldestPendingTxAge(time.Since(receivedAt))
+}
+
+// StartPromotingScheduledTxsPeriodically will make this instance of the pool to move periodically
+// to the pending state the scheduled txs whose schedule has been reached, so they are loaded by the
+// sequencer and propagated to the peers. It's started by every pool instance, so the scheduled txs
+// received by the RPC only nodes are promoted too. The promotion runs until the context is done,
+// if it's called more than once only the first call starts the promotion
+func (p *Pool) StartPromotingScheduledTxsPeriodically(ctx context.Context) {
+	if !p.cfg.ScheduledTxs.Enabled {
+		return
+	}
+	p.promoteScheduledTxsOnce.Do(func() {
+		go func(p *Pool) {
+			for {
+				select {
+				case <-ctx.Done():
+					return
+				case <-time.After(p.cfg.ScheduledTxs.CheckInterval.Duration):
+					p.promoteScheduledTxs(ctx)
+				}
+			}
+		}(p)
+	})
+}
+
+// promoteScheduledTxs moves to the pending state the scheduled txs that are eligible to be sequenced
+func (p *Pool) promoteScheduledTxs(ctx context.Context) {
+	txs, err := p.Storage.GetTxsByStatus(ctx, TxStatusScheduled, 0)
+	if err != nil {
+		log.Errorf("failed to get the scheduled txs from the pool, error: %v", err)
+		return
+	}
+	if len(txs) == 0 {
+		return
+	}
+
+	lastL2Block, err := p.state.GetLastL2Block(ctx, nil)
+	if err != nil {
+		log.Errorf("failed to get the last L2 block to promote the scheduled txs, error: %v", err)
+		return
+	}
+
+	now := time.Now()
+	for _, tx := range txs {
+		if !tx.Schedule.isEligible(now, lastL2Block.NumberU64()) {
+			continue
+		}
+		// the tx is stored again instead of updating its status, so it's seen as a new
+		// pending tx by the cache and it's not evicted because of the time it was scheduled
+		tx.Status = TxStatusPending
+		tx.ReceivedAt = now
+		if err := p.addTxToStorage(ctx, tx); err != nil {
+			log.Errorf("failed to move the scheduled tx %s to pending, error: %v", tx.Hash().String(), err)
+			continue
+		}
+		log.Infof("scheduled tx %s moved to pending", tx.Hash().String())
+
+		// the scheduled txs were not propagated when they were added
+		for _, handler := range p.newTxEventHandlers {
+			handler(tx)
+		}
+	}
+}
+
+// CheckHealth returns an error if the pool storage is unreachable
+func (p *Pool) CheckHealth(ctx context.Context) error {
+	return p.Storage.Ping(ctx)
+}
+
+// canEvictLowestGasPriceTx returns true if the eviction of the lowest gas price tx is enabled and the
+// provided tx pays a higher gas price than the lowest gas price pending tx that can be evicted
+func (p *Pool) canEvictLowestGasPriceTx(ctx context.Context, poolTx Transaction) (bool, error) {
+	if !p.cfg.Eviction.EvictLowestGasPrice {
+		return false, nil
+	}
+
+	lowestTx, err := p.Storage.GetLowestGasPriceNonWIPPendingTx(ctx)
+	if errors.Is(err, ErrNotFound) {
+		return false, nil
+	} else if err != nil {
+		return false, err
+	}
+	return poolTx.GasPrice().Cmp(lowestTx.GasPrice()) > 0, nil
+}
+
+// evictLowestGasPriceTxIfFull deletes the lowest gas price pending tx that can be evicted while the pool is over the GlobalQueue limit
+func (p *Pool) evictLowestGasPriceTxIfFull(ctx context.Context) {
+	txCount, err := p.Storage.CountTransactionsByStatus(ctx, TxStatusPending)
+	if err != nil {
+		log.Errorf("failed to count pool txs by status pending while evicting txs from the pool, error: %v", err)
+		return
+	}
+
+	for ; txCount > p.cfg.GlobalQueue; txCount-- {
+		lowestTx, err := p.Storage.GetLowestGasPriceNonWIPPendingTx(ctx)
+		if errors.Is(err, ErrNotFound) {
+			return
+		} else if err != nil {
+			log.Errorf("failed to get the lowest gas price tx to evict from the pool, error: %v", err)
+			return
+		}
+
+		err = p.Storage.DeleteTransactionByHash(ctx, lowestTx.Hash())
+		if err != nil {
+			log.Errorf("failed to evict tx %s from the pool, error: %v", lowestTx.Hash().String(), err)
+			return
+		}
+		poolMetrics.TxsEvicted(1)
+		log.Infof("evicted tx %s with gas price %s from the pool because the pool is full", lowestTx.Hash().String(), lowestTx.GasPrice().String())
+	}
+}
+
+// IsAddressBlocked returns true if the address is in the in memory blocked addresses
+func (p *Pool) IsAddressBlocked(address common.Address) bool {
+	_, blocked := p.blockedAddresses.Load(address.String())
+	return blocked
 }
 
 // refreshBlockedAddresses refreshes the list of blocked addresses for the provided instance of pool
 func (p *Pool) refreshBlockedAddresses() {
-	blockedAddresses, err := p.storage.GetAllAddressesBlocked(context.Background())
+	blockedAddresses, err := p.Storage.GetAllAddressesBlocked(context.Background())
 	if err != nil {
 		log.Error("failed to load blocked addresses")
 		return
@@ -172,16 +420,157 @@ func (p *Pool) StartPollingMinSuggestedGasPrice(ctx context.Context) {
 
 // AddTx adds a transaction to the pool with the pending state
 func (p *Pool) AddTx(ctx context.Context, tx types.Transaction, ip string) error {
+	return p.addTx(ctx, *NewTransaction(tx, ip, false))
+}
+
+// AddLocalTx adds a local transaction to the pool with the pending state. Local txs are submitted
+// by the operator of the node, they are exempted from the gas price floors, from the pool size
+// limit and from the eviction
+func (p *Pool) AddLocalTx(ctx context.Context, tx types.Transaction, ip string) error {
+	poolTx := NewTransaction(tx, ip, false)
+	poolTx.IsLocal = true
+	return p.addTx(ctx, *poolTx)
+}
+
+// AddScheduledTx adds a transaction to the pool with the scheduled state. The tx is validated as
+// any other tx, but it's not provided to the sequencer until the schedule is reached, then it's
+// moved to the pending state. A tx with an empty schedule is added as pending
+func (p *Pool) AddScheduledTx(ctx context.Context, tx types.Transaction, ip string, schedule TxSchedule) error {
+	if !p.cfg.ScheduledTxs.Enabled {
+		return ErrScheduledTxsDisabled
+	}
+	if schedule.IsEmpty() {
+		return p.AddTx(ctx, tx, ip)
+	}
+
+	maxTimestamp := uint64(time.Now().Add(p.cfg.ScheduledTxs.MaxDelay.Duration).Unix())
+	if schedule.NotBeforeTimestamp > maxTimestamp {
+		return ErrScheduleTooFarAhead
+	}
+	if schedule.NotBeforeBlock > 0 {
+		lastL2Block, err := p.state.GetLastL2Block(ctx, nil)
+		if err != nil {
+			return err
+		}
+		if schedule.NotBeforeBlock > lastL2Block.NumberU64()+p.cfg.ScheduledTxs.MaxBlocksAhead {
+			return ErrScheduleTooFarAhead
+		}
+	}
+
 	poolTx := NewTransaction(tx, ip, false)
-	if err := p.validateTx(ctx, *poolTx); err != nil {
+	poolTx.Status = TxStatusScheduled
+	poolTx.Schedule = schedule
+	return p.addTx(ctx, *poolTx)
+}
+
+// AddConditionalTx adds a transaction to the pool that is only sequenced if its conditions are met. The
+// conditions are checked against the state of the next L2 block when the tx is added, and again by the
+// sequencer when the tx is selected. A tx with empty conditions is added as any other tx
+func (p *Pool) AddConditionalTx(ctx context.Context, tx types.Transaction, ip string, conditions TxConditions) error {
+	if !p.cfg.ConditionalTxs.Enabled {
+		return ErrConditionalTxsDisabled
+	}
+	if conditions.IsEmpty() {
+		return p.AddTx(ctx, tx, ip)
+	}
+
+	if p.cfg.ConditionalTxs.MaxKnownStorageSlots > 0 && conditions.knownStorageSlots() > p.cfg.ConditionalTxs.MaxKnownStorageSlots {
+		return ErrTooManyKnownStorageSlots
+	}
+
+	lastL2Block, err := p.state.GetLastL2Block(ctx, nil)
+	if err != nil {
+		return err
+	}
+	getStorageAt := func(address common.Address, slot common.Hash) (common.Hash, error) {
+		value, err := p.state.GetStorageAt(ctx, address, slot.Big(), lastL2Block.Root())
+		if err != nil {
+			return common.Hash{}, err
+		}
+		return common.BigToHash(value), nil
+	}
+	if err := conditions.Check(lastL2Block.NumberU64()+1, uint64(time.Now().Unix()), getStorageAt); err != nil {
+		return err
+	}
+
+	poolTx := NewTransaction(tx, ip, false)
+	poolTx.Conditions = conditions
+	return p.addTx(ctx, *poolTx)
+}
+
+func (p *Pool) addTx(ctx context.Context, poolTx Transaction) error {
+	if err := p.validateTx(ctx, poolTx); err != nil {
+		poolMetrics.TxRejected(rejectionReason(err))
+		return err
+	}
+
+	if err := p.storeTx(ctx, poolTx); err != nil {
+		poolMetrics.TxRejected(rejectionReason(err))
 		return err
 	}
+	poolMetrics.TxAdded()
 
-	return p.StoreTx(ctx, tx, ip, false)
+	p.markReplacedTxsAsFailed(ctx, poolTx.Transaction)
+
+	// scheduled txs are not propagated until they are moved to pending, the
+	// peers would provide them to their sequencer before the schedule
+	if poolTx.Status != TxStatusScheduled {
+		for _, handler := range p.newTxEventHandlers {
+			handler(poolTx)
+		}
+	}
+
+	if p.cfg.GlobalQueue > 0 && p.cfg.Eviction.EvictLowestGasPrice {
+		p.evictLowestGasPriceTxIfFull(ctx)
+	}
+	return nil
+}
+
+// RegisterNewTxEventHandler adds the provided handler to the list of handlers that
+// will be triggered when a new tx is added to the pool. It must be called before
+// the pool starts receiving txs
+func (p *Pool) RegisterNewTxEventHandler(h NewTxEventHandler) {
+	p.newTxEventHandlers = append(p.newTxEventHandlers, h)
+}
+
+// markReplacedTxsAsFailed sets as failed the pending and scheduled txs with the same sender and nonce than the provided
+// tx, as they have been replaced by it. The txs that are WIP are set as failed by the sequencer when
+// it loads the replacement tx
+func (p *Pool) markReplacedTxsAsFailed(ctx context.Context, tx types.Transaction) {
+	from, err := state.GetSender(tx)
+	if err != nil {
+		log.Errorf("failed to get sender of tx %s to mark the replaced txs, error: %v", tx.Hash().String(), err)
+		return
+	}
+
+	oldTxs, err := p.Storage.GetTxsByFromAndNonce(ctx, from, tx.Nonce())
+	if err != nil {
+		log.Errorf("failed to get the txs replaced by tx %s, error: %v", tx.Hash().String(), err)
+		return
+	}
+
+	failedReason := fmt.Sprintf("%s by tx %s", ErrReplacedTransaction.Error(), tx.Hash().String())
+	for _, oldTx := range oldTxs {
+		if oldTx.Hash() == tx.Hash() || (oldTx.Status != TxStatusPending && oldTx.Status != TxStatusScheduled) || oldTx.IsWIP {
+			continue
+		}
+		err := p.Storage.UpdateTxStatus(ctx, TxStatusUpdateInfo{Hash: oldTx.Hash(), NewStatus: TxStatusFailed, FailedReason: &failedReason})
+		if err != nil {
+			log.Errorf("failed to set as failed tx %s replaced by tx %s, error: %v", oldTx.Hash().String(), tx.Hash().String(), err)
+			continue
+		}
+		log.Infof("tx %s has been replaced by tx %s", oldTx.Hash().String(), tx.Hash().String())
+	}
 }
 
 // StoreTx adds a transaction to the pool with the pending state
 func (p *Pool) StoreTx(ctx context.Context, tx types.Transaction, ip string, isWIP bool) error {
+	return p.storeTx(ctx, *NewTransaction(tx, ip, isWIP))
+}
+
+func (p *Pool) storeTx(ctx context.Context, poolTx Transaction) error {
+	tx, ip := poolTx.Transaction, poolTx.IP
+
 	// Execute transaction to calculate its zkCounters
 	preExecutionResponse, err := p.preExecuteTx(ctx, tx)
 	if errors.Is(err, runtime.ErrIntrinsicInvalidBatchGasLimit) {
@@ -228,22 +617,24 @@ func (p *Pool) StoreTx(ctx context.Context, tx types.Transaction, ip string, isW
 		}
 	}
 
-	gasPrices, err := p.GetGasPrices(ctx)
-	if err != nil {
-		return err
-	}
+	// local txs are exempted from the gas price floors
+	if !poolTx.IsLocal {
+		gasPrices, err := p.GetGasPrices(ctx)
+		if err != nil {
+			return err
+		}
 
-	err = p.ValidateBreakEvenGasPrice(ctx, tx, preExecutionResponse.txResponse.GasUsed, gasPrices)
-	if err != nil {
-		return err
+		err = p.ValidateBreakEvenGasPrice(ctx, tx, preExecutionResponse.txResponse.GasUsed, gasPrices)
+		if err != nil {
+			return err
+		}
 	}
 
-	poolTx := NewTransaction(tx, ip, isWIP)
 	poolTx.GasUsed = preExecutionResponse.txResponse.GasUsed
 	poolTx.ZKCounters = preExecutionResponse.usedZKCounters
 	poolTx.ReservedZKCounters = preExecutionResponse.reservedZKCounters
 
-	return p.storage.AddTx(ctx, *poolTx)
+	return p.addTxToStorage(ctx, poolTx)
 }
 
 // ValidateBreakEvenGasPrice validates the effective gas price
@@ -351,28 +742,50 @@ func (p *Pool) preExecuteTx(ctx context.Context, tx types.Transaction) (preExecu
 // limit parameter is used to limit amount of pending txs from the db,
 // if limit = 0, then there is no limit
 func (p *Pool) GetPendingTxs(ctx context.Context, limit uint64) ([]Transaction, error) {
-	return p.storage.GetTxsByStatus(ctx, TxStatusPending, limit)
+	return p.Storage.GetTxsByStatus(ctx, TxStatusPending, limit)
+}
+
+// GetPendingAndQueuedTxs returns the txs with pending status grouped by sender and sorted by nonce. The txs that can
+// be executed now are returned as pending and the txs that are waiting for a nonce gap to be filled are returned as
+// queued, accordingly to the queued flag set when they are added to the pool and unset when the gap is filled.
+// limit parameter is used to limit amount of pending txs from the db, keeping the ones with the highest
+// gas price, if limit = 0, then there is no limit
+func (p *Pool) GetPendingAndQueuedTxs(ctx context.Context, limit uint64) (pending map[common.Address][]Transaction, queued map[common.Address][]Transaction, err error) {
+	txs, err := p.Storage.GetTxsByStatus(ctx, TxStatusPending, limit)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	pending, queued = splitPendingAndQueuedTxs(txs)
+	return pending, queued, nil
+}
+
+// GetQueuedTxs returns the pending txs that are waiting for a nonce gap to be filled
+// limit parameter is used to limit amount of queued txs from the db,
+// if limit = 0, then there is no limit
+func (p *Pool) GetQueuedTxs(ctx context.Context, limit uint64) ([]Transaction, error) {
+	return p.Storage.GetQueuedTxs(ctx, limit)
 }
 
-// GetNonWIPPendingTxs from the pool
+// GetNonWIPPendingTxs from the pool, the queued txs are not returned as they can't be executed yet
 func (p *Pool) GetNonWIPPendingTxs(ctx context.Context) ([]Transaction, error) {
-	return p.storage.GetNonWIPPendingTxs(ctx)
+	return p.Storage.GetNonWIPPendingTxs(ctx)
 }
 
 // GetSelectedTxs gets selected txs from the pool db
 func (p *Pool) GetSelectedTxs(ctx context.Context, limit uint64) ([]Transaction, error) {
-	return p.storage.GetTxsByStatus(ctx, TxStatusSelected, limit)
+	return p.Storage.GetTxsByStatus(ctx, TxStatusSelected, limit)
 }
 
 // GetPendingTxHashesSince returns the hashes of pending tx since the given date.
 func (p *Pool) GetPendingTxHashesSince(ctx context.Context, since time.Time) ([]common.Hash, error) {
-	return p.storage.GetPendingTxHashesSince(ctx, since)
+	return p.Storage.GetPendingTxHashesSince(ctx, since)
 }
 
 // UpdateTxStatus updates a transaction state accordingly to the
 // provided state and hash
 func (p *Pool) UpdateTxStatus(ctx context.Context, hash common.Hash, newStatus TxStatus, isWIP bool, failedReason *string) error {
-	return p.storage.UpdateTxStatus(ctx, TxStatusUpdateInfo{
+	return p.Storage.UpdateTxStatus(ctx, TxStatusUpdateInfo{
 		Hash:         hash,
 		NewStatus:    newStatus,
 		IsWIP:        isWIP,
@@ -382,29 +795,40 @@ func (p *Pool) UpdateTxStatus(ctx context.Context, hash common.Hash, newStatus T
 
 // SetGasPrices sets the current L2 Gas Price and L1 Gas Price
 func (p *Pool) SetGasPrices(ctx context.Context, l2GasPrice uint64, l1GasPrice uint64) error {
-	return p.storage.SetGasPrices(ctx, l2GasPrice, l1GasPrice)
+	return p.Storage.SetGasPrices(ctx, l2GasPrice, l1GasPrice)
 }
 
 // DeleteGasPricesHistoryOlderThan deletes gas prices older than a given date except the most recent one
 func (p *Pool) DeleteGasPricesHistoryOlderThan(ctx context.Context, date time.Time) error {
-	return p.storage.DeleteGasPricesHistoryOlderThan(ctx, date)
+	return p.Storage.DeleteGasPricesHistoryOlderThan(ctx, date)
 }
 
 // GetGasPrices returns the current L2 Gas Price and L1 Gas Price
 func (p *Pool) GetGasPrices(ctx context.Context) (GasPrices, error) {
-	l2GasPrice, l1GasPrice, err := p.storage.GetGasPrices(ctx)
+	l2GasPrice, l1GasPrice, err := p.Storage.GetGasPrices(ctx)
 	return GasPrices{L1GasPrice: l1GasPrice, L2GasPrice: l2GasPrice}, err
 }
 
 // CountPendingTransactions get number of pending transactions
 // used in bench tests
 func (p *Pool) CountPendingTransactions(ctx context.Context) (uint64, error) {
-	return p.storage.CountTransactionsByStatus(ctx, TxStatusPending)
+	return p.Storage.CountTransactionsByStatus(ctx, TxStatusPending)
+}
+
+// GetPendingNonce returns the nonce of the next tx of the address, that is the nonce after the
+// pending and selected txs of the address in the pool that are continuous from the provided
+// account nonce. The txs after a nonce gap are not counted as they can't be executed yet
+func (p *Pool) GetPendingNonce(ctx context.Context, address common.Address, accountNonce uint64) (uint64, error) {
+	nonces, err := p.Storage.GetNoncesByFrom(ctx, address)
+	if err != nil {
+		return 0, err
+	}
+	return nextContinuousNonce(nonces, accountNonce), nil
 }
 
 // IsTxPending check if tx is still pending
 func (p *Pool) IsTxPending(ctx context.Context, hash common.Hash) (bool, error) {
-	return p.storage.IsTxPending(ctx, hash)
+	return p.Storage.IsTxPending(ctx, hash)
 }
 
 func (p *Pool) validateTx(ctx context.Context, poolTx Transaction) error {
@@ -457,13 +881,41 @@ func (p *Pool) validateTx(ctx context.Context, poolTx Transaction) error {
 		return ErrNegativeValue
 	}
 
+	// Reject transactions that can't fit in a batch without having to execute them
+	if poolTx.Gas() > state.MaxTxGasLimit {
+		return ErrGasLimit
+	}
+
+	// Reject transactions with a fee over the configured cap, protecting the users from
+	// wrongly set gas prices
+	if exceedsTxFeeCap(poolTx.GasPrice(), poolTx.Gas(), p.cfg.TxFeeCap) {
+		log.Infof("%v: %v", ErrTxFeeCapExceeded.Error(), from.String())
+		return ErrTxFeeCapExceeded
+	}
+
 	// check if sender is blocked
-	_, blocked := p.blockedAddresses.Load(from.String())
-	if blocked {
+	if p.IsAddressBlocked(from) {
 		log.Infof("%v: %v", ErrBlockedSender.Error(), from.String())
 		return ErrBlockedSender
 	}
 
+	// check if recipient is blocked
+	if to := poolTx.To(); to != nil && p.IsAddressBlocked(*to) {
+		log.Infof("%v: %v", ErrBlockedRecipient.Error(), to.String())
+		return ErrBlockedRecipient
+	}
+
+	// check if sender is allowed when the allow-list mode is enabled
+	allowed, err := p.IsAddressAllowed(ctx, from)
+	if err != nil {
+		log.Errorf("failed to check if the sender %s is allowed, error: %v", from.String(), err)
+		return err
+	}
+	if !allowed {
+		log.Infof("%v: %v", ErrSenderNotAllowed.Error(), from.String())
+		return ErrSenderNotAllowed
+	}
+
 	lastL2Block, err := p.state.GetLastL2Block(ctx, nil)
 	if err != nil {
 		log.Errorf("failed to load last l2 block while adding tx to the pool", err)
@@ -482,7 +934,7 @@ func (p *Pool) validateTx(ctx context.Context, poolTx Transaction) error {
 
 	// check if sender has reached the limit of transactions in the pool
 	if p.cfg.AccountQueue > 0 {
-		// txCount, err := p.storage.CountTransactionsByFromAndStatus(ctx, from, TxStatusPending)
+		// txCount, err := p.Storage.CountTransactionsByFromAndStatus(ctx, from, TxStatusPending)
 		// if err != nil {
 		// 	return err
 		// }
@@ -497,27 +949,36 @@ func (p *Pool) validateTx(ctx context.Context, poolTx Transaction) error {
 		}
 	}
 
-	// check if the pool is full
-	if p.cfg.GlobalQueue > 0 {
-		txCount, err := p.storage.CountTransactionsByStatus(ctx, TxStatusPending)
+	// check if the pool is full, local txs are always accepted
+	if p.cfg.GlobalQueue > 0 && !poolTx.IsLocal {
+		txCount, err := p.Storage.CountTransactionsByStatus(ctx, TxStatusPending)
 		if err != nil {
 			log.Errorf("failed to count pool txs by status pending while adding tx to the pool", err)
 			return err
 		}
 		if txCount >= p.cfg.GlobalQueue {
-			return ErrTxPoolOverflow
+			canEvict, err := p.canEvictLowestGasPriceTx(ctx, poolTx)
+			if err != nil {
+				log.Errorf("failed to get the lowest gas price tx to evict while adding tx to the pool, error: %v", err)
+				return err
+			}
+			if !canEvict {
+				return ErrTxPoolOverflow
+			}
 		}
 	}
 
-	// Reject transactions with a gas price lower than the minimum gas price
-	p.minSuggestedGasPriceMux.RLock()
-	gasPriceCmp := poolTx.GasPrice().Cmp(p.minSuggestedGasPrice)
-	if gasPriceCmp == -1 {
-		log.Debugf("low gas price: minSuggestedGasPrice %v got %v", p.minSuggestedGasPrice, poolTx.GasPrice())
-	}
-	p.minSuggestedGasPriceMux.RUnlock()
-	if gasPriceCmp == -1 {
-		return ErrGasPrice
+	// Reject transactions with a gas price lower than the minimum gas price, except the local ones
+	if !poolTx.IsLocal {
+		p.minSuggestedGasPriceMux.RLock()
+		gasPriceCmp := poolTx.GasPrice().Cmp(p.minSuggestedGasPrice)
+		if gasPriceCmp == -1 {
+			log.Debugf("low gas price: minSuggestedGasPrice %v got %v", p.minSuggestedGasPrice, poolTx.GasPrice())
+		}
+		p.minSuggestedGasPriceMux.RUnlock()
+		if gasPriceCmp == -1 {
+			return ErrGasPrice
+		}
 	}
 
 	// Transactor should have enough funds to cover the costs
@@ -543,7 +1004,7 @@ func (p *Pool) validateTx(ctx context.Context, poolTx Transaction) error {
 
 	// try to get a transaction from the pool with the same nonce to check
 	// if the new one has a price bump
-	oldTxs, err := p.storage.GetTxsByFromAndNonce(ctx, from, poolTx.Nonce())
+	oldTxs, err := p.Storage.GetTxsByFromAndNonce(ctx, from, poolTx.Nonce())
 	if err != nil {
 		log.Errorf("failed to txs for the same account and nonce while adding tx to the pool", err)
 		return err
@@ -558,19 +1019,22 @@ func (p *Pool) validateTx(ctx context.Context, poolTx Transaction) error {
 			continue
 		}
 
-		oldTxPrice := new(big.Int).Mul(oldTx.GasPrice(), new(big.Int).SetUint64(oldTx.Gas()))
-		txPrice := new(big.Int).Mul(poolTx.GasPrice(), new(big.Int).SetUint64(poolTx.Gas()))
-
 		if oldTx.Hash() == poolTx.Hash() {
 			return ErrAlreadyKnown
 		}
 
-		// if old Tx Price is higher than the new poolTx price, it returns an error
-		if oldTxPrice.Cmp(txPrice) > 0 {
+		// the new tx must increase the gas price of the old tx at least by the PriceBump percentage
+		if !hasPriceBump(oldTx.GasPrice(), poolTx.GasPrice(), p.cfg.PriceBump) {
 			return ErrReplaceUnderpriced
 		}
 	}
 
+	// Throttle the IPs and the accounts flooding the pool once the tx is known to be new, so the txs
+	// sent again don't consume the tokens of the new ones
+	if err := p.checkRateLimits(poolTx, from); err != nil {
+		return err
+	}
+
 	// Executor field size requirements check
 	if err := p.checkTxFieldCompatibilityWithExecutor(ctx, poolTx.Transaction); err != nil {
 		return err
@@ -588,7 +1052,7 @@ func (p *Pool) pollMinSuggestedGasPrice(ctx context.Context) {
 		fromTimestamp = p.startTimestamp
 	}
 
-	l2GasPrice, err := p.storage.MinL2GasPriceSince(ctx, fromTimestamp)
+	l2GasPrice, err := p.Storage.MinL2GasPriceSince(ctx, fromTimestamp)
 	if err != nil {
 		if err == state.ErrNotFound {
 			log.Warnf("No suggested min gas price since: %v", fromTimestamp)
@@ -650,13 +1114,13 @@ func (p *Pool) DeleteReorgedTransactions(ctx context.Context, transactions []*ty
 		hashes = append(hashes, tx.Hash())
 	}
 
-	return p.storage.DeleteTransactionsByHashes(ctx, hashes)
+	return p.Storage.DeleteTransactionsByHashes(ctx, hashes)
 }
 
 // UpdateTxWIPStatus updates a transaction wip status accordingly to the
 // provided WIP status and hash
 func (p *Pool) UpdateTxWIPStatus(ctx context.Context, hash common.Hash, isWIP bool) error {
-	return p.storage.UpdateTxWIPStatus(ctx, hash, isWIP)
+	return p.Storage.UpdateTxWIPStatus(ctx, hash, isWIP)
 }
 
 // GetDefaultMinGasPriceAllowed return the configured DefaultMinGasPriceAllowed value
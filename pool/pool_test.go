@@ -258,6 +258,92 @@ func Test_AddTx(t *testing.T) {
 	assert.Equal(t, 1, c, "invalid number of txs in the pool")
 }
 
+func Test_AddTx_RateLimitAfterDedup(t *testing.T) {
+	ctx := context.Background()
+
+	data := prepareToExecuteTx(t, chainID.Uint64())
+	defer data.stateSqlDB.Close() //nolint:gosec,errcheck
+	defer data.poolSqlDB.Close()  //nolint:gosec,errcheck
+
+	s, err := pgpoolstorage.NewPostgresPoolStorage(poolDBCfg)
+	require.NoError(t, err)
+	rateLimitCfg := cfg
+	rateLimitCfg.RateLimit = pool.RateLimitCfg{SenderTxsPerSecond: 0.001, SenderBurst: 1, GossipPeersIPs: []string{"101.1.50.21"}}
+	p := setupPool(t, rateLimitCfg, bc, s, data.st, chainID.Uint64(), ctx, nil)
+
+	auth, err := operations.GetAuth(senderPrivateKey, chainID.Uint64())
+	require.NoError(t, err)
+	signTx := func(nonce uint64) ethTypes.Transaction {
+		tx := ethTypes.NewTransaction(nonce, common.HexToAddress(senderAddress), big.NewInt(0), gasLimit, gasPrice, nil)
+		signedTx, err := auth.Signer(auth.From, tx)
+		require.NoError(t, err)
+		return *signedTx
+	}
+
+	// the tx sent again is known, so it doesn't consume the only token of the sender
+	require.NoError(t, p.AddTx(ctx, signTx(0), ip))
+	require.ErrorIs(t, p.AddTx(ctx, signTx(0), ip), pool.ErrAlreadyKnown)
+	require.ErrorIs(t, p.AddTx(ctx, signTx(1), ip), pool.ErrTxRateLimitExceeded)
+
+	// the txs propagated by the gossip peers are not limited
+	require.NoError(t, p.AddTx(ctx, signTx(1), "101.1.50.21"))
+}
+
+func Test_AddTx_QueuedAfterNonceGap(t *testing.T) {
+	ctx := context.Background()
+
+	data := prepareToExecuteTx(t, chainID.Uint64())
+	defer data.stateSqlDB.Close() //nolint:gosec,errcheck
+	defer data.poolSqlDB.Close()  //nolint:gosec,errcheck
+
+	s, err := pgpoolstorage.NewPostgresPoolStorage(poolDBCfg)
+	require.NoError(t, err)
+	p := setupPool(t, cfg, bc, s, data.st, chainID.Uint64(), ctx, nil)
+
+	auth, err := operations.GetAuth(senderPrivateKey, chainID.Uint64())
+	require.NoError(t, err)
+	signTx := func(nonce uint64) ethTypes.Transaction {
+		tx := ethTypes.NewTransaction(nonce, common.HexToAddress(senderAddress), big.NewInt(0), gasLimit, gasPrice, nil)
+		signedTx, err := auth.Signer(auth.From, tx)
+		require.NoError(t, err)
+		return *signedTx
+	}
+
+	queuedHashes := func() []common.Hash {
+		txs, err := p.GetQueuedTxs(ctx, 0)
+		require.NoError(t, err)
+		hashes := []common.Hash{}
+		for _, tx := range txs {
+			hashes = append(hashes, tx.Hash())
+		}
+		return hashes
+	}
+
+	// the txs after the nonce gap are queued and they are not provided to the sequencer
+	tx1, tx2 := signTx(1), signTx(2)
+	require.NoError(t, p.AddTx(ctx, tx1, ip))
+	require.NoError(t, p.AddTx(ctx, tx2, ip))
+	assert.ElementsMatch(t, []common.Hash{tx1.Hash(), tx2.Hash()}, queuedHashes())
+	nonWIPTxs, err := p.GetNonWIPPendingTxs(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, nonWIPTxs)
+
+	// the tx filling the gap promotes the queued txs
+	tx0 := signTx(0)
+	require.NoError(t, p.AddTx(ctx, tx0, ip))
+	assert.Empty(t, queuedHashes())
+	nonWIPTxs, err = p.GetNonWIPPendingTxs(ctx)
+	require.NoError(t, err)
+	assert.Len(t, nonWIPTxs, 3)
+
+	pending, queued, err := p.GetPendingAndQueuedTxs(ctx, 0)
+	require.NoError(t, err)
+	from := common.HexToAddress(senderAddress)
+	require.Len(t, pending[from], 3)
+	assert.Equal(t, tx0.Hash(), pending[from][0].Hash())
+	assert.Empty(t, queued)
+}
+
 func Test_AddTx_OversizedData(t *testing.T) {
 	initOrResetDB(t)
 
@@ -1538,6 +1624,28 @@ func Test_BlockedAddress(t *testing.T) {
 	// allowed to add tx again
 	err = p.AddTx(ctx, *signedTx, ip)
 	require.NoError(t, err)
+
+	// block recipient
+	blockedRecipient := common.HexToAddress("0x1")
+	_, err = poolSqlDB.Exec(ctx, "INSERT INTO pool.blocked(addr) VALUES($1)", blockedRecipient.String())
+	require.NoError(t, err)
+
+	// wait it to refresh
+	time.Sleep(cfg.IntervalToRefreshBlockedAddresses.Duration)
+
+	// get blocked when try to add new tx to the blocked recipient
+	tx = ethTypes.NewTx(&ethTypes.LegacyTx{
+		Nonce:    2,
+		GasPrice: big.NewInt(0).SetInt64(int64(gasPrices.L2GasPrice)),
+		Gas:      24000,
+		To:       &blockedRecipient,
+		Value:    big.NewInt(1000),
+	})
+	signedTx, err = auth.Signer(auth.From, tx)
+	require.NoError(t, err)
+
+	err = p.AddTx(ctx, *signedTx, ip)
+	require.Equal(t, pool.ErrBlockedRecipient, err)
 }
 
 /*
@@ -1828,6 +1936,131 @@ func Test_AddTx_GlobalQueueLimit(t *testing.T) {
 	require.Error(t, err, pool.ErrTxPoolOverflow)
 }
 
+func Test_AddTx_GlobalQueueEvictLowestGasPrice(t *testing.T) {
+	eventStorage, err := nileventstorage.NewNilEventStorage()
+	if err != nil {
+		log.Fatal(err)
+	}
+	eventLog := event.NewEventLog(event.Config{}, eventStorage)
+
+	initOrResetDB(t)
+
+	stateSqlDB, err := db.NewSQLDB(stateDBCfg)
+	if err != nil {
+		panic(err)
+	}
+	defer stateSqlDB.Close() //nolint:gosec,errcheck
+
+	poolSqlDB, err := db.NewSQLDB(poolDBCfg)
+	require.NoError(t, err)
+	defer poolSqlDB.Close() //nolint:gosec,errcheck
+
+	st := newState(stateSqlDB, eventLog)
+
+	// generate accounts
+	accounts := map[common.Address]*ecdsa.PrivateKey{}
+	genesisActions := []*state.GenesisAction{
+		{
+			Address: senderAddress,
+			Type:    int(merkletree.LeafTypeBalance),
+			Value:   "1000000000000000000000",
+		},
+	}
+	for i := 0; i < int(cfg.GlobalQueue); i++ {
+		privateKey, err := crypto.GenerateKey()
+		require.NoError(t, err)
+		publicKey := privateKey.Public().(*ecdsa.PublicKey)
+		fromAddress := crypto.PubkeyToAddress(*publicKey)
+		accounts[fromAddress] = privateKey
+		genesisActions = append(genesisActions, &state.GenesisAction{
+			Address: fromAddress.String(),
+			Type:    int(merkletree.LeafTypeBalance),
+			Value:   "1000000000000000000000",
+		})
+	}
+
+	genesisBlock := state.Block{
+		BlockNumber: 0,
+		BlockHash:   state.ZeroHash,
+		ParentHash:  state.ZeroHash,
+		ReceivedAt:  time.Now(),
+	}
+	genesis := state.Genesis{
+		Actions: genesisActions,
+	}
+	ctx := context.Background()
+	dbTx, err := st.BeginStateTransaction(ctx)
+	require.NoError(t, err)
+	_, err = st.SetGenesis(ctx, genesisBlock, genesis, metrics.SynchronizerCallerLabel, dbTx)
+	require.NoError(t, err)
+	require.NoError(t, dbTx.Commit(ctx))
+
+	s, err := pgpoolstorage.NewPostgresPoolStorage(poolDBCfg)
+	require.NoError(t, err)
+
+	evictionCfg := cfg
+	evictionCfg.Eviction.EvictLowestGasPrice = true
+	p := setupPool(t, evictionCfg, bc, s, st, chainID.Uint64(), ctx, eventLog)
+
+	for _, privateKey := range accounts {
+		auth, err := bind.NewKeyedTransactorWithChainID(privateKey, chainID)
+		require.NoError(t, err)
+		tx := ethTypes.NewTx(&ethTypes.LegacyTx{
+			Nonce:    0,
+			Value:    big.NewInt(0),
+			Gas:      uint64(1000000),
+			GasPrice: gasPrice,
+		})
+
+		signedTx, err := auth.Signer(auth.From, tx)
+		require.NoError(t, err)
+
+		err = p.AddTx(ctx, *signedTx, ip)
+		require.NoError(t, err)
+	}
+
+	// A tx with a lower gas price than all the pending txs is rejected
+	tx := ethTypes.NewTx(&ethTypes.LegacyTx{
+		Nonce:    0,
+		Value:    big.NewInt(0),
+		Gas:      uint64(1000000),
+		GasPrice: big.NewInt(1),
+	})
+
+	privateKey, err := crypto.HexToECDSA(strings.TrimPrefix(senderPrivateKey, "0x"))
+	require.NoError(t, err)
+
+	auth, err := bind.NewKeyedTransactorWithChainID(privateKey, chainID)
+	require.NoError(t, err)
+
+	signedTx, err := auth.Signer(auth.From, tx)
+	require.NoError(t, err)
+
+	err = p.AddTx(ctx, *signedTx, ip)
+	require.ErrorIs(t, err, pool.ErrTxPoolOverflow)
+
+	// A tx with a higher gas price evicts one of the lowest gas price pending txs
+	tx = ethTypes.NewTx(&ethTypes.LegacyTx{
+		Nonce:    0,
+		Value:    big.NewInt(0),
+		Gas:      uint64(1000000),
+		GasPrice: new(big.Int).Add(gasPrice, big.NewInt(1)),
+	})
+	signedTx, err = auth.Signer(auth.From, tx)
+	require.NoError(t, err)
+
+	err = p.AddTx(ctx, *signedTx, ip)
+	require.NoError(t, err)
+
+	txCount, err := p.CountPendingTransactions(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, cfg.GlobalQueue, txCount)
+
+	isPending, err := p.IsTxPending(ctx, signedTx.Hash())
+	require.NoError(t, err)
+	assert.True(t, isPending)
+}
+
 func Test_AddTx_NonceTooHigh(t *testing.T) {
 	eventStorage, err := nileventstorage.NewNilEventStorage()
 	if err != nil {
@@ -2032,3 +2265,38 @@ func createSignedTx(t *testing.T, dataLen int, gasPrice *big.Int, gasLimit uint6
 	require.NoError(t, err)
 	return signedTx
 }
+
+func Test_PendingBlock(t *testing.T) {
+	ctx := context.Background()
+
+	initOrResetDB(t)
+
+	s, err := pgpoolstorage.NewPostgresPoolStorage(poolDBCfg)
+	require.NoError(t, err)
+
+	_, err = s.GetPendingBlock(ctx)
+	require.ErrorIs(t, err, pool.ErrNotFound)
+
+	now := time.Now().UTC().Round(time.Microsecond)
+	for _, number := range []uint64{10, 11} {
+		block := pool.PendingBlock{
+			Number:     number,
+			Hash:       common.BigToHash(big.NewInt(0).SetUint64(number)),
+			ParentHash: common.BigToHash(big.NewInt(0).SetUint64(number - 1)),
+			StateRoot:  common.HexToHash("0x1"),
+			Timestamp:  now,
+			TxHashes:   []common.Hash{common.HexToHash("0x2"), common.HexToHash("0x3")},
+			UpdatedAt:  now,
+		}
+		require.NoError(t, s.UpdatePendingBlock(ctx, block))
+
+		pendingBlock, err := s.GetPendingBlock(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, block.Number, pendingBlock.Number)
+		assert.Equal(t, block.Hash, pendingBlock.Hash)
+		assert.Equal(t, block.ParentHash, pendingBlock.ParentHash)
+		assert.Equal(t, block.StateRoot, pendingBlock.StateRoot)
+		assert.Equal(t, block.TxHashes, pendingBlock.TxHashes)
+		assert.True(t, block.Timestamp.Equal(pendingBlock.Timestamp))
+	}
+}
@@ -0,0 +1,73 @@
+package pool
+
+import (
+	"context"
+
+	"github.com/0xPolygonHermez/zkevm-node/log"
+	poolMetrics "github.com/0xPolygonHermez/zkevm-node/pool/metrics"
+	"github.com/0xPolygonHermez/zkevm-node/state"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// StartPruningNonceTooLowTxs will make this instance of the pool to mark as failed, every time a new
+// L2 block is added to the state, the txs of the senders of the block txs whose nonce is already lower
+// than the nonce of the sender in the state, so they are not tested again by the sequencer. If it's
+// called more than once only the first call starts the pruning
+func (p *Pool) StartPruningNonceTooLowTxs() {
+	if !p.cfg.PruneNonceTooLowTxs {
+		return
+	}
+	p.pruneNonceTooLowTxsOnce.Do(func() {
+		p.state.RegisterNewL2BlockEventHandler(p.pruneNonceTooLowTxs)
+		p.state.StartToMonitorNewL2Blocks()
+	})
+}
+
+// pruneNonceTooLowTxs marks as failed the pending and scheduled txs of the senders of the txs of the new
+// L2 block whose nonce is lower than the nonce of the sender after the block. The txs of the block are
+// skipped, as their status is updated by the sequencer
+func (p *Pool) pruneNonceTooLowTxs(event state.NewL2BlockEvent) {
+	ctx := context.Background()
+	blockTxs := make(map[common.Hash]struct{})
+	senders := make(map[common.Address]struct{})
+	for _, tx := range event.Block.Transactions() {
+		blockTxs[tx.Hash()] = struct{}{}
+		from, err := state.GetSender(*tx)
+		if err != nil {
+			log.Errorf("failed to get the sender of tx %s to prune the nonce too low txs, error: %v", tx.Hash().String(), err)
+			continue
+		}
+		senders[from] = struct{}{}
+	}
+
+	failedReason := ErrNonceTooLow.Error()
+	for from := range senders {
+		nonce, err := p.state.GetNonce(ctx, from, event.Block.Root())
+		if err != nil {
+			log.Errorf("failed to get the nonce of %s to prune the nonce too low txs, error: %v", from.String(), err)
+			continue
+		}
+		txs, err := p.Storage.GetNonWIPTxsByFromWithNonceLowerThan(ctx, from, nonce)
+		if err != nil {
+			log.Errorf("failed to get the nonce too low txs of %s from the pool, error: %v", from.String(), err)
+			continue
+		}
+
+		updateInfos := make([]TxStatusUpdateInfo, 0, len(txs))
+		for _, tx := range txs {
+			if _, found := blockTxs[tx.Hash()]; found {
+				continue
+			}
+			updateInfos = append(updateInfos, TxStatusUpdateInfo{Hash: tx.Hash(), NewStatus: TxStatusFailed, FailedReason: &failedReason})
+		}
+		if len(updateInfos) == 0 {
+			continue
+		}
+		if err := p.Storage.UpdateTxsStatus(ctx, updateInfos); err != nil {
+			log.Errorf("failed to mark as failed the nonce too low txs of %s, error: %v", from.String(), err)
+			continue
+		}
+		poolMetrics.TxsPruned(uint64(len(updateInfos)))
+		log.Infof("marked as failed %d nonce too low txs of %s after L2 block %d", len(updateInfos), from.String(), event.Block.NumberU64())
+	}
+}
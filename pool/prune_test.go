@@ -0,0 +1,117 @@
+package pool
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/0xPolygonHermez/zkevm-node/state"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// pruneStorageStub keeps the txs in memory and implements only the storage methods used by the pruning
+type pruneStorageStub struct {
+	Storage
+	txs map[common.Hash]Transaction
+}
+
+func (s *pruneStorageStub) GetNonWIPTxsByFromWithNonceLowerThan(ctx context.Context, from common.Address, nonce uint64) ([]Transaction, error) {
+	txs := make([]Transaction, 0)
+	for _, tx := range s.txs {
+		sender, err := state.GetSender(tx.Transaction)
+		if err != nil {
+			return nil, err
+		}
+		if sender == from && tx.Nonce() < nonce && !tx.IsWIP && (tx.Status == TxStatusPending || tx.Status == TxStatusScheduled) {
+			txs = append(txs, tx)
+		}
+	}
+	return txs, nil
+}
+
+func (s *pruneStorageStub) UpdateTxsStatus(ctx context.Context, updateInfos []TxStatusUpdateInfo) error {
+	for _, updateInfo := range updateInfos {
+		tx := s.txs[updateInfo.Hash]
+		tx.Status = updateInfo.NewStatus
+		tx.FailedReason = updateInfo.FailedReason
+		s.txs[updateInfo.Hash] = tx
+	}
+	return nil
+}
+
+// pruneStateStub returns the nonces of the accounts and keeps the registered new L2 block handlers
+type pruneStateStub struct {
+	stateInterface
+	nonces     map[common.Address]uint64
+	handlers   []state.NewL2BlockEventHandler
+	monitoring bool
+}
+
+func (s *pruneStateStub) GetNonce(ctx context.Context, address common.Address, root common.Hash) (uint64, error) {
+	return s.nonces[address], nil
+}
+
+func (s *pruneStateStub) RegisterNewL2BlockEventHandler(h state.NewL2BlockEventHandler) {
+	s.handlers = append(s.handlers, h)
+}
+
+func (s *pruneStateStub) StartToMonitorNewL2Blocks() {
+	s.monitoring = true
+}
+
+func TestPruneNonceTooLowTxs(t *testing.T) {
+	signer := types.NewEIP155Signer(big.NewInt(1000))
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	from := crypto.PubkeyToAddress(key.PublicKey)
+
+	newTx := func(nonce uint64, gasPrice int64, status TxStatus, isWIP bool) Transaction {
+		tx := types.NewTransaction(nonce, common.Address{}, big.NewInt(0), 21000, big.NewInt(gasPrice), nil)
+		signedTx, err := types.SignTx(tx, signer, key)
+		require.NoError(t, err)
+		poolTx := NewTransaction(*signedTx, "", isWIP)
+		poolTx.Status = status
+		return *poolTx
+	}
+
+	staleTx := newTx(0, 10, TxStatusPending, false)
+	staleScheduledTx := newTx(0, 20, TxStatusScheduled, false)
+	wipTx := newTx(0, 30, TxStatusPending, true)
+	blockTx := newTx(1, 10, TxStatusPending, false)
+	nextTx := newTx(2, 10, TxStatusPending, false)
+
+	storage := &pruneStorageStub{txs: map[common.Hash]Transaction{}}
+	for _, tx := range []Transaction{staleTx, staleScheduledTx, wipTx, blockTx, nextTx} {
+		storage.txs[tx.Hash()] = tx
+	}
+	st := &pruneStateStub{nonces: map[common.Address]uint64{from: 2}}
+
+	p := &Pool{Storage: storage, state: st}
+	p.StartPruningNonceTooLowTxs()
+	assert.Empty(t, st.handlers)
+	assert.False(t, st.monitoring)
+
+	p = &Pool{Storage: storage, state: st, cfg: Config{PruneNonceTooLowTxs: true}}
+	p.StartPruningNonceTooLowTxs()
+	p.StartPruningNonceTooLowTxs()
+	require.Len(t, st.handlers, 1)
+	assert.True(t, st.monitoring)
+
+	header := state.NewL2Header(&types.Header{Number: big.NewInt(1), Root: common.HexToHash("0x1")})
+	block := state.NewL2BlockWithHeader(header).WithBody([]*types.Transaction{&blockTx.Transaction}, nil)
+	st.handlers[0](state.NewL2BlockEvent{Block: *block})
+
+	for _, tx := range []Transaction{staleTx, staleScheduledTx} {
+		prunedTx := storage.txs[tx.Hash()]
+		assert.Equal(t, TxStatusFailed, prunedTx.Status)
+		require.NotNil(t, prunedTx.FailedReason)
+		assert.Equal(t, ErrNonceTooLow.Error(), *prunedTx.FailedReason)
+	}
+	for _, tx := range []Transaction{wipTx, blockTx, nextTx} {
+		assert.Equal(t, TxStatusPending, storage.txs[tx.Hash()].Status)
+	}
+}
@@ -0,0 +1,89 @@
+package pool
+
+import (
+	"sync"
+	"time"
+
+	"github.com/0xPolygonHermez/zkevm-node/log"
+	"github.com/ethereum/go-ethereum/common"
+	"golang.org/x/time/rate"
+)
+
+// rateLimiterCleanupInterval is the min time between cleanups of the idle buckets of a rateLimiter
+const rateLimiterCleanupInterval = time.Minute
+
+// rateLimiter is a token bucket rate limiter with a bucket per key (e.g. IP or sender address).
+// The buckets that are full again are removed periodically to keep the memory bounded
+type rateLimiter struct {
+	mu          sync.Mutex
+	limit       rate.Limit
+	burst       int
+	buckets     map[string]*rate.Limiter
+	lastCleanup time.Time
+}
+
+// newRateLimiter creates a rateLimiter allowing txsPerSecond per key with the provided burst,
+// it returns nil if txsPerSecond is 0 as the rate limit is disabled
+func newRateLimiter(txsPerSecond float64, burst uint64) *rateLimiter {
+	if txsPerSecond == 0 {
+		return nil
+	}
+	if burst == 0 {
+		burst = 1
+	}
+	return &rateLimiter{
+		limit:       rate.Limit(txsPerSecond),
+		burst:       int(burst),
+		buckets:     make(map[string]*rate.Limiter),
+		lastCleanup: time.Now(),
+	}
+}
+
+// allow returns true if the bucket of the key has a token left and consumes it
+func (r *rateLimiter) allow(key string) bool {
+	if r == nil {
+		return true
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	now := time.Now()
+	if now.Sub(r.lastCleanup) > rateLimiterCleanupInterval {
+		r.cleanup(now)
+	}
+
+	bucket, found := r.buckets[key]
+	if !found {
+		bucket = rate.NewLimiter(r.limit, r.burst)
+		r.buckets[key] = bucket
+	}
+	return bucket.AllowN(now, 1)
+}
+
+// cleanup removes the buckets that are full, as they behave the same than a new bucket
+func (r *rateLimiter) cleanup(now time.Time) {
+	for key, bucket := range r.buckets {
+		if bucket.TokensAt(now) >= float64(r.burst) {
+			delete(r.buckets, key)
+		}
+	}
+	r.lastCleanup = now
+}
+
+// checkRateLimits consumes a token of the buckets of the IP and the sender of the tx. The local txs
+// and the txs propagated by the gossip peers, already throttled by the peers, are never throttled
+func (p *Pool) checkRateLimits(poolTx Transaction, from common.Address) error {
+	if _, found := p.gossipPeersIPs[poolTx.IP]; poolTx.IsLocal || found {
+		return nil
+	}
+	if poolTx.IP != "" && !p.ipRateLimiter.allow(poolTx.IP) {
+		log.Infof("%v: ip %v", ErrTxRateLimitExceeded.Error(), poolTx.IP)
+		return ErrTxRateLimitExceeded
+	}
+	if !p.senderRateLimiter.allow(from.String()) {
+		log.Infof("%v: %v", ErrTxRateLimitExceeded.Error(), from.String())
+		return ErrTxRateLimitExceeded
+	}
+	return nil
+}
@@ -0,0 +1,48 @@
+package pool
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_rateLimiter(t *testing.T) {
+	disabled := newRateLimiter(0, 10)
+	assert.Nil(t, disabled)
+	assert.True(t, disabled.allow("key"))
+
+	r := newRateLimiter(1, 2)
+	assert.True(t, r.allow("key1"))
+	assert.True(t, r.allow("key1"))
+	assert.False(t, r.allow("key1"))
+	// the buckets are independent
+	assert.True(t, r.allow("key2"))
+
+	// only the full buckets are removed
+	r.cleanup(time.Now())
+	assert.Len(t, r.buckets, 2)
+	r.cleanup(time.Now().Add(10 * time.Second))
+	assert.Len(t, r.buckets, 0)
+}
+
+func Test_checkRateLimits(t *testing.T) {
+	p := &Pool{
+		ipRateLimiter:     newRateLimiter(1, 1),
+		senderRateLimiter: newRateLimiter(1, 2),
+		gossipPeersIPs:    map[string]struct{}{"10.0.0.2": {}},
+	}
+	sender := common.HexToAddress("0x1")
+
+	assert.NoError(t, p.checkRateLimits(Transaction{IP: "10.0.0.1"}, sender))
+	assert.ErrorIs(t, p.checkRateLimits(Transaction{IP: "10.0.0.1"}, sender), ErrTxRateLimitExceeded)
+
+	// the txs propagated by the gossip peers and the local ones don't consume the tokens of the sender
+	for i := 0; i < 3; i++ {
+		assert.NoError(t, p.checkRateLimits(Transaction{IP: "10.0.0.2"}, sender))
+		assert.NoError(t, p.checkRateLimits(Transaction{IP: "10.0.0.1", IsLocal: true}, sender))
+	}
+	assert.NoError(t, p.checkRateLimits(Transaction{IP: "10.0.0.3"}, sender))
+	assert.ErrorIs(t, p.checkRateLimits(Transaction{IP: "10.0.0.4"}, sender), ErrTxRateLimitExceeded)
+}
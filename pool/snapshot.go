@@ -0,0 +1,117 @@
+package pool
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/0xPolygonHermez/zkevm-node/hex"
+	"github.com/0xPolygonHermez/zkevm-node/state"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// snapshotStatuses are the statuses of the txs exported to a snapshot, the txs
+// that are not processed yet by the sequencer
+var snapshotStatuses = []TxStatus{TxStatusPending, TxStatusScheduled}
+
+// snapshotTx is the representation of a pool tx in a snapshot
+type snapshotTx struct {
+	Encoded            string           `json:"encoded"`
+	Status             TxStatus         `json:"status"`
+	ReceivedAt         time.Time        `json:"receivedAt"`
+	IP                 string           `json:"ip"`
+	IsLocal            bool             `json:"isLocal"`
+	ZKCounters         state.ZKCounters `json:"zkCounters"`
+	ReservedZKCounters state.ZKCounters `json:"reservedZkCounters"`
+	NotBeforeTimestamp uint64           `json:"notBeforeTimestamp,omitempty"`
+	NotBeforeBlock     uint64           `json:"notBeforeBlock,omitempty"`
+	Conditions         *TxConditions    `json:"conditions,omitempty"`
+}
+
+// ExportTxs writes to w the pending and scheduled txs of the storage, one JSON object
+// per line, so they can be restored with ImportTxs in another pool. It returns the
+// number of txs exported
+func ExportTxs(ctx context.Context, s Storage, w io.Writer) (uint64, error) {
+	encoder := json.NewEncoder(w)
+	count := uint64(0)
+	for _, status := range snapshotStatuses {
+		txs, err := s.GetTxsByStatus(ctx, status, 0)
+		if err != nil {
+			return count, fmt.Errorf("failed to get %s txs: %w", status, err)
+		}
+		for _, tx := range txs {
+			b, err := tx.MarshalBinary()
+			if err != nil {
+				return count, fmt.Errorf("failed to encode tx %s: %w", tx.Hash().String(), err)
+			}
+			stx := snapshotTx{
+				Encoded:            hex.EncodeToHex(b),
+				Status:             tx.Status,
+				ReceivedAt:         tx.ReceivedAt,
+				IP:                 tx.IP,
+				IsLocal:            tx.IsLocal,
+				ZKCounters:         tx.ZKCounters,
+				ReservedZKCounters: tx.ReservedZKCounters,
+				NotBeforeTimestamp: tx.Schedule.NotBeforeTimestamp,
+				NotBeforeBlock:     tx.Schedule.NotBeforeBlock,
+			}
+			if !tx.Conditions.IsEmpty() {
+				conditions := tx.Conditions
+				stx.Conditions = &conditions
+			}
+			if err := encoder.Encode(stx); err != nil {
+				return count, fmt.Errorf("failed to write tx %s: %w", tx.Hash().String(), err)
+			}
+			count++
+		}
+	}
+	return count, nil
+}
+
+// ImportTxs adds to the storage the txs read from r, written by ExportTxs. The txs keep
+// the status, the received timestamp, the counters and the conditions they had in the exported pool, the
+// txs already in the storage are overwritten. It returns the number of txs imported
+func ImportTxs(ctx context.Context, s Storage, r io.Reader) (uint64, error) {
+	decoder := json.NewDecoder(bufio.NewReader(r))
+	count := uint64(0)
+	for {
+		var stx snapshotTx
+		err := decoder.Decode(&stx)
+		if err == io.EOF {
+			return count, nil
+		} else if err != nil {
+			return count, fmt.Errorf("failed to read tx %d: %w", count, err)
+		}
+
+		if stx.Status != TxStatusPending && stx.Status != TxStatusScheduled {
+			return count, fmt.Errorf("invalid status %s of tx %d", stx.Status, count)
+		}
+
+		b, err := hex.DecodeHex(stx.Encoded)
+		if err != nil {
+			return count, fmt.Errorf("failed to decode tx %d: %w", count, err)
+		}
+		var tx types.Transaction
+		if err := tx.UnmarshalBinary(b); err != nil {
+			return count, fmt.Errorf("failed to decode tx %d: %w", count, err)
+		}
+
+		poolTx := NewTransaction(tx, stx.IP, false)
+		poolTx.Status = stx.Status
+		poolTx.ReceivedAt = stx.ReceivedAt
+		poolTx.IsLocal = stx.IsLocal
+		poolTx.ZKCounters = stx.ZKCounters
+		poolTx.ReservedZKCounters = stx.ReservedZKCounters
+		poolTx.Schedule = TxSchedule{NotBeforeTimestamp: stx.NotBeforeTimestamp, NotBeforeBlock: stx.NotBeforeBlock}
+		if stx.Conditions != nil {
+			poolTx.Conditions = *stx.Conditions
+		}
+		if err := s.AddTx(ctx, *poolTx); err != nil {
+			return count, fmt.Errorf("failed to add tx %s: %w", tx.Hash().String(), err)
+		}
+		count++
+	}
+}
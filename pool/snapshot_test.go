@@ -0,0 +1,74 @@
+package pool
+
+import (
+	"bytes"
+	"context"
+	"math/big"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/0xPolygonHermez/zkevm-node/state"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExportAndImportTxs(t *testing.T) {
+	ctx := context.Background()
+	receivedAt := time.Unix(1700000000, 123456789).UTC()
+
+	newTx := func(nonce uint64, status TxStatus) Transaction {
+		tx := types.NewTransaction(nonce, common.HexToAddress("0x1"), big.NewInt(1), 21000, big.NewInt(10), nil)
+		poolTx := NewTransaction(*tx, "127.0.0.1", false)
+		poolTx.Status = status
+		poolTx.ReceivedAt = receivedAt
+		poolTx.ZKCounters = state.ZKCounters{GasUsed: 21000, Steps: 100}
+		poolTx.ReservedZKCounters = state.ZKCounters{GasUsed: 21000, Steps: 200}
+		return *poolTx
+	}
+	pendingTx := newTx(0, TxStatusPending)
+	pendingTx.IsLocal = true
+	scheduledTx := newTx(1, TxStatusScheduled)
+	scheduledTx.Schedule = TxSchedule{NotBeforeTimestamp: 1700000100, NotBeforeBlock: 10}
+	conditionalTx := newTx(3, TxStatusPending)
+	conditionalTx.Conditions = TxConditions{
+		KnownAccounts:  map[common.Address]map[common.Hash]common.Hash{common.HexToAddress("0x2"): {common.HexToHash("0x1"): common.HexToHash("0x2")}},
+		BlockNumberMax: 100,
+		TimestampMin:   1700000000,
+	}
+	failedTx := newTx(2, TxStatusFailed)
+
+	source := &storageStub{txs: map[common.Hash]Transaction{}}
+	for _, tx := range []Transaction{pendingTx, scheduledTx, conditionalTx, failedTx} {
+		require.NoError(t, source.AddTx(ctx, tx))
+	}
+
+	var snapshot bytes.Buffer
+	exported, err := ExportTxs(ctx, source, &snapshot)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(3), exported)
+
+	target := &storageStub{txs: map[common.Hash]Transaction{}}
+	imported, err := ImportTxs(ctx, target, &snapshot)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(3), imported)
+	require.Len(t, target.txs, 3)
+
+	for _, expected := range []Transaction{pendingTx, scheduledTx, conditionalTx} {
+		tx, found := target.txs[expected.Hash()]
+		require.True(t, found)
+		assert.Equal(t, expected.Status, tx.Status)
+		assert.True(t, expected.ReceivedAt.Equal(tx.ReceivedAt))
+		assert.Equal(t, expected.IP, tx.IP)
+		assert.Equal(t, expected.IsLocal, tx.IsLocal)
+		assert.Equal(t, expected.ZKCounters, tx.ZKCounters)
+		assert.Equal(t, expected.ReservedZKCounters, tx.ReservedZKCounters)
+		assert.Equal(t, expected.Schedule, tx.Schedule)
+		assert.Equal(t, expected.Conditions, tx.Conditions)
+	}
+
+	_, err = ImportTxs(ctx, target, strings.NewReader(`{"encoded":"0x00","status":"failed"}`))
+	assert.ErrorContains(t, err, "invalid status")
+}
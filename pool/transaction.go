@@ -17,6 +17,8 @@ const (
 	TxStatusSelected TxStatus = "selected"
 	// TxStatusFailed represents a tx that has been failed after processing
 	TxStatusFailed TxStatus = "failed"
+	// TxStatusScheduled represents a tx that is held in the pool until its schedule is reached
+	TxStatusScheduled TxStatus = "scheduled"
 )
 
 // TxStatus represents the state of a tx
@@ -35,6 +37,13 @@ type TxStatusUpdateInfo struct {
 	FailedReason *string
 }
 
+// TxStatusChange represents a change of the status of a tx in the pool
+type TxStatusChange struct {
+	Status       TxStatus
+	FailedReason *string
+	ChangedAt    time.Time
+}
+
 // Transaction represents a pool tx
 type Transaction struct {
 	types.Transaction
@@ -44,8 +53,94 @@ type Transaction struct {
 	ReceivedAt            time.Time
 	PreprocessedStateRoot common.Hash
 	IsWIP                 bool
+	IsLocal               bool
 	IP                    string
 	FailedReason          *string
+	Schedule              TxSchedule
+	Conditions            TxConditions
+	// Queued is true when the tx is pending but it can't be executed until the txs with the
+	// previous nonces of the sender are added to the pool
+	Queued bool
+}
+
+// TxSchedule represents the conditions a scheduled tx must meet to be provided to the sequencer, a
+// zero value means no condition
+type TxSchedule struct {
+	// NotBeforeTimestamp is the unix timestamp in seconds before which the tx is not sequenced
+	NotBeforeTimestamp uint64
+	// NotBeforeBlock is the L2 block number before which the tx is not sequenced
+	NotBeforeBlock uint64
+}
+
+// IsEmpty returns true if the schedule has no conditions
+func (s TxSchedule) IsEmpty() bool {
+	return s.NotBeforeTimestamp == 0 && s.NotBeforeBlock == 0
+}
+
+// isEligible returns true if the schedule conditions are met at the provided time and L2 block number
+func (s TxSchedule) isEligible(now time.Time, blockNumber uint64) bool {
+	return uint64(now.Unix()) >= s.NotBeforeTimestamp && blockNumber >= s.NotBeforeBlock
+}
+
+// TxConditions represents the conditions a conditional tx must meet to be sequenced, they are checked
+// against the L2 block the tx is going to be added to, a zero value means no condition
+type TxConditions struct {
+	// KnownAccounts are the expected storage values of the accounts, by storage slot
+	KnownAccounts map[common.Address]map[common.Hash]common.Hash `json:"knownAccounts,omitempty"`
+	// BlockNumberMin is the min L2 block number the tx can be sequenced in
+	BlockNumberMin uint64 `json:"blockNumberMin,omitempty"`
+	// BlockNumberMax is the max L2 block number the tx can be sequenced in
+	BlockNumberMax uint64 `json:"blockNumberMax,omitempty"`
+	// TimestampMin is the min timestamp of the L2 block the tx can be sequenced in
+	TimestampMin uint64 `json:"timestampMin,omitempty"`
+	// TimestampMax is the max timestamp of the L2 block the tx can be sequenced in
+	TimestampMax uint64 `json:"timestampMax,omitempty"`
+}
+
+// IsEmpty returns true if the tx has no conditions
+func (c TxConditions) IsEmpty() bool {
+	return len(c.KnownAccounts) == 0 && c.BlockNumberMin == 0 && c.BlockNumberMax == 0 && c.TimestampMin == 0 && c.TimestampMax == 0
+}
+
+// knownStorageSlots returns the number of storage slots checked by the known accounts
+func (c TxConditions) knownStorageSlots() uint64 {
+	var slots uint64
+	for _, storage := range c.KnownAccounts {
+		slots += uint64(len(storage))
+	}
+	return slots
+}
+
+// MinNotReached returns true if the L2 block with the provided number and timestamp is before the min
+// block number or timestamp of the conditions, but not after their max block number or timestamp
+func (c TxConditions) MinNotReached(blockNumber, timestamp uint64) bool {
+	if (c.BlockNumberMax > 0 && blockNumber > c.BlockNumberMax) || (c.TimestampMax > 0 && timestamp > c.TimestampMax) {
+		return false
+	}
+	return blockNumber < c.BlockNumberMin || timestamp < c.TimestampMin
+}
+
+// Check returns an error if the conditions are not met in the L2 block with the provided number and
+// timestamp, the current storage values of the known accounts are read with getStorageAt
+func (c TxConditions) Check(blockNumber, timestamp uint64, getStorageAt func(address common.Address, slot common.Hash) (common.Hash, error)) error {
+	if blockNumber < c.BlockNumberMin || (c.BlockNumberMax > 0 && blockNumber > c.BlockNumberMax) {
+		return ErrBlockNumberConditionNotMet
+	}
+	if timestamp < c.TimestampMin || (c.TimestampMax > 0 && timestamp > c.TimestampMax) {
+		return ErrTimestampConditionNotMet
+	}
+	for address, storage := range c.KnownAccounts {
+		for slot, expectedValue := range storage {
+			value, err := getStorageAt(address, slot)
+			if err != nil {
+				return err
+			}
+			if value != expectedValue {
+				return ErrKnownAccountsConditionNotMet
+			}
+		}
+	}
+	return nil
 }
 
 // NewTransaction creates a new transaction
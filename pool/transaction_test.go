@@ -0,0 +1,120 @@
+package pool
+
+import (
+	"context"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/0xPolygonHermez/zkevm-node/state"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/jackc/pgx/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_txScheduleIsEligible(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	testCases := []struct {
+		name        string
+		schedule    TxSchedule
+		blockNumber uint64
+		expected    bool
+	}{
+		{name: "empty schedule", schedule: TxSchedule{}, blockNumber: 0, expected: true},
+		{name: "timestamp reached", schedule: TxSchedule{NotBeforeTimestamp: 1700000000}, blockNumber: 0, expected: true},
+		{name: "timestamp not reached", schedule: TxSchedule{NotBeforeTimestamp: 1700000001}, blockNumber: 0, expected: false},
+		{name: "block reached", schedule: TxSchedule{NotBeforeBlock: 10}, blockNumber: 10, expected: true},
+		{name: "block not reached", schedule: TxSchedule{NotBeforeBlock: 11}, blockNumber: 10, expected: false},
+		{name: "timestamp reached but block not reached", schedule: TxSchedule{NotBeforeTimestamp: 1700000000, NotBeforeBlock: 11}, blockNumber: 10, expected: false},
+		{name: "timestamp and block reached", schedule: TxSchedule{NotBeforeTimestamp: 1699999999, NotBeforeBlock: 9}, blockNumber: 10, expected: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, tc.schedule.isEligible(now, tc.blockNumber))
+		})
+	}
+}
+
+// lastL2BlockStateStub returns the provided block as the last L2 block and the provided nonces as the nonces of the accounts
+type lastL2BlockStateStub struct {
+	stateInterface
+	lastL2Block *state.L2Block
+	nonces      map[common.Address]uint64
+}
+
+func (s *lastL2BlockStateStub) GetLastL2Block(ctx context.Context, dbTx pgx.Tx) (*state.L2Block, error) {
+	return s.lastL2Block, nil
+}
+
+func (s *lastL2BlockStateStub) GetNonce(ctx context.Context, address common.Address, root common.Hash) (uint64, error) {
+	return s.nonces[address], nil
+}
+
+func TestPromoteScheduledTxs(t *testing.T) {
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	newTx := func(nonce uint64, schedule TxSchedule) Transaction {
+		signedTx, err := types.SignTx(types.NewTransaction(nonce, common.Address{}, big.NewInt(0), 21000, big.NewInt(1), nil), types.NewEIP155Signer(big.NewInt(1000)), key)
+		require.NoError(t, err)
+		tx := NewTransaction(*signedTx, "", false)
+		tx.Status = TxStatusScheduled
+		tx.Schedule = schedule
+		return *tx
+	}
+	eligibleTx := newTx(0, TxSchedule{NotBeforeBlock: 10})
+	notEligibleTx := newTx(1, TxSchedule{NotBeforeBlock: 11})
+
+	storage := &storageStub{txs: map[common.Hash]Transaction{}}
+	for _, tx := range []Transaction{eligibleTx, notEligibleTx} {
+		storage.txs[tx.Hash()] = tx
+	}
+	header := state.NewL2Header(&types.Header{Number: big.NewInt(10)})
+	st := &lastL2BlockStateStub{lastL2Block: state.NewL2BlockWithHeader(header)}
+
+	p := &Pool{Storage: storage, state: st}
+	var newTxs []common.Hash
+	p.RegisterNewTxEventHandler(func(tx Transaction) {
+		newTxs = append(newTxs, tx.Hash())
+	})
+	p.promoteScheduledTxs(context.Background())
+
+	assert.Equal(t, TxStatusPending, storage.txs[eligibleTx.Hash()].Status)
+	assert.Equal(t, TxStatusScheduled, storage.txs[notEligibleTx.Hash()].Status)
+	assert.Equal(t, []common.Hash{eligibleTx.Hash()}, newTxs)
+}
+
+func Test_txConditionsCheck(t *testing.T) {
+	address := common.HexToAddress("0x1")
+	slot := common.HexToHash("0x2")
+	getStorageAt := func(common.Address, common.Hash) (common.Hash, error) {
+		return common.HexToHash("0x3"), nil
+	}
+	testCases := []struct {
+		name          string
+		conditions    TxConditions
+		expectedErr   error
+		minNotReached bool
+	}{
+		{name: "empty conditions", conditions: TxConditions{}},
+		{name: "block number in range", conditions: TxConditions{BlockNumberMin: 10, BlockNumberMax: 10}},
+		{name: "block number below min", conditions: TxConditions{BlockNumberMin: 11}, expectedErr: ErrBlockNumberConditionNotMet, minNotReached: true},
+		{name: "block number above max", conditions: TxConditions{BlockNumberMax: 9}, expectedErr: ErrBlockNumberConditionNotMet},
+		{name: "timestamp in range", conditions: TxConditions{TimestampMin: 1000, TimestampMax: 1000}},
+		{name: "timestamp below min", conditions: TxConditions{TimestampMin: 1001}, expectedErr: ErrTimestampConditionNotMet, minNotReached: true},
+		{name: "timestamp above max", conditions: TxConditions{TimestampMax: 999}, expectedErr: ErrTimestampConditionNotMet},
+		{name: "block number below min and timestamp above max", conditions: TxConditions{BlockNumberMin: 11, TimestampMax: 999}, expectedErr: ErrBlockNumberConditionNotMet},
+		{name: "known storage value", conditions: TxConditions{KnownAccounts: map[common.Address]map[common.Hash]common.Hash{address: {slot: common.HexToHash("0x3")}}}},
+		{name: "unknown storage value", conditions: TxConditions{KnownAccounts: map[common.Address]map[common.Hash]common.Hash{address: {slot: common.HexToHash("0x4")}}}, expectedErr: ErrKnownAccountsConditionNotMet},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.ErrorIs(t, tc.conditions.Check(10, 1000, getStorageAt), tc.expectedErr)
+			assert.Equal(t, tc.minNotReached, tc.conditions.MinNotReached(10, 1000))
+		})
+	}
+}
@@ -0,0 +1,470 @@
+package pool
+
+import (
+	"bytes"
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/0xPolygonHermez/zkevm-node/log"
+	"github.com/0xPolygonHermez/zkevm-node/state"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// cacheLoadRetryInterval is the time to wait before retrying a failed load of the cache when the
+// periodic refresh is disabled
+const cacheLoadRetryInterval = time.Second
+
+// cachedTx is a tx stored in the txCache together with its sender
+type cachedTx struct {
+	tx   Transaction
+	from common.Address
+}
+
+// txCache is an in-memory index of the pending and selected txs of the pool, by hash,
+// by sender and by gas price (higher gas price first)
+type txCache struct {
+	txs      map[common.Hash]*cachedTx
+	bySender map[common.Address]map[common.Hash]*cachedTx
+	byPrice  []*cachedTx
+}
+
+// newTxCache creates an empty txCache
+func newTxCache() *txCache {
+	return &txCache{
+		txs:      make(map[common.Hash]*cachedTx),
+		bySender: make(map[common.Address]map[common.Hash]*cachedTx),
+	}
+}
+
+// isCachedStatus returns true if the txs with the provided status are kept in the txCache
+func isCachedStatus(status TxStatus) bool {
+	return status == TxStatusPending || status == TxStatusSelected
+}
+
+// hasHigherPriority returns true if tx1 goes before tx2 in the byPrice index
+func hasHigherPriority(tx1 *cachedTx, tx2 *cachedTx) bool {
+	if cmp := tx1.tx.GasPrice().Cmp(tx2.tx.GasPrice()); cmp != 0 {
+		return cmp > 0
+	}
+	hash1, hash2 := tx1.tx.Hash(), tx2.tx.Hash()
+	return bytes.Compare(hash1[:], hash2[:]) < 0
+}
+
+// add adds the tx to the txCache, replacing the tx with the same hash if exists.
+// Txs with a status that is not cached are ignored
+func (c *txCache) add(tx Transaction, from common.Address) {
+	c.delete(tx.Hash())
+	if !isCachedStatus(tx.Status) {
+		return
+	}
+
+	ct := &cachedTx{tx: tx, from: from}
+	c.txs[tx.Hash()] = ct
+	if _, found := c.bySender[from]; !found {
+		c.bySender[from] = make(map[common.Hash]*cachedTx)
+	}
+	c.bySender[from][tx.Hash()] = ct
+
+	i := sort.Search(len(c.byPrice), func(i int) bool { return hasHigherPriority(ct, c.byPrice[i]) })
+	c.byPrice = append(c.byPrice, nil)
+	copy(c.byPrice[i+1:], c.byPrice[i:])
+	c.byPrice[i] = ct
+}
+
+// delete removes the tx with the provided hash from the txCache
+func (c *txCache) delete(hash common.Hash) {
+	ct, found := c.txs[hash]
+	if !found {
+		return
+	}
+
+	delete(c.txs, hash)
+	delete(c.bySender[ct.from], hash)
+	if len(c.bySender[ct.from]) == 0 {
+		delete(c.bySender, ct.from)
+	}
+
+	i := sort.Search(len(c.byPrice), func(i int) bool { return !hasHigherPriority(c.byPrice[i], ct) })
+	if i < len(c.byPrice) && c.byPrice[i] == ct {
+		c.byPrice = append(c.byPrice[:i], c.byPrice[i+1:]...)
+	}
+}
+
+// updateStatus updates the status of the tx with the provided hash, removing it from the txCache
+// if the new status is not cached
+func (c *txCache) updateStatus(updateInfo TxStatusUpdateInfo) {
+	ct, found := c.txs[updateInfo.Hash]
+	if !found {
+		return
+	}
+	if !isCachedStatus(updateInfo.NewStatus) {
+		c.delete(updateInfo.Hash)
+		return
+	}
+	ct.tx.Status = updateInfo.NewStatus
+	ct.tx.IsWIP = updateInfo.IsWIP
+	if updateInfo.FailedReason != nil {
+		ct.tx.FailedReason = updateInfo.FailedReason
+	}
+}
+
+// promoteQueued unsets the queued flag of the cached txs of the sender with a nonce lower than the provided one
+func (c *txCache) promoteQueued(from common.Address, nonce uint64) {
+	for _, ct := range c.bySender[from] {
+		if ct.tx.Nonce() < nonce {
+			ct.tx.Queued = false
+		}
+	}
+}
+
+// nonce returns the next nonce of the provided address accordingly to the cached txs
+func (c *txCache) nonce(address common.Address) uint64 {
+	txs, found := c.bySender[address]
+	if !found {
+		return 0
+	}
+
+	nonce := uint64(0)
+	for _, ct := range txs {
+		if ct.tx.Nonce()+1 > nonce {
+			nonce = ct.tx.Nonce() + 1
+		}
+	}
+	return nonce
+}
+
+// nonces returns the nonces of the cached txs of the sender sorted in ascending order
+func (c *txCache) nonces(address common.Address) []uint64 {
+	nonces := make([]uint64, 0, len(c.bySender[address]))
+	found := make(map[uint64]bool, len(c.bySender[address]))
+	for _, ct := range c.bySender[address] {
+		if !found[ct.tx.Nonce()] {
+			found[ct.tx.Nonce()] = true
+			nonces = append(nonces, ct.tx.Nonce())
+		}
+	}
+	sort.Slice(nonces, func(i, j int) bool { return nonces[i] < nonces[j] })
+	return nonces
+}
+
+// txsByStatus returns the cached txs with the provided status sorted by gas price (higher first).
+// If the limit is 0 all the txs are returned
+func (c *txCache) txsByStatus(status TxStatus, limit uint64) []Transaction {
+	txs := make([]Transaction, 0)
+	for _, ct := range c.byPrice {
+		if limit > 0 && uint64(len(txs)) >= limit {
+			break
+		}
+		if ct.tx.Status == status {
+			txs = append(txs, ct.tx)
+		}
+	}
+	return txs
+}
+
+// countByStatus returns the number of cached txs with any of the provided statuses
+func (c *txCache) countByStatus(status ...TxStatus) uint64 {
+	count := uint64(0)
+	for _, ct := range c.txs {
+		for _, s := range status {
+			if ct.tx.Status == s {
+				count++
+				break
+			}
+		}
+	}
+	return count
+}
+
+// cachedStorage is a write-through cache of the pending and selected txs layered over the pool
+// storage, which remains the durable store. The hot read paths (pending nonce, pending txs
+// sorted by gas price, count of pending txs) are served from memory. As the storage can be
+// shared with other instances of the pool (e.g. RPC and sequencer running in different
+// processes), the cache is reloaded periodically from the storage to get their changes
+type cachedStorage struct {
+	Storage
+
+	cache  *txCache
+	loaded bool
+	// cacheMux protects the cache
+	cacheMux sync.RWMutex
+	// refreshMux prevents the changes done while loading the cache from the storage from being lost
+	refreshMux sync.RWMutex
+}
+
+// newCachedStorage creates a cachedStorage layered over the provided storage. The cache is not
+// used until it's loaded from the storage for the first time
+func newCachedStorage(s Storage) *cachedStorage {
+	return &cachedStorage{
+		Storage: s,
+		cache:   newTxCache(),
+	}
+}
+
+// startRefreshing loads the cache from the storage and reloads it every interval until ctx is done. If
+// the interval is 0 the cache is loaded only once and then it's only updated by the writes of this instance
+func (s *cachedStorage) startRefreshing(ctx context.Context, interval time.Duration) {
+	for {
+		err := s.refresh(ctx)
+		if err != nil {
+			log.Errorf("failed to load pool txs cache from the storage, error: %v", err)
+		} else if interval == 0 {
+			return
+		}
+
+		wait := interval
+		if interval == 0 {
+			wait = cacheLoadRetryInterval
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(wait):
+		}
+	}
+}
+
+// refresh replaces the content of the cache with the pending and selected txs of the storage
+func (s *cachedStorage) refresh(ctx context.Context) error {
+	s.refreshMux.Lock()
+	defer s.refreshMux.Unlock()
+
+	cache := newTxCache()
+	for _, status := range []TxStatus{TxStatusPending, TxStatusSelected} {
+		txs, err := s.Storage.GetTxsByStatus(ctx, status, 0)
+		if err != nil {
+			return err
+		}
+		for _, tx := range txs {
+			from, err := state.GetSender(tx.Transaction)
+			if err != nil {
+				return err
+			}
+			cache.add(tx, from)
+		}
+	}
+
+	s.cacheMux.Lock()
+	s.cache = cache
+	s.loaded = true
+	s.cacheMux.Unlock()
+	return nil
+}
+
+// AddTx adds the tx to the storage and to the cache
+func (s *cachedStorage) AddTx(ctx context.Context, tx Transaction) error {
+	s.refreshMux.RLock()
+	defer s.refreshMux.RUnlock()
+
+	if err := s.Storage.AddTx(ctx, tx); err != nil {
+		return err
+	}
+
+	from, err := state.GetSender(tx.Transaction)
+	if err != nil {
+		return err
+	}
+
+	s.cacheMux.Lock()
+	s.cache.add(tx, from)
+	s.cacheMux.Unlock()
+	return nil
+}
+
+// UpdateTxStatus updates the status of the tx in the storage and in the cache
+func (s *cachedStorage) UpdateTxStatus(ctx context.Context, updateInfo TxStatusUpdateInfo) error {
+	s.refreshMux.RLock()
+	defer s.refreshMux.RUnlock()
+
+	if err := s.Storage.UpdateTxStatus(ctx, updateInfo); err != nil {
+		return err
+	}
+
+	s.cacheMux.Lock()
+	s.cache.updateStatus(updateInfo)
+	s.cacheMux.Unlock()
+	return nil
+}
+
+// UpdateTxsStatus updates the status of the txs in the storage and in the cache
+func (s *cachedStorage) UpdateTxsStatus(ctx context.Context, updateInfos []TxStatusUpdateInfo) error {
+	s.refreshMux.RLock()
+	defer s.refreshMux.RUnlock()
+
+	err := s.Storage.UpdateTxsStatus(ctx, updateInfos)
+
+	// the storage could have updated some of the txs before failing, so the cache is updated
+	// anyway. Wrong updates are fixed on the next refresh
+	s.cacheMux.Lock()
+	for _, updateInfo := range updateInfos {
+		s.cache.updateStatus(updateInfo)
+	}
+	s.cacheMux.Unlock()
+	return err
+}
+
+// UpdateTxWIPStatus updates the WIP status of the tx in the storage and in the cache
+func (s *cachedStorage) UpdateTxWIPStatus(ctx context.Context, hash common.Hash, isWIP bool) error {
+	s.refreshMux.RLock()
+	defer s.refreshMux.RUnlock()
+
+	if err := s.Storage.UpdateTxWIPStatus(ctx, hash, isWIP); err != nil {
+		return err
+	}
+
+	s.cacheMux.Lock()
+	if ct, found := s.cache.txs[hash]; found {
+		ct.tx.IsWIP = isWIP
+	}
+	s.cacheMux.Unlock()
+	return nil
+}
+
+// PromoteQueuedTxs unsets the queued flag of the txs of the sender with a nonce lower than the provided
+// one in the storage and in the cache
+func (s *cachedStorage) PromoteQueuedTxs(ctx context.Context, from common.Address, nonce uint64) error {
+	s.refreshMux.RLock()
+	defer s.refreshMux.RUnlock()
+
+	if err := s.Storage.PromoteQueuedTxs(ctx, from, nonce); err != nil {
+		return err
+	}
+
+	s.cacheMux.Lock()
+	s.cache.promoteQueued(from, nonce)
+	s.cacheMux.Unlock()
+	return nil
+}
+
+// MarkWIPTxsAsPending resets the WIP status of all the txs in the storage and in the cache
+func (s *cachedStorage) MarkWIPTxsAsPending(ctx context.Context) error {
+	s.refreshMux.RLock()
+	defer s.refreshMux.RUnlock()
+
+	if err := s.Storage.MarkWIPTxsAsPending(ctx); err != nil {
+		return err
+	}
+
+	s.cacheMux.Lock()
+	for _, ct := range s.cache.txs {
+		ct.tx.IsWIP = false
+	}
+	s.cacheMux.Unlock()
+	return nil
+}
+
+// DeleteTransactionsByHashes deletes the txs from the storage and from the cache
+func (s *cachedStorage) DeleteTransactionsByHashes(ctx context.Context, hashes []common.Hash) error {
+	s.refreshMux.RLock()
+	defer s.refreshMux.RUnlock()
+
+	if err := s.Storage.DeleteTransactionsByHashes(ctx, hashes); err != nil {
+		return err
+	}
+
+	s.cacheMux.Lock()
+	for _, hash := range hashes {
+		s.cache.delete(hash)
+	}
+	s.cacheMux.Unlock()
+	return nil
+}
+
+// DeleteTransactionByHash deletes the tx from the storage and from the cache
+func (s *cachedStorage) DeleteTransactionByHash(ctx context.Context, hash common.Hash) error {
+	s.refreshMux.RLock()
+	defer s.refreshMux.RUnlock()
+
+	if err := s.Storage.DeleteTransactionByHash(ctx, hash); err != nil {
+		return err
+	}
+
+	s.cacheMux.Lock()
+	s.cache.delete(hash)
+	s.cacheMux.Unlock()
+	return nil
+}
+
+// DeleteNonWIPTransactionsByStatusOlderThan deletes the non WIP and non local txs with the provided status received
+// before the provided date from the storage and from the cache
+func (s *cachedStorage) DeleteNonWIPTransactionsByStatusOlderThan(ctx context.Context, status TxStatus, date time.Time) (uint64, error) {
+	s.refreshMux.RLock()
+	defer s.refreshMux.RUnlock()
+
+	count, err := s.Storage.DeleteNonWIPTransactionsByStatusOlderThan(ctx, status, date)
+	if err != nil {
+		return 0, err
+	}
+
+	s.cacheMux.Lock()
+	for hash, ct := range s.cache.txs {
+		if ct.tx.Status == status && !ct.tx.IsWIP && !ct.tx.IsLocal && ct.tx.ReceivedAt.Before(date) {
+			s.cache.delete(hash)
+		}
+	}
+	s.cacheMux.Unlock()
+	return count, nil
+}
+
+// GetNonce gets the nonce to the provided address accordingly to the txs in the pool
+func (s *cachedStorage) GetNonce(ctx context.Context, address common.Address) (uint64, error) {
+	s.cacheMux.RLock()
+	defer s.cacheMux.RUnlock()
+
+	if !s.loaded {
+		return s.Storage.GetNonce(ctx, address)
+	}
+	return s.cache.nonce(address), nil
+}
+
+// GetNoncesByFrom returns the nonces of the pending and selected txs of the sender sorted in ascending order
+func (s *cachedStorage) GetNoncesByFrom(ctx context.Context, from common.Address) ([]uint64, error) {
+	s.cacheMux.RLock()
+	defer s.cacheMux.RUnlock()
+
+	if !s.loaded {
+		return s.Storage.GetNoncesByFrom(ctx, from)
+	}
+	return s.cache.nonces(from), nil
+}
+
+// GetTxsByStatus returns the txs with the provided status sorted by gas price (higher first)
+func (s *cachedStorage) GetTxsByStatus(ctx context.Context, status TxStatus, limit uint64) ([]Transaction, error) {
+	s.cacheMux.RLock()
+	defer s.cacheMux.RUnlock()
+
+	if !s.loaded || !isCachedStatus(status) {
+		return s.Storage.GetTxsByStatus(ctx, status, limit)
+	}
+	return s.cache.txsByStatus(status, limit), nil
+}
+
+// CountTransactionsByStatus gets the number of txs accordingly to the provided statuses
+func (s *cachedStorage) CountTransactionsByStatus(ctx context.Context, status ...TxStatus) (uint64, error) {
+	s.cacheMux.RLock()
+	defer s.cacheMux.RUnlock()
+
+	if !s.loaded {
+		return s.Storage.CountTransactionsByStatus(ctx, status...)
+	}
+	for _, st := range status {
+		if !isCachedStatus(st) {
+			return s.Storage.CountTransactionsByStatus(ctx, status...)
+		}
+	}
+	return s.cache.countByStatus(status...), nil
+}
+
+// IsTxPending determines if the tx associated to the given hash is pending or not
+func (s *cachedStorage) IsTxPending(ctx context.Context, hash common.Hash) (bool, error) {
+	s.cacheMux.RLock()
+	defer s.cacheMux.RUnlock()
+
+	if !s.loaded {
+		return s.Storage.IsTxPending(ctx, hash)
+	}
+	ct, found := s.cache.txs[hash]
+	return found && ct.tx.Status == TxStatusPending, nil
+}
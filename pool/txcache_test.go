@@ -0,0 +1,191 @@
+package pool
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"math/big"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/0xPolygonHermez/zkevm-node/state"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// storageStub keeps the txs in memory and implements only the storage methods used by the cachedStorage
+type storageStub struct {
+	Storage
+	txs   map[common.Hash]Transaction
+	reads int
+}
+
+func (s *storageStub) AddTx(ctx context.Context, tx Transaction) error {
+	s.txs[tx.Hash()] = tx
+	return nil
+}
+
+func (s *storageStub) GetTxsByStatus(ctx context.Context, status TxStatus, limit uint64) ([]Transaction, error) {
+	s.reads++
+	txs := []Transaction{}
+	for _, tx := range s.txs {
+		if tx.Status == status {
+			txs = append(txs, tx)
+		}
+	}
+	return txs, nil
+}
+
+func (s *storageStub) GetNonce(ctx context.Context, address common.Address) (uint64, error) {
+	s.reads++
+	return 0, nil
+}
+
+func (s *storageStub) GetNoncesByFrom(ctx context.Context, from common.Address) ([]uint64, error) {
+	s.reads++
+	nonces := []uint64{}
+	for _, tx := range s.txs {
+		if sender, err := state.GetSender(tx.Transaction); err == nil && sender == from && isCachedStatus(tx.Status) {
+			nonces = append(nonces, tx.Nonce())
+		}
+	}
+	sort.Slice(nonces, func(i, j int) bool { return nonces[i] < nonces[j] })
+	return nonces, nil
+}
+
+func (s *storageStub) PromoteQueuedTxs(ctx context.Context, from common.Address, nonce uint64) error {
+	for hash, tx := range s.txs {
+		if sender, err := state.GetSender(tx.Transaction); err == nil && sender == from && tx.Nonce() < nonce {
+			tx.Queued = false
+			s.txs[hash] = tx
+		}
+	}
+	return nil
+}
+
+func (s *storageStub) UpdateTxStatus(ctx context.Context, updateInfo TxStatusUpdateInfo) error {
+	tx := s.txs[updateInfo.Hash]
+	tx.Status = updateInfo.NewStatus
+	s.txs[updateInfo.Hash] = tx
+	return nil
+}
+
+func (s *storageStub) DeleteTransactionByHash(ctx context.Context, hash common.Hash) error {
+	delete(s.txs, hash)
+	return nil
+}
+
+func Test_cachedStorage(t *testing.T) {
+	ctx := context.Background()
+	signer := types.NewEIP155Signer(big.NewInt(1000))
+	key1, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	key2, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	addr1 := crypto.PubkeyToAddress(key1.PublicKey)
+	addr2 := crypto.PubkeyToAddress(key2.PublicKey)
+
+	newTx := func(nonce uint64, gasPrice int64, key *ecdsa.PrivateKey) Transaction {
+		tx := types.NewTransaction(nonce, common.Address{}, big.NewInt(0), 21000, big.NewInt(gasPrice), nil)
+		signedTx, err := types.SignTx(tx, signer, key)
+		require.NoError(t, err)
+		return *NewTransaction(*signedTx, "", false)
+	}
+
+	// tx added by another instance of the pool before loading the cache
+	stub := &storageStub{txs: map[common.Hash]Transaction{}}
+	tx0 := newTx(0, 10, key1)
+	require.NoError(t, stub.AddTx(ctx, tx0))
+
+	s := newCachedStorage(stub)
+
+	// the storage is used until the cache is loaded
+	_, err = s.GetNonce(ctx, addr1)
+	require.NoError(t, err)
+	assert.Equal(t, 1, stub.reads)
+
+	require.NoError(t, s.refresh(ctx))
+	stub.reads = 0
+
+	tx1 := newTx(1, 30, key1)
+	tx2 := newTx(0, 20, key2)
+	tx3 := newTx(1, 5, key2)
+	for _, tx := range []Transaction{tx1, tx2, tx3} {
+		require.NoError(t, s.AddTx(ctx, tx))
+	}
+
+	nonce, err := s.GetNonce(ctx, addr1)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(2), nonce)
+	nonces, err := s.GetNoncesByFrom(ctx, addr2)
+	require.NoError(t, err)
+	assert.Equal(t, []uint64{0, 1}, nonces)
+
+	txs, err := s.GetTxsByStatus(ctx, TxStatusPending, 0)
+	require.NoError(t, err)
+	require.Len(t, txs, 4)
+	assert.Equal(t, []common.Hash{tx1.Hash(), tx2.Hash(), tx0.Hash(), tx3.Hash()}, []common.Hash{txs[0].Hash(), txs[1].Hash(), txs[2].Hash(), txs[3].Hash()})
+
+	txs, err = s.GetTxsByStatus(ctx, TxStatusPending, 2)
+	require.NoError(t, err)
+	assert.Len(t, txs, 2)
+
+	// selected txs are still counted for the nonce but are not pending anymore
+	require.NoError(t, s.UpdateTxStatus(ctx, TxStatusUpdateInfo{Hash: tx1.Hash(), NewStatus: TxStatusSelected}))
+	pending, err := s.IsTxPending(ctx, tx1.Hash())
+	require.NoError(t, err)
+	assert.False(t, pending)
+	nonce, err = s.GetNonce(ctx, addr1)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(2), nonce)
+
+	// invalid and deleted txs are removed from the cache
+	require.NoError(t, s.UpdateTxStatus(ctx, TxStatusUpdateInfo{Hash: tx3.Hash(), NewStatus: TxStatusInvalid}))
+	require.NoError(t, s.DeleteTransactionByHash(ctx, tx2.Hash()))
+	nonce, err = s.GetNonce(ctx, addr2)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(0), nonce)
+
+	count, err := s.CountTransactionsByStatus(ctx, TxStatusPending)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(1), count)
+	count, err = s.CountTransactionsByStatus(ctx, TxStatusPending, TxStatusSelected)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(2), count)
+	assert.Equal(t, 0, stub.reads)
+
+	// changes done by other instances are loaded on refresh
+	tx4 := newTx(2, 1, key1)
+	require.NoError(t, stub.AddTx(ctx, tx4))
+	require.NoError(t, s.refresh(ctx))
+	nonce, err = s.GetNonce(ctx, addr1)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(3), nonce)
+}
+
+func Test_cachedStorageStopsRefreshing(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	s := newCachedStorage(&storageStub{txs: map[common.Hash]Transaction{}})
+
+	stopped := make(chan struct{})
+	go func() {
+		s.startRefreshing(ctx, time.Hour)
+		close(stopped)
+	}()
+	require.Eventually(t, func() bool {
+		s.cacheMux.RLock()
+		defer s.cacheMux.RUnlock()
+		return s.loaded
+	}, time.Second, time.Millisecond)
+
+	// the cache is not reloaded anymore once the context is done
+	cancel()
+	select {
+	case <-stopped:
+	case <-time.After(time.Second):
+		t.Fatal("the cache is still refreshing after the context is done")
+	}
+}
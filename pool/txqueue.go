@@ -0,0 +1,136 @@
+package pool
+
+import (
+	"context"
+	"sort"
+
+	"github.com/0xPolygonHermez/zkevm-node/log"
+	"github.com/0xPolygonHermez/zkevm-node/state"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// StartPromotingQueuedTxs will make this instance of the pool to promote, every time a new L2 block is
+// added to the state, the queued txs of the senders of the block txs whose nonce gap has been filled by
+// the block, e.g. by a forced tx or by a tx added to the pool by another instance of the pool. If it's
+// called more than once only the first call starts the promotion
+func (p *Pool) StartPromotingQueuedTxs() {
+	p.promoteQueuedTxsOnce.Do(func() {
+		p.state.RegisterNewL2BlockEventHandler(p.promoteQueuedTxsAfterL2Block)
+		p.state.StartToMonitorNewL2Blocks()
+	})
+}
+
+// promoteQueuedTxsAfterL2Block promotes the queued txs of the senders of the txs of the new L2 block
+// accordingly to the nonce of the sender after the block
+func (p *Pool) promoteQueuedTxsAfterL2Block(event state.NewL2BlockEvent) {
+	ctx := context.Background()
+	senders := make(map[common.Address]struct{})
+	for _, tx := range event.Block.Transactions() {
+		from, err := state.GetSender(*tx)
+		if err != nil {
+			log.Errorf("failed to get the sender of tx %s to promote the queued txs, error: %v", tx.Hash().String(), err)
+			continue
+		}
+		senders[from] = struct{}{}
+	}
+
+	for from := range senders {
+		nonce, err := p.state.GetNonce(ctx, from, event.Block.Root())
+		if err != nil {
+			log.Errorf("failed to get the nonce of %s to promote the queued txs, error: %v", from.String(), err)
+			continue
+		}
+		if err := p.promoteQueuedTxs(ctx, from, nonce); err != nil {
+			log.Errorf("failed to promote the queued txs of %s after L2 block %d, error: %v", from.String(), event.Block.NumberU64(), err)
+		}
+	}
+}
+
+// addTxToStorage adds the tx to the storage, setting it as queued if it comes after a nonce gap of its
+// sender, and promotes the queued txs of the sender whose nonce gap has been filled by the tx
+func (p *Pool) addTxToStorage(ctx context.Context, poolTx Transaction) error {
+	from, err := state.GetSender(poolTx.Transaction)
+	if err != nil {
+		return err
+	}
+	lastL2Block, err := p.state.GetLastL2Block(ctx, nil)
+	if err != nil {
+		return err
+	}
+	accountNonce, err := p.state.GetNonce(ctx, from, lastL2Block.Root())
+	if err != nil {
+		return err
+	}
+	nonces, err := p.Storage.GetNoncesByFrom(ctx, from)
+	if err != nil {
+		return err
+	}
+	poolTx.Queued = poolTx.Nonce() > nextContinuousNonce(nonces, accountNonce)
+
+	if err := p.Storage.AddTx(ctx, poolTx); err != nil {
+		return err
+	}
+
+	// queued txs are promoted too, as the txs filling their nonce gap could have been added meanwhile
+	if err := p.promoteQueuedTxs(ctx, from, accountNonce); err != nil {
+		log.Errorf("failed to promote the queued txs of %s after adding tx %s, error: %v", from.String(), poolTx.Hash().String(), err)
+	}
+	return nil
+}
+
+// promoteQueuedTxs unsets the queued flag of the txs of the sender that are continuous from the provided
+// account nonce. The tx with the account nonce is always promoted, as it can be executed even if it's
+// not known by this instance of the pool yet
+func (p *Pool) promoteQueuedTxs(ctx context.Context, from common.Address, accountNonce uint64) error {
+	nonces, err := p.Storage.GetNoncesByFrom(ctx, from)
+	if err != nil {
+		return err
+	}
+	nonce := nextContinuousNonce(nonces, accountNonce)
+	if nonce == accountNonce {
+		nonce++
+	}
+	return p.Storage.PromoteQueuedTxs(ctx, from, nonce)
+}
+
+// splitPendingAndQueuedTxs groups the txs by sender sorted by nonce and splits them in the txs that can be
+// executed (pending) and the txs that are waiting for a nonce gap to be filled (queued)
+func splitPendingAndQueuedTxs(txs []Transaction) (pending map[common.Address][]Transaction, queued map[common.Address][]Transaction) {
+	pending = make(map[common.Address][]Transaction)
+	queued = make(map[common.Address][]Transaction)
+
+	for _, tx := range txs {
+		from, err := state.GetSender(tx.Transaction)
+		if err != nil {
+			continue
+		}
+		if tx.Queued {
+			queued[from] = append(queued[from], tx)
+		} else {
+			pending[from] = append(pending[from], tx)
+		}
+	}
+
+	for _, txsByAddr := range []map[common.Address][]Transaction{pending, queued} {
+		for _, addrTxs := range txsByAddr {
+			sort.SliceStable(addrTxs, func(i, j int) bool { return addrTxs[i].Nonce() < addrTxs[j].Nonce() })
+		}
+	}
+
+	return pending, queued
+}
+
+// nextContinuousNonce returns the nonce after the highest nonce of the sorted nonces that is
+// continuous from the provided account nonce
+func nextContinuousNonce(sortedNonces []uint64, accountNonce uint64) uint64 {
+	nonce := accountNonce
+	for _, n := range sortedNonces {
+		if n < nonce {
+			continue
+		} else if n > nonce {
+			break
+		}
+		nonce++
+	}
+	return nonce
+}
@@ -0,0 +1,170 @@
+package pool
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"math/big"
+	"sort"
+	"testing"
+
+	"github.com/0xPolygonHermez/zkevm-node/state"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/trie"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_splitPendingAndQueuedTxs(t *testing.T) {
+	signer := types.NewEIP155Signer(big.NewInt(1000))
+	key1, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	key2, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	addr1 := crypto.PubkeyToAddress(key1.PublicKey)
+	addr2 := crypto.PubkeyToAddress(key2.PublicKey)
+
+	newTx := func(nonce uint64, key *ecdsa.PrivateKey, queued bool) Transaction {
+		tx := types.NewTransaction(nonce, common.Address{}, big.NewInt(0), 21000, big.NewInt(1), nil)
+		signedTx, err := types.SignTx(tx, signer, key)
+		require.NoError(t, err)
+		poolTx := NewTransaction(*signedTx, "", false)
+		poolTx.Queued = queued
+		return *poolTx
+	}
+
+	txs := []Transaction{
+		newTx(6, key1, false), newTx(4, key1, false), newTx(5, key1, false), newTx(8, key1, true),
+		newTx(3, key2, true), newTx(2, key2, true),
+	}
+
+	pending, queued := splitPendingAndQueuedTxs(txs)
+
+	assert.Equal(t, []uint64{4, 5, 6}, nonceList(pending[addr1]))
+	assert.Equal(t, []uint64{8}, nonceList(queued[addr1]))
+	assert.Empty(t, pending[addr2])
+	assert.Equal(t, []uint64{2, 3}, nonceList(queued[addr2]))
+}
+
+func Test_addTxToStorageSetsQueued(t *testing.T) {
+	ctx := context.Background()
+	signer := types.NewEIP155Signer(big.NewInt(1000))
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	addr := crypto.PubkeyToAddress(key.PublicKey)
+
+	newTx := func(nonce uint64) Transaction {
+		tx := types.NewTransaction(nonce, common.Address{}, big.NewInt(0), 21000, big.NewInt(1), nil)
+		signedTx, err := types.SignTx(tx, signer, key)
+		require.NoError(t, err)
+		return *NewTransaction(*signedTx, "", false)
+	}
+
+	storage := &storageStub{txs: map[common.Hash]Transaction{}}
+	header := state.NewL2Header(&types.Header{Number: big.NewInt(10)})
+	st := &lastL2BlockStateStub{lastL2Block: state.NewL2BlockWithHeader(header), nonces: map[common.Address]uint64{addr: 1}}
+	p := &Pool{Storage: newCachedStorage(storage), state: st}
+
+	queuedNonces := func() []uint64 {
+		nonces := []uint64{}
+		for _, tx := range storage.txs {
+			if tx.Queued {
+				nonces = append(nonces, tx.Nonce())
+			}
+		}
+		sort.Slice(nonces, func(i, j int) bool { return nonces[i] < nonces[j] })
+		return nonces
+	}
+
+	// the txs after a nonce gap are queued
+	require.NoError(t, p.addTxToStorage(ctx, newTx(3)))
+	require.NoError(t, p.addTxToStorage(ctx, newTx(5)))
+	assert.Equal(t, []uint64{3, 5}, queuedNonces())
+
+	// the tx with the account nonce fills the gap of the txs continuous from it
+	require.NoError(t, p.addTxToStorage(ctx, newTx(1)))
+	assert.Equal(t, []uint64{3, 5}, queuedNonces())
+	require.NoError(t, p.addTxToStorage(ctx, newTx(2)))
+	assert.Equal(t, []uint64{5}, queuedNonces())
+
+	// the txs continuous from the account nonce are not queued
+	require.NoError(t, p.addTxToStorage(ctx, newTx(4)))
+	assert.Empty(t, queuedNonces())
+}
+
+func Test_promoteQueuedTxsAfterL2Block(t *testing.T) {
+	signer := types.NewEIP155Signer(big.NewInt(1000))
+	key1, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	key2, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	addr1 := crypto.PubkeyToAddress(key1.PublicKey)
+	addr2 := crypto.PubkeyToAddress(key2.PublicKey)
+
+	newTx := func(nonce uint64, key *ecdsa.PrivateKey) *types.Transaction {
+		tx := types.NewTransaction(nonce, common.Address{}, big.NewInt(0), 21000, big.NewInt(1), nil)
+		signedTx, err := types.SignTx(tx, signer, key)
+		require.NoError(t, err)
+		return signedTx
+	}
+	newQueuedTx := func(nonce uint64, key *ecdsa.PrivateKey) Transaction {
+		poolTx := NewTransaction(*newTx(nonce, key), "", false)
+		poolTx.Queued = true
+		return *poolTx
+	}
+
+	// the txs of addr1 were queued waiting for the tx with nonce 4, which has been forced
+	storage := &storageStub{txs: map[common.Hash]Transaction{}}
+	for _, tx := range []Transaction{newQueuedTx(5, key1), newQueuedTx(6, key1), newQueuedTx(8, key1), newQueuedTx(3, key2)} {
+		storage.txs[tx.Hash()] = tx
+	}
+	st := &lastL2BlockStateStub{nonces: map[common.Address]uint64{addr1: 5, addr2: 1}}
+	p := &Pool{Storage: storage, state: st}
+
+	header := state.NewL2Header(&types.Header{Number: big.NewInt(10)})
+	block := state.NewL2Block(header, []*types.Transaction{newTx(4, key1)}, nil, []*types.Receipt{{}}, trie.NewStackTrie(nil))
+	p.promoteQueuedTxsAfterL2Block(state.NewL2BlockEvent{Block: *block})
+
+	queuedNonces := map[common.Address][]uint64{}
+	for _, tx := range storage.txs {
+		if tx.Queued {
+			from, err := state.GetSender(tx.Transaction)
+			require.NoError(t, err)
+			queuedNonces[from] = append(queuedNonces[from], tx.Nonce())
+		}
+	}
+	assert.Equal(t, []uint64{8}, queuedNonces[addr1])
+	// the senders of the txs of other blocks are not promoted
+	assert.Equal(t, []uint64{3}, queuedNonces[addr2])
+}
+
+// nonceList returns the nonces of the txs in the same order
+func nonceList(txs []Transaction) []uint64 {
+	nonces := []uint64{}
+	for _, tx := range txs {
+		nonces = append(nonces, tx.Nonce())
+	}
+	return nonces
+}
+
+func Test_nextContinuousNonce(t *testing.T) {
+	testCases := []struct {
+		name          string
+		nonces        []uint64
+		accountNonce  uint64
+		expectedNonce uint64
+	}{
+		{"no txs in the pool", []uint64{}, 3, 3},
+		{"continuous txs", []uint64{3, 4, 5}, 3, 6},
+		{"txs with a nonce gap", []uint64{3, 4, 6, 7}, 3, 5},
+		{"txs already in the state", []uint64{1, 2, 3}, 3, 4},
+		{"txs after the account nonce", []uint64{5, 6}, 3, 3},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expectedNonce, nextContinuousNonce(tc.nonces, tc.accountNonce))
+		})
+	}
+}
@@ -0,0 +1,447 @@
+package pool
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/0xPolygonHermez/zkevm-node/log"
+	"github.com/0xPolygonHermez/zkevm-node/state"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+const (
+	// UserOperationStatusPending represents a user operation that has not been bundled yet
+	UserOperationStatusPending UserOperationStatus = "pending"
+	// UserOperationStatusBundled represents a user operation included in a bundle tx added to the pool
+	UserOperationStatusBundled UserOperationStatus = "bundled"
+	// UserOperationStatusIncluded represents a user operation executed by the entry point in a mined bundle tx
+	UserOperationStatusIncluded UserOperationStatus = "included"
+	// UserOperationStatusFailed represents a user operation that the bundler failed to include in a bundle tx
+	UserOperationStatusFailed UserOperationStatus = "failed"
+)
+
+// UserOperationStatus represents the state of a user operation
+type UserOperationStatus string
+
+// String returns a representation of the user operation state in a string format
+func (s UserOperationStatus) String() string {
+	return string(s)
+}
+
+// UserOp represents an EIP-4337 user operation as it's sent to the entry point contract
+type UserOp struct {
+	Sender               common.Address
+	Nonce                *big.Int
+	InitCode             []byte
+	CallData             []byte
+	CallGasLimit         *big.Int
+	VerificationGasLimit *big.Int
+	PreVerificationGas   *big.Int
+	MaxFeePerGas         *big.Int
+	MaxPriorityFeePerGas *big.Int
+	PaymasterAndData     []byte
+	Signature            []byte
+}
+
+var (
+	abiAddress, _ = abi.NewType("address", "", nil)
+	abiUint256, _ = abi.NewType("uint256", "", nil)
+	abiBytes32, _ = abi.NewType("bytes32", "", nil)
+
+	userOpPackArgs = abi.Arguments{
+		{Type: abiAddress}, {Type: abiUint256}, {Type: abiBytes32}, {Type: abiBytes32}, {Type: abiUint256},
+		{Type: abiUint256}, {Type: abiUint256}, {Type: abiUint256}, {Type: abiUint256}, {Type: abiBytes32},
+	}
+	userOpHashArgs = abi.Arguments{{Type: abiBytes32}, {Type: abiAddress}, {Type: abiUint256}}
+)
+
+// Hash returns the hash of the user operation for the provided entry point and chain, it's the
+// hash signed by the sender and the one used by the entry point to identify the user operation
+func (op UserOp) Hash(entryPoint common.Address, chainID uint64) common.Hash {
+	packed, err := userOpPackArgs.Pack(
+		op.Sender, bigOrZero(op.Nonce), crypto.Keccak256Hash(op.InitCode), crypto.Keccak256Hash(op.CallData),
+		bigOrZero(op.CallGasLimit), bigOrZero(op.VerificationGasLimit), bigOrZero(op.PreVerificationGas),
+		bigOrZero(op.MaxFeePerGas), bigOrZero(op.MaxPriorityFeePerGas), crypto.Keccak256Hash(op.PaymasterAndData),
+	)
+	if err != nil {
+		// the args always match the types of the arguments
+		panic(err)
+	}
+	encoded, err := userOpHashArgs.Pack(crypto.Keccak256Hash(packed), entryPoint, new(big.Int).SetUint64(chainID))
+	if err != nil {
+		panic(err)
+	}
+	return crypto.Keccak256Hash(encoded)
+}
+
+// Paymaster returns the address of the paymaster sponsoring the user operation, or
+// false if the sender pays the fees of the user operation
+func (op UserOp) Paymaster() (common.Address, bool) {
+	if len(op.PaymasterAndData) < common.AddressLength {
+		return common.Address{}, false
+	}
+	return common.BytesToAddress(op.PaymasterAndData[:common.AddressLength]), true
+}
+
+// gasLimit returns the max gas the user operation can consume
+func (op UserOp) gasLimit() *big.Int {
+	gas := new(big.Int).Add(bigOrZero(op.CallGasLimit), bigOrZero(op.VerificationGasLimit))
+	return gas.Add(gas, bigOrZero(op.PreVerificationGas))
+}
+
+func bigOrZero(b *big.Int) *big.Int {
+	if b == nil {
+		return big.NewInt(0)
+	}
+	return b
+}
+
+// UserOperation represents a user operation in the alternate mempool of the pool
+type UserOperation struct {
+	UserOp
+	Hash         common.Hash
+	EntryPoint   common.Address
+	Status       UserOperationStatus
+	ReceivedAt   time.Time
+	IP           string
+	BundleTxHash *common.Hash
+	FailedReason *string
+}
+
+// UserOperationsBundle is a tx built by a UserOperationsBundler that includes a
+// set of user operations, e.g. a call to handleOps of the entry point
+type UserOperationsBundle struct {
+	Tx             types.Transaction
+	UserOperations []common.Hash
+}
+
+// UserOperationsBundler is the hook used by the pool to include the pending user
+// operations into the batches. It's implemented by a bundler module that builds
+// and signs the bundle txs, which are added to the pool as local txs
+type UserOperationsBundler interface {
+	Bundle(ctx context.Context, userOps []UserOperation) ([]UserOperationsBundle, error)
+}
+
+// SupportedEntryPoints returns the addresses of the entry points of the user operations accepted by the pool
+func (p *Pool) SupportedEntryPoints() []common.Address {
+	if !p.cfg.AccountAbstraction.Enabled {
+		return []common.Address{}
+	}
+	return p.cfg.AccountAbstraction.EntryPoints
+}
+
+// AddUserOperation adds a user operation to the alternate mempool of the pool with the pending
+// state and returns its hash. The user operations are not loaded by the sequencer, they are
+// provided to the registered bundler that includes them in the bundle txs
+func (p *Pool) AddUserOperation(ctx context.Context, userOp UserOp, entryPoint common.Address, ip string) (common.Hash, error) {
+	poolUserOp := UserOperation{
+		UserOp:     userOp,
+		Hash:       userOp.Hash(entryPoint, p.chainID),
+		EntryPoint: entryPoint,
+		Status:     UserOperationStatusPending,
+		ReceivedAt: time.Now(),
+		IP:         ip,
+	}
+	replacedUserOp, err := p.validateUserOperation(ctx, poolUserOp)
+	if err != nil {
+		return common.Hash{}, err
+	}
+
+	if err := p.Storage.AddUserOperation(ctx, poolUserOp); err != nil {
+		return common.Hash{}, err
+	}
+
+	if replacedUserOp != nil {
+		failedReason := fmt.Sprintf("%s by user operation %s", ErrReplacedTransaction.Error(), poolUserOp.Hash.String())
+		err := p.Storage.UpdateUserOperationsStatus(ctx, []common.Hash{replacedUserOp.Hash}, UserOperationStatusFailed, nil, &failedReason)
+		if err != nil {
+			log.Errorf("failed to set as failed user operation %s replaced by user operation %s, error: %v", replacedUserOp.Hash.String(), poolUserOp.Hash.String(), err)
+		}
+	}
+	return poolUserOp.Hash, nil
+}
+
+// validateUserOperation checks the user operation can be included by the bundler, it returns the
+// pending user operation with the same sender and nonce replaced by the provided one, if any
+func (p *Pool) validateUserOperation(ctx context.Context, userOp UserOperation) (*UserOperation, error) {
+	cfg := p.cfg.AccountAbstraction
+	if !cfg.Enabled {
+		return nil, ErrUserOperationsDisabled
+	}
+
+	supported := false
+	for _, entryPoint := range cfg.EntryPoints {
+		supported = supported || entryPoint == userOp.EntryPoint
+	}
+	if !supported {
+		return nil, ErrUnsupportedEntryPoint
+	}
+
+	if userOp.IP != "" && !IsValidIP(userOp.IP) {
+		return nil, ErrInvalidIP
+	}
+	if userOp.IP != "" && !p.ipRateLimiter.allow(userOp.IP) {
+		return nil, ErrTxRateLimitExceeded
+	}
+	if !p.senderRateLimiter.allow(userOp.Sender.String()) {
+		return nil, ErrTxRateLimitExceeded
+	}
+
+	if p.IsAddressBlocked(userOp.Sender) {
+		return nil, ErrBlockedSender
+	}
+	if allowed, err := p.IsAddressAllowed(ctx, userOp.Sender); err != nil {
+		return nil, err
+	} else if !allowed {
+		return nil, ErrSenderNotAllowed
+	}
+	if len(userOp.Signature) == 0 {
+		return nil, ErrUserOpMissingSignature
+	}
+
+	if bigOrZero(userOp.VerificationGasLimit).Cmp(new(big.Int).SetUint64(cfg.MaxVerificationGas)) > 0 {
+		return nil, ErrUserOpVerificationGasLimit
+	}
+	if userOp.gasLimit().Cmp(new(big.Int).SetUint64(state.MaxTxGasLimit)) > 0 {
+		return nil, ErrGasLimit
+	}
+
+	maxFeePerGas := bigOrZero(userOp.MaxFeePerGas)
+	if bigOrZero(userOp.MaxPriorityFeePerGas).Cmp(maxFeePerGas) > 0 {
+		return nil, ErrUserOpInvalidFees
+	}
+	p.minSuggestedGasPriceMux.RLock()
+	gasPriceCmp := maxFeePerGas.Cmp(p.minSuggestedGasPrice)
+	p.minSuggestedGasPriceMux.RUnlock()
+	if gasPriceCmp == -1 {
+		return nil, ErrGasPrice
+	}
+
+	if _, err := p.Storage.GetUserOperationByHash(ctx, userOp.Hash); err == nil {
+		return nil, ErrAlreadyKnown
+	} else if err != ErrNotFound {
+		return nil, err
+	}
+
+	// the sender must be deployed by the init code or already have code
+	lastL2Block, err := p.state.GetLastL2Block(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	code, err := p.state.GetCode(ctx, userOp.Sender, lastL2Block.Root())
+	if err != nil {
+		return nil, err
+	}
+	if len(code) == 0 && len(userOp.InitCode) == 0 {
+		return nil, ErrUserOpSenderNotDeployed
+	} else if len(code) > 0 && len(userOp.InitCode) > 0 {
+		return nil, ErrUserOpSenderAlreadyDeployed
+	}
+
+	pendingUserOps, err := p.Storage.GetPendingUserOperationsBySender(ctx, userOp.Sender)
+	if err != nil {
+		return nil, err
+	}
+	var replacedUserOp *UserOperation
+	for i, pendingUserOp := range pendingUserOps {
+		if pendingUserOp.EntryPoint != userOp.EntryPoint || bigOrZero(pendingUserOp.Nonce).Cmp(bigOrZero(userOp.Nonce)) != 0 {
+			continue
+		}
+		// a user operation can be replaced increasing both fees by the price bump
+		if !hasPriceBump(bigOrZero(pendingUserOp.MaxFeePerGas), maxFeePerGas, p.cfg.PriceBump) ||
+			!hasPriceBump(bigOrZero(pendingUserOp.MaxPriorityFeePerGas), bigOrZero(userOp.MaxPriorityFeePerGas), p.cfg.PriceBump) {
+			return nil, ErrReplaceUnderpriced
+		}
+		replacedUserOp = &pendingUserOps[i]
+		break
+	}
+	if replacedUserOp == nil && cfg.MaxUserOpsPerSender > 0 && uint64(len(pendingUserOps)) >= cfg.MaxUserOpsPerSender {
+		return nil, ErrUserOpAccountOverflow
+	}
+
+	// the signature and the rest of the validation rules of the account and the paymaster are only
+	// known by the entry point, the simulation is the last check as it's the most expensive one
+	if err := p.simulateUserOperation(ctx, userOp); err != nil {
+		return nil, err
+	}
+	return replacedUserOp, nil
+}
+
+// RegisterUserOperationsBundler sets the bundler that includes the pending user operations into
+// the batches. It must be called before StartBundlingUserOperationsPeriodically
+func (p *Pool) RegisterUserOperationsBundler(b UserOperationsBundler) {
+	p.userOpsBundler = b
+}
+
+// StartBundlingUserOperationsPeriodically will make this instance of the pool to provide periodically
+// the pending user operations to the registered bundler and to add the bundle txs it builds to the
+// pool, so they are selected by the sequencer as any other tx, until the context is done. Before each
+// bundling the bundled user operations are updated with the result of their bundle txs. If it's
+// called more than once only the first call starts the bundling
+func (p *Pool) StartBundlingUserOperationsPeriodically(ctx context.Context) {
+	if !p.cfg.AccountAbstraction.Enabled || p.userOpsBundler == nil {
+		return
+	}
+	p.bundleUserOpsOnce.Do(func() {
+		go func(p *Pool) {
+			for {
+				select {
+				case <-ctx.Done():
+					return
+				case <-time.After(p.cfg.AccountAbstraction.BundleInterval.Duration):
+					p.reconcileBundledUserOperations(ctx)
+					p.bundleUserOperations(ctx)
+				}
+			}
+		}(p)
+	})
+}
+
+// bundleUserOperations provides the pending user operations to the bundler and adds the bundle txs
+// to the pool as local txs. The user operations of the bundles rejected by the pool are set as failed
+func (p *Pool) bundleUserOperations(ctx context.Context) {
+	userOps, err := p.Storage.GetPendingUserOperations(ctx, p.cfg.AccountAbstraction.MaxUserOpsPerBundle)
+	if err != nil {
+		log.Errorf("failed to get the pending user operations from the pool, error: %v", err)
+		return
+	}
+	if len(userOps) == 0 {
+		return
+	}
+
+	bundles, err := p.userOpsBundler.Bundle(ctx, userOps)
+	if err != nil {
+		log.Errorf("failed to bundle %d user operations, error: %v", len(userOps), err)
+		return
+	}
+
+	for _, bundle := range bundles {
+		txHash := bundle.Tx.Hash()
+		if err := p.AddLocalTx(ctx, bundle.Tx, ""); err != nil {
+			failedReason := fmt.Sprintf("bundle tx %s rejected by the pool: %v", txHash.String(), err)
+			log.Warnf("%s, %d user operations set as failed", failedReason, len(bundle.UserOperations))
+			err := p.Storage.UpdateUserOperationsStatus(ctx, bundle.UserOperations, UserOperationStatusFailed, &txHash, &failedReason)
+			if err != nil {
+				log.Errorf("failed to set as failed the user operations of the bundle tx %s, error: %v", txHash.String(), err)
+			}
+			continue
+		}
+
+		err := p.Storage.UpdateUserOperationsStatus(ctx, bundle.UserOperations, UserOperationStatusBundled, &txHash, nil)
+		if err != nil {
+			log.Errorf("failed to set as bundled the user operations of the bundle tx %s, error: %v", txHash.String(), err)
+			continue
+		}
+		log.Infof("%d user operations bundled in tx %s", len(bundle.UserOperations), txHash.String())
+	}
+}
+
+// reconcileBundledUserOperations updates the status of the bundled user operations with the result of their
+// bundle txs. When the bundle tx is mined, the user operations executed by the entry point, the ones with a
+// UserOperationEvent in the receipt, are set as included and the rest as failed. When the bundle tx reverts
+// or it's discarded by the pool, the user operations are simulated again and set back as pending to be
+// bundled again if they are still valid, or as failed otherwise
+func (p *Pool) reconcileBundledUserOperations(ctx context.Context) {
+	userOps, err := p.Storage.GetBundledUserOperations(ctx)
+	if err != nil {
+		log.Errorf("failed to get the bundled user operations from the pool, error: %v", err)
+		return
+	}
+
+	bundles := make(map[common.Hash][]UserOperation)
+	for _, userOp := range userOps {
+		if userOp.BundleTxHash == nil {
+			continue
+		}
+		bundles[*userOp.BundleTxHash] = append(bundles[*userOp.BundleTxHash], userOp)
+	}
+
+	for txHash, bundleUserOps := range bundles {
+		receipt, err := p.state.GetTransactionReceipt(ctx, txHash, nil)
+		if errors.Is(err, state.ErrNotFound) {
+			discarded, err := p.isBundleTxDiscarded(ctx, txHash)
+			if err != nil {
+				log.Errorf("failed to get the bundle tx %s from the pool, error: %v", txHash.String(), err)
+			} else if discarded {
+				p.retryUserOperations(ctx, txHash, bundleUserOps)
+			}
+			continue
+		} else if err != nil {
+			log.Errorf("failed to get the receipt of the bundle tx %s, error: %v", txHash.String(), err)
+			continue
+		}
+
+		if receipt.Status != types.ReceiptStatusSuccessful {
+			p.retryUserOperations(ctx, txHash, bundleUserOps)
+			continue
+		}
+
+		executed := make(map[common.Hash]bool)
+		for _, l := range receipt.Logs {
+			if len(l.Topics) > 1 && l.Topics[0] == userOperationEventID {
+				executed[l.Topics[1]] = true
+			}
+		}
+		included, notIncluded := make([]common.Hash, 0, len(bundleUserOps)), make([]common.Hash, 0)
+		for _, userOp := range bundleUserOps {
+			if executed[userOp.Hash] {
+				included = append(included, userOp.Hash)
+			} else {
+				notIncluded = append(notIncluded, userOp.Hash)
+			}
+		}
+		if len(included) > 0 {
+			if err := p.Storage.UpdateUserOperationsStatus(ctx, included, UserOperationStatusIncluded, nil, nil); err != nil {
+				log.Errorf("failed to set as included the user operations of the bundle tx %s, error: %v", txHash.String(), err)
+			}
+		}
+		if len(notIncluded) > 0 {
+			failedReason := fmt.Sprintf("not executed by the entry point in bundle tx %s", txHash.String())
+			if err := p.Storage.UpdateUserOperationsStatus(ctx, notIncluded, UserOperationStatusFailed, nil, &failedReason); err != nil {
+				log.Errorf("failed to set as failed the user operations not executed by the bundle tx %s, error: %v", txHash.String(), err)
+			}
+		}
+		log.Infof("%d user operations included and %d failed in the bundle tx %s", len(included), len(notIncluded), txHash.String())
+	}
+}
+
+// isBundleTxDiscarded returns true if the bundle tx is no longer in the pool or it has been
+// set as failed or invalid, so it's not going to be mined
+func (p *Pool) isBundleTxDiscarded(ctx context.Context, txHash common.Hash) (bool, error) {
+	tx, err := p.Storage.GetTransactionByHash(ctx, txHash)
+	if errors.Is(err, ErrNotFound) {
+		return true, nil
+	} else if err != nil {
+		return false, err
+	}
+	return tx.Status == TxStatusFailed || tx.Status == TxStatusInvalid, nil
+}
+
+// retryUserOperations simulates again the user operations of a bundle tx that is not going to be mined,
+// the ones that are still valid are set back as pending and the rest as failed
+func (p *Pool) retryUserOperations(ctx context.Context, txHash common.Hash, userOps []UserOperation) {
+	pending := make([]common.Hash, 0, len(userOps))
+	for _, userOp := range userOps {
+		if err := p.simulateUserOperation(ctx, userOp); err != nil {
+			failedReason := fmt.Sprintf("bundle tx %s not mined and %v", txHash.String(), err)
+			if err := p.Storage.UpdateUserOperationsStatus(ctx, []common.Hash{userOp.Hash}, UserOperationStatusFailed, nil, &failedReason); err != nil {
+				log.Errorf("failed to set as failed the user operation %s, error: %v", userOp.Hash.String(), err)
+			}
+			continue
+		}
+		pending = append(pending, userOp.Hash)
+	}
+	if len(pending) > 0 {
+		if err := p.Storage.UpdateUserOperationsStatus(ctx, pending, UserOperationStatusPending, nil, nil); err != nil {
+			log.Errorf("failed to set as pending the user operations of the bundle tx %s, error: %v", txHash.String(), err)
+			return
+		}
+	}
+	log.Infof("bundle tx %s not mined, %d user operations set as pending and %d as failed", txHash.String(), len(pending), len(userOps)-len(pending))
+}
@@ -0,0 +1,378 @@
+package pool
+
+import (
+	"context"
+	"math/big"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/0xPolygonHermez/zkevm-node/state"
+	"github.com/0xPolygonHermez/zkevm-node/state/runtime"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/jackc/pgx/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// userOpsStorageStub keeps the user operations in memory and implements only the storage methods used by the user operations
+type userOpsStorageStub struct {
+	Storage
+	userOps map[common.Hash]UserOperation
+	txs     map[common.Hash]*Transaction
+}
+
+func (s *userOpsStorageStub) AddUserOperation(ctx context.Context, userOp UserOperation) error {
+	s.userOps[userOp.Hash] = userOp
+	return nil
+}
+
+func (s *userOpsStorageStub) GetUserOperationByHash(ctx context.Context, hash common.Hash) (*UserOperation, error) {
+	userOp, found := s.userOps[hash]
+	if !found {
+		return nil, ErrNotFound
+	}
+	return &userOp, nil
+}
+
+func (s *userOpsStorageStub) GetPendingUserOperationsBySender(ctx context.Context, sender common.Address) ([]UserOperation, error) {
+	userOps := []UserOperation{}
+	for _, userOp := range s.userOps {
+		if userOp.Status == UserOperationStatusPending && userOp.Sender == sender {
+			userOps = append(userOps, userOp)
+		}
+	}
+	return userOps, nil
+}
+
+func (s *userOpsStorageStub) UpdateUserOperationsStatus(ctx context.Context, hashes []common.Hash, status UserOperationStatus, bundleTxHash *common.Hash, failedReason *string) error {
+	for _, hash := range hashes {
+		userOp := s.userOps[hash]
+		userOp.Status = status
+		userOp.FailedReason = failedReason
+		s.userOps[hash] = userOp
+	}
+	return nil
+}
+
+func (s *userOpsStorageStub) GetBundledUserOperations(ctx context.Context) ([]UserOperation, error) {
+	userOps := []UserOperation{}
+	for _, userOp := range s.userOps {
+		if userOp.Status == UserOperationStatusBundled {
+			userOps = append(userOps, userOp)
+		}
+	}
+	return userOps, nil
+}
+
+func (s *userOpsStorageStub) GetTransactionByHash(ctx context.Context, hash common.Hash) (*Transaction, error) {
+	tx, found := s.txs[hash]
+	if !found {
+		return nil, ErrNotFound
+	}
+	return tx, nil
+}
+
+// codeStateStub returns the code of the deployed accounts, the receipts of the mined txs and simulates the
+// validation of the user operations by the entry point, failing for the senders in failedOps
+type codeStateStub struct {
+	stateInterface
+	code      map[common.Address][]byte
+	receipts  map[common.Hash]*types.Receipt
+	failedOps map[common.Address]string
+	sigFailed map[common.Address]bool
+}
+
+func (s *codeStateStub) GetLastL2Block(ctx context.Context, dbTx pgx.Tx) (*state.L2Block, error) {
+	return state.NewL2BlockWithHeader(state.NewL2Header(&types.Header{Number: big.NewInt(1)})), nil
+}
+
+func (s *codeStateStub) GetCode(ctx context.Context, address common.Address, root common.Hash) ([]byte, error) {
+	return s.code[address], nil
+}
+
+func (s *codeStateStub) GetTransactionReceipt(ctx context.Context, transactionHash common.Hash, dbTx pgx.Tx) (*types.Receipt, error) {
+	receipt, found := s.receipts[transactionHash]
+	if !found {
+		return nil, state.ErrNotFound
+	}
+	return receipt, nil
+}
+
+func (s *codeStateStub) ProcessUnsignedTransaction(ctx context.Context, tx *types.Transaction, senderAddress common.Address, l2BlockNumber *uint64, noZKEVMCounters bool, stateOverride state.StateOverride, dbTx pgx.Tx) (*runtime.ExecutionResult, error) {
+	method, err := entryPointABI.MethodById(tx.Data())
+	if err != nil {
+		return nil, err
+	}
+	args, err := method.Inputs.Unpack(tx.Data()[4:])
+	if err != nil {
+		return nil, err
+	}
+	userOp := abi.ConvertType(args[0], new(UserOp)).(*UserOp)
+
+	var revertData []byte
+	if reason, found := s.failedOps[userOp.Sender]; found {
+		revertData = failedOpRevert(reason)
+	} else {
+		revertData = validationResultRevert(s.sigFailed[userOp.Sender], 0, 0)
+	}
+	return &runtime.ExecutionResult{ReturnValue: revertData, Err: runtime.ErrExecutionReverted}, nil
+}
+
+func failedOpRevert(reason string) []byte {
+	data, err := failedOpError.Inputs.Pack(big.NewInt(0), reason)
+	if err != nil {
+		panic(err)
+	}
+	return append(failedOpError.ID[:4], data...)
+}
+
+func validationResultRevert(sigFailed bool, validAfter, validUntil int64) []byte {
+	type stakeInfo struct {
+		Stake           *big.Int
+		UnstakeDelaySec *big.Int
+	}
+	returnInfo := validationReturnInfo{
+		PreOpGas:         big.NewInt(50000),
+		Prefund:          big.NewInt(1000),
+		SigFailed:        sigFailed,
+		ValidAfter:       big.NewInt(validAfter),
+		ValidUntil:       big.NewInt(validUntil),
+		PaymasterContext: []byte{},
+	}
+	noStake := stakeInfo{Stake: big.NewInt(0), UnstakeDelaySec: big.NewInt(0)}
+	data, err := validationResultError.Inputs.Pack(returnInfo, noStake, noStake, noStake)
+	if err != nil {
+		panic(err)
+	}
+	return append(validationResultError.ID[:4], data...)
+}
+
+func withSignature(userOp UserOp, signature ...byte) UserOp {
+	userOp.Signature = signature
+	return userOp
+}
+
+func TestUserOpHash(t *testing.T) {
+	entryPoint := common.HexToAddress("0x5FF137D4b0FDCD49DcA30c7CF57E578a026d2789")
+	userOp := UserOp{
+		Sender:               common.HexToAddress("0x1"),
+		Nonce:                big.NewInt(1),
+		CallData:             []byte{0x1},
+		CallGasLimit:         big.NewInt(100000),
+		VerificationGasLimit: big.NewInt(100000),
+		PreVerificationGas:   big.NewInt(21000),
+		MaxFeePerGas:         big.NewInt(10),
+		MaxPriorityFeePerGas: big.NewInt(1),
+		Signature:            []byte{0x1},
+	}
+	hash := userOp.Hash(entryPoint, 1000)
+	assert.Equal(t, hash, userOp.Hash(entryPoint, 1000))
+
+	// the signature is not part of the hash
+	signed := userOp
+	signed.Signature = []byte{0x2}
+	assert.Equal(t, hash, signed.Hash(entryPoint, 1000))
+
+	assert.NotEqual(t, hash, userOp.Hash(common.HexToAddress("0x2"), 1000))
+	assert.NotEqual(t, hash, userOp.Hash(entryPoint, 1001))
+	bumped := userOp
+	bumped.Nonce = big.NewInt(2)
+	assert.NotEqual(t, hash, bumped.Hash(entryPoint, 1000))
+
+	_, sponsored := userOp.Paymaster()
+	assert.False(t, sponsored)
+	userOp.PaymasterAndData = append(common.HexToAddress("0x3").Bytes(), 0x1)
+	paymaster, sponsored := userOp.Paymaster()
+	assert.True(t, sponsored)
+	assert.Equal(t, common.HexToAddress("0x3"), paymaster)
+}
+
+func TestAddUserOperation(t *testing.T) {
+	ctx := context.Background()
+	entryPoint := common.HexToAddress("0x5FF137D4b0FDCD49DcA30c7CF57E578a026d2789")
+	deployed := common.HexToAddress("0x1")
+	notDeployed := common.HexToAddress("0x2")
+	rejected := common.HexToAddress("0x5")
+	wrongSignature := common.HexToAddress("0x6")
+
+	storage := &userOpsStorageStub{userOps: map[common.Hash]UserOperation{}}
+	p := &Pool{
+		cfg: Config{
+			PriceBump: 10,
+			AccountAbstraction: AccountAbstractionCfg{
+				Enabled:             true,
+				EntryPoints:         []common.Address{entryPoint},
+				MaxVerificationGas:  200000,
+				MaxUserOpsPerSender: 2,
+			},
+		},
+		Storage: storage,
+		state: &codeStateStub{
+			code:      map[common.Address][]byte{deployed: {0x1}, rejected: {0x1}, wrongSignature: {0x1}},
+			failedOps: map[common.Address]string{rejected: "AA23 reverted (or OOG)"},
+			sigFailed: map[common.Address]bool{wrongSignature: true},
+		},
+		chainID:                 1000,
+		blockedAddresses:        sync.Map{},
+		minSuggestedGasPrice:    big.NewInt(5),
+		minSuggestedGasPriceMux: new(sync.RWMutex),
+	}
+
+	newUserOp := func(sender common.Address, nonce, maxFeePerGas int64) UserOp {
+		return UserOp{
+			Sender:               sender,
+			Nonce:                big.NewInt(nonce),
+			CallGasLimit:         big.NewInt(100000),
+			VerificationGasLimit: big.NewInt(100000),
+			PreVerificationGas:   big.NewInt(21000),
+			MaxFeePerGas:         big.NewInt(maxFeePerGas),
+			MaxPriorityFeePerGas: big.NewInt(maxFeePerGas),
+			Signature:            []byte{0x1},
+		}
+	}
+
+	hash, err := p.AddUserOperation(ctx, newUserOp(deployed, 0, 10), entryPoint, "127.0.0.1")
+	require.NoError(t, err)
+	require.Contains(t, storage.userOps, hash)
+	assert.Equal(t, UserOperationStatusPending, storage.userOps[hash].Status)
+
+	_, err = p.AddUserOperation(ctx, newUserOp(deployed, 0, 10), entryPoint, "127.0.0.1")
+	assert.ErrorIs(t, err, ErrAlreadyKnown)
+
+	// replacement of a pending user operation
+	underpriced := newUserOp(deployed, 0, 10)
+	underpriced.CallData = []byte{0x1}
+	_, err = p.AddUserOperation(ctx, underpriced, entryPoint, "")
+	assert.ErrorIs(t, err, ErrReplaceUnderpriced)
+	replacement, err := p.AddUserOperation(ctx, newUserOp(deployed, 0, 11), entryPoint, "")
+	require.NoError(t, err)
+	assert.Equal(t, UserOperationStatusFailed, storage.userOps[hash].Status)
+	assert.Equal(t, UserOperationStatusPending, storage.userOps[replacement].Status)
+
+	_, err = p.AddUserOperation(ctx, newUserOp(deployed, 1, 10), entryPoint, "")
+	require.NoError(t, err)
+	_, err = p.AddUserOperation(ctx, newUserOp(deployed, 2, 10), entryPoint, "")
+	assert.ErrorIs(t, err, ErrUserOpAccountOverflow)
+
+	invalid := []struct {
+		name       string
+		userOp     UserOp
+		entryPoint common.Address
+		err        error
+	}{
+		{"unsupported entry point", newUserOp(deployed, 3, 10), common.HexToAddress("0x4"), ErrUnsupportedEntryPoint},
+		{"missing signature", withSignature(newUserOp(deployed, 3, 10)), entryPoint, ErrUserOpMissingSignature},
+		{"gas price too low", newUserOp(deployed, 3, 4), entryPoint, ErrGasPrice},
+		{"sender not deployed", newUserOp(notDeployed, 0, 10), entryPoint, ErrUserOpSenderNotDeployed},
+		{"simulation failed", newUserOp(rejected, 0, 10), entryPoint, ErrUserOpSimulationFailed},
+		{"invalid signature", newUserOp(wrongSignature, 0, 10), entryPoint, ErrUserOpInvalidSignature},
+	}
+	for _, tc := range invalid {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := p.AddUserOperation(ctx, tc.userOp, tc.entryPoint, "")
+			assert.ErrorIs(t, err, tc.err)
+		})
+	}
+
+	withInitCode := newUserOp(notDeployed, 0, 10)
+	withInitCode.InitCode = []byte{0x1}
+	_, err = p.AddUserOperation(ctx, withInitCode, entryPoint, "")
+	require.NoError(t, err)
+
+	highVerificationGas := newUserOp(notDeployed, 1, 10)
+	highVerificationGas.VerificationGasLimit = big.NewInt(200001)
+	_, err = p.AddUserOperation(ctx, highVerificationGas, entryPoint, "")
+	assert.ErrorIs(t, err, ErrUserOpVerificationGasLimit)
+
+	invalidFees := newUserOp(notDeployed, 1, 10)
+	invalidFees.MaxPriorityFeePerGas = big.NewInt(11)
+	_, err = p.AddUserOperation(ctx, invalidFees, entryPoint, "")
+	assert.ErrorIs(t, err, ErrUserOpInvalidFees)
+
+	p.cfg.AccountAbstraction.Enabled = false
+	_, err = p.AddUserOperation(ctx, newUserOp(deployed, 3, 10), entryPoint, "")
+	assert.ErrorIs(t, err, ErrUserOperationsDisabled)
+	assert.Empty(t, p.SupportedEntryPoints())
+}
+
+func TestCheckValidationResult(t *testing.T) {
+	now := time.Unix(1000, 0)
+
+	assert.NoError(t, checkValidationResult(validationResultRevert(false, 0, 0), now))
+	assert.NoError(t, checkValidationResult(validationResultRevert(false, 999, 1001), now))
+	assert.ErrorIs(t, checkValidationResult(validationResultRevert(true, 0, 0), now), ErrUserOpInvalidSignature)
+	assert.ErrorIs(t, checkValidationResult(validationResultRevert(false, 0, 1000), now), ErrUserOpOutOfTimeRange)
+	assert.ErrorIs(t, checkValidationResult(validationResultRevert(false, 1001, 0), now), ErrUserOpOutOfTimeRange)
+
+	err := checkValidationResult(failedOpRevert("AA21 didn't pay prefund"), now)
+	assert.ErrorIs(t, err, ErrUserOpSimulationFailed)
+	assert.ErrorContains(t, err, "AA21 didn't pay prefund")
+	assert.ErrorIs(t, checkValidationResult([]byte{0x1, 0x2, 0x3, 0x4}, now), ErrUserOpSimulationFailed)
+}
+
+func TestReconcileBundledUserOperations(t *testing.T) {
+	ctx := context.Background()
+	entryPoint := common.HexToAddress("0x5FF137D4b0FDCD49DcA30c7CF57E578a026d2789")
+	sender := common.HexToAddress("0x1")
+	rejected := common.HexToAddress("0x2")
+
+	minedTx, revertedTx, pendingTx, invalidTx := common.HexToHash("0xa1"), common.HexToHash("0xa2"), common.HexToHash("0xa3"), common.HexToHash("0xa4")
+	bundled := func(hash common.Hash, sender common.Address, bundleTxHash common.Hash) UserOperation {
+		return UserOperation{
+			UserOp:       UserOp{Sender: sender, Nonce: big.NewInt(0), Signature: []byte{0x1}},
+			Hash:         hash,
+			EntryPoint:   entryPoint,
+			Status:       UserOperationStatusBundled,
+			BundleTxHash: &bundleTxHash,
+		}
+	}
+	executed, notExecuted := common.HexToHash("0x1"), common.HexToHash("0x2")
+	retried, dropped := common.HexToHash("0x3"), common.HexToHash("0x4")
+	waiting, discarded := common.HexToHash("0x5"), common.HexToHash("0x6")
+
+	storage := &userOpsStorageStub{
+		userOps: map[common.Hash]UserOperation{
+			executed:    bundled(executed, sender, minedTx),
+			notExecuted: bundled(notExecuted, sender, minedTx),
+			retried:     bundled(retried, sender, revertedTx),
+			dropped:     bundled(dropped, rejected, revertedTx),
+			waiting:     bundled(waiting, sender, pendingTx),
+			discarded:   bundled(discarded, sender, invalidTx),
+		},
+		txs: map[common.Hash]*Transaction{
+			pendingTx: {Status: TxStatusPending},
+			invalidTx: {Status: TxStatusInvalid},
+		},
+	}
+	p := &Pool{
+		Storage: storage,
+		state: &codeStateStub{
+			receipts: map[common.Hash]*types.Receipt{
+				minedTx: {Status: types.ReceiptStatusSuccessful, Logs: []*types.Log{
+					{Address: entryPoint, Topics: []common.Hash{userOperationEventID, executed, common.BytesToHash(sender.Bytes()), {}}},
+				}},
+				revertedTx: {Status: types.ReceiptStatusFailed},
+			},
+			failedOps: map[common.Address]string{rejected: "AA25 invalid account nonce"},
+		},
+	}
+
+	p.reconcileBundledUserOperations(ctx)
+
+	expected := map[common.Hash]UserOperationStatus{
+		executed:    UserOperationStatusIncluded,
+		notExecuted: UserOperationStatusFailed,
+		retried:     UserOperationStatusPending,
+		dropped:     UserOperationStatusFailed,
+		waiting:     UserOperationStatusBundled,
+		discarded:   UserOperationStatusPending,
+	}
+	for hash, status := range expected {
+		assert.Equal(t, status, storage.userOps[hash].Status, hash.String())
+	}
+	require.NotNil(t, storage.userOps[dropped].FailedReason)
+	assert.Contains(t, *storage.userOps[dropped].FailedReason, "AA25 invalid account nonce")
+}
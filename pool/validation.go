@@ -1,8 +1,36 @@
 package pool
 
-import "net"
+import (
+	"math/big"
+	"net"
+
+	"github.com/ethereum/go-ethereum/params"
+)
 
 // IsValidIP returns true if the given string is a valid IP address
 func IsValidIP(ip string) bool {
 	return ip != "" && net.ParseIP(ip) != nil
 }
+
+// exceedsTxFeeCap checks if the fee of a tx (gasPrice * gas) is over the provided cap in ether.
+// A cap of 0 means there is no cap
+func exceedsTxFeeCap(gasPrice *big.Int, gas uint64, feeCap float64) bool {
+	if feeCap == 0 {
+		return false
+	}
+	fee := new(big.Int).Mul(gasPrice, new(big.Int).SetUint64(gas))
+	feeEth := new(big.Float).Quo(new(big.Float).SetInt(fee), new(big.Float).SetInt(big.NewInt(params.Ether)))
+	return feeEth.Cmp(big.NewFloat(feeCap)) > 0
+}
+
+// hasPriceBump checks if the new gas price is higher than the old gas price at least by the provided
+// percentage. A new gas price equal to the old one is never accepted
+func hasPriceBump(oldGasPrice, newGasPrice *big.Int, priceBump uint64) bool {
+	if newGasPrice.Cmp(oldGasPrice) <= 0 {
+		return false
+	}
+	// newGasPrice * 100 >= oldGasPrice * (100 + priceBump)
+	const hundred = 100
+	minGasPrice := new(big.Int).Mul(oldGasPrice, new(big.Int).SetUint64(hundred+priceBump))
+	return new(big.Int).Mul(newGasPrice, big.NewInt(hundred)).Cmp(minGasPrice) >= 0
+}
@@ -1,8 +1,10 @@
 package pool
 
 import (
+	"math/big"
 	"testing"
 
+	"github.com/ethereum/go-ethereum/params"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -25,3 +27,52 @@ func Test_IsValidIP(t *testing.T) {
 		})
 	}
 }
+
+func Test_exceedsTxFeeCap(t *testing.T) {
+	oneGwei := big.NewInt(params.GWei)
+	var tests = []struct {
+		name     string
+		gasPrice *big.Int
+		gas      uint64
+		feeCap   float64
+		expected bool
+	}{
+		{"No cap", new(big.Int).Mul(oneGwei, big.NewInt(1000000)), 30000000, 0, false},
+		{"Under cap", oneGwei, 21000, 1, false},
+		{"Equal to cap", oneGwei, 1000000000, 1, false},
+		{"Over cap", oneGwei, 1000000001, 1, true},
+		{"Over decimal cap", big.NewInt(params.GWei * 100), 21000, 0.001, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := exceedsTxFeeCap(tt.gasPrice, tt.gas, tt.feeCap)
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+}
+
+func Test_hasPriceBump(t *testing.T) {
+	var tests = []struct {
+		name        string
+		oldGasPrice int64
+		newGasPrice int64
+		priceBump   uint64
+		expected    bool
+	}{
+		{"Same gas price", 100, 100, 0, false},
+		{"Higher gas price without bump", 100, 101, 0, true},
+		{"Lower gas price", 100, 99, 10, false},
+		{"Under bump", 100, 109, 10, false},
+		{"Equal to bump", 100, 110, 10, true},
+		{"Over bump", 100, 200, 10, true},
+		{"Rounded bump", 15, 17, 10, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := hasPriceBump(big.NewInt(tt.oldGasPrice), big.NewInt(tt.newGasPrice), tt.priceBump)
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+}
@@ -0,0 +1,110 @@
+package sequencer
+
+import (
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/0xPolygonHermez/zkevm-node/log"
+)
+
+const (
+	// AdminPauseEndpoint is the admin endpoint to pause the sequencer
+	AdminPauseEndpoint = "/pause"
+	// AdminResumeEndpoint is the admin endpoint to resume the sequencer
+	AdminResumeEndpoint = "/resume"
+	// AdminStatusEndpoint is the admin endpoint to get the status of the sequencer
+	AdminStatusEndpoint = "/status"
+
+	adminServerTimeout = 10 * time.Second
+)
+
+// adminStatusResponse is the response of the admin endpoints
+type adminStatusResponse struct {
+	Status string `json:"status"`
+}
+
+// sequencerAdmin is the interface used by the admin server to control the sequencer
+type sequencerAdmin interface {
+	Pause()
+	Resume()
+	Status() string
+}
+
+// Pause pauses the sequencer. The wip batch is closed if it has txs, and no more txs are loaded
+// from the pool or selected until the sequencer is resumed
+func (s *Sequencer) Pause() {
+	s.finalizer.Pause()
+}
+
+// Resume resumes a paused sequencer
+func (s *Sequencer) Resume() {
+	s.finalizer.Resume()
+}
+
+// Status returns the status of the sequencer (running, pausing or paused)
+func (s *Sequencer) Status() string {
+	return s.finalizer.Status()
+}
+
+// newAdminHandler returns the http handler with the admin endpoints
+func newAdminHandler(admin sequencerAdmin) http.Handler {
+	writeStatus := func(w http.ResponseWriter) {
+		w.Header().Set("Content-Type", "application/json")
+		err := json.NewEncoder(w).Encode(adminStatusResponse{Status: admin.Status()})
+		if err != nil {
+			log.Errorf("failed to write admin status response, error: %v", err)
+		}
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc(AdminPauseEndpoint, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		log.Warnf("sequencer pause requested from %s", r.RemoteAddr)
+		admin.Pause()
+		writeStatus(w)
+	})
+	mux.HandleFunc(AdminResumeEndpoint, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		log.Warnf("sequencer resume requested from %s", r.RemoteAddr)
+		admin.Resume()
+		writeStatus(w)
+	})
+	mux.HandleFunc(AdminStatusEndpoint, func(w http.ResponseWriter, r *http.Request) {
+		writeStatus(w)
+	})
+	return mux
+}
+
+// startAdminServer starts the http server with the admin endpoints
+func (s *Sequencer) startAdminServer() {
+	address := fmt.Sprintf("%s:%d", s.cfg.AdminServer.Host, s.cfg.AdminServer.Port)
+	lis, err := net.Listen("tcp", address)
+	if err != nil {
+		log.Errorf("failed to create tcp listener for sequencer admin server: %v", err)
+		return
+	}
+
+	adminServer := &http.Server{
+		Handler:           newAdminHandler(s),
+		ReadHeaderTimeout: adminServerTimeout,
+		ReadTimeout:       adminServerTimeout,
+	}
+	log.Infof("sequencer admin server listening on %s", address)
+	if err := adminServer.Serve(lis); err != nil {
+		if err == http.ErrServerClosed {
+			log.Warnf("http server for sequencer admin stopped")
+			return
+		}
+		log.Errorf("closed http connection for sequencer admin server: %v", err)
+		return
+	}
+}
@@ -0,0 +1,55 @@
+package sequencer
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type adminMock struct {
+	status string
+}
+
+func (a *adminMock) Pause()         { a.status = FinalizerStatusPaused }
+func (a *adminMock) Resume()        { a.status = FinalizerStatusRunning }
+func (a *adminMock) Status() string { return a.status }
+
+func TestAdminHandler(t *testing.T) {
+	admin := &adminMock{status: FinalizerStatusRunning}
+	server := httptest.NewServer(newAdminHandler(admin))
+	defer server.Close()
+
+	call := func(method string, endpoint string) (int, adminStatusResponse) {
+		req, err := http.NewRequest(method, server.URL+endpoint, nil)
+		require.NoError(t, err)
+		res, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		defer res.Body.Close()
+
+		var status adminStatusResponse
+		if res.StatusCode == http.StatusOK {
+			require.NoError(t, json.NewDecoder(res.Body).Decode(&status))
+		}
+		return res.StatusCode, status
+	}
+
+	code, status := call(http.MethodGet, AdminStatusEndpoint)
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, FinalizerStatusRunning, status.Status)
+
+	code, _ = call(http.MethodGet, AdminPauseEndpoint)
+	assert.Equal(t, http.StatusMethodNotAllowed, code)
+	assert.Equal(t, FinalizerStatusRunning, admin.status)
+
+	code, status = call(http.MethodPost, AdminPauseEndpoint)
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, FinalizerStatusPaused, status.Status)
+
+	code, status = call(http.MethodPost, AdminResumeEndpoint)
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, FinalizerStatusRunning, status.Status)
+}
@@ -9,6 +9,7 @@ import (
 	"github.com/0xPolygonHermez/zkevm-node/event"
 	"github.com/0xPolygonHermez/zkevm-node/hex"
 	"github.com/0xPolygonHermez/zkevm-node/log"
+	seqMetrics "github.com/0xPolygonHermez/zkevm-node/sequencer/metrics"
 	"github.com/0xPolygonHermez/zkevm-node/state"
 	stateMetrics "github.com/0xPolygonHermez/zkevm-node/state/metrics"
 	"github.com/ethereum/go-ethereum/common"
@@ -23,6 +24,7 @@ type Batch struct {
 	imStateRoot             common.Hash // intermediate stateRoot when processing tx-by-tx
 	finalStateRoot          common.Hash // final stateroot of the batch when a L2 block is processed
 	countOfTxs              int
+	lastTxTimestamp         time.Time // timestamp when the last tx was added to the batch
 	countOfL2Blocks         int
 	imRemainingResources    state.BatchResources // remaining batch resources when processing tx-by-tx
 	finalRemainingResources state.BatchResources // remaining batch resources when a L2 block is processed
@@ -97,6 +99,9 @@ func (f *finalizer) setWIPBatch(ctx context.Context, wipStateBatch *state.Batch)
 		finalRemainingResources: remainingResources,
 	}
 
+	// Txs included in the wip batch before the restart are not taken into account for the per account limit
+	f.workerIntf.ResetBatchTracking()
+
 	return wipBatch, nil
 }
 
@@ -284,6 +289,9 @@ func (f *finalizer) openNewWIPBatch(ctx context.Context, batchNumber uint64, sta
 
 	maxRemainingResources := getMaxRemainingResources(f.batchConstraints)
 
+	// Reset the count of txs per account included in the batch and the txs deferred in the previous batch
+	f.workerIntf.ResetBatchTracking()
+
 	return &Batch{
 		batchNumber:             newStateBatch.BatchNumber,
 		coinbase:                newStateBatch.Coinbase,
@@ -305,6 +313,10 @@ func (f *finalizer) closeWIPBatch(ctx context.Context) error {
 	}
 
 	usedResources := getUsedBatchResources(f.batchConstraints, f.wipBatch.imRemainingResources)
+	if f.batchConstraints.MaxCumulativeGasUsed > 0 {
+		seqMetrics.BatchGasUtilization(float64(usedResources.ZKCounters.GasUsed) * 100 / float64(f.batchConstraints.MaxCumulativeGasUsed)) //nolint:gomnd
+	}
+
 	receipt := state.ProcessingReceipt{
 		BatchNumber:    f.wipBatch.batchNumber,
 		BatchResources: usedResources,
@@ -545,10 +557,12 @@ func (f *finalizer) checkIfFinalizeBatch() (bool, state.ClosingReason) {
 		return true, state.ForcedBatchDeadlineClosingReason
 	}
 
-	// Batch timestamp resolution
-	if !f.wipBatch.isEmpty() && f.wipBatch.timestamp.Add(f.cfg.BatchMaxDeltaTimestamp.Duration).Before(time.Now()) {
-		log.Infof("closing batch %d, because of batch max delta timestamp reached", f.wipBatch.batchNumber)
-		return true, state.MaxDeltaTimestampClosingReason
+	// Batch closing policies (batch timestamp resolution, tx count, gas utilization, idle timeout)
+	for _, policy := range f.batchClosingPolicies {
+		if closeBatch, closingReason := policy.ShouldCloseBatch(f.wipBatch, time.Now()); closeBatch {
+			log.Infof("closing batch %d, closing reason: %s", f.wipBatch.batchNumber, closingReason)
+			return true, closingReason
+		}
 	}
 
 	return false, ""
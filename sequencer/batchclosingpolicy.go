@@ -0,0 +1,99 @@
+package sequencer
+
+import (
+	"time"
+
+	"github.com/0xPolygonHermez/zkevm-node/state"
+)
+
+// BatchClosingPolicy decides if the wip batch must be closed
+type BatchClosingPolicy interface {
+	// ShouldCloseBatch returns true if the batch must be closed, also it returns the closing reason
+	ShouldCloseBatch(batch *Batch, now time.Time) (bool, state.ClosingReason)
+}
+
+// newBatchClosingPolicies returns the batch closing policies enabled in the config. The policies are
+// checked in order and the batch is closed when any of them requests it
+func newBatchClosingPolicies(cfg FinalizerCfg, batchConstraints state.BatchConstraintsCfg) []BatchClosingPolicy {
+	policies := []BatchClosingPolicy{
+		&maxDeltaTimestampClosingPolicy{maxDeltaTimestamp: cfg.BatchMaxDeltaTimestamp.Duration},
+	}
+
+	policyCfg := cfg.BatchClosingPolicy
+	if policyCfg.MaxTxsPerBatch != 0 {
+		policies = append(policies, &txCountClosingPolicy{maxTxs: policyCfg.MaxTxsPerBatch})
+	}
+	if policyCfg.MaxGasUtilizationPct != 0 && batchConstraints.MaxCumulativeGasUsed != 0 {
+		policies = append(policies, &gasUtilizationClosingPolicy{
+			maxGasUsed: batchConstraints.MaxCumulativeGasUsed * uint64(policyCfg.MaxGasUtilizationPct) / 100, //nolint:gomnd
+			gasLimit:   batchConstraints.MaxCumulativeGasUsed,
+		})
+	}
+	if policyCfg.IdleTimeout.Duration != 0 {
+		policies = append(policies, &idleTimeoutClosingPolicy{idleTimeout: policyCfg.IdleTimeout.Duration})
+	}
+
+	return policies
+}
+
+// maxDeltaTimestampClosingPolicy closes a non-empty batch when it has been open for longer than maxDeltaTimestamp
+type maxDeltaTimestampClosingPolicy struct {
+	maxDeltaTimestamp time.Duration
+}
+
+// ShouldCloseBatch checks if the batch max delta timestamp has been reached
+func (p *maxDeltaTimestampClosingPolicy) ShouldCloseBatch(batch *Batch, now time.Time) (bool, state.ClosingReason) {
+	if !batch.isEmpty() && batch.timestamp.Add(p.maxDeltaTimestamp).Before(now) {
+		return true, state.MaxDeltaTimestampClosingReason
+	}
+	return false, state.EmptyClosingReason
+}
+
+// txCountClosingPolicy closes the batch when it contains maxTxs txs
+type txCountClosingPolicy struct {
+	maxTxs uint64
+}
+
+// ShouldCloseBatch checks if the batch has reached the max number of txs
+func (p *txCountClosingPolicy) ShouldCloseBatch(batch *Batch, now time.Time) (bool, state.ClosingReason) {
+	if uint64(batch.countOfTxs) >= p.maxTxs {
+		return true, state.MaxTxsClosingReason
+	}
+	return false, state.EmptyClosingReason
+}
+
+// gasUtilizationClosingPolicy closes the batch when the gas used by its txs reaches maxGasUsed
+type gasUtilizationClosingPolicy struct {
+	maxGasUsed uint64
+	gasLimit   uint64
+}
+
+// ShouldCloseBatch checks if the gas used by the batch has reached the max gas utilization
+func (p *gasUtilizationClosingPolicy) ShouldCloseBatch(batch *Batch, now time.Time) (bool, state.ClosingReason) {
+	if p.gasLimit-batch.imRemainingResources.ZKCounters.GasUsed >= p.maxGasUsed {
+		return true, state.MaxGasUtilizationClosingReason
+	}
+	return false, state.EmptyClosingReason
+}
+
+// idleTimeoutClosingPolicy closes a batch with txs when no new tx has been added to it for idleTimeout
+type idleTimeoutClosingPolicy struct {
+	idleTimeout time.Duration
+}
+
+// ShouldCloseBatch checks if the batch has been idle for longer than the idle timeout
+func (p *idleTimeoutClosingPolicy) ShouldCloseBatch(batch *Batch, now time.Time) (bool, state.ClosingReason) {
+	if batch.countOfTxs == 0 {
+		return false, state.EmptyClosingReason
+	}
+
+	lastTxTimestamp := batch.lastTxTimestamp
+	if lastTxTimestamp.IsZero() {
+		// The txs were added before a restart, we use the batch timestamp instead
+		lastTxTimestamp = batch.timestamp
+	}
+	if lastTxTimestamp.Add(p.idleTimeout).Before(now) {
+		return true, state.IdleTimeoutClosingReason
+	}
+	return false, state.EmptyClosingReason
+}
@@ -0,0 +1,82 @@
+package sequencer
+
+import (
+	"testing"
+	"time"
+
+	cfgTypes "github.com/0xPolygonHermez/zkevm-node/config/types"
+	"github.com/0xPolygonHermez/zkevm-node/state"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBatchClosingPolicies(t *testing.T) {
+	now := time.Now()
+	constraints := state.BatchConstraintsCfg{MaxCumulativeGasUsed: 1000}
+
+	// Only the batch max delta timestamp policy is enabled by default
+	policies := newBatchClosingPolicies(FinalizerCfg{BatchMaxDeltaTimestamp: cfgTypes.NewDuration(10 * time.Second)}, constraints)
+	require.Len(t, policies, 1)
+
+	cfg := FinalizerCfg{
+		BatchMaxDeltaTimestamp: cfgTypes.NewDuration(10 * time.Second),
+		BatchClosingPolicy: BatchClosingPolicyCfg{
+			MaxTxsPerBatch:       5,
+			MaxGasUtilizationPct: 80,
+			IdleTimeout:          cfgTypes.NewDuration(2 * time.Second),
+		},
+	}
+	policies = newBatchClosingPolicies(cfg, constraints)
+	require.Len(t, policies, 4)
+
+	shouldClose := func(batch *Batch) (bool, state.ClosingReason) {
+		for _, policy := range policies {
+			if closeBatch, reason := policy.ShouldCloseBatch(batch, now); closeBatch {
+				return true, reason
+			}
+		}
+		return false, state.EmptyClosingReason
+	}
+
+	newBatch := func() *Batch {
+		return &Batch{
+			timestamp:            now.Add(-time.Second),
+			lastTxTimestamp:      now,
+			countOfL2Blocks:      1,
+			countOfTxs:           1,
+			imRemainingResources: state.BatchResources{ZKCounters: state.ZKCounters{GasUsed: 900}},
+		}
+	}
+
+	closeBatch, _ := shouldClose(newBatch())
+	assert.False(t, closeBatch)
+
+	batch := newBatch()
+	batch.timestamp = now.Add(-11 * time.Second)
+	closeBatch, reason := shouldClose(batch)
+	assert.True(t, closeBatch)
+	assert.Equal(t, state.MaxDeltaTimestampClosingReason, reason)
+
+	batch = newBatch()
+	batch.countOfTxs = 5
+	closeBatch, reason = shouldClose(batch)
+	assert.True(t, closeBatch)
+	assert.Equal(t, state.MaxTxsClosingReason, reason)
+
+	batch = newBatch()
+	batch.imRemainingResources.ZKCounters.GasUsed = 200
+	closeBatch, reason = shouldClose(batch)
+	assert.True(t, closeBatch)
+	assert.Equal(t, state.MaxGasUtilizationClosingReason, reason)
+
+	batch = newBatch()
+	batch.lastTxTimestamp = now.Add(-3 * time.Second)
+	closeBatch, reason = shouldClose(batch)
+	assert.True(t, closeBatch)
+	assert.Equal(t, state.IdleTimeoutClosingReason, reason)
+
+	// An idle batch without txs is not closed
+	batch.countOfTxs = 0
+	closeBatch, _ = shouldClose(batch)
+	assert.False(t, closeBatch)
+}
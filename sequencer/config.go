@@ -3,6 +3,7 @@ package sequencer
 import (
 	"github.com/0xPolygonHermez/zkevm-data-streamer/log"
 	"github.com/0xPolygonHermez/zkevm-node/config/types"
+	"github.com/ethereum/go-ethereum/common"
 )
 
 // Config represents the configuration of a sequencer
@@ -25,11 +26,28 @@ type Config struct {
 	// StateConsistencyCheckInterval is the time the sequencer waits to check if a state inconsistency has happened
 	StateConsistencyCheckInterval types.Duration `mapstructure:"StateConsistencyCheckInterval"`
 
+	// Worker's specific config properties
+	Worker WorkerCfg `mapstructure:"Worker"`
+
 	// Finalizer's specific config properties
 	Finalizer FinalizerCfg `mapstructure:"Finalizer"`
 
 	// StreamServerCfg is the config for the stream server
 	StreamServer StreamServerCfg `mapstructure:"StreamServer"`
+
+	// AdminServer is the config for the sequencer admin server
+	AdminServer AdminServerCfg `mapstructure:"AdminServer"`
+}
+
+// AdminServerCfg contains the sequencer admin server configuration properties. The admin server allows
+// to pause and resume the sequencer using the /pause, /resume and /status endpoints
+type AdminServerCfg struct {
+	// Enabled is a flag to enable/disable the admin server
+	Enabled bool `mapstructure:"Enabled"`
+	// Host to listen on
+	Host string `mapstructure:"Host"`
+	// Port to listen on
+	Port int `mapstructure:"Port"`
 }
 
 // StreamServerCfg contains the data streamer's configuration properties
@@ -50,6 +68,32 @@ type StreamServerCfg struct {
 	UpgradeEtrogBatchNumber uint64 `mapstructure:"UpgradeEtrogBatchNumber"`
 }
 
+// WorkerCfg contains the worker's configuration properties
+type WorkerCfg struct {
+	// TxSorterType is the name of the registered sorter used to order the ready txs
+	// possible values: gasprice/auction/receivedtime
+	TxSorterType string `mapstructure:"TxSorterType"`
+
+	// MaxTxsPerAccountPerBatch is the max number of txs from the same sender that can be included in a batch.
+	// When the limit is reached the txs of other senders are selected, so a single account cannot fill the
+	// whole batch. 0 means no limit
+	MaxTxsPerAccountPerBatch uint64 `mapstructure:"MaxTxsPerAccountPerBatch"`
+
+	// PriorityAddresses is the list of senders (e.g. bridge claim relayers, oracles) whose txs are always
+	// selected before the txs of other senders, regardless of the order set by the TxSorterType
+	PriorityAddresses []common.Address `mapstructure:"PriorityAddresses"`
+
+	// MinGasPriceWei is the min gas price (in wei) that a tx must pay to be selected. The txs with a lower gas price
+	// are kept in the worker (they are not set as invalid). 0 means no limit
+	MinGasPriceWei uint64 `mapstructure:"MinGasPriceWei"`
+
+	// SelectionMode is the way the next tx to process is selected from the ready txs
+	// possible values: greedy/knapsack
+	// greedy: selects the first tx, in the order of the TxSorterType, that fits in the batch
+	// knapsack: selects the fitting tx with the highest fee per unit of its most used batch resource, to maximize the total fees of the batch
+	SelectionMode string `mapstructure:"SelectionMode"`
+}
+
 // FinalizerCfg contains the finalizer's configuration properties
 type FinalizerCfg struct {
 	// ForcedBatchesTimeout is the time the finalizer waits after receiving closing signal to process Forced Batches
@@ -91,10 +135,37 @@ type FinalizerCfg struct {
 	// in the processPendingL2Blocks go func
 	SequentialProcessL2Block bool `mapstructure:"SequentialProcessL2Block"`
 
+	// TxProfitabilityCheckerType type for checking if it is profitable for the sequencer to include a tx in the batch.
+	// The unprofitable txs are deferred until a new batch is opened
+	// possible values: base/acceptall
+	TxProfitabilityCheckerType TxProfitabilityCheckerType `mapstructure:"TxProfitabilityCheckerType"`
+
+	// StoreFailedTxDetails indicates if the execution error details (revert reason, gas used, sender nonce and balance)
+	// of the txs discarded by the sequencer are stored in the pool as the failed reason, so they can be queried
+	// using the pool_getTxStatus endpoint
+	StoreFailedTxDetails bool `mapstructure:"StoreFailedTxDetails"`
+
+	// BatchClosingPolicy is the config for the optional policies used to close the wip batch
+	BatchClosingPolicy BatchClosingPolicyCfg `mapstructure:"BatchClosingPolicy"`
+
 	// Metrics is the config for the sequencer metrics
 	Metrics MetricsCfg `mapstructure:"Metrics"`
 }
 
+// BatchClosingPolicyCfg contains the optional batch closing policies configuration properties. These policies are
+// checked together with the max txs, resources margin, forced batch deadline and BatchMaxDeltaTimestamp closing conditions.
+// A policy is disabled when its value is 0
+type BatchClosingPolicyCfg struct {
+	// MaxTxsPerBatch is the number of txs after which the batch is closed, it must be lower than the MaxTxsPerBatch constraint to take effect
+	MaxTxsPerBatch uint64 `mapstructure:"MaxTxsPerBatch"`
+
+	// MaxGasUtilizationPct is the percentage of the MaxCumulativeGasUsed constraint after which the batch is closed
+	MaxGasUtilizationPct uint32 `mapstructure:"MaxGasUtilizationPct"`
+
+	// IdleTimeout is the time without new txs after which a batch with txs is closed
+	IdleTimeout types.Duration `mapstructure:"IdleTimeout"`
+}
+
 // MetricsCfg contains the sequencer metrics configuration properties
 type MetricsCfg struct {
 	// Interval is the interval of time to calculate sequencer metrics
@@ -33,4 +33,18 @@ var (
 	ErrBatchResourceOverFlow = errors.New("batch resource overflow")
 	// ErrTransactionsListEmpty happens when txSortedList is empty
 	ErrTransactionsListEmpty = errors.New("transactions list empty")
+	// ErrTxNotProfitable happens when the fee of a tx doesn't cover the estimated cost of including it in the batch
+	ErrTxNotProfitable = errors.New("tx not profitable")
+	// ErrTxConditionsNotReached happens when the min block number or timestamp of a conditional tx have not been reached yet
+	ErrTxConditionsNotReached = errors.New("tx conditions not reached")
+	// ErrTxConditionsNotChecked happens when the storage of the known accounts of a conditional tx can't be read
+	ErrTxConditionsNotChecked = errors.New("tx conditions not checked")
+	// ErrSenderNotChecked happens when it can't be checked if the sender of a tx is allowed by the allow-list mode of the pool
+	ErrSenderNotChecked = errors.New("sender not checked")
+	// ErrUnknownTxProfitabilityChecker happens when the configured tx profitability checker type is not supported
+	ErrUnknownTxProfitabilityChecker = errors.New("unknown tx profitability checker")
+	// ErrUnknownTxSorter happens when the configured tx sorter has not been registered
+	ErrUnknownTxSorter = errors.New("unknown tx sorter")
+	// ErrUnknownSelectionMode happens when the configured worker selection mode is not supported
+	ErrUnknownSelectionMode = errors.New("unknown selection mode")
 )
@@ -15,6 +15,7 @@ import (
 	"github.com/0xPolygonHermez/zkevm-node/hex"
 	"github.com/0xPolygonHermez/zkevm-node/log"
 	"github.com/0xPolygonHermez/zkevm-node/pool"
+	seqMetrics "github.com/0xPolygonHermez/zkevm-node/sequencer/metrics"
 	"github.com/0xPolygonHermez/zkevm-node/state"
 	stateMetrics "github.com/0xPolygonHermez/zkevm-node/state/metrics"
 	"github.com/0xPolygonHermez/zkevm-node/state/runtime"
@@ -27,6 +28,21 @@ const (
 	changeL2BlockSize         = 9 //1 byte (tx type = 0B) + 4 bytes for deltaTimestamp + 4 for l1InfoTreeIndex
 )
 
+const (
+	finalizerRunning int32 = iota
+	finalizerPausing
+	finalizerPaused
+)
+
+const (
+	// FinalizerStatusRunning is the status of the finalizer when it's processing txs
+	FinalizerStatusRunning = "running"
+	// FinalizerStatusPausing is the status of the finalizer when it has been requested to pause but the wip batch is not closed yet
+	FinalizerStatusPausing = "pausing"
+	// FinalizerStatusPaused is the status of the finalizer when it's paused
+	FinalizerStatusPaused = "paused"
+)
+
 var (
 	now = time.Now
 )
@@ -44,6 +60,7 @@ type finalizer struct {
 	wipL2Block       *L2Block
 	batchConstraints state.BatchConstraintsCfg
 	haltFinalizer    atomic.Bool
+	status           atomic.Int32 // finalizerRunning, finalizerPausing or finalizerPaused
 	// forced batches
 	nextForcedBatches       []state.ForcedBatch
 	nextForcedBatchDeadline int64
@@ -58,6 +75,10 @@ type finalizer struct {
 	eventLog *event.EventLog
 	// effective gas price calculation instance
 	effectiveGasPrice *pool.EffectiveGasPrice
+	// tx profitability checker
+	profitabilityChecker txProfitabilityChecker
+	// batch closing policies
+	batchClosingPolicies []BatchClosingPolicy
 	// pending L2 blocks to process (executor)
 	pendingL2BlocksToProcess   chan *L2Block
 	pendingL2BlocksToProcessWG *sync.WaitGroup
@@ -96,7 +117,7 @@ func newFinalizer(
 	streamServer *datastreamer.StreamServer,
 	workerReadyTxsCond *timeoutCond,
 	dataToStream chan interface{},
-) *finalizer {
+) (*finalizer, error) {
 	f := finalizer{
 		cfg:              cfg,
 		isSynced:         isSynced,
@@ -106,6 +127,8 @@ func newFinalizer(
 		stateIntf:        stateIntf,
 		etherman:         etherman,
 		batchConstraints: batchConstraints,
+		// batch closing policies
+		batchClosingPolicies: newBatchClosingPolicies(cfg, batchConstraints),
 		// forced batches
 		nextForcedBatches:       make([]state.ForcedBatch, 0),
 		nextForcedBatchDeadline: 0,
@@ -139,9 +162,19 @@ func newFinalizer(
 		dataToStream: dataToStream,
 	}
 
+	switch cfg.TxProfitabilityCheckerType {
+	case ProfitabilityBase:
+		f.profitabilityChecker = NewTxProfitabilityCheckerBase(f.effectiveGasPrice)
+	case ProfitabilityAcceptAll:
+		f.profitabilityChecker = NewTxProfitabilityCheckerAcceptAll()
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrUnknownTxProfitabilityChecker, cfg.TxProfitabilityCheckerType)
+	}
+
 	f.haltFinalizer.Store(false)
+	f.status.Store(finalizerRunning)
 
-	return &f
+	return &f, nil
 }
 
 // Start starts the finalizer.
@@ -375,12 +408,24 @@ func (f *finalizer) finalizeBatches(ctx context.Context) {
 	log.Debug("finalizer init loop")
 	showNotFoundTxLog := true // used to log debug only the first message when there is no txs to process
 	for {
+		// The finalizer has been paused, we close the wip batch if it has txs and we wait until it's resumed
+		if f.status.Load() != finalizerRunning {
+			f.waitWhilePaused(ctx)
+			if err := ctx.Err(); err != nil {
+				log.Errorf("stopping finalizer because of context, error: %v", err)
+				return
+			}
+			continue
+		}
+
 		// We have reached the L2 block time, we need to close the current L2 block and open a new one
 		if f.wipL2Block.timestamp+uint64(f.cfg.L2BlockMaxDeltaTimestamp.Seconds()) <= uint64(time.Now().Unix()) {
 			f.finalizeWIPL2Block(ctx)
 		}
 
+		selectionStart := time.Now()
 		tx, err := f.workerIntf.GetBestFittingTx(f.wipBatch.imRemainingResources)
+		seqMetrics.TxSelectionTime(time.Since(selectionStart))
 
 		// If we have txs pending to process but none of them fits into the wip batch, we close the wip batch and open a new one
 		if err == ErrNoFittingTransaction {
@@ -403,11 +448,31 @@ func (f *finalizer) finalizeBatches(ctx context.Context) {
 					} else if err == ErrBatchResourceOverFlow {
 						log.Infof("skipping tx %s due to a batch resource overflow", tx.HashStr)
 						break
+					} else if err == ErrTxNotProfitable {
+						log.Infof("deferring tx %s because it is not profitable", tx.HashStr)
+						seqMetrics.TxDeferred()
+						break
+					} else if err == ErrTxConditionsNotReached {
+						log.Infof("deferring tx %s because its conditions have not been reached yet", tx.HashStr)
+						seqMetrics.TxDeferred()
+						break
+					} else if err == ErrTxConditionsNotChecked {
+						log.Infof("deferring tx %s because its conditions could not be checked", tx.HashStr)
+						seqMetrics.TxDeferred()
+						break
+					} else if err == ErrSenderNotChecked {
+						log.Infof("deferring tx %s because its sender could not be checked in the allow list", tx.HashStr)
+						seqMetrics.TxDeferred()
+						break
+					} else if err == pool.ErrBlockedSender || err == pool.ErrBlockedRecipient || err == pool.ErrSenderNotAllowed || isTxConditionsError(err) {
+						log.Infof("discarding tx %s, error: %v", tx.HashStr, err)
+						break
 					} else {
 						log.Errorf("failed to process tx %s, error: %v", err)
 						break
 					}
 				}
+				seqMetrics.TxSelected()
 				break
 			}
 		} else {
@@ -470,10 +535,30 @@ func (f *finalizer) processTransaction(ctx context.Context, tx *TxTracker, first
 
 	txGasPrice := tx.GasPrice
 
-	// If it is the first time we process this tx then we calculate the EffectiveGasPrice
+	// If it is the first time we process this tx then we check the blocked addresses and we calculate the EffectiveGasPrice
 	if firstTxProcess {
+		// Check if the sender or the recipient have been blocked after the tx was added to the pool
+		if err := f.checkBlockedAddresses(ctx, tx); err != nil {
+			return nil, err
+		}
+
+		// Check if the conditions of a conditional tx are met in the wip L2 block
+		if err := f.checkTxConditions(ctx, tx); err != nil {
+			return nil, err
+		}
+
 		// Get L1 gas price and store in txTracker to make it consistent during the lifespan of the transaction
 		tx.L1GasPrice, tx.L2GasPrice = f.poolIntf.GetL1AndL2GasPrice()
+
+		// Check if the tx fee covers the cost of including it in the batch, if not we defer the tx until a new batch is opened
+		isProfitable, err := f.profitabilityChecker.IsProfitable(tx)
+		if err != nil {
+			log.Warnf("failed to check profitability for tx %s, error: %v", tx.HashStr, err)
+		} else if !isProfitable {
+			f.workerIntf.DeferTx(tx.Hash)
+			return nil, ErrTxNotProfitable
+		}
+
 		// Get the tx and l2 gas price we will use in the egp calculation. If egp is disabled we will use a "simulated" tx gas price
 		txGasPrice, txL2GasPrice := f.effectiveGasPrice.GetTxAndL2GasPrice(tx.GasPrice, tx.L1GasPrice, tx.L2GasPrice)
 
@@ -558,6 +643,7 @@ func (f *finalizer) processTransaction(ctx context.Context, tx *TxTracker, first
 		f.workerIntf.DeleteTx(tx.Hash, tx.From)
 
 		// Set tx as invalid in the pool
+		seqMetrics.TxInvalidated()
 		errMsg := err.Error()
 		err = f.poolIntf.UpdateTxStatus(ctx, tx.Hash, pool.TxStatusInvalid, false, &errMsg)
 		if err != nil {
@@ -584,6 +670,84 @@ func (f *finalizer) processTransaction(ctx context.Context, tx *TxTracker, first
 	return nil, nil
 }
 
+// checkBlockedAddresses returns an error if the sender or the recipient of the tx are blocked in the pool, or
+// if the sender is not allowed by the allow-list mode of the pool. In that case the tx is deleted from the
+// worker and set as failed in the pool. If it can't be checked if the sender is allowed the tx is deferred
+func (f *finalizer) checkBlockedAddresses(ctx context.Context, tx *TxTracker) error {
+	var blockedErr error
+	if f.poolIntf.IsAddressBlocked(tx.From) {
+		blockedErr = pool.ErrBlockedSender
+	} else if tx.To != nil && f.poolIntf.IsAddressBlocked(*tx.To) {
+		blockedErr = pool.ErrBlockedRecipient
+	} else if allowed, err := f.poolIntf.IsAddressAllowed(ctx, tx.From); err != nil {
+		log.Warnf("failed to check if the sender of tx %s is allowed, error: %v", tx.HashStr, err)
+		f.workerIntf.DeferTx(tx.Hash)
+		return ErrSenderNotChecked
+	} else if !allowed {
+		blockedErr = pool.ErrSenderNotAllowed
+	} else {
+		return nil
+	}
+
+	f.workerIntf.DeleteTx(tx.Hash, tx.From)
+	seqMetrics.TxInvalidated()
+
+	failedReason := blockedErr.Error()
+	err := f.poolIntf.UpdateTxStatus(ctx, tx.Hash, pool.TxStatusFailed, false, &failedReason)
+	if err != nil {
+		log.Errorf("failed to update status to failed in the pool for tx %s, error: %v", tx.HashStr, err)
+	}
+
+	return blockedErr
+}
+
+// checkTxConditions checks the conditions of a conditional tx against the wip L2 block and its intermediate state
+// root, the state the tx is going to be processed on, that includes the txs already processed in the wip L2 block.
+// If the min block number or timestamp are not reached yet, or the storage can't be read, the tx is deferred.
+// If the conditions are not met the tx is removed from the worker and set as failed in the pool
+func (f *finalizer) checkTxConditions(ctx context.Context, tx *TxTracker) error {
+	if tx.Conditions.IsEmpty() {
+		return nil
+	}
+	if tx.Conditions.MinNotReached(f.wipL2Block.blockNumber, f.wipL2Block.timestamp) {
+		f.workerIntf.DeferTx(tx.Hash)
+		return ErrTxConditionsNotReached
+	}
+
+	stateRoot := f.wipBatch.imStateRoot
+	getStorageAt := func(address common.Address, slot common.Hash) (common.Hash, error) {
+		value, err := f.stateIntf.GetStorageAt(ctx, address, slot.Big(), stateRoot)
+		if err != nil {
+			return common.Hash{}, err
+		}
+		return common.BigToHash(value), nil
+	}
+	conditionsErr := tx.Conditions.Check(f.wipL2Block.blockNumber, f.wipL2Block.timestamp, getStorageAt)
+	if conditionsErr == nil {
+		return nil
+	} else if !isTxConditionsError(conditionsErr) {
+		log.Warnf("failed to read the storage of the known accounts of tx %s, error: %v", tx.HashStr, conditionsErr)
+		f.workerIntf.DeferTx(tx.Hash)
+		return ErrTxConditionsNotChecked
+	}
+
+	f.workerIntf.DeleteTx(tx.Hash, tx.From)
+	seqMetrics.TxInvalidated()
+
+	failedReason := conditionsErr.Error()
+	err := f.poolIntf.UpdateTxStatus(ctx, tx.Hash, pool.TxStatusFailed, false, &failedReason)
+	if err != nil {
+		log.Errorf("failed to update status to failed in the pool for tx %s, error: %v", tx.HashStr, err)
+	}
+
+	return conditionsErr
+}
+
+// isTxConditionsError returns true if the error is returned because the conditions of a tx are not met
+func isTxConditionsError(err error) bool {
+	return err == pool.ErrBlockNumberConditionNotMet || err == pool.ErrTimestampConditionNotMet || err == pool.ErrKnownAccountsConditionNotMet
+}
+
 // handleProcessTransactionResponse handles the response of transaction processing.
 func (f *finalizer) handleProcessTransactionResponse(ctx context.Context, tx *TxTracker, result *state.ProcessBatchResponse, oldStateRoot common.Hash) (errWg *sync.WaitGroup, err error) {
 	txResponse := result.BlockResponses[0].TransactionResponses[0]
@@ -667,6 +831,7 @@ func (f *finalizer) handleProcessTransactionResponse(ctx context.Context, tx *Tx
 			// Delete the transaction from the txSorted list
 			f.workerIntf.DeleteTx(tx.Hash, tx.From)
 
+			seqMetrics.TxInvalidated()
 			errMsg := "node OOC"
 			err = f.poolIntf.UpdateTxStatus(ctx, tx.Hash, pool.TxStatusInvalid, false, &errMsg)
 			if err != nil {
@@ -698,6 +863,7 @@ func (f *finalizer) handleProcessTransactionResponse(ctx context.Context, tx *Tx
 	f.wipL2Block.addTx(tx)
 
 	f.wipBatch.countOfTxs++
+	f.wipBatch.lastTxTimestamp = time.Now()
 
 	f.updateWorkerAfterSuccessfulProcessing(ctx, tx.Hash, tx.From, false, result)
 
@@ -780,10 +946,14 @@ func (f *finalizer) handleProcessTransactionError(ctx context.Context, result *s
 	log.Infof("rom error in tx %s, errorCode: %d", tx.HashStr, errorCode)
 	wg := new(sync.WaitGroup)
 	failedReason := executor.RomErr(errorCode).Error()
+	if f.cfg.StoreFailedTxDetails {
+		failedReason = getFailedTxDetails(txResponse, addressInfo)
+	}
 	if executor.IsROMOutOfCountersError(errorCode) {
 		log.Errorf("ROM out of counters error, marking tx %s as invalid, errorCode: %d", tx.HashStr, errorCode)
 
 		f.workerIntf.DeleteTx(tx.Hash, tx.From)
+		seqMetrics.TxInvalidated()
 
 		wg.Add(1)
 		go func() {
@@ -819,6 +989,7 @@ func (f *finalizer) handleProcessTransactionError(ctx context.Context, result *s
 		// Delete the transaction from the txSorted list
 		f.workerIntf.DeleteTx(tx.Hash, tx.From)
 		log.Debugf("tx %s deleted from txSorted list", tx.HashStr)
+		seqMetrics.TxInvalidated()
 
 		wg.Add(1)
 		go func() {
@@ -837,6 +1008,22 @@ func (f *finalizer) handleProcessTransactionError(ctx context.Context, result *s
 	return wg
 }
 
+// getFailedTxDetails returns the execution error of the tx including the revert reason, the gas used and the
+// nonce and balance of the sender
+func getFailedTxDetails(txResponse *state.ProcessTransactionResponse, addressInfo *state.InfoReadWrite) string {
+	details := state.ConstructErrorFromRevert(txResponse.RomError, txResponse.ReturnValue).Error()
+	details += fmt.Sprintf(", gasUsed: %d", txResponse.GasUsed)
+	if addressInfo != nil {
+		if addressInfo.Nonce != nil {
+			details += fmt.Sprintf(", nonce: %d", *addressInfo.Nonce)
+		}
+		if addressInfo.Balance != nil {
+			details += fmt.Sprintf(", balance: %s", addressInfo.Balance.String())
+		}
+	}
+	return details
+}
+
 // checkIfProverRestarted checks if the proverID changed
 func (f *finalizer) checkIfProverRestarted(proverID string) {
 	if f.proverID != "" && f.proverID != proverID {
@@ -854,6 +1041,53 @@ func (f *finalizer) logZKCounters(counters state.ZKCounters) string {
 		counters.Binaries, counters.Sha256Hashes_V2, counters.Steps)
 }
 
+// Pause requests the finalizer to stop processing new txs. The wip batch is closed if it has txs and
+// no new batches are created until the finalizer is resumed
+func (f *finalizer) Pause() {
+	if f.status.CompareAndSwap(finalizerRunning, finalizerPausing) {
+		log.Infof("pausing finalizer")
+	}
+}
+
+// Resume resumes the processing of txs after the finalizer has been paused
+func (f *finalizer) Resume() {
+	if f.status.Swap(finalizerRunning) != finalizerRunning {
+		log.Infof("resuming finalizer")
+	}
+}
+
+// IsPaused returns true if the finalizer is paused or it's being paused
+func (f *finalizer) IsPaused() bool {
+	return f.status.Load() != finalizerRunning
+}
+
+// Status returns the current status of the finalizer (running, pausing or paused)
+func (f *finalizer) Status() string {
+	switch f.status.Load() {
+	case finalizerPausing:
+		return FinalizerStatusPausing
+	case finalizerPaused:
+		return FinalizerStatusPaused
+	default:
+		return FinalizerStatusRunning
+	}
+}
+
+// waitWhilePaused closes the wip batch when the finalizer is being paused and waits for new txs interval
+func (f *finalizer) waitWhilePaused(ctx context.Context) {
+	if f.status.Load() == finalizerPausing {
+		if f.wipBatch.countOfTxs > 0 {
+			f.finalizeWIPBatch(ctx, state.SequencerPausedClosingReason)
+		}
+		if f.status.CompareAndSwap(finalizerPausing, finalizerPaused) {
+			log.Infof("finalizer paused, wip batch: %d", f.wipBatch.batchNumber)
+		}
+		return
+	}
+
+	time.Sleep(f.cfg.NewTxsWaitInterval.Duration)
+}
+
 // Halt halts the finalizer
 func (f *finalizer) Halt(ctx context.Context, err error, isFatal bool) {
 	f.haltFinalizer.Store(true)
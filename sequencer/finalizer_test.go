@@ -2,7 +2,9 @@ package sequencer
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"math/big"
 	"sync"
 	"testing"
 	"time"
@@ -13,6 +15,7 @@ import (
 	"github.com/0xPolygonHermez/zkevm-node/hex"
 	"github.com/0xPolygonHermez/zkevm-node/pool"
 	"github.com/0xPolygonHermez/zkevm-node/state"
+	"github.com/0xPolygonHermez/zkevm-node/state/runtime"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
@@ -61,6 +64,7 @@ var (
 		},
 		ResourceExhaustedMarginPct: 10,
 		SequentialBatchSanityCheck: true,
+		TxProfitabilityCheckerType: ProfitabilityAcceptAll,
 	}
 	poolCfg = pool.Config{
 		EffectiveGasPrice: pool.EffectiveGasPriceCfg{
@@ -117,9 +121,10 @@ func TestNewFinalizer(t *testing.T) {
 	poolMock.On("GetLastSentFlushID", context.Background()).Return(uint64(0), nil)
 
 	// arrange and act
-	f = newFinalizer(cfg, poolCfg, workerMock, poolMock, stateMock, ethermanMock, seqAddr, isSynced, bc, eventLog, nil, newTimeoutCond(&sync.Mutex{}), nil)
+	f, err = newFinalizer(cfg, poolCfg, workerMock, poolMock, stateMock, ethermanMock, seqAddr, isSynced, bc, eventLog, nil, newTimeoutCond(&sync.Mutex{}), nil)
 
 	// assert
+	require.NoError(t, err)
 	assert.NotNil(t, f)
 	assert.Equal(t, f.cfg, cfg)
 	assert.Equal(t, f.workerIntf, workerMock)
@@ -127,6 +132,11 @@ func TestNewFinalizer(t *testing.T) {
 	assert.Equal(t, f.stateIntf, stateMock)
 	assert.Equal(t, f.sequencerAddress, seqAddr)
 	assert.Equal(t, f.batchConstraints, bc)
+
+	unknownCfg := cfg
+	unknownCfg.TxProfitabilityCheckerType = "unknown"
+	_, err = newFinalizer(unknownCfg, poolCfg, workerMock, poolMock, stateMock, ethermanMock, seqAddr, isSynced, bc, eventLog, nil, newTimeoutCond(&sync.Mutex{}), nil)
+	assert.ErrorIs(t, err, ErrUnknownTxProfitabilityChecker)
 }
 
 /*func TestFinalizer_handleProcessTransactionResponse(t *testing.T) {
@@ -1770,6 +1780,152 @@ func TestFinalizer_updateWorkerAfterSuccessfulProcessing(t *testing.T) {
 	}
 }
 
+func TestFinalizer_checkBlockedAddresses(t *testing.T) {
+	testCases := []struct {
+		name             string
+		senderBlocked    bool
+		recipientBlocked bool
+		senderNotAllowed bool
+		allowedErr       error
+		expectedErr      error
+	}{
+		{
+			name: "No address blocked",
+		},
+		{
+			name:          "Sender blocked",
+			senderBlocked: true,
+			expectedErr:   pool.ErrBlockedSender,
+		},
+		{
+			name:             "Recipient blocked",
+			recipientBlocked: true,
+			expectedErr:      pool.ErrBlockedRecipient,
+		},
+		{
+			name:             "Sender not allowed",
+			senderNotAllowed: true,
+			expectedErr:      pool.ErrSenderNotAllowed,
+		},
+		{
+			name:        "Sender not checked",
+			allowedErr:  errors.New("registry error"),
+			expectedErr: ErrSenderNotChecked,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// arrange
+			finalizerInstance := setupFinalizer(false)
+			to := receiverAddr
+			tx := &TxTracker{Hash: oldHash, HashStr: oldHash.String(), From: senderAddr, To: &to}
+			poolMock.On("IsAddressBlocked", senderAddr).Return(tc.senderBlocked).Once()
+			if !tc.senderBlocked {
+				poolMock.On("IsAddressBlocked", receiverAddr).Return(tc.recipientBlocked).Once()
+			}
+			if !tc.senderBlocked && !tc.recipientBlocked {
+				poolMock.On("IsAddressAllowed", ctx, senderAddr).Return(!tc.senderNotAllowed, tc.allowedErr).Once()
+			}
+			if tc.allowedErr != nil {
+				workerMock.On("DeferTx", tx.Hash).Return().Once()
+			} else if tc.expectedErr != nil {
+				failedReason := tc.expectedErr.Error()
+				workerMock.On("DeleteTx", tx.Hash, tx.From).Return().Once()
+				poolMock.On("UpdateTxStatus", ctx, tx.Hash, pool.TxStatusFailed, false, &failedReason).Return(nil).Once()
+			}
+
+			// act
+			err := finalizerInstance.checkBlockedAddresses(ctx, tx)
+
+			// assert
+			assert.ErrorIs(t, err, tc.expectedErr)
+			workerMock.AssertExpectations(t)
+			poolMock.AssertExpectations(t)
+		})
+	}
+}
+
+func TestFinalizer_checkTxConditions(t *testing.T) {
+	slot := common.HexToHash("0x1")
+	testCases := []struct {
+		name         string
+		conditions   pool.TxConditions
+		storageValue *big.Int
+		storageErr   error
+		expectedErr  error
+		failed       bool
+	}{
+		{
+			name: "No conditions",
+		},
+		{
+			name:       "Conditions met",
+			conditions: pool.TxConditions{BlockNumberMin: 10, BlockNumberMax: 10, TimestampMax: 1000},
+		},
+		{
+			name:        "Min block number not reached",
+			conditions:  pool.TxConditions{BlockNumberMin: 11},
+			expectedErr: ErrTxConditionsNotReached,
+		},
+		{
+			name:        "Max timestamp exceeded",
+			conditions:  pool.TxConditions{TimestampMin: 999, TimestampMax: 999},
+			expectedErr: pool.ErrTimestampConditionNotMet,
+			failed:      true,
+		},
+		{
+			name:         "Known accounts met",
+			conditions:   pool.TxConditions{KnownAccounts: map[common.Address]map[common.Hash]common.Hash{receiverAddr: {slot: common.HexToHash("0x2")}}},
+			storageValue: big.NewInt(2),
+		},
+		{
+			name:         "Known accounts not met",
+			conditions:   pool.TxConditions{KnownAccounts: map[common.Address]map[common.Hash]common.Hash{receiverAddr: {slot: common.HexToHash("0x2")}}},
+			storageValue: big.NewInt(3),
+			expectedErr:  pool.ErrKnownAccountsConditionNotMet,
+			failed:       true,
+		},
+		{
+			name:        "Known accounts storage read error",
+			conditions:  pool.TxConditions{KnownAccounts: map[common.Address]map[common.Hash]common.Hash{receiverAddr: {slot: common.HexToHash("0x2")}}},
+			storageErr:  errors.New("state root not found"),
+			expectedErr: ErrTxConditionsNotChecked,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// arrange
+			finalizerInstance := setupFinalizer(true)
+			finalizerInstance.wipL2Block = &L2Block{blockNumber: 10, timestamp: 1000, imStateRoot: oldHash}
+			finalizerInstance.wipBatch = &Batch{imStateRoot: newHash}
+			tx := &TxTracker{Hash: oldHash, HashStr: oldHash.String(), From: senderAddr, Conditions: tc.conditions}
+			if tc.storageValue != nil || tc.storageErr != nil {
+				// the storage is read at the intermediate state root after the txs already processed in the wip L2 block
+				stateMock.On("GetStorageAt", ctx, receiverAddr, slot.Big(), newHash).Return(tc.storageValue, tc.storageErr).Once()
+			}
+			if tc.expectedErr == ErrTxConditionsNotReached || tc.expectedErr == ErrTxConditionsNotChecked {
+				workerMock.On("DeferTx", tx.Hash).Return().Once()
+			}
+			if tc.failed {
+				failedReason := tc.expectedErr.Error()
+				workerMock.On("DeleteTx", tx.Hash, tx.From).Return().Once()
+				poolMock.On("UpdateTxStatus", ctx, tx.Hash, pool.TxStatusFailed, false, &failedReason).Return(nil).Once()
+			}
+
+			// act
+			err := finalizerInstance.checkTxConditions(ctx, tx)
+
+			// assert
+			assert.ErrorIs(t, err, tc.expectedErr)
+			workerMock.AssertExpectations(t)
+			poolMock.AssertExpectations(t)
+			stateMock.AssertExpectations(t)
+		})
+	}
+}
+
 /*func TestFinalizer_reprocessFullBatch(t *testing.T) {
 	successfulResult := &state.ProcessBatchResponse{
 		NewStateRoot: newHash,
@@ -2214,6 +2370,7 @@ func setupFinalizer(withWipBatch bool) *finalizer {
 		stateIntf:                  stateMock,
 		wipBatch:                   wipBatch,
 		batchConstraints:           bc,
+		batchClosingPolicies:       newBatchClosingPolicies(cfg, bc),
 		nextForcedBatches:          make([]state.ForcedBatch, 0),
 		nextForcedBatchDeadline:    0,
 		nextForcedBatchesMux:       new(sync.Mutex),
@@ -2230,3 +2387,82 @@ func setupFinalizer(withWipBatch bool) *finalizer {
 		pendingFlushIDCond:         sync.NewCond(new(sync.Mutex)),
 	}
 }
+
+func Test_getFailedTxDetails(t *testing.T) {
+	nonce := uint64(3)
+	txResponse := &state.ProcessTransactionResponse{
+		RomError: runtime.ErrIntrinsicInvalidBalance,
+		GasUsed:  21000,
+	}
+	addressInfo := &state.InfoReadWrite{
+		Nonce:   &nonce,
+		Balance: big.NewInt(100),
+	}
+
+	details := getFailedTxDetails(txResponse, addressInfo)
+	assert.Equal(t, fmt.Sprintf("%s, gasUsed: 21000, nonce: 3, balance: 100", runtime.ErrIntrinsicInvalidBalance.Error()), details)
+
+	// revert reason is included when the return value contains it
+	txResponse.RomError = runtime.ErrExecutionReverted
+	txResponse.ReturnValue, err = hex.DecodeHex("0x08c379a0" +
+		"0000000000000000000000000000000000000000000000000000000000000020" +
+		"0000000000000000000000000000000000000000000000000000000000000004" +
+		"6661696c00000000000000000000000000000000000000000000000000000000")
+	require.NoError(t, err)
+
+	details = getFailedTxDetails(txResponse, nil)
+	assert.Equal(t, fmt.Sprintf("%s: fail, gasUsed: 21000", runtime.ErrExecutionReverted.Error()), details)
+}
+
+func TestFinalizer_PauseResume(t *testing.T) {
+	f = setupFinalizer(true)
+	assert.Equal(t, FinalizerStatusRunning, f.Status())
+	assert.False(t, f.IsPaused())
+
+	f.Pause()
+	assert.Equal(t, FinalizerStatusPausing, f.Status())
+	assert.True(t, f.IsPaused())
+
+	// The wip batch has no txs, so it's not closed before pausing
+	f.waitWhilePaused(context.Background())
+	assert.Equal(t, FinalizerStatusPaused, f.Status())
+
+	// Pausing an already paused finalizer keeps it paused
+	f.Pause()
+	assert.Equal(t, FinalizerStatusPaused, f.Status())
+
+	f.Resume()
+	assert.Equal(t, FinalizerStatusRunning, f.Status())
+	assert.False(t, f.IsPaused())
+}
+
+func TestFinalizer_updatePendingBlock(t *testing.T) {
+	// arrange
+	finalizerInstance := setupFinalizer(false)
+	l2Block := &L2Block{
+		trackingNum: 1,
+		batchResponse: &state.ProcessBatchResponse{
+			NewStateRoot: newHash,
+			BlockResponses: []*state.ProcessBlockResponse{
+				{
+					BlockNumber:          10,
+					BlockHash:            common.HexToHash("0x10"),
+					ParentHash:           common.HexToHash("0x9"),
+					Timestamp:            1000,
+					TransactionResponses: []*state.ProcessTransactionResponse{{TxHash: oldHash}},
+				},
+			},
+		},
+	}
+	expected := mock.MatchedBy(func(block pool.PendingBlock) bool {
+		return block.Number == 10 && block.Hash == common.HexToHash("0x10") && block.ParentHash == common.HexToHash("0x9") &&
+			block.StateRoot == newHash && block.Timestamp.Unix() == 1000 && len(block.TxHashes) == 1 && block.TxHashes[0] == oldHash
+	})
+	poolMock.On("UpdatePendingBlock", ctx, expected).Return(fmt.Errorf(testErrStr)).Once()
+
+	// act, a failure to update the pending block doesn't halt the finalizer
+	finalizerInstance.updatePendingBlock(ctx, l2Block)
+
+	// assert
+	poolMock.AssertExpectations(t)
+}
@@ -29,6 +29,14 @@ type txPool interface {
 	GetDefaultMinGasPriceAllowed() uint64
 	GetL1AndL2GasPrice() (uint64, uint64)
 	GetEarliestProcessedTx(ctx context.Context) (common.Hash, error)
+	IsAddressBlocked(address common.Address) bool
+	IsAddressAllowed(ctx context.Context, address common.Address) (bool, error)
+	UpdatePendingBlock(ctx context.Context, block pool.PendingBlock) error
+}
+
+// selectionSimulatorPool contains the methods required to load the txs of the pool to simulate their selection.
+type selectionSimulatorPool interface {
+	GetPendingTxs(ctx context.Context, limit uint64) ([]pool.Transaction, error)
 }
 
 // ethermanInterface contains the methods required to interact with ethereum.
@@ -95,4 +103,10 @@ type workerInterface interface {
 	NewTxTracker(tx types.Transaction, usedZKcounters state.ZKCounters, reservedZKCouners state.ZKCounters, ip string) (*TxTracker, error)
 	AddForcedTx(txHash common.Hash, addr common.Address)
 	DeleteForcedTx(txHash common.Hash, addr common.Address)
+	DeferTx(txHash common.Hash)
+	ResetBatchTracking()
+}
+
+type txProfitabilityChecker interface {
+	IsProfitable(tx *TxTracker) (bool, error)
 }
@@ -18,6 +18,7 @@ import (
 type L2Block struct {
 	createdAt                 time.Time
 	trackingNum               uint64
+	blockNumber               uint64
 	timestamp                 uint64
 	deltaTimestamp            uint32
 	imStateRoot               common.Hash
@@ -223,6 +224,8 @@ func (f *finalizer) processL2Block(ctx context.Context, l2Block *L2Block) error
 
 	f.addPendingL2BlockToStore(ctx, l2Block)
 
+	f.updatePendingBlock(ctx, l2Block)
+
 	// metrics
 	l2Block.metrics.l2BlockTimes.sequencer = time.Since(processStart) - l2Block.metrics.l2BlockTimes.executor
 	l2Block.metrics.close(l2Block.createdAt, int64(len(l2Block.transactions)))
@@ -241,6 +244,33 @@ func (f *finalizer) processL2Block(ctx context.Context, l2Block *L2Block) error
 	return nil
 }
 
+// updatePendingBlock shares through the pool the L2 block processed by the executor, so the RPC nodes can
+// serve it as the pending block until it's stored in the state
+func (f *finalizer) updatePendingBlock(ctx context.Context, l2Block *L2Block) {
+	blockResponse := l2Block.batchResponse.BlockResponses[0]
+
+	txHashes := make([]common.Hash, 0, len(blockResponse.TransactionResponses))
+	for _, txResponse := range blockResponse.TransactionResponses {
+		txHashes = append(txHashes, txResponse.TxHash)
+	}
+
+	pendingBlock := pool.PendingBlock{
+		Number:     blockResponse.BlockNumber,
+		Hash:       blockResponse.BlockHash,
+		ParentHash: blockResponse.ParentHash,
+		StateRoot:  l2Block.batchResponse.NewStateRoot,
+		Timestamp:  time.Unix(int64(blockResponse.Timestamp), 0),
+		TxHashes:   txHashes,
+		UpdatedAt:  time.Now(),
+	}
+
+	// The pending block is only a hint for the RPC, so we don't halt the sequencer if we fail to update it
+	err := f.poolIntf.UpdatePendingBlock(ctx, pendingBlock)
+	if err != nil {
+		log.Warnf("failed to update the pending block %d [%d] in the pool, error: %v", blockResponse.BlockNumber, l2Block.trackingNum, err)
+	}
+}
+
 // executeL2Block executes a L2 Block in the executor and returns the batch response from the executor and the batchL2Data size
 func (f *finalizer) executeL2Block(ctx context.Context, initialStateRoot common.Hash, l2Block *L2Block) (*state.ProcessBatchResponse, uint64, error) {
 	executeL2BLockError := func(err error) {
@@ -525,6 +555,9 @@ func (f *finalizer) openNewWIPL2Block(ctx context.Context, prevTimestamp uint64,
 		f.Halt(ctx, fmt.Errorf("number of L2 block [%d] responses returned by the executor is %d and must be 1", f.wipL2Block.trackingNum, len(batchResponse.BlockResponses)), false)
 	}
 
+	// The L2 block number is used to check the conditions of the conditional txs
+	f.wipL2Block.blockNumber = batchResponse.BlockResponses[0].BlockNumber
+
 	// Update imStateRoot
 	oldIMStateRoot := f.wipBatch.imStateRoot
 	f.wipL2Block.imStateRoot = batchResponse.NewStateRoot
@@ -0,0 +1,100 @@
+package metrics
+
+import (
+	"time"
+
+	"github.com/0xPolygonHermez/zkevm-node/metrics"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const (
+	// Prefix for the metrics of the sequencer package.
+	Prefix = "sequencer_"
+
+	// TxCandidatesExaminedName is the name of the metric that counts the txs examined when selecting the next tx.
+	TxCandidatesExaminedName = Prefix + "tx_candidates_examined"
+
+	// TxsSelectedName is the name of the metric that counts the txs selected and added to a batch.
+	TxsSelectedName = Prefix + "txs_selected"
+
+	// TxsInvalidatedName is the name of the metric that counts the txs set as invalid or failed when processing them.
+	TxsInvalidatedName = Prefix + "txs_invalidated"
+
+	// TxsDeferredName is the name of the metric that counts the txs deferred to a later batch.
+	TxsDeferredName = Prefix + "txs_deferred"
+
+	// TxSelectionTimeName is the name of the metric that observes the time to select the next tx.
+	TxSelectionTimeName = Prefix + "tx_selection_time"
+
+	// BatchGasUtilizationName is the name of the metric with the percentage of gas used by the last closed batch.
+	BatchGasUtilizationName = Prefix + "batch_gas_utilization_percentage"
+)
+
+// Register the metrics for the sequencer package.
+func Register() {
+	counters := []prometheus.CounterOpts{
+		{
+			Name: TxCandidatesExaminedName,
+			Help: "[SEQUENCER] number of txs examined when selecting the next tx",
+		},
+		{
+			Name: TxsSelectedName,
+			Help: "[SEQUENCER] number of txs selected and added to a batch",
+		},
+		{
+			Name: TxsInvalidatedName,
+			Help: "[SEQUENCER] number of txs set as invalid or failed when processing them",
+		},
+		{
+			Name: TxsDeferredName,
+			Help: "[SEQUENCER] number of txs deferred to a later batch",
+		},
+	}
+	histograms := []prometheus.HistogramOpts{
+		{
+			Name: TxSelectionTimeName,
+			Help: "[SEQUENCER] time to select the next tx",
+		},
+	}
+	gauges := []prometheus.GaugeOpts{
+		{
+			Name: BatchGasUtilizationName,
+			Help: "[SEQUENCER] percentage of gas used by the last closed batch",
+		},
+	}
+
+	metrics.RegisterCounters(counters...)
+	metrics.RegisterHistograms(histograms...)
+	metrics.RegisterGauges(gauges...)
+}
+
+// TxCandidatesExamined increases the counter of txs examined when selecting the next tx.
+func TxCandidatesExamined(count int) {
+	metrics.CounterAdd(TxCandidatesExaminedName, float64(count))
+}
+
+// TxSelected increases the counter of txs selected and added to a batch.
+func TxSelected() {
+	metrics.CounterInc(TxsSelectedName)
+}
+
+// TxInvalidated increases the counter of txs set as invalid or failed when processing them.
+func TxInvalidated() {
+	metrics.CounterInc(TxsInvalidatedName)
+}
+
+// TxDeferred increases the counter of txs deferred to a later batch.
+func TxDeferred() {
+	metrics.CounterInc(TxsDeferredName)
+}
+
+// TxSelectionTime observes the time to select the next tx on the histogram.
+func TxSelectionTime(lastProcessTime time.Duration) {
+	execTimeInSeconds := float64(lastProcessTime) / float64(time.Second)
+	metrics.HistogramObserve(TxSelectionTimeName, execTimeInSeconds)
+}
+
+// BatchGasUtilization sets the gauge with the percentage of gas used by the last closed batch.
+func BatchGasUtilization(percentage float64) {
+	metrics.GaugeSet(BatchGasUtilizationName, percentage)
+}
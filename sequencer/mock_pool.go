@@ -248,6 +248,52 @@ func (_m *PoolMock) GetTxZkCountersByHash(ctx context.Context, hash common.Hash)
 	return r0, r1, r2
 }
 
+// IsAddressAllowed provides a mock function with given fields: ctx, address
+func (_m *PoolMock) IsAddressAllowed(ctx context.Context, address common.Address) (bool, error) {
+	ret := _m.Called(ctx, address)
+
+	if len(ret) == 0 {
+		panic("no return value specified for IsAddressAllowed")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, common.Address) (bool, error)); ok {
+		return rf(ctx, address)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, common.Address) bool); ok {
+		r0 = rf(ctx, address)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, common.Address) error); ok {
+		r1 = rf(ctx, address)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// IsAddressBlocked provides a mock function with given fields: address
+func (_m *PoolMock) IsAddressBlocked(address common.Address) bool {
+	ret := _m.Called(address)
+
+	if len(ret) == 0 {
+		panic("no return value specified for IsAddressBlocked")
+	}
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(common.Address) bool); ok {
+		r0 = rf(address)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	return r0
+}
+
 // MarkWIPTxsAsPending provides a mock function with given fields: ctx
 func (_m *PoolMock) MarkWIPTxsAsPending(ctx context.Context) error {
 	ret := _m.Called(ctx)
@@ -266,6 +312,24 @@ func (_m *PoolMock) MarkWIPTxsAsPending(ctx context.Context) error {
 	return r0
 }
 
+// UpdatePendingBlock provides a mock function with given fields: ctx, block
+func (_m *PoolMock) UpdatePendingBlock(ctx context.Context, block pool.PendingBlock) error {
+	ret := _m.Called(ctx, block)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdatePendingBlock")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, pool.PendingBlock) error); ok {
+		r0 = rf(ctx, block)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // UpdateTxStatus provides a mock function with given fields: ctx, hash, newStatus, isWIP, failedReason
 func (_m *PoolMock) UpdateTxStatus(ctx context.Context, hash common.Hash, newStatus pool.TxStatus, isWIP bool, failedReason *string) error {
 	ret := _m.Called(ctx, hash, newStatus, isWIP, failedReason)
@@ -70,6 +70,11 @@ func (_m *WorkerMock) DeletePendingTxToStore(txHash common.Hash, addr common.Add
 	_m.Called(txHash, addr)
 }
 
+// DeferTx provides a mock function with given fields: txHash
+func (_m *WorkerMock) DeferTx(txHash common.Hash) {
+	_m.Called(txHash)
+}
+
 // DeleteTx provides a mock function with given fields: txHash, from
 func (_m *WorkerMock) DeleteTx(txHash common.Hash, from common.Address) {
 	_m.Called(txHash, from)
@@ -155,6 +160,11 @@ func (_m *WorkerMock) NewTxTracker(tx types.Transaction, usedZKcounters state.ZK
 	return r0, r1
 }
 
+// ResetBatchTracking provides a mock function with given fields:
+func (_m *WorkerMock) ResetBatchTracking() {
+	_m.Called()
+}
+
 // UpdateAfterSingleSuccessfulTxExecution provides a mock function with given fields: from, touchedAddresses
 func (_m *WorkerMock) UpdateAfterSingleSuccessfulTxExecution(from common.Address, touchedAddresses map[common.Address]*state.InfoReadWrite) []*TxTracker {
 	ret := _m.Called(from, touchedAddresses)
@@ -0,0 +1,52 @@
+package sequencer
+
+import (
+	"github.com/0xPolygonHermez/zkevm-node/pool"
+)
+
+// TxProfitabilityCheckerType checks profitability of including a tx in the batch
+type TxProfitabilityCheckerType string
+
+const (
+	// ProfitabilityBase checks that the tx fee covers the estimated L1 data and L2 execution cost
+	ProfitabilityBase = "base"
+	// ProfitabilityAcceptAll includes the tx anyway and don't check anything
+	ProfitabilityAcceptAll = "acceptall"
+)
+
+// TxProfitabilityCheckerBase checks that the tx fee covers the estimated L1 data and L2 execution cost
+type TxProfitabilityCheckerBase struct {
+	EffectiveGasPrice *pool.EffectiveGasPrice
+}
+
+// NewTxProfitabilityCheckerBase init base tx profitability checker
+func NewTxProfitabilityCheckerBase(effectiveGasPrice *pool.EffectiveGasPrice) *TxProfitabilityCheckerBase {
+	return &TxProfitabilityCheckerBase{
+		EffectiveGasPrice: effectiveGasPrice,
+	}
+}
+
+// IsProfitable compares the fee collected for the tx with the estimated cost, calculated using the breakEvenGasPrice
+// (L1 data cost + L2 execution cost)
+func (pc *TxProfitabilityCheckerBase) IsProfitable(tx *TxTracker) (bool, error) {
+	breakEvenGasPrice, err := pc.EffectiveGasPrice.CalculateBreakEvenGasPrice(tx.RawTx, tx.GasPrice, tx.UsedZKCounters.GasUsed, tx.L1GasPrice)
+	if err != nil {
+		return false, err
+	}
+
+	// As the collected fee and the estimated cost are calculated with the same gasUsed, it's enough to compare the gas prices
+	return tx.GasPrice.Cmp(breakEvenGasPrice) >= 0, nil
+}
+
+// TxProfitabilityCheckerAcceptAll includes the tx anyway and don't check anything
+type TxProfitabilityCheckerAcceptAll struct{}
+
+// NewTxProfitabilityCheckerAcceptAll init tx profitability checker that accept all txs
+func NewTxProfitabilityCheckerAcceptAll() *TxProfitabilityCheckerAcceptAll {
+	return &TxProfitabilityCheckerAcceptAll{}
+}
+
+// IsProfitable includes the tx anyway and don't check anything
+func (pc *TxProfitabilityCheckerAcceptAll) IsProfitable(tx *TxTracker) (bool, error) {
+	return true, nil
+}
@@ -0,0 +1,55 @@
+package sequencer
+
+import (
+	"bytes"
+	"math/big"
+	"testing"
+
+	"github.com/0xPolygonHermez/zkevm-node/pool"
+	"github.com/0xPolygonHermez/zkevm-node/state"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTxProfitabilityCheckerBase(t *testing.T) {
+	egp := pool.NewEffectiveGasPrice(pool.EffectiveGasPriceCfg{
+		Enabled:          true,
+		L1GasPriceFactor: 0.25,
+		ByteGasCost:      16,
+		ZeroByteGasCost:  4,
+		NetProfit:        1,
+	})
+	checker := NewTxProfitabilityCheckerBase(egp)
+
+	// breakEvenGasPrice = (100000*25 + 11*16*100) / 100000 = 25
+	testCases := []struct {
+		name               string
+		gasPrice           int64
+		l1GasPrice         uint64
+		expectedProfitable bool
+		expectedErr        error
+	}{
+		{name: "gasPrice greater than breakEvenGasPrice", gasPrice: 30, l1GasPrice: 100, expectedProfitable: true},
+		{name: "gasPrice equal to breakEvenGasPrice", gasPrice: 25, l1GasPrice: 100, expectedProfitable: true},
+		{name: "gasPrice lower than breakEvenGasPrice", gasPrice: 24, l1GasPrice: 100, expectedProfitable: false},
+		{name: "L1 gas price is zero", gasPrice: 30, l1GasPrice: 0, expectedProfitable: false, expectedErr: pool.ErrZeroL1GasPrice},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tx := &TxTracker{
+				RawTx:          bytes.Repeat([]byte{1}, 10),
+				GasPrice:       big.NewInt(tc.gasPrice),
+				L1GasPrice:     tc.l1GasPrice,
+				UsedZKCounters: state.ZKCounters{GasUsed: 100000},
+			}
+
+			isProfitable, err := checker.IsProfitable(tx)
+			assert.ErrorIs(t, err, tc.expectedErr)
+			assert.Equal(t, tc.expectedProfitable, isProfitable)
+		})
+	}
+
+	isProfitable, err := NewTxProfitabilityCheckerAcceptAll().IsProfitable(&TxTracker{GasPrice: big.NewInt(1)})
+	assert.NoError(t, err)
+	assert.True(t, isProfitable)
+}
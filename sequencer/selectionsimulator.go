@@ -0,0 +1,65 @@
+package sequencer
+
+import (
+	"context"
+	"sync"
+
+	"github.com/0xPolygonHermez/zkevm-node/log"
+	"github.com/0xPolygonHermez/zkevm-node/state"
+)
+
+// SelectionSimulator simulates the selection of the pending txs of the pool for an empty batch, without
+// executing them and without modifying the pool or the worker of the sequencer. It loads the pending txs in a
+// new worker, so the TxSorterType and the SelectionMode can be tuned before setting them in the sequencer
+type SelectionSimulator struct {
+	cfg         WorkerCfg
+	constraints state.BatchConstraintsCfg
+	pool        selectionSimulatorPool
+	state       stateInterface
+}
+
+// NewSelectionSimulator creates a new SelectionSimulator that uses the provided worker config and batch constraints
+func NewSelectionSimulator(cfg WorkerCfg, constraints state.BatchConstraintsCfg, pool selectionSimulatorPool, state stateInterface) *SelectionSimulator {
+	return &SelectionSimulator{
+		cfg:         cfg,
+		constraints: constraints,
+		pool:        pool,
+		state:       state,
+	}
+}
+
+// SimulateSelection loads up to limit pending txs from the pool (0 means no limit) and simulates their selection
+// for an empty batch. The txSorterType and the selectionMode override the ones of the worker config if not empty
+func (s *SelectionSimulator) SimulateSelection(ctx context.Context, txSorterType string, selectionMode string, limit uint64) (SelectionSimulation, error) {
+	workerCfg := s.cfg
+	if txSorterType != "" {
+		workerCfg.TxSorterType = txSorterType
+	}
+	if selectionMode != "" {
+		workerCfg.SelectionMode = selectionMode
+	}
+
+	worker, err := NewWorker(workerCfg, s.state, s.constraints, newTimeoutCond(&sync.Mutex{}))
+	if err != nil {
+		return SelectionSimulation{}, err
+	}
+
+	poolTxs, err := s.pool.GetPendingTxs(ctx, limit)
+	if err != nil {
+		return SelectionSimulation{}, err
+	}
+	for _, poolTx := range poolTxs {
+		txTracker, err := worker.NewTxTracker(poolTx.Transaction, poolTx.ZKCounters, poolTx.ReservedZKCounters, poolTx.IP)
+		if err != nil {
+			log.Warnf("tx %s not simulated, error: %v", poolTx.Hash().String(), err)
+			continue
+		}
+		txTracker.PoolReceivedAt = poolTx.ReceivedAt
+		txTracker.Conditions = poolTx.Conditions
+		if _, dropReason := worker.AddTxTracker(ctx, txTracker); dropReason != nil {
+			log.Debugf("tx %s not simulated, drop reason: %v", txTracker.HashStr, dropReason)
+		}
+	}
+
+	return worker.SimulateSelection(getMaxRemainingResources(s.constraints)), nil
+}
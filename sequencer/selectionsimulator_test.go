@@ -0,0 +1,73 @@
+package sequencer
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/0xPolygonHermez/zkevm-node/pool"
+	"github.com/0xPolygonHermez/zkevm-node/state"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type selectionSimulatorPoolStub struct {
+	txs   []pool.Transaction
+	limit uint64
+}
+
+func (p *selectionSimulatorPoolStub) GetPendingTxs(ctx context.Context, limit uint64) ([]pool.Transaction, error) {
+	p.limit = limit
+	return p.txs, nil
+}
+
+func TestSelectionSimulator(t *testing.T) {
+	const chainID = 1000
+	signer := types.NewEIP155Signer(big.NewInt(chainID))
+	newPoolTx := func(gasPrice int64, gasUsed uint64, keccaks uint32) pool.Transaction {
+		key, err := crypto.GenerateKey()
+		require.NoError(t, err)
+		tx, err := types.SignTx(types.NewTransaction(1, common.Address{}, big.NewInt(0), 21000, big.NewInt(gasPrice), nil), signer, key)
+		require.NoError(t, err)
+		counters := state.ZKCounters{GasUsed: gasUsed, KeccakHashes: keccaks}
+		return pool.Transaction{Transaction: *tx, ZKCounters: counters, ReservedZKCounters: counters}
+	}
+	// tx 0 pays the highest gas price but uses most of the keccaks of the batch
+	poolTxs := []pool.Transaction{newPoolTx(10, 2, 8), newPoolTx(5, 5, 5), newPoolTx(4, 5, 5)}
+
+	ctx := context.Background()
+	stateMock := NewStateMock(t)
+	stateMock.On("GetLastStateRoot", ctx, nil).Return(common.Hash{0}, nil)
+	stateMock.On("GetNonceByStateRoot", ctx, mock.Anything, common.Hash{0}).Return(big.NewInt(1), nil)
+	stateMock.On("GetBalanceByStateRoot", ctx, mock.Anything, common.Hash{0}).Return(big.NewInt(1000000000), nil)
+
+	constraints := state.BatchConstraintsCfg{
+		MaxTxsPerBatch: 300, MaxBatchBytesSize: 120000, MaxCumulativeGasUsed: 10, MaxKeccakHashes: 10, MaxPoseidonHashes: 10,
+		MaxPoseidonPaddings: 10, MaxMemAligns: 10, MaxArithmetics: 10, MaxBinaries: 10, MaxSteps: 10, MaxSHA256Hashes: 10,
+	}
+	p := &selectionSimulatorPoolStub{txs: poolTxs}
+	simulator := NewSelectionSimulator(WorkerCfg{TxSorterType: TxSorterTypeGasPrice}, constraints, p, stateMock)
+
+	simulation, err := simulator.SimulateSelection(ctx, "", "", 10)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(10), p.limit)
+	require.Len(t, simulation.Txs, 1)
+	assert.Equal(t, poolTxs[0].Hash(), simulation.Txs[0].Hash)
+	assert.Equal(t, uint64(2), simulation.EstimatedGas)
+	assert.Equal(t, big.NewInt(20), simulation.EstimatedFee)
+
+	// the selection mode of the config is overridden
+	simulation, err = simulator.SimulateSelection(ctx, "", SelectionModeKnapsack, 0)
+	require.NoError(t, err)
+	require.Len(t, simulation.Txs, 2)
+	assert.Equal(t, poolTxs[1].Hash(), simulation.Txs[0].Hash)
+	assert.Equal(t, poolTxs[2].Hash(), simulation.Txs[1].Hash)
+	assert.Equal(t, big.NewInt(45), simulation.EstimatedFee)
+
+	_, err = simulator.SimulateSelection(ctx, "unknown", "", 0)
+	assert.ErrorIs(t, err, ErrUnknownTxSorter)
+}
@@ -10,6 +10,7 @@ import (
 	"github.com/0xPolygonHermez/zkevm-node/event"
 	"github.com/0xPolygonHermez/zkevm-node/log"
 	"github.com/0xPolygonHermez/zkevm-node/pool"
+	seqMetrics "github.com/0xPolygonHermez/zkevm-node/sequencer/metrics"
 	"github.com/0xPolygonHermez/zkevm-node/state"
 	"github.com/ethereum/go-ethereum/common"
 )
@@ -59,6 +60,8 @@ func New(cfg Config, batchCfg state.BatchConfig, poolCfg pool.Config, txPool txP
 		eventLog:  eventLog,
 	}
 
+	seqMetrics.Register()
+
 	// TODO: Make configurable
 	channelBufferSize := 200 * datastreamChannelMultiplier // nolint:gomnd
 	sequencer.dataToStream = make(chan interface{}, channelBufferSize)
@@ -93,17 +96,27 @@ func (s *Sequencer) Start(ctx context.Context) {
 		s.updateDataStreamerFile(ctx, s.cfg.StreamServer.ChainID)
 	}
 
-	go s.loadFromPool(ctx)
-
 	if s.streamServer != nil {
 		go s.sendDataToStreamer(s.cfg.StreamServer.ChainID)
 	}
 
 	s.workerReadyTxsCond = newTimeoutCond(&sync.Mutex{})
-	s.worker = NewWorker(s.stateIntf, s.batchCfg.Constraints, s.workerReadyTxsCond)
-	s.finalizer = newFinalizer(s.cfg.Finalizer, s.poolCfg, s.worker, s.pool, s.stateIntf, s.etherman, s.address, s.isSynced, s.batchCfg.Constraints, s.eventLog, s.streamServer, s.workerReadyTxsCond, s.dataToStream)
+	s.worker, err = NewWorker(s.cfg.Worker, s.stateIntf, s.batchCfg.Constraints, s.workerReadyTxsCond)
+	if err != nil {
+		log.Fatalf("failed to create worker, error: %v", err)
+	}
+	s.finalizer, err = newFinalizer(s.cfg.Finalizer, s.poolCfg, s.worker, s.pool, s.stateIntf, s.etherman, s.address, s.isSynced, s.batchCfg.Constraints, s.eventLog, s.streamServer, s.workerReadyTxsCond, s.dataToStream)
+	if err != nil {
+		log.Fatalf("failed to create finalizer, error: %v", err)
+	}
 	go s.finalizer.Start(ctx)
 
+	go s.loadFromPool(ctx)
+
+	if s.cfg.AdminServer.Enabled {
+		go s.startAdminServer()
+	}
+
 	go s.deleteOldPoolTxs(ctx)
 
 	go s.expireOldWorkerTxs(ctx)
@@ -195,6 +208,12 @@ func (s *Sequencer) expireOldWorkerTxs(ctx context.Context) {
 // loadFromPool keeps loading transactions from the pool
 func (s *Sequencer) loadFromPool(ctx context.Context) {
 	for {
+		// While the sequencer is paused we don't load new txs from the pool
+		if s.finalizer.IsPaused() {
+			time.Sleep(s.cfg.LoadPoolTxsCheckInterval.Duration)
+			continue
+		}
+
 		poolTransactions, err := s.pool.GetNonWIPPendingTxs(ctx)
 		if err != nil && err != pool.ErrNotFound {
 			log.Errorf("error loading txs from pool, error: %v", err)
@@ -218,6 +237,9 @@ func (s *Sequencer) addTxToWorker(ctx context.Context, tx pool.Transaction) erro
 	if err != nil {
 		return err
 	}
+	txTracker.PoolReceivedAt = tx.ReceivedAt
+	txTracker.Conditions = tx.Conditions
+
 	replacedTx, dropReason := s.worker.AddTxTracker(ctx, txTracker)
 	if dropReason != nil {
 		failedReason := dropReason.Error()
@@ -0,0 +1,139 @@
+package sequencer
+
+import (
+	"bytes"
+	"fmt"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+const (
+	// TxSorterTypeGasPrice sorts the ready txs by gas price (default)
+	TxSorterTypeGasPrice = "gasprice"
+	// TxSorterTypeAuction sorts the ready txs strictly by gas price (first-price auction), breaking ties by tx hash
+	TxSorterTypeAuction = "auction"
+	// TxSorterTypeReceivedTime sorts the ready txs by the time they were received by the pool (first-come first-served)
+	TxSorterTypeReceivedTime = "receivedtime"
+)
+
+// TxSorter defines the order in which the worker provides the ready txs to the finalizer
+type TxSorter interface {
+	// Compare returns a positive value if tx1 has more priority than tx2, a negative value
+	// if tx1 has less priority than tx2 and 0 if both txs have the same priority
+	Compare(tx1 *TxTracker, tx2 *TxTracker) int
+}
+
+// TxSorterConstructor creates a new instance of a TxSorter
+type TxSorterConstructor func() TxSorter
+
+var (
+	txSorters    = make(map[string]TxSorterConstructor)
+	txSortersMux sync.RWMutex
+)
+
+func init() {
+	RegisterTxSorter(TxSorterTypeGasPrice, func() TxSorter { return &gasPriceTxSorter{} })
+	RegisterTxSorter(TxSorterTypeAuction, func() TxSorter { return &auctionTxSorter{} })
+	RegisterTxSorter(TxSorterTypeReceivedTime, func() TxSorter { return &receivedTimeTxSorter{} })
+}
+
+// RegisterTxSorter makes a TxSorter available by the provided name, so it can be selected
+// with the Sequencer.Worker.TxSorterType config parameter. It's intended to be called from
+// the init function of the package that implements the sorter. If RegisterTxSorter is called
+// twice with the same name or if the constructor is nil, it panics
+func RegisterTxSorter(name string, constructor TxSorterConstructor) {
+	txSortersMux.Lock()
+	defer txSortersMux.Unlock()
+
+	if name == "" {
+		panic("tx sorter name cannot be empty")
+	}
+	if constructor == nil {
+		panic(fmt.Sprintf("tx sorter %s constructor is nil", name))
+	}
+	if _, found := txSorters[name]; found {
+		panic(fmt.Sprintf("tx sorter %s already registered", name))
+	}
+	txSorters[name] = constructor
+}
+
+// NewTxSorter returns a new instance of the TxSorter registered with the provided name
+func NewTxSorter(name string) (TxSorter, error) {
+	txSortersMux.RLock()
+	constructor, found := txSorters[name]
+	txSortersMux.RUnlock()
+
+	if !found {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownTxSorter, name)
+	}
+	return constructor(), nil
+}
+
+// gasPriceTxSorter gives more priority to the txs with higher gasPrice
+type gasPriceTxSorter struct{}
+
+// Compare compares the gasPrice of tx1 and tx2
+func (s *gasPriceTxSorter) Compare(tx1 *TxTracker, tx2 *TxTracker) int {
+	return tx1.GasPrice.Cmp(tx2.GasPrice)
+}
+
+// auctionTxSorter gives more priority to the txs with higher gasPrice. As the pool only accepts legacy txs,
+// the gasPrice is the priority fee declared by the sender. Txs with the same gasPrice are sorted by hash (lower
+// hash first), so the resulting order is deterministic and can be audited
+type auctionTxSorter struct{}
+
+// Compare compares the gasPrice of tx1 and tx2, and their hash if the gasPrice is the same
+func (s *auctionTxSorter) Compare(tx1 *TxTracker, tx2 *TxTracker) int {
+	if cmp := tx1.GasPrice.Cmp(tx2.GasPrice); cmp != 0 {
+		return cmp
+	}
+	return bytes.Compare(tx2.Hash.Bytes(), tx1.Hash.Bytes())
+}
+
+// receivedTimeTxSorter gives more priority to the txs received earlier by the pool, regardless of their gasPrice.
+// As only the tx with the lowest nonce of each sender is ready, the nonce order of a sender is always kept.
+// Txs received at the same time are sorted by hash (lower hash first)
+type receivedTimeTxSorter struct{}
+
+// Compare compares the pool received time of tx1 and tx2, and their hash if the received time is the same
+func (s *receivedTimeTxSorter) Compare(tx1 *TxTracker, tx2 *TxTracker) int {
+	if tx1.PoolReceivedAt.Before(tx2.PoolReceivedAt) {
+		return 1
+	} else if tx1.PoolReceivedAt.After(tx2.PoolReceivedAt) {
+		return -1
+	}
+	return bytes.Compare(tx2.Hash.Bytes(), tx1.Hash.Bytes())
+}
+
+// prioritySenderTxSorter gives more priority to the txs sent by the priority senders, regardless of the order
+// of the wrapped sorter. The txs with the same sender priority are ordered using the wrapped sorter
+type prioritySenderTxSorter struct {
+	prioritySenders map[common.Address]struct{}
+	sorter          TxSorter
+}
+
+// newPrioritySenderTxSorter creates a prioritySenderTxSorter that wraps the provided sorter
+func newPrioritySenderTxSorter(sorter TxSorter, prioritySenders []common.Address) *prioritySenderTxSorter {
+	s := &prioritySenderTxSorter{
+		prioritySenders: make(map[common.Address]struct{}, len(prioritySenders)),
+		sorter:          sorter,
+	}
+	for _, addr := range prioritySenders {
+		s.prioritySenders[addr] = struct{}{}
+	}
+	return s
+}
+
+// Compare compares first the sender priority of tx1 and tx2 and then uses the wrapped sorter
+func (s *prioritySenderTxSorter) Compare(tx1 *TxTracker, tx2 *TxTracker) int {
+	_, isPriority1 := s.prioritySenders[tx1.From]
+	_, isPriority2 := s.prioritySenders[tx2.From]
+
+	if isPriority1 && !isPriority2 {
+		return 1
+	} else if !isPriority1 && isPriority2 {
+		return -1
+	}
+	return s.sorter.Compare(tx1, tx2)
+}
@@ -0,0 +1,126 @@
+package sequencer
+
+import (
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// lowerGasPriceTxSorter gives more priority to the txs with lower gasPrice
+type lowerGasPriceTxSorter struct{}
+
+func (s *lowerGasPriceTxSorter) Compare(tx1 *TxTracker, tx2 *TxTracker) int {
+	return tx2.GasPrice.Cmp(tx1.GasPrice)
+}
+
+// unregisterTxSorter removes the TxSorter registered with the provided name, so the tests
+// registering sorters can be run several times
+func unregisterTxSorter(name string) {
+	txSortersMux.Lock()
+	defer txSortersMux.Unlock()
+	delete(txSorters, name)
+}
+
+func TestRegisterTxSorter(t *testing.T) {
+	const name = "test_lowergasprice"
+	RegisterTxSorter(name, func() TxSorter { return &lowerGasPriceTxSorter{} })
+	t.Cleanup(func() { unregisterTxSorter(name) })
+
+	assert.Panics(t, func() { RegisterTxSorter(name, func() TxSorter { return &lowerGasPriceTxSorter{} }) })
+	assert.Panics(t, func() { RegisterTxSorter(TxSorterTypeGasPrice, func() TxSorter { return &gasPriceTxSorter{} }) })
+	assert.Panics(t, func() { RegisterTxSorter("", func() TxSorter { return &gasPriceTxSorter{} }) })
+	assert.Panics(t, func() { RegisterTxSorter("test_nil", nil) })
+
+	_, err := NewTxSorter("unknown")
+	assert.ErrorIs(t, err, ErrUnknownTxSorter)
+
+	_, err = NewWorker(WorkerCfg{TxSorterType: "unknown"}, nil, rcMax, nil)
+	assert.ErrorIs(t, err, ErrUnknownTxSorter)
+
+	sorter, err := NewTxSorter(name)
+	require.NoError(t, err)
+
+	el := newTxSortedList(sorter)
+	el.add(&TxTracker{HashStr: "0x01", GasPrice: new(big.Int).SetInt64(10)})
+	el.add(&TxTracker{HashStr: "0x02", GasPrice: new(big.Int).SetInt64(30)})
+	el.add(&TxTracker{HashStr: "0x03", GasPrice: new(big.Int).SetInt64(20)})
+	el.add(&TxTracker{HashStr: "0x04", GasPrice: new(big.Int).SetInt64(20)})
+
+	expected := []string{"0x01", "0x03", "0x04", "0x02"}
+	for i, hash := range expected {
+		assert.Equal(t, hash, el.getByIndex(i).HashStr)
+	}
+
+	assert.True(t, el.delete(&TxTracker{HashStr: "0x04"}))
+	assert.True(t, el.delete(&TxTracker{HashStr: "0x01"}))
+	assert.Equal(t, 2, el.len())
+	assert.Equal(t, "0x03", el.getByIndex(0).HashStr)
+	assert.Equal(t, "0x02", el.getByIndex(1).HashStr)
+}
+
+func TestPrioritySenderTxSorter(t *testing.T) {
+	priorityAddr := common.Address{1}
+	otherAddr := common.Address{2}
+
+	sorter := newPrioritySenderTxSorter(&gasPriceTxSorter{}, []common.Address{priorityAddr})
+
+	el := newTxSortedList(sorter)
+	el.add(&TxTracker{HashStr: "0x01", From: otherAddr, GasPrice: new(big.Int).SetInt64(100)})
+	el.add(&TxTracker{HashStr: "0x02", From: priorityAddr, GasPrice: new(big.Int).SetInt64(1)})
+	el.add(&TxTracker{HashStr: "0x03", From: otherAddr, GasPrice: new(big.Int).SetInt64(50)})
+	el.add(&TxTracker{HashStr: "0x04", From: priorityAddr, GasPrice: new(big.Int).SetInt64(10)})
+
+	// The txs of the priority sender go first, each group sorted by gasPrice
+	expected := []string{"0x04", "0x02", "0x01", "0x03"}
+	for i, hash := range expected {
+		assert.Equal(t, hash, el.getByIndex(i).HashStr)
+	}
+
+	assert.True(t, el.delete(&TxTracker{HashStr: "0x02", From: priorityAddr, GasPrice: new(big.Int).SetInt64(1)}))
+	assert.Equal(t, 3, el.len())
+	assert.Equal(t, "0x04", el.getByIndex(0).HashStr)
+	assert.Equal(t, "0x01", el.getByIndex(1).HashStr)
+}
+
+func TestAuctionTxSorter(t *testing.T) {
+	sorter, err := NewTxSorter(TxSorterTypeAuction)
+	require.NoError(t, err)
+
+	el := newTxSortedList(sorter)
+	el.add(&TxTracker{Hash: common.Hash{3}, HashStr: "0x03", GasPrice: new(big.Int).SetInt64(10)})
+	el.add(&TxTracker{Hash: common.Hash{1}, HashStr: "0x01", GasPrice: new(big.Int).SetInt64(10)})
+	el.add(&TxTracker{Hash: common.Hash{4}, HashStr: "0x04", GasPrice: new(big.Int).SetInt64(20)})
+	el.add(&TxTracker{Hash: common.Hash{2}, HashStr: "0x02", GasPrice: new(big.Int).SetInt64(10)})
+
+	// Txs with the same gasPrice are sorted by hash regardless of the insertion order
+	expected := []string{"0x04", "0x01", "0x02", "0x03"}
+	for i, hash := range expected {
+		assert.Equal(t, hash, el.getByIndex(i).HashStr)
+	}
+
+	assert.True(t, el.delete(&TxTracker{Hash: common.Hash{2}, HashStr: "0x02", GasPrice: new(big.Int).SetInt64(10)}))
+	assert.Equal(t, 3, el.len())
+	assert.Equal(t, "0x03", el.getByIndex(2).HashStr)
+}
+
+func TestReceivedTimeTxSorter(t *testing.T) {
+	sorter, err := NewTxSorter(TxSorterTypeReceivedTime)
+	require.NoError(t, err)
+
+	now := time.Now()
+	el := newTxSortedList(sorter)
+	el.add(&TxTracker{Hash: common.Hash{1}, HashStr: "0x01", GasPrice: new(big.Int).SetInt64(100), PoolReceivedAt: now.Add(2 * time.Second)})
+	el.add(&TxTracker{Hash: common.Hash{2}, HashStr: "0x02", GasPrice: new(big.Int).SetInt64(1), PoolReceivedAt: now})
+	el.add(&TxTracker{Hash: common.Hash{4}, HashStr: "0x04", GasPrice: new(big.Int).SetInt64(50), PoolReceivedAt: now.Add(time.Second)})
+	el.add(&TxTracker{Hash: common.Hash{3}, HashStr: "0x03", GasPrice: new(big.Int).SetInt64(10), PoolReceivedAt: now.Add(time.Second)})
+
+	// Txs are sorted by arrival regardless of the gasPrice, and by hash when they arrived at the same time
+	expected := []string{"0x02", "0x03", "0x04", "0x01"}
+	for i, hash := range expected {
+		assert.Equal(t, hash, el.getByIndex(i).HashStr)
+	}
+}
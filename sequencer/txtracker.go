@@ -4,6 +4,7 @@ import (
 	"math/big"
 	"time"
 
+	"github.com/0xPolygonHermez/zkevm-node/pool"
 	"github.com/0xPolygonHermez/zkevm-node/state"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
@@ -15,6 +16,7 @@ type TxTracker struct {
 	HashStr            string
 	From               common.Address
 	FromStr            string
+	To                 *common.Address
 	Nonce              uint64
 	Gas                uint64 // To check if it fits into a batch
 	GasPrice           *big.Int
@@ -24,6 +26,7 @@ type TxTracker struct {
 	ReservedZKCounters state.ZKCounters
 	RawTx              []byte
 	ReceivedAt         time.Time // To check if it has been in the txSortedList for too long
+	PoolReceivedAt     time.Time // Time when the tx was received by the pool
 	IP                 string    // IP of the tx sender
 	FailedReason       *string   // FailedReason is the reason why the tx failed, if it failed
 	EffectiveGasPrice  *big.Int
@@ -32,6 +35,7 @@ type TxTracker struct {
 	EGPLog             state.EffectiveGasPriceLog
 	L1GasPrice         uint64
 	L2GasPrice         uint64
+	Conditions         pool.TxConditions // Conditions of a conditional tx, checked when the tx is selected
 }
 
 // newTxTracker creates and inti a TxTracker
@@ -51,6 +55,7 @@ func newTxTracker(tx types.Transaction, usedZKCounters state.ZKCounters, reserve
 		HashStr:            tx.Hash().String(),
 		From:               addr,
 		FromStr:            addr.String(),
+		To:                 tx.To(),
 		Nonce:              tx.Nonce(),
 		Gas:                tx.Gas(),
 		GasPrice:           tx.GasPrice(),
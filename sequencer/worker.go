@@ -6,17 +6,28 @@ import (
 	"math/big"
 	"runtime"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/0xPolygonHermez/zkevm-node/log"
 	"github.com/0xPolygonHermez/zkevm-node/pool"
+	seqMetrics "github.com/0xPolygonHermez/zkevm-node/sequencer/metrics"
 	"github.com/0xPolygonHermez/zkevm-node/state"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
 )
 
+const (
+	// SelectionModeGreedy selects the first tx, in the order of the tx sorter, that fits in the batch (default)
+	SelectionModeGreedy = "greedy"
+	// SelectionModeKnapsack selects the fitting tx with the highest fee per unit of its most used batch resource,
+	// to maximize the total fees of the batch
+	SelectionModeKnapsack = "knapsack"
+)
+
 // Worker represents the worker component of the sequencer
 type Worker struct {
+	cfg              WorkerCfg
 	pool             map[string]*addrQueue
 	txSortedList     *txSortedList
 	workerMutex      sync.Mutex
@@ -24,19 +35,45 @@ type Worker struct {
 	batchConstraints state.BatchConstraintsCfg
 	readyTxsCond     *timeoutCond
 	wipTx            *TxTracker
+	batchTxsCount    map[common.Address]uint64
+	deferredTxs      map[common.Hash]struct{}
+	prioritySenders  map[common.Address]struct{}
 }
 
 // NewWorker creates an init a worker
-func NewWorker(state stateInterface, constraints state.BatchConstraintsCfg, readyTxsCond *timeoutCond) *Worker {
+func NewWorker(cfg WorkerCfg, state stateInterface, constraints state.BatchConstraintsCfg, readyTxsCond *timeoutCond) (*Worker, error) {
+	sorterType := cfg.TxSorterType
+	if sorterType == "" {
+		sorterType = TxSorterTypeGasPrice
+	}
+	sorter, err := NewTxSorter(sorterType)
+	if err != nil {
+		return nil, err
+	}
+	if cfg.SelectionMode != "" && cfg.SelectionMode != SelectionModeGreedy && cfg.SelectionMode != SelectionModeKnapsack {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownSelectionMode, cfg.SelectionMode)
+	}
+	prioritySenders := make(map[common.Address]struct{}, len(cfg.PriorityAddresses))
+	for _, addr := range cfg.PriorityAddresses {
+		prioritySenders[addr] = struct{}{}
+	}
+	if len(cfg.PriorityAddresses) > 0 {
+		sorter = newPrioritySenderTxSorter(sorter, cfg.PriorityAddresses)
+	}
+
 	w := Worker{
+		cfg:              cfg,
 		pool:             make(map[string]*addrQueue),
-		txSortedList:     newTxSortedList(),
+		txSortedList:     newTxSortedList(sorter),
 		state:            state,
 		batchConstraints: constraints,
 		readyTxsCond:     readyTxsCond,
+		batchTxsCount:    make(map[common.Address]uint64),
+		deferredTxs:      make(map[common.Hash]struct{}),
+		prioritySenders:  prioritySenders,
 	}
 
-	return &w
+	return &w, nil
 }
 
 // NewTxTracker creates and inits a TxTracker
@@ -157,6 +194,8 @@ func (w *Worker) UpdateAfterSingleSuccessfulTxExecution(from common.Address, tou
 	if len(touchedAddresses) == 0 {
 		log.Warnf("touchedAddresses is nil or empty")
 	}
+	w.batchTxsCount[from]++
+
 	txsToDelete := make([]*TxTracker, 0)
 	touchedFrom, found := touchedAddresses[from]
 	if found {
@@ -310,9 +349,15 @@ func (w *Worker) GetBestFittingTx(resources state.BatchResources) (*TxTracker, e
 		return nil, ErrTransactionsListEmpty
 	}
 
+	if w.cfg.SelectionMode == SelectionModeKnapsack {
+		return w.getBestFeeDensityTx(resources)
+	}
+
 	var (
 		tx         *TxTracker
 		foundMutex sync.RWMutex
+		notFitting atomic.Bool
+		examined   atomic.Int64
 	)
 
 	nGoRoutines := runtime.NumCPU()
@@ -334,9 +379,21 @@ func (w *Worker) GetBestFittingTx(resources state.BatchResources) (*TxTracker, e
 				foundMutex.RUnlock()
 
 				txCandidate := w.txSortedList.getByIndex(i)
+				examined.Add(1)
+				if w.isTxSkipped(txCandidate) {
+					continue
+				}
+
+				if w.isAccountBatchLimitReached(txCandidate.From) {
+					// The sender has reached the max number of txs for the batch, we give the opportunity to other senders.
+					// As for the skipped txs, closing the batch because of them would let a single sender close every batch
+					continue
+				}
+
 				overflow, _ := bresources.Sub(state.BatchResources{ZKCounters: txCandidate.ReservedZKCounters, Bytes: txCandidate.Bytes})
 				if overflow {
 					// We don't add this Tx
+					notFitting.Store(true)
 					continue
 				}
 
@@ -353,13 +410,199 @@ func (w *Worker) GetBestFittingTx(resources state.BatchResources) (*TxTracker, e
 	}
 	wg.Wait()
 
+	seqMetrics.TxCandidatesExamined(int(examined.Load()))
+
 	if foundAt != -1 {
 		log.Debugf("best fitting tx %s found at index %d with gasPrice %d", tx.HashStr, foundAt, tx.GasPrice)
 		w.wipTx = tx
 		return tx, nil
+	} else if notFitting.Load() {
+		return nil, ErrNoFittingTransaction
 	} else {
+		// All the ready txs have been skipped (deferred, underpriced or from senders that reached the max txs per batch),
+		// closing the batch doesn't help, so we wait for new txs
+		return nil, ErrTransactionsListEmpty
+	}
+}
+
+// DeferTx skips a tx from being selected until a new wip batch is opened. The tx is kept in the worker
+func (w *Worker) DeferTx(txHash common.Hash) {
+	w.workerMutex.Lock()
+	defer w.workerMutex.Unlock()
+
+	w.deferredTxs[txHash] = struct{}{}
+	w.resetWipTx(txHash)
+}
+
+// getBestFeeDensityTx gets the fitting tx with the highest fee (gasPrice * gasUsed) per unit of the batch resource
+// that the tx uses the most, relative to the available batch resources. It's the usual greedy approximation for a
+// knapsack with several constraints (gas and ZK counters)
+func (w *Worker) getBestFeeDensityTx(resources state.BatchResources) (*TxTracker, error) {
+	tx, notFitting := w.findBestFeeDensityTx(resources, nil)
+
+	seqMetrics.TxCandidatesExamined(w.txSortedList.len())
+
+	if tx != nil {
+		log.Debugf("best fee density tx %s found with gasPrice %d", tx.HashStr, tx.GasPrice)
+		w.wipTx = tx
+		return tx, nil
+	} else if notFitting {
 		return nil, ErrNoFittingTransaction
+	} else {
+		return nil, ErrTransactionsListEmpty
+	}
+}
+
+// findBestFeeDensityTx returns the fitting tx with the highest fee density, ignoring the excluded txs. The fitting
+// txs of the priority senders are always selected before the txs of other senders. It also returns if some tx has
+// been discarded because it doesn't fit in the batch
+func (w *Worker) findBestFeeDensityTx(resources state.BatchResources, excluded map[common.Hash]struct{}) (tx *TxTracker, notFitting bool) {
+	var (
+		bestDensity    *big.Float
+		bestIsPriority bool
+	)
+
+	for i := 0; i < w.txSortedList.len(); i++ {
+		txCandidate := w.txSortedList.getByIndex(i)
+		if _, found := excluded[txCandidate.Hash]; found || w.isTxSkipped(txCandidate) {
+			continue
+		}
+
+		if w.isAccountBatchLimitReached(txCandidate.From) {
+			continue
+		}
+
+		bresources := resources
+		overflow, _ := bresources.Sub(state.BatchResources{ZKCounters: txCandidate.ReservedZKCounters, Bytes: txCandidate.Bytes})
+		if overflow {
+			notFitting = true
+			continue
+		}
+
+		fee := new(big.Float).SetInt(new(big.Int).Mul(txCandidate.GasPrice, new(big.Int).SetUint64(txCandidate.UsedZKCounters.GasUsed)))
+		share := getMaxResourcesShare(resources, txCandidate)
+		density := fee
+		if share > 0 {
+			density = new(big.Float).Quo(fee, big.NewFloat(share))
+		}
+
+		_, isPriority := w.prioritySenders[txCandidate.From]
+		if bestIsPriority && !isPriority {
+			continue
+		}
+
+		// In case of the same density we keep the tx with more priority in the tx sorter
+		if tx == nil || (isPriority && !bestIsPriority) || density.Cmp(bestDensity) > 0 {
+			tx = txCandidate
+			bestDensity = density
+			bestIsPriority = isPriority
+		}
+	}
+
+	return tx, notFitting
+}
+
+// getMaxResourcesShare returns the max share (0-1) of the available batch resources reserved by the tx
+func getMaxResourcesShare(resources state.BatchResources, tx *TxTracker) float64 {
+	share := func(reserved uint64, available uint64) float64 {
+		if available == 0 {
+			return 0
+		}
+		return float64(reserved) / float64(available)
+	}
+
+	r, a := tx.ReservedZKCounters, resources.ZKCounters
+	shares := []float64{
+		share(tx.Bytes, resources.Bytes),
+		share(r.GasUsed, a.GasUsed),
+		share(uint64(r.KeccakHashes), uint64(a.KeccakHashes)),
+		share(uint64(r.PoseidonHashes), uint64(a.PoseidonHashes)),
+		share(uint64(r.PoseidonPaddings), uint64(a.PoseidonPaddings)),
+		share(uint64(r.MemAligns), uint64(a.MemAligns)),
+		share(uint64(r.Arithmetics), uint64(a.Arithmetics)),
+		share(uint64(r.Binaries), uint64(a.Binaries)),
+		share(uint64(r.Steps), uint64(a.Steps)),
+		share(uint64(r.Sha256Hashes_V2), uint64(a.Sha256Hashes_V2)),
 	}
+
+	maxShare := float64(0)
+	for _, s := range shares {
+		if s > maxShare {
+			maxShare = s
+		}
+	}
+	return maxShare
+}
+
+// SelectionSimulation is the result of simulating the selection of the ready txs for a batch
+type SelectionSimulation struct {
+	// Txs are the txs that would be selected, in selection order
+	Txs []*TxTracker
+	// EstimatedGas is the sum of the gas used by the selected txs (from the pre-execution in the pool)
+	EstimatedGas uint64
+	// EstimatedFee is the sum of the fees (gasPrice * gasUsed) of the selected txs
+	EstimatedFee *big.Int
+}
+
+// SimulateSelection simulates the selection of the ready txs that fit in the provided batch resources, without
+// executing them and without modifying the worker. As the txs are not executed, only the current ready tx of each
+// sender is taken into account
+func (w *Worker) SimulateSelection(resources state.BatchResources) SelectionSimulation {
+	w.workerMutex.Lock()
+	defer w.workerMutex.Unlock()
+
+	simulation := SelectionSimulation{
+		Txs:          []*TxTracker{},
+		EstimatedFee: new(big.Int),
+	}
+
+	addTx := func(tx *TxTracker) {
+		simulation.Txs = append(simulation.Txs, tx)
+		simulation.EstimatedGas += tx.UsedZKCounters.GasUsed
+		simulation.EstimatedFee.Add(simulation.EstimatedFee, new(big.Int).Mul(tx.GasPrice, new(big.Int).SetUint64(tx.UsedZKCounters.GasUsed)))
+	}
+
+	if w.cfg.SelectionMode == SelectionModeKnapsack {
+		selected := make(map[common.Hash]struct{})
+		for {
+			tx, _ := w.findBestFeeDensityTx(resources, selected)
+			if tx == nil {
+				break
+			}
+			_, _ = resources.Sub(state.BatchResources{ZKCounters: tx.ReservedZKCounters, Bytes: tx.Bytes})
+			selected[tx.Hash] = struct{}{}
+			addTx(tx)
+		}
+		return simulation
+	}
+
+	for i := 0; i < w.txSortedList.len(); i++ {
+		tx := w.txSortedList.getByIndex(i)
+		if w.isTxSkipped(tx) {
+			continue
+		}
+		if w.isAccountBatchLimitReached(tx.From) {
+			continue
+		}
+
+		overflow, _ := resources.Sub(state.BatchResources{ZKCounters: tx.ReservedZKCounters, Bytes: tx.Bytes})
+		if overflow {
+			continue
+		}
+
+		addTx(tx)
+	}
+
+	return simulation
+}
+
+// ResetBatchTracking resets the number of txs included per account and the deferred txs. It must be called when a new wip batch is opened
+func (w *Worker) ResetBatchTracking() {
+	w.workerMutex.Lock()
+	defer w.workerMutex.Unlock()
+
+	w.batchTxsCount = make(map[common.Address]uint64)
+	w.deferredTxs = make(map[common.Hash]struct{})
 }
 
 // ExpireTransactions deletes old txs
@@ -397,6 +640,18 @@ func (w *Worker) addTxToSortedList(readyTx *TxTracker) {
 	}
 }
 
+// isTxSkipped returns true if the tx has been deferred or if its gas price is lower than the min gas price
+func (w *Worker) isTxSkipped(tx *TxTracker) bool {
+	if _, deferred := w.deferredTxs[tx.Hash]; deferred {
+		return true
+	}
+	return w.cfg.MinGasPriceWei > 0 && tx.GasPrice.Cmp(new(big.Int).SetUint64(w.cfg.MinGasPriceWei)) < 0
+}
+
+func (w *Worker) isAccountBatchLimitReached(addr common.Address) bool {
+	return w.cfg.MaxTxsPerAccountPerBatch > 0 && w.batchTxsCount[addr] >= w.cfg.MaxTxsPerAccountPerBatch
+}
+
 func (w *Worker) resetWipTx(txHash common.Hash) {
 	if (w.wipTx != nil) && (w.wipTx.Hash == txHash) {
 		w.wipTx = nil
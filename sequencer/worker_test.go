@@ -10,6 +10,7 @@ import (
 	"github.com/0xPolygonHermez/zkevm-node/state"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 const (
@@ -284,6 +285,439 @@ func TestWorkerGetBestTx(t *testing.T) {
 }
 
 func initWorker(stateMock *StateMock, rcMax state.BatchConstraintsCfg) *Worker {
-	worker := NewWorker(stateMock, rcMax, newTimeoutCond(&sync.Mutex{}))
+	worker, err := NewWorker(WorkerCfg{}, stateMock, rcMax, newTimeoutCond(&sync.Mutex{}))
+	if err != nil {
+		panic(err)
+	}
 	return worker
 }
+
+func TestWorkerNonceChain(t *testing.T) {
+	var nilErr error
+
+	rc := state.BatchResources{
+		ZKCounters: state.ZKCounters{GasUsed: 10, KeccakHashes: 10, PoseidonHashes: 10, PoseidonPaddings: 10, MemAligns: 10, Arithmetics: 10, Binaries: 10, Steps: 10, Sha256Hashes_V2: 10},
+		Bytes:      10,
+	}
+
+	stateMock := NewStateMock(t)
+	worker := initWorker(stateMock, rcMax)
+
+	ctx := context.Background()
+
+	stateMock.On("GetLastStateRoot", ctx, nil).Return(common.Hash{0}, nilErr)
+	stateMock.On("GetNonceByStateRoot", ctx, common.Address{1}, common.Hash{0}).Return(new(big.Int).SetInt64(1), nilErr)
+	stateMock.On("GetBalanceByStateRoot", ctx, common.Address{1}, common.Hash{0}).Return(new(big.Int).SetInt64(10), nilErr)
+	stateMock.On("GetNonceByStateRoot", ctx, common.Address{2}, common.Hash{0}).Return(new(big.Int).SetInt64(1), nilErr)
+	stateMock.On("GetBalanceByStateRoot", ctx, common.Address{2}, common.Hash{0}).Return(new(big.Int).SetInt64(10), nilErr)
+
+	// The txs with nonce 2 and 3 of from:0x01 are not ready until the tx with nonce 1 is processed
+	addTxsTC := []workerAddTxTestCase{
+		{
+			name: "Adding from:0x01, tx:0x13/nonce:3/gp:10", from: common.Address{1}, txHash: common.Hash{0x13}, nonce: 3, gasPrice: new(big.Int).SetInt64(10),
+			cost: new(big.Int).SetInt64(1), usedBytes: 1,
+			expectedTxSortedList: []common.Hash{},
+		},
+		{
+			name: "Adding from:0x01, tx:0x12/nonce:2/gp:10", from: common.Address{1}, txHash: common.Hash{0x12}, nonce: 2, gasPrice: new(big.Int).SetInt64(10),
+			cost: new(big.Int).SetInt64(1), usedBytes: 1,
+			expectedTxSortedList: []common.Hash{},
+		},
+		{
+			name: "Adding from:0x02, tx:0x21/nonce:1/gp:5", from: common.Address{2}, txHash: common.Hash{0x21}, nonce: 1, gasPrice: new(big.Int).SetInt64(5),
+			cost: new(big.Int).SetInt64(1), usedBytes: 1,
+			expectedTxSortedList: []common.Hash{{0x21}},
+		},
+		{
+			name: "Adding from:0x01, tx:0x11/nonce:1/gp:10", from: common.Address{1}, txHash: common.Hash{0x11}, nonce: 1, gasPrice: new(big.Int).SetInt64(10),
+			cost: new(big.Int).SetInt64(1), usedBytes: 1,
+			expectedTxSortedList: []common.Hash{{0x11}, {0x21}},
+		},
+	}
+
+	processWorkerAddTxTestCases(ctx, t, worker, addTxsTC)
+
+	// The whole nonce chain of from:0x01 must be drained before the tx of from:0x02 (lower gasPrice)
+	expectedGetBestTx := []common.Hash{{0x11}, {0x12}, {0x13}, {0x21}}
+	for i, expectedHash := range expectedGetBestTx {
+		tx, err := worker.GetBestFittingTx(rc)
+		assert.NoError(t, err)
+		if tx == nil {
+			t.Fatalf("Error GetBestFittingTx(%d). Expected=%s, Actual=nil", i, expectedHash.String())
+		}
+		assert.Equal(t, expectedHash.String(), tx.HashStr)
+
+		worker.DeleteTx(tx.Hash, tx.From)
+		touch := make(map[common.Address]*state.InfoReadWrite)
+		newNonce := tx.Nonce + 1
+		touch[tx.From] = &state.InfoReadWrite{Address: tx.From, Nonce: &newNonce, Balance: new(big.Int).SetInt64(10)}
+		txsToDelete := worker.UpdateAfterSingleSuccessfulTxExecution(tx.From, touch)
+		assert.Empty(t, txsToDelete)
+	}
+
+	_, err := worker.GetBestFittingTx(rc)
+	assert.ErrorIs(t, err, ErrTransactionsListEmpty)
+}
+
+func TestWorkerMaxTxsPerAccountPerBatch(t *testing.T) {
+	var nilErr error
+
+	rc := state.BatchResources{
+		ZKCounters: state.ZKCounters{GasUsed: 10, KeccakHashes: 10, PoseidonHashes: 10, PoseidonPaddings: 10, MemAligns: 10, Arithmetics: 10, Binaries: 10, Steps: 10, Sha256Hashes_V2: 10},
+		Bytes:      10,
+	}
+
+	stateMock := NewStateMock(t)
+	worker, err := NewWorker(WorkerCfg{MaxTxsPerAccountPerBatch: 2}, stateMock, rcMax, newTimeoutCond(&sync.Mutex{}))
+	require.NoError(t, err)
+
+	ctx := context.Background()
+
+	stateMock.On("GetLastStateRoot", ctx, nil).Return(common.Hash{0}, nilErr)
+	stateMock.On("GetNonceByStateRoot", ctx, common.Address{1}, common.Hash{0}).Return(new(big.Int).SetInt64(1), nilErr)
+	stateMock.On("GetBalanceByStateRoot", ctx, common.Address{1}, common.Hash{0}).Return(new(big.Int).SetInt64(10), nilErr)
+	stateMock.On("GetNonceByStateRoot", ctx, common.Address{2}, common.Hash{0}).Return(new(big.Int).SetInt64(1), nilErr)
+	stateMock.On("GetBalanceByStateRoot", ctx, common.Address{2}, common.Hash{0}).Return(new(big.Int).SetInt64(10), nilErr)
+
+	addTxsTC := []workerAddTxTestCase{
+		{
+			name: "Adding from:0x01, tx:0x11/nonce:1/gp:10", from: common.Address{1}, txHash: common.Hash{0x11}, nonce: 1, gasPrice: new(big.Int).SetInt64(10),
+			cost: new(big.Int).SetInt64(1), usedBytes: 1,
+			expectedTxSortedList: []common.Hash{{0x11}},
+		},
+		{
+			name: "Adding from:0x01, tx:0x12/nonce:2/gp:10", from: common.Address{1}, txHash: common.Hash{0x12}, nonce: 2, gasPrice: new(big.Int).SetInt64(10),
+			cost: new(big.Int).SetInt64(1), usedBytes: 1,
+			expectedTxSortedList: []common.Hash{{0x11}},
+		},
+		{
+			name: "Adding from:0x01, tx:0x13/nonce:3/gp:10", from: common.Address{1}, txHash: common.Hash{0x13}, nonce: 3, gasPrice: new(big.Int).SetInt64(10),
+			cost: new(big.Int).SetInt64(1), usedBytes: 1,
+			expectedTxSortedList: []common.Hash{{0x11}},
+		},
+		{
+			name: "Adding from:0x02, tx:0x21/nonce:1/gp:5", from: common.Address{2}, txHash: common.Hash{0x21}, nonce: 1, gasPrice: new(big.Int).SetInt64(5),
+			cost: new(big.Int).SetInt64(1), usedBytes: 1,
+			expectedTxSortedList: []common.Hash{{0x11}, {0x21}},
+		},
+	}
+
+	processWorkerAddTxTestCases(ctx, t, worker, addTxsTC)
+
+	processTx := func(tx *TxTracker) {
+		worker.DeleteTx(tx.Hash, tx.From)
+		touch := make(map[common.Address]*state.InfoReadWrite)
+		newNonce := tx.Nonce + 1
+		touch[tx.From] = &state.InfoReadWrite{Address: tx.From, Nonce: &newNonce, Balance: new(big.Int).SetInt64(10)}
+		worker.UpdateAfterSingleSuccessfulTxExecution(tx.From, touch)
+	}
+
+	// After 2 txs of from:0x01 the tx of from:0x02 is selected although it has lower gasPrice
+	expectedGetBestTx := []common.Hash{{0x11}, {0x12}, {0x21}}
+	for i, expectedHash := range expectedGetBestTx {
+		tx, err := worker.GetBestFittingTx(rc)
+		require.NoError(t, err)
+		require.NotNil(t, tx, "GetBestFittingTx(%d)", i)
+		assert.Equal(t, expectedHash.String(), tx.HashStr)
+		processTx(tx)
+	}
+
+	// from:0x01 has reached the limit for the current batch and no other tx is ready, the batch must not be closed
+	_, err = worker.GetBestFittingTx(rc)
+	assert.ErrorIs(t, err, ErrTransactionsListEmpty)
+
+	// The same in the knapsack selection mode
+	worker.cfg.SelectionMode = SelectionModeKnapsack
+	_, err = worker.GetBestFittingTx(rc)
+	assert.ErrorIs(t, err, ErrTransactionsListEmpty)
+	worker.cfg.SelectionMode = SelectionModeGreedy
+
+	// In a new batch the remaining tx of from:0x01 can be selected
+	worker.ResetBatchTracking()
+	tx, err := worker.GetBestFittingTx(rc)
+	require.NoError(t, err)
+	assert.Equal(t, common.Hash{0x13}.String(), tx.HashStr)
+}
+
+func TestWorkerDeferTx(t *testing.T) {
+	var nilErr error
+
+	rc := state.BatchResources{
+		ZKCounters: state.ZKCounters{GasUsed: 10, KeccakHashes: 10, PoseidonHashes: 10, PoseidonPaddings: 10, MemAligns: 10, Arithmetics: 10, Binaries: 10, Steps: 10, Sha256Hashes_V2: 10},
+		Bytes:      10,
+	}
+
+	stateMock := NewStateMock(t)
+	worker := initWorker(stateMock, rcMax)
+
+	ctx := context.Background()
+
+	stateMock.On("GetLastStateRoot", ctx, nil).Return(common.Hash{0}, nilErr)
+	stateMock.On("GetNonceByStateRoot", ctx, common.Address{1}, common.Hash{0}).Return(new(big.Int).SetInt64(1), nilErr)
+	stateMock.On("GetBalanceByStateRoot", ctx, common.Address{1}, common.Hash{0}).Return(new(big.Int).SetInt64(10), nilErr)
+	stateMock.On("GetNonceByStateRoot", ctx, common.Address{2}, common.Hash{0}).Return(new(big.Int).SetInt64(1), nilErr)
+	stateMock.On("GetBalanceByStateRoot", ctx, common.Address{2}, common.Hash{0}).Return(new(big.Int).SetInt64(10), nilErr)
+
+	addTxsTC := []workerAddTxTestCase{
+		{
+			name: "Adding from:0x01, tx:0x11/nonce:1/gp:10", from: common.Address{1}, txHash: common.Hash{0x11}, nonce: 1, gasPrice: new(big.Int).SetInt64(10),
+			cost: new(big.Int).SetInt64(1), usedBytes: 1,
+			expectedTxSortedList: []common.Hash{{0x11}},
+		},
+		{
+			name: "Adding from:0x02, tx:0x21/nonce:1/gp:5", from: common.Address{2}, txHash: common.Hash{0x21}, nonce: 1, gasPrice: new(big.Int).SetInt64(5),
+			cost: new(big.Int).SetInt64(1), usedBytes: 1,
+			expectedTxSortedList: []common.Hash{{0x11}, {0x21}},
+		},
+	}
+
+	processWorkerAddTxTestCases(ctx, t, worker, addTxsTC)
+
+	tx, err := worker.GetBestFittingTx(rc)
+	require.NoError(t, err)
+	assert.Equal(t, common.Hash{0x11}.String(), tx.HashStr)
+
+	// The deferred tx is not selected again in the current batch
+	worker.DeferTx(tx.Hash)
+	tx, err = worker.GetBestFittingTx(rc)
+	require.NoError(t, err)
+	assert.Equal(t, common.Hash{0x21}.String(), tx.HashStr)
+
+	worker.DeferTx(tx.Hash)
+	_, err = worker.GetBestFittingTx(rc)
+	assert.ErrorIs(t, err, ErrTransactionsListEmpty)
+
+	// In a new batch the deferred txs can be selected again
+	worker.ResetBatchTracking()
+	tx, err = worker.GetBestFittingTx(rc)
+	require.NoError(t, err)
+	assert.Equal(t, common.Hash{0x11}.String(), tx.HashStr)
+}
+
+func TestWorkerSimulateSelection(t *testing.T) {
+	var nilErr error
+
+	rc := state.BatchResources{
+		ZKCounters: state.ZKCounters{GasUsed: 10, KeccakHashes: 10, PoseidonHashes: 10, PoseidonPaddings: 10, MemAligns: 10, Arithmetics: 10, Binaries: 10, Steps: 10, Sha256Hashes_V2: 10},
+		Bytes:      10,
+	}
+
+	stateMock := NewStateMock(t)
+	worker := initWorker(stateMock, rcMax)
+
+	ctx := context.Background()
+
+	stateMock.On("GetLastStateRoot", ctx, nil).Return(common.Hash{0}, nilErr)
+	for _, addr := range []common.Address{{1}, {2}, {3}} {
+		stateMock.On("GetNonceByStateRoot", ctx, addr, common.Hash{0}).Return(new(big.Int).SetInt64(1), nilErr)
+		stateMock.On("GetBalanceByStateRoot", ctx, addr, common.Hash{0}).Return(new(big.Int).SetInt64(10), nilErr)
+	}
+
+	addTxsTC := []workerAddTxTestCase{
+		{
+			name: "Adding from:0x01, tx:0x01/gp:10", from: common.Address{1}, txHash: common.Hash{1}, nonce: 1, gasPrice: new(big.Int).SetInt64(10),
+			cost:                 new(big.Int).SetInt64(1),
+			reservedZKCounters:   state.ZKCounters{GasUsed: 4, KeccakHashes: 4, PoseidonHashes: 4, PoseidonPaddings: 4, MemAligns: 4, Arithmetics: 4, Binaries: 4, Steps: 4, Sha256Hashes_V2: 4},
+			usedBytes:            1,
+			expectedTxSortedList: []common.Hash{{1}},
+		},
+		{
+			name: "Adding from:0x02, tx:0x02/gp:8", from: common.Address{2}, txHash: common.Hash{2}, nonce: 1, gasPrice: new(big.Int).SetInt64(8),
+			cost:                 new(big.Int).SetInt64(1),
+			reservedZKCounters:   state.ZKCounters{GasUsed: 7, KeccakHashes: 7, PoseidonHashes: 7, PoseidonPaddings: 7, MemAligns: 7, Arithmetics: 7, Binaries: 7, Steps: 7, Sha256Hashes_V2: 7},
+			usedBytes:            1,
+			expectedTxSortedList: []common.Hash{{1}, {2}},
+		},
+		{
+			name: "Adding from:0x03, tx:0x03/gp:5", from: common.Address{3}, txHash: common.Hash{3}, nonce: 1, gasPrice: new(big.Int).SetInt64(5),
+			cost:                 new(big.Int).SetInt64(1),
+			reservedZKCounters:   state.ZKCounters{GasUsed: 5, KeccakHashes: 5, PoseidonHashes: 5, PoseidonPaddings: 5, MemAligns: 5, Arithmetics: 5, Binaries: 5, Steps: 5, Sha256Hashes_V2: 5},
+			usedBytes:            1,
+			expectedTxSortedList: []common.Hash{{1}, {2}, {3}},
+		},
+	}
+
+	processWorkerAddTxTestCases(ctx, t, worker, addTxsTC)
+
+	// tx 0x02 doesn't fit after selecting tx 0x01, so the simulation continues with tx 0x03
+	simulation := worker.SimulateSelection(rc)
+	require.Len(t, simulation.Txs, 2)
+	assert.Equal(t, common.Hash{1}, simulation.Txs[0].Hash)
+	assert.Equal(t, common.Hash{3}, simulation.Txs[1].Hash)
+	assert.Equal(t, uint64(9), simulation.EstimatedGas)
+	assert.Equal(t, big.NewInt(4*10+5*5), simulation.EstimatedFee)
+
+	// The simulation must not modify the worker
+	assert.Equal(t, 3, worker.txSortedList.len())
+	tx, err := worker.GetBestFittingTx(rc)
+	require.NoError(t, err)
+	assert.Equal(t, common.Hash{1}, tx.Hash)
+}
+
+func TestWorkerMinGasPrice(t *testing.T) {
+	var nilErr error
+
+	rc := state.BatchResources{
+		ZKCounters: state.ZKCounters{GasUsed: 10, KeccakHashes: 10, PoseidonHashes: 10, PoseidonPaddings: 10, MemAligns: 10, Arithmetics: 10, Binaries: 10, Steps: 10, Sha256Hashes_V2: 10},
+		Bytes:      10,
+	}
+
+	stateMock := NewStateMock(t)
+	worker, err := NewWorker(WorkerCfg{MinGasPriceWei: 8}, stateMock, rcMax, newTimeoutCond(&sync.Mutex{}))
+	require.NoError(t, err)
+
+	ctx := context.Background()
+
+	stateMock.On("GetLastStateRoot", ctx, nil).Return(common.Hash{0}, nilErr)
+	stateMock.On("GetNonceByStateRoot", ctx, common.Address{1}, common.Hash{0}).Return(new(big.Int).SetInt64(1), nilErr)
+	stateMock.On("GetBalanceByStateRoot", ctx, common.Address{1}, common.Hash{0}).Return(new(big.Int).SetInt64(10), nilErr)
+	stateMock.On("GetNonceByStateRoot", ctx, common.Address{2}, common.Hash{0}).Return(new(big.Int).SetInt64(1), nilErr)
+	stateMock.On("GetBalanceByStateRoot", ctx, common.Address{2}, common.Hash{0}).Return(new(big.Int).SetInt64(10), nilErr)
+
+	addTxsTC := []workerAddTxTestCase{
+		{
+			name: "Adding from:0x01, tx:0x11/nonce:1/gp:10", from: common.Address{1}, txHash: common.Hash{0x11}, nonce: 1, gasPrice: new(big.Int).SetInt64(10),
+			cost: new(big.Int).SetInt64(1), usedBytes: 1,
+			expectedTxSortedList: []common.Hash{{0x11}},
+		},
+		{
+			name: "Adding from:0x02, tx:0x21/nonce:1/gp:5", from: common.Address{2}, txHash: common.Hash{0x21}, nonce: 1, gasPrice: new(big.Int).SetInt64(5),
+			cost: new(big.Int).SetInt64(1), usedBytes: 1,
+			expectedTxSortedList: []common.Hash{{0x11}, {0x21}},
+		},
+	}
+
+	processWorkerAddTxTestCases(ctx, t, worker, addTxsTC)
+
+	tx, err := worker.GetBestFittingTx(rc)
+	require.NoError(t, err)
+	assert.Equal(t, common.Hash{0x11}.String(), tx.HashStr)
+	worker.DeleteTx(tx.Hash, tx.From)
+
+	// The underpriced tx is kept in the worker but it's not selected
+	_, err = worker.GetBestFittingTx(rc)
+	assert.ErrorIs(t, err, ErrTransactionsListEmpty)
+	assert.Equal(t, 1, worker.txSortedList.len())
+}
+
+func TestWorkerKnapsackSelectionMode(t *testing.T) {
+	var nilErr error
+
+	rc := state.BatchResources{
+		ZKCounters: state.ZKCounters{GasUsed: 10, KeccakHashes: 10, PoseidonHashes: 10, PoseidonPaddings: 10, MemAligns: 10, Arithmetics: 10, Binaries: 10, Steps: 10, Sha256Hashes_V2: 10},
+		Bytes:      10,
+	}
+
+	_, err := NewWorker(WorkerCfg{SelectionMode: "unknown"}, nil, rcMax, nil)
+	assert.ErrorIs(t, err, ErrUnknownSelectionMode)
+
+	stateMock := NewStateMock(t)
+	worker, err := NewWorker(WorkerCfg{SelectionMode: SelectionModeKnapsack}, stateMock, rcMax, newTimeoutCond(&sync.Mutex{}))
+	require.NoError(t, err)
+
+	ctx := context.Background()
+
+	stateMock.On("GetLastStateRoot", ctx, nil).Return(common.Hash{0}, nilErr)
+	for _, addr := range []common.Address{{1}, {2}, {3}} {
+		stateMock.On("GetNonceByStateRoot", ctx, addr, common.Hash{0}).Return(new(big.Int).SetInt64(1), nilErr)
+		stateMock.On("GetBalanceByStateRoot", ctx, addr, common.Hash{0}).Return(new(big.Int).SetInt64(10), nilErr)
+	}
+
+	addTxsTC := []workerAddTxTestCase{
+		{
+			name: "Adding from:0x01, tx:0x01/gp:10/gas:2/keccaks:8", from: common.Address{1}, txHash: common.Hash{1}, nonce: 1, gasPrice: new(big.Int).SetInt64(10),
+			cost: new(big.Int).SetInt64(1), reservedZKCounters: state.ZKCounters{GasUsed: 2, KeccakHashes: 8}, usedBytes: 1,
+			expectedTxSortedList: []common.Hash{{1}},
+		},
+		{
+			name: "Adding from:0x02, tx:0x02/gp:5/gas:5/keccaks:5", from: common.Address{2}, txHash: common.Hash{2}, nonce: 1, gasPrice: new(big.Int).SetInt64(5),
+			cost: new(big.Int).SetInt64(1), reservedZKCounters: state.ZKCounters{GasUsed: 5, KeccakHashes: 5}, usedBytes: 1,
+			expectedTxSortedList: []common.Hash{{1}, {2}},
+		},
+		{
+			name: "Adding from:0x03, tx:0x03/gp:4/gas:5/keccaks:5", from: common.Address{3}, txHash: common.Hash{3}, nonce: 1, gasPrice: new(big.Int).SetInt64(4),
+			cost: new(big.Int).SetInt64(1), reservedZKCounters: state.ZKCounters{GasUsed: 5, KeccakHashes: 5}, usedBytes: 1,
+			expectedTxSortedList: []common.Hash{{1}, {2}, {3}},
+		},
+	}
+
+	processWorkerAddTxTestCases(ctx, t, worker, addTxsTC)
+
+	// The greedy mode would select only tx 0x01 (fee 20) as it uses most of the keccaks of the batch.
+	// The knapsack mode selects 0x02 and 0x03 (fee 25 + 20)
+	expectedGetBestTx := []common.Hash{{2}, {3}}
+
+	simulation := worker.SimulateSelection(rc)
+	require.Len(t, simulation.Txs, len(expectedGetBestTx))
+	for i, expectedHash := range expectedGetBestTx {
+		assert.Equal(t, expectedHash, simulation.Txs[i].Hash)
+	}
+	assert.Equal(t, big.NewInt(45), simulation.EstimatedFee)
+
+	for _, expectedHash := range expectedGetBestTx {
+		tx, err := worker.GetBestFittingTx(rc)
+		require.NoError(t, err)
+		assert.Equal(t, expectedHash, tx.Hash)
+
+		_, _ = rc.Sub(state.BatchResources{ZKCounters: tx.ReservedZKCounters, Bytes: tx.Bytes})
+		worker.DeleteTx(tx.Hash, tx.From)
+	}
+
+	_, err = worker.GetBestFittingTx(rc)
+	assert.ErrorIs(t, err, ErrNoFittingTransaction)
+}
+
+func TestWorkerKnapsackSelectionModePrioritySenders(t *testing.T) {
+	var nilErr error
+
+	rc := state.BatchResources{
+		ZKCounters: state.ZKCounters{GasUsed: 10, KeccakHashes: 10, PoseidonHashes: 10, PoseidonPaddings: 10, MemAligns: 10, Arithmetics: 10, Binaries: 10, Steps: 10, Sha256Hashes_V2: 10},
+		Bytes:      10,
+	}
+
+	stateMock := NewStateMock(t)
+	worker, err := NewWorker(WorkerCfg{SelectionMode: SelectionModeKnapsack, PriorityAddresses: []common.Address{{1}}}, stateMock, rcMax, newTimeoutCond(&sync.Mutex{}))
+	require.NoError(t, err)
+
+	ctx := context.Background()
+
+	stateMock.On("GetLastStateRoot", ctx, nil).Return(common.Hash{0}, nilErr)
+	for _, addr := range []common.Address{{1}, {2}, {3}} {
+		stateMock.On("GetNonceByStateRoot", ctx, addr, common.Hash{0}).Return(new(big.Int).SetInt64(1), nilErr)
+		stateMock.On("GetBalanceByStateRoot", ctx, addr, common.Hash{0}).Return(new(big.Int).SetInt64(10), nilErr)
+	}
+
+	addTxsTC := []workerAddTxTestCase{
+		{
+			name: "Adding from:0x02, tx:0x02/gp:5/gas:5/keccaks:5", from: common.Address{2}, txHash: common.Hash{2}, nonce: 1, gasPrice: new(big.Int).SetInt64(5),
+			cost: new(big.Int).SetInt64(1), reservedZKCounters: state.ZKCounters{GasUsed: 5, KeccakHashes: 5}, usedBytes: 1,
+			expectedTxSortedList: []common.Hash{{2}},
+		},
+		{
+			name: "Adding from:0x03, tx:0x03/gp:4/gas:5/keccaks:5", from: common.Address{3}, txHash: common.Hash{3}, nonce: 1, gasPrice: new(big.Int).SetInt64(4),
+			cost: new(big.Int).SetInt64(1), reservedZKCounters: state.ZKCounters{GasUsed: 5, KeccakHashes: 5}, usedBytes: 1,
+			expectedTxSortedList: []common.Hash{{2}, {3}},
+		},
+		{
+			name: "Adding priority from:0x01, tx:0x01/gp:1/gas:2/keccaks:8", from: common.Address{1}, txHash: common.Hash{1}, nonce: 1, gasPrice: new(big.Int).SetInt64(1),
+			cost: new(big.Int).SetInt64(1), reservedZKCounters: state.ZKCounters{GasUsed: 2, KeccakHashes: 8}, usedBytes: 1,
+			expectedTxSortedList: []common.Hash{{1}, {2}, {3}},
+		},
+	}
+
+	processWorkerAddTxTestCases(ctx, t, worker, addTxsTC)
+
+	// The tx of the priority sender is selected even with the lowest fee density and although
+	// then neither 0x02 nor 0x03 fit in the batch
+	for _, expectedHash := range []common.Hash{{1}} {
+		tx, err := worker.GetBestFittingTx(rc)
+		require.NoError(t, err)
+		assert.Equal(t, expectedHash, tx.Hash)
+
+		_, _ = rc.Sub(state.BatchResources{ZKCounters: tx.ReservedZKCounters, Bytes: tx.Bytes})
+		worker.DeleteTx(tx.Hash, tx.From)
+	}
+
+	_, err = worker.GetBestFittingTx(rc)
+	assert.ErrorIs(t, err, ErrNoFittingTransaction)
+}
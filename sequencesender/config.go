@@ -28,6 +28,9 @@ type Config struct {
 	// PrivateKey defines all the key store files that are going
 	// to be read in order to provide the private keys to sign the L1 txs
 	PrivateKey types.KeystoreFileConfig `mapstructure:"PrivateKey"`
+	// ExternalSigner defines the external signer used to sign the L1 txs instead
+	// of the PrivateKey, it's used if its URL is set
+	ExternalSigner types.ExternalSignerConfig `mapstructure:"ExternalSigner"`
 	// Batch number where there is a forkid change (fork upgrade)
 	ForkUpgradeBatchNumber uint64
 	// GasOffset is the amount of gas to be added to the gas estimation in order
@@ -0,0 +1,68 @@
+package state
+
+import (
+	"bytes"
+	"context"
+	"sort"
+
+	"github.com/0xPolygonHermez/zkevm-node/state/runtime"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/jackc/pgx/v4"
+)
+
+// CreateAccessList processes the given unsigned transaction on top of the state of the L2 block, the last one
+// if l2BlockNumber is nil, and returns the addresses and storage keys it accesses along with the execution result.
+// As in the access lists created by geth, the sender, the recipient and the precompiled contracts are excluded,
+// and so are the coinbase and the system smart contract, which are touched by every transaction
+func (s *State) CreateAccessList(ctx context.Context, tx *types.Transaction, senderAddress common.Address, l2BlockNumber *uint64, dbTx pgx.Tx) (types.AccessList, *runtime.ExecutionResult, error) {
+	var l2Block *L2Block
+	var err error
+	if l2BlockNumber == nil {
+		l2Block, err = s.GetLastL2Block(ctx, dbTx)
+	} else {
+		l2Block, err = s.GetL2BlockByNumber(ctx, *l2BlockNumber, dbTx)
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+
+	batch, err := s.GetBatchByL2BlockNumber(ctx, l2Block.NumberU64(), dbTx)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	response, err := s.processUnsignedTransactionOnL2Block(ctx, tx, senderAddress, batch, l2Block, true, nil, dbTx)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	excluded := map[common.Address]bool{
+		senderAddress:                 true,
+		batch.Coinbase:                true,
+		l2Block.Coinbase():            true,
+		common.HexToAddress(SystemSC): true,
+	}
+	if tx.To() != nil {
+		excluded[*tx.To()] = true
+	}
+	for _, precompile := range vm.PrecompiledAddressesBerlin {
+		excluded[precompile] = true
+	}
+
+	accessList := types.AccessList{}
+	for address, info := range response.ReadWriteAddresses {
+		if excluded[address] {
+			continue
+		}
+		storageKeys := make([]common.Hash, len(info.StorageKeys))
+		copy(storageKeys, info.StorageKeys)
+		accessList = append(accessList, types.AccessTuple{Address: address, StorageKeys: storageKeys})
+	}
+	sort.Slice(accessList, func(i, j int) bool {
+		return bytes.Compare(accessList[i].Address.Bytes(), accessList[j].Address.Bytes()) < 0
+	})
+
+	return accessList, newExecutionResult(response.BlockResponses[0].TransactionResponses[0]), nil
+}
@@ -83,6 +83,12 @@ const (
 	MaxDeltaTimestampClosingReason ClosingReason = "Max delta timestamp"
 	// NoTxFitsClosingReason is the closing reason used when any of the txs in the pool (worker) fits in the remaining resources of the batch
 	NoTxFitsClosingReason ClosingReason = "No transaction fits"
+	// MaxGasUtilizationClosingReason is the closing reason used when the configured max gas utilization of the batch is reached
+	MaxGasUtilizationClosingReason ClosingReason = "Max gas utilization"
+	// IdleTimeoutClosingReason is the closing reason used when no new tx has been added to the batch for the configured idle timeout
+	IdleTimeoutClosingReason ClosingReason = "Idle timeout"
+	// SequencerPausedClosingReason is the closing reason used when the sequencer is paused by the operator
+	SequencerPausedClosingReason ClosingReason = "Sequencer paused"
 
 	// Reason due Synchronizer
 	// ------------------------------------------------------------------------------------------
@@ -162,7 +162,7 @@ func (s *State) ExecuteBatchV2(ctx context.Context, batch Batch, L1InfoTreeRoot
 	log.Debugf("ExecuteBatchV2[processBatchRequest.SkipVerifyL1InfoRoot]: %v", processBatchRequest.SkipVerifyL1InfoRoot)
 	log.Debugf("ExecuteBatchV2[processBatchRequest.L1InfoTreeData]: %+v", l1InfoTreeData)
 
-	processBatchResponse, err := s.executorClient.ProcessBatchV2(ctx, processBatchRequest)
+	processBatchResponse, err := s.executeBatchRequestV2(ctx, processBatchRequest)
 	if err != nil {
 		log.Error("error executing batch: ", err)
 		return nil, err
@@ -286,7 +286,7 @@ func (s *State) sendBatchRequestToExecutorV2(ctx context.Context, batchRequest *
 	log.Debugf("executor batch %d request, %s", newBatchNum, batchRequestLog)
 
 	now := time.Now()
-	batchResponse, err := s.executorClient.ProcessBatchV2(ctx, batchRequest)
+	batchResponse, err := s.executeBatchRequestV2(ctx, batchRequest)
 	elapsed := time.Since(now)
 
 	//workarroundDuplicatedBlock(res)
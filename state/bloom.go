@@ -0,0 +1,40 @@
+package state
+
+import (
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// BloomMatchesFilter checks if the logs bloom may contain logs matching the
+// addresses and topics of a filter. A bloom can return false positives, so
+// true means the logs must be checked, but false means no log matches
+func BloomMatchesFilter(bloom types.Bloom, addresses []common.Address, topics [][]common.Hash) bool {
+	if len(addresses) > 0 {
+		included := false
+		for _, address := range addresses {
+			if types.BloomLookup(bloom, address) {
+				included = true
+				break
+			}
+		}
+		if !included {
+			return false
+		}
+	}
+
+	for _, topicsAtPosition := range topics {
+		// no topics in a position means any topic is accepted
+		included := len(topicsAtPosition) == 0
+		for _, topic := range topicsAtPosition {
+			if types.BloomLookup(bloom, topic) {
+				included = true
+				break
+			}
+		}
+		if !included {
+			return false
+		}
+	}
+
+	return true
+}
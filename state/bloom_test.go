@@ -0,0 +1,42 @@
+package state_test
+
+import (
+	"testing"
+
+	"github.com/0xPolygonHermez/zkevm-node/state"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBloomMatchesFilter(t *testing.T) {
+	address := common.HexToAddress("0x1")
+	topic0 := common.HexToHash("0x2")
+	topic1 := common.HexToHash("0x3")
+	bloom := types.CreateBloom(types.Receipts{{Logs: []*types.Log{{Address: address, Topics: []common.Hash{topic0, topic1}}}}})
+	otherAddress := common.HexToAddress("0x4")
+	otherTopic := common.HexToHash("0x5")
+
+	testCases := []struct {
+		name      string
+		addresses []common.Address
+		topics    [][]common.Hash
+		expected  bool
+	}{
+		{name: "no filter", expected: true},
+		{name: "matching address", addresses: []common.Address{otherAddress, address}, expected: true},
+		{name: "not matching address", addresses: []common.Address{otherAddress}, expected: false},
+		{name: "matching topics", topics: [][]common.Hash{{topic0}, {otherTopic, topic1}}, expected: true},
+		{name: "any topic at a position", topics: [][]common.Hash{{}, {topic1}}, expected: true},
+		{name: "not matching topic", topics: [][]common.Hash{{topic0}, {otherTopic}}, expected: false},
+		{name: "matching address and not matching topic", addresses: []common.Address{address}, topics: [][]common.Hash{{otherTopic}}, expected: false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, state.BloomMatchesFilter(bloom, tc.addresses, tc.topics))
+		})
+	}
+
+	assert.False(t, state.BloomMatchesFilter(types.Bloom{}, []common.Address{address}, nil))
+}
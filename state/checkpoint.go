@@ -0,0 +1,94 @@
+package state
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/0xPolygonHermez/zkevm-node/log"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/jackc/pgx/v4"
+)
+
+var (
+	// ErrCheckpointBatchNotClosed is returned when a checkpoint is created for a batch that is not closed
+	ErrCheckpointBatchNotClosed = errors.New("checkpoints can only be created for closed batches")
+	// ErrCheckpointStateRootMismatch is returned when the batch of a checkpoint doesn't have the state root of the checkpoint anymore
+	ErrCheckpointStateRootMismatch = errors.New("the state root of the checkpoint batch doesn't match the checkpoint")
+)
+
+// Checkpoint is a named snapshot of the state at the end of a closed batch. The nodes of the merkle
+// tree are addressed by their hash and never removed, so the state root of the checkpoint remains
+// valid after newer batches are processed and rolling back to it only needs to remove the newer
+// batches, without replaying any of them
+type Checkpoint struct {
+	Name          string
+	BatchNumber   uint64
+	StateRoot     common.Hash
+	LocalExitRoot common.Hash
+	AccInputHash  common.Hash
+	CreatedAt     time.Time
+}
+
+// CreateCheckpoint creates a checkpoint with the provided name of the state at the end of the provided
+// batch, that must be closed. A previous checkpoint with the same name is replaced
+func (s *State) CreateCheckpoint(ctx context.Context, name string, batchNumber uint64, dbTx pgx.Tx) (*Checkpoint, error) {
+	closed, err := s.IsBatchClosed(ctx, batchNumber, dbTx)
+	if err != nil {
+		return nil, err
+	}
+	if !closed {
+		return nil, fmt.Errorf("batch %d: %w", batchNumber, ErrCheckpointBatchNotClosed)
+	}
+
+	batch, err := s.GetBatchByNumber(ctx, batchNumber, dbTx)
+	if err != nil {
+		return nil, err
+	}
+
+	checkpoint := Checkpoint{
+		Name:          name,
+		BatchNumber:   batch.BatchNumber,
+		StateRoot:     batch.StateRoot,
+		LocalExitRoot: batch.LocalExitRoot,
+		AccInputHash:  batch.AccInputHash,
+		CreatedAt:     time.Now(),
+	}
+	if err := s.AddCheckpoint(ctx, checkpoint, dbTx); err != nil {
+		return nil, err
+	}
+	log.Infof("checkpoint %s created for batch %d with state root %s", name, batchNumber, batch.StateRoot.String())
+	return &checkpoint, nil
+}
+
+// RollbackToCheckpoint removes the batches newer than the batch of the checkpoint with the provided
+// name, together with their L2 blocks and the checkpoints created for them, and returns the checkpoint,
+// so the caller can continue processing from its state root. If any of the removed batches was
+// virtualized, the L1 blocks from the first one that virtualized them are removed too, so the
+// synchronizer syncs them again from L1. It returns ErrNotFound if there is no checkpoint with the
+// provided name
+func (s *State) RollbackToCheckpoint(ctx context.Context, name string, dbTx pgx.Tx) (*Checkpoint, error) {
+	checkpoint, err := s.GetCheckpoint(ctx, name, dbTx)
+	if err != nil {
+		return nil, err
+	}
+
+	// the batch could have been reprocessed with a different result after the checkpoint was created
+	batch, err := s.GetBatchByNumber(ctx, checkpoint.BatchNumber, dbTx)
+	if err != nil {
+		return nil, err
+	}
+	if batch.StateRoot != checkpoint.StateRoot {
+		return nil, fmt.Errorf("checkpoint %s, batch %d state root %s: %w", name, batch.BatchNumber, batch.StateRoot.String(), ErrCheckpointStateRootMismatch)
+	}
+
+	if err := s.ResetToBatchNumber(ctx, checkpoint.BatchNumber, dbTx); err != nil {
+		return nil, err
+	}
+	// the L1InfoTree cache is rebuilt on the next request that needs it, as in Reset
+	s.l1InfoTree = nil
+	s.l1InfoTreeRecursive = nil
+	log.Infof("state rolled back to checkpoint %s, batch %d with state root %s", name, checkpoint.BatchNumber, checkpoint.StateRoot.String())
+	return checkpoint, nil
+}
@@ -0,0 +1,68 @@
+package state_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/0xPolygonHermez/zkevm-node/state"
+	"github.com/0xPolygonHermez/zkevm-node/state/mocks"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreateCheckpoint(t *testing.T) {
+	ctx := context.Background()
+	mockStorage := mocks.NewStorageMock(t)
+	testState := state.NewState(state.Config{}, mockStorage, nil, nil, nil, nil, nil)
+	dbTx := mocks.NewDbTxMock(t)
+
+	batch := &state.Batch{
+		BatchNumber:   5,
+		StateRoot:     common.HexToHash("0x1"),
+		LocalExitRoot: common.HexToHash("0x2"),
+		AccInputHash:  common.HexToHash("0x3"),
+	}
+
+	// the batch must be closed
+	mockStorage.EXPECT().IsBatchClosed(ctx, uint64(6), dbTx).Return(false, nil).Once()
+	_, err := testState.CreateCheckpoint(ctx, "wip", 6, dbTx)
+	require.ErrorIs(t, err, state.ErrCheckpointBatchNotClosed)
+
+	mockStorage.EXPECT().IsBatchClosed(ctx, uint64(5), dbTx).Return(true, nil).Once()
+	mockStorage.EXPECT().GetBatchByNumber(ctx, uint64(5), dbTx).Return(batch, nil).Once()
+	mockStorage.EXPECT().AddCheckpoint(ctx, mock.MatchedBy(func(c state.Checkpoint) bool {
+		return c.Name == "batch5" && c.BatchNumber == 5 && c.StateRoot == batch.StateRoot &&
+			c.LocalExitRoot == batch.LocalExitRoot && c.AccInputHash == batch.AccInputHash
+	}), dbTx).Return(nil).Once()
+	checkpoint, err := testState.CreateCheckpoint(ctx, "batch5", 5, dbTx)
+	require.NoError(t, err)
+	assert.Equal(t, batch.StateRoot, checkpoint.StateRoot)
+}
+
+func TestRollbackToCheckpoint(t *testing.T) {
+	ctx := context.Background()
+	mockStorage := mocks.NewStorageMock(t)
+	testState := state.NewState(state.Config{}, mockStorage, nil, nil, nil, nil, nil)
+	dbTx := mocks.NewDbTxMock(t)
+
+	checkpoint := &state.Checkpoint{Name: "batch5", BatchNumber: 5, StateRoot: common.HexToHash("0x1")}
+
+	mockStorage.EXPECT().GetCheckpoint(ctx, "unknown", dbTx).Return(nil, state.ErrNotFound).Once()
+	_, err := testState.RollbackToCheckpoint(ctx, "unknown", dbTx)
+	require.ErrorIs(t, err, state.ErrNotFound)
+
+	// the batch was reprocessed after the checkpoint was created
+	mockStorage.EXPECT().GetCheckpoint(ctx, "batch5", dbTx).Return(checkpoint, nil).Once()
+	mockStorage.EXPECT().GetBatchByNumber(ctx, uint64(5), dbTx).Return(&state.Batch{BatchNumber: 5, StateRoot: common.HexToHash("0x2")}, nil).Once()
+	_, err = testState.RollbackToCheckpoint(ctx, "batch5", dbTx)
+	require.ErrorIs(t, err, state.ErrCheckpointStateRootMismatch)
+
+	mockStorage.EXPECT().GetCheckpoint(ctx, "batch5", dbTx).Return(checkpoint, nil).Once()
+	mockStorage.EXPECT().GetBatchByNumber(ctx, uint64(5), dbTx).Return(&state.Batch{BatchNumber: 5, StateRoot: checkpoint.StateRoot}, nil).Once()
+	mockStorage.EXPECT().ResetToBatchNumber(ctx, uint64(5), dbTx).Return(nil).Once()
+	rolledBackTo, err := testState.RollbackToCheckpoint(ctx, "batch5", dbTx)
+	require.NoError(t, err)
+	assert.Equal(t, checkpoint, rolledBackTo)
+}
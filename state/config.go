@@ -49,6 +49,25 @@ type Config struct {
 	// AvoidForkIDInMemory is a configuration that forces the ForkID information to be loaded
 	// from the DB every time it's needed
 	AvoidForkIDInMemory bool
+
+	// ExecutionCacheMaxBytesSize is the memory budget in bytes of the cache of the responses of the
+	// executor, so the batches already executed by the node are not sent again to the executor.
+	// 0 disables the cache
+	ExecutionCacheMaxBytesSize uint64 `mapstructure:"ExecutionCacheMaxBytesSize"`
+
+	// ColdStorage is the configuration of the secondary database where the receipts and logs
+	// of the old verified batches are archived, so the main database only keeps the recent ones
+	ColdStorage ColdStorageConfig `mapstructure:"ColdStorage"`
+}
+
+// ColdStorageConfig represents the configuration of the cold storage of the state
+type ColdStorageConfig struct {
+	// Enabled reads the receipts and logs archived in the cold storage when they are
+	// not found in the main database
+	Enabled bool `mapstructure:"Enabled"`
+
+	// DB is the configuration of the cold storage database
+	DB db.Config `mapstructure:"DB"`
 }
 
 // BatchConfig represents the configuration of the batch constraints
@@ -317,3 +317,38 @@ func convertToCounters(resp *executor.ProcessBatchResponse) ZKCounters {
 		Steps:            resp.CntSteps,
 	}
 }
+
+func convertToExecutorStateOverride(stateOverride StateOverride) map[string]*executor.OverrideAccount {
+	if len(stateOverride) == 0 {
+		return nil
+	}
+	results := make(map[string]*executor.OverrideAccount, len(stateOverride))
+	for address, account := range stateOverride {
+		overrideAccount := &executor.OverrideAccount{}
+		if account.Nonce != nil {
+			overrideAccount.Nonce = *account.Nonce
+		}
+		if account.Code != nil {
+			overrideAccount.Code = *account.Code
+		}
+		if account.Balance != nil {
+			overrideAccount.Balance = account.Balance.Bytes()
+		}
+		if account.State != nil {
+			overrideAccount.State = convertToExecutorStorage(*account.State)
+		}
+		if account.StateDiff != nil {
+			overrideAccount.StateDiff = convertToExecutorStorage(*account.StateDiff)
+		}
+		results[address.String()] = overrideAccount
+	}
+	return results
+}
+
+func convertToExecutorStorage(storage map[common.Hash]common.Hash) map[string]string {
+	results := make(map[string]string, len(storage))
+	for key, value := range storage {
+		results[key.String()] = value.String()
+	}
+	return results
+}
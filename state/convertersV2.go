@@ -1,10 +1,12 @@
 package state
 
 import (
+	"bytes"
 	"context"
 	"errors"
 	"fmt"
 	"math/big"
+	"sort"
 	"time"
 
 	"github.com/0xPolygonHermez/zkevm-node/encoding"
@@ -346,7 +348,15 @@ func convertToReadWriteAddressesV2(addresses map[string]*executor.InfoReadWriteV
 			}
 		}
 
-		results[address] = &InfoReadWrite{Address: address, Nonce: nonce, Balance: balance}
+		storageKeys := make([]common.Hash, 0, len(addrInfo.ScStorage))
+		for key := range addrInfo.ScStorage {
+			storageKeys = append(storageKeys, common.HexToHash(key))
+		}
+		sort.Slice(storageKeys, func(i, j int) bool {
+			return bytes.Compare(storageKeys[i].Bytes(), storageKeys[j].Bytes()) < 0
+		})
+
+		results[address] = &InfoReadWrite{Address: address, Nonce: nonce, Balance: balance, StorageKeys: storageKeys}
 	}
 
 	return results, nil
@@ -376,3 +386,30 @@ func convertProcessingContext(p *ProcessingContextV2) (*ProcessingContext, error
 	}
 	return &result, nil
 }
+
+func convertToExecutorStateOverrideV2(stateOverride StateOverride) map[string]*executor.OverrideAccountV2 {
+	if len(stateOverride) == 0 {
+		return nil
+	}
+	results := make(map[string]*executor.OverrideAccountV2, len(stateOverride))
+	for address, account := range stateOverride {
+		overrideAccount := &executor.OverrideAccountV2{}
+		if account.Nonce != nil {
+			overrideAccount.Nonce = *account.Nonce
+		}
+		if account.Code != nil {
+			overrideAccount.Code = *account.Code
+		}
+		if account.Balance != nil {
+			overrideAccount.Balance = account.Balance.Bytes()
+		}
+		if account.State != nil {
+			overrideAccount.State = convertToExecutorStorage(*account.State)
+		}
+		if account.StateDiff != nil {
+			overrideAccount.StateDiff = convertToExecutorStorage(*account.StateDiff)
+		}
+		results[address.String()] = overrideAccount
+	}
+	return results
+}
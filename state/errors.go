@@ -67,6 +67,9 @@ var (
 	// ErrMaxNativeBlockHashBlockRangeLimitExceeded returned when the range between block number range
 	// to filter native block hashes is bigger than the configured limit
 	ErrMaxNativeBlockHashBlockRangeLimitExceeded = errors.New("native block hashes are limited to a %v block range")
+	// ErrMaxTraceBlockRangeLimitExceeded returned when the range between block number range
+	// to trace is bigger than the configured limit
+	ErrMaxTraceBlockRangeLimitExceeded = errors.New("traces are limited to a %v block range")
 )
 
 // ConstructErrorFromRevert extracts the reverted reason from the provided returnValue
@@ -0,0 +1,128 @@
+package state
+
+import (
+	"container/list"
+	"context"
+	"crypto/sha256"
+	"sync"
+
+	"github.com/0xPolygonHermez/zkevm-node/state/metrics"
+	"github.com/0xPolygonHermez/zkevm-node/state/runtime/executor"
+	"google.golang.org/protobuf/proto"
+)
+
+type executionCacheEntry struct {
+	key      [sha256.Size]byte
+	response *executor.ProcessBatchResponseV2
+	// persisted is true if the executor stored the new state in the merkle tree
+	persisted bool
+	size      uint64
+}
+
+// executionCache is a thread-safe LRU cache of the responses of the executor, limited by the size
+// in bytes of the responses. The key of a response is the hash of the fields of the request that
+// determine the result of the execution: the old state root, the batch L2 data, the L1 info tree
+// data, the timestamp limit, the coinbase, the fork and so on
+type executionCache struct {
+	mutex   sync.Mutex
+	maxSize uint64
+	size    uint64
+	entries map[[sha256.Size]byte]*list.Element
+	lru     *list.List
+}
+
+func newExecutionCache(maxSize uint64) *executionCache {
+	return &executionCache{
+		maxSize: maxSize,
+		entries: make(map[[sha256.Size]byte]*list.Element),
+		lru:     list.New(),
+	}
+}
+
+// executionCacheKey returns the key of the request in the cache. The context id, which is unique
+// per request, and the update of the merkle tree, which doesn't change the result, are not part of it
+func executionCacheKey(request *executor.ProcessBatchRequestV2) ([sha256.Size]byte, error) {
+	keyRequest := proto.Clone(request).(*executor.ProcessBatchRequestV2)
+	keyRequest.ContextId = ""
+	keyRequest.UpdateMerkleTree = 0
+	encoded, err := proto.MarshalOptions{Deterministic: true}.Marshal(keyRequest)
+	if err != nil {
+		return [sha256.Size]byte{}, err
+	}
+	return sha256.Sum256(encoded), nil
+}
+
+// get returns a copy of the cached response. When the request needs to update the merkle tree only
+// the responses of executions that stored the new state are returned
+func (c *executionCache) get(key [sha256.Size]byte, updateMerkleTree bool) (*executor.ProcessBatchResponseV2, bool) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	element, found := c.entries[key]
+	if !found || (updateMerkleTree && !element.Value.(*executionCacheEntry).persisted) {
+		metrics.ExecutionCacheMiss()
+		return nil, false
+	}
+	c.lru.MoveToFront(element)
+	metrics.ExecutionCacheHit()
+	return proto.Clone(element.Value.(*executionCacheEntry).response).(*executor.ProcessBatchResponseV2), true
+}
+
+func (c *executionCache) add(key [sha256.Size]byte, response *executor.ProcessBatchResponseV2, persisted bool) {
+	size := uint64(proto.Size(response))
+	if size > c.maxSize {
+		return
+	}
+	entry := &executionCacheEntry{
+		key:       key,
+		response:  proto.Clone(response).(*executor.ProcessBatchResponseV2),
+		persisted: persisted,
+		size:      size,
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if element, found := c.entries[key]; found {
+		previous := element.Value.(*executionCacheEntry)
+		// a persisted execution is kept over one that wasn't persisted
+		entry.persisted = entry.persisted || previous.persisted
+		c.size -= previous.size
+		element.Value = entry
+		c.lru.MoveToFront(element)
+	} else {
+		c.entries[key] = c.lru.PushFront(entry)
+	}
+	c.size += entry.size
+
+	for c.size > c.maxSize {
+		oldest := c.lru.Back()
+		c.lru.Remove(oldest)
+		oldestEntry := oldest.Value.(*executionCacheEntry)
+		delete(c.entries, oldestEntry.key)
+		c.size -= oldestEntry.size
+	}
+}
+
+// executeBatchRequestV2 sends the request to the executor, unless the same batch was already executed
+// and its response is in the execution cache. Only the responses without executor error are cached
+func (s *State) executeBatchRequestV2(ctx context.Context, request *executor.ProcessBatchRequestV2) (*executor.ProcessBatchResponseV2, error) {
+	if s.executionCache == nil {
+		return s.executorClient.ProcessBatchV2(ctx, request)
+	}
+
+	updateMerkleTree := request.UpdateMerkleTree == cTrue
+	key, err := executionCacheKey(request)
+	if err != nil {
+		return nil, err
+	}
+	if response, found := s.executionCache.get(key, updateMerkleTree); found {
+		return response, nil
+	}
+
+	response, err := s.executorClient.ProcessBatchV2(ctx, request)
+	if err == nil && response != nil && response.Error == executor.ExecutorError_EXECUTOR_ERROR_NO_ERROR {
+		s.executionCache.add(key, response, updateMerkleTree)
+	}
+	return response, err
+}
@@ -0,0 +1,61 @@
+package state_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/0xPolygonHermez/zkevm-node/state"
+	"github.com/0xPolygonHermez/zkevm-node/state/mocks"
+	"github.com/0xPolygonHermez/zkevm-node/state/runtime/executor"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExecuteBatchV2Cache(t *testing.T) {
+	ctx := context.Background()
+	mockStorage := mocks.NewStorageMock(t)
+	mockExecutor := mocks.NewExecutorServiceClientMock(t)
+	cfg := state.Config{
+		ExecutionCacheMaxBytesSize: 1024 * 1024,
+		ForkIDIntervals:            []state.ForkIDInterval{{FromBatchNumber: 0, ToBatchNumber: 100, ForkId: state.FORKID_ETROG}},
+	}
+	testState := state.NewState(cfg, mockStorage, mockExecutor, nil, nil, nil, nil)
+	dbTx := mocks.NewDbTxMock(t)
+
+	previousBatch := &state.Batch{BatchNumber: 1, StateRoot: common.HexToHash("0x1"), AccInputHash: common.HexToHash("0x2")}
+	mockStorage.EXPECT().GetBatchByNumber(ctx, uint64(1), dbTx).Return(previousBatch, nil)
+	mockStorage.EXPECT().GetForkIDByBatchNumber(mock.Anything).Return(state.FORKID_ETROG).Maybe()
+
+	batch := state.Batch{BatchNumber: 2, BatchL2Data: []byte{0x0b}}
+	response := &executor.ProcessBatchResponseV2{NewStateRoot: common.HexToHash("0x3").Bytes(), Error: executor.ExecutorError_EXECUTOR_ERROR_NO_ERROR}
+	isPersisted := func(persisted bool) interface{} {
+		return mock.MatchedBy(func(r *executor.ProcessBatchRequestV2) bool { return (r.UpdateMerkleTree == 1) == persisted })
+	}
+	timestampLimit := time.Unix(1000, 0)
+
+	// the second execution without updating the merkle tree is served by the cache
+	mockExecutor.EXPECT().ProcessBatchV2(ctx, isPersisted(false)).Return(response, nil).Once()
+	for i := 0; i < 2; i++ {
+		res, err := testState.ExecuteBatchV2(ctx, batch, common.Hash{}, nil, timestampLimit, false, 0, nil, dbTx)
+		require.NoError(t, err)
+		assert.Equal(t, response.NewStateRoot, res.NewStateRoot)
+	}
+
+	// the execution wasn't persisted, so it's sent to the executor when the merkle tree must be updated
+	mockExecutor.EXPECT().ProcessBatchV2(ctx, isPersisted(true)).Return(response, nil).Once()
+	for i := 0; i < 2; i++ {
+		_, err := testState.ExecuteBatchV2(ctx, batch, common.Hash{}, nil, timestampLimit, true, 0, nil, dbTx)
+		require.NoError(t, err)
+	}
+	_, err := testState.ExecuteBatchV2(ctx, batch, common.Hash{}, nil, timestampLimit, false, 0, nil, dbTx)
+	require.NoError(t, err)
+
+	// another batch data is another execution
+	mockExecutor.EXPECT().ProcessBatchV2(ctx, isPersisted(false)).Return(response, nil).Once()
+	batch.BatchL2Data = []byte{0x0c}
+	_, err = testState.ExecuteBatchV2(ctx, batch, common.Hash{}, nil, timestampLimit, false, 0, nil, dbTx)
+	require.NoError(t, err)
+}
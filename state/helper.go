@@ -1,6 +1,7 @@
 package state
 
 import (
+	"errors"
 	"fmt"
 	"math/big"
 	"sort"
@@ -8,6 +9,7 @@ import (
 
 	"github.com/0xPolygonHermez/zkevm-node/hex"
 	"github.com/0xPolygonHermez/zkevm-node/log"
+	"github.com/0xPolygonHermez/zkevm-node/state/runtime"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/rlp"
@@ -326,6 +328,17 @@ func GenerateReceipt(blockNumber *big.Int, processedTx *ProcessTransactionRespon
 	return receipt
 }
 
+// GetRevertData returns the data returned by the processed transaction when it
+// was reverted, the data of the successful or failed transactions is not kept
+func GetRevertData(processedTx *ProcessTransactionResponse) []byte {
+	if !errors.Is(processedTx.RomError, runtime.ErrExecutionReverted) || len(processedTx.ReturnValue) == 0 {
+		return nil
+	}
+	revertData := make([]byte, len(processedTx.ReturnValue))
+	copy(revertData, processedTx.ReturnValue)
+	return revertData
+}
+
 // IsPreEIP155Tx checks if the tx is a tx that has a chainID as zero and
 // V field is either 27 or 28
 func IsPreEIP155Tx(tx types.Transaction) bool {
@@ -19,6 +19,7 @@ type storage interface {
 	ResetToL1BlockNumber(ctx context.Context, blockNumber uint64, dbTx pgx.Tx) error
 	ResetForkID(ctx context.Context, batchNumber uint64, dbTx pgx.Tx) error
 	ResetTrustedState(ctx context.Context, batchNumber uint64, dbTx pgx.Tx) error
+	ResetToBatchNumber(ctx context.Context, batchNumber uint64, dbTx pgx.Tx) error
 	AddBlock(ctx context.Context, block *Block, dbTx pgx.Tx) error
 	GetTxsOlderThanNL1Blocks(ctx context.Context, nL1Blocks uint64, dbTx pgx.Tx) ([]common.Hash, error)
 	GetTxsOlderThanNL1BlocksUntilTxHash(ctx context.Context, nL1Blocks uint64, earliestTxHash common.Hash, dbTx pgx.Tx) ([]common.Hash, error)
@@ -67,12 +68,14 @@ type storage interface {
 	GetTransactionByHash(ctx context.Context, transactionHash common.Hash, dbTx pgx.Tx) (*types.Transaction, error)
 	GetTransactionByL2Hash(ctx context.Context, transactionHash common.Hash, dbTx pgx.Tx) (*types.Transaction, error)
 	GetTransactionReceipt(ctx context.Context, transactionHash common.Hash, dbTx pgx.Tx) (*types.Receipt, error)
+	GetTransactionRevertData(ctx context.Context, transactionHash common.Hash, dbTx pgx.Tx) ([]byte, error)
+	GetL2BlockReceipts(ctx context.Context, blockNumber uint64, dbTx pgx.Tx) ([]*types.Receipt, error)
 	GetTransactionByL2BlockHashAndIndex(ctx context.Context, blockHash common.Hash, index uint64, dbTx pgx.Tx) (*types.Transaction, error)
 	GetTransactionByL2BlockNumberAndIndex(ctx context.Context, blockNumber uint64, index uint64, dbTx pgx.Tx) (*types.Transaction, error)
 	GetL2BlockTransactionCountByHash(ctx context.Context, blockHash common.Hash, dbTx pgx.Tx) (uint64, error)
 	GetL2BlockTransactionCountByNumber(ctx context.Context, blockNumber uint64, dbTx pgx.Tx) (uint64, error)
 	GetTransactionEGPLogByHash(ctx context.Context, transactionHash common.Hash, dbTx pgx.Tx) (*EffectiveGasPriceLog, error)
-	AddL2Block(ctx context.Context, batchNumber uint64, l2Block *L2Block, receipts []*types.Receipt, txsL2Hash []common.Hash, txsEGPData []StoreTxEGPData, imStateRoots []common.Hash, dbTx pgx.Tx) error
+	AddL2Block(ctx context.Context, batchNumber uint64, l2Block *L2Block, receipts []*types.Receipt, txsL2Hash []common.Hash, txsEGPData []StoreTxEGPData, imStateRoots []common.Hash, txsRevertData [][]byte, dbTx pgx.Tx) error
 	GetLastVirtualizedL2BlockNumber(ctx context.Context, dbTx pgx.Tx) (uint64, error)
 	GetLastConsolidatedL2BlockNumber(ctx context.Context, dbTx pgx.Tx) (uint64, error)
 	GetLastVerifiedL2BlockNumberUntilL1Block(ctx context.Context, l1FinalizedBlockNumber uint64, dbTx pgx.Tx) (uint64, error)
@@ -162,9 +165,31 @@ type storage interface {
 	GetAllL1InfoTreeRecursiveRootEntries(ctx context.Context, dbTx pgx.Tx) ([]L1InfoTreeRecursiveExitRootStorageEntry, error)
 	GetLatestL1InfoTreeRecursiveRoot(ctx context.Context, maxBlockNumber uint64, dbTx pgx.Tx) (L1InfoTreeRecursiveExitRootStorageEntry, error)
 	storeblobsequences
+	storecheckpoints
+	storeprune
+	storesyncprogress
 }
 
 type storeblobsequences interface {
 	AddBlobSequence(ctx context.Context, blobSequence *BlobSequence, dbTx pgx.Tx) error
 	GetLastBlobSequence(ctx context.Context, dbTx pgx.Tx) (*BlobSequence, error)
 }
+
+type storecheckpoints interface {
+	AddCheckpoint(ctx context.Context, checkpoint Checkpoint, dbTx pgx.Tx) error
+	GetCheckpoint(ctx context.Context, name string, dbTx pgx.Tx) (*Checkpoint, error)
+	GetCheckpoints(ctx context.Context, dbTx pgx.Tx) ([]Checkpoint, error)
+	DeleteCheckpoint(ctx context.Context, name string, dbTx pgx.Tx) error
+}
+
+type storesyncprogress interface {
+	AddSyncProgress(ctx context.Context, progress SyncProgress, dbTx pgx.Tx) error
+	GetLastSyncProgress(ctx context.Context, dbTx pgx.Tx) (*SyncProgress, error)
+	GetLastSyncProgresses(ctx context.Context, limit uint64, dbTx pgx.Tx) ([]SyncProgress, error)
+	GetSyncProgressesFromBlockNumber(ctx context.Context, blockNumber uint64, dbTx pgx.Tx) ([]SyncProgress, error)
+	GetSyncProgressEvents(ctx context.Context, blockNumber uint64, dbTx pgx.Tx) ([]SyncProgressEvent, error)
+}
+
+type storeprune interface {
+	DeleteReceiptsAndLogsUpToBatchNumber(ctx context.Context, batchNumber uint64, dbTx pgx.Tx) (uint64, error)
+}
@@ -205,10 +205,13 @@ type NewL2BlockEvent struct {
 // monitor new blocks and execute handlers registered to be executed
 // when a new l2 block is detected. This is used by the RPC WebSocket
 // filter subscription but can be used by any other component that
-// needs to react to a new L2 block added to the state.
+// needs to react to a new L2 block added to the state. If it's called
+// more than once only the first call starts the monitoring.
 func (s *State) StartToMonitorNewL2Blocks() {
-	go InfiniteSafeRun(s.monitorNewL2Blocks, "fail to monitor new l2 blocks: %v:", time.Second)
-	go InfiniteSafeRun(s.handleEvents, "fail to handle events: %v", time.Second)
+	s.monitorNewL2BlocksOnce.Do(func() {
+		go InfiniteSafeRun(s.monitorNewL2Blocks, "fail to monitor new l2 blocks: %v:", time.Second)
+		go InfiniteSafeRun(s.handleEvents, "fail to handle events: %v", time.Second)
+	})
 }
 
 // RegisterNewL2BlockEventHandler add the provided handler to the list of handlers
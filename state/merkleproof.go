@@ -0,0 +1,53 @@
+package state
+
+import (
+	"context"
+	"math/big"
+
+	"github.com/0xPolygonHermez/zkevm-node/merkletree"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// AccountProof contains the merkle proofs of the leaves of an account and of some of its storage
+// positions in the state tree with a given root
+type AccountProof struct {
+	Address          common.Address
+	BalanceProof     *merkletree.MerkleProof
+	NonceProof       *merkletree.MerkleProof
+	CodeHashProof    *merkletree.MerkleProof
+	CodeLengthProof  *merkletree.MerkleProof
+	StoragePositions []*big.Int
+	StorageProofs    []*merkletree.MerkleProof
+}
+
+// GetAccountProof returns the proofs of the balance, nonce, code hash, code length and of the provided
+// storage positions of the address in the state tree with the provided root
+func (s *State) GetAccountProof(ctx context.Context, address common.Address, storagePositions []*big.Int, root common.Hash) (*AccountProof, error) {
+	if s.tree == nil {
+		return nil, ErrStateTreeNil
+	}
+
+	var err error
+	proof := &AccountProof{Address: address, StoragePositions: storagePositions}
+	if proof.BalanceProof, err = s.tree.GetBalanceProof(ctx, address, root.Bytes()); err != nil {
+		return nil, err
+	}
+	if proof.NonceProof, err = s.tree.GetNonceProof(ctx, address, root.Bytes()); err != nil {
+		return nil, err
+	}
+	if proof.CodeHashProof, err = s.tree.GetCodeHashProof(ctx, address, root.Bytes()); err != nil {
+		return nil, err
+	}
+	if proof.CodeLengthProof, err = s.tree.GetCodeLengthProof(ctx, address, root.Bytes()); err != nil {
+		return nil, err
+	}
+	proof.StorageProofs = make([]*merkletree.MerkleProof, 0, len(storagePositions))
+	for _, position := range storagePositions {
+		storageProof, err := s.tree.GetStorageProof(ctx, address, position, root.Bytes())
+		if err != nil {
+			return nil, err
+		}
+		proof.StorageProofs = append(proof.StorageProofs, storageProof)
+	}
+	return proof, nil
+}
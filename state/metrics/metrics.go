@@ -15,6 +15,10 @@ const (
 	Prefix = "state_"
 	// ExecutorProcessingTimeName is the name of the metric that shows the processing time in the executor.
 	ExecutorProcessingTimeName = Prefix + "executor_processing_time"
+	// ExecutionCacheHitsName is the name of the metric that counts the batch executions served by the execution cache.
+	ExecutionCacheHitsName = Prefix + "execution_cache_hits"
+	// ExecutionCacheMissesName is the name of the metric that counts the batch executions not found in the execution cache.
+	ExecutionCacheMissesName = Prefix + "execution_cache_misses"
 	// CallerLabelName is the name of the label for the caller.
 	CallerLabelName = "caller"
 
@@ -38,7 +42,19 @@ func Register() {
 		},
 	}
 
+	counters := []prometheus.CounterOpts{
+		{
+			Name: ExecutionCacheHitsName,
+			Help: "[STATE] number of batch executions served by the execution cache",
+		},
+		{
+			Name: ExecutionCacheMissesName,
+			Help: "[STATE] number of batch executions not found in the execution cache",
+		},
+	}
+
 	metrics.RegisterHistogramVecs(histogramVecs...)
+	metrics.RegisterCounters(counters...)
 }
 
 // ExecutorProcessingTime observes the last processing time of the executor in the histogram vector by the provided elapsed time
@@ -47,3 +63,13 @@ func ExecutorProcessingTime(caller string, lastExecutionTime time.Duration) {
 	execTimeInSeconds := float64(lastExecutionTime) / float64(time.Second)
 	metrics.HistogramVecObserve(ExecutorProcessingTimeName, caller, execTimeInSeconds)
 }
+
+// ExecutionCacheHit increases the counter of batch executions served by the execution cache.
+func ExecutionCacheHit() {
+	metrics.CounterInc(ExecutionCacheHitsName)
+}
+
+// ExecutionCacheMiss increases the counter of batch executions not found in the execution cache.
+func ExecutionCacheMiss() {
+	metrics.CounterInc(ExecutionCacheMissesName)
+}
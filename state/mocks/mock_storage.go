@@ -226,6 +226,54 @@ func (_c *StorageMock_AddBlock_Call) RunAndReturn(run func(context.Context, *sta
 	return _c
 }
 
+// AddCheckpoint provides a mock function with given fields: ctx, checkpoint, dbTx
+func (_m *StorageMock) AddCheckpoint(ctx context.Context, checkpoint state.Checkpoint, dbTx pgx.Tx) error {
+	ret := _m.Called(ctx, checkpoint, dbTx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AddCheckpoint")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, state.Checkpoint, pgx.Tx) error); ok {
+		r0 = rf(ctx, checkpoint, dbTx)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// StorageMock_AddCheckpoint_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AddCheckpoint'
+type StorageMock_AddCheckpoint_Call struct {
+	*mock.Call
+}
+
+// AddCheckpoint is a helper method to define mock.On call
+//   - ctx context.Context
+//   - checkpoint state.Checkpoint
+//   - dbTx pgx.Tx
+func (_e *StorageMock_Expecter) AddCheckpoint(ctx interface{}, checkpoint interface{}, dbTx interface{}) *StorageMock_AddCheckpoint_Call {
+	return &StorageMock_AddCheckpoint_Call{Call: _e.mock.On("AddCheckpoint", ctx, checkpoint, dbTx)}
+}
+
+func (_c *StorageMock_AddCheckpoint_Call) Run(run func(ctx context.Context, checkpoint state.Checkpoint, dbTx pgx.Tx)) *StorageMock_AddCheckpoint_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(state.Checkpoint), args[2].(pgx.Tx))
+	})
+	return _c
+}
+
+func (_c *StorageMock_AddCheckpoint_Call) Return(_a0 error) *StorageMock_AddCheckpoint_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *StorageMock_AddCheckpoint_Call) RunAndReturn(run func(context.Context, state.Checkpoint, pgx.Tx) error) *StorageMock_AddCheckpoint_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // AddForcedBatch provides a mock function with given fields: ctx, forcedBatch, tx
 func (_m *StorageMock) AddForcedBatch(ctx context.Context, forcedBatch *state.ForcedBatch, tx pgx.Tx) error {
 	ret := _m.Called(ctx, forcedBatch, tx)
@@ -514,17 +562,17 @@ func (_c *StorageMock_AddL1InfoTreeRecursiveRootToExitRoot_Call) RunAndReturn(ru
 	return _c
 }
 
-// AddL2Block provides a mock function with given fields: ctx, batchNumber, l2Block, receipts, txsL2Hash, txsEGPData, imStateRoots, dbTx
-func (_m *StorageMock) AddL2Block(ctx context.Context, batchNumber uint64, l2Block *state.L2Block, receipts []*types.Receipt, txsL2Hash []common.Hash, txsEGPData []state.StoreTxEGPData, imStateRoots []common.Hash, dbTx pgx.Tx) error {
-	ret := _m.Called(ctx, batchNumber, l2Block, receipts, txsL2Hash, txsEGPData, imStateRoots, dbTx)
+// AddL2Block provides a mock function with given fields: ctx, batchNumber, l2Block, receipts, txsL2Hash, txsEGPData, imStateRoots, txsRevertData, dbTx
+func (_m *StorageMock) AddL2Block(ctx context.Context, batchNumber uint64, l2Block *state.L2Block, receipts []*types.Receipt, txsL2Hash []common.Hash, txsEGPData []state.StoreTxEGPData, imStateRoots []common.Hash, txsRevertData [][]byte, dbTx pgx.Tx) error {
+	ret := _m.Called(ctx, batchNumber, l2Block, receipts, txsL2Hash, txsEGPData, imStateRoots, txsRevertData, dbTx)
 
 	if len(ret) == 0 {
 		panic("no return value specified for AddL2Block")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, uint64, *state.L2Block, []*types.Receipt, []common.Hash, []state.StoreTxEGPData, []common.Hash, pgx.Tx) error); ok {
-		r0 = rf(ctx, batchNumber, l2Block, receipts, txsL2Hash, txsEGPData, imStateRoots, dbTx)
+	if rf, ok := ret.Get(0).(func(context.Context, uint64, *state.L2Block, []*types.Receipt, []common.Hash, []state.StoreTxEGPData, []common.Hash, [][]byte, pgx.Tx) error); ok {
+		r0 = rf(ctx, batchNumber, l2Block, receipts, txsL2Hash, txsEGPData, imStateRoots, txsRevertData, dbTx)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -545,14 +593,15 @@ type StorageMock_AddL2Block_Call struct {
 //   - txsL2Hash []common.Hash
 //   - txsEGPData []state.StoreTxEGPData
 //   - imStateRoots []common.Hash
+//   - txsRevertData [][]byte
 //   - dbTx pgx.Tx
-func (_e *StorageMock_Expecter) AddL2Block(ctx interface{}, batchNumber interface{}, l2Block interface{}, receipts interface{}, txsL2Hash interface{}, txsEGPData interface{}, imStateRoots interface{}, dbTx interface{}) *StorageMock_AddL2Block_Call {
-	return &StorageMock_AddL2Block_Call{Call: _e.mock.On("AddL2Block", ctx, batchNumber, l2Block, receipts, txsL2Hash, txsEGPData, imStateRoots, dbTx)}
+func (_e *StorageMock_Expecter) AddL2Block(ctx interface{}, batchNumber interface{}, l2Block interface{}, receipts interface{}, txsL2Hash interface{}, txsEGPData interface{}, imStateRoots interface{}, txsRevertData interface{}, dbTx interface{}) *StorageMock_AddL2Block_Call {
+	return &StorageMock_AddL2Block_Call{Call: _e.mock.On("AddL2Block", ctx, batchNumber, l2Block, receipts, txsL2Hash, txsEGPData, imStateRoots, txsRevertData, dbTx)}
 }
 
-func (_c *StorageMock_AddL2Block_Call) Run(run func(ctx context.Context, batchNumber uint64, l2Block *state.L2Block, receipts []*types.Receipt, txsL2Hash []common.Hash, txsEGPData []state.StoreTxEGPData, imStateRoots []common.Hash, dbTx pgx.Tx)) *StorageMock_AddL2Block_Call {
+func (_c *StorageMock_AddL2Block_Call) Run(run func(ctx context.Context, batchNumber uint64, l2Block *state.L2Block, receipts []*types.Receipt, txsL2Hash []common.Hash, txsEGPData []state.StoreTxEGPData, imStateRoots []common.Hash, txsRevertData [][]byte, dbTx pgx.Tx)) *StorageMock_AddL2Block_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(uint64), args[2].(*state.L2Block), args[3].([]*types.Receipt), args[4].([]common.Hash), args[5].([]state.StoreTxEGPData), args[6].([]common.Hash), args[7].(pgx.Tx))
+		run(args[0].(context.Context), args[1].(uint64), args[2].(*state.L2Block), args[3].([]*types.Receipt), args[4].([]common.Hash), args[5].([]state.StoreTxEGPData), args[6].([]common.Hash), args[7].([][]byte), args[8].(pgx.Tx))
 	})
 	return _c
 }
@@ -562,7 +611,7 @@ func (_c *StorageMock_AddL2Block_Call) Return(_a0 error) *StorageMock_AddL2Block
 	return _c
 }
 
-func (_c *StorageMock_AddL2Block_Call) RunAndReturn(run func(context.Context, uint64, *state.L2Block, []*types.Receipt, []common.Hash, []state.StoreTxEGPData, []common.Hash, pgx.Tx) error) *StorageMock_AddL2Block_Call {
+func (_c *StorageMock_AddL2Block_Call) RunAndReturn(run func(context.Context, uint64, *state.L2Block, []*types.Receipt, []common.Hash, []state.StoreTxEGPData, []common.Hash, [][]byte, pgx.Tx) error) *StorageMock_AddL2Block_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -712,6 +761,54 @@ func (_c *StorageMock_AddSequence_Call) RunAndReturn(run func(context.Context, s
 	return _c
 }
 
+// AddSyncProgress provides a mock function with given fields: ctx, progress, dbTx
+func (_m *StorageMock) AddSyncProgress(ctx context.Context, progress state.SyncProgress, dbTx pgx.Tx) error {
+	ret := _m.Called(ctx, progress, dbTx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AddSyncProgress")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, state.SyncProgress, pgx.Tx) error); ok {
+		r0 = rf(ctx, progress, dbTx)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// StorageMock_AddSyncProgress_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AddSyncProgress'
+type StorageMock_AddSyncProgress_Call struct {
+	*mock.Call
+}
+
+// AddSyncProgress is a helper method to define mock.On call
+//   - ctx context.Context
+//   - progress state.SyncProgress
+//   - dbTx pgx.Tx
+func (_e *StorageMock_Expecter) AddSyncProgress(ctx interface{}, progress interface{}, dbTx interface{}) *StorageMock_AddSyncProgress_Call {
+	return &StorageMock_AddSyncProgress_Call{Call: _e.mock.On("AddSyncProgress", ctx, progress, dbTx)}
+}
+
+func (_c *StorageMock_AddSyncProgress_Call) Run(run func(ctx context.Context, progress state.SyncProgress, dbTx pgx.Tx)) *StorageMock_AddSyncProgress_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(state.SyncProgress), args[2].(pgx.Tx))
+	})
+	return _c
+}
+
+func (_c *StorageMock_AddSyncProgress_Call) Return(_a0 error) *StorageMock_AddSyncProgress_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *StorageMock_AddSyncProgress_Call) RunAndReturn(run func(context.Context, state.SyncProgress, pgx.Tx) error) *StorageMock_AddSyncProgress_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // AddTrustedReorg provides a mock function with given fields: ctx, reorg, dbTx
 func (_m *StorageMock) AddTrustedReorg(ctx context.Context, reorg *state.TrustedReorg, dbTx pgx.Tx) error {
 	ret := _m.Called(ctx, reorg, dbTx)
@@ -1338,6 +1435,112 @@ func (_c *StorageMock_DeleteBatchProofs_Call) RunAndReturn(run func(context.Cont
 	return _c
 }
 
+// DeleteCheckpoint provides a mock function with given fields: ctx, name, dbTx
+func (_m *StorageMock) DeleteCheckpoint(ctx context.Context, name string, dbTx pgx.Tx) error {
+	ret := _m.Called(ctx, name, dbTx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteCheckpoint")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, pgx.Tx) error); ok {
+		r0 = rf(ctx, name, dbTx)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// StorageMock_DeleteCheckpoint_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteCheckpoint'
+type StorageMock_DeleteCheckpoint_Call struct {
+	*mock.Call
+}
+
+// DeleteCheckpoint is a helper method to define mock.On call
+//   - ctx context.Context
+//   - name string
+//   - dbTx pgx.Tx
+func (_e *StorageMock_Expecter) DeleteCheckpoint(ctx interface{}, name interface{}, dbTx interface{}) *StorageMock_DeleteCheckpoint_Call {
+	return &StorageMock_DeleteCheckpoint_Call{Call: _e.mock.On("DeleteCheckpoint", ctx, name, dbTx)}
+}
+
+func (_c *StorageMock_DeleteCheckpoint_Call) Run(run func(ctx context.Context, name string, dbTx pgx.Tx)) *StorageMock_DeleteCheckpoint_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(pgx.Tx))
+	})
+	return _c
+}
+
+func (_c *StorageMock_DeleteCheckpoint_Call) Return(_a0 error) *StorageMock_DeleteCheckpoint_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *StorageMock_DeleteCheckpoint_Call) RunAndReturn(run func(context.Context, string, pgx.Tx) error) *StorageMock_DeleteCheckpoint_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteReceiptsAndLogsUpToBatchNumber provides a mock function with given fields: ctx, batchNumber, dbTx
+func (_m *StorageMock) DeleteReceiptsAndLogsUpToBatchNumber(ctx context.Context, batchNumber uint64, dbTx pgx.Tx) (uint64, error) {
+	ret := _m.Called(ctx, batchNumber, dbTx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteReceiptsAndLogsUpToBatchNumber")
+	}
+
+	var r0 uint64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint64, pgx.Tx) (uint64, error)); ok {
+		return rf(ctx, batchNumber, dbTx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uint64, pgx.Tx) uint64); ok {
+		r0 = rf(ctx, batchNumber, dbTx)
+	} else {
+		r0 = ret.Get(0).(uint64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uint64, pgx.Tx) error); ok {
+		r1 = rf(ctx, batchNumber, dbTx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// StorageMock_DeleteReceiptsAndLogsUpToBatchNumber_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteReceiptsAndLogsUpToBatchNumber'
+type StorageMock_DeleteReceiptsAndLogsUpToBatchNumber_Call struct {
+	*mock.Call
+}
+
+// DeleteReceiptsAndLogsUpToBatchNumber is a helper method to define mock.On call
+//   - ctx context.Context
+//   - batchNumber uint64
+//   - dbTx pgx.Tx
+func (_e *StorageMock_Expecter) DeleteReceiptsAndLogsUpToBatchNumber(ctx interface{}, batchNumber interface{}, dbTx interface{}) *StorageMock_DeleteReceiptsAndLogsUpToBatchNumber_Call {
+	return &StorageMock_DeleteReceiptsAndLogsUpToBatchNumber_Call{Call: _e.mock.On("DeleteReceiptsAndLogsUpToBatchNumber", ctx, batchNumber, dbTx)}
+}
+
+func (_c *StorageMock_DeleteReceiptsAndLogsUpToBatchNumber_Call) Run(run func(ctx context.Context, batchNumber uint64, dbTx pgx.Tx)) *StorageMock_DeleteReceiptsAndLogsUpToBatchNumber_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uint64), args[2].(pgx.Tx))
+	})
+	return _c
+}
+
+func (_c *StorageMock_DeleteReceiptsAndLogsUpToBatchNumber_Call) Return(_a0 uint64, _a1 error) *StorageMock_DeleteReceiptsAndLogsUpToBatchNumber_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *StorageMock_DeleteReceiptsAndLogsUpToBatchNumber_Call) RunAndReturn(run func(context.Context, uint64, pgx.Tx) (uint64, error)) *StorageMock_DeleteReceiptsAndLogsUpToBatchNumber_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // DeleteUngeneratedBatchProofs provides a mock function with given fields: ctx, dbTx
 func (_m *StorageMock) DeleteUngeneratedBatchProofs(ctx context.Context, dbTx pgx.Tx) error {
 	ret := _m.Called(ctx, dbTx)
@@ -2124,6 +2327,125 @@ func (_c *StorageMock_GetBlockNumVirtualBatchByBatchNum_Call) RunAndReturn(run f
 	return _c
 }
 
+// GetCheckpoint provides a mock function with given fields: ctx, name, dbTx
+func (_m *StorageMock) GetCheckpoint(ctx context.Context, name string, dbTx pgx.Tx) (*state.Checkpoint, error) {
+	ret := _m.Called(ctx, name, dbTx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetCheckpoint")
+	}
+
+	var r0 *state.Checkpoint
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, pgx.Tx) (*state.Checkpoint, error)); ok {
+		return rf(ctx, name, dbTx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, pgx.Tx) *state.Checkpoint); ok {
+		r0 = rf(ctx, name, dbTx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*state.Checkpoint)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, pgx.Tx) error); ok {
+		r1 = rf(ctx, name, dbTx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// StorageMock_GetCheckpoint_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetCheckpoint'
+type StorageMock_GetCheckpoint_Call struct {
+	*mock.Call
+}
+
+// GetCheckpoint is a helper method to define mock.On call
+//   - ctx context.Context
+//   - name string
+//   - dbTx pgx.Tx
+func (_e *StorageMock_Expecter) GetCheckpoint(ctx interface{}, name interface{}, dbTx interface{}) *StorageMock_GetCheckpoint_Call {
+	return &StorageMock_GetCheckpoint_Call{Call: _e.mock.On("GetCheckpoint", ctx, name, dbTx)}
+}
+
+func (_c *StorageMock_GetCheckpoint_Call) Run(run func(ctx context.Context, name string, dbTx pgx.Tx)) *StorageMock_GetCheckpoint_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(pgx.Tx))
+	})
+	return _c
+}
+
+func (_c *StorageMock_GetCheckpoint_Call) Return(_a0 *state.Checkpoint, _a1 error) *StorageMock_GetCheckpoint_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *StorageMock_GetCheckpoint_Call) RunAndReturn(run func(context.Context, string, pgx.Tx) (*state.Checkpoint, error)) *StorageMock_GetCheckpoint_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetCheckpoints provides a mock function with given fields: ctx, dbTx
+func (_m *StorageMock) GetCheckpoints(ctx context.Context, dbTx pgx.Tx) ([]state.Checkpoint, error) {
+	ret := _m.Called(ctx, dbTx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetCheckpoints")
+	}
+
+	var r0 []state.Checkpoint
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, pgx.Tx) ([]state.Checkpoint, error)); ok {
+		return rf(ctx, dbTx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, pgx.Tx) []state.Checkpoint); ok {
+		r0 = rf(ctx, dbTx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]state.Checkpoint)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, pgx.Tx) error); ok {
+		r1 = rf(ctx, dbTx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// StorageMock_GetCheckpoints_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetCheckpoints'
+type StorageMock_GetCheckpoints_Call struct {
+	*mock.Call
+}
+
+// GetCheckpoints is a helper method to define mock.On call
+//   - ctx context.Context
+//   - dbTx pgx.Tx
+func (_e *StorageMock_Expecter) GetCheckpoints(ctx interface{}, dbTx interface{}) *StorageMock_GetCheckpoints_Call {
+	return &StorageMock_GetCheckpoints_Call{Call: _e.mock.On("GetCheckpoints", ctx, dbTx)}
+}
+
+func (_c *StorageMock_GetCheckpoints_Call) Run(run func(ctx context.Context, dbTx pgx.Tx)) *StorageMock_GetCheckpoints_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(pgx.Tx))
+	})
+	return _c
+}
+
+func (_c *StorageMock_GetCheckpoints_Call) Return(_a0 []state.Checkpoint, _a1 error) *StorageMock_GetCheckpoints_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *StorageMock_GetCheckpoints_Call) RunAndReturn(run func(context.Context, pgx.Tx) ([]state.Checkpoint, error)) *StorageMock_GetCheckpoints_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // GetDSBatches provides a mock function with given fields: ctx, firstBatchNumber, lastBatchNumber, readWIPBatch, dbTx
 func (_m *StorageMock) GetDSBatches(ctx context.Context, firstBatchNumber uint64, lastBatchNumber uint64, readWIPBatch bool, dbTx pgx.Tx) ([]*state.DSBatch, error) {
 	ret := _m.Called(ctx, firstBatchNumber, lastBatchNumber, readWIPBatch, dbTx)
@@ -3410,6 +3732,66 @@ func (_c *StorageMock_GetL2BlockHeaderByNumber_Call) RunAndReturn(run func(conte
 	return _c
 }
 
+// GetL2BlockReceipts provides a mock function with given fields: ctx, blockNumber, dbTx
+func (_m *StorageMock) GetL2BlockReceipts(ctx context.Context, blockNumber uint64, dbTx pgx.Tx) ([]*types.Receipt, error) {
+	ret := _m.Called(ctx, blockNumber, dbTx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetL2BlockReceipts")
+	}
+
+	var r0 []*types.Receipt
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint64, pgx.Tx) ([]*types.Receipt, error)); ok {
+		return rf(ctx, blockNumber, dbTx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uint64, pgx.Tx) []*types.Receipt); ok {
+		r0 = rf(ctx, blockNumber, dbTx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*types.Receipt)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uint64, pgx.Tx) error); ok {
+		r1 = rf(ctx, blockNumber, dbTx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// StorageMock_GetL2BlockReceipts_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetL2BlockReceipts'
+type StorageMock_GetL2BlockReceipts_Call struct {
+	*mock.Call
+}
+
+// GetL2BlockReceipts is a helper method to define mock.On call
+//   - ctx context.Context
+//   - blockNumber uint64
+//   - dbTx pgx.Tx
+func (_e *StorageMock_Expecter) GetL2BlockReceipts(ctx interface{}, blockNumber interface{}, dbTx interface{}) *StorageMock_GetL2BlockReceipts_Call {
+	return &StorageMock_GetL2BlockReceipts_Call{Call: _e.mock.On("GetL2BlockReceipts", ctx, blockNumber, dbTx)}
+}
+
+func (_c *StorageMock_GetL2BlockReceipts_Call) Run(run func(ctx context.Context, blockNumber uint64, dbTx pgx.Tx)) *StorageMock_GetL2BlockReceipts_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uint64), args[2].(pgx.Tx))
+	})
+	return _c
+}
+
+func (_c *StorageMock_GetL2BlockReceipts_Call) Return(_a0 []*types.Receipt, _a1 error) *StorageMock_GetL2BlockReceipts_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *StorageMock_GetL2BlockReceipts_Call) RunAndReturn(run func(context.Context, uint64, pgx.Tx) ([]*types.Receipt, error)) *StorageMock_GetL2BlockReceipts_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // GetL2BlockTransactionCountByHash provides a mock function with given fields: ctx, blockHash, dbTx
 func (_m *StorageMock) GetL2BlockTransactionCountByHash(ctx context.Context, blockHash common.Hash, dbTx pgx.Tx) (uint64, error) {
 	ret := _m.Called(ctx, blockHash, dbTx)
@@ -4475,6 +4857,125 @@ func (_c *StorageMock_GetLastNBatchesByL2BlockNumber_Call) RunAndReturn(run func
 	return _c
 }
 
+// GetLastSyncProgress provides a mock function with given fields: ctx, dbTx
+func (_m *StorageMock) GetLastSyncProgress(ctx context.Context, dbTx pgx.Tx) (*state.SyncProgress, error) {
+	ret := _m.Called(ctx, dbTx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetLastSyncProgress")
+	}
+
+	var r0 *state.SyncProgress
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, pgx.Tx) (*state.SyncProgress, error)); ok {
+		return rf(ctx, dbTx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, pgx.Tx) *state.SyncProgress); ok {
+		r0 = rf(ctx, dbTx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*state.SyncProgress)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, pgx.Tx) error); ok {
+		r1 = rf(ctx, dbTx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// StorageMock_GetLastSyncProgress_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetLastSyncProgress'
+type StorageMock_GetLastSyncProgress_Call struct {
+	*mock.Call
+}
+
+// GetLastSyncProgress is a helper method to define mock.On call
+//   - ctx context.Context
+//   - dbTx pgx.Tx
+func (_e *StorageMock_Expecter) GetLastSyncProgress(ctx interface{}, dbTx interface{}) *StorageMock_GetLastSyncProgress_Call {
+	return &StorageMock_GetLastSyncProgress_Call{Call: _e.mock.On("GetLastSyncProgress", ctx, dbTx)}
+}
+
+func (_c *StorageMock_GetLastSyncProgress_Call) Run(run func(ctx context.Context, dbTx pgx.Tx)) *StorageMock_GetLastSyncProgress_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(pgx.Tx))
+	})
+	return _c
+}
+
+func (_c *StorageMock_GetLastSyncProgress_Call) Return(_a0 *state.SyncProgress, _a1 error) *StorageMock_GetLastSyncProgress_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *StorageMock_GetLastSyncProgress_Call) RunAndReturn(run func(context.Context, pgx.Tx) (*state.SyncProgress, error)) *StorageMock_GetLastSyncProgress_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetLastSyncProgresses provides a mock function with given fields: ctx, limit, dbTx
+func (_m *StorageMock) GetLastSyncProgresses(ctx context.Context, limit uint64, dbTx pgx.Tx) ([]state.SyncProgress, error) {
+	ret := _m.Called(ctx, limit, dbTx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetLastSyncProgresses")
+	}
+
+	var r0 []state.SyncProgress
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint64, pgx.Tx) ([]state.SyncProgress, error)); ok {
+		return rf(ctx, limit, dbTx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uint64, pgx.Tx) []state.SyncProgress); ok {
+		r0 = rf(ctx, limit, dbTx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]state.SyncProgress)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uint64, pgx.Tx) error); ok {
+		r1 = rf(ctx, limit, dbTx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// StorageMock_GetLastSyncProgresses_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetLastSyncProgresses'
+type StorageMock_GetLastSyncProgresses_Call struct {
+	*mock.Call
+}
+
+// GetLastSyncProgresses is a helper method to define mock.On call
+//   - ctx context.Context
+//   - limit uint64
+//   - dbTx pgx.Tx
+func (_e *StorageMock_Expecter) GetLastSyncProgresses(ctx interface{}, limit interface{}, dbTx interface{}) *StorageMock_GetLastSyncProgresses_Call {
+	return &StorageMock_GetLastSyncProgresses_Call{Call: _e.mock.On("GetLastSyncProgresses", ctx, limit, dbTx)}
+}
+
+func (_c *StorageMock_GetLastSyncProgresses_Call) Run(run func(ctx context.Context, limit uint64, dbTx pgx.Tx)) *StorageMock_GetLastSyncProgresses_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uint64), args[2].(pgx.Tx))
+	})
+	return _c
+}
+
+func (_c *StorageMock_GetLastSyncProgresses_Call) Return(_a0 []state.SyncProgress, _a1 error) *StorageMock_GetLastSyncProgresses_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *StorageMock_GetLastSyncProgresses_Call) RunAndReturn(run func(context.Context, uint64, pgx.Tx) ([]state.SyncProgress, error)) *StorageMock_GetLastSyncProgresses_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // GetLastTrustedForcedBatchNumber provides a mock function with given fields: ctx, dbTx
 func (_m *StorageMock) GetLastTrustedForcedBatchNumber(ctx context.Context, dbTx pgx.Tx) (uint64, error) {
 	ret := _m.Called(ctx, dbTx)
@@ -6206,6 +6707,126 @@ func (_c *StorageMock_GetSyncInfoData_Call) RunAndReturn(run func(context.Contex
 	return _c
 }
 
+// GetSyncProgressEvents provides a mock function with given fields: ctx, blockNumber, dbTx
+func (_m *StorageMock) GetSyncProgressEvents(ctx context.Context, blockNumber uint64, dbTx pgx.Tx) ([]state.SyncProgressEvent, error) {
+	ret := _m.Called(ctx, blockNumber, dbTx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetSyncProgressEvents")
+	}
+
+	var r0 []state.SyncProgressEvent
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint64, pgx.Tx) ([]state.SyncProgressEvent, error)); ok {
+		return rf(ctx, blockNumber, dbTx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uint64, pgx.Tx) []state.SyncProgressEvent); ok {
+		r0 = rf(ctx, blockNumber, dbTx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]state.SyncProgressEvent)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uint64, pgx.Tx) error); ok {
+		r1 = rf(ctx, blockNumber, dbTx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// StorageMock_GetSyncProgressEvents_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetSyncProgressEvents'
+type StorageMock_GetSyncProgressEvents_Call struct {
+	*mock.Call
+}
+
+// GetSyncProgressEvents is a helper method to define mock.On call
+//   - ctx context.Context
+//   - blockNumber uint64
+//   - dbTx pgx.Tx
+func (_e *StorageMock_Expecter) GetSyncProgressEvents(ctx interface{}, blockNumber interface{}, dbTx interface{}) *StorageMock_GetSyncProgressEvents_Call {
+	return &StorageMock_GetSyncProgressEvents_Call{Call: _e.mock.On("GetSyncProgressEvents", ctx, blockNumber, dbTx)}
+}
+
+func (_c *StorageMock_GetSyncProgressEvents_Call) Run(run func(ctx context.Context, blockNumber uint64, dbTx pgx.Tx)) *StorageMock_GetSyncProgressEvents_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uint64), args[2].(pgx.Tx))
+	})
+	return _c
+}
+
+func (_c *StorageMock_GetSyncProgressEvents_Call) Return(_a0 []state.SyncProgressEvent, _a1 error) *StorageMock_GetSyncProgressEvents_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *StorageMock_GetSyncProgressEvents_Call) RunAndReturn(run func(context.Context, uint64, pgx.Tx) ([]state.SyncProgressEvent, error)) *StorageMock_GetSyncProgressEvents_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetSyncProgressesFromBlockNumber provides a mock function with given fields: ctx, blockNumber, dbTx
+func (_m *StorageMock) GetSyncProgressesFromBlockNumber(ctx context.Context, blockNumber uint64, dbTx pgx.Tx) ([]state.SyncProgress, error) {
+	ret := _m.Called(ctx, blockNumber, dbTx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetSyncProgressesFromBlockNumber")
+	}
+
+	var r0 []state.SyncProgress
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint64, pgx.Tx) ([]state.SyncProgress, error)); ok {
+		return rf(ctx, blockNumber, dbTx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uint64, pgx.Tx) []state.SyncProgress); ok {
+		r0 = rf(ctx, blockNumber, dbTx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]state.SyncProgress)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uint64, pgx.Tx) error); ok {
+		r1 = rf(ctx, blockNumber, dbTx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// StorageMock_GetSyncProgressesFromBlockNumber_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetSyncProgressesFromBlockNumber'
+type StorageMock_GetSyncProgressesFromBlockNumber_Call struct {
+	*mock.Call
+}
+
+// GetSyncProgressesFromBlockNumber is a helper method to define mock.On call
+//   - ctx context.Context
+//   - blockNumber uint64
+//   - dbTx pgx.Tx
+func (_e *StorageMock_Expecter) GetSyncProgressesFromBlockNumber(ctx interface{}, blockNumber interface{}, dbTx interface{}) *StorageMock_GetSyncProgressesFromBlockNumber_Call {
+	return &StorageMock_GetSyncProgressesFromBlockNumber_Call{Call: _e.mock.On("GetSyncProgressesFromBlockNumber", ctx, blockNumber, dbTx)}
+}
+
+func (_c *StorageMock_GetSyncProgressesFromBlockNumber_Call) Run(run func(ctx context.Context, blockNumber uint64, dbTx pgx.Tx)) *StorageMock_GetSyncProgressesFromBlockNumber_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uint64), args[2].(pgx.Tx))
+	})
+	return _c
+}
+
+func (_c *StorageMock_GetSyncProgressesFromBlockNumber_Call) Return(_a0 []state.SyncProgress, _a1 error) *StorageMock_GetSyncProgressesFromBlockNumber_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *StorageMock_GetSyncProgressesFromBlockNumber_Call) RunAndReturn(run func(context.Context, uint64, pgx.Tx) ([]state.SyncProgress, error)) *StorageMock_GetSyncProgressesFromBlockNumber_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // GetTimeForLatestBatchVirtualization provides a mock function with given fields: ctx, dbTx
 func (_m *StorageMock) GetTimeForLatestBatchVirtualization(ctx context.Context, dbTx pgx.Tx) (time.Time, error) {
 	ret := _m.Called(ctx, dbTx)
@@ -6625,6 +7246,66 @@ func (_c *StorageMock_GetTransactionReceipt_Call) RunAndReturn(run func(context.
 	return _c
 }
 
+// GetTransactionRevertData provides a mock function with given fields: ctx, transactionHash, dbTx
+func (_m *StorageMock) GetTransactionRevertData(ctx context.Context, transactionHash common.Hash, dbTx pgx.Tx) ([]byte, error) {
+	ret := _m.Called(ctx, transactionHash, dbTx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetTransactionRevertData")
+	}
+
+	var r0 []byte
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, common.Hash, pgx.Tx) ([]byte, error)); ok {
+		return rf(ctx, transactionHash, dbTx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, common.Hash, pgx.Tx) []byte); ok {
+		r0 = rf(ctx, transactionHash, dbTx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]byte)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, common.Hash, pgx.Tx) error); ok {
+		r1 = rf(ctx, transactionHash, dbTx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// StorageMock_GetTransactionRevertData_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetTransactionRevertData'
+type StorageMock_GetTransactionRevertData_Call struct {
+	*mock.Call
+}
+
+// GetTransactionRevertData is a helper method to define mock.On call
+//   - ctx context.Context
+//   - transactionHash common.Hash
+//   - dbTx pgx.Tx
+func (_e *StorageMock_Expecter) GetTransactionRevertData(ctx interface{}, transactionHash interface{}, dbTx interface{}) *StorageMock_GetTransactionRevertData_Call {
+	return &StorageMock_GetTransactionRevertData_Call{Call: _e.mock.On("GetTransactionRevertData", ctx, transactionHash, dbTx)}
+}
+
+func (_c *StorageMock_GetTransactionRevertData_Call) Run(run func(ctx context.Context, transactionHash common.Hash, dbTx pgx.Tx)) *StorageMock_GetTransactionRevertData_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(common.Hash), args[2].(pgx.Tx))
+	})
+	return _c
+}
+
+func (_c *StorageMock_GetTransactionRevertData_Call) Return(_a0 []byte, _a1 error) *StorageMock_GetTransactionRevertData_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *StorageMock_GetTransactionRevertData_Call) RunAndReturn(run func(context.Context, common.Hash, pgx.Tx) ([]byte, error)) *StorageMock_GetTransactionRevertData_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // GetTransactionsByBatchNumber provides a mock function with given fields: ctx, batchNumber, dbTx
 func (_m *StorageMock) GetTransactionsByBatchNumber(ctx context.Context, batchNumber uint64, dbTx pgx.Tx) ([]types.Transaction, []uint8, error) {
 	ret := _m.Called(ctx, batchNumber, dbTx)
@@ -8036,6 +8717,54 @@ func (_c *StorageMock_ResetForkID_Call) RunAndReturn(run func(context.Context, u
 	return _c
 }
 
+// ResetToBatchNumber provides a mock function with given fields: ctx, batchNumber, dbTx
+func (_m *StorageMock) ResetToBatchNumber(ctx context.Context, batchNumber uint64, dbTx pgx.Tx) error {
+	ret := _m.Called(ctx, batchNumber, dbTx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ResetToBatchNumber")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint64, pgx.Tx) error); ok {
+		r0 = rf(ctx, batchNumber, dbTx)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// StorageMock_ResetToBatchNumber_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ResetToBatchNumber'
+type StorageMock_ResetToBatchNumber_Call struct {
+	*mock.Call
+}
+
+// ResetToBatchNumber is a helper method to define mock.On call
+//   - ctx context.Context
+//   - batchNumber uint64
+//   - dbTx pgx.Tx
+func (_e *StorageMock_Expecter) ResetToBatchNumber(ctx interface{}, batchNumber interface{}, dbTx interface{}) *StorageMock_ResetToBatchNumber_Call {
+	return &StorageMock_ResetToBatchNumber_Call{Call: _e.mock.On("ResetToBatchNumber", ctx, batchNumber, dbTx)}
+}
+
+func (_c *StorageMock_ResetToBatchNumber_Call) Run(run func(ctx context.Context, batchNumber uint64, dbTx pgx.Tx)) *StorageMock_ResetToBatchNumber_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uint64), args[2].(pgx.Tx))
+	})
+	return _c
+}
+
+func (_c *StorageMock_ResetToBatchNumber_Call) Return(_a0 error) *StorageMock_ResetToBatchNumber_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *StorageMock_ResetToBatchNumber_Call) RunAndReturn(run func(context.Context, uint64, pgx.Tx) error) *StorageMock_ResetToBatchNumber_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // ResetToL1BlockNumber provides a mock function with given fields: ctx, blockNumber, dbTx
 func (_m *StorageMock) ResetToL1BlockNumber(ctx context.Context, blockNumber uint64, dbTx pgx.Tx) error {
 	ret := _m.Called(ctx, blockNumber, dbTx)
@@ -0,0 +1,86 @@
+package pgstatestorage
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/0xPolygonHermez/zkevm-node/state"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/jackc/pgx/v4"
+)
+
+// AddCheckpoint stores a checkpoint in the state, replacing the previous checkpoint with the same name
+func (p *PostgresStorage) AddCheckpoint(ctx context.Context, checkpoint state.Checkpoint, dbTx pgx.Tx) error {
+	const addCheckpointSQL = `
+		INSERT INTO state.checkpoint (name, batch_num, state_root, local_exit_root, acc_input_hash, created_at) VALUES ($1, $2, $3, $4, $5, $6)
+		ON CONFLICT (name) DO UPDATE SET
+			batch_num = EXCLUDED.batch_num, state_root = EXCLUDED.state_root, local_exit_root = EXCLUDED.local_exit_root,
+			acc_input_hash = EXCLUDED.acc_input_hash, created_at = EXCLUDED.created_at`
+
+	e := p.getExecQuerier(dbTx)
+	_, err := e.Exec(ctx, addCheckpointSQL, checkpoint.Name, checkpoint.BatchNumber, checkpoint.StateRoot.String(),
+		checkpoint.LocalExitRoot.String(), checkpoint.AccInputHash.String(), checkpoint.CreatedAt)
+	return err
+}
+
+// GetCheckpoint returns the checkpoint with the provided name, it returns state.ErrNotFound if it doesn't exist
+func (p *PostgresStorage) GetCheckpoint(ctx context.Context, name string, dbTx pgx.Tx) (*state.Checkpoint, error) {
+	const getCheckpointSQL = "SELECT name, batch_num, state_root, local_exit_root, acc_input_hash, created_at FROM state.checkpoint WHERE name = $1"
+
+	e := p.getExecQuerier(dbTx)
+	checkpoint, err := scanCheckpoint(e.QueryRow(ctx, getCheckpointSQL, name))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, state.ErrNotFound
+	} else if err != nil {
+		return nil, err
+	}
+	return checkpoint, nil
+}
+
+// GetCheckpoints returns all the checkpoints sorted by batch number
+func (p *PostgresStorage) GetCheckpoints(ctx context.Context, dbTx pgx.Tx) ([]state.Checkpoint, error) {
+	const getCheckpointsSQL = "SELECT name, batch_num, state_root, local_exit_root, acc_input_hash, created_at FROM state.checkpoint ORDER BY batch_num ASC, name ASC"
+
+	e := p.getExecQuerier(dbTx)
+	rows, err := e.Query(ctx, getCheckpointsSQL)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	checkpoints := make([]state.Checkpoint, 0)
+	for rows.Next() {
+		checkpoint, err := scanCheckpoint(rows)
+		if err != nil {
+			return nil, err
+		}
+		checkpoints = append(checkpoints, *checkpoint)
+	}
+	return checkpoints, rows.Err()
+}
+
+// DeleteCheckpoint deletes the checkpoint with the provided name
+func (p *PostgresStorage) DeleteCheckpoint(ctx context.Context, name string, dbTx pgx.Tx) error {
+	const deleteCheckpointSQL = "DELETE FROM state.checkpoint WHERE name = $1"
+
+	e := p.getExecQuerier(dbTx)
+	_, err := e.Exec(ctx, deleteCheckpointSQL, name)
+	return err
+}
+
+func scanCheckpoint(row pgx.Row) (*state.Checkpoint, error) {
+	var (
+		checkpoint                             state.Checkpoint
+		stateRoot, localExitRoot, accInputHash string
+		createdAt                              time.Time
+	)
+	if err := row.Scan(&checkpoint.Name, &checkpoint.BatchNumber, &stateRoot, &localExitRoot, &accInputHash, &createdAt); err != nil {
+		return nil, err
+	}
+	checkpoint.StateRoot = common.HexToHash(stateRoot)
+	checkpoint.LocalExitRoot = common.HexToHash(localExitRoot)
+	checkpoint.AccInputHash = common.HexToHash(accInputHash)
+	checkpoint.CreatedAt = createdAt
+	return &checkpoint, nil
+}
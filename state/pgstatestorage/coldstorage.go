@@ -0,0 +1,266 @@
+package pgstatestorage
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/0xPolygonHermez/zkevm-node/log"
+	"github.com/0xPolygonHermez/zkevm-node/state"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/jackc/pgtype"
+	"github.com/jackc/pgx/v4"
+)
+
+// archiveBatchesPerStep is the number of batches copied to the cold storage in each DB tx
+const archiveBatchesPerStep = 100
+
+// coldStorageTables are the tables copied to the cold storage with the queries that select the
+// rows of a range of batches, in the order required by their foreign keys. Only the columns of the
+// batches needed to read the receipts and logs are copied, the L2 data of the batches is not
+var coldStorageTables = []struct {
+	name  string
+	query string
+}{
+	{"batch", `SELECT batch_num, global_exit_root, local_exit_root, state_root, acc_input_hash, timestamp, coinbase, checked
+	             FROM state.batch WHERE batch_num BETWEEN $1 AND $2`},
+	{"l2block", `SELECT * FROM state.l2block WHERE batch_num BETWEEN $1 AND $2`},
+	{"transaction", `SELECT t.* FROM state.transaction t INNER JOIN state.l2block b ON b.block_num = t.l2_block_num
+	                  WHERE b.batch_num BETWEEN $1 AND $2`},
+	{"receipt", `SELECT r.* FROM state.receipt r INNER JOIN state.l2block b ON b.block_num = r.block_num
+	              WHERE b.batch_num BETWEEN $1 AND $2`},
+	{"log", `SELECT l.* FROM state.log l INNER JOIN state.l2block b ON b.block_num = l.block_num
+	          WHERE b.batch_num BETWEEN $1 AND $2`},
+}
+
+// TieredStorage is a state storage that keeps the recent history in the main (hot) database and
+// archives the receipts and logs of the old batches in a secondary (cold) database with the same
+// schema. The receipts and logs, and the txs read by their index, not found in the hot database
+// are read from the cold one
+type TieredStorage struct {
+	*PostgresStorage
+	cold *PostgresStorage
+}
+
+// NewTieredStorage creates a new tiered storage from the hot and the cold storages
+func NewTieredStorage(hot *PostgresStorage, cold *PostgresStorage) *TieredStorage {
+	return &TieredStorage{
+		PostgresStorage: hot,
+		cold:            cold,
+	}
+}
+
+// GetTransactionReceipt gets a transaction receipt accordingly to the provided transaction hash,
+// if it's not in the hot storage it's read from the cold storage
+func (t *TieredStorage) GetTransactionReceipt(ctx context.Context, transactionHash common.Hash, dbTx pgx.Tx) (*types.Receipt, error) {
+	receipt, err := t.PostgresStorage.GetTransactionReceipt(ctx, transactionHash, dbTx)
+	if errors.Is(err, state.ErrNotFound) {
+		return t.cold.GetTransactionReceipt(ctx, transactionHash, nil)
+	}
+	return receipt, err
+}
+
+// GetTransactionRevertData gets the revert data of the transaction, if its receipt is not in
+// the hot storage it's read from the cold storage
+func (t *TieredStorage) GetTransactionRevertData(ctx context.Context, transactionHash common.Hash, dbTx pgx.Tx) ([]byte, error) {
+	revertData, err := t.PostgresStorage.GetTransactionRevertData(ctx, transactionHash, dbTx)
+	if errors.Is(err, state.ErrNotFound) {
+		return t.cold.GetTransactionRevertData(ctx, transactionHash, nil)
+	}
+	return revertData, err
+}
+
+// GetL2BlockReceipts gets the receipts of the txs of the L2 block, if they are not in the hot
+// storage they are read from the cold storage
+func (t *TieredStorage) GetL2BlockReceipts(ctx context.Context, blockNumber uint64, dbTx pgx.Tx) ([]*types.Receipt, error) {
+	receipts, err := t.PostgresStorage.GetL2BlockReceipts(ctx, blockNumber, dbTx)
+	if err != nil || len(receipts) > 0 {
+		return receipts, err
+	}
+	return t.cold.GetL2BlockReceipts(ctx, blockNumber, nil)
+}
+
+// GetTransactionByL2BlockHashAndIndex gets a transaction accordingly to the block hash and transaction
+// index provided, if it's not in the hot storage it's read from the cold storage
+func (t *TieredStorage) GetTransactionByL2BlockHashAndIndex(ctx context.Context, blockHash common.Hash, index uint64, dbTx pgx.Tx) (*types.Transaction, error) {
+	tx, err := t.PostgresStorage.GetTransactionByL2BlockHashAndIndex(ctx, blockHash, index, dbTx)
+	if errors.Is(err, state.ErrNotFound) {
+		return t.cold.GetTransactionByL2BlockHashAndIndex(ctx, blockHash, index, nil)
+	}
+	return tx, err
+}
+
+// GetTransactionByL2BlockNumberAndIndex gets a transaction accordingly to the block number and transaction
+// index provided, if it's not in the hot storage it's read from the cold storage
+func (t *TieredStorage) GetTransactionByL2BlockNumberAndIndex(ctx context.Context, blockNumber uint64, index uint64, dbTx pgx.Tx) (*types.Transaction, error) {
+	tx, err := t.PostgresStorage.GetTransactionByL2BlockNumberAndIndex(ctx, blockNumber, index, dbTx)
+	if errors.Is(err, state.ErrNotFound) {
+		return t.cold.GetTransactionByL2BlockNumberAndIndex(ctx, blockNumber, index, nil)
+	}
+	return tx, err
+}
+
+// GetTxsByBlockNumber returns all the txs in a given block, if they are not in the hot
+// storage they are read from the cold storage
+func (t *TieredStorage) GetTxsByBlockNumber(ctx context.Context, blockNumber uint64, dbTx pgx.Tx) ([]*types.Transaction, error) {
+	txs, err := t.PostgresStorage.GetTxsByBlockNumber(ctx, blockNumber, dbTx)
+	if (err != nil && !errors.Is(err, state.ErrNotFound)) || len(txs) > 0 {
+		return txs, err
+	}
+	return t.cold.GetTxsByBlockNumber(ctx, blockNumber, nil)
+}
+
+// GetLogsByBlockNumber gets all the logs of the L2 block, if they are not in the hot
+// storage they are read from the cold storage
+func (t *TieredStorage) GetLogsByBlockNumber(ctx context.Context, blockNumber uint64, dbTx pgx.Tx) ([]*types.Log, error) {
+	logs, err := t.PostgresStorage.GetLogsByBlockNumber(ctx, blockNumber, dbTx)
+	if err != nil || len(logs) > 0 {
+		return logs, err
+	}
+	return t.cold.GetLogsByBlockNumber(ctx, blockNumber, nil)
+}
+
+// GetLogs returns the logs that match the filter, the logs of the blocks archived in the
+// cold storage are read from it and the logs of the newer blocks from the hot storage
+func (t *TieredStorage) GetLogs(ctx context.Context, fromBlock uint64, toBlock uint64, addresses []common.Address, topics [][]common.Hash, blockHash *common.Hash, since *time.Time, dbTx pgx.Tx) ([]*types.Log, error) {
+	lastColdBlockNumber, err := t.cold.GetLastL2BlockNumber(ctx, nil)
+	if errors.Is(err, state.ErrStateNotSynchronized) {
+		return t.PostgresStorage.GetLogs(ctx, fromBlock, toBlock, addresses, topics, blockHash, since, dbTx)
+	} else if err != nil {
+		return nil, err
+	}
+
+	if blockHash != nil {
+		logs, err := t.PostgresStorage.GetLogs(ctx, fromBlock, toBlock, addresses, topics, blockHash, since, dbTx)
+		if err != nil || len(logs) > 0 {
+			return logs, err
+		}
+		return t.cold.GetLogs(ctx, fromBlock, toBlock, addresses, topics, blockHash, since, nil)
+	}
+
+	if fromBlock > lastColdBlockNumber {
+		return t.PostgresStorage.GetLogs(ctx, fromBlock, toBlock, addresses, topics, blockHash, since, dbTx)
+	}
+	if err := t.checkLogsBlockRange(fromBlock, toBlock); err != nil {
+		return nil, err
+	}
+
+	logs, err := t.cold.GetLogs(ctx, fromBlock, min(toBlock, lastColdBlockNumber), addresses, topics, nil, since, nil)
+	if err != nil || toBlock <= lastColdBlockNumber {
+		return logs, err
+	}
+	hotLogs, err := t.PostgresStorage.GetLogs(ctx, lastColdBlockNumber+1, toBlock, addresses, topics, nil, since, dbTx)
+	if err != nil {
+		return nil, err
+	}
+	logs = append(logs, hotLogs...)
+
+	if t.cfg.MaxLogsCount > 0 && uint64(len(logs)) > t.cfg.MaxLogsCount {
+		return nil, state.ErrMaxLogsCountLimitExceeded
+	}
+	return logs, nil
+}
+
+// ArchiveUpToBatchNumber copies the batches up to the provided batch number (inclusive) that are not
+// in the cold storage yet, with their L2 blocks, txs, receipts and logs, to the cold storage. Once
+// they are committed in the cold storage, the receipts and logs are deleted from the hot storage,
+// keeping there the tx index and the state roots of the receipts, as the data stream is generated
+// from the hot storage. It returns the number of archived receipts
+func (t *TieredStorage) ArchiveUpToBatchNumber(ctx context.Context, batchNumber uint64) (uint64, error) {
+	const getNextColdBatchNumberSQL = "SELECT COALESCE(MAX(batch_num) + 1, 0) FROM state.batch"
+	var nextColdBatchNumber uint64
+	if err := t.cold.QueryRow(ctx, getNextColdBatchNumberSQL).Scan(&nextColdBatchNumber); err != nil {
+		return 0, err
+	}
+
+	var archivedReceipts uint64
+	for fromBatchNumber := nextColdBatchNumber; fromBatchNumber <= batchNumber; fromBatchNumber += archiveBatchesPerStep {
+		toBatchNumber := min(fromBatchNumber+archiveBatchesPerStep-1, batchNumber)
+		receipts, err := t.archiveBatches(ctx, fromBatchNumber, toBatchNumber)
+		if err != nil {
+			return archivedReceipts, err
+		}
+		archivedReceipts += receipts
+		log.Debugf("archived the batches from %d to %d in the cold storage", fromBatchNumber, toBatchNumber)
+	}
+
+	if _, err := t.PostgresStorage.DeleteReceiptsAndLogsUpToBatchNumber(ctx, batchNumber, nil); err != nil {
+		return archivedReceipts, err
+	}
+	return archivedReceipts, nil
+}
+
+// archiveBatches copies the rows of the batches in the range to the cold storage in a single DB tx,
+// it returns the number of copied receipts
+func (t *TieredStorage) archiveBatches(ctx context.Context, fromBatchNumber uint64, toBatchNumber uint64) (uint64, error) {
+	coldTx, err := t.cold.Begin(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	var archivedReceipts uint64
+	for _, table := range coldStorageTables {
+		copied, err := t.copyToColdStorage(ctx, coldTx, table.name, table.query, fromBatchNumber, toBatchNumber)
+		if err != nil {
+			if rollbackErr := coldTx.Rollback(ctx); rollbackErr != nil {
+				log.Errorf("failed to rollback the archive of the batches from %d to %d, error: %v", fromBatchNumber, toBatchNumber, rollbackErr)
+			}
+			return 0, err
+		}
+		if table.name == "receipt" {
+			archivedReceipts = uint64(copied)
+		}
+	}
+
+	return archivedReceipts, coldTx.Commit(ctx)
+}
+
+// copyToColdStorage copies the rows returned by the query in the hot storage to the table of the
+// cold storage. The JSONB values are copied as they are stored, without decoding them
+func (t *TieredStorage) copyToColdStorage(ctx context.Context, coldTx pgx.Tx, table string, query string, args ...interface{}) (int64, error) {
+	rows, err := t.PostgresStorage.Query(ctx, query, args...)
+	if err != nil {
+		return 0, err
+	}
+	defer rows.Close()
+
+	fields := rows.FieldDescriptions()
+	columns := make([]string, 0, len(fields))
+	for _, field := range fields {
+		columns = append(columns, string(field.Name))
+	}
+
+	values := [][]interface{}{}
+	for rows.Next() {
+		row, err := rows.Values()
+		if err != nil {
+			return 0, err
+		}
+		rawRow := rows.RawValues()
+		for i, field := range fields {
+			if field.DataTypeOID != pgtype.JSONBOID || row[i] == nil {
+				continue
+			}
+			var value pgtype.JSONB
+			if field.Format == pgx.BinaryFormatCode {
+				err = value.DecodeBinary(nil, rawRow[i])
+			} else {
+				err = value.DecodeText(nil, rawRow[i])
+			}
+			if err != nil {
+				return 0, err
+			}
+			row[i] = value
+		}
+		values = append(values, row)
+	}
+	if err := rows.Err(); err != nil {
+		return 0, err
+	}
+	if len(values) == 0 {
+		return 0, nil
+	}
+
+	return coldTx.CopyFrom(ctx, pgx.Identifier{"state", table}, columns, pgx.CopyFromRows(values))
+}
@@ -0,0 +1,124 @@
+package pgstatestorage_test
+
+import (
+	"fmt"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/0xPolygonHermez/zkevm-node/db"
+	"github.com/0xPolygonHermez/zkevm-node/state"
+	"github.com/0xPolygonHermez/zkevm-node/state/pgstatestorage"
+	"github.com/0xPolygonHermez/zkevm-node/test/dbutils"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/trie"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const coldStateDBName = "state_cold_db"
+
+// newColdStorage creates a cold storage with the state schema in a database of the server of the state DB
+func newColdStorage(t *testing.T) *pgstatestorage.PostgresStorage {
+	var exists bool
+	require.NoError(t, stateDb.QueryRow(ctx, "SELECT EXISTS (SELECT FROM pg_database WHERE datname = $1)", coldStateDBName).Scan(&exists))
+	if !exists {
+		_, err := stateDb.Exec(ctx, "CREATE DATABASE "+coldStateDBName)
+		require.NoError(t, err)
+	}
+
+	coldDBCfg := stateDBCfg
+	coldDBCfg.Name = coldStateDBName
+	require.NoError(t, dbutils.InitOrResetState(coldDBCfg))
+	coldDb, err := db.NewSQLDB(coldDBCfg)
+	require.NoError(t, err)
+	t.Cleanup(coldDb.Close)
+	return pgstatestorage.NewPostgresStorage(stateCfg, coldDb)
+}
+
+func TestTieredStorageReadThrough(t *testing.T) {
+	initOrResetDB()
+	tiered := pgstatestorage.NewTieredStorage(pgstatestorage.NewPostgresStorage(stateCfg, stateDb), newColdStorage(t))
+
+	dbTx, err := testState.BeginStateTransaction(ctx)
+	require.NoError(t, err)
+	require.NoError(t, testState.AddBlock(ctx, block, dbTx))
+	batchNumber := uint64(1)
+	_, err = dbTx.Exec(ctx, "INSERT INTO state.batch (batch_num, wip) VALUES ($1, FALSE)", batchNumber)
+	require.NoError(t, err)
+
+	txs := []*types.Transaction{
+		types.NewTransaction(1, common.HexToAddress("0x1"), big.NewInt(0), 21000, big.NewInt(1), nil),
+		types.NewTransaction(2, common.HexToAddress("0x1"), big.NewInt(0), 21000, big.NewInt(1), nil),
+	}
+	receipts := make([]*types.Receipt, 0, len(txs))
+	imStateRoots := make([]common.Hash, 0, len(txs))
+	storeTxsEGPData := make([]state.StoreTxEGPData, 0, len(txs))
+	txsL2Hash := make([]common.Hash, 0, len(txs))
+	for i, tx := range txs {
+		receipts = append(receipts, &types.Receipt{
+			Type:              tx.Type(),
+			PostState:         common.HexToHash(fmt.Sprintf("0x%d1", i)).Bytes(),
+			Status:            types.ReceiptStatusSuccessful,
+			EffectiveGasPrice: big.NewInt(1),
+			BlockNumber:       big.NewInt(1),
+			TxHash:            tx.Hash(),
+			TransactionIndex:  uint(i),
+			Logs:              []*types.Log{{Address: common.HexToAddress("0x1"), TxHash: tx.Hash(), TxIndex: uint(i), Index: uint(i), Topics: []common.Hash{common.HexToHash("0x2")}, Data: []byte{}}},
+		})
+		imStateRoots = append(imStateRoots, common.HexToHash(fmt.Sprintf("0x%d2", i)))
+		storeTxsEGPData = append(storeTxsEGPData, state.StoreTxEGPData{EffectivePercentage: state.MaxEffectivePercentage})
+		txsL2Hash = append(txsL2Hash, tx.Hash())
+	}
+	header := state.NewL2Header(&types.Header{Number: big.NewInt(1), Time: uint64(time.Now().Unix())})
+	l2Block := state.NewL2Block(header, txs, []*state.L2Header{}, receipts, trie.NewStackTrie(nil))
+	require.NoError(t, testState.AddL2Block(ctx, batchNumber, l2Block, receipts, txsL2Hash, storeTxsEGPData, imStateRoots, nil, dbTx))
+	require.NoError(t, dbTx.Commit(ctx))
+
+	archivedReceipts, err := tiered.ArchiveUpToBatchNumber(ctx, batchNumber)
+	require.NoError(t, err)
+	require.Equal(t, uint64(len(txs)), archivedReceipts)
+
+	assertTxsByBlock := func(t *testing.T) {
+		blockTxs, err := tiered.GetTxsByBlockNumber(ctx, l2Block.NumberU64(), nil)
+		require.NoError(t, err)
+		require.Len(t, blockTxs, len(txs))
+		for i, tx := range txs {
+			assert.Equal(t, tx.Hash(), blockTxs[i].Hash())
+
+			byNumber, err := tiered.GetTransactionByL2BlockNumberAndIndex(ctx, l2Block.NumberU64(), uint64(i), nil)
+			require.NoError(t, err)
+			assert.Equal(t, tx.Hash(), byNumber.Hash())
+
+			byHash, err := tiered.GetTransactionByL2BlockHashAndIndex(ctx, l2Block.Hash(), uint64(i), nil)
+			require.NoError(t, err)
+			assert.Equal(t, tx.Hash(), byHash.Hash())
+		}
+		_, err = tiered.GetTransactionByL2BlockNumberAndIndex(ctx, l2Block.NumberU64(), uint64(len(txs)), nil)
+		assert.ErrorIs(t, err, state.ErrNotFound)
+	}
+
+	// the hot storage keeps the tx index of the archived receipts
+	t.Run("txs by block from the hot storage", assertTxsByBlock)
+
+	// the archives made before the hot storage kept the tx index of the archived receipts are read from the cold storage
+	_, err = stateDb.Exec(ctx, "DELETE FROM state.pruned_receipt")
+	require.NoError(t, err)
+	t.Run("txs by block from the cold storage", assertTxsByBlock)
+
+	t.Run("logs by block from the cold storage", func(t *testing.T) {
+		hotLogs, err := testState.GetLogsByBlockNumber(ctx, l2Block.NumberU64(), nil)
+		require.NoError(t, err)
+		assert.Empty(t, hotLogs)
+
+		logs, err := tiered.GetLogsByBlockNumber(ctx, l2Block.NumberU64(), nil)
+		require.NoError(t, err)
+		require.Len(t, logs, len(txs))
+		for i, l := range logs {
+			assert.Equal(t, txs[i].Hash(), l.TxHash)
+			assert.Equal(t, uint(i), l.TxIndex)
+			assert.Equal(t, l2Block.Hash(), l.BlockHash)
+		}
+	})
+}
@@ -91,8 +91,12 @@ func scanL2Block(row pgx.Row) (*state.DSL2Block, error) {
 
 // GetDSL2Transactions returns the L2 transactions
 func (p *PostgresStorage) GetDSL2Transactions(ctx context.Context, firstL2Block, lastL2Block uint64, dbTx pgx.Tx) ([]*state.DSL2Transaction, error) {
+	// the receipts of the pruned blocks are deleted, but their tx index and state roots are kept in the pruned receipts
 	const l2TxSQL = `SELECT l2_block_num, t.effective_percentage, t.encoded, r.post_state, r.im_state_root
-					 FROM state.transaction t, state.receipt r
+					 FROM state.transaction t, (
+						SELECT tx_hash, tx_index, post_state, im_state_root FROM state.receipt
+						UNION ALL
+						SELECT tx_hash, tx_index, post_state, im_state_root FROM state.pruned_receipt) r
 					 WHERE l2_block_num BETWEEN $1 AND $2 AND r.tx_hash = t.hash
 					 ORDER BY t.l2_block_num ASC, r.tx_index ASC`
 
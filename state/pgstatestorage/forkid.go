@@ -64,7 +64,7 @@ func (p *PostgresStorage) UpdateForkIDToBatchNumber(ctx context.Context, forkID
 func (p *PostgresStorage) UpdateForkIDBlockNumber(ctx context.Context, forkdID uint64, newBlockNumber uint64, updateMemCache bool, dbTx pgx.Tx) error {
 	const sql = "UPDATE state.fork_id SET block_num = $1 WHERE fork_id = $2"
 	e := p.getExecQuerier(dbTx)
-	if _, err := e.Exec(ctx, sql, forkdID, newBlockNumber); err != nil {
+	if _, err := e.Exec(ctx, sql, newBlockNumber, forkdID); err != nil {
 		return err
 	}
 	if updateMemCache {
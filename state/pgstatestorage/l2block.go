@@ -169,7 +169,7 @@ func (p *PostgresStorage) GetL2BlockTransactionCountByNumber(ctx context.Context
 }
 
 // AddL2Block adds a new L2 block to the State Store
-func (p *PostgresStorage) AddL2Block(ctx context.Context, batchNumber uint64, l2Block *state.L2Block, receipts []*types.Receipt, txsL2Hash []common.Hash, txsEGPData []state.StoreTxEGPData, imStateRoots []common.Hash, dbTx pgx.Tx) error {
+func (p *PostgresStorage) AddL2Block(ctx context.Context, batchNumber uint64, l2Block *state.L2Block, receipts []*types.Receipt, txsL2Hash []common.Hash, txsEGPData []state.StoreTxEGPData, imStateRoots []common.Hash, txsRevertData [][]byte, dbTx pgx.Tx) error {
 	// TODO: Optimize this function using only one SQL (with several values) to insert all the txs, receipts and logs
 	log.Debugf("[AddL2Block] adding L2 block %d", l2Block.NumberU64())
 	start := time.Now()
@@ -177,8 +177,8 @@ func (p *PostgresStorage) AddL2Block(ctx context.Context, batchNumber uint64, l2
 	e := p.getExecQuerier(dbTx)
 
 	const addL2BlockSQL = `
-        INSERT INTO state.l2block (block_num, block_hash, header, uncles, parent_hash, state_root, received_at, batch_num, created_at)
-                           VALUES (       $1,         $2,     $3,     $4,          $5,         $6,          $7,        $8,         $9)`
+        INSERT INTO state.l2block (block_num, block_hash, header, uncles, parent_hash, state_root, received_at, batch_num, created_at, logs_bloom)
+                           VALUES (       $1,         $2,     $3,     $4,          $5,         $6,          $7,        $8,         $9,        $10)`
 
 	var header = "{}"
 	if l2Block.Header() != nil {
@@ -202,7 +202,7 @@ func (p *PostgresStorage) AddL2Block(ctx context.Context, batchNumber uint64, l2
 	if _, err := e.Exec(ctx, addL2BlockSQL,
 		l2Block.Number().Uint64(), l2Block.Hash().String(), header, uncles,
 		l2Block.ParentHash().String(), l2Block.Root().String(),
-		l2Block.ReceivedAt, batchNumber, time.Now().UTC()); err != nil {
+		l2Block.ReceivedAt, batchNumber, time.Now().UTC(), l2Block.Bloom().Bytes()); err != nil {
 		return err
 	}
 
@@ -255,10 +255,13 @@ func (p *PostgresStorage) AddL2Block(ctx context.Context, batchNumber uint64, l2
 	}
 
 	if len(receipts) > 0 {
-		p.AddReceipts(ctx, receipts, imStateRoots, dbTx)
+		p.AddReceipts(ctx, receipts, imStateRoots, txsRevertData, dbTx)
 
 		var logs []*types.Log
 		for _, receipt := range receipts {
+			for _, l := range receipt.Logs {
+				l.BlockNumber = l2Block.NumberU64()
+			}
 			logs = append(logs, receipt.Logs...)
 		}
 		p.AddLogs(ctx, logs, dbTx)
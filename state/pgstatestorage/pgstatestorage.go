@@ -47,18 +47,25 @@ func (p *PostgresStorage) ResetToL1BlockNumber(ctx context.Context, blockNumber
 
 // ResetForkID resets the state to reprocess the newer batches with the correct forkID
 func (p *PostgresStorage) ResetForkID(ctx context.Context, batchNumber uint64, dbTx pgx.Tx) error {
+	return p.ResetToBatchNumber(ctx, batchNumber-1, dbTx)
+}
+
+// ResetToBatchNumber removes the batches with number greater than the given one, together with
+// the L1 blocks from the first one that virtualized any of them, so the synchronizer syncs again
+// from L1 the virtual and verified batches removed, and the proofs of the removed batches
+func (p *PostgresStorage) ResetToBatchNumber(ctx context.Context, batchNumber uint64, dbTx pgx.Tx) error {
 	e := p.getExecQuerier(dbTx)
-	const resetVirtualStateSQL = "delete from state.block where block_num >=(select min(block_num) from state.virtual_batch where batch_num >= $1)"
+	const resetVirtualStateSQL = "delete from state.block where block_num >=(select min(block_num) from state.virtual_batch where batch_num > $1)"
 	if _, err := e.Exec(ctx, resetVirtualStateSQL, batchNumber); err != nil {
 		return err
 	}
-	err := p.ResetTrustedState(ctx, batchNumber-1, dbTx)
+	err := p.ResetTrustedState(ctx, batchNumber, dbTx)
 	if err != nil {
 		return err
 	}
 
 	// Delete proofs for higher batches
-	const deleteProofsSQL = "delete from state.proof where batch_num >= $1 or (batch_num <= $1 and batch_num_final  >= $1)"
+	const deleteProofsSQL = "delete from state.proof where batch_num > $1 or (batch_num <= $1 and batch_num_final > $1)"
 	if _, err := e.Exec(ctx, deleteProofsSQL, batchNumber); err != nil {
 		return err
 	}
@@ -125,9 +132,9 @@ func (p *PostgresStorage) GetLogsByBlockNumber(ctx context.Context, blockNumber
       SELECT t.l2_block_num, b.block_hash, l.tx_hash, r.tx_index, l.log_index, l.address, l.data, l.topic0, l.topic1, l.topic2, l.topic3
         FROM state.log l
        INNER JOIN state.transaction t ON t.hash = l.tx_hash
-       INNER JOIN state.l2block b ON b.block_num = t.l2_block_num
+       INNER JOIN state.l2block b ON b.block_num = l.block_num
        INNER JOIN state.receipt r ON r.tx_hash = t.hash
-       WHERE b.block_num = $1
+       WHERE l.block_num = $1
        ORDER BY r.tx_index ASC, l.log_index ASC`
 
 	q := p.getExecQuerier(dbTx)
@@ -142,12 +149,11 @@ func (p *PostgresStorage) GetLogsByBlockNumber(ctx context.Context, blockNumber
 // GetLogs returns the logs that match the filter
 func (p *PostgresStorage) GetLogs(ctx context.Context, fromBlock uint64, toBlock uint64, addresses []common.Address, topics [][]common.Hash, blockHash *common.Hash, since *time.Time, dbTx pgx.Tx) ([]*types.Log, error) {
 	// query parts
-	const queryCount = `SELECT count(*) `
 	const querySelect = `SELECT t.l2_block_num, b.block_hash, l.tx_hash, r.tx_index, l.log_index, l.address, l.data, l.topic0, l.topic1, l.topic2, l.topic3 `
 
 	const queryBody = `FROM state.log l
        INNER JOIN state.transaction t ON t.hash = l.tx_hash
-       INNER JOIN state.l2block b ON b.block_num = t.l2_block_num
+       INNER JOIN state.l2block b ON b.block_num = l.block_num
        INNER JOIN state.receipt r ON r.tx_hash = t.hash
        WHERE (l.address = any($1) OR $1 IS NULL)
          AND (l.topic0 = any($2) OR $2 IS NULL)
@@ -156,32 +162,40 @@ func (p *PostgresStorage) GetLogs(ctx context.Context, fromBlock uint64, toBlock
          AND (l.topic3 = any($5) OR $5 IS NULL)
          AND (b.created_at >= $6 OR $6 IS NULL) `
 
+	// the block range is filtered by the block number of the logs, so the
+	// indexes of the logs by block number, address and topic can be used
 	const queryFilterByBlockHash = `AND b.block_hash = $7 `
-	const queryFilterByBlockNumbers = `AND b.block_num BETWEEN $7 AND $8 `
-
-	const queryOrder = `ORDER BY b.block_num ASC, r.tx_index ASC, l.log_index ASC`
-
-	// count queries
-	const queryToCountLogsByBlockHash = "" +
-		queryCount +
-		queryBody +
-		queryFilterByBlockHash
-	const queryToCountLogsByBlockNumbers = "" +
-		queryCount +
-		queryBody +
-		queryFilterByBlockNumbers
-
-	// select queries
-	const queryToSelectLogsByBlockHash = "" +
-		querySelect +
-		queryBody +
-		queryFilterByBlockHash +
-		queryOrder
-	const queryToSelectLogsByBlockNumbers = "" +
-		querySelect +
-		queryBody +
-		queryFilterByBlockNumbers +
-		queryOrder
+	const queryFilterByBlockNumbers = `AND l.block_num BETWEEN $7 AND $8 `
+	const queryFilterByBloomBlockNumbers = `AND l.block_num = any($7) `
+
+	const queryOrder = `ORDER BY l.block_num ASC, r.tx_index ASC, l.log_index ASC `
+
+	// the number of logs is bounded by fetching one log over the limit
+	// instead of counting all the logs that match the filter
+	const queryLimitByBlockHash = `LIMIT $8`
+	const queryLimitByBlockNumbers = `LIMIT $9`
+
+	if blockHash == nil {
+		if err := p.checkLogsBlockRange(fromBlock, toBlock); err != nil {
+			return nil, err
+		}
+	}
+
+	// when the logs are filtered by address or topic, the blooms of the blocks
+	// are checked first so only the logs of the blocks that may contain matching
+	// logs are scanned. The blooms are only loaded for a bounded block range
+	var bloomBlockNumbers []uint64
+	filterByBloom := (len(addresses) > 0 || len(topics) > 0) && (blockHash != nil || p.cfg.MaxLogsBlockRange > 0)
+	if filterByBloom {
+		var err error
+		bloomBlockNumbers, err = p.getL2BlockNumbersMatchingBloom(ctx, fromBlock, toBlock, blockHash, addresses, topics, dbTx)
+		if err != nil {
+			return nil, err
+		}
+		if len(bloomBlockNumbers) == 0 {
+			return []*types.Log{}, nil
+		}
+	}
 
 	args := []interface{}{}
 
@@ -205,45 +219,95 @@ func (p *PostgresStorage) GetLogs(ctx context.Context, fromBlock uint64, toBlock
 	args = append(args, since)
 
 	// block filter
-	var queryToCount string
-	var queryToSelect string
-	if blockHash != nil {
+	query := querySelect + queryBody
+	if filterByBloom {
+		args = append(args, bloomBlockNumbers)
+		query += queryFilterByBloomBlockNumbers + queryOrder
+		if p.cfg.MaxLogsCount > 0 {
+			query += queryLimitByBlockHash
+		}
+	} else if blockHash != nil {
 		args = append(args, blockHash.String())
-		queryToCount = queryToCountLogsByBlockHash
-		queryToSelect = queryToSelectLogsByBlockHash
+		query += queryFilterByBlockHash + queryOrder
+		if p.cfg.MaxLogsCount > 0 {
+			query += queryLimitByBlockHash
+		}
 	} else {
-		if toBlock < fromBlock {
-			return nil, state.ErrInvalidBlockRange
+		args = append(args, fromBlock, toBlock)
+		query += queryFilterByBlockNumbers + queryOrder
+		if p.cfg.MaxLogsCount > 0 {
+			query += queryLimitByBlockNumbers
 		}
+	}
+	if p.cfg.MaxLogsCount > 0 {
+		args = append(args, p.cfg.MaxLogsCount+1)
+	}
 
-		blockRange := toBlock - fromBlock
-		if p.cfg.MaxLogsBlockRange > 0 && blockRange > p.cfg.MaxLogsBlockRange {
-			return nil, state.ErrMaxLogsBlockRangeLimitExceeded
-		}
+	q := p.getExecQuerier(dbTx)
+	rows, err := q.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	logs, err := scanLogs(rows)
+	if err != nil {
+		return nil, err
+	}
 
-		args = append(args, fromBlock, toBlock)
-		queryToCount = queryToCountLogsByBlockNumbers
-		queryToSelect = queryToSelectLogsByBlockNumbers
+	if p.cfg.MaxLogsCount > 0 && uint64(len(logs)) > p.cfg.MaxLogsCount {
+		return nil, state.ErrMaxLogsCountLimitExceeded
+	}
+	return logs, nil
+}
+
+// checkLogsBlockRange checks the block range of a logs query is valid and within the limit
+func (p *PostgresStorage) checkLogsBlockRange(fromBlock uint64, toBlock uint64) error {
+	if toBlock < fromBlock {
+		return state.ErrInvalidBlockRange
+	}
+
+	blockRange := toBlock - fromBlock
+	if p.cfg.MaxLogsBlockRange > 0 && blockRange > p.cfg.MaxLogsBlockRange {
+		return state.ErrMaxLogsBlockRangeLimitExceeded
 	}
+	return nil
+}
+
+// getL2BlockNumbersMatchingBloom returns the numbers of the l2 blocks, identified by hash or by block
+// range, whose logs bloom may contain logs matching the addresses and topics. The blocks without
+// a stored bloom are always returned
+func (p *PostgresStorage) getL2BlockNumbersMatchingBloom(ctx context.Context, fromBlock uint64, toBlock uint64, blockHash *common.Hash, addresses []common.Address, topics [][]common.Hash, dbTx pgx.Tx) ([]uint64, error) {
+	const getBloomsByBlockHashSQL = "SELECT block_num, logs_bloom FROM state.l2block WHERE block_hash = $1"
+	const getBloomsByBlockNumbersSQL = "SELECT block_num, logs_bloom FROM state.l2block WHERE block_num BETWEEN $1 AND $2 ORDER BY block_num ASC"
 
 	q := p.getExecQuerier(dbTx)
-	if p.cfg.MaxLogsCount > 0 {
-		var count uint64
-		err := q.QueryRow(ctx, queryToCount, args...).Scan(&count)
-		if err != nil {
+	var rows pgx.Rows
+	var err error
+	if blockHash != nil {
+		rows, err = q.Query(ctx, getBloomsByBlockHashSQL, blockHash.String())
+	} else {
+		rows, err = q.Query(ctx, getBloomsByBlockNumbersSQL, fromBlock, toBlock)
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	blockNumbers := []uint64{}
+	for rows.Next() {
+		var blockNumber uint64
+		var logsBloom []byte
+		if err := rows.Scan(&blockNumber, &logsBloom); err != nil {
 			return nil, err
 		}
-
-		if count > p.cfg.MaxLogsCount {
-			return nil, state.ErrMaxLogsCountLimitExceeded
+		if logsBloom == nil || state.BloomMatchesFilter(types.BytesToBloom(logsBloom), addresses, topics) {
+			blockNumbers = append(blockNumbers, blockNumber)
 		}
 	}
-
-	rows, err := q.Query(ctx, queryToSelect, args...)
-	if err != nil {
+	if err := rows.Err(); err != nil {
 		return nil, err
 	}
-	return scanLogs(rows)
+
+	return blockNumbers, nil
 }
 
 func (p *PostgresStorage) addressesToHex(addresses []common.Address) []string {
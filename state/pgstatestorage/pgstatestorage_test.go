@@ -207,7 +207,7 @@ func TestGetBatchByL2BlockNumber(t *testing.T) {
 		txsL2Hash[i] = common.HexToHash(fmt.Sprintf("0x%d", i))
 	}
 
-	err = pgStateStorage.AddL2Block(ctx, batchNumber, l2Block, receipts, txsL2Hash, storeTxsEGPData, imStateRoots, dbTx)
+	err = pgStateStorage.AddL2Block(ctx, batchNumber, l2Block, receipts, txsL2Hash, storeTxsEGPData, imStateRoots, nil, dbTx)
 	require.NoError(t, err)
 	result, err := pgStateStorage.BatchNumberByL2BlockNumber(ctx, l2Block.Number().Uint64(), dbTx)
 	require.NoError(t, err)
@@ -597,6 +597,67 @@ func TestVirtualBatch(t *testing.T) {
 	require.NoError(t, dbTx.Commit(ctx))
 }
 
+func TestRollbackToCheckpointSyncsAgain(t *testing.T) {
+	initOrResetDB()
+
+	ctx := context.Background()
+	dbTx, err := testState.BeginStateTransaction(ctx)
+	require.NoError(t, err)
+
+	addBatch := func(batchNumber uint64) {
+		_, err := dbTx.Exec(ctx, `INSERT INTO state.batch
+		(batch_num, global_exit_root, local_exit_root, state_root, timestamp, coinbase, raw_txs_data, wip)
+		VALUES($1, '0x0000000000000000000000000000000000000000000000000000000000000000', '0x0000000000000000000000000000000000000000000000000000000000000000', $2, '2022-12-19 08:17:45.000', '0x0000000000000000000000000000000000000000', NULL, FALSE)`,
+			batchNumber, common.BigToHash(new(big.Int).SetUint64(batchNumber)).String())
+		require.NoError(t, err)
+	}
+	addBlockWithVirtualBatch := func(blockNumber, batchNumber uint64, blockHash common.Hash) {
+		block := &state.Block{BlockNumber: blockNumber, BlockHash: blockHash, ParentHash: common.HexToHash("0x1"), ReceivedAt: time.Now()}
+		require.NoError(t, testState.AddBlock(ctx, block, dbTx))
+		virtualBatch := state.VirtualBatch{BlockNumber: blockNumber, BatchNumber: batchNumber, TxHash: blockHash}
+		require.NoError(t, testState.AddVirtualBatch(ctx, &virtualBatch, dbTx))
+	}
+
+	// batch 1 virtualized in block 1, batch 2 virtualized and verified in block 2 and batch 3 only trusted
+	for batchNumber := uint64(1); batchNumber <= 3; batchNumber++ {
+		addBatch(batchNumber)
+	}
+	addBlockWithVirtualBatch(1, 1, common.HexToHash("0x11"))
+	addBlockWithVirtualBatch(2, 2, common.HexToHash("0x12"))
+	verifiedBatch := state.VerifiedBatch{BlockNumber: 2, BatchNumber: 2, StateRoot: common.HexToHash("0x2"), TxHash: common.HexToHash("0x22"), IsTrusted: true}
+	require.NoError(t, testState.AddVerifiedBatch(ctx, &verifiedBatch, dbTx))
+
+	_, err = testState.CreateCheckpoint(ctx, "batch1", 1, dbTx)
+	require.NoError(t, err)
+	_, err = testState.RollbackToCheckpoint(ctx, "batch1", dbTx)
+	require.NoError(t, err)
+
+	// the L1 block that virtualized the removed batches is removed, so it's synced again
+	lastBlock, err := testState.GetLastBlock(ctx, dbTx)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(1), lastBlock.BlockNumber)
+	lastVirtualBatchNumber, err := testState.GetLastVirtualBatchNum(ctx, dbTx)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(1), lastVirtualBatchNumber)
+	_, err = testState.GetVerifiedBatch(ctx, 2, dbTx)
+	assert.ErrorIs(t, err, state.ErrNotFound)
+	_, err = testState.GetBatchByNumber(ctx, 3, dbTx)
+	assert.ErrorIs(t, err, state.ErrNotFound)
+
+	// sync again the batch 2 from the L1 block 2
+	addBatch(2)
+	addBlockWithVirtualBatch(2, 2, common.HexToHash("0x12"))
+	require.NoError(t, testState.AddVerifiedBatch(ctx, &verifiedBatch, dbTx))
+	lastVirtualBatchNumber, err = testState.GetLastVirtualBatchNum(ctx, dbTx)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(2), lastVirtualBatchNumber)
+	lastVerifiedBatch, err := testState.GetLastVerifiedBatch(ctx, dbTx)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(2), lastVerifiedBatch.BatchNumber)
+
+	require.NoError(t, dbTx.Commit(ctx))
+}
+
 func TestForkIDs(t *testing.T) {
 	initOrResetDB()
 
@@ -729,7 +790,7 @@ func TestGetLastVerifiedL2BlockNumberUntilL1Block(t *testing.T) {
 			txsL2Hash[i] = common.HexToHash(fmt.Sprintf("0x%d", i))
 		}
 
-		err = testState.AddL2Block(ctx, batchNumber, l2Block, []*types.Receipt{}, txsL2Hash, storeTxsEGPData, []common.Hash{}, dbTx)
+		err = testState.AddL2Block(ctx, batchNumber, l2Block, []*types.Receipt{}, txsL2Hash, storeTxsEGPData, []common.Hash{}, nil, dbTx)
 		require.NoError(t, err)
 
 		virtualBatch := state.VirtualBatch{BlockNumber: blockNumber, BatchNumber: batchNumber, Coinbase: addr, SequencerAddr: addr, TxHash: hash}
@@ -988,7 +1049,7 @@ func TestGetLogs(t *testing.T) {
 			txsL2Hash[i] = common.HexToHash(fmt.Sprintf("0x%d", i))
 		}
 
-		err = testState.AddL2Block(ctx, batchNumber, l2Block, receipts, txsL2Hash, storeTxsEGPData, stateRoots, dbTx)
+		err = testState.AddL2Block(ctx, batchNumber, l2Block, receipts, txsL2Hash, storeTxsEGPData, stateRoots, nil, dbTx)
 		require.NoError(t, err)
 	}
 
@@ -1189,7 +1250,7 @@ func TestGetLogsByBlockNumber(t *testing.T) {
 			txsL2Hash[i] = common.HexToHash(fmt.Sprintf("0x%d", i))
 		}
 
-		err = testState.AddL2Block(ctx, batchNumber, l2Block, receipts, txsL2Hash, storeTxsEGPData, stateRoots, dbTx)
+		err = testState.AddL2Block(ctx, batchNumber, l2Block, receipts, txsL2Hash, storeTxsEGPData, stateRoots, nil, dbTx)
 		require.NoError(t, err)
 	}
 
@@ -1255,6 +1316,140 @@ func TestGetLogsByBlockNumber(t *testing.T) {
 	}
 }
 
+func TestGetLogsByBlockNumberRange(t *testing.T) {
+	initOrResetDB()
+
+	ctx := context.Background()
+
+	cfg := state.Config{
+		MaxLogsCount:      8,
+		MaxLogsBlockRange: 10,
+		ForkIDIntervals:   stateCfg.ForkIDIntervals,
+	}
+	storage := pgstatestorage.NewPostgresStorage(cfg, stateDb)
+
+	dbTx, err := testState.BeginStateTransaction(ctx)
+	require.NoError(t, err)
+	require.NoError(t, testState.AddBlock(ctx, block, dbTx))
+
+	batchNumber := uint64(1)
+	_, err = testState.Exec(ctx, "INSERT INTO state.batch (batch_num, wip) VALUES ($1, FALSE)", batchNumber)
+	require.NoError(t, err)
+
+	addresses := []common.Address{common.HexToAddress("0x1"), common.HexToAddress("0x2")}
+	topics := []common.Hash{common.HexToHash("0xa"), common.HexToHash("0xb")}
+
+	// 3 blocks with 2 txs each, the logs of each tx are emitted by a different
+	// address and each tx has one log of each topic
+	const blocksCount, txsPerBlock = 3, 2
+	blockHashes := make([]common.Hash, 0, blocksCount)
+	txBlockNumbers := map[common.Hash]uint64{}
+	nonce := uint64(0)
+	for b := uint64(1); b <= blocksCount; b++ {
+		transactions := make([]*types.Transaction, 0, txsPerBlock)
+		receipts := make([]*types.Receipt, 0, txsPerBlock)
+		for i := 0; i < txsPerBlock; i++ {
+			nonce++
+			tx := types.NewTx(&types.LegacyTx{Nonce: nonce, Value: new(big.Int), GasPrice: big.NewInt(0)})
+			logs := make([]*types.Log, 0, len(topics))
+			for l, topic := range topics {
+				// the block number is set when the block is stored
+				logs = append(logs, &types.Log{TxHash: tx.Hash(), TxIndex: uint(i), Index: uint(i*len(topics) + l), Address: addresses[i], Topics: []common.Hash{topic}})
+			}
+			transactions = append(transactions, tx)
+			receipts = append(receipts, &types.Receipt{
+				Type:              tx.Type(),
+				PostState:         state.ZeroHash.Bytes(),
+				EffectiveGasPrice: big.NewInt(0),
+				BlockNumber:       big.NewInt(0).SetUint64(b),
+				TxHash:            tx.Hash(),
+				TransactionIndex:  uint(i),
+				Status:            types.ReceiptStatusSuccessful,
+				Logs:              logs,
+			})
+			txBlockNumbers[tx.Hash()] = b
+		}
+
+		header := state.NewL2Header(&types.Header{Number: big.NewInt(0).SetUint64(b), Time: uint64(time.Now().Unix())})
+		l2Block := state.NewL2Block(header, transactions, []*state.L2Header{}, receipts, trie.NewStackTrie(nil))
+		blockHashes = append(blockHashes, l2Block.Hash())
+
+		storeTxsEGPData := make([]state.StoreTxEGPData, 0, len(transactions))
+		txsL2Hash := make([]common.Hash, 0, len(transactions))
+		stateRoots := make([]common.Hash, 0, len(transactions))
+		for _, tx := range transactions {
+			storeTxsEGPData = append(storeTxsEGPData, state.StoreTxEGPData{EffectivePercentage: state.MaxEffectivePercentage})
+			txsL2Hash = append(txsL2Hash, tx.Hash())
+			stateRoots = append(stateRoots, state.ZeroHash)
+		}
+		require.NoError(t, testState.AddL2Block(ctx, batchNumber, l2Block, receipts, txsL2Hash, storeTxsEGPData, stateRoots, nil, dbTx))
+	}
+	require.NoError(t, dbTx.Commit(ctx))
+
+	// the logs are stored with the number of the block of their tx
+	for txHash, blockNumber := range txBlockNumbers {
+		var logBlockNumbers []uint64
+		rows, err := stateDb.Query(ctx, "SELECT block_num FROM state.log WHERE tx_hash = $1", txHash.String())
+		require.NoError(t, err)
+		for rows.Next() {
+			var logBlockNumber uint64
+			require.NoError(t, rows.Scan(&logBlockNumber))
+			logBlockNumbers = append(logBlockNumbers, logBlockNumber)
+		}
+		require.NoError(t, rows.Err())
+		assert.Equal(t, []uint64{blockNumber, blockNumber}, logBlockNumbers)
+	}
+
+	testCases := []struct {
+		name          string
+		from          uint64
+		to            uint64
+		addresses     []common.Address
+		topics        [][]common.Hash
+		blockHash     *common.Hash
+		expectedCount int
+		expectedError error
+	}{
+		{name: "logs up to the limit", from: 1, to: 2, expectedCount: 8},
+		{name: "logs over the limit", from: 1, to: 3, expectedError: state.ErrMaxLogsCountLimitExceeded},
+		{name: "logs of an address", from: 1, to: 3, addresses: addresses[:1], expectedCount: 6},
+		{name: "logs of a topic", from: 2, to: 3, topics: [][]common.Hash{topics[1:]}, expectedCount: 4},
+		{name: "logs of an address and a topic", from: 1, to: 3, addresses: addresses[1:], topics: [][]common.Hash{topics[:1]}, expectedCount: 3},
+		{name: "logs of a block", blockHash: &blockHashes[1], expectedCount: 4},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			logs, err := storage.GetLogs(ctx, testCase.from, testCase.to, testCase.addresses, testCase.topics, testCase.blockHash, nil, nil)
+			if testCase.expectedError != nil {
+				assert.ErrorIs(t, err, testCase.expectedError)
+				return
+			}
+			require.NoError(t, err)
+			require.Len(t, logs, testCase.expectedCount)
+
+			lastBlockNumber := uint64(0)
+			for _, l := range logs {
+				assert.Equal(t, txBlockNumbers[l.TxHash], l.BlockNumber)
+				assert.Equal(t, blockHashes[l.BlockNumber-1], l.BlockHash)
+				assert.GreaterOrEqual(t, l.BlockNumber, lastBlockNumber)
+				lastBlockNumber = l.BlockNumber
+				if testCase.blockHash != nil {
+					assert.Equal(t, *testCase.blockHash, l.BlockHash)
+				} else {
+					assert.True(t, l.BlockNumber >= testCase.from && l.BlockNumber <= testCase.to)
+				}
+				if len(testCase.addresses) > 0 {
+					assert.Contains(t, testCase.addresses, l.Address)
+				}
+				if len(testCase.topics) > 0 {
+					assert.Contains(t, testCase.topics[0], l.Topics[0])
+				}
+			}
+		})
+	}
+}
+
 func TestGetNativeBlockHashesInRange(t *testing.T) {
 	initOrResetDB()
 
@@ -1337,7 +1532,7 @@ func TestGetNativeBlockHashesInRange(t *testing.T) {
 			txsL2Hash[i] = common.HexToHash(fmt.Sprintf("0x%d", i))
 		}
 
-		err = testState.AddL2Block(ctx, batchNumber, l2Block, receipts, txsL2Hash, storeTxsEGPData, stateRoots, dbTx)
+		err = testState.AddL2Block(ctx, batchNumber, l2Block, receipts, txsL2Hash, storeTxsEGPData, stateRoots, nil, dbTx)
 		require.NoError(t, err)
 
 		nativeBlockHashes = append(nativeBlockHashes, l2Block.Header().Root)
@@ -1666,3 +1861,132 @@ func TestGetLastGER(t *testing.T) {
 	require.Equal(t, common.HexToHash("0x2").String(), ger.String())
 
 }
+
+func TestGetDSL2TransactionsAfterPruning(t *testing.T) {
+	initOrResetDB()
+	ctx := context.Background()
+
+	dbTx, err := testState.BeginStateTransaction(ctx)
+	require.NoError(t, err)
+	defer func() { require.NoError(t, dbTx.Commit(ctx)) }()
+
+	require.NoError(t, testState.AddBlock(ctx, block, dbTx))
+	batchNumber := uint64(1)
+	_, err = dbTx.Exec(ctx, "INSERT INTO state.batch (batch_num, wip) VALUES ($1, FALSE)", batchNumber)
+	require.NoError(t, err)
+
+	txs := []*types.Transaction{
+		types.NewTransaction(1, common.HexToAddress("0x1"), big.NewInt(0), 21000, big.NewInt(1), nil),
+		types.NewTransaction(2, common.HexToAddress("0x1"), big.NewInt(0), 21000, big.NewInt(1), nil),
+	}
+	receipts := make([]*types.Receipt, 0, len(txs))
+	imStateRoots := make([]common.Hash, 0, len(txs))
+	storeTxsEGPData := make([]state.StoreTxEGPData, 0, len(txs))
+	txsL2Hash := make([]common.Hash, 0, len(txs))
+	for i, tx := range txs {
+		receipts = append(receipts, &types.Receipt{
+			Type:              tx.Type(),
+			PostState:         common.HexToHash(fmt.Sprintf("0x%d1", i)).Bytes(),
+			Status:            types.ReceiptStatusSuccessful,
+			EffectiveGasPrice: big.NewInt(1),
+			BlockNumber:       big.NewInt(1),
+			TxHash:            tx.Hash(),
+			TransactionIndex:  uint(i),
+		})
+		imStateRoots = append(imStateRoots, common.HexToHash(fmt.Sprintf("0x%d2", i)))
+		storeTxsEGPData = append(storeTxsEGPData, state.StoreTxEGPData{EffectivePercentage: state.MaxEffectivePercentage})
+		txsL2Hash = append(txsL2Hash, tx.Hash())
+	}
+	header := state.NewL2Header(&types.Header{Number: big.NewInt(1), Time: uint64(time.Now().Unix())})
+	l2Block := state.NewL2Block(header, txs, []*state.L2Header{}, receipts, trie.NewStackTrie(nil))
+	require.NoError(t, testState.AddL2Block(ctx, batchNumber, l2Block, receipts, txsL2Hash, storeTxsEGPData, imStateRoots, nil, dbTx))
+
+	expected, err := testState.GetDSL2Transactions(ctx, 1, 1, dbTx)
+	require.NoError(t, err)
+	require.Len(t, expected, len(txs))
+
+	prunedReceipts, err := testState.DeleteReceiptsAndLogsUpToBatchNumber(ctx, batchNumber, dbTx)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(len(txs)), prunedReceipts)
+	_, err = testState.GetTransactionReceipt(ctx, txs[0].Hash(), dbTx)
+	require.ErrorIs(t, err, state.ErrNotFound)
+
+	// the data stream is regenerated with the txs of the pruned blocks and their state roots
+	l2Txs, err := testState.GetDSL2Transactions(ctx, 1, 1, dbTx)
+	require.NoError(t, err)
+	assert.Equal(t, expected, l2Txs)
+	for i, l2Tx := range l2Txs {
+		assert.Equal(t, common.BytesToHash(receipts[i].PostState), l2Tx.StateRoot)
+		assert.Equal(t, imStateRoots[i], l2Tx.ImStateRoot)
+	}
+
+	// pruning again doesn't fail for the already pruned receipts
+	prunedReceipts, err = testState.DeleteReceiptsAndLogsUpToBatchNumber(ctx, batchNumber, dbTx)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(0), prunedReceipts)
+}
+
+func TestGetTxsByBlockAfterPruning(t *testing.T) {
+	initOrResetDB()
+	ctx := context.Background()
+
+	dbTx, err := testState.BeginStateTransaction(ctx)
+	require.NoError(t, err)
+	defer func() { require.NoError(t, dbTx.Commit(ctx)) }()
+
+	require.NoError(t, testState.AddBlock(ctx, block, dbTx))
+	batchNumber := uint64(1)
+	_, err = dbTx.Exec(ctx, "INSERT INTO state.batch (batch_num, wip) VALUES ($1, FALSE)", batchNumber)
+	require.NoError(t, err)
+
+	txs := []*types.Transaction{
+		types.NewTransaction(1, common.HexToAddress("0x1"), big.NewInt(0), 21000, big.NewInt(1), nil),
+		types.NewTransaction(2, common.HexToAddress("0x1"), big.NewInt(0), 21000, big.NewInt(1), nil),
+	}
+	receipts := make([]*types.Receipt, 0, len(txs))
+	imStateRoots := make([]common.Hash, 0, len(txs))
+	storeTxsEGPData := make([]state.StoreTxEGPData, 0, len(txs))
+	txsL2Hash := make([]common.Hash, 0, len(txs))
+	for i, tx := range txs {
+		receipts = append(receipts, &types.Receipt{
+			Type:              tx.Type(),
+			PostState:         common.HexToHash(fmt.Sprintf("0x%d1", i)).Bytes(),
+			Status:            types.ReceiptStatusSuccessful,
+			EffectiveGasPrice: big.NewInt(1),
+			BlockNumber:       big.NewInt(1),
+			TxHash:            tx.Hash(),
+			TransactionIndex:  uint(i),
+		})
+		imStateRoots = append(imStateRoots, common.HexToHash(fmt.Sprintf("0x%d2", i)))
+		storeTxsEGPData = append(storeTxsEGPData, state.StoreTxEGPData{EffectivePercentage: state.MaxEffectivePercentage})
+		txsL2Hash = append(txsL2Hash, tx.Hash())
+	}
+	header := state.NewL2Header(&types.Header{Number: big.NewInt(1), Time: uint64(time.Now().Unix())})
+	l2Block := state.NewL2Block(header, txs, []*state.L2Header{}, receipts, trie.NewStackTrie(nil))
+	require.NoError(t, testState.AddL2Block(ctx, batchNumber, l2Block, receipts, txsL2Hash, storeTxsEGPData, imStateRoots, nil, dbTx))
+
+	prunedReceipts, err := testState.DeleteReceiptsAndLogsUpToBatchNumber(ctx, batchNumber, dbTx)
+	require.NoError(t, err)
+	require.Equal(t, uint64(len(txs)), prunedReceipts)
+
+	// the txs of the pruned blocks are still found by block and index, using the tx index of the pruned receipts
+	blockTxs, err := testState.GetTxsByBlockNumber(ctx, l2Block.NumberU64(), dbTx)
+	require.NoError(t, err)
+	require.Len(t, blockTxs, len(txs))
+	for i, tx := range txs {
+		assert.Equal(t, tx.Hash(), blockTxs[i].Hash())
+
+		byNumber, err := testState.GetTransactionByL2BlockNumberAndIndex(ctx, l2Block.NumberU64(), uint64(i), dbTx)
+		require.NoError(t, err)
+		assert.Equal(t, tx.Hash(), byNumber.Hash())
+
+		byHash, err := testState.GetTransactionByL2BlockHashAndIndex(ctx, l2Block.Hash(), uint64(i), dbTx)
+		require.NoError(t, err)
+		assert.Equal(t, tx.Hash(), byHash.Hash())
+	}
+
+	_, err = testState.GetTransactionByL2BlockNumberAndIndex(ctx, l2Block.NumberU64(), uint64(len(txs)), dbTx)
+	assert.ErrorIs(t, err, state.ErrNotFound)
+	_, err = testState.GetTransactionByL2BlockHashAndIndex(ctx, l2Block.Hash(), uint64(len(txs)), dbTx)
+	assert.ErrorIs(t, err, state.ErrNotFound)
+}
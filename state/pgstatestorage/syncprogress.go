@@ -0,0 +1,160 @@
+package pgstatestorage
+
+import (
+	"context"
+	"errors"
+
+	"github.com/0xPolygonHermez/zkevm-node/state"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/jackc/pgx/v4"
+)
+
+// AddSyncProgress stores the progress of the synchronizer after processing an L1 block
+func (p *PostgresStorage) AddSyncProgress(ctx context.Context, progress state.SyncProgress, dbTx pgx.Tx) error {
+	const addSyncProgressSQL = `
+		INSERT INTO state.sync_progress (block_num, block_hash, virtual_batch_num, events_hash, created_at) VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (block_num) DO UPDATE SET
+			block_hash = EXCLUDED.block_hash, virtual_batch_num = EXCLUDED.virtual_batch_num,
+			events_hash = EXCLUDED.events_hash, created_at = EXCLUDED.created_at`
+
+	e := p.getExecQuerier(dbTx)
+	_, err := e.Exec(ctx, addSyncProgressSQL, progress.BlockNumber, progress.BlockHash.String(),
+		progress.LastVirtualBatchNumber, progress.EventsHash.String(), progress.CreatedAt)
+	return err
+}
+
+// GetLastSyncProgress returns the progress of the synchronizer for the last L1 block it
+// was stored for, it returns state.ErrNotFound if there is no progress stored
+func (p *PostgresStorage) GetLastSyncProgress(ctx context.Context, dbTx pgx.Tx) (*state.SyncProgress, error) {
+	const getLastSyncProgressSQL = `
+		SELECT block_num, block_hash, virtual_batch_num, events_hash, created_at
+		  FROM state.sync_progress
+		 ORDER BY block_num DESC
+		 LIMIT 1`
+
+	var (
+		progress   state.SyncProgress
+		blockHash  string
+		eventsHash string
+	)
+	e := p.getExecQuerier(dbTx)
+	err := e.QueryRow(ctx, getLastSyncProgressSQL).
+		Scan(&progress.BlockNumber, &blockHash, &progress.LastVirtualBatchNumber, &eventsHash, &progress.CreatedAt)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, state.ErrNotFound
+	} else if err != nil {
+		return nil, err
+	}
+	progress.BlockHash = common.HexToHash(blockHash)
+	progress.EventsHash = common.HexToHash(eventsHash)
+	return &progress, nil
+}
+
+// GetLastSyncProgresses returns the progresses of the synchronizer for the last L1 blocks they
+// were stored for, up to the provided limit, from the newest to the oldest one
+func (p *PostgresStorage) GetLastSyncProgresses(ctx context.Context, limit uint64, dbTx pgx.Tx) ([]state.SyncProgress, error) {
+	const getLastSyncProgressesSQL = `
+		SELECT block_num, block_hash, virtual_batch_num, events_hash, created_at
+		  FROM state.sync_progress
+		 ORDER BY block_num DESC
+		 LIMIT $1`
+
+	e := p.getExecQuerier(dbTx)
+	rows, err := e.Query(ctx, getLastSyncProgressesSQL, limit)
+	if err != nil {
+		return nil, err
+	}
+	return scanSyncProgresses(rows)
+}
+
+func scanSyncProgresses(rows pgx.Rows) ([]state.SyncProgress, error) {
+	defer rows.Close()
+
+	progresses := make([]state.SyncProgress, 0)
+	for rows.Next() {
+		var (
+			progress   state.SyncProgress
+			blockHash  string
+			eventsHash string
+		)
+		if err := rows.Scan(&progress.BlockNumber, &blockHash, &progress.LastVirtualBatchNumber, &eventsHash, &progress.CreatedAt); err != nil {
+			return nil, err
+		}
+		progress.BlockHash = common.HexToHash(blockHash)
+		progress.EventsHash = common.HexToHash(eventsHash)
+		progresses = append(progresses, progress)
+	}
+	return progresses, rows.Err()
+}
+
+// GetSyncProgressesFromBlockNumber returns the progresses of the synchronizer for the L1 blocks from the
+// provided one, preceded by the previous progress the first of them is chained to, if any, from the
+// oldest to the newest one
+func (p *PostgresStorage) GetSyncProgressesFromBlockNumber(ctx context.Context, blockNumber uint64, dbTx pgx.Tx) ([]state.SyncProgress, error) {
+	const getSyncProgressesFromBlockNumberSQL = `
+		SELECT block_num, block_hash, virtual_batch_num, events_hash, created_at
+		  FROM state.sync_progress
+		 WHERE block_num >= COALESCE((SELECT MAX(block_num) FROM state.sync_progress WHERE block_num < $1), $1)
+		 ORDER BY block_num ASC`
+
+	e := p.getExecQuerier(dbTx)
+	rows, err := e.Query(ctx, getSyncProgressesFromBlockNumberSQL, blockNumber)
+	if err != nil {
+		return nil, err
+	}
+	return scanSyncProgresses(rows)
+}
+
+// GetSyncProgressEvents returns the events stored in the state by the synchronizer for the L1
+// block, with the data each one stored, ordered by event and by the batch, forced batch, exit
+// root or fork ID they stored. The data that is updated after the event is processed, like
+// the last batch of a fork ID, is not included
+func (p *PostgresStorage) GetSyncProgressEvents(ctx context.Context, blockNumber uint64, dbTx pgx.Tx) ([]state.SyncProgressEvent, error) {
+	const getSyncProgressEventsSQL = `
+		SELECT name, data FROM (
+			SELECT 'virtualBatch' AS name, v.batch_num AS pos,
+			       concat_ws(',', v.batch_num, v.tx_hash, v.coinbase, v.sequencer_addr, v.l1_info_root,
+			                 EXTRACT(EPOCH FROM v.timestamp_batch_etrog)::BIGINT, encode(b.raw_txs_data, 'hex')) AS data
+			  FROM state.virtual_batch v
+			  JOIN state.batch b ON b.batch_num = v.batch_num
+			 WHERE v.block_num = $1
+			UNION ALL
+			SELECT 'verifiedBatch', batch_num, concat_ws(',', batch_num, tx_hash, aggregator, state_root)
+			  FROM state.verified_batch
+			 WHERE block_num = $1
+			UNION ALL
+			SELECT 'forcedBatch', forced_batch_num,
+			       concat_ws(',', forced_batch_num, global_exit_root, EXTRACT(EPOCH FROM timestamp)::BIGINT, raw_txs_data, coinbase)
+			  FROM state.forced_batch
+			 WHERE block_num = $1
+			UNION ALL
+			SELECT 'exitRoot', id,
+			       concat_ws(',', EXTRACT(EPOCH FROM timestamp)::BIGINT, encode(mainnet_exit_root, 'hex'), encode(rollup_exit_root, 'hex'),
+			                 encode(global_exit_root, 'hex'), encode(prev_block_hash, 'hex'), encode(l1_info_root, 'hex'),
+			                 l1_info_tree_index, l1_info_tree_recursive_index)
+			  FROM state.exit_root
+			 WHERE block_num = $1
+			UNION ALL
+			SELECT 'forkID', fork_id, concat_ws(',', fork_id, from_batch_num, version)
+			  FROM state.fork_id
+			 WHERE block_num = $1
+		) events
+		ORDER BY name, pos`
+
+	e := p.getExecQuerier(dbTx)
+	rows, err := e.Query(ctx, getSyncProgressEventsSQL, blockNumber)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	events := make([]state.SyncProgressEvent, 0)
+	for rows.Next() {
+		var event state.SyncProgressEvent
+		if err := rows.Scan(&event.Name, &event.Data); err != nil {
+			return nil, err
+		}
+		events = append(events, event)
+	}
+	return events, rows.Err()
+}
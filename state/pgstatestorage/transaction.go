@@ -253,6 +253,7 @@ func (p *PostgresStorage) GetTransactionReceipt(ctx context.Context, transaction
 	var txHash, encodedTx, contractAddress, l2BlockHash string
 	var l2BlockNum uint64
 	var effective_gas_price *uint64
+	var logsBloom []byte
 
 	const getReceiptSQL = `
 		SELECT 
@@ -265,6 +266,7 @@ func (p *PostgresStorage) GetTransactionReceipt(ctx context.Context, transaction
 			r.gas_used,
 			r.contract_address,
 			r.effective_gas_price,
+			r.logs_bloom,
 			t.encoded,
 			t.l2_block_num,
 			b.block_hash
@@ -287,6 +289,7 @@ func (p *PostgresStorage) GetTransactionReceipt(ctx context.Context, transaction
 			&receipt.GasUsed,
 			&contractAddress,
 			&effective_gas_price,
+			&logsBloom,
 			&encodedTx,
 			&l2BlockNum,
 			&l2BlockHash,
@@ -312,13 +315,146 @@ func (p *PostgresStorage) GetTransactionReceipt(ctx context.Context, transaction
 		receipt.EffectiveGasPrice = big.NewInt(0).SetUint64(*effective_gas_price)
 	}
 	receipt.Logs = logs
-	receipt.Bloom = types.CreateBloom(types.Receipts{&receipt})
+	// the receipts stored before the bloom was persisted compute it from their logs
+	if logsBloom != nil {
+		receipt.Bloom = types.BytesToBloom(logsBloom)
+	} else {
+		receipt.Bloom = types.CreateBloom(types.Receipts{&receipt})
+	}
 
 	return &receipt, nil
 }
 
-// GetTransactionByL2BlockHashAndIndex gets a transaction accordingly to the block hash and transaction index provided.
-// since we only have a single transaction per l2 block, any index different from 0 will return a not found result
+// GetTransactionRevertData gets the data returned by the reverted transaction with the
+// provided hash, it's nil for the transactions that were not reverted
+func (p *PostgresStorage) GetTransactionRevertData(ctx context.Context, transactionHash common.Hash, dbTx pgx.Tx) ([]byte, error) {
+	const getRevertDataSQL = "SELECT revert_data FROM state.receipt WHERE tx_hash = $1"
+
+	var revertData []byte
+	q := p.getExecQuerier(dbTx)
+	err := q.QueryRow(ctx, getRevertDataSQL, transactionHash.String()).Scan(&revertData)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, state.ErrNotFound
+	} else if err != nil {
+		return nil, err
+	}
+
+	return revertData, nil
+}
+
+// GetL2BlockReceipts gets the receipts of all the txs of the L2 block with the provided number,
+// sorted by tx index. The receipts and their logs are loaded with a single query each
+func (p *PostgresStorage) GetL2BlockReceipts(ctx context.Context, blockNumber uint64, dbTx pgx.Tx) ([]*types.Receipt, error) {
+	const getReceiptsSQL = `
+		SELECT 
+			r.tx_index,
+			r.tx_hash,
+		    r.type,
+			r.post_state,
+			r.status,
+			r.cumulative_gas_used,
+			r.gas_used,
+			r.contract_address,
+			r.effective_gas_price,
+			r.logs_bloom,
+			b.block_hash
+	      FROM state.receipt r
+		 INNER JOIN state.l2block b
+		    ON b.block_num = r.block_num
+		 WHERE r.block_num = $1
+		 ORDER BY r.tx_index ASC`
+
+	q := p.getExecQuerier(dbTx)
+	rows, err := q.Query(ctx, getReceiptsSQL, blockNumber)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	receipts := []*types.Receipt{}
+	logsBlooms := [][]byte{}
+	for rows.Next() {
+		var txHash, contractAddress, l2BlockHash string
+		var effective_gas_price *uint64
+		var logsBloom []byte
+		receipt := types.Receipt{}
+		err := rows.Scan(&receipt.TransactionIndex,
+			&txHash,
+			&receipt.Type,
+			&receipt.PostState,
+			&receipt.Status,
+			&receipt.CumulativeGasUsed,
+			&receipt.GasUsed,
+			&contractAddress,
+			&effective_gas_price,
+			&logsBloom,
+			&l2BlockHash,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		receipt.TxHash = common.HexToHash(txHash)
+		receipt.ContractAddress = common.HexToAddress(contractAddress)
+		receipt.BlockNumber = big.NewInt(0).SetUint64(blockNumber)
+		receipt.BlockHash = common.HexToHash(l2BlockHash)
+		if effective_gas_price != nil {
+			receipt.EffectiveGasPrice = big.NewInt(0).SetUint64(*effective_gas_price)
+		}
+		receipt.Logs = []*types.Log{}
+		receipts = append(receipts, &receipt)
+		logsBlooms = append(logsBlooms, logsBloom)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	if len(receipts) == 0 {
+		return receipts, nil
+	}
+
+	const getBlockLogsSQL = `
+	SELECT b.block_num, b.block_hash, l.tx_hash, r.tx_index, l.log_index, l.address, l.data, l.topic0, l.topic1, l.topic2, l.topic3
+	FROM state.log l
+	INNER JOIN state.receipt r ON r.tx_hash = l.tx_hash
+	INNER JOIN state.l2block b ON b.block_num = r.block_num
+	WHERE l.block_num = $1
+	ORDER BY l.log_index ASC`
+	logRows, err := q.Query(ctx, getBlockLogsSQL, blockNumber)
+	if err != nil {
+		return nil, err
+	}
+	logs, err := scanLogs(logRows)
+	if err != nil {
+		return nil, err
+	}
+
+	receiptsByTxHash := make(map[common.Hash]*types.Receipt, len(receipts))
+	for _, receipt := range receipts {
+		receiptsByTxHash[receipt.TxHash] = receipt
+	}
+	for _, log := range logs {
+		if receipt, found := receiptsByTxHash[log.TxHash]; found {
+			receipt.Logs = append(receipt.Logs, log)
+		}
+	}
+
+	// the receipts stored before the bloom was persisted compute it from their logs
+	for i, receipt := range receipts {
+		if logsBlooms[i] != nil {
+			receipt.Bloom = types.BytesToBloom(logsBlooms[i])
+		} else {
+			receipt.Bloom = types.CreateBloom(types.Receipts{receipt})
+		}
+	}
+
+	return receipts, nil
+}
+
+// txIndexesSQL is the tx index of the txs, taken from the receipts or, for the txs of the pruned
+// blocks, from the pruned receipts, so the txs are still found by index once their receipts are pruned
+const txIndexesSQL = `(SELECT tx_hash, tx_index FROM state.receipt UNION ALL SELECT tx_hash, tx_index FROM state.pruned_receipt)`
+
+// GetTransactionByL2BlockHashAndIndex gets a transaction accordingly to the block hash and transaction index provided
 func (p *PostgresStorage) GetTransactionByL2BlockHashAndIndex(ctx context.Context, blockHash common.Hash, index uint64, dbTx pgx.Tx) (*types.Transaction, error) {
 	var encoded string
 	q := p.getExecQuerier(dbTx)
@@ -327,7 +463,7 @@ func (p *PostgresStorage) GetTransactionByL2BlockHashAndIndex(ctx context.Contex
           FROM state.transaction t
          INNER JOIN state.l2block b
             ON t.l2_block_num = b.block_num
-         INNER JOIN state.receipt r
+         INNER JOIN ` + txIndexesSQL + ` r
             ON r.tx_hash = t.hash
          WHERE b.block_hash = $1
            AND r.tx_index = $2`
@@ -346,11 +482,16 @@ func (p *PostgresStorage) GetTransactionByL2BlockHashAndIndex(ctx context.Contex
 	return tx, nil
 }
 
-// GetTransactionByL2BlockNumberAndIndex gets a transaction accordingly to the block number and transaction index provided.
-// since we only have a single transaction per l2 block, any index different from 0 will return a not found result
+// GetTransactionByL2BlockNumberAndIndex gets a transaction accordingly to the block number and transaction index provided
 func (p *PostgresStorage) GetTransactionByL2BlockNumberAndIndex(ctx context.Context, blockNumber uint64, index uint64, dbTx pgx.Tx) (*types.Transaction, error) {
 	var encoded string
-	const getTransactionByL2BlockNumberAndIndexSQL = "SELECT t.encoded FROM state.transaction t WHERE t.l2_block_num = $1 AND 0 = $2"
+	const getTransactionByL2BlockNumberAndIndexSQL = `
+        SELECT t.encoded
+          FROM state.transaction t
+         INNER JOIN ` + txIndexesSQL + ` r
+            ON r.tx_hash = t.hash
+         WHERE t.l2_block_num = $1
+           AND r.tx_index = $2`
 
 	q := p.getExecQuerier(dbTx)
 	err := q.QueryRow(ctx, getTransactionByL2BlockNumberAndIndexSQL, blockNumber, index).Scan(&encoded)
@@ -377,7 +518,7 @@ func (p *PostgresStorage) getTransactionLogs(ctx context.Context, transactionHas
 	FROM state.log l
 	INNER JOIN state.transaction t ON t.hash = l.tx_hash
 	INNER JOIN state.l2block b ON b.block_num = t.l2_block_num 
-	INNER JOIN state.receipt r ON r.tx_hash = t.hash
+	INNER JOIN ` + txIndexesSQL + ` r ON r.tx_hash = t.hash
 	WHERE t.hash = $1
 	ORDER BY l.log_index ASC`
 	rows, err := q.Query(ctx, getTransactionLogsSQL, transactionHash.String())
@@ -448,10 +589,9 @@ func scanLogs(rows pgx.Rows) ([]*types.Log, error) {
 func (p *PostgresStorage) GetTxsByBlockNumber(ctx context.Context, blockNumber uint64, dbTx pgx.Tx) ([]*types.Transaction, error) {
 	const getTxsByBlockNumSQL = `SELECT t.encoded 
 	   FROM state.transaction t
-	   JOIN state.receipt r
+	   JOIN ` + txIndexesSQL + ` r
 	     ON t.hash = r.tx_hash
 	  WHERE t.l2_block_num = $1
-	    AND r.block_num = $1
 	  ORDER by r.tx_index ASC`
 
 	q := p.getExecQuerier(dbTx)
@@ -532,14 +672,16 @@ func (p *PostgresStorage) AddReceipt(ctx context.Context, receipt *types.Receipt
 	}
 
 	const addReceiptSQL = `
-        INSERT INTO state.receipt (tx_hash, type, post_state, status, cumulative_gas_used, gas_used, effective_gas_price, block_num, tx_index, contract_address, im_state_root)
-                           VALUES (     $1,   $2,         $3,     $4,                  $5,       $6,        		  $7,        $8,       $9,			    $10,           $11)`
-	_, err := e.Exec(ctx, addReceiptSQL, receipt.TxHash.String(), receipt.Type, receipt.PostState, receipt.Status, receipt.CumulativeGasUsed, receipt.GasUsed, effectiveGasPrice, receipt.BlockNumber.Uint64(), receipt.TransactionIndex, receipt.ContractAddress.String(), imStateRoot.Bytes())
+        INSERT INTO state.receipt (tx_hash, type, post_state, status, cumulative_gas_used, gas_used, effective_gas_price, block_num, tx_index, contract_address, im_state_root, logs_bloom)
+                           VALUES (     $1,   $2,         $3,     $4,                  $5,       $6,        		  $7,        $8,       $9,			    $10,           $11,        $12)`
+	logsBloom := types.CreateBloom(types.Receipts{receipt})
+	_, err := e.Exec(ctx, addReceiptSQL, receipt.TxHash.String(), receipt.Type, receipt.PostState, receipt.Status, receipt.CumulativeGasUsed, receipt.GasUsed, effectiveGasPrice, receipt.BlockNumber.Uint64(), receipt.TransactionIndex, receipt.ContractAddress.String(), imStateRoot.Bytes(), logsBloom.Bytes())
 	return err
 }
 
-// AddReceipts adds a list of receipts to the State Store
-func (p *PostgresStorage) AddReceipts(ctx context.Context, receipts []*types.Receipt, imStateRoots []common.Hash, dbTx pgx.Tx) error {
+// AddReceipts adds a list of receipts to the State Store, the revert data of
+// each receipt is optional and stored when provided
+func (p *PostgresStorage) AddReceipts(ctx context.Context, receipts []*types.Receipt, imStateRoots []common.Hash, revertData [][]byte, dbTx pgx.Tx) error {
 	if len(receipts) == 0 {
 		return nil
 	}
@@ -551,12 +693,17 @@ func (p *PostgresStorage) AddReceipts(ctx context.Context, receipts []*types.Rec
 		if receipt.EffectiveGasPrice != nil {
 			egp = receipt.EffectiveGasPrice.Uint64()
 		}
-		receiptRow := []interface{}{receipt.TxHash.String(), receipt.Type, receipt.PostState, receipt.Status, receipt.CumulativeGasUsed, receipt.GasUsed, egp, receipt.BlockNumber.Uint64(), receipt.TransactionIndex, receipt.ContractAddress.String(), imStateRoots[i].Bytes()}
+		var receiptRevertData []byte
+		if i < len(revertData) {
+			receiptRevertData = revertData[i]
+		}
+		logsBloom := types.CreateBloom(types.Receipts{receipt})
+		receiptRow := []interface{}{receipt.TxHash.String(), receipt.Type, receipt.PostState, receipt.Status, receipt.CumulativeGasUsed, receipt.GasUsed, egp, receipt.BlockNumber.Uint64(), receipt.TransactionIndex, receipt.ContractAddress.String(), imStateRoots[i].Bytes(), logsBloom.Bytes(), receiptRevertData}
 		receiptRows = append(receiptRows, receiptRow)
 	}
 
 	_, err := dbTx.CopyFrom(ctx, pgx.Identifier{"state", "receipt"},
-		[]string{"tx_hash", "type", "post_state", "status", "cumulative_gas_used", "gas_used", "effective_gas_price", "block_num", "tx_index", "contract_address", "im_state_root"},
+		[]string{"tx_hash", "type", "post_state", "status", "cumulative_gas_used", "gas_used", "effective_gas_price", "block_num", "tx_index", "contract_address", "im_state_root", "logs_bloom", "revert_data"},
 		pgx.CopyFromRows(receiptRows))
 
 	return err
@@ -564,8 +711,8 @@ func (p *PostgresStorage) AddReceipts(ctx context.Context, receipts []*types.Rec
 
 // AddLog adds a new log to the State Store
 func (p *PostgresStorage) AddLog(ctx context.Context, l *types.Log, dbTx pgx.Tx) error {
-	const addLogSQL = `INSERT INTO state.log (tx_hash, log_index, address, data, topic0, topic1, topic2, topic3)
-	                                  VALUES (     $1,        $2,      $3,   $4,     $5,     $6,     $7,     $8)`
+	const addLogSQL = `INSERT INTO state.log (tx_hash, log_index, address, data, topic0, topic1, topic2, topic3, block_num)
+	                                  VALUES (     $1,        $2,      $3,   $4,     $5,     $6,     $7,     $8,        $9)`
 
 	var topicsAsHex [maxTopics]*string
 	for i := 0; i < len(l.Topics); i++ {
@@ -576,7 +723,7 @@ func (p *PostgresStorage) AddLog(ctx context.Context, l *types.Log, dbTx pgx.Tx)
 	e := p.getExecQuerier(dbTx)
 	_, err := e.Exec(ctx, addLogSQL,
 		l.TxHash.String(), l.Index, l.Address.String(), hex.EncodeToHex(l.Data),
-		topicsAsHex[0], topicsAsHex[1], topicsAsHex[2], topicsAsHex[3])
+		topicsAsHex[0], topicsAsHex[1], topicsAsHex[2], topicsAsHex[3], l.BlockNumber)
 	return err
 }
 
@@ -594,12 +741,12 @@ func (p *PostgresStorage) AddLogs(ctx context.Context, logs []*types.Log, dbTx p
 			topicHex := log.Topics[i].String()
 			topicsAsHex[i] = &topicHex
 		}
-		logRow := []interface{}{log.TxHash.String(), log.Index, log.Address.String(), hex.EncodeToHex(log.Data), topicsAsHex[0], topicsAsHex[1], topicsAsHex[2], topicsAsHex[3]}
+		logRow := []interface{}{log.TxHash.String(), log.Index, log.Address.String(), hex.EncodeToHex(log.Data), topicsAsHex[0], topicsAsHex[1], topicsAsHex[2], topicsAsHex[3], log.BlockNumber}
 		logsRows = append(logsRows, logRow)
 	}
 
 	_, err := dbTx.CopyFrom(ctx, pgx.Identifier{"state", "log"},
-		[]string{"tx_hash", "log_index", "address", "data", "topic0", "topic1", "topic2", "topic3"},
+		[]string{"tx_hash", "log_index", "address", "data", "topic0", "topic1", "topic2", "topic3", "block_num"},
 		pgx.CopyFromRows(logsRows))
 
 	return err
@@ -651,3 +798,35 @@ func (p *PostgresStorage) GetL2TxHashByTxHash(ctx context.Context, hash common.H
 	l2Hash := common.HexToHash(*l2HashHex)
 	return &l2Hash, nil
 }
+
+// DeleteReceiptsAndLogsUpToBatchNumber deletes the receipts and the logs of the txs of the L2 blocks of the batches
+// up to the provided batch number (inclusive), keeping the tx index and the state roots of the receipts needed to
+// regenerate the data stream. It returns the number of deleted receipts
+func (p *PostgresStorage) DeleteReceiptsAndLogsUpToBatchNumber(ctx context.Context, batchNumber uint64, dbTx pgx.Tx) (uint64, error) {
+	const deleteLogsSQL = `
+		DELETE FROM state.log l USING state.l2block b
+		 WHERE l.block_num = b.block_num AND b.batch_num <= $1`
+	// the data stream needs the tx index and the state roots of the receipts to be regenerated
+	const keepPrunedReceiptsSQL = `
+		INSERT INTO state.pruned_receipt (tx_hash, tx_index, post_state, im_state_root)
+		SELECT r.tx_hash, r.tx_index, r.post_state, r.im_state_root
+		  FROM state.receipt r, state.l2block b
+		 WHERE r.block_num = b.block_num AND b.batch_num <= $1
+		    ON CONFLICT (tx_hash) DO NOTHING`
+	const deleteReceiptsSQL = `
+		DELETE FROM state.receipt r USING state.l2block b
+		 WHERE r.block_num = b.block_num AND b.batch_num <= $1`
+
+	e := p.getExecQuerier(dbTx)
+	if _, err := e.Exec(ctx, deleteLogsSQL, batchNumber); err != nil {
+		return 0, err
+	}
+	if _, err := e.Exec(ctx, keepPrunedReceiptsSQL, batchNumber); err != nil {
+		return 0, err
+	}
+	commandTag, err := e.Exec(ctx, deleteReceiptsSQL, batchNumber)
+	if err != nil {
+		return 0, err
+	}
+	return uint64(commandTag.RowsAffected()), nil
+}
@@ -0,0 +1,50 @@
+package state
+
+import (
+	"context"
+	"errors"
+
+	"github.com/jackc/pgx/v4"
+)
+
+var (
+	// ErrPruneKeepVerifiedBatchesZero is returned when the pruning is requested without keeping any verified batch
+	ErrPruneKeepVerifiedBatchesZero = errors.New("the number of verified batches to keep must be greater than 0")
+	// ErrNothingToPrune is returned when there aren't more verified batches than the ones to keep
+	ErrNothingToPrune = errors.New("there are no verified batches old enough to be pruned")
+)
+
+// GetLastHistoryBatchNumber returns the number of the last batch older than the last keepVerifiedBatches
+// verified batches, the receipts and logs of the batches up to it can be pruned or archived
+func (s *State) GetLastHistoryBatchNumber(ctx context.Context, keepVerifiedBatches uint64, dbTx pgx.Tx) (uint64, error) {
+	if keepVerifiedBatches == 0 {
+		return 0, ErrPruneKeepVerifiedBatchesZero
+	}
+	lastVerifiedBatch, err := s.GetLastVerifiedBatch(ctx, dbTx)
+	if errors.Is(err, ErrNotFound) {
+		return 0, ErrNothingToPrune
+	} else if err != nil {
+		return 0, err
+	}
+	if lastVerifiedBatch.BatchNumber <= keepVerifiedBatches {
+		return 0, ErrNothingToPrune
+	}
+	return lastVerifiedBatch.BatchNumber - keepVerifiedBatches, nil
+}
+
+// PruneHistory deletes the receipts and logs of the txs of the batches older than the last
+// keepVerifiedBatches verified batches. The batches, the L2 blocks and the txs are kept, so the
+// state roots and the data needed to prove the batches are not affected, and the tx index and the
+// state roots of the receipts are kept so the data stream can be regenerated. It returns the number
+// of the last pruned batch and the number of receipts deleted
+func (s *State) PruneHistory(ctx context.Context, keepVerifiedBatches uint64, dbTx pgx.Tx) (uint64, uint64, error) {
+	lastPrunedBatchNumber, err := s.GetLastHistoryBatchNumber(ctx, keepVerifiedBatches, dbTx)
+	if err != nil {
+		return 0, 0, err
+	}
+	prunedReceipts, err := s.DeleteReceiptsAndLogsUpToBatchNumber(ctx, lastPrunedBatchNumber, dbTx)
+	if err != nil {
+		return 0, 0, err
+	}
+	return lastPrunedBatchNumber, prunedReceipts, nil
+}
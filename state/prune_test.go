@@ -0,0 +1,36 @@
+package state_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/0xPolygonHermez/zkevm-node/state"
+	"github.com/0xPolygonHermez/zkevm-node/state/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPruneHistory(t *testing.T) {
+	ctx := context.Background()
+	mockStorage := mocks.NewStorageMock(t)
+	testState := state.NewState(state.Config{}, mockStorage, nil, nil, nil, nil, nil)
+	dbTx := mocks.NewDbTxMock(t)
+
+	_, _, err := testState.PruneHistory(ctx, 0, dbTx)
+	require.ErrorIs(t, err, state.ErrPruneKeepVerifiedBatchesZero)
+
+	mockStorage.EXPECT().GetLastVerifiedBatch(ctx, dbTx).Return(nil, state.ErrNotFound).Once()
+	_, _, err = testState.PruneHistory(ctx, 10, dbTx)
+	require.ErrorIs(t, err, state.ErrNothingToPrune)
+
+	mockStorage.EXPECT().GetLastVerifiedBatch(ctx, dbTx).Return(&state.VerifiedBatch{BatchNumber: 10}, nil).Once()
+	_, _, err = testState.PruneHistory(ctx, 10, dbTx)
+	require.ErrorIs(t, err, state.ErrNothingToPrune)
+
+	mockStorage.EXPECT().GetLastVerifiedBatch(ctx, dbTx).Return(&state.VerifiedBatch{BatchNumber: 25}, nil).Once()
+	mockStorage.EXPECT().DeleteReceiptsAndLogsUpToBatchNumber(ctx, uint64(15), dbTx).Return(uint64(7), nil).Once()
+	lastPrunedBatchNumber, prunedReceipts, err := testState.PruneHistory(ctx, 10, dbTx)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(15), lastPrunedBatchNumber)
+	assert.Equal(t, uint64(7), prunedReceipts)
+}
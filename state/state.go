@@ -7,6 +7,7 @@ import (
 
 	"github.com/0xPolygonHermez/zkevm-node/event"
 	"github.com/0xPolygonHermez/zkevm-node/l1infotree"
+	"github.com/0xPolygonHermez/zkevm-node/log"
 	"github.com/0xPolygonHermez/zkevm-node/merkletree"
 	"github.com/0xPolygonHermez/zkevm-node/state/metrics"
 	"github.com/0xPolygonHermez/zkevm-node/state/runtime/executor"
@@ -37,9 +38,11 @@ type State struct {
 	eventLog            *event.EventLog
 	l1InfoTree          *l1infotree.L1InfoTree
 	l1InfoTreeRecursive *l1infotree.L1InfoTreeRecursive
+	executionCache      *executionCache
 
 	newL2BlockEvents        chan NewL2BlockEvent
 	newL2BlockEventHandlers []NewL2BlockEventHandler
+	monitorNewL2BlocksOnce  sync.Once
 }
 
 // NewState creates a new State
@@ -60,6 +63,9 @@ func NewState(cfg Config, storage storage, executorClient executor.ExecutorServi
 		l1InfoTree:              mt,
 		l1InfoTreeRecursive:     mtr,
 	}
+	if cfg.ExecutionCacheMaxBytesSize > 0 {
+		state.executionCache = newExecutionCache(cfg.ExecutionCacheMaxBytesSize)
+	}
 
 	return state
 }
@@ -143,6 +149,54 @@ func (s *State) GetTree() *merkletree.StateTree {
 	return s.tree
 }
 
+// WarmupStateTreeCache reads the balance, nonce and code of the senders and receivers of the txs
+// of the last numBatches batches at the state root of the last L2 block, so these values are
+// loaded into the cache of the state tree
+func (s *State) WarmupStateTreeCache(ctx context.Context, numBatches uint64) error {
+	if s.tree == nil {
+		return ErrStateTreeNil
+	}
+	lastL2Block, err := s.GetLastL2Block(ctx, nil)
+	if err != nil {
+		return err
+	}
+	lastBatchNumber, err := s.GetLastBatchNumber(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	addresses := make(map[common.Address]struct{})
+	for i := uint64(0); i < numBatches && i < lastBatchNumber; i++ {
+		txs, err := s.GetTxsByBatchNumber(ctx, lastBatchNumber-i, nil)
+		if err != nil {
+			return err
+		}
+		for _, tx := range txs {
+			if sender, err := GetSender(*tx); err == nil {
+				addresses[sender] = struct{}{}
+			}
+			if tx.To() != nil {
+				addresses[*tx.To()] = struct{}{}
+			}
+		}
+	}
+
+	root := lastL2Block.Root().Bytes()
+	for address := range addresses {
+		if _, err := s.tree.GetBalance(ctx, address, root); err != nil {
+			return err
+		}
+		if _, err := s.tree.GetNonce(ctx, address, root); err != nil {
+			return err
+		}
+		if _, err := s.tree.GetCode(ctx, address, root); err != nil {
+			return err
+		}
+	}
+	log.Infof("state tree cache warmed up with %d accounts of the last %d batches", len(addresses), numBatches)
+	return nil
+}
+
 // FlushMerkleTree persists updates in the Merkle tree
 func (s *State) FlushMerkleTree(ctx context.Context, newStateRoot common.Hash) error {
 	if s.tree == nil {
@@ -0,0 +1,215 @@
+package state
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/jackc/pgx/v4"
+)
+
+// syncProgressCheckedBlocks is the number of the last progresses whose events hash is recomputed
+// from the state when the synchronizer starts
+const syncProgressCheckedBlocks = 64
+
+// ErrSyncProgressMismatch is returned when the progress stored by the synchronizer doesn't match the state
+var ErrSyncProgressMismatch = errors.New("the synchronizer progress doesn't match the state")
+
+// SyncProgress is the progress of the synchronizer after processing an L1 block. It's stored in the
+// same DB tx as the block, so the last progress always belongs to the last L1 block of the state.
+// The events hash chains the hash of the previous progress with the events of the block
+type SyncProgress struct {
+	BlockNumber            uint64
+	BlockHash              common.Hash
+	LastVirtualBatchNumber uint64
+	EventsHash             common.Hash
+	CreatedAt              time.Time
+}
+
+// SyncProgressEvent is an event of an L1 block processed by the synchronizer, with the data it
+// stored in the state: the sequenced batches with their L2 data, the verified batches with their
+// state roots, the forced batches, the exit roots and the fork IDs
+type SyncProgressEvent struct {
+	Name string
+	Data string
+}
+
+// NextSyncProgressEventsHash returns the events hash of an L1 block from the events hash of
+// the previous progress and the events of the block
+func NextSyncProgressEventsHash(previousEventsHash common.Hash, blockNumber uint64, blockHash common.Hash, events []SyncProgressEvent) common.Hash {
+	data := append([]byte{}, previousEventsHash.Bytes()...)
+	data = binary.BigEndian.AppendUint64(data, blockNumber)
+	data = append(data, blockHash.Bytes()...)
+	for _, event := range events {
+		data = binary.BigEndian.AppendUint64(data, uint64(len(event.Name)))
+		data = append(data, []byte(event.Name)...)
+		data = binary.BigEndian.AppendUint64(data, uint64(len(event.Data)))
+		data = append(data, []byte(event.Data)...)
+	}
+	return crypto.Keccak256Hash(data)
+}
+
+// StoreSyncProgress stores the progress of the synchronizer after processing the events of the
+// L1 block, chaining the hash of the events stored in the state for the block with the events
+// hash of the previous progress
+func (s *State) StoreSyncProgress(ctx context.Context, blockNumber uint64, blockHash common.Hash, dbTx pgx.Tx) error {
+	var previousEventsHash common.Hash
+	previousProgress, err := s.GetLastSyncProgress(ctx, dbTx)
+	if err != nil && !errors.Is(err, ErrNotFound) {
+		return err
+	} else if err == nil {
+		previousEventsHash = previousProgress.EventsHash
+	}
+
+	lastVirtualBatchNumber, err := s.GetLastVirtualBatchNum(ctx, dbTx)
+	if err != nil {
+		return err
+	}
+	eventsHash, err := s.syncProgressEventsHash(ctx, previousEventsHash, blockNumber, blockHash, dbTx)
+	if err != nil {
+		return err
+	}
+
+	return s.AddSyncProgress(ctx, SyncProgress{
+		BlockNumber:            blockNumber,
+		BlockHash:              blockHash,
+		LastVirtualBatchNumber: lastVirtualBatchNumber,
+		EventsHash:             eventsHash,
+		CreatedAt:              time.Now(),
+	}, dbTx)
+}
+
+// syncProgressEventsHash returns the events hash of the L1 block from the events stored in the state for it
+func (s *State) syncProgressEventsHash(ctx context.Context, previousEventsHash common.Hash, blockNumber uint64, blockHash common.Hash, dbTx pgx.Tx) (common.Hash, error) {
+	events, err := s.GetSyncProgressEvents(ctx, blockNumber, dbTx)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	return NextSyncProgressEventsHash(previousEventsHash, blockNumber, blockHash, events), nil
+}
+
+// UpdateForkIDBlockNumber updates the L1 block of the fork ID, when it's received again in another L1 block, and
+// recomputes in the same DB tx the events hash of the progresses stored since the L1 block the fork ID is moved
+// from or to, as the fork ID is an event of the L1 block it's stored for and the events hashes are chained
+func (s *State) UpdateForkIDBlockNumber(ctx context.Context, forkID uint64, newBlockNumber uint64, updateMemCache bool, dbTx pgx.Tx) error {
+	forkIDs, err := s.GetForkIDs(ctx, dbTx)
+	if err != nil {
+		return err
+	}
+	fromBlockNumber := newBlockNumber
+	for _, f := range forkIDs {
+		if f.ForkId == forkID {
+			fromBlockNumber = min(f.BlockNumber, newBlockNumber)
+		}
+	}
+
+	if err := s.storage.UpdateForkIDBlockNumber(ctx, forkID, newBlockNumber, updateMemCache, dbTx); err != nil {
+		return err
+	}
+	return s.rehashSyncProgresses(ctx, fromBlockNumber, dbTx)
+}
+
+// rehashSyncProgresses recomputes the events hash of the progresses stored for the L1 blocks from the
+// provided one, from the events stored in the state, chaining them to the previous progress
+func (s *State) rehashSyncProgresses(ctx context.Context, fromBlockNumber uint64, dbTx pgx.Tx) error {
+	progresses, err := s.GetSyncProgressesFromBlockNumber(ctx, fromBlockNumber, dbTx)
+	if err != nil {
+		return err
+	}
+
+	var previousEventsHash common.Hash
+	for _, progress := range progresses {
+		if progress.BlockNumber < fromBlockNumber {
+			previousEventsHash = progress.EventsHash
+			continue
+		}
+		eventsHash, err := s.syncProgressEventsHash(ctx, previousEventsHash, progress.BlockNumber, progress.BlockHash, dbTx)
+		if err != nil {
+			return err
+		}
+		progress.EventsHash = eventsHash
+		if err := s.AddSyncProgress(ctx, progress, dbTx); err != nil {
+			return err
+		}
+		previousEventsHash = eventsHash
+	}
+	return nil
+}
+
+// CheckSyncProgress checks the last progress stored by the synchronizer matches the last L1 block
+// and the last virtual batch of the state, and recomputes the events hash of the last progresses
+// from the L1 blocks and their events stored in the state, so the synchronizer doesn't resume from
+// a state that was modified or partially removed. The states synchronized before the progress was
+// stored are not checked
+func (s *State) CheckSyncProgress(ctx context.Context, dbTx pgx.Tx) (*SyncProgress, error) {
+	// the oldest progress is only used to chain the events hash of the next one, unless it's the first one
+	progresses, err := s.GetLastSyncProgresses(ctx, syncProgressCheckedBlocks+1, dbTx)
+	if err != nil {
+		return nil, err
+	}
+	if len(progresses) == 0 {
+		return nil, nil
+	}
+	progress := &progresses[0]
+
+	lastBlock, err := s.GetLastBlock(ctx, dbTx)
+	if err != nil {
+		return nil, err
+	}
+	if lastBlock.BlockNumber != progress.BlockNumber || lastBlock.BlockHash != progress.BlockHash {
+		return nil, fmt.Errorf("last L1 block %d %s, progress stored for L1 block %d %s: %w",
+			lastBlock.BlockNumber, lastBlock.BlockHash.String(), progress.BlockNumber, progress.BlockHash.String(), ErrSyncProgressMismatch)
+	}
+
+	lastVirtualBatchNumber, err := s.GetLastVirtualBatchNum(ctx, dbTx)
+	if err != nil {
+		return nil, err
+	}
+	if lastVirtualBatchNumber != progress.LastVirtualBatchNumber {
+		return nil, fmt.Errorf("last virtual batch %d, progress stored with virtual batch %d: %w",
+			lastVirtualBatchNumber, progress.LastVirtualBatchNumber, ErrSyncProgressMismatch)
+	}
+
+	for i := range progresses {
+		var previousEventsHash common.Hash
+		if i+1 < len(progresses) {
+			previousEventsHash = progresses[i+1].EventsHash
+		} else if len(progresses) > syncProgressCheckedBlocks {
+			break
+		}
+		if err := s.checkSyncProgressEvents(ctx, previousEventsHash, progresses[i], dbTx); err != nil {
+			return nil, err
+		}
+	}
+
+	return progress, nil
+}
+
+// checkSyncProgressEvents checks the progress matches the L1 block stored in the state and its
+// events hash matches the one recomputed from the events stored in the state for the block
+func (s *State) checkSyncProgressEvents(ctx context.Context, previousEventsHash common.Hash, progress SyncProgress, dbTx pgx.Tx) error {
+	block, err := s.GetBlockByNumber(ctx, progress.BlockNumber, dbTx)
+	if errors.Is(err, ErrNotFound) {
+		return fmt.Errorf("L1 block %d of the progress not found: %w", progress.BlockNumber, ErrSyncProgressMismatch)
+	} else if err != nil {
+		return err
+	}
+	if block.BlockHash != progress.BlockHash {
+		return fmt.Errorf("L1 block %d %s, progress stored for L1 block %s: %w",
+			block.BlockNumber, block.BlockHash.String(), progress.BlockHash.String(), ErrSyncProgressMismatch)
+	}
+
+	eventsHash, err := s.syncProgressEventsHash(ctx, previousEventsHash, progress.BlockNumber, progress.BlockHash, dbTx)
+	if err != nil {
+		return err
+	}
+	if eventsHash != progress.EventsHash {
+		return fmt.Errorf("events hash of L1 block %d %s, progress stored with events hash %s: %w",
+			progress.BlockNumber, eventsHash.String(), progress.EventsHash.String(), ErrSyncProgressMismatch)
+	}
+	return nil
+}
@@ -0,0 +1,160 @@
+package state_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/0xPolygonHermez/zkevm-node/state"
+	"github.com/0xPolygonHermez/zkevm-node/state/mocks"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/jackc/pgx/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNextSyncProgressEventsHash(t *testing.T) {
+	blockHash := common.HexToHash("0x1")
+	events := []state.SyncProgressEvent{{Name: "virtualBatch", Data: "1,0x2"}, {Name: "verifiedBatch", Data: "1,0x3"}}
+
+	hash := state.NextSyncProgressEventsHash(common.Hash{}, 10, blockHash, events)
+	assert.Equal(t, hash, state.NextSyncProgressEventsHash(common.Hash{}, 10, blockHash, events))
+	assert.NotEqual(t, hash, state.NextSyncProgressEventsHash(common.HexToHash("0x2"), 10, blockHash, events))
+	assert.NotEqual(t, hash, state.NextSyncProgressEventsHash(common.Hash{}, 11, blockHash, events))
+	assert.NotEqual(t, hash, state.NextSyncProgressEventsHash(common.Hash{}, 10, blockHash, events[:1]))
+	assert.NotEqual(t, hash, state.NextSyncProgressEventsHash(common.Hash{}, 10, blockHash, []state.SyncProgressEvent{events[1], events[0]}))
+	assert.NotEqual(t, hash, state.NextSyncProgressEventsHash(common.Hash{}, 10, blockHash, []state.SyncProgressEvent{events[0], {Name: "verifiedBatch", Data: "1,0x4"}}))
+}
+
+func TestCheckSyncProgress(t *testing.T) {
+	ctx := context.Background()
+	mockStorage := mocks.NewStorageMock(t)
+	testState := state.NewState(state.Config{}, mockStorage, nil, nil, nil, nil, nil)
+	dbTx := mocks.NewDbTxMock(t)
+
+	previousBlock := &state.Block{BlockNumber: 9, BlockHash: common.HexToHash("0x2")}
+	lastBlock := &state.Block{BlockNumber: 10, BlockHash: common.HexToHash("0x1")}
+	previousEvents := []state.SyncProgressEvent{{Name: "forkID", Data: "9,1,v9"}}
+	lastEvents := []state.SyncProgressEvent{{Name: "virtualBatch", Data: "5,0x5"}}
+	previousProgress := state.SyncProgress{
+		BlockNumber:            9,
+		BlockHash:              previousBlock.BlockHash,
+		LastVirtualBatchNumber: 4,
+		EventsHash:             state.NextSyncProgressEventsHash(common.Hash{}, 9, previousBlock.BlockHash, previousEvents),
+	}
+	progress := state.SyncProgress{
+		BlockNumber:            10,
+		BlockHash:              lastBlock.BlockHash,
+		LastVirtualBatchNumber: 5,
+		EventsHash:             state.NextSyncProgressEventsHash(previousProgress.EventsHash, 10, lastBlock.BlockHash, lastEvents),
+	}
+	progresses := []state.SyncProgress{progress, previousProgress}
+
+	// no progress stored yet
+	mockStorage.EXPECT().GetLastSyncProgresses(ctx, uint64(65), dbTx).Return([]state.SyncProgress{}, nil).Once()
+	resumed, err := testState.CheckSyncProgress(ctx, dbTx)
+	require.NoError(t, err)
+	assert.Nil(t, resumed)
+
+	expectEvents := func(events []state.SyncProgressEvent) {
+		mockStorage.EXPECT().GetBlockByNumber(ctx, uint64(10), dbTx).Return(lastBlock, nil).Once()
+		mockStorage.EXPECT().GetSyncProgressEvents(ctx, uint64(10), dbTx).Return(events, nil).Once()
+	}
+
+	mockStorage.EXPECT().GetLastSyncProgresses(ctx, uint64(65), dbTx).Return(progresses, nil).Once()
+	mockStorage.EXPECT().GetLastBlock(ctx, dbTx).Return(lastBlock, nil).Once()
+	mockStorage.EXPECT().GetLastVirtualBatchNum(ctx, dbTx).Return(uint64(5), nil).Once()
+	expectEvents(lastEvents)
+	mockStorage.EXPECT().GetBlockByNumber(ctx, uint64(9), dbTx).Return(previousBlock, nil).Once()
+	mockStorage.EXPECT().GetSyncProgressEvents(ctx, uint64(9), dbTx).Return(previousEvents, nil).Once()
+	resumed, err = testState.CheckSyncProgress(ctx, dbTx)
+	require.NoError(t, err)
+	assert.Equal(t, &progress, resumed)
+
+	// the last block was removed after the progress was stored
+	mockStorage.EXPECT().GetLastSyncProgresses(ctx, uint64(65), dbTx).Return(progresses, nil).Once()
+	mockStorage.EXPECT().GetLastBlock(ctx, dbTx).Return(previousBlock, nil).Once()
+	_, err = testState.CheckSyncProgress(ctx, dbTx)
+	require.ErrorIs(t, err, state.ErrSyncProgressMismatch)
+
+	// a virtual batch was removed after the progress was stored
+	mockStorage.EXPECT().GetLastSyncProgresses(ctx, uint64(65), dbTx).Return(progresses, nil).Once()
+	mockStorage.EXPECT().GetLastBlock(ctx, dbTx).Return(lastBlock, nil).Once()
+	mockStorage.EXPECT().GetLastVirtualBatchNum(ctx, dbTx).Return(uint64(4), nil).Once()
+	_, err = testState.CheckSyncProgress(ctx, dbTx)
+	require.ErrorIs(t, err, state.ErrSyncProgressMismatch)
+
+	// the data stored by the events of the last block was modified after the progress was stored
+	mockStorage.EXPECT().GetLastSyncProgresses(ctx, uint64(65), dbTx).Return(progresses, nil).Once()
+	mockStorage.EXPECT().GetLastBlock(ctx, dbTx).Return(lastBlock, nil).Once()
+	mockStorage.EXPECT().GetLastVirtualBatchNum(ctx, dbTx).Return(uint64(5), nil).Once()
+	expectEvents([]state.SyncProgressEvent{{Name: "virtualBatch", Data: "5,0x6"}})
+	_, err = testState.CheckSyncProgress(ctx, dbTx)
+	require.ErrorIs(t, err, state.ErrSyncProgressMismatch)
+
+	// the first progress stored is chained with an empty events hash
+	mockStorage.EXPECT().GetLastSyncProgresses(ctx, uint64(65), dbTx).Return([]state.SyncProgress{progress}, nil).Once()
+	mockStorage.EXPECT().GetLastBlock(ctx, dbTx).Return(lastBlock, nil).Once()
+	mockStorage.EXPECT().GetLastVirtualBatchNum(ctx, dbTx).Return(uint64(5), nil).Once()
+	expectEvents(lastEvents)
+	_, err = testState.CheckSyncProgress(ctx, dbTx)
+	require.ErrorIs(t, err, state.ErrSyncProgressMismatch)
+}
+
+func TestStoreSyncProgress(t *testing.T) {
+	ctx := context.Background()
+	mockStorage := mocks.NewStorageMock(t)
+	testState := state.NewState(state.Config{}, mockStorage, nil, nil, nil, nil, nil)
+	dbTx := mocks.NewDbTxMock(t)
+
+	blockHash := common.HexToHash("0x1")
+	previousProgress := &state.SyncProgress{BlockNumber: 9, EventsHash: common.HexToHash("0x2")}
+	events := []state.SyncProgressEvent{{Name: "virtualBatch", Data: "5,0x5"}}
+
+	mockStorage.EXPECT().GetLastSyncProgress(ctx, dbTx).Return(previousProgress, nil).Once()
+	mockStorage.EXPECT().GetLastVirtualBatchNum(ctx, dbTx).Return(uint64(5), nil).Once()
+	mockStorage.EXPECT().GetSyncProgressEvents(ctx, uint64(10), dbTx).Return(events, nil).Once()
+	mockStorage.EXPECT().AddSyncProgress(ctx, mock.Anything, dbTx).
+		Run(func(ctx context.Context, progress state.SyncProgress, dbTx pgx.Tx) {
+			assert.Equal(t, uint64(10), progress.BlockNumber)
+			assert.Equal(t, blockHash, progress.BlockHash)
+			assert.Equal(t, uint64(5), progress.LastVirtualBatchNumber)
+			assert.Equal(t, state.NextSyncProgressEventsHash(previousProgress.EventsHash, 10, blockHash, events), progress.EventsHash)
+		}).
+		Return(nil).Once()
+	require.NoError(t, testState.StoreSyncProgress(ctx, 10, blockHash, dbTx))
+}
+
+func TestUpdateForkIDBlockNumberRehashesSyncProgress(t *testing.T) {
+	ctx := context.Background()
+	mockStorage := mocks.NewStorageMock(t)
+	testState := state.NewState(state.Config{}, mockStorage, nil, nil, nil, nil, nil)
+	dbTx := mocks.NewDbTxMock(t)
+
+	// the fork ID stored for the L1 block 8 is received again in the L1 block 10
+	forkIDEvent := state.SyncProgressEvent{Name: "forkID", Data: "9,1,v9"}
+	virtualBatchEvent := state.SyncProgressEvent{Name: "virtualBatch", Data: "5,0x5"}
+	previousProgress := state.SyncProgress{BlockNumber: 7, BlockHash: common.HexToHash("0x7"), EventsHash: common.HexToHash("0x70")}
+	forkIDProgress := state.SyncProgress{BlockNumber: 8, BlockHash: common.HexToHash("0x8")}
+	forkIDProgress.EventsHash = state.NextSyncProgressEventsHash(previousProgress.EventsHash, 8, forkIDProgress.BlockHash, []state.SyncProgressEvent{forkIDEvent})
+	lastProgress := state.SyncProgress{BlockNumber: 9, BlockHash: common.HexToHash("0x9")}
+	lastProgress.EventsHash = state.NextSyncProgressEventsHash(forkIDProgress.EventsHash, 9, lastProgress.BlockHash, []state.SyncProgressEvent{virtualBatchEvent})
+
+	mockStorage.EXPECT().GetForkIDs(ctx, dbTx).Return([]state.ForkIDInterval{{ForkId: 9, FromBatchNumber: 1, BlockNumber: 8}}, nil).Once()
+	mockStorage.EXPECT().UpdateForkIDBlockNumber(ctx, uint64(9), uint64(10), true, dbTx).Return(nil).Once()
+	mockStorage.EXPECT().GetSyncProgressesFromBlockNumber(ctx, uint64(8), dbTx).
+		Return([]state.SyncProgress{previousProgress, forkIDProgress, lastProgress}, nil).Once()
+	mockStorage.EXPECT().GetSyncProgressEvents(ctx, uint64(8), dbTx).Return([]state.SyncProgressEvent{}, nil).Once()
+	mockStorage.EXPECT().GetSyncProgressEvents(ctx, uint64(9), dbTx).Return([]state.SyncProgressEvent{virtualBatchEvent}, nil).Once()
+
+	// the progresses since the L1 block the fork ID is moved from are chained again without it
+	rehashedForkIDProgress := forkIDProgress
+	rehashedForkIDProgress.EventsHash = state.NextSyncProgressEventsHash(previousProgress.EventsHash, 8, forkIDProgress.BlockHash, []state.SyncProgressEvent{})
+	rehashedLastProgress := lastProgress
+	rehashedLastProgress.EventsHash = state.NextSyncProgressEventsHash(rehashedForkIDProgress.EventsHash, 9, lastProgress.BlockHash, []state.SyncProgressEvent{virtualBatchEvent})
+	require.NotEqual(t, lastProgress.EventsHash, rehashedLastProgress.EventsHash)
+	mockStorage.EXPECT().AddSyncProgress(ctx, rehashedForkIDProgress, dbTx).Return(nil).Once()
+	mockStorage.EXPECT().AddSyncProgress(ctx, rehashedLastProgress, dbTx).Return(nil).Once()
+
+	require.NoError(t, testState.UpdateForkIDBlockNumber(ctx, 9, 10, true, dbTx))
+}
@@ -15,6 +15,7 @@ import (
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core"
 	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/params"
 	"github.com/ethereum/go-ethereum/trie"
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v4"
@@ -188,9 +189,10 @@ func (s *State) StoreTransactions(ctx context.Context, batchNumber uint64, proce
 				storeTxsEGPData[0].EGPLog = txsEGPLog[i]
 			}
 			txsL2Hash := []common.Hash{processedTx.TxHashL2_V2}
+			txsRevertData := [][]byte{GetRevertData(processedTx)}
 
 			// Store L2 block and its transaction
-			if err := s.AddL2Block(ctx, batchNumber, l2Block, receipts, txsL2Hash, storeTxsEGPData, imStateRoots, dbTx); err != nil {
+			if err := s.AddL2Block(ctx, batchNumber, l2Block, receipts, txsL2Hash, storeTxsEGPData, imStateRoots, txsRevertData, dbTx); err != nil {
 				return err
 			}
 			txIndex++
@@ -242,6 +244,7 @@ func (s *State) StoreL2Block(ctx context.Context, batchNumber uint64, l2Block *P
 	receipts := make([]*types.Receipt, 0, numTxs)
 	txsL2Hash := make([]common.Hash, 0, numTxs)
 	imStateRoots := make([]common.Hash, 0, numTxs)
+	txsRevertData := make([][]byte, 0, numTxs)
 	var receipt *types.Receipt
 
 	for i, txResponse := range l2Block.TransactionResponses {
@@ -266,6 +269,7 @@ func (s *State) StoreL2Block(ctx context.Context, batchNumber uint64, l2Block *P
 		receipt = GenerateReceipt(header.Number, txResponse, uint(i), forkID)
 		receipts = append(receipts, receipt)
 		imStateRoots = append(imStateRoots, txResp.StateRoot)
+		txsRevertData = append(txsRevertData, GetRevertData(txResponse))
 	}
 
 	// Create block to be able to calculate its hash
@@ -278,7 +282,7 @@ func (s *State) StoreL2Block(ctx context.Context, batchNumber uint64, l2Block *P
 	}
 
 	// Store L2 block and its transactions
-	if err := s.AddL2Block(ctx, batchNumber, block, receipts, txsL2Hash, storeTxsEGPData, imStateRoots, dbTx); err != nil {
+	if err := s.AddL2Block(ctx, batchNumber, block, receipts, txsL2Hash, storeTxsEGPData, imStateRoots, txsRevertData, dbTx); err != nil {
 		return err
 	}
 
@@ -289,7 +293,7 @@ func (s *State) StoreL2Block(ctx context.Context, batchNumber uint64, l2Block *P
 
 // PreProcessUnsignedTransaction processes the unsigned transaction in order to calculate its zkCounters
 func (s *State) PreProcessUnsignedTransaction(ctx context.Context, tx *types.Transaction, sender common.Address, l2BlockNumber *uint64, dbTx pgx.Tx) (*ProcessBatchResponse, error) {
-	response, err := s.internalProcessUnsignedTransaction(ctx, tx, sender, l2BlockNumber, false, dbTx)
+	response, err := s.internalProcessUnsignedTransaction(ctx, tx, sender, l2BlockNumber, false, nil, dbTx)
 	if err != nil {
 		return response, err
 	}
@@ -304,7 +308,7 @@ func (s *State) PreProcessTransaction(ctx context.Context, tx *types.Transaction
 		return nil, err
 	}
 
-	response, err := s.internalProcessUnsignedTransaction(ctx, tx, sender, nil, false, dbTx)
+	response, err := s.internalProcessUnsignedTransaction(ctx, tx, sender, nil, false, nil, dbTx)
 	if err != nil {
 		return response, err
 	}
@@ -313,14 +317,57 @@ func (s *State) PreProcessTransaction(ctx context.Context, tx *types.Transaction
 }
 
 // ProcessUnsignedTransaction processes the given unsigned transaction.
-func (s *State) ProcessUnsignedTransaction(ctx context.Context, tx *types.Transaction, senderAddress common.Address, l2BlockNumber *uint64, noZKEVMCounters bool, dbTx pgx.Tx) (*runtime.ExecutionResult, error) {
-	result := new(runtime.ExecutionResult)
-	response, err := s.internalProcessUnsignedTransaction(ctx, tx, senderAddress, l2BlockNumber, noZKEVMCounters, dbTx)
+func (s *State) ProcessUnsignedTransaction(ctx context.Context, tx *types.Transaction, senderAddress common.Address, l2BlockNumber *uint64, noZKEVMCounters bool, stateOverride StateOverride, dbTx pgx.Tx) (*runtime.ExecutionResult, error) {
+	response, err := s.internalProcessUnsignedTransaction(ctx, tx, senderAddress, l2BlockNumber, noZKEVMCounters, stateOverride, dbTx)
+	if err != nil {
+		return nil, err
+	}
+
+	return newExecutionResult(response.BlockResponses[0].TransactionResponses[0]), nil
+}
+
+// ProcessUnsignedTransactionOnPendingL2Block processes the given unsigned transaction on top of the state root of
+// the pending L2 block, that is a L2 block processed by the sequencer after the last L2 block stored in the state
+func (s *State) ProcessUnsignedTransactionOnPendingL2Block(ctx context.Context, tx *types.Transaction, senderAddress common.Address, pendingStateRoot common.Hash, pendingTimestamp uint64, noZKEVMCounters bool, stateOverride StateOverride, dbTx pgx.Tx) (*runtime.ExecutionResult, error) {
+	lastL2Block, err := s.GetLastL2Block(ctx, dbTx)
+	if err != nil {
+		return nil, err
+	}
+
+	batch, err := s.GetBatchByL2BlockNumber(ctx, lastL2Block.NumberU64(), dbTx)
+	if err != nil {
+		return nil, err
+	}
+
+	header := lastL2Block.Header()
+	header.Root = pendingStateRoot
+	header.Time = pendingTimestamp
+	pendingL2Block := NewL2BlockWithHeader(header)
+
+	response, err := s.processUnsignedTransactionOnL2Block(ctx, tx, senderAddress, batch, pendingL2Block, noZKEVMCounters, stateOverride, dbTx)
 	if err != nil {
 		return nil, err
 	}
 
-	r := response.BlockResponses[0].TransactionResponses[0]
+	return newExecutionResult(response.BlockResponses[0].TransactionResponses[0]), nil
+}
+
+// getNonceWithStateOverride returns the nonce of the address in the state root, or
+// the nonce set by the state override if it overrides it.
+func (s *State) getNonceWithStateOverride(ctx context.Context, address common.Address, root common.Hash, stateOverride StateOverride) (uint64, error) {
+	if account, found := stateOverride[address]; found && account.Nonce != nil {
+		return *account.Nonce, nil
+	}
+	nonce, err := s.tree.GetNonce(ctx, address, root.Bytes())
+	if err != nil {
+		return 0, err
+	}
+	return nonce.Uint64(), nil
+}
+
+// newExecutionResult returns the execution result of the processed unsigned transaction.
+func newExecutionResult(r *ProcessTransactionResponse) *runtime.ExecutionResult {
+	result := new(runtime.ExecutionResult)
 	result.ReturnValue = r.ReturnValue
 	result.GasLeft = r.GasLeft
 	result.GasUsed = r.GasUsed
@@ -333,11 +380,11 @@ func (s *State) ProcessUnsignedTransaction(ctx context.Context, tx *types.Transa
 		result.Err = r.RomError
 	}
 
-	return result, nil
+	return result
 }
 
 // internalProcessUnsignedTransaction processes the given unsigned transaction.
-func (s *State) internalProcessUnsignedTransaction(ctx context.Context, tx *types.Transaction, senderAddress common.Address, l2BlockNumber *uint64, noZKEVMCounters bool, dbTx pgx.Tx) (*ProcessBatchResponse, error) {
+func (s *State) internalProcessUnsignedTransaction(ctx context.Context, tx *types.Transaction, senderAddress common.Address, l2BlockNumber *uint64, noZKEVMCounters bool, stateOverride StateOverride, dbTx pgx.Tx) (*ProcessBatchResponse, error) {
 	var l2Block *L2Block
 	var err error
 	if l2BlockNumber == nil {
@@ -354,17 +401,22 @@ func (s *State) internalProcessUnsignedTransaction(ctx context.Context, tx *type
 		return nil, err
 	}
 
+	return s.processUnsignedTransactionOnL2Block(ctx, tx, senderAddress, batch, l2Block, noZKEVMCounters, stateOverride, dbTx)
+}
+
+// processUnsignedTransactionOnL2Block processes the given unsigned transaction on top of the state of the L2 block.
+func (s *State) processUnsignedTransactionOnL2Block(ctx context.Context, tx *types.Transaction, senderAddress common.Address, batch *Batch, l2Block *L2Block, noZKEVMCounters bool, stateOverride StateOverride, dbTx pgx.Tx) (*ProcessBatchResponse, error) {
 	forkID := s.GetForkIDByBatchNumber(batch.BatchNumber)
 	if forkID < FORKID_ETROG {
-		return s.internalProcessUnsignedTransactionV1(ctx, tx, senderAddress, *batch, *l2Block, forkID, noZKEVMCounters, dbTx)
+		return s.internalProcessUnsignedTransactionV1(ctx, tx, senderAddress, *batch, *l2Block, forkID, noZKEVMCounters, stateOverride, dbTx)
 	} else {
-		return s.internalProcessUnsignedTransactionV2(ctx, tx, senderAddress, *batch, *l2Block, forkID, noZKEVMCounters, dbTx)
+		return s.internalProcessUnsignedTransactionV2(ctx, tx, senderAddress, *batch, *l2Block, forkID, noZKEVMCounters, stateOverride, dbTx)
 	}
 }
 
 // internalProcessUnsignedTransactionV1 processes the given unsigned transaction.
 // pre ETROG
-func (s *State) internalProcessUnsignedTransactionV1(ctx context.Context, tx *types.Transaction, senderAddress common.Address, batch Batch, l2Block L2Block, forkID uint64, noZKEVMCounters bool, dbTx pgx.Tx) (*ProcessBatchResponse, error) {
+func (s *State) internalProcessUnsignedTransactionV1(ctx context.Context, tx *types.Transaction, senderAddress common.Address, batch Batch, l2Block L2Block, forkID uint64, noZKEVMCounters bool, stateOverride StateOverride, dbTx pgx.Tx) (*ProcessBatchResponse, error) {
 	var attempts = 1
 
 	if s.executorClient == nil {
@@ -384,11 +436,10 @@ func (s *State) internalProcessUnsignedTransactionV1(ctx context.Context, tx *ty
 		timestamp = uint64(time.Now().Unix())
 	}
 
-	loadedNonce, err := s.tree.GetNonce(ctx, senderAddress, l2Block.Root().Bytes())
+	nonce, err := s.getNonceWithStateOverride(ctx, senderAddress, l2Block.Root(), stateOverride)
 	if err != nil {
 		return nil, err
 	}
-	nonce := loadedNonce.Uint64()
 
 	batchL2Data, err := EncodeUnsignedTransaction(*tx, s.cfg.ChainID, &nonce, forkID)
 	if err != nil {
@@ -408,6 +459,7 @@ func (s *State) internalProcessUnsignedTransactionV1(ctx context.Context, tx *ty
 		ChainId:          s.cfg.ChainID,
 		UpdateMerkleTree: cFalse,
 		ContextId:        uuid.NewString(),
+		StateOverride:    convertToExecutorStateOverride(stateOverride),
 
 		// v1 fields
 		GlobalExitRoot: l2Block.GlobalExitRoot().Bytes(),
@@ -492,7 +544,7 @@ func (s *State) internalProcessUnsignedTransactionV1(ctx context.Context, tx *ty
 
 // internalProcessUnsignedTransactionV2 processes the given unsigned transaction.
 // post ETROG
-func (s *State) internalProcessUnsignedTransactionV2(ctx context.Context, tx *types.Transaction, senderAddress common.Address, batch Batch, l2Block L2Block, forkID uint64, noZKEVMCounters bool, dbTx pgx.Tx) (*ProcessBatchResponse, error) {
+func (s *State) internalProcessUnsignedTransactionV2(ctx context.Context, tx *types.Transaction, senderAddress common.Address, batch Batch, l2Block L2Block, forkID uint64, noZKEVMCounters bool, stateOverride StateOverride, dbTx pgx.Tx) (*ProcessBatchResponse, error) {
 	var attempts = 1
 
 	if s.executorClient == nil {
@@ -502,11 +554,10 @@ func (s *State) internalProcessUnsignedTransactionV2(ctx context.Context, tx *ty
 		return nil, ErrStateTreeNil
 	}
 
-	loadedNonce, err := s.tree.GetNonce(ctx, senderAddress, l2Block.Root().Bytes())
+	nonce, err := s.getNonceWithStateOverride(ctx, senderAddress, l2Block.Root(), stateOverride)
 	if err != nil {
 		return nil, err
 	}
-	nonce := loadedNonce.Uint64()
 
 	transactions := s.BuildChangeL2Block(uint32(0), uint32(0))
 
@@ -530,6 +581,7 @@ func (s *State) internalProcessUnsignedTransactionV2(ctx context.Context, tx *ty
 		ChainId:          s.cfg.ChainID,
 		UpdateMerkleTree: cFalse,
 		ContextId:        uuid.NewString(),
+		StateOverride:    convertToExecutorStateOverrideV2(stateOverride),
 
 		// v2 fields
 		L1InfoRoot:             l2Block.BlockInfoRoot().Bytes(),
@@ -678,9 +730,10 @@ func (s *State) StoreTransaction(ctx context.Context, batchNumber uint64, proces
 
 	storeTxsEGPData := []StoreTxEGPData{{EGPLog: egpLog, EffectivePercentage: uint8(processedTx.EffectivePercentage)}}
 	txsL2Hash := []common.Hash{processedTx.TxHashL2_V2}
+	txsRevertData := [][]byte{GetRevertData(processedTx)}
 
 	// Store L2 block and its transaction
-	if err := s.AddL2Block(ctx, batchNumber, l2Block, receipts, txsL2Hash, storeTxsEGPData, imStateRoots, dbTx); err != nil {
+	if err := s.AddL2Block(ctx, batchNumber, l2Block, receipts, txsL2Hash, storeTxsEGPData, imStateRoots, txsRevertData, dbTx); err != nil {
 		return nil, err
 	}
 
@@ -702,9 +755,13 @@ func CheckSupersetBatchTransactions(existingTxHashes []common.Hash, processedTxs
 	return nil
 }
 
-// EstimateGas for a transaction
-func (s *State) EstimateGas(transaction *types.Transaction, senderAddress common.Address, l2BlockNumber *uint64, dbTx pgx.Tx) (uint64, []byte, error) {
+// EstimateGas for a transaction. The accounts of the state override, if any, are
+// overridden during the executions of the transaction
+func (s *State) EstimateGas(transaction *types.Transaction, senderAddress common.Address, l2BlockNumber *uint64, stateOverride StateOverride, dbTx pgx.Tx) (uint64, []byte, error) {
 	const ethTransferGas = 21000
+	// The binary search stops once the estimation is within this ratio of the
+	// gas really needed, as geth does
+	const estimateGasErrorRatio = 0.015
 
 	ctx := context.Background()
 
@@ -730,11 +787,10 @@ func (s *State) EstimateGas(transaction *types.Transaction, senderAddress common
 		return 0, nil, err
 	}
 
-	loadedNonce, err := s.tree.GetNonce(ctx, senderAddress, l2Block.Root().Bytes())
+	nonce, err := s.getNonceWithStateOverride(ctx, senderAddress, l2Block.Root(), stateOverride)
 	if err != nil {
 		return 0, nil, err
 	}
-	nonce := loadedNonce.Uint64()
 
 	highEnd := MaxTxGasLimit
 
@@ -742,11 +798,16 @@ func (s *State) EstimateGas(transaction *types.Transaction, senderAddress common
 	// of the account afford
 	isGasPriceSet := transaction.GasPrice().BitLen() != 0
 	if isGasPriceSet {
-		senderBalance, err := s.tree.GetBalance(ctx, senderAddress, l2Block.Root().Bytes())
-		if errors.Is(err, ErrNotFound) {
-			senderBalance = big.NewInt(0)
-		} else if err != nil {
-			return 0, nil, err
+		var senderBalance *big.Int
+		if senderOverride, found := stateOverride[senderAddress]; found && senderOverride.Balance != nil {
+			senderBalance = senderOverride.Balance
+		} else {
+			senderBalance, err = s.tree.GetBalance(ctx, senderAddress, l2Block.Root().Bytes())
+			if errors.Is(err, ErrNotFound) {
+				senderBalance = big.NewInt(0)
+			} else if err != nil {
+				return 0, nil, err
+			}
 		}
 
 		availableBalance := new(big.Int).Set(senderBalance)
@@ -775,23 +836,28 @@ func (s *State) EstimateGas(transaction *types.Transaction, senderAddress common
 	}
 
 	// set start values for lowEnd and highEnd:
-	lowEnd, err := core.IntrinsicGas(transaction.Data(), transaction.AccessList(), s.isContractCreation(transaction), true, false, false)
+	intrinsicGas, err := core.IntrinsicGas(transaction.Data(), transaction.AccessList(), s.isContractCreation(transaction), true, false, false)
 	if err != nil {
 		return 0, nil, err
 	}
 
 	// if the intrinsic gas is the same as the constant value for eth transfer
 	// and the transaction has a receiver address
-	if lowEnd == ethTransferGas && transaction.To() != nil {
+	if intrinsicGas == ethTransferGas && transaction.To() != nil {
 		receiver := *transaction.To()
 		// check if the receiver address is not a smart contract
-		code, err := s.tree.GetCode(ctx, receiver, l2Block.Root().Bytes())
+		var code []byte
+		if receiverOverride, found := stateOverride[receiver]; found && receiverOverride.Code != nil {
+			code = *receiverOverride.Code
+		} else {
+			code, err = s.tree.GetCode(ctx, receiver, l2Block.Root().Bytes())
+		}
 		if err != nil {
 			log.Warnf("error while getting code for address %v: %v", receiver.String(), err)
 		} else if len(code) == 0 {
 			// in case it is just an account, we can avoid the execution and return
 			// the transfer constant amount
-			return lowEnd, nil, nil
+			return intrinsicGas, nil, nil
 		}
 	}
 
@@ -800,19 +866,23 @@ func (s *State) EstimateGas(transaction *types.Transaction, senderAddress common
 	// was reverted and the accompanying error
 	txExecutions := []time.Duration{}
 	var totalExecutionTime time.Duration
+	testTransaction := func(gas uint64, shouldOmitErr bool) (failed, reverted bool, gasUsed uint64, returnValue []byte, err error) {
+		txExecutionStart := time.Now()
+		log.Debugf("Estimate gas. Trying to execute TX with %v gas", gas)
+		if forkID < FORKID_ETROG {
+			failed, reverted, gasUsed, returnValue, err = s.internalTestGasEstimationTransactionV1(ctx, batch, l2Block, latestL2BlockNumber, transaction, forkID, senderAddress, gas, nonce, stateOverride, shouldOmitErr)
+		} else {
+			failed, reverted, gasUsed, returnValue, err = s.internalTestGasEstimationTransactionV2(ctx, batch, l2Block, latestL2BlockNumber, transaction, forkID, senderAddress, gas, nonce, stateOverride, shouldOmitErr)
+		}
+		executionTime := time.Since(txExecutionStart)
+		totalExecutionTime += executionTime
+		txExecutions = append(txExecutions, executionTime)
+		return failed, reverted, gasUsed, returnValue, err
+	}
 
 	// Check if the highEnd is a good value to make the transaction pass, if it fails we
 	// can return immediately.
-	log.Debugf("Estimate gas. Trying to execute TX with %v gas", highEnd)
-	var failed, reverted bool
-	var gasUsed uint64
-	var returnValue []byte
-	if forkID < FORKID_ETROG {
-		failed, reverted, gasUsed, returnValue, err = s.internalTestGasEstimationTransactionV1(ctx, batch, l2Block, latestL2BlockNumber, transaction, forkID, senderAddress, highEnd, nonce, false)
-	} else {
-		failed, reverted, gasUsed, returnValue, err = s.internalTestGasEstimationTransactionV2(ctx, batch, l2Block, latestL2BlockNumber, transaction, forkID, senderAddress, highEnd, nonce, false)
-	}
-
+	failed, reverted, gasUsed, returnValue, err := testTransaction(highEnd, false)
 	if failed {
 		if reverted {
 			return 0, returnValue, err
@@ -825,14 +895,35 @@ func (s *State) EstimateGas(transaction *types.Transaction, senderAddress common
 		)
 	}
 
-	// sets
-	if lowEnd < gasUsed {
-		lowEnd = gasUsed
+	// lowEnd is the highest gas known to make the transaction fail and highEnd
+	// the lowest gas known to make it pass. The transaction needs at least
+	// the intrinsic gas and the gas it used
+	lowEnd := intrinsicGas - 1
+	if gasUsed > 0 && lowEnd < gasUsed-1 {
+		lowEnd = gasUsed - 1
 	}
 
-	// Start the binary search for the lowest possible gas price
-	for (lowEnd < highEnd) && (highEnd-lowEnd) > 4096 {
-		txExecutionStart := time.Now()
+	// Most txs don't need much more gas than the gas they use, the calls only need
+	// the 1/64 of the gas they can't forward to the callee and the stipend of the
+	// value transfers, so this value is tried before starting the binary search
+	optimisticGas := (gasUsed + params.CallStipend) * 64 / 63 // nolint:gomnd
+	if optimisticGas > lowEnd && optimisticGas < highEnd {
+		failed, reverted, _, _, err = testTransaction(optimisticGas, true)
+		if err != nil && !reverted {
+			return 0, nil, err
+		}
+		if failed {
+			lowEnd = optimisticGas
+		} else {
+			highEnd = optimisticGas
+		}
+	}
+
+	// Start the binary search for the lowest possible gas
+	for lowEnd+1 < highEnd {
+		if float64(highEnd-lowEnd)/float64(highEnd) < estimateGasErrorRatio {
+			break
+		}
 		mid := (lowEnd + highEnd) / 2 // nolint:gomnd
 		if mid > lowEnd*2 {
 			// Most txs don't need much higher gas limit than their gas used, and most txs don't
@@ -841,15 +932,7 @@ func (s *State) EstimateGas(transaction *types.Transaction, senderAddress common
 			mid = lowEnd * 2 // nolint:gomnd
 		}
 
-		log.Debugf("Estimate gas. Trying to execute TX with %v gas", mid)
-		if forkID < FORKID_ETROG {
-			failed, reverted, _, _, err = s.internalTestGasEstimationTransactionV1(ctx, batch, l2Block, latestL2BlockNumber, transaction, forkID, senderAddress, mid, nonce, true)
-		} else {
-			failed, reverted, _, _, err = s.internalTestGasEstimationTransactionV2(ctx, batch, l2Block, latestL2BlockNumber, transaction, forkID, senderAddress, mid, nonce, true)
-		}
-		executionTime := time.Since(txExecutionStart)
-		totalExecutionTime += executionTime
-		txExecutions = append(txExecutions, executionTime)
+		failed, reverted, _, _, err = testTransaction(mid, true)
 		if err != nil && !reverted {
 			// Reverts are ignored in the binary search, but are checked later on
 			// during the execution for the optimal gas limit found
@@ -858,7 +941,7 @@ func (s *State) EstimateGas(transaction *types.Transaction, senderAddress common
 
 		if failed {
 			// If the transaction failed => increase the gas
-			lowEnd = mid + 1
+			lowEnd = mid
 		} else {
 			// If the transaction didn't fail => make this ok value the high end
 			highEnd = mid
@@ -879,7 +962,7 @@ func (s *State) EstimateGas(transaction *types.Transaction, senderAddress common
 // before ETROG
 func (s *State) internalTestGasEstimationTransactionV1(ctx context.Context, batch *Batch, l2Block *L2Block, latestL2BlockNumber uint64,
 	transaction *types.Transaction, forkID uint64, senderAddress common.Address,
-	gas uint64, nonce uint64, shouldOmitErr bool) (failed, reverted bool, gasUsed uint64, returnValue []byte, err error) {
+	gas uint64, nonce uint64, stateOverride StateOverride, shouldOmitErr bool) (failed, reverted bool, gasUsed uint64, returnValue []byte, err error) {
 	timestamp := l2Block.Time()
 	if l2Block.NumberU64() == latestL2BlockNumber {
 		timestamp = uint64(time.Now().Unix())
@@ -912,6 +995,7 @@ func (s *State) internalTestGasEstimationTransactionV1(ctx context.Context, batc
 		ChainId:          s.cfg.ChainID,
 		UpdateMerkleTree: cFalse,
 		ContextId:        uuid.NewString(),
+		StateOverride:    convertToExecutorStateOverride(stateOverride),
 
 		// v1 fields
 		GlobalExitRoot: batch.GlobalExitRoot.Bytes(),
@@ -975,8 +1059,14 @@ func (s *State) internalTestGasEstimationTransactionV1(ctx context.Context, batc
 // after ETROG
 func (s *State) internalTestGasEstimationTransactionV2(ctx context.Context, batch *Batch, l2Block *L2Block, latestL2BlockNumber uint64,
 	transaction *types.Transaction, forkID uint64, senderAddress common.Address,
-	gas uint64, nonce uint64, shouldOmitErr bool) (failed, reverted bool, gasUsed uint64, returnValue []byte, err error) {
-	deltaTimestamp := uint32(uint64(time.Now().Unix()) - l2Block.Time())
+	gas uint64, nonce uint64, stateOverride StateOverride, shouldOmitErr bool) (failed, reverted bool, gasUsed uint64, returnValue []byte, err error) {
+	// the transaction is executed in a new L2 block on top of the latest one,
+	// or with the timestamp of the requested L2 block
+	timestamp := l2Block.Time()
+	if l2Block.NumberU64() == latestL2BlockNumber {
+		timestamp = uint64(time.Now().Unix())
+	}
+	deltaTimestamp := uint32(timestamp - l2Block.Time())
 	transactions := s.BuildChangeL2Block(deltaTimestamp, uint32(0))
 
 	tx := types.NewTx(&types.LegacyTx{
@@ -1008,10 +1098,11 @@ func (s *State) internalTestGasEstimationTransactionV2(ctx context.Context, batc
 		ChainId:          s.cfg.ChainID,
 		UpdateMerkleTree: cFalse,
 		ContextId:        uuid.NewString(),
+		StateOverride:    convertToExecutorStateOverrideV2(stateOverride),
 
 		// v2 fields
 		L1InfoRoot:             l2Block.BlockInfoRoot().Bytes(),
-		TimestampLimit:         uint64(time.Now().Unix()),
+		TimestampLimit:         timestamp,
 		SkipFirstChangeL2Block: cTrue,
 		SkipWriteBlockInfoRoot: cTrue,
 	}
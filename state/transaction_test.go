@@ -0,0 +1,92 @@
+package state_test
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/0xPolygonHermez/zkevm-node/state"
+	"github.com/0xPolygonHermez/zkevm-node/state/mocks"
+	"github.com/0xPolygonHermez/zkevm-node/state/runtime"
+	"github.com/0xPolygonHermez/zkevm-node/state/runtime/executor"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+)
+
+func TestEstimateGasWithStateOverride(t *testing.T) {
+	const (
+		gasNeeded = uint64(60000)
+		gasUsed   = uint64(50000)
+	)
+	ctx := context.Background()
+	mockStorage := mocks.NewStorageMock(t)
+	mockExecutor := mocks.NewExecutorServiceClientMock(t)
+	testState := state.NewState(state.Config{ChainID: 1000}, mockStorage, mockExecutor, nil, nil, nil, nil)
+	dbTx := mocks.NewDbTxMock(t)
+
+	sender := common.HexToAddress("0x1")
+	receiver := common.HexToAddress("0x2")
+	nonce := uint64(9)
+	code := []byte{0x60, 0x01}
+	stateOverride := state.StateOverride{
+		sender:   {Nonce: &nonce, Balance: big.NewInt(1000)},
+		receiver: {Code: &code},
+	}
+	l2Block := state.NewL2BlockWithHeader(state.NewL2Header(&types.Header{Number: big.NewInt(10), Root: common.HexToHash("0x3")}))
+
+	mockStorage.EXPECT().GetLastL2Block(ctx, dbTx).Return(l2Block, nil).Once()
+	mockStorage.EXPECT().GetBatchByL2BlockNumber(ctx, uint64(10), dbTx).Return(&state.Batch{BatchNumber: 5}, nil).Once()
+	mockStorage.EXPECT().GetForkIDByBatchNumber(uint64(5)).Return(state.FORKID_ETROG).Once()
+	mockStorage.EXPECT().GetLastL2BlockNumber(ctx, dbTx).Return(uint64(10), nil).Once()
+
+	// the executor runs the tx successfully only with the gas it needs
+	executions := 0
+	mockExecutor.EXPECT().ProcessBatchV2(ctx, mock.Anything).RunAndReturn(func(ctx context.Context, request *executor.ProcessBatchRequestV2, opts ...grpc.CallOption) (*executor.ProcessBatchResponseV2, error) {
+		executions++
+		require.Len(t, request.StateOverride, 2)
+		assert.Equal(t, nonce, request.StateOverride[sender.String()].Nonce)
+		assert.Equal(t, code, request.StateOverride[receiver.String()].Code)
+
+		batch, err := state.DecodeBatchV2(request.BatchL2Data)
+		require.NoError(t, err)
+		tx := batch.Blocks[0].Transactions[0].Tx
+		assert.Equal(t, nonce, tx.Nonce())
+
+		txResponse := &executor.ProcessTransactionResponseV2{Error: executor.RomError_ROM_ERROR_NO_ERROR}
+		blockGasUsed := gasUsed
+		if tx.Gas() < gasNeeded {
+			txResponse.Error = executor.RomError_ROM_ERROR_OUT_OF_GAS
+			blockGasUsed = tx.Gas()
+		}
+		return &executor.ProcessBatchResponseV2{
+			Error:          executor.ExecutorError_EXECUTOR_ERROR_NO_ERROR,
+			ErrorRom:       executor.RomError_ROM_ERROR_NO_ERROR,
+			BlockResponses: []*executor.ProcessBlockResponseV2{{GasUsed: blockGasUsed, Responses: []*executor.ProcessTransactionResponseV2{txResponse}}},
+		}, nil
+	})
+
+	tx := types.NewTx(&types.LegacyTx{To: &receiver, Data: []byte{0x1}})
+	estimation, returnValue, err := testState.EstimateGas(tx, sender, nil, stateOverride, dbTx)
+	require.NoError(t, err)
+	assert.Nil(t, returnValue)
+	assert.GreaterOrEqual(t, estimation, gasNeeded)
+	assert.LessOrEqual(t, float64(estimation-gasNeeded)/float64(estimation), 0.015)
+	assert.Less(t, executions, 10)
+}
+
+func TestGetRevertData(t *testing.T) {
+	returnValue := []byte{0x08, 0xc3, 0x79, 0xa0}
+
+	reverted := &state.ProcessTransactionResponse{RomError: runtime.ErrExecutionReverted, ReturnValue: returnValue}
+	assert.Equal(t, returnValue, state.GetRevertData(reverted))
+
+	succeeded := &state.ProcessTransactionResponse{ReturnValue: returnValue}
+	assert.Nil(t, state.GetRevertData(succeeded))
+
+	outOfGas := &state.ProcessTransactionResponse{RomError: runtime.ErrOutOfGas, ReturnValue: returnValue}
+	assert.Nil(t, state.GetRevertData(outOfGas))
+}
@@ -311,11 +311,24 @@ func (r *BatchResources) SumUp(other BatchResources) {
 
 // InfoReadWrite has information about modified addresses during the execution
 type InfoReadWrite struct {
-	Address common.Address
-	Nonce   *uint64
-	Balance *big.Int
+	Address     common.Address
+	Nonce       *uint64
+	Balance     *big.Int
+	StorageKeys []common.Hash
 }
 
+// OverrideAccount indicates the overriding fields of an account during the execution of an unsigned transaction
+type OverrideAccount struct {
+	Nonce     *uint64
+	Code      *[]byte
+	Balance   *big.Int
+	State     *map[common.Hash]common.Hash
+	StateDiff *map[common.Hash]common.Hash
+}
+
+// StateOverride is the set of accounts to override during the execution of an unsigned transaction
+type StateOverride map[common.Address]OverrideAccount
+
 // TraceConfig sets the debug configuration for the executor
 type TraceConfig struct {
 	DisableStorage   bool
@@ -694,6 +694,65 @@ func (_c *StateFullInterface_BeginStateTransaction_Call) RunAndReturn(run func(c
 	return _c
 }
 
+// CheckSyncProgress provides a mock function with given fields: ctx, dbTx
+func (_m *StateFullInterface) CheckSyncProgress(ctx context.Context, dbTx pgx.Tx) (*state.SyncProgress, error) {
+	ret := _m.Called(ctx, dbTx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CheckSyncProgress")
+	}
+
+	var r0 *state.SyncProgress
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, pgx.Tx) (*state.SyncProgress, error)); ok {
+		return rf(ctx, dbTx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, pgx.Tx) *state.SyncProgress); ok {
+		r0 = rf(ctx, dbTx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*state.SyncProgress)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, pgx.Tx) error); ok {
+		r1 = rf(ctx, dbTx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// StateFullInterface_CheckSyncProgress_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CheckSyncProgress'
+type StateFullInterface_CheckSyncProgress_Call struct {
+	*mock.Call
+}
+
+// CheckSyncProgress is a helper method to define mock.On call
+//   - ctx context.Context
+//   - dbTx pgx.Tx
+func (_e *StateFullInterface_Expecter) CheckSyncProgress(ctx interface{}, dbTx interface{}) *StateFullInterface_CheckSyncProgress_Call {
+	return &StateFullInterface_CheckSyncProgress_Call{Call: _e.mock.On("CheckSyncProgress", ctx, dbTx)}
+}
+
+func (_c *StateFullInterface_CheckSyncProgress_Call) Run(run func(ctx context.Context, dbTx pgx.Tx)) *StateFullInterface_CheckSyncProgress_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(pgx.Tx))
+	})
+	return _c
+}
+
+func (_c *StateFullInterface_CheckSyncProgress_Call) Return(_a0 *state.SyncProgress, _a1 error) *StateFullInterface_CheckSyncProgress_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *StateFullInterface_CheckSyncProgress_Call) RunAndReturn(run func(context.Context, pgx.Tx) (*state.SyncProgress, error)) *StateFullInterface_CheckSyncProgress_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // CloseBatch provides a mock function with given fields: ctx, receipt, dbTx
 func (_m *StateFullInterface) CloseBatch(ctx context.Context, receipt state.ProcessingReceipt, dbTx pgx.Tx) error {
 	ret := _m.Called(ctx, receipt, dbTx)
@@ -2767,6 +2826,55 @@ func (_c *StateFullInterface_StoreL2Block_Call) RunAndReturn(run func(context.Co
 	return _c
 }
 
+// StoreSyncProgress provides a mock function with given fields: ctx, blockNumber, blockHash, dbTx
+func (_m *StateFullInterface) StoreSyncProgress(ctx context.Context, blockNumber uint64, blockHash common.Hash, dbTx pgx.Tx) error {
+	ret := _m.Called(ctx, blockNumber, blockHash, dbTx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for StoreSyncProgress")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint64, common.Hash, pgx.Tx) error); ok {
+		r0 = rf(ctx, blockNumber, blockHash, dbTx)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// StateFullInterface_StoreSyncProgress_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'StoreSyncProgress'
+type StateFullInterface_StoreSyncProgress_Call struct {
+	*mock.Call
+}
+
+// StoreSyncProgress is a helper method to define mock.On call
+//   - ctx context.Context
+//   - blockNumber uint64
+//   - blockHash common.Hash
+//   - dbTx pgx.Tx
+func (_e *StateFullInterface_Expecter) StoreSyncProgress(ctx interface{}, blockNumber interface{}, blockHash interface{}, dbTx interface{}) *StateFullInterface_StoreSyncProgress_Call {
+	return &StateFullInterface_StoreSyncProgress_Call{Call: _e.mock.On("StoreSyncProgress", ctx, blockNumber, blockHash, dbTx)}
+}
+
+func (_c *StateFullInterface_StoreSyncProgress_Call) Run(run func(ctx context.Context, blockNumber uint64, blockHash common.Hash, dbTx pgx.Tx)) *StateFullInterface_StoreSyncProgress_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uint64), args[2].(common.Hash), args[3].(pgx.Tx))
+	})
+	return _c
+}
+
+func (_c *StateFullInterface_StoreSyncProgress_Call) Return(_a0 error) *StateFullInterface_StoreSyncProgress_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *StateFullInterface_StoreSyncProgress_Call) RunAndReturn(run func(context.Context, uint64, common.Hash, pgx.Tx) error) *StateFullInterface_StoreSyncProgress_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // StoreTransaction provides a mock function with given fields: ctx, batchNumber, processedTx, coinbase, timestamp, egpLog, globalExitRoot, blockInfoRoot, dbTx
 func (_m *StateFullInterface) StoreTransaction(ctx context.Context, batchNumber uint64, processedTx *state.ProcessTransactionResponse, coinbase common.Address, timestamp uint64, egpLog *state.EffectiveGasPriceLog, globalExitRoot common.Hash, blockInfoRoot common.Hash, dbTx pgx.Tx) (*state.L2Header, error) {
 	ret := _m.Called(ctx, batchNumber, processedTx, coinbase, timestamp, egpLog, globalExitRoot, blockInfoRoot, dbTx)
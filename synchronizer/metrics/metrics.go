@@ -40,6 +40,12 @@ const (
 
 	// LastSyncedBatchNumberName is the name of tha lable to get latest synced batch number
 	LastSyncedBatchNumberName = Prefix + "latest_synced_batch_number"
+
+	// L1ReorgsName is the name of the label for the number of L1 reorgs detected.
+	L1ReorgsName = Prefix + "L1_reorgs"
+
+	// LastL1ReorgDepthName is the name of the label for the number of L1 blocks reverted by the last L1 reorg.
+	LastL1ReorgDepthName = Prefix + "last_L1_reorg_depth"
 )
 
 // Register the metrics for the synchronizer package.
@@ -49,6 +55,16 @@ func Register() {
 			Name: LastSyncedBatchNumberName,
 			Help: "[SYNCHRONIZER] last synced batch number",
 		},
+		{
+			Name: LastL1ReorgDepthName,
+			Help: "[SYNCHRONIZER] number of L1 blocks reverted by the last L1 reorg",
+		},
+	}
+	counters := []prometheus.CounterOpts{
+		{
+			Name: L1ReorgsName,
+			Help: "[SYNCHRONIZER] number of L1 reorgs detected",
+		},
 	}
 	histograms := []prometheus.HistogramOpts{
 		{
@@ -90,6 +106,7 @@ func Register() {
 	}
 
 	metrics.RegisterGauges(gauges...)
+	metrics.RegisterCounters(counters...)
 	metrics.RegisterHistograms(histograms...)
 }
 
@@ -98,6 +115,12 @@ func LastSyncedBatchNumber(batchNum float64) {
 	metrics.GaugeSet(LastSyncedBatchNumberName, batchNum)
 }
 
+// L1Reorg increments the number of L1 reorgs detected and sets the depth of the last one
+func L1Reorg(depth uint64) {
+	metrics.CounterInc(L1ReorgsName)
+	metrics.GaugeSet(LastL1ReorgDepthName, float64(depth))
+}
+
 // InitializationTime observes the time initializing the synchronizer on the histogram.
 func InitializationTime(lastProcessTime time.Duration) {
 	execTimeInSeconds := float64(lastProcessTime) / float64(time.Second)
@@ -360,6 +360,17 @@ func (s *ClientSynchronizer) Sync() error {
 		log.Errorf("error getting last L1 block. Error: %v", err)
 		return rollback(s.ctx, dbTx, err)
 	}
+	// the state must be the one left by the last L1 block processed, otherwise it was modified
+	// out of the synchronizer and resuming from it would build on top of a wrong state
+	syncProgress, err := s.state.CheckSyncProgress(s.ctx, dbTx)
+	if err != nil {
+		log.Errorf("error checking the synchronizer progress. Error: %v", err)
+		return rollback(s.ctx, dbTx, err)
+	}
+	if syncProgress != nil {
+		log.Infof("resuming synchronization from L1 block %d, virtual batch %d, events hash %s",
+			syncProgress.BlockNumber, syncProgress.LastVirtualBatchNumber, syncProgress.EventsHash.String())
+	}
 	initBatchNumber, err := s.state.GetLastBatchNumber(s.ctx, dbTx)
 	if err != nil {
 		log.Error("error getting latest batchNumber synced. Error: ", err)
@@ -786,6 +797,7 @@ func (s *ClientSynchronizer) checkReorg(latestBlock *state.Block) (*state.Block,
 			}
 			if errors.Is(err, state.ErrNotFound) {
 				log.Warn("error checking reorg: previous block not found in db: ", err)
+				s.reportL1Reorg(latestEthBlockSynced, state.Block{}, depth)
 				return &state.Block{}, nil
 			} else if err != nil {
 				log.Error("error getting previousBlock from db. Error: ", err)
@@ -800,12 +812,35 @@ func (s *ClientSynchronizer) checkReorg(latestBlock *state.Block) (*state.Block,
 	if latestEthBlockSynced.BlockHash != reorgedBlock.BlockHash {
 		latestBlock = &reorgedBlock
 		log.Info("Reorg detected in block: ", latestEthBlockSynced.BlockNumber, " last block OK: ", latestBlock.BlockNumber)
+		s.reportL1Reorg(latestEthBlockSynced, reorgedBlock, depth)
 		return latestBlock, nil
 	}
 	log.Debugf("No reorg detected in block: %d. BlockHash: %s", latestEthBlockSynced.BlockNumber, latestEthBlockSynced.BlockHash.String())
 	return nil, nil
 }
 
+// reportL1Reorg updates the reorg metrics and stores an event with the L1 blocks that are going to be reverted,
+// the depth is the number of stored L1 blocks reverted. The pool is not rolled back: the L1 reorg only reverts
+// the virtual and verified state of the batches, their L2 blocks are kept in the trusted state, so the pool txs
+// they include are still valid. The batches sequenced again with a different content are reverted as a trusted
+// reorg when the sequence is processed
+func (s *ClientSynchronizer) reportL1Reorg(latestBlock, commonAncestor state.Block, depth uint64) {
+	metrics.L1Reorg(depth)
+
+	event := &event.Event{
+		ReceivedAt:  time.Now(),
+		Source:      event.Source_Node,
+		Component:   event.Component_Synchronizer,
+		Level:       event.Level_Warning,
+		EventID:     event.EventID_SynchronizerL1Reorg,
+		Description: fmt.Sprintf("L1 reorg detected in block %d (%s), reverting %d stored blocks to block %d (%s)", latestBlock.BlockNumber, latestBlock.BlockHash.String(), depth, commonAncestor.BlockNumber, commonAncestor.BlockHash.String()),
+	}
+	err := s.eventLog.LogEvent(s.ctx, event)
+	if err != nil {
+		log.Errorf("error storing event payload: %v", err)
+	}
+}
+
 // Stop function stops the synchronizer
 func (s *ClientSynchronizer) Stop() {
 	s.cancelCtx()
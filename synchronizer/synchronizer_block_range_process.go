@@ -19,6 +19,7 @@ type stateBlockRangeProcessor interface {
 	AddBlock(ctx context.Context, block *state.Block, dbTx pgx.Tx) error
 	GetForkIDByBatchNumber(batchNumber uint64) uint64
 	GetForkIDByBlockNumber(blockNumber uint64) uint64
+	StoreSyncProgress(ctx context.Context, blockNumber uint64, blockHash common.Hash, dbTx pgx.Tx) error
 }
 
 // BlockRangeProcess is the struct that process the block range that implements syncinterfaces.BlockRangeProcessor
@@ -126,6 +127,13 @@ func (s *BlockRangeProcess) processBlock(ctx context.Context, blocks []etherman.
 			return err
 		}
 	}
+	if storeBlock == syncinterfaces.StoreL1Blocks {
+		err = s.state.StoreSyncProgress(ctx, blocks[i].BlockNumber, blocks[i].BlockHash, dbTx)
+		if err != nil {
+			log.Errorf("error adding sync progress to db. BlockNumber: %d, error: %v", blocks[i].BlockNumber, err)
+			return err
+		}
+	}
 	log.Debug("Checking FlushID to commit L1 data to db")
 	err = s.flushIdManager.CheckFlushID(dbTx)
 	if err != nil {
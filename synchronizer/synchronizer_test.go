@@ -3,12 +3,14 @@ package synchronizer
 import (
 	context "context"
 	"math/big"
+	"strings"
 	"testing"
 	"time"
 
 	cfgTypes "github.com/0xPolygonHermez/zkevm-node/config/types"
 	"github.com/0xPolygonHermez/zkevm-node/etherman"
 	"github.com/0xPolygonHermez/zkevm-node/etherman/smartcontracts/etrogpolygonzkevm"
+	"github.com/0xPolygonHermez/zkevm-node/event"
 	"github.com/0xPolygonHermez/zkevm-node/jsonrpc/types"
 	"github.com/0xPolygonHermez/zkevm-node/state"
 	"github.com/0xPolygonHermez/zkevm-node/state/metrics"
@@ -173,6 +175,11 @@ func TestForcedBatchEtrog(t *testing.T) {
 				Return(lastBlock, nil).
 				Once()
 
+			m.State.
+				On("CheckSyncProgress", mock.Anything, mock.Anything).
+				Return(nil, nil).
+				Once()
+
 			m.State.
 				On("GetLastBatchNumber", ctx, m.DbTx).
 				Return(uint64(10), nil).
@@ -306,6 +313,11 @@ func TestForcedBatchEtrog(t *testing.T) {
 				Return(nil).
 				Once()
 
+			m.State.
+				On("StoreSyncProgress", ctx, ethermanBlock.BlockNumber, ethermanBlock.BlockHash, m.DbTx).
+				Return(nil).
+				Once()
+
 			fb := []state.ForcedBatch{{
 				BlockNumber:       lastBlock.BlockNumber,
 				ForcedBatchNumber: 1,
@@ -436,6 +448,11 @@ func TestSequenceForcedBatchIncaberry(t *testing.T) {
 				Return(lastBlock, nil).
 				Once()
 
+			m.State.
+				On("CheckSyncProgress", mock.Anything, mock.Anything).
+				Return(nil, nil).
+				Once()
+
 			m.State.
 				On("GetLastBatchNumber", ctx, m.DbTx).
 				Return(uint64(10), nil).
@@ -554,6 +571,11 @@ func TestSequenceForcedBatchIncaberry(t *testing.T) {
 				Return(nil).
 				Once()
 
+			m.State.
+				On("StoreSyncProgress", ctx, ethermanBlock.BlockNumber, ethermanBlock.BlockHash, m.DbTx).
+				Return(nil).
+				Once()
+
 			fb := []state.ForcedBatch{{
 				BlockNumber:       lastBlock.BlockNumber,
 				ForcedBatchNumber: 1,
@@ -902,3 +924,69 @@ func expectedCallsForsyncTrustedState(t *testing.T, m *mocks, sync *ClientSynchr
 		Return(nil).
 		Once()
 }
+
+func TestCheckReorgRevertsToTheCommonAncestor(t *testing.T) {
+	genesis, cfg, m := setupGenericTest(t)
+	genesis.BlockNumber = 1
+	eventLog := mock_syncinterfaces.NewEventLogInterface(t)
+	ethermanForL1 := []syncinterfaces.EthermanFullInterface{m.Etherman}
+	syncInterface, err := NewSynchronizer(true, m.Etherman, ethermanForL1, m.State, m.Pool, m.EthTxManager, m.ZKEVMClient, m.zkEVMClientEthereumCompatible, eventLog, *genesis, *cfg, false)
+	require.NoError(t, err)
+	sync, ok := syncInterface.(*ClientSynchronizer)
+	require.True(t, ok)
+
+	// block 8 is the common ancestor, blocks 9 and 10 were reorged on L1
+	l1Block8 := ethTypes.NewBlockWithHeader(&ethTypes.Header{Number: big.NewInt(8), ParentHash: common.HexToHash("0x7")})
+	l1Block9 := ethTypes.NewBlockWithHeader(&ethTypes.Header{Number: big.NewInt(9), ParentHash: l1Block8.Hash()})
+	l1Block10 := ethTypes.NewBlockWithHeader(&ethTypes.Header{Number: big.NewInt(10), ParentHash: l1Block9.Hash()})
+	storedBlock8 := state.Block{BlockNumber: 8, BlockHash: l1Block8.Hash(), ParentHash: l1Block8.ParentHash()}
+	storedBlock9 := state.Block{BlockNumber: 9, BlockHash: common.HexToHash("0x19"), ParentHash: l1Block8.Hash()}
+	storedBlock10 := state.Block{BlockNumber: 10, BlockHash: common.HexToHash("0x110"), ParentHash: storedBlock9.BlockHash}
+
+	m.Etherman.On("EthBlockByNumber", mock.Anything, uint64(10)).Return(l1Block10, nil).Once()
+	m.Etherman.On("EthBlockByNumber", mock.Anything, uint64(9)).Return(l1Block9, nil).Once()
+	m.Etherman.On("EthBlockByNumber", mock.Anything, uint64(8)).Return(l1Block8, nil).Once()
+	m.State.On("BeginStateTransaction", mock.Anything).Return(m.DbTx, nil).Twice()
+	m.State.On("GetPreviousBlock", mock.Anything, uint64(1), m.DbTx).Return(&storedBlock9, nil).Once()
+	m.State.On("GetPreviousBlock", mock.Anything, uint64(2), m.DbTx).Return(&storedBlock8, nil).Once()
+	m.DbTx.On("Commit", mock.Anything).Return(nil).Twice()
+	eventLog.On("LogEvent", mock.Anything, mock.MatchedBy(func(e *event.Event) bool {
+		return e.EventID == event.EventID_SynchronizerL1Reorg && e.Component == event.Component_Synchronizer &&
+			strings.Contains(e.Description, "reverting 2 stored blocks to block 8")
+	})).Return(nil).Once()
+
+	commonAncestor, err := sync.checkReorg(&storedBlock10)
+	require.NoError(t, err)
+	require.NotNil(t, commonAncestor)
+	assert.Equal(t, storedBlock8, *commonAncestor)
+	m.checkExpectedCalls(t)
+}
+
+func TestCheckReorgRevertsAllTheStoredBlocks(t *testing.T) {
+	genesis, cfg, m := setupGenericTest(t)
+	genesis.BlockNumber = 1
+	eventLog := mock_syncinterfaces.NewEventLogInterface(t)
+	ethermanForL1 := []syncinterfaces.EthermanFullInterface{m.Etherman}
+	syncInterface, err := NewSynchronizer(true, m.Etherman, ethermanForL1, m.State, m.Pool, m.EthTxManager, m.ZKEVMClient, m.zkEVMClientEthereumCompatible, eventLog, *genesis, *cfg, false)
+	require.NoError(t, err)
+	sync, ok := syncInterface.(*ClientSynchronizer)
+	require.True(t, ok)
+
+	// the only stored block was reorged on L1, the depth is the number of stored blocks, not the block number
+	l1Block100 := ethTypes.NewBlockWithHeader(&ethTypes.Header{Number: big.NewInt(100), ParentHash: common.HexToHash("0x99")})
+	storedBlock100 := state.Block{BlockNumber: 100, BlockHash: common.HexToHash("0x1100"), ParentHash: common.HexToHash("0x99")}
+
+	m.Etherman.On("EthBlockByNumber", mock.Anything, uint64(100)).Return(l1Block100, nil).Once()
+	m.State.On("BeginStateTransaction", mock.Anything).Return(m.DbTx, nil).Once()
+	m.State.On("GetPreviousBlock", mock.Anything, uint64(1), m.DbTx).Return(nil, state.ErrNotFound).Once()
+	m.DbTx.On("Commit", mock.Anything).Return(nil).Once()
+	eventLog.On("LogEvent", mock.Anything, mock.MatchedBy(func(e *event.Event) bool {
+		return e.EventID == event.EventID_SynchronizerL1Reorg && strings.Contains(e.Description, "reverting 1 stored blocks to block 0")
+	})).Return(nil).Once()
+
+	commonAncestor, err := sync.checkReorg(&storedBlock100)
+	require.NoError(t, err)
+	require.NotNil(t, commonAncestor)
+	assert.Equal(t, state.Block{}, *commonAncestor)
+	m.checkExpectedCalls(t)
+}
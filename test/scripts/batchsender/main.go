@@ -111,7 +111,7 @@ func sendBatches(cliCtx *cli.Context) error {
 		return err
 	}
 
-	ethMan, err := etherman.NewClient(cfg.Etherman, cfg.NetworkConfig.L1Config)
+	ethMan, err := etherman.NewClient(ctx, cfg.Etherman, cfg.NetworkConfig.L1Config)
 	if err != nil {
 		return err
 	}
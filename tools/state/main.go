@@ -90,6 +90,13 @@ func main() {
 			Flags: []cli.Flag{&configFileFlag, &networkFlag, &customNetworkFlag, &configChainIDFlag, &firstBatchNumberFlag,
 				&lastBatchNumberFlag, &writeOnHashDBFlag, &dontStopOnErrorFlag, &preferExecutionStateRootFlag},
 		},
+		{
+			Name:    "verify",
+			Aliases: []string{},
+			Usage:   "reexecute the synced batches and compare the state roots with the ones on DB and the ones posted on L1",
+			Action:  verifyCmd,
+			Flags:   []cli.Flag{&configFileFlag, &networkFlag, &customNetworkFlag, &configChainIDFlag, &firstBatchNumberFlag, &lastBatchNumberFlag},
+		},
 	}
 	err := app.Run(os.Args)
 	if err != nil {
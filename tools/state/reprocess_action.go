@@ -2,11 +2,14 @@ package main
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"time"
 
 	"github.com/0xPolygonHermez/zkevm-node/log"
 	"github.com/0xPolygonHermez/zkevm-node/state"
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/jackc/pgx/v4"
 )
 
 type reprocessAction struct {
@@ -92,18 +95,16 @@ func (r *reprocessAction) step(i uint64, oldStateRoot common.Hash, oldAccInputHa
 		return batch2, nil, err
 	}
 
-	request := state.ProcessRequest{
-		BatchNumber:     batch2.BatchNumber,
-		OldStateRoot:    oldStateRoot,
-		OldAccInputHash: oldAccInputHash,
-		Coinbase:        batch2.Coinbase,
-		Timestamp_V1:    batch2.Timestamp,
-
-		GlobalExitRoot_V1: batch2.GlobalExitRoot,
-		Transactions:      batch2.BatchL2Data,
+	forkID := r.st.GetForkIDByBatchNumber(batch2.BatchNumber)
+	request, err := newProcessRequest(r.ctx, r.st, batch2, forkID, oldStateRoot, oldAccInputHash, dbTx)
+	if err != nil {
+		log.Errorf("error building the request to process batch %d. Error: %v", i, err)
+		if rollbackErr := dbTx.Rollback(r.ctx); rollbackErr != nil {
+			log.Errorf("failed to rollback dbTx: %v", rollbackErr)
+		}
+		return batch2, nil, err
 	}
 	log.Debugf("Processing batch %d: ntx:%d StateRoot:%s", batch2.BatchNumber, len(batch2.BatchL2Data), batch2.StateRoot)
-	forkID := r.st.GetForkIDByBatchNumber(batch2.BatchNumber)
 	syncedTxs, _, _, err := state.DecodeTxs(batch2.BatchL2Data, forkID)
 	if err != nil {
 		log.Errorf("error decoding synced txs from trustedstate. Error: %v, TrustedBatchL2Data: %s", err, batch2.BatchL2Data)
@@ -114,7 +115,18 @@ func (r *reprocessAction) step(i uint64, oldStateRoot common.Hash, oldAccInputHa
 	var response *state.ProcessBatchResponse
 
 	log.Infof("id:%d len_trs:%d oldStateRoot:%s", batch2.BatchNumber, len(syncedTxs), request.OldStateRoot)
-	response, err = r.st.ProcessBatch(r.ctx, request, r.updateHasbDB)
+	if forkID >= state.FORKID_ETROG {
+		response, err = r.st.ProcessBatchV2(r.ctx, request, r.updateHasbDB)
+	} else {
+		response, err = r.st.ProcessBatch(r.ctx, request, r.updateHasbDB)
+	}
+	if response == nil {
+		if rollbackErr := dbTx.Rollback(r.ctx); rollbackErr != nil {
+			log.Errorf("failed to rollback dbTx: %v", rollbackErr)
+		}
+		log.Errorf("error processing batch %d. Error: %v", i, err)
+		return batch2, nil, err
+	}
 	for _, blockResponse := range response.BlockResponses {
 		for tx_i, txresponse := range blockResponse.TransactionResponses {
 			if txresponse.RomError != nil {
@@ -160,3 +172,71 @@ func (r *reprocessAction) step(i uint64, oldStateRoot common.Hash, oldAccInputHa
 
 	return batch2, response, nil
 }
+
+// batchDataReader is the state data used to build the request to reexecute a batch
+type batchDataReader interface {
+	GetL1InfoTreeDataFromBatchL2Data(ctx context.Context, batchL2Data []byte, dbTx pgx.Tx) (map[uint32]state.L1DataV2, common.Hash, common.Hash, error)
+	GetVirtualBatch(ctx context.Context, batchNumber uint64, dbTx pgx.Tx) (*state.VirtualBatch, error)
+	GetForcedBatch(ctx context.Context, forcedBatchNumber uint64, dbTx pgx.Tx) (*state.ForcedBatch, error)
+	GetBlockByNumber(ctx context.Context, blockNumber uint64, dbTx pgx.Tx) (*state.Block, error)
+}
+
+// newProcessRequest builds the request to reexecute the batch with the executor API of its fork. The
+// requests of the etrog batches are built as the synchronizer does when it processes the sequenced
+// batches: the forced batches and the initial batch use their forced data, and the rest of batches use
+// the L1InfoTree leaves of their L2 blocks and the timestamp of the L1 block that virtualized them
+func newProcessRequest(ctx context.Context, st batchDataReader, batch *state.Batch, forkID uint64, oldStateRoot, oldAccInputHash common.Hash, dbTx pgx.Tx) (state.ProcessRequest, error) {
+	request := state.ProcessRequest{
+		BatchNumber:     batch.BatchNumber,
+		OldStateRoot:    oldStateRoot,
+		OldAccInputHash: oldAccInputHash,
+		Coinbase:        batch.Coinbase,
+		Transactions:    batch.BatchL2Data,
+		ForkID:          forkID,
+	}
+	if forkID < state.FORKID_ETROG {
+		request.Timestamp_V1 = batch.Timestamp
+		request.GlobalExitRoot_V1 = batch.GlobalExitRoot
+		return request, nil
+	}
+
+	request.SkipVerifyL1InfoRoot_V2 = true
+	virtualBatch, err := st.GetVirtualBatch(ctx, batch.BatchNumber, dbTx)
+	if errors.Is(err, state.ErrNotFound) {
+		virtualBatch = nil
+	} else if err != nil {
+		return request, err
+	}
+
+	switch {
+	case batch.ForcedBatchNum != nil:
+		forcedBatch, err := st.GetForcedBatch(ctx, *batch.ForcedBatchNum, dbTx)
+		if err != nil {
+			return request, err
+		}
+		request.L1InfoRoot_V2 = forcedBatch.GlobalExitRoot
+		request.TimestampLimit_V2 = uint64(forcedBatch.ForcedAt.Unix())
+	case batch.BatchNumber == 1 && virtualBatch != nil:
+		// the initial batch is sequenced by the rollup contract with the hash of the previous L1 block
+		block, err := st.GetBlockByNumber(ctx, virtualBatch.BlockNumber, dbTx)
+		if err != nil {
+			return request, err
+		}
+		request.ForcedBlockHashL1 = block.ParentHash
+		request.L1InfoRoot_V2 = batch.GlobalExitRoot
+		request.TimestampLimit_V2 = uint64(batch.Timestamp.Unix())
+	default:
+		leaves, l1InfoRoot, _, err := st.GetL1InfoTreeDataFromBatchL2Data(ctx, batch.BatchL2Data, dbTx)
+		if err != nil {
+			return request, err
+		}
+		request.L1InfoRoot_V2 = l1InfoRoot
+		request.L1InfoTreeData_V2 = leaves
+		// the trusted batches not virtualized yet are not limited by an L1 block
+		request.TimestampLimit_V2 = uint64(time.Now().Unix())
+		if virtualBatch != nil && virtualBatch.TimestampBatchEtrog != nil {
+			request.TimestampLimit_V2 = uint64(virtualBatch.TimestampBatchEtrog.Unix())
+		}
+	}
+	return request, nil
+}
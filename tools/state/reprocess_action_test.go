@@ -0,0 +1,147 @@
+package main
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/0xPolygonHermez/zkevm-node/state"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/jackc/pgx/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// batchDataReaderStub returns the provided virtual batches, forced batches, L1 blocks and L1InfoTree data
+type batchDataReaderStub struct {
+	virtualBatches map[uint64]*state.VirtualBatch
+	forcedBatches  map[uint64]*state.ForcedBatch
+	blocks         map[uint64]*state.Block
+	leaves         map[uint32]state.L1DataV2
+	l1InfoRoot     common.Hash
+}
+
+func (s *batchDataReaderStub) GetL1InfoTreeDataFromBatchL2Data(ctx context.Context, batchL2Data []byte, dbTx pgx.Tx) (map[uint32]state.L1DataV2, common.Hash, common.Hash, error) {
+	return s.leaves, s.l1InfoRoot, common.Hash{}, nil
+}
+
+func (s *batchDataReaderStub) GetVirtualBatch(ctx context.Context, batchNumber uint64, dbTx pgx.Tx) (*state.VirtualBatch, error) {
+	if virtualBatch, found := s.virtualBatches[batchNumber]; found {
+		return virtualBatch, nil
+	}
+	return nil, state.ErrNotFound
+}
+
+func (s *batchDataReaderStub) GetForcedBatch(ctx context.Context, forcedBatchNumber uint64, dbTx pgx.Tx) (*state.ForcedBatch, error) {
+	if forcedBatch, found := s.forcedBatches[forcedBatchNumber]; found {
+		return forcedBatch, nil
+	}
+	return nil, state.ErrNotFound
+}
+
+func (s *batchDataReaderStub) GetBlockByNumber(ctx context.Context, blockNumber uint64, dbTx pgx.Tx) (*state.Block, error) {
+	if block, found := s.blocks[blockNumber]; found {
+		return block, nil
+	}
+	return nil, state.ErrNotFound
+}
+
+func TestNewProcessRequest(t *testing.T) {
+	oldStateRoot := common.HexToHash("0x1")
+	oldAccInputHash := common.HexToHash("0x2")
+	coinbase := common.HexToAddress("0x3")
+	globalExitRoot := common.HexToHash("0x4")
+	batchTimestamp := time.Unix(1700000000, 0)
+	virtualizedAt := time.Unix(1700000100, 0)
+	forcedAt := time.Unix(1700000200, 0)
+	forcedBatchNumber := uint64(1)
+
+	leaves := map[uint32]state.L1DataV2{
+		1: {GlobalExitRoot: common.HexToHash("0x5"), BlockHashL1: common.HexToHash("0x6"), MinTimestamp: 1699999999},
+	}
+	st := &batchDataReaderStub{
+		virtualBatches: map[uint64]*state.VirtualBatch{
+			1: {BatchNumber: 1, BlockNumber: 100},
+			2: {BatchNumber: 2, BlockNumber: 101, TimestampBatchEtrog: &virtualizedAt},
+		},
+		forcedBatches: map[uint64]*state.ForcedBatch{
+			forcedBatchNumber: {ForcedBatchNumber: forcedBatchNumber, GlobalExitRoot: common.HexToHash("0x7"), ForcedAt: forcedAt},
+		},
+		blocks: map[uint64]*state.Block{
+			100: {BlockNumber: 100, ParentHash: common.HexToHash("0x8")},
+		},
+		leaves:     leaves,
+		l1InfoRoot: common.HexToHash("0x9"),
+	}
+	newBatch := func(batchNumber uint64) *state.Batch {
+		return &state.Batch{
+			BatchNumber:    batchNumber,
+			Coinbase:       coinbase,
+			Timestamp:      batchTimestamp,
+			GlobalExitRoot: globalExitRoot,
+			BatchL2Data:    []byte{0x1},
+		}
+	}
+
+	t.Run("pre etrog batch", func(t *testing.T) {
+		request, err := newProcessRequest(context.Background(), st, newBatch(2), state.FORKID_DRAGONFRUIT, oldStateRoot, oldAccInputHash, nil)
+		require.NoError(t, err)
+		assert.Equal(t, state.ProcessRequest{
+			BatchNumber:       2,
+			OldStateRoot:      oldStateRoot,
+			OldAccInputHash:   oldAccInputHash,
+			Coinbase:          coinbase,
+			Transactions:      []byte{0x1},
+			ForkID:            state.FORKID_DRAGONFRUIT,
+			Timestamp_V1:      batchTimestamp,
+			GlobalExitRoot_V1: globalExitRoot,
+		}, request)
+	})
+
+	t.Run("etrog virtual batch", func(t *testing.T) {
+		request, err := newProcessRequest(context.Background(), st, newBatch(2), state.FORKID_ELDERBERRY, oldStateRoot, oldAccInputHash, nil)
+		require.NoError(t, err)
+		assert.Equal(t, state.ProcessRequest{
+			BatchNumber:             2,
+			OldStateRoot:            oldStateRoot,
+			OldAccInputHash:         oldAccInputHash,
+			Coinbase:                coinbase,
+			Transactions:            []byte{0x1},
+			ForkID:                  state.FORKID_ELDERBERRY,
+			L1InfoRoot_V2:           common.HexToHash("0x9"),
+			L1InfoTreeData_V2:       leaves,
+			TimestampLimit_V2:       uint64(virtualizedAt.Unix()),
+			SkipVerifyL1InfoRoot_V2: true,
+		}, request)
+	})
+
+	t.Run("etrog trusted batch", func(t *testing.T) {
+		before := uint64(time.Now().Unix())
+		request, err := newProcessRequest(context.Background(), st, newBatch(3), state.FORKID_ETROG, oldStateRoot, oldAccInputHash, nil)
+		require.NoError(t, err)
+		assert.Equal(t, common.HexToHash("0x9"), request.L1InfoRoot_V2)
+		assert.Equal(t, leaves, request.L1InfoTreeData_V2)
+		assert.GreaterOrEqual(t, request.TimestampLimit_V2, before)
+	})
+
+	t.Run("etrog forced batch", func(t *testing.T) {
+		batch := newBatch(2)
+		batch.ForcedBatchNum = &forcedBatchNumber
+		request, err := newProcessRequest(context.Background(), st, batch, state.FORKID_ETROG, oldStateRoot, oldAccInputHash, nil)
+		require.NoError(t, err)
+		assert.Equal(t, common.HexToHash("0x7"), request.L1InfoRoot_V2)
+		assert.Empty(t, request.L1InfoTreeData_V2)
+		assert.Equal(t, uint64(forcedAt.Unix()), request.TimestampLimit_V2)
+		assert.Equal(t, common.Hash{}, request.ForcedBlockHashL1)
+	})
+
+	t.Run("etrog initial batch", func(t *testing.T) {
+		request, err := newProcessRequest(context.Background(), st, newBatch(1), state.FORKID_ETROG, oldStateRoot, oldAccInputHash, nil)
+		require.NoError(t, err)
+		assert.Equal(t, globalExitRoot, request.L1InfoRoot_V2)
+		assert.Empty(t, request.L1InfoTreeData_V2)
+		assert.Equal(t, uint64(batchTimestamp.Unix()), request.TimestampLimit_V2)
+		assert.Equal(t, common.HexToHash("0x8"), request.ForcedBlockHashL1)
+		assert.True(t, request.SkipVerifyL1InfoRoot_V2)
+	})
+}
@@ -76,8 +76,8 @@ func getUpdateHashDB(cliCtx *cli.Context) bool {
 	return cliCtx.Bool(writeOnHashDBFlag.Name)
 }
 
-func newEtherman(c config.Config) (*etherman.Client, error) {
-	etherman, err := etherman.NewClient(c.Etherman, c.NetworkConfig.L1Config)
+func newEtherman(ctx context.Context, c config.Config) (*etherman.Client, error) {
+	etherman, err := etherman.NewClient(ctx, c.Etherman, c.NetworkConfig.L1Config)
 	if err != nil {
 		return nil, err
 	}
@@ -123,7 +123,7 @@ func getL2ChainID(cliCtx *cli.Context, c *config.Config) uint64 {
 		return flagL2chainID
 	}
 
-	etherman, err := newEtherman(*c)
+	etherman, err := newEtherman(cliCtx.Context, *c)
 	if err != nil {
 		log.Fatal(err)
 	}
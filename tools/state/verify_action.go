@@ -0,0 +1,112 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/0xPolygonHermez/zkevm-node/log"
+	"github.com/0xPolygonHermez/zkevm-node/state"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/jackc/pgx/v4"
+)
+
+// verifyDivergence is the first batch whose calculated state root doesn't match the
+// state root stored on DB or the state root posted on L1 when the batch was verified
+type verifyDivergence struct {
+	batchNumber         uint64
+	calculatedStateRoot common.Hash
+	localStateRoot      common.Hash
+	// l1StateRoot and l1BlockNumber are only set if the batch is verified on L1
+	l1StateRoot   common.Hash
+	l1BlockNumber uint64
+	isVerified    bool
+}
+
+func (d *verifyDivergence) String() string {
+	if !d.isVerified {
+		return fmt.Sprintf("batch %d: calculated state root %s != local state root %s",
+			d.batchNumber, d.calculatedStateRoot, d.localStateRoot)
+	}
+	return fmt.Sprintf("batch %d: calculated state root %s, local state root %s, L1 state root %s (verified in L1 block %d)",
+		d.batchNumber, d.calculatedStateRoot, d.localStateRoot, d.l1StateRoot, d.l1BlockNumber)
+}
+
+// matches checks the calculated state root matches the local state root and, if the
+// batch is verified, the L1 state root
+func (d *verifyDivergence) matches() bool {
+	return d.calculatedStateRoot == d.localStateRoot && (!d.isVerified || d.calculatedStateRoot == d.l1StateRoot)
+}
+
+// verifyAction reexecutes the synced batches chaining the calculated state roots, so the
+// result doesn't depend on the state roots stored on DB, and compares them with the
+// state roots on DB and, for the verified batches, with the state roots posted on L1
+type verifyAction struct {
+	reprocessAction
+}
+
+// start returns the first divergence found or nil if all the batches match
+func (v *verifyAction) start() (*verifyDivergence, error) {
+	batch, err := getBatchByNumber(v.ctx, v.st, v.firstBatchNumber-1)
+	if err != nil {
+		return nil, err
+	}
+	oldStateRoot := batch.StateRoot
+	oldAccInputHash := batch.AccInputHash
+
+	for i := v.firstBatchNumber; i <= v.lastBatchNumber; i++ {
+		v.output.startProcessingBatch(i)
+		batchOnDB, response, stepErr := v.step(i, oldStateRoot, oldAccInputHash)
+		if batchOnDB == nil || response == nil {
+			v.output.finishProcessingBatch(common.Hash{}, stepErr)
+			return nil, stepErr
+		}
+
+		divergence, err := compareBatchStateRoots(v.ctx, v.st, batchOnDB, response.NewStateRoot)
+		if err != nil {
+			v.output.finishProcessingBatch(common.Hash{}, err)
+			return nil, err
+		}
+		if !divergence.matches() {
+			v.output.finishProcessingBatch(response.NewStateRoot, errors.New(divergence.String()))
+			return divergence, nil
+		}
+		if stepErr != nil {
+			v.output.finishProcessingBatch(response.NewStateRoot, stepErr)
+			return nil, stepErr
+		}
+		v.output.finishProcessingBatch(response.NewStateRoot, nil)
+		if divergence.isVerified {
+			log.Infof("batch %d matches the state root verified on L1 block %d", i, divergence.l1BlockNumber)
+		}
+
+		oldStateRoot = response.NewStateRoot
+		oldAccInputHash = response.NewAccInputHash
+	}
+	return nil, nil
+}
+
+// verifiedBatchReader is the state data used to get the state roots posted on L1
+type verifiedBatchReader interface {
+	GetVerifiedBatch(ctx context.Context, batchNumber uint64, dbTx pgx.Tx) (*state.VerifiedBatch, error)
+}
+
+// compareBatchStateRoots compares the calculated state root of the batch with its state root
+// on DB and, if the batch is verified on L1, with the state root posted on L1
+func compareBatchStateRoots(ctx context.Context, st verifiedBatchReader, batchOnDB *state.Batch, calculatedStateRoot common.Hash) (*verifyDivergence, error) {
+	divergence := &verifyDivergence{
+		batchNumber:         batchOnDB.BatchNumber,
+		calculatedStateRoot: calculatedStateRoot,
+		localStateRoot:      batchOnDB.StateRoot,
+	}
+	verifiedBatch, err := st.GetVerifiedBatch(ctx, batchOnDB.BatchNumber, nil)
+	if errors.Is(err, state.ErrNotFound) {
+		return divergence, nil
+	} else if err != nil {
+		return nil, err
+	}
+	divergence.isVerified = true
+	divergence.l1StateRoot = verifiedBatch.StateRoot
+	divergence.l1BlockNumber = verifiedBatch.BlockNumber
+	return divergence, nil
+}
@@ -0,0 +1,76 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/0xPolygonHermez/zkevm-node/state"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/jackc/pgx/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// verifiedBatchReaderStub returns the provided verified batches
+type verifiedBatchReaderStub struct {
+	verifiedBatches map[uint64]*state.VerifiedBatch
+	err             error
+}
+
+func (s *verifiedBatchReaderStub) GetVerifiedBatch(ctx context.Context, batchNumber uint64, dbTx pgx.Tx) (*state.VerifiedBatch, error) {
+	if s.err != nil {
+		return nil, s.err
+	}
+	if verifiedBatch, found := s.verifiedBatches[batchNumber]; found {
+		return verifiedBatch, nil
+	}
+	return nil, state.ErrNotFound
+}
+
+func TestCompareBatchStateRoots(t *testing.T) {
+	root1, root2 := common.HexToHash("0x1"), common.HexToHash("0x2")
+	st := &verifiedBatchReaderStub{
+		verifiedBatches: map[uint64]*state.VerifiedBatch{
+			1: {BatchNumber: 1, BlockNumber: 100, StateRoot: root1},
+			2: {BatchNumber: 2, BlockNumber: 101, StateRoot: root2},
+		},
+	}
+
+	testCases := []struct {
+		name                string
+		batchOnDB           *state.Batch
+		calculatedStateRoot common.Hash
+		expectedMatches     bool
+		expectedVerified    bool
+	}{
+		{name: "matching root of a trusted batch", batchOnDB: &state.Batch{BatchNumber: 3, StateRoot: root1}, calculatedStateRoot: root1, expectedMatches: true},
+		{name: "matching root of a verified batch", batchOnDB: &state.Batch{BatchNumber: 1, StateRoot: root1}, calculatedStateRoot: root1, expectedMatches: true, expectedVerified: true},
+		{name: "mismatched local root", batchOnDB: &state.Batch{BatchNumber: 3, StateRoot: root1}, calculatedStateRoot: root2},
+		{name: "mismatched L1 root", batchOnDB: &state.Batch{BatchNumber: 2, StateRoot: root1}, calculatedStateRoot: root1, expectedVerified: true},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			divergence, err := compareBatchStateRoots(context.Background(), st, tc.batchOnDB, tc.calculatedStateRoot)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedMatches, divergence.matches())
+			assert.Equal(t, tc.expectedVerified, divergence.isVerified)
+			assert.Equal(t, tc.batchOnDB.BatchNumber, divergence.batchNumber)
+			assert.Equal(t, tc.calculatedStateRoot, divergence.calculatedStateRoot)
+			assert.Equal(t, tc.batchOnDB.StateRoot, divergence.localStateRoot)
+			if tc.expectedVerified {
+				verifiedBatch := st.verifiedBatches[tc.batchOnDB.BatchNumber]
+				assert.Equal(t, verifiedBatch.StateRoot, divergence.l1StateRoot)
+				assert.Equal(t, verifiedBatch.BlockNumber, divergence.l1BlockNumber)
+			}
+		})
+	}
+
+	divergence, err := compareBatchStateRoots(context.Background(), st, &state.Batch{BatchNumber: 2, StateRoot: root1}, root1)
+	require.NoError(t, err)
+	assert.Equal(t, "batch 2: calculated state root "+root1.String()+", local state root "+root1.String()+", L1 state root "+root2.String()+" (verified in L1 block 101)", divergence.String())
+
+	st.err = errors.New("failed to get the verified batch")
+	_, err = compareBatchStateRoots(context.Background(), st, &state.Batch{BatchNumber: 1, StateRoot: root1}, root1)
+	assert.ErrorIs(t, err, st.err)
+}
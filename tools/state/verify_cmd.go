@@ -0,0 +1,70 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/0xPolygonHermez/zkevm-node/config"
+	"github.com/0xPolygonHermez/zkevm-node/db"
+	"github.com/0xPolygonHermez/zkevm-node/log"
+	"github.com/0xPolygonHermez/zkevm-node/state"
+	"github.com/urfave/cli/v2"
+)
+
+func verifyCmd(cliCtx *cli.Context) error {
+	cfg, err := config.Load(cliCtx, isNetworkConfigNeeded(cliCtx))
+	if err != nil {
+		return err
+	}
+	log.Init(cfg.Log)
+	stateSqlDB, err := db.NewSQLDB(cfg.State.DB)
+	if err != nil {
+		log.Fatal(err)
+	}
+	l2ChainID := getL2ChainID(cliCtx, cfg)
+	needsExecutor := true
+	needsStateTree := true
+
+	st := newState(cliCtx.Context, cfg, l2ChainID, []state.ForkIDInterval{}, stateSqlDB, nil, needsExecutor, needsStateTree)
+
+	forksIdIntervals, err := getforkIDIntervalsFromDB(cliCtx.Context, st)
+	if err != nil {
+		log.Errorf("error getting forkIDs from db. Error: %v", err)
+		return err
+	}
+	st.UpdateForkIDIntervalsInMemory(forksIdIntervals)
+
+	lastBatchNumber := cliCtx.Uint64(lastBatchNumberFlag.Name)
+	if lastBatchNumber == 0 {
+		lastVerifiedBatch, err := st.GetLastVerifiedBatch(cliCtx.Context, nil)
+		if err != nil {
+			log.Errorf("error getting the last verified batch. Error: %v", err)
+			return err
+		}
+		lastBatchNumber = lastVerifiedBatch.BatchNumber
+	}
+
+	action := verifyAction{
+		reprocessAction: reprocessAction{
+			firstBatchNumber: getFirstBatchNumber(cliCtx),
+			lastBatchNumber:  lastBatchNumber,
+			l2ChainId:        l2ChainID,
+			st:               st,
+			ctx:              cliCtx.Context,
+			output:           &reprocessingOutputPretty{},
+		},
+	}
+	action.output.start(action.firstBatchNumber, action.lastBatchNumber, l2ChainID)
+	log.Infof("Verifying batches from %d to %d", action.firstBatchNumber, action.lastBatchNumber)
+	divergence, err := action.start()
+	if err != nil {
+		action.output.end(err)
+		log.Errorf("error verifying batches. Error: %v", err)
+		return err
+	}
+	if divergence != nil {
+		fmt.Printf("DIVERGENCE: %s\n", divergence)
+		return fmt.Errorf("first divergence found in batch %d", divergence.batchNumber)
+	}
+	fmt.Printf("OK: batches [%d to %d] match the local and the L1 state roots\n", action.firstBatchNumber, action.lastBatchNumber)
+	return nil
+}